@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/invites"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
+	"github.com/spf13/cobra"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Workspace administration",
+}
+
+var adminInvitesCmd = &cobra.Command{
+	Use:   "invites",
+	Short: "Invite people to the workspace",
+}
+
+var adminInvitesSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Invite email addresses to the workspace and channels",
+	Long: `Invite people to the workspace by email, straight into one or more channels, for
+onboarding automation.
+
+Invites go through admin.users.invite, which needs an Enterprise Grid org admin
+token with admin.users:write (the workspace is the token's team, or --team). When
+the workspace or token cannot use it (not_an_enterprise, not_allowed_token_type,
+missing_scope, ...), slk falls back to the legacy users.admin.invite, which only
+accepts tokens of a workspace admin, such as browser-session (xoxc) tokens.
+
+Addresses come from --emails, from --csv, or both. The CSV needs a header row with an
+email column; optional name (or real_name) and channels columns set the invitee's
+name and extra channels, separated by semicolons:
+
+  email,name,channels
+  ada@example.com,Ada Lovelace,#eng;#design
+
+Every invitee joins --channels plus their own; Slack needs at least one channel.
+Channels are resolved before anything is sent, so a typo fails the whole run. A
+failed invite is reported in the result and does not stop the others.
+
+Output (JSON):
+  {
+    "sent": 1,
+    "failed": 1,
+    "invites": [
+      {"email": "ada@example.com", "name": "Ada Lovelace", "channels": ["#onboarding"], "channel_ids": ["C123ABC"], "method": "admin.users.invite", "sent": true},
+      {"email": "bob@example.com", "channels": ["#onboarding"], "channel_ids": ["C123ABC"], "method": "admin.users.invite", "sent": false, "error": "admin.users.invite: already_in_team"}
+    ]
+  }
+
+Required Scopes:
+  admin.users:write (Enterprise Grid org admin, user token)
+  channels:read, groups:read to resolve #channel names`,
+	Example: `  slk admin invites send --emails ada@example.com,bob@example.com --channels "#onboarding"
+
+  # From a CSV export, previewing first
+  slk admin invites send --csv new-hires.csv --channels "#onboarding" --dry-run --human
+  slk admin invites send --csv new-hires.csv --channels "#onboarding"
+
+  # Single-channel guests
+  slk admin invites send --emails contractor@agency.com --channels "#proj-apollo" --guest`,
+	Args: cobra.NoArgs,
+	RunE: runAdminInvitesSend,
+}
+
+func init() {
+	rootCmd.AddCommand(adminCmd)
+	adminCmd.AddCommand(adminInvitesCmd)
+	adminInvitesCmd.AddCommand(adminInvitesSendCmd)
+
+	adminInvitesSendCmd.Flags().String("emails", "", `Comma-separated addresses; "Name <address>" sets the name`)
+	adminInvitesSendCmd.Flags().String("csv", "", "CSV file of invitees with an email column (- for stdin)")
+	adminInvitesSendCmd.Flags().String("channels", "", "Comma-separated channel names or IDs every invitee joins")
+	adminInvitesSendCmd.Flags().String("team", "", "Workspace ID for admin.users.invite (default: the token's team)")
+	adminInvitesSendCmd.Flags().Bool("guest", false, "Invite single-channel guests instead of full members")
+	adminInvitesSendCmd.Flags().String("message", "", "Custom message added to the invite email (admin.users.invite only)")
+	adminInvitesSendCmd.Flags().Bool("resend", false, "Resend invites to addresses that were already invited")
+	adminInvitesSendCmd.Flags().Bool("dry-run", false, "Resolve channels and report the invites without sending them")
+	adminInvitesSendCmd.MarkFlagsOneRequired("emails", "csv")
+}
+
+func runAdminInvitesSend(cmd *cobra.Command, args []string) error {
+	emailsFlag, _ := cmd.Flags().GetString("emails")
+	csvPath, _ := cmd.Flags().GetString("csv")
+	channelsFlag, _ := cmd.Flags().GetString("channels")
+	team, _ := cmd.Flags().GetString("team")
+	guest, _ := cmd.Flags().GetBool("guest")
+	message, _ := cmd.Flags().GetString("message")
+	resend, _ := cmd.Flags().GetBool("resend")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	invitees, err := readInvitees(emailsFlag, csvPath)
+	if err != nil {
+		return err
+	}
+	if len(invitees) == 0 {
+		return cerrors.ConfigError("no email addresses to invite")
+	}
+	common := splitList(channelsFlag)
+	for i := range invitees {
+		invitees[i].Channels = append(append([]string(nil), common...), invitees[i].Channels...)
+		if len(invitees[i].Channels) == 0 {
+			return cerrors.ConfigError("%s has no channels; pass --channels or add a channels column", invitees[i].Email)
+		}
+	}
+	invitees = invites.Dedupe(invitees)
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	if team == "" {
+		team = cmdCtx.TeamID
+	}
+
+	resolved := map[string]string{}
+	result := invites.SendResult{DryRun: dryRun, Invites: []invites.Invite{}}
+	for _, inv := range invitees {
+		entry := invites.Invite{Email: inv.Email, Name: inv.Name, Channels: inv.Channels}
+		for _, channel := range inv.Channels {
+			id, ok := resolved[channel]
+			if !ok {
+				if id, err = cmdCtx.ResolveChannel(channel); err != nil {
+					return err
+				}
+				resolved[channel] = id
+			}
+			entry.ChannelIDs = append(entry.ChannelIDs, id)
+		}
+		result.Invites = append(result.Invites, entry)
+	}
+	if dryRun {
+		return output.Print(cmd, result)
+	}
+
+	for i, inv := range result.Invites {
+		method, err := cmdCtx.Client.InviteUser(cmdCtx.Ctx, slack.InviteParams{
+			Email:         inv.Email,
+			ChannelIDs:    inv.ChannelIDs,
+			TeamID:        team,
+			RealName:      inv.Name,
+			Guest:         guest,
+			CustomMessage: message,
+			Resend:        resend,
+		})
+		result.Invites[i].Method = method
+		if err != nil {
+			// Local policy refuses every invite the same way; stop at the first.
+			if cerrors.ExitCode(err) == cerrors.ExitPolicy {
+				return err
+			}
+			result.Invites[i].Error = err.Error()
+			result.Failed++
+			continue
+		}
+		result.Invites[i].Sent = true
+		result.Sent++
+	}
+	if result.Failed > 0 {
+		warnings.Add(warnings.PartialResults, "%d of %d invites failed; see the error field", result.Failed, len(result.Invites))
+	}
+	return output.Print(cmd, result)
+}
+
+// readInvitees collects invitees from --emails and the --csv file, in that order.
+func readInvitees(emailsFlag, csvPath string) ([]invites.Invitee, error) {
+	var invitees []invites.Invitee
+	for _, raw := range splitList(emailsFlag) {
+		email, name, err := invites.ParseEmail(raw)
+		if err != nil {
+			return nil, cerrors.ConfigError("--emails: %v", err)
+		}
+		invitees = append(invitees, invites.Invitee{Email: email, Name: name})
+	}
+	if csvPath == "" {
+		return invitees, nil
+	}
+	var r io.Reader = os.Stdin
+	if csvPath != "-" {
+		f, err := os.Open(csvPath)
+		if err != nil {
+			return nil, cerrors.ConfigError("--csv: %v", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	fromCSV, err := invites.ReadCSV(r)
+	if err != nil {
+		return nil, cerrors.ConfigError("--csv %s: %v", csvPath, err)
+	}
+	return append(invitees, fromCSV...), nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/alerts"
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/eventstore"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/policy"
+	"github.com/kehao95/slack-agent-cli/internal/redact"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// alertStateRetention is how long sent-alert keys are remembered for deduplication.
+const alertStateRetention = 30 * 24 * time.Hour
+
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Keyword alerting",
+	Long:  "Watch channels for keywords and patterns and forward matches to DMs, channels, or webhooks.",
+}
+
+var alertsRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run keyword alert rules",
+	Long: `Match new messages against the rules in --rules and forward each match to the
+rule's notify targets. Each match is printed as one JSON line (--human for text).
+
+Rules file (YAML or JSON):
+
+  rules:
+    - name: outage
+      channels: ["#support", "#general"]   # omit to watch every channel
+      keywords: ["outage", "down for"]     # case-insensitive, whole words
+      patterns: ['INC-\d+']                # regular expressions
+      include_bots: false
+      notify:
+        - dm: "@oncall"
+        - channel: "#alerts"
+        - webhook: https://hooks.slack.com/services/T000/B000/XXX
+
+Sources:
+  daemon  Tail the local event cache written by "slk daemon run" (default). Sees
+          thread replies and every channel the daemon receives.
+  poll    Poll conversations.history for the rule channels every --interval. Needs
+          channels on every rule and does not see thread replies.
+
+Only messages that arrive after the first run are checked. Progress and the keys of
+sent alerts are kept in --state, so a restart neither repeats alerts nor misses
+messages; a match that no target accepted is retried. Messages from the active
+identity never trigger alerts.
+
+DM and channel targets follow quiet_hours: outside a target's allowed window the
+alert is not posted there and the target is reported under "errors". Webhooks
+leave Slack and are always called. --override-quiet-hours posts regardless.
+
+Matched message text is passed through the redaction filter before it is posted or
+sent to a webhook, like messages send; --no-redact forwards it as-is. The JSON
+lines printed locally keep the original text.`,
+	Example: `  # Alongside the daemon
+  SLACK_CLI_ROLE=bot slk daemon run &
+  SLACK_CLI_ROLE=bot slk alerts run --rules alerts.yaml
+
+  # Without Socket Mode: poll the rule channels every minute
+  slk alerts run --rules alerts.yaml --source poll --interval 1m
+
+  # Check rules against new messages without sending anything
+  slk alerts run --rules alerts.yaml --dry-run --once --human`,
+	Args: cobra.NoArgs,
+	RunE: runAlertsRun,
+}
+
+func init() {
+	rootCmd.AddCommand(alertsCmd)
+	alertsCmd.AddCommand(alertsRunCmd)
+
+	alertsRunCmd.Flags().String("rules", "", "Rules file, YAML or JSON (required)")
+	alertsRunCmd.Flags().String("source", "daemon", "Message source: daemon or poll")
+	alertsRunCmd.Flags().Duration("interval", 30*time.Second, "How often to check for new messages")
+	alertsRunCmd.Flags().String("state", "", "State file (default: alerts/<team>/<rules name>.state.json next to the config file)")
+	alertsRunCmd.Flags().Bool("once", false, "Check once and exit instead of running until interrupted")
+	alertsRunCmd.Flags().Bool("dry-run", false, "Report matches without sending or recording them")
+	alertsRunCmd.MarkFlagRequired("rules")
+	addQuietHoursFlag(alertsRunCmd)
+	addRedactFlag(alertsRunCmd)
+}
+
+func runAlertsRun(cmd *cobra.Command, args []string) error {
+	rulesPath, _ := cmd.Flags().GetString("rules")
+	source, _ := cmd.Flags().GetString("source")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	statePath, _ := cmd.Flags().GetString("state")
+	once, _ := cmd.Flags().GetBool("once")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if source != "daemon" && source != "poll" {
+		return fmt.Errorf("--source must be daemon or poll, got %q", source)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	rules, err := alerts.LoadRules(rulesPath)
+	if err != nil {
+		return cerrors.ConfigError("%v", err)
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	if err := cmdCtx.EnsureAuthIdentity(cmdCtx.Ctx); err != nil {
+		return err
+	}
+
+	channelIDs := make([][]string, len(rules.Rules))
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		for _, ch := range rule.Channels {
+			id, err := cmdCtx.ResolveChannel(ch)
+			if err != nil {
+				return fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			channelIDs[i] = append(channelIDs[i], id)
+		}
+		for j := range rule.Notify {
+			if err := resolveAlertTarget(cmdCtx, &rule.Notify[j]); err != nil {
+				return fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+		}
+	}
+
+	_, configPath, err := config.Load(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+	if statePath == "" {
+		name := strings.TrimSuffix(filepath.Base(rulesPath), filepath.Ext(rulesPath))
+		statePath = filepath.Join(filepath.Dir(configPath), "alerts", cmdCtx.TeamID, name+".state.json")
+	}
+	state, err := alerts.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+	state.Prune(time.Now().Add(-alertStateRetention))
+
+	// Surface a broken quiet_hours config now rather than on the first match.
+	if _, err := quietHoursDecision(cmd, cmdCtx, "", false); err != nil {
+		return err
+	}
+	redactor, err := newRedactor(cmd, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	var notifier alerts.Notifier = alerts.Dispatcher{Poster: cmdCtx.Client}
+	if redactor != nil {
+		notifier = redactingNotifier{Notifier: notifier, redactor: redactor}
+	}
+	notifier = quietHoursNotifier{Notifier: notifier, cmd: cmd, cmdCtx: cmdCtx}
+	engine := alerts.NewEngine(rules, state, notifier, alerts.EngineOptions{
+		ChannelIDs: channelIDs,
+		SelfID:     cmdCtx.AuthUserID,
+		DryRun:     dryRun,
+	})
+
+	var check func(ctx context.Context) ([]alerts.Alert, error)
+	switch source {
+	case "daemon":
+		dbPath, err := eventstore.DefaultPath(configPath, cmdCtx.TeamID)
+		if err != nil {
+			return cerrors.ConfigError("resolve event store path: %w", err)
+		}
+		store, err := eventstore.Open(dbPath)
+		if err != nil {
+			return fmt.Errorf("open event store: %w", err)
+		}
+		defer store.Close()
+		if state.Cursor == 0 {
+			if state.Cursor, err = store.LatestCursor(cmdCtx.Ctx); err != nil {
+				return err
+			}
+		}
+		check = func(ctx context.Context) ([]alerts.Alert, error) {
+			return checkAlertsFromStore(ctx, store, engine, state)
+		}
+	case "poll":
+		watched, ok := engine.WatchedChannels()
+		if !ok || len(watched) == 0 {
+			return cerrors.ConfigError("--source poll needs channels on every rule")
+		}
+		now := strconv.FormatInt(time.Now().Unix(), 10) + ".000000"
+		for _, id := range watched {
+			if state.Latest[id] == "" {
+				state.Latest[id] = now
+			}
+		}
+		check = func(ctx context.Context) ([]alerts.Alert, error) {
+			return checkAlertsByPolling(ctx, cmdCtx, watched, engine, state)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(cmdCtx.Ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fired, err := check(ctx)
+		for _, alert := range fired {
+			if printErr := output.Print(cmd, alert); printErr != nil {
+				return printErr
+			}
+		}
+		if !dryRun {
+			if saveErr := state.Save(); saveErr != nil {
+				return saveErr
+			}
+		}
+		if err != nil && ctx.Err() == nil {
+			if once {
+				return err
+			}
+			output.Warnf("%v", err)
+		}
+		if once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// quietHoursNotifier holds back DM and channel alerts outside their quiet_hours window.
+// Webhook targets are not Slack writes and pass straight through.
+type quietHoursNotifier struct {
+	alerts.Notifier
+	cmd    *cobra.Command
+	cmdCtx *CommandContext
+}
+
+// Notify implements alerts.Notifier.
+func (n quietHoursNotifier) Notify(ctx context.Context, target alerts.Target, alert alerts.Alert) error {
+	if target.DM != "" || target.Channel != "" {
+		decision, err := quietHoursDecision(n.cmd, n.cmdCtx, target.Channel, false)
+		if err != nil {
+			return err
+		}
+		if decision != nil && decision.Action == policy.ActionReject {
+			return quietHoursError(n.cmd, decision)
+		}
+	}
+	return n.Notifier.Notify(ctx, target, alert)
+}
+
+// redactingNotifier masks secrets in an alert's matched text before it is posted or sent
+// to a webhook.
+type redactingNotifier struct {
+	alerts.Notifier
+	redactor *redact.Redactor
+}
+
+// Notify implements alerts.Notifier.
+func (n redactingNotifier) Notify(ctx context.Context, target alerts.Target, alert alerts.Alert) error {
+	alert.Text, _ = n.redactor.Text(alert.Text)
+	alert.Match, _ = n.redactor.Text(alert.Match)
+	return n.Notifier.Notify(ctx, target, alert)
+}
+
+// resolveAlertTarget replaces a target's DM user or channel name with its ID.
+func resolveAlertTarget(cmdCtx *CommandContext, target *alerts.Target) error {
+	switch {
+	case target.Channel != "":
+		id, err := cmdCtx.ResolveChannel(target.Channel)
+		if err != nil {
+			return err
+		}
+		target.Channel = id
+	case target.DM != "":
+		name := strings.TrimPrefix(target.DM, "@")
+		if strings.HasPrefix(name, "U") || strings.HasPrefix(name, "W") {
+			if _, err := cmdCtx.UserResolver.GetUser(cmdCtx.Ctx, name); err == nil {
+				target.DM = name
+				return nil
+			}
+		}
+		user, ok := cmdCtx.UserResolver.FindByName(cmdCtx.Ctx, name)
+		if !ok {
+			return cerrors.UserNotFoundError(target.DM)
+		}
+		target.DM = user.ID
+	}
+	return nil
+}
+
+// checkAlertsFromStore processes message events the daemon cached after state.Cursor.
+func checkAlertsFromStore(ctx context.Context, store *eventstore.Store, engine *alerts.Engine, state *alerts.State) ([]alerts.Alert, error) {
+	var fired []alerts.Alert
+	for {
+		events, err := store.Query(ctx, eventstore.Filter{Type: "message", SinceCursor: state.Cursor, Limit: 200})
+		if err != nil {
+			return fired, err
+		}
+		for _, ev := range events {
+			fired = append(fired, engine.Process(ctx, alerts.Message{
+				ChannelID: ev.ChannelID,
+				Channel:   ev.Channel,
+				UserID:    ev.UserID,
+				User:      ev.User,
+				BotID:     ev.BotID,
+				TS:        ev.TS,
+				ThreadTS:  ev.ThreadTS,
+				Text:      ev.Text,
+			})...)
+			state.Cursor = ev.Cursor
+		}
+		if len(events) < 200 {
+			return fired, nil
+		}
+	}
+}
+
+// checkAlertsByPolling reads each watched channel's history since the newest message
+// seen there, oldest first.
+func checkAlertsByPolling(ctx context.Context, cmdCtx *CommandContext, channelIDs []string, engine *alerts.Engine, state *alerts.State) ([]alerts.Alert, error) {
+	var fired []alerts.Alert
+	for _, channelID := range channelIDs {
+		name := cmdCtx.ChannelResolver.ResolveName(ctx, channelID)
+		if name != channelID {
+			name = "#" + name
+		}
+		cursor := ""
+		var pages [][]alerts.Message
+		for {
+			resp, err := cmdCtx.Client.ListConversationsHistory(ctx, slack.HistoryParams{
+				Channel: channelID,
+				Oldest:  state.Latest[channelID],
+				Cursor:  cursor,
+				Limit:   200,
+			})
+			if err != nil {
+				return fired, fmt.Errorf("poll %s: %w", channelID, err)
+			}
+			var page []alerts.Message
+			for _, msg := range resp.Messages {
+				page = append(page, alerts.Message{
+					ChannelID: channelID,
+					Channel:   name,
+					UserID:    msg.User,
+					BotID:     msg.BotID,
+					TS:        msg.Timestamp,
+					ThreadTS:  msg.ThreadTimestamp,
+					Text:      msg.Text,
+				})
+			}
+			pages = append(pages, page)
+			if !resp.HasMore || resp.ResponseMetaData.NextCursor == "" {
+				break
+			}
+			cursor = resp.ResponseMetaData.NextCursor
+		}
+		// History pages are newest first; process oldest first so Latest only moves forward.
+		for p := len(pages) - 1; p >= 0; p-- {
+			for m := len(pages[p]) - 1; m >= 0; m-- {
+				msg := pages[p][m]
+				if msg.UserID != "" {
+					msg.User = cmdCtx.UserResolver.GetMentionName(ctx, msg.UserID)
+				}
+				fired = append(fired, engine.Process(ctx, msg)...)
+				state.Latest[channelID] = msg.TS
+			}
+		}
+	}
+	return fired, nil
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+)
+
+var apphomeCmd = &cobra.Command{
+	Use:   "apphome",
+	Short: "App Home tab operations",
+	Long:  "Publish the app's Home tab for a user and link people to it.",
+}
+
+var apphomePublishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish a user's App Home tab",
+	Long: `Replace the Home tab a user sees for this app (views.publish), for example with
+a dashboard of the agent's recent actions.
+
+--view is a Home tab view, {"type": "home", "blocks": [...]}, or a bare block array,
+which is wrapped in one. Blocks get the same checks as blocks validate, except that
+a Home tab allows 100 blocks instead of 50. private_metadata, callback_id, and
+external_id in the view are passed through.
+
+Each publish replaces the whole tab. Pass the hash from the previous publish as
+--hash to fail with hash_conflict instead of overwriting a newer update from
+another process.
+
+Output (JSON):
+  {
+    "ok": true,
+    "user": "@alice",
+    "user_id": "U123ABC",
+    "view_id": "V123ABC",
+    "hash": "1705312365.a1b2c3d4"
+  }
+
+Required Scopes:
+  None beyond a bot token for the app, and the Home tab enabled under App Home in
+  the app settings; user tokens cannot call views.publish.
+  users:read to resolve --user @name`,
+	Example: `  # Publish a dashboard to Alice's Home tab
+  slk apphome publish --user @alice --view view.json
+
+  # Generate the view and publish it only if nothing else updated it since
+  summarize-actions | slk apphome publish --user U0123ABCD --view - --hash "$HASH"`,
+	Args: cobra.NoArgs,
+	RunE: runApphomePublish,
+}
+
+var apphomeOpenCmd = &cobra.Command{
+	Use:   "open",
+	Short: "Print a link that opens the app's Home tab",
+	Long: `Print links that open this app's Home tab in Slack, for posting next to a
+summary ("see the dashboard") or opening from a script. Slack has no API to switch a
+person's client to a tab, so this only builds the links.
+
+The app ID is looked up from the bot token with bots.info (cached); pass --app to
+skip the lookup, for example when running with a user token.
+
+Output (JSON):
+  {
+    "app_id": "A123ABC",
+    "team_id": "T123ABC",
+    "url": "https://slack.com/app_redirect?app=A123ABC&team=T123ABC",
+    "deep_link": "slack://app?id=A123ABC&tab=home&team=T123ABC"
+  }
+
+Required Scopes:
+  users:read (bots.info), unless --app is set`,
+	Example: `  # Link to the Home tab from a message
+  slk messages send --channel "#ops" --text "Dashboard: $(slk apphome open | jq -r .url)"
+
+  # Open the Home tab in the desktop app (macOS)
+  open "$(slk apphome open | jq -r .deep_link)"`,
+	Args: cobra.NoArgs,
+	RunE: runApphomeOpen,
+}
+
+func init() {
+	rootCmd.AddCommand(apphomeCmd)
+	apphomeCmd.AddCommand(apphomePublishCmd)
+	apphomeCmd.AddCommand(apphomeOpenCmd)
+
+	apphomePublishCmd.Flags().StringP("user", "u", "", "User ID or @name whose Home tab to publish (required)")
+	apphomePublishCmd.Flags().String("view", "", "Home tab view JSON file, or - for stdin (required)")
+	apphomePublishCmd.Flags().String("hash", "", "Hash from the previous publish; fail instead of overwriting a newer view")
+	apphomePublishCmd.MarkFlagRequired("user")
+	apphomePublishCmd.MarkFlagRequired("view")
+
+	apphomeOpenCmd.Flags().String("app", "", "App ID (A...); looked up from the bot token when omitted")
+}
+
+// ApphomePublishResult is the output of apphome publish.
+type ApphomePublishResult struct {
+	OK     bool   `json:"ok"`
+	User   string `json:"user"`
+	UserID string `json:"user_id"`
+	ViewID string `json:"view_id"`
+	Hash   string `json:"hash,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ApphomePublishResult) Lines() []string {
+	return []string{fmt.Sprintf("Published Home tab for %s (view %s)", r.User, r.ViewID)}
+}
+
+// ApphomeOpenResult is the output of apphome open.
+type ApphomeOpenResult struct {
+	AppID    string `json:"app_id"`
+	TeamID   string `json:"team_id"`
+	URL      string `json:"url"`
+	DeepLink string `json:"deep_link"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ApphomeOpenResult) Lines() []string {
+	return []string{r.URL}
+}
+
+// parseHomeView builds a Home tab view from a view object or a bare block array.
+func parseHomeView(data []byte) (slackapi.HomeTabViewRequest, error) {
+	view, blocks, err := decodeView(data, slackapi.VTHomeTab)
+	if err != nil {
+		return slackapi.HomeTabViewRequest{}, err
+	}
+	return slackapi.HomeTabViewRequest{
+		Type:            slackapi.VTHomeTab,
+		Blocks:          slackapi.Blocks{BlockSet: blocks},
+		PrivateMetadata: view.PrivateMetadata,
+		CallbackID:      view.CallbackID,
+		ExternalID:      view.ExternalID,
+	}, nil
+}
+
+// appHomeLinks returns the web redirect and desktop deep link to an app's Home tab.
+func appHomeLinks(appID, teamID string) (string, string) {
+	web := url.Values{"app": {appID}, "team": {teamID}}
+	deep := url.Values{"id": {appID}, "tab": {"home"}, "team": {teamID}}
+	return "https://slack.com/app_redirect?" + web.Encode(), "slack://app?" + deep.Encode()
+}
+
+func runApphomePublish(cmd *cobra.Command, args []string) error {
+	userInput, _ := cmd.Flags().GetString("user")
+	hash, _ := cmd.Flags().GetString("hash")
+
+	data, err := readViewFlag(cmd)
+	if err != nil {
+		return err
+	}
+	view, err := parseHomeView(data)
+	if err != nil {
+		return err
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	userID, err := cmdCtx.ResolveUser(userInput)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cmdCtx.Client.PublishHomeView(cmdCtx.Ctx, userID, view, hash)
+	if err != nil {
+		return err
+	}
+
+	result := ApphomePublishResult{
+		OK:     true,
+		User:   userInput,
+		UserID: userID,
+		ViewID: resp.ID,
+		Hash:   resp.Hash,
+	}
+	if name := cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, userID); name != "" && name != userID {
+		result.User = "@" + strings.TrimPrefix(name, "@")
+	}
+	return output.Print(cmd, result)
+}
+
+func runApphomeOpen(cmd *cobra.Command, args []string) error {
+	appID, _ := cmd.Flags().GetString("app")
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	if appID == "" {
+		if err := cmdCtx.EnsureAuthIdentity(cmdCtx.Ctx); err != nil {
+			return err
+		}
+		if cmdCtx.AuthBotID == "" {
+			return cerrors.ConfigError("apphome open needs a bot token to find the app; pass --app A... instead")
+		}
+		bot, ok := cmdCtx.BotResolver.Lookup(cmdCtx.Ctx, cmdCtx.AuthBotID)
+		if !ok || bot.AppID == "" {
+			return cerrors.NotFoundError("app for bot", cmdCtx.AuthBotID, "Hint: Pass the app ID with --app (shown under Basic Information in the app settings)")
+		}
+		appID = bot.AppID
+	}
+
+	result := ApphomeOpenResult{AppID: appID, TeamID: cmdCtx.TeamID}
+	result.URL, result.DeepLink = appHomeLinks(appID, cmdCtx.TeamID)
+	return output.Print(cmd, result)
+}
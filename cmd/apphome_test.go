@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func TestParseHomeView(t *testing.T) {
+	view, err := parseHomeView([]byte(`{"type":"home","callback_id":"dash","blocks":[{"type":"section","text":{"type":"mrkdwn","text":"*Recent actions*"}}]}`))
+	if err != nil {
+		t.Fatalf("parseHomeView: %v", err)
+	}
+	if view.Type != slackapi.VTHomeTab || view.CallbackID != "dash" || len(view.Blocks.BlockSet) != 1 {
+		t.Fatalf("view = %+v", view)
+	}
+
+	// A bare block array is wrapped, and Home tabs allow more blocks than messages.
+	blocks := "[" + strings.TrimSuffix(strings.Repeat(`{"type":"divider"},`, 60), ",") + "]"
+	view, err = parseHomeView([]byte(blocks))
+	if err != nil || view.Type != slackapi.VTHomeTab || len(view.Blocks.BlockSet) != 60 {
+		t.Fatalf("parseHomeView(array) = %d blocks, %v", len(view.Blocks.BlockSet), err)
+	}
+
+	for _, bad := range []string{
+		`{"type":"modal","blocks":[{"type":"divider"}]}`,
+		`{"type":"home","blocks":[]}`,
+		`{"type":"home","blocks":[{"type":"header","text":{"type":"mrkdwn","text":"x"}}]}`,
+		``,
+	} {
+		if _, err := parseHomeView([]byte(bad)); err == nil {
+			t.Errorf("parseHomeView(%s) succeeded", bad)
+		}
+	}
+}
+
+func TestAppHomeLinks(t *testing.T) {
+	web, deep := appHomeLinks("A0123ABCD", "T0123ABCD")
+	if web != "https://slack.com/app_redirect?app=A0123ABCD&team=T0123ABCD" {
+		t.Fatalf("web link = %s", web)
+	}
+	if deep != "slack://app?id=A0123ABCD&tab=home&team=T0123ABCD" {
+		t.Fatalf("deep link = %s", deep)
+	}
+}
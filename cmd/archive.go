@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kehao95/slack-agent-cli/internal/archive"
+	"github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/users"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Read official Slack workspace export archives",
+	Long:  `Commands for reading Slack workspace export archives offline. No Slack token is required.`,
+}
+
+var archiveReadCmd = &cobra.Command{
+	Use:   "read",
+	Short: "Read channel messages from a Slack export archive",
+	Long: `Read messages for one channel from an official Slack workspace export (the .zip
+downloaded from Workspace settings, or its unpacked directory).
+
+Messages are emitted in the same JSON schema as 'slk messages list', including resolved
+user references from the archive's users.json, so downstream tooling works identically
+on live and exported data. Like the live command, top-level history is newest first and
+omits thread replies; use --thread to read one thread.
+
+Output (JSON):
+  {
+    "channel": "#general",
+    "channel_id": "C123ABC",
+    "channel_name": "general",
+    "messages": [
+      {"type": "message", "user": "@alice", "user_id": "U123ABC", "text": "...", "ts": "1705312365.000100"}
+    ],
+    "has_more": false,
+    "next_cursor": ""
+  }`,
+	Example: `  # Read #general from an export
+  slk archive read --path export.zip --channel general
+
+  # Only messages since a date
+  slk archive read --path export.zip --channel "#general" --since 2024-01-01T00:00:00Z
+
+  # Read a thread
+  slk archive read --path ./export --channel general --thread 1705312365.000100`,
+	RunE: runArchiveRead,
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+	archiveCmd.AddCommand(archiveReadCmd)
+
+	archiveReadCmd.Flags().String("path", "", "Path to the export .zip or unpacked directory (required)")
+	archiveReadCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	archiveReadCmd.Flags().IntP("limit", "l", 0, "Maximum messages to return (0 = all)")
+	archiveReadCmd.Flags().String("since", "", "Messages after this time (ISO or relative like 24h)")
+	archiveReadCmd.Flags().String("until", "", "Messages before this time")
+	archiveReadCmd.Flags().String("thread", "", "Thread timestamp to read replies")
+	archiveReadCmd.Flags().Bool("raw-json", false, "Preserve raw Slack IDs in JSON output")
+	archiveReadCmd.MarkFlagRequired("path")
+	archiveReadCmd.MarkFlagRequired("channel")
+}
+
+func runArchiveRead(cmd *cobra.Command, args []string) error {
+	archivePath, _ := cmd.Flags().GetString("path")
+	channelInput, _ := cmd.Flags().GetString("channel")
+	limit, _ := cmd.Flags().GetInt("limit")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	thread, _ := cmd.Flags().GetString("thread")
+	rawJSON, _ := cmd.Flags().GetBool("raw-json")
+
+	a, err := archive.Open(archivePath)
+	if err != nil {
+		return errors.NewErrorWithCode(errors.ExitGeneral, "%v", err)
+	}
+	defer a.Close()
+
+	ch, ok := a.FindChannel(channelInput)
+	if !ok {
+		return errors.NotFoundError("channel", channelInput, fmt.Sprintf("Hint: the archive at %s does not list this channel", archivePath))
+	}
+	oldest, latest, err := slack.ParseTimeRange(since, until)
+	if err != nil {
+		return err
+	}
+
+	query := archive.Query{Oldest: oldest, Latest: latest, Thread: thread}
+	if limit > 0 {
+		query.Limit = limit + 1
+	}
+	msgs, err := a.Messages(ch, query)
+	if err != nil {
+		return err
+	}
+	hasMore := limit > 0 && len(msgs) > limit
+	if hasMore {
+		msgs = msgs[:limit]
+	}
+
+	result := messages.Result{
+		Channel:     ch.ID,
+		ChannelName: ch.Name,
+		ThreadTS:    thread,
+		Messages:    msgs,
+		HasMore:     hasMore,
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result.SetUserResolver(ctx, users.NewResolver(a))
+	result.SetRawJSON(rawJSON)
+	return output.Print(cmd, result)
+}
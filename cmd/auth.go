@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
 	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +22,13 @@ var authCmd = &cobra.Command{
 var authTestCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Verify credentials work",
-	Long:  "Test that the configured Slack tokens are valid by making an auth.test API call.",
+	Long: `Test that the configured Slack tokens are valid by making an auth.test API call.
+
+Before calling Slack, the active token is classified by prefix (xoxp- user, xoxb- bot,
+xoxc- browser client, xapp- app-level) and checked for common copy mistakes such as
+quotes or a "Bearer " prefix. The result includes the token type, the scopes Slack
+granted, and warnings for commands the token cannot run (for example, messages search
+with a bot token). Authentication failures explain what to do for that token type.`,
 	Example: `  slk auth test
   slk auth test --human`,
 	RunE: runAuthTest,
@@ -38,21 +49,148 @@ func init() {
 	authCmd.AddCommand(authWhoamiCmd)
 }
 
+// diagnosedCommands are the commands auth test checks against the token's type and
+// scopes; their requirements come from commandScopeTable.
+var diagnosedCommands = []string{
+	"messages list", "messages search", "messages send", "channels list",
+	"users list", "reactions add", "pins list", "emoji list",
+}
+
+// authFailureCodes are Slack errors that mean the token itself was rejected.
+var authFailureCodes = []string{"invalid_auth", "not_authed", "token_revoked", "token_expired", "account_inactive", "not_allowed_token_type"}
+
 func runAuthTest(cmd *cobra.Command, args []string) error {
-	cmdCtx, err := NewCommandContext(cmd, 10*time.Second)
+	cfg, path, err := config.Load(cfgFile)
 	if err != nil {
-		return err
+		return cerrors.ConfigError("failed to load config: %w", err)
 	}
-	defer cmdCtx.Close()
 
-	result, err := cmdCtx.Client.AuthTest(cmdCtx.Ctx)
+	// Classify the stored token first so malformed values are explained instead of
+	// surfacing as invalid_auth.
+	role := strings.ToLower(strings.TrimSpace(cfg.Role))
+	if role == "" {
+		role = config.RoleUser
+	}
+	rawToken := cfg.UserToken
+	if role == config.RoleBot {
+		rawToken = cfg.BotToken
+	}
+	info := slack.ClassifyToken(rawToken)
+	if err := checkTokenForRole(info, role); err != nil {
+		return cerrors.ConfigError("invalid config (%s): %v", path, err)
+	}
+
+	token, cookie, role, err := cfg.ActiveAuth()
 	if err != nil {
-		return fmt.Errorf("auth test: %w", err)
+		return cerrors.ConfigError("invalid config (%s): %w", path, err)
 	}
 
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+	result, err := slack.NewAuto(token, cookie).AuthTest(ctx)
+	if err != nil {
+		return authTestError(err, info)
+	}
+
+	result.Token = &info
+	result.Warnings = tokenWarnings(info, result.Scopes, cfg)
+	if role == config.RoleUser {
+		result.BotID = ""
+		result.IsBot = false
+	}
 	return output.Print(cmd, result)
 }
 
+// checkTokenForRole rejects tokens that are malformed or cannot serve the active role.
+func checkTokenForRole(info slack.TokenInfo, role string) error {
+	if len(info.Problems) > 0 {
+		return fmt.Errorf("%s token: %s", role, strings.Join(info.Problems, "; "))
+	}
+	switch {
+	case info.Type == slack.TokenApp:
+		return fmt.Errorf("%s token is an app-level xapp- token, which only opens Socket Mode connections; put it in app_token and configure a %s token", role, role)
+	case role == config.RoleUser && info.Type == slack.TokenBot:
+		return fmt.Errorf("role=user but the user token is a bot token (xoxb-); set role to bot or configure an xoxp-/xoxc- token")
+	case role == config.RoleBot && (info.Type == slack.TokenUser || info.Type == slack.TokenClient):
+		return fmt.Errorf("role=bot but the bot token is a %s token (%s); set role to user or configure an xoxb- token", info.Type, info.Prefix)
+	}
+	return nil
+}
+
+// tokenWarnings lists commands the token cannot run and other configuration gaps.
+// Scope checks are skipped when Slack did not report scopes (browser client tokens).
+func tokenWarnings(info slack.TokenInfo, scopes []string, cfg *config.Config) []string {
+	granted := map[string]bool{}
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	var warnings []string
+	for _, command := range diagnosedCommands {
+		req, _ := lookupCommandScopes(command)
+		unsupported := false
+		for _, kind := range req.unsupported {
+			unsupported = unsupported || kind == info.Type
+		}
+		if unsupported {
+			warnings = append(warnings, fmt.Sprintf("%s is not available to %s tokens; use a user token (xoxp-) with role=user", req.command, info.Type))
+			continue
+		}
+		if len(granted) == 0 {
+			continue
+		}
+		var missing []string
+		for _, scope := range req.scopes {
+			if !granted[scope] {
+				missing = append(missing, scope)
+			}
+		}
+		if len(missing) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s needs the %s scope", req.command, strings.Join(missing, " and ")))
+		}
+	}
+
+	if warning := cookieExpiryWarning(cfg, time.Now()); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	appToken := strings.TrimSpace(cfg.AppToken)
+	if appToken == "" {
+		warnings = append(warnings, "app_token is not set; events stream and daemon run need an xapp- token")
+	} else if app := slack.ClassifyToken(appToken); app.Type != slack.TokenApp || len(app.Problems) > 0 {
+		warnings = append(warnings, "app_token is not a valid xapp- token; events stream and daemon run will fail")
+	}
+	return warnings
+}
+
+// authTestError turns a rejected token into an auth error with a fix for its type.
+// Other failures (network, rate limits) are returned for the usual classification.
+func authTestError(err error, info slack.TokenInfo) error {
+	code := ""
+	for _, c := range authFailureCodes {
+		if strings.Contains(err.Error(), c) {
+			code = c
+			break
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("auth test: %w", err)
+	}
+
+	var hint string
+	switch info.Type {
+	case slack.TokenClient:
+		hint = "xoxc- tokens end with the browser session; copy a fresh token and d= cookie into SLACK_CLIENT_TOKEN and SLACK_CLIENT_COOKIE"
+	case slack.TokenBot:
+		hint = "the bot token may have been revoked by an app reinstall; copy the current Bot User OAuth Token from the app settings"
+	case slack.TokenUser:
+		hint = "the user token may have been revoked or the app uninstalled; get a new one with slk auth oauth"
+	default:
+		hint = "check that the configured token was copied completely"
+	}
+	return cerrors.AuthError("auth test failed (%s) with %s token: %s", code, info.Type, hint)
+}
+
 func runAuthWhoami(cmd *cobra.Command, args []string) error {
 	// whoami is essentially the same as test - both call auth.test
 	return runAuthTest(cmd, args)
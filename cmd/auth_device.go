@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// Device authorization follows the shape of RFC 8628. The relay is an
+// 'slk auth oauth' server reachable from both machines: the headless host asks it
+// for a code, the user enters the code on the relay's /device page and authorizes
+// in Slack, and the host polls the relay until the exchanged token is available.
+const (
+	deviceCodeTTL      = 10 * time.Minute
+	devicePollInterval = 5 * time.Second
+	// userCodeAlphabet omits vowels and look-alike characters.
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+)
+
+// deviceScopePattern limits the scopes a device may request through the relay.
+var deviceScopePattern = regexp.MustCompile(`^[a-z][a-z.:_]*(,[a-z][a-z.:_]*)*$`)
+
+var authDeviceCmd = &cobra.Command{
+	Use:   "device",
+	Short: "Authorize a headless host with a short code",
+	Long: `Authorize from another machine when this host has no browser and cannot accept
+the OAuth callback.
+
+The command asks a relay for a short code, prints a URL and the code, and polls the
+relay until someone opens the URL, enters the code, and approves the app in Slack.
+The resulting user token is saved to the config file (use --save=false to print it
+instead). --scope-preset asks the relay for a curated scope set; otherwise the
+relay's --scopes apply.
+
+The relay is an 'slk auth oauth' server running where both machines can reach it,
+with its redirect URI registered in the Slack app. Pass its base URL with --relay
+or SLACK_CLI_AUTH_RELAY. Only outbound HTTPS is needed on this host.`,
+	Example: `  # On a reachable machine
+  slk auth oauth --client-id ID --client-secret SECRET --redirect-uri https://relay.example.com/callback
+
+  # On the headless host
+  slk auth device --relay https://relay.example.com`,
+	Args: cobra.NoArgs,
+	RunE: runAuthDevice,
+}
+
+func init() {
+	authCmd.AddCommand(authDeviceCmd)
+
+	authDeviceCmd.Flags().String("relay", "", "Base URL of the slk auth oauth relay (or SLACK_CLI_AUTH_RELAY env)")
+	authDeviceCmd.Flags().Bool("save", true, "Save the token to the config file")
+	authDeviceCmd.Flags().String("scope-preset", "", "Request a curated scope set: readonly|poster|full|watch (see slk auth plan)")
+}
+
+// DeviceCodeResponse is returned by the relay's /device/code endpoint.
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceTokenResponse is returned by the relay's /device/token endpoint: an RFC 8628
+// error while waiting, or the Slack token exchange once authorized.
+type deviceTokenResponse struct {
+	Error string              `json:"error,omitempty"`
+	Token *OAuthTokenResponse `json:"token,omitempty"`
+}
+
+// DeviceAuthResult is the output of auth device.
+type DeviceAuthResult struct {
+	OK         bool     `json:"ok"`
+	Team       string   `json:"team,omitempty"`
+	TeamID     string   `json:"team_id"`
+	UserID     string   `json:"user_id"`
+	Scopes     []string `json:"scopes,omitempty"`
+	ConfigPath string   `json:"config_path,omitempty"`
+	Token      string   `json:"token,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r DeviceAuthResult) Lines() []string {
+	lines := []string{fmt.Sprintf("Authorized %s in %s (%s)", r.UserID, r.Team, r.TeamID)}
+	if len(r.Scopes) > 0 {
+		lines = append(lines, "Scopes: "+strings.Join(r.Scopes, ", "))
+	}
+	if r.ConfigPath != "" {
+		lines = append(lines, "Token saved to "+r.ConfigPath)
+	}
+	if r.Token != "" {
+		lines = append(lines, "Token: "+r.Token)
+	}
+	return lines
+}
+
+func runAuthDevice(cmd *cobra.Command, args []string) error {
+	relay, _ := cmd.Flags().GetString("relay")
+	if relay == "" {
+		relay = os.Getenv("SLACK_CLI_AUTH_RELAY")
+	}
+	relay = strings.TrimRight(strings.TrimSpace(relay), "/")
+	if relay == "" {
+		return cerrors.ConfigError("--relay is required (or set SLACK_CLI_AUTH_RELAY) to the base URL of an slk auth oauth server")
+	}
+	save, _ := cmd.Flags().GetBool("save")
+	form := url.Values{}
+	if preset, _ := cmd.Flags().GetString("scope-preset"); preset != "" {
+		scopes, err := presetScopes(preset)
+		if err != nil {
+			return err
+		}
+		form.Set("scope", scopes)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	var code DeviceCodeResponse
+	if err := postRelay(ctx, client, relay+"/device/code", form, &code); err != nil {
+		return cerrors.NetworkError("request device code from %s: %w", relay, err)
+	}
+	fmt.Fprintf(output.Stderr(), "To authorize this host, open %s and enter the code:\n\n    %s\n\n", code.VerificationURI, code.UserCode)
+	if code.VerificationURIComplete != "" {
+		fmt.Fprintf(output.Stderr(), "Or open %s\n", code.VerificationURIComplete)
+	}
+	fmt.Fprintln(output.Stderr(), "Waiting for authorization...")
+
+	token, err := pollDeviceToken(ctx, client, relay, code)
+	if err != nil {
+		return err
+	}
+
+	userToken := token.AuthedUser.AccessToken
+	if userToken == "" {
+		return cerrors.AuthError("relay returned no user token; check that the app requests user scopes")
+	}
+	result := DeviceAuthResult{
+		OK:     true,
+		Team:   token.Team.Name,
+		TeamID: token.Team.ID,
+		UserID: token.AuthedUser.ID,
+		Scopes: slack.ParseScopes(token.AuthedUser.Scope),
+	}
+	if !save {
+		result.Token = userToken
+		return output.Print(cmd, result)
+	}
+
+	cfg, path, err := config.LoadFile(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+	cfg.UserToken = userToken
+	cfg.Cookie = ""
+	cfg.CookieExpires = ""
+	if result.ConfigPath, err = config.Save(path, cfg); err != nil {
+		return cerrors.ConfigError("save config: %w", err)
+	}
+	return output.Print(cmd, result)
+}
+
+// pollDeviceToken polls the relay at the advertised interval until the code is
+// authorized, denied, or expired.
+func pollDeviceToken(ctx context.Context, client *http.Client, relay string, code DeviceCodeResponse) (*OAuthTokenResponse, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = devicePollInterval
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	if code.ExpiresIn <= 0 {
+		deadline = time.Now().Add(deviceCodeTTL)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, cerrors.TimeoutError("device authorization cancelled")
+		case <-time.After(interval):
+		}
+		if time.Now().After(deadline) {
+			return nil, cerrors.TimeoutError("device code expired before it was authorized; run slk auth device again")
+		}
+
+		var resp deviceTokenResponse
+		if err := postRelay(ctx, client, relay+"/device/token", url.Values{"device_code": {code.DeviceCode}}, &resp); err != nil {
+			return nil, cerrors.NetworkError("poll %s: %w", relay, err)
+		}
+		switch resp.Error {
+		case "":
+			if resp.Token == nil {
+				return nil, cerrors.NetworkError("relay returned an empty token response")
+			}
+			if !resp.Token.OK {
+				return nil, cerrors.AuthError("token exchange failed: %s", resp.Token.Error)
+			}
+			return resp.Token, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += devicePollInterval
+		case "access_denied":
+			return nil, cerrors.AuthError("authorization was denied in Slack")
+		case "expired_token":
+			return nil, cerrors.TimeoutError("device code expired before it was authorized; run slk auth device again")
+		default:
+			return nil, cerrors.AuthError("relay error: %s", resp.Error)
+		}
+	}
+}
+
+func postRelay(ctx context.Context, client *http.Client, endpoint string, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("unexpected response (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// deviceGrant is one pending device authorization on the relay.
+type deviceGrant struct {
+	deviceCode string
+	userCode   string
+	scopes     string
+	expires    time.Time
+	lastPoll   time.Time
+	token      *OAuthTokenResponse
+	denied     bool
+}
+
+// deviceRegistry holds pending device authorizations for the oauth server.
+type deviceRegistry struct {
+	mu       sync.Mutex
+	byDevice map[string]*deviceGrant
+	byUser   map[string]*deviceGrant
+	now      func() time.Time
+}
+
+func newDeviceRegistry() *deviceRegistry {
+	return &deviceRegistry{
+		byDevice: map[string]*deviceGrant{},
+		byUser:   map[string]*deviceGrant{},
+		now:      time.Now,
+	}
+}
+
+// create registers a new grant with fresh device and user codes. scopes overrides the
+// server's --scopes for this grant when set.
+func (d *deviceRegistry) create(scopes string) (*deviceGrant, error) {
+	deviceBytes := make([]byte, 32)
+	if _, err := rand.Read(deviceBytes); err != nil {
+		return nil, err
+	}
+	userCode, err := newUserCode()
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expireLocked()
+	grant := &deviceGrant{deviceCode: hex.EncodeToString(deviceBytes), userCode: userCode, scopes: scopes, expires: d.now().Add(deviceCodeTTL)}
+	d.byDevice[grant.deviceCode] = grant
+	d.byUser[grant.userCode] = grant
+	return grant, nil
+}
+
+// pending returns the scopes requested by a live grant awaiting authorization, and
+// whether there is one for the user code.
+func (d *deviceRegistry) pending(userCode string) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expireLocked()
+	grant, ok := d.byUser[normalizeUserCode(userCode)]
+	if !ok || grant.token != nil || grant.denied {
+		return "", false
+	}
+	return grant.scopes, true
+}
+
+// complete attaches the Slack exchange result (or a denial) to the grant for a user
+// code. It reports whether the code was a pending device grant.
+func (d *deviceRegistry) complete(userCode string, token *OAuthTokenResponse, denied bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expireLocked()
+	grant, ok := d.byUser[normalizeUserCode(userCode)]
+	if !ok || grant.token != nil || grant.denied {
+		return false
+	}
+	grant.token, grant.denied = token, denied
+	return true
+}
+
+// poll returns the RFC 8628 error for a device code, or the token once, after which
+// the grant is forgotten.
+func (d *deviceRegistry) poll(deviceCode string) deviceTokenResponse {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expireLocked()
+	grant, ok := d.byDevice[deviceCode]
+	if !ok {
+		return deviceTokenResponse{Error: "expired_token"}
+	}
+	now := d.now()
+	tooFast := !grant.lastPoll.IsZero() && now.Sub(grant.lastPoll) < devicePollInterval/2
+	grant.lastPoll = now
+	switch {
+	case grant.denied:
+		d.removeLocked(grant)
+		return deviceTokenResponse{Error: "access_denied"}
+	case grant.token != nil:
+		d.removeLocked(grant)
+		return deviceTokenResponse{Token: grant.token}
+	case tooFast:
+		return deviceTokenResponse{Error: "slow_down"}
+	default:
+		return deviceTokenResponse{Error: "authorization_pending"}
+	}
+}
+
+func (d *deviceRegistry) expireLocked() {
+	now := d.now()
+	for _, grant := range d.byDevice {
+		if now.After(grant.expires) {
+			d.removeLocked(grant)
+		}
+	}
+}
+
+func (d *deviceRegistry) removeLocked(grant *deviceGrant) {
+	delete(d.byDevice, grant.deviceCode)
+	delete(d.byUser, grant.userCode)
+}
+
+func newUserCode() (string, error) {
+	var b strings.Builder
+	for i := 0; i < 8; i++ {
+		if i == 4 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(userCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(userCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// normalizeUserCode accepts codes typed in lower case or without the dash.
+func normalizeUserCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) == 8 {
+		return code[:4] + "-" + code[4:]
+	}
+	return code
+}
+
+// registerDeviceHandlers adds the relay endpoints to the oauth server.
+func registerDeviceHandlers(mux *http.ServeMux, devices *deviceRegistry, clientID string) {
+	mux.HandleFunc("/device/code", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		scopes := strings.TrimSpace(r.FormValue("scope"))
+		if scopes != "" && !deviceScopePattern.MatchString(scopes) {
+			http.Error(w, "invalid scope", http.StatusBadRequest)
+			return
+		}
+		grant, err := devices.create(scopes)
+		if err != nil {
+			http.Error(w, "could not create device code", http.StatusInternalServerError)
+			return
+		}
+		base := requestBaseURL(r)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(DeviceCodeResponse{
+			DeviceCode:              grant.deviceCode,
+			UserCode:                grant.userCode,
+			VerificationURI:         base + "/device",
+			VerificationURIComplete: base + "/device?code=" + url.QueryEscape(grant.userCode),
+			ExpiresIn:               int(deviceCodeTTL.Seconds()),
+			Interval:                int(devicePollInterval.Seconds()),
+		})
+		output.Statusf("Issued device code %s\n", grant.userCode)
+	})
+
+	mux.HandleFunc("/device/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		resp := devices.poll(r.FormValue("device_code"))
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Error != "" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		code := normalizeUserCode(r.URL.Query().Get("code"))
+		if scopes, ok := devices.pending(code); code != "" && ok {
+			if scopes == "" {
+				scopes = oauthScopes
+			}
+			http.Redirect(w, r, buildAuthURL(clientID, oauthRedirectURI, scopes, code), http.StatusFound)
+			return
+		}
+		message := "Enter the code shown on the device."
+		if code != "" {
+			message = "That code is invalid or has expired. Check the device and try again."
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head><title>Authorize device</title></head>
+<body>
+<h1>Authorize a device</h1>
+<p>%s</p>
+<form method="get" action="/device">
+<input name="code" placeholder="XXXX-XXXX" autocomplete="off" autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>`, html.EscapeString(message))
+	})
+}
+
+// requestBaseURL reconstructs the public base URL of the relay, honoring proxies.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+	host := r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		host = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	return scheme + "://" + host
+}
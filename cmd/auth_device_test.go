@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeviceRegistryLifecycle(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	devices := newDeviceRegistry()
+	devices.now = func() time.Time { return now }
+
+	grant, err := devices.create("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := devices.pending(strings.ToLower(strings.ReplaceAll(grant.userCode, "-", ""))); !ok {
+		t.Fatal("user code should match without dash and in lower case")
+	}
+	if got := devices.poll(grant.deviceCode).Error; got != "authorization_pending" {
+		t.Fatalf("first poll = %q, want authorization_pending", got)
+	}
+	if got := devices.poll(grant.deviceCode).Error; got != "slow_down" {
+		t.Fatalf("immediate second poll = %q, want slow_down", got)
+	}
+
+	token := &OAuthTokenResponse{OK: true}
+	token.AuthedUser.AccessToken = "xoxp-device"
+	if !devices.complete(grant.userCode, token, false) {
+		t.Fatal("complete should accept a pending code")
+	}
+	if devices.complete(grant.userCode, token, false) {
+		t.Fatal("complete should not accept a code twice")
+	}
+	now = now.Add(devicePollInterval)
+	if got := devices.poll(grant.deviceCode); got.Token == nil || got.Token.AuthedUser.AccessToken != "xoxp-device" {
+		t.Fatalf("poll after completion = %+v, want token", got)
+	}
+	if got := devices.poll(grant.deviceCode).Error; got != "expired_token" {
+		t.Fatalf("token should be handed out once, got %q", got)
+	}
+
+	expiring, _ := devices.create("")
+	now = now.Add(deviceCodeTTL + time.Second)
+	if _, ok := devices.pending(expiring.userCode); ok {
+		t.Fatal("expired code should not be pending")
+	}
+}
+
+func TestAuthDeviceRelayFlow(t *testing.T) {
+	devices := newDeviceRegistry()
+	mux := http.NewServeMux()
+	registerDeviceHandlers(mux, devices, "client-id")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	var code DeviceCodeResponse
+	if err := postRelay(context.Background(), client, server.URL+"/device/code", url.Values{"scope": {"chat:write,channels:read"}}, &code); err != nil {
+		t.Fatalf("device code: %v", err)
+	}
+	if code.VerificationURI != server.URL+"/device" || code.UserCode == "" {
+		t.Fatalf("unexpected code response %+v", code)
+	}
+
+	resp, err := client.Get(code.VerificationURIComplete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	location, _ := url.Parse(resp.Header.Get("Location"))
+	if resp.StatusCode != http.StatusFound || location.Host != "slack.com" || location.Query().Get("state") != code.UserCode || location.Query().Get("user_scope") != "chat:write,channels:read" {
+		t.Fatalf("verification page should redirect to Slack with the code as state, got %d %s", resp.StatusCode, location)
+	}
+
+	token := &OAuthTokenResponse{OK: true}
+	token.Team.ID = "T1"
+	token.AuthedUser.ID = "U1"
+	token.AuthedUser.AccessToken = "xoxp-device"
+	devices.complete(code.UserCode, token, false)
+
+	code.Interval = 1
+	got, err := pollDeviceToken(context.Background(), client, server.URL, code)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if got.AuthedUser.AccessToken != "xoxp-device" {
+		t.Fatalf("token = %q", got.AuthedUser.AccessToken)
+	}
+}
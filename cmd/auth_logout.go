@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/cache"
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/eventstore"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove stored tokens and team caches",
+	Long: `Remove every token and cookie from the config file, delete the token vault
+(vault.json and vault.json.key), and delete the team-scoped cache and event store
+directories, so a host can be decommissioned cleanly. Other settings (role,
+defaults, channel rules) are kept.
+
+With --revoke, each stored user and bot token, including every identity in the
+vault, is first revoked with auth.revoke, so a copy of the token taken from a
+compromised host stops working too. App-level
+tokens (xapp-) cannot be revoked through the API; regenerate them in the app
+settings. Tokens are removed locally even if revocation fails, and the command
+then exits with an auth error.
+
+Tokens supplied only through environment variables are not stored and cannot be
+removed; unset them in the environment.`,
+	Example: `  # Forget credentials on this host
+  slk auth logout
+
+  # Also invalidate the tokens on Slack's side
+  slk auth logout --revoke`,
+	Args: cobra.NoArgs,
+	RunE: runAuthLogout,
+}
+
+func init() {
+	authCmd.AddCommand(authLogoutCmd)
+
+	authLogoutCmd.Flags().Bool("revoke", false, "Revoke stored user and bot tokens, including vault identities, with auth.revoke before removing them")
+}
+
+// TokenRevocation reports the outcome of revoking one stored token.
+type TokenRevocation struct {
+	Key     string `json:"key"`
+	Revoked bool   `json:"revoked"`
+	Error   string `json:"error,omitempty"`
+}
+
+// LogoutResult is the output of auth logout.
+type LogoutResult struct {
+	ConfigPath   string            `json:"config_path"`
+	Removed      []string          `json:"removed"`
+	Revocations  []TokenRevocation `json:"revocations,omitempty"`
+	ClearedPaths []string          `json:"cleared_paths"`
+	Warnings     []string          `json:"warnings,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r LogoutResult) Lines() []string {
+	lines := []string{fmt.Sprintf("Logged out (%s)", r.ConfigPath)}
+	if len(r.Removed) == 0 {
+		lines = append(lines, "No stored credentials.")
+	} else {
+		lines = append(lines, "Removed: "+strings.Join(r.Removed, ", "))
+	}
+	for _, rev := range r.Revocations {
+		status := "revoked"
+		if !rev.Revoked {
+			status = "not revoked"
+			if rev.Error != "" {
+				status += ": " + rev.Error
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", rev.Key, status))
+	}
+	for _, path := range r.ClearedPaths {
+		lines = append(lines, "Deleted "+path)
+	}
+	for _, warning := range r.Warnings {
+		lines = append(lines, "Warning: "+warning)
+	}
+	return lines
+}
+
+// storedCredential is one API token in the config file or vault, with its cookie if
+// any. Vault entries record their team, so they need no auth.test to find it.
+type storedCredential struct {
+	key    string
+	token  string
+	cookie string
+	teamID string
+}
+
+// storedCredentials lists the user and bot tokens in cfg and the vault that can call
+// the Web API. Vault tokens already in the config file are listed once.
+func storedCredentials(cfg *config.Config, v *vault.Vault) []storedCredential {
+	var creds []storedCredential
+	seen := map[string]bool{}
+	if token := strings.TrimSpace(cfg.UserToken); token != "" {
+		creds = append(creds, storedCredential{key: "user_token", token: token, cookie: strings.TrimSpace(cfg.Cookie)})
+		seen[token] = true
+	}
+	if token := strings.TrimSpace(cfg.BotToken); token != "" && !seen[token] {
+		creds = append(creds, storedCredential{key: "bot_token", token: token})
+		seen[token] = true
+	}
+	if v == nil {
+		return creds
+	}
+	for _, e := range v.Entries() {
+		if token := strings.TrimSpace(e.Token); token != "" && !seen[token] {
+			creds = append(creds, storedCredential{key: "vault:" + e.ID(), token: token, teamID: e.TeamID})
+			seen[token] = true
+		}
+	}
+	return creds
+}
+
+func runAuthLogout(cmd *cobra.Command, args []string) error {
+	revoke, _ := cmd.Flags().GetBool("revoke")
+
+	cfg, path, err := config.LoadFile(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+	result := LogoutResult{ConfigPath: path, Removed: []string{}, ClearedPaths: []string{}}
+
+	// Identify each token's team before it is revoked, so its caches can be found.
+	teams := map[string]bool{}
+	if envTeamID := strings.TrimSpace(os.Getenv("SLACK_TEAM_ID")); envTeamID != "" {
+		teams[envTeamID] = true
+	}
+	var failed []string
+	vaultPath := vault.DefaultPath(path)
+	v, err := vault.Open(vaultPath)
+	if err != nil {
+		v = nil
+		if revoke {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("token vault %s could not be read, so its identities were deleted without being revoked: %v", vaultPath, err))
+			failed = append(failed, "vault")
+		}
+	}
+	for _, cred := range storedCredentials(cfg, v) {
+		client := slack.NewAuto(cred.token, cred.cookie)
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		if cred.teamID != "" {
+			teams[cred.teamID] = true
+		} else if info, err := client.AuthTest(ctx); err == nil {
+			teams[info.TeamID] = true
+		} else {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: could not identify its workspace, so its caches were kept: %v", cred.key, err))
+		}
+		if revoke {
+			revoked, err := client.AuthRevoke(ctx)
+			rev := TokenRevocation{Key: cred.key, Revoked: revoked}
+			if err != nil {
+				rev.Error = err.Error()
+				failed = append(failed, cred.key)
+			}
+			result.Revocations = append(result.Revocations, rev)
+		}
+		cancel()
+	}
+	if revoke && strings.TrimSpace(cfg.AppToken) != "" {
+		result.Warnings = append(result.Warnings, "app_token cannot be revoked through the API; regenerate it under Basic Information in the app settings")
+	}
+
+	removed := map[string]*string{
+		"user_token":     &cfg.UserToken,
+		"bot_token":      &cfg.BotToken,
+		"app_token":      &cfg.AppToken,
+		"cookie":         &cfg.Cookie,
+		"cookie_expires": &cfg.CookieExpires,
+	}
+	for key, field := range removed {
+		if *field != "" {
+			result.Removed = append(result.Removed, key)
+			*field = ""
+		}
+	}
+	sort.Strings(result.Removed)
+	if len(result.Removed) > 0 {
+		if _, err := config.Save(path, cfg); err != nil {
+			return cerrors.ConfigError("save config: %w", err)
+		}
+	}
+
+	teamIDs := make([]string, 0, len(teams))
+	for teamID := range teams {
+		teamIDs = append(teamIDs, teamID)
+	}
+	sort.Strings(teamIDs)
+	for _, teamID := range teamIDs {
+		cleared, err := clearTeamData(path, teamID)
+		result.ClearedPaths = append(result.ClearedPaths, cleared...)
+		if err != nil {
+			result.Warnings = append(result.Warnings, err.Error())
+		}
+	}
+
+	vaultFiles, err := vault.Remove(vaultPath)
+	result.ClearedPaths = append(result.ClearedPaths, vaultFiles...)
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+	for _, name := range []string{"SLACK_USER_TOKEN", "SLACK_CLIENT_TOKEN", "SLACK_BOT_TOKEN", "SLACK_APP_TOKEN", "SLACK_CLIENT_COOKIE"} {
+		if os.Getenv(name) != "" {
+			result.Warnings = append(result.Warnings, name+" is still set in the environment")
+		}
+	}
+
+	if err := output.Print(cmd, result); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return cerrors.AuthError("revocation failed for %s; the tokens were removed locally but may still work, revoke them in the Slack app settings", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// clearTeamData deletes the metadata cache and event store directories for a team and
+// returns the paths that existed.
+func clearTeamData(configPath, teamID string) ([]string, error) {
+	store, err := cache.DefaultStore(teamID)
+	if err != nil {
+		return nil, fmt.Errorf("locate cache for %s: %w", teamID, err)
+	}
+	eventsPath, err := eventstore.DefaultPath(configPath, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("locate event store for %s: %w", teamID, err)
+	}
+
+	var cleared []string
+	for _, dir := range []string{store.BasePath, filepath.Dir(eventsPath)} {
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return cleared, fmt.Errorf("delete %s: %w", dir, err)
+		}
+		cleared = append(cleared, dir)
+	}
+	return cleared, nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+func TestAuthLogoutDeletesVault(t *testing.T) {
+	t.Setenv(vault.PassphraseEnv, "")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	path := filepath.Join(t.TempDir(), "config.json")
+	previous := cfgFile
+	cfgFile = path
+	t.Cleanup(func() { cfgFile = previous })
+
+	if _, err := config.Save(path, config.DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	v, err := vault.Open(vault.DefaultPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.Put(vault.Entry{TeamID: "T1", UserID: "U1", Kind: config.RoleUser, Token: "xoxp-agent-one"})
+	if err := v.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("revoke", false, "")
+	cmd.SetOut(io.Discard)
+	cmd.SetContext(context.Background())
+	if err := runAuthLogout(cmd, nil); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+	for _, p := range []string{v.Path(), v.Path() + ".key"} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("%s survived logout (%v)", p, err)
+		}
+	}
+}
+
+func TestStoredCredentialsIncludesVault(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.UserToken = "xoxp-agent-one"
+	v, _ := vault.Open(filepath.Join(t.TempDir(), "vault.json"))
+	v.Put(vault.Entry{TeamID: "T1", UserID: "U1", Kind: config.RoleUser, Token: "xoxp-agent-one"})
+	v.Put(vault.Entry{TeamID: "T2", UserID: "B1", Kind: config.RoleBot, Token: "xoxb-helper"})
+
+	creds := storedCredentials(cfg, v)
+	if len(creds) != 2 || creds[0].key != "user_token" || creds[1].key != "vault:T2/B1" || creds[1].teamID != "T2" {
+		t.Fatalf("credentials = %+v, want the config token once and the other vault entry", creds)
+	}
+}
@@ -10,10 +10,14 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/vault"
 	"github.com/spf13/cobra"
 )
 
@@ -23,9 +27,14 @@ var (
 	oauthClientSecret string
 	oauthRedirectURI  string
 	oauthScopes       string
+	oauthScopePreset  string
 	oauthSaveConfig   bool
+	oauthVault        bool
 )
 
+// oauthVaultMu serializes vault writes from concurrent callbacks.
+var oauthVaultMu sync.Mutex
+
 var authOAuthCmd = &cobra.Command{
 	Use:   "oauth",
 	Short: "Start OAuth callback server for token exchange",
@@ -33,7 +42,12 @@ var authOAuthCmd = &cobra.Command{
 
 This server receives the authorization code from Slack and exchanges it
 for an access token. Expose the server publicly and configure your Slack
-app's redirect URI to point to the /callback endpoint.`,
+app's redirect URI to point to the /callback endpoint.
+
+The server keeps running after each exchange, so several people can authorize
+one after another. Every exchanged user and bot token is stored in the encrypted
+token vault keyed by team and user; list them with 'slk auth tokens list' and
+switch the active identity with 'slk auth tokens use'. Disable with --vault=false.`,
 	Example: `  # Start OAuth server on default port
   slk auth oauth --client-id YOUR_CLIENT_ID --client-secret YOUR_CLIENT_SECRET
 
@@ -41,7 +55,10 @@ app's redirect URI to point to the /callback endpoint.`,
   slk auth oauth --port 9000 --client-id ID --client-secret SECRET --redirect-uri https://example.com/callback
 
   # Auto-save token to config after successful exchange
-  slk auth oauth --client-id ID --client-secret SECRET --save`,
+  slk auth oauth --client-id ID --client-secret SECRET --save
+
+  # Request only the scopes read-only agents need
+  slk auth oauth --client-id ID --client-secret SECRET --scope-preset readonly`,
 	RunE: runAuthOAuth,
 }
 
@@ -52,8 +69,11 @@ func init() {
 	authOAuthCmd.Flags().StringVar(&oauthClientID, "client-id", "", "Slack app client ID (or SLACK_CLIENT_ID env)")
 	authOAuthCmd.Flags().StringVar(&oauthClientSecret, "client-secret", "", "Slack app client secret (or SLACK_CLIENT_SECRET env)")
 	authOAuthCmd.Flags().StringVar(&oauthRedirectURI, "redirect-uri", "", "OAuth redirect URI (optional, for token exchange)")
-	authOAuthCmd.Flags().StringVar(&oauthScopes, "scopes", "channels:read,channels:history,chat:write,users:read,search:read,reactions:read,reactions:write,pins:read,pins:write,emoji:read", "OAuth user scopes to request")
-	authOAuthCmd.Flags().BoolVar(&oauthSaveConfig, "save", false, "Save token to config file after successful exchange")
+	authOAuthCmd.Flags().StringVar(&oauthScopes, "scopes", "channels:read,channels:history,chat:write,users:read,search:read,reactions:read,reactions:write,pins:read,pins:write,emoji:read,files:read", "OAuth user scopes to request")
+	authOAuthCmd.Flags().StringVar(&oauthScopePreset, "scope-preset", "", "Request a curated scope set instead of --scopes: readonly|poster|full|watch (see slk auth plan)")
+	authOAuthCmd.MarkFlagsMutuallyExclusive("scopes", "scope-preset")
+	authOAuthCmd.Flags().BoolVar(&oauthSaveConfig, "save", false, "Save token to config file after successful exchange (the last authorization wins)")
+	authOAuthCmd.Flags().BoolVar(&oauthVault, "vault", true, "Store every exchanged token in the encrypted token vault")
 }
 
 // OAuthTokenResponse represents Slack's oauth.v2.access response
@@ -91,6 +111,13 @@ func runAuthOAuth(cmd *cobra.Command, args []string) error {
 	if clientID == "" || clientSecret == "" {
 		return fmt.Errorf("client-id and client-secret are required (use flags or SLACK_CLIENT_ID/SLACK_CLIENT_SECRET env vars)")
 	}
+	if oauthScopePreset != "" {
+		scopes, err := presetScopes(oauthScopePreset)
+		if err != nil {
+			return err
+		}
+		oauthScopes = scopes
+	}
 
 	mux := http.NewServeMux()
 
@@ -101,10 +128,14 @@ func runAuthOAuth(cmd *cobra.Command, args []string) error {
 	})
 
 	// OAuth callback endpoint
+	devices := newDeviceRegistry()
 	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-		handleOAuthCallback(w, r, clientID, clientSecret)
+		handleOAuthCallback(w, r, clientID, clientSecret, devices)
 	})
 
+	// Device authorization relay for slk auth device
+	registerDeviceHandlers(mux, devices, clientID)
+
 	// Root endpoint - shows instructions
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/" {
@@ -112,7 +143,7 @@ func runAuthOAuth(cmd *cobra.Command, args []string) error {
 			return
 		}
 		w.Header().Set("Content-Type", "text/html")
-		authURL := buildAuthURL(clientID, oauthRedirectURI, oauthScopes)
+		authURL := buildAuthURL(clientID, oauthRedirectURI, oauthScopes, "")
 		fmt.Fprintf(w, `<!DOCTYPE html>
 <html>
 <head><title>Slack OAuth</title></head>
@@ -125,6 +156,7 @@ func runAuthOAuth(cmd *cobra.Command, args []string) error {
 <li><code>GET /</code> - This page</li>
 <li><code>GET /callback?code=XXX</code> - OAuth callback (exchanges code for token)</li>
 <li><code>GET /health</code> - Health check</li>
+<li><code>GET /device</code> - Enter a code from <code>slk auth device</code></li>
 </ul>
 </body>
 </html>`, authURL)
@@ -139,20 +171,37 @@ func runAuthOAuth(cmd *cobra.Command, args []string) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// SIGHUP would otherwise terminate the server. The config file is already read
+	// on every callback, so a reload only needs to be acknowledged.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				output.Statusf("Received SIGHUP; config is re-read on each callback, server keeps running.\n")
+			}
+		}
+	}()
+
 	go func() {
 		<-ctx.Done()
-		fmt.Fprintln(os.Stderr, "\nShutting down server...")
+		output.Statusf("\nShutting down server...\n")
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		server.Shutdown(shutdownCtx)
 	}()
 
-	fmt.Fprintf(os.Stderr, "OAuth callback server listening on http://localhost:%d\n", oauthPort)
-	fmt.Fprintf(os.Stderr, "Endpoints:\n")
-	fmt.Fprintf(os.Stderr, "  GET /          - Instructions and auth link\n")
-	fmt.Fprintf(os.Stderr, "  GET /callback  - OAuth callback (receives code, exchanges for token)\n")
-	fmt.Fprintf(os.Stderr, "  GET /health    - Health check\n")
-	fmt.Fprintf(os.Stderr, "\nPress Ctrl+C to stop\n\n")
+	output.Statusf("OAuth callback server listening on http://localhost:%d\n", oauthPort)
+	output.Statusf("Endpoints:\n")
+	output.Statusf("  GET /          - Instructions and auth link\n")
+	output.Statusf("  GET /callback  - OAuth callback (receives code, exchanges for token)\n")
+	output.Statusf("  GET /health    - Health check\n")
+	output.Statusf("  GET /device    - Device authorization relay for slk auth device\n")
+	output.Statusf("\nPress Ctrl+C to stop\n\n")
 
 	if err := server.ListenAndServe(); err != http.ErrServerClosed {
 		return fmt.Errorf("server error: %w", err)
@@ -161,11 +210,16 @@ func runAuthOAuth(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func handleOAuthCallback(w http.ResponseWriter, r *http.Request, clientID, clientSecret string) {
+func handleOAuthCallback(w http.ResponseWriter, r *http.Request, clientID, clientSecret string, devices *deviceRegistry) {
 	code := r.URL.Query().Get("code")
 	errorParam := r.URL.Query().Get("error")
+	// state carries the user code when the authorization started from /device.
+	state := r.URL.Query().Get("state")
 
 	if errorParam != "" {
+		if state != "" && devices.complete(state, nil, true) {
+			fmt.Fprintf(output.Stderr(), "Device code %s was denied: %s\n", state, errorParam)
+		}
 		errorDesc := r.URL.Query().Get("error_description")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
@@ -174,7 +228,7 @@ func handleOAuthCallback(w http.ResponseWriter, r *http.Request, clientID, clien
 			"error":       errorParam,
 			"description": errorDesc,
 		})
-		fmt.Fprintf(os.Stderr, "OAuth error: %s - %s\n", errorParam, errorDesc)
+		fmt.Fprintf(output.Stderr(), "OAuth error: %s - %s\n", errorParam, errorDesc)
 		return
 	}
 
@@ -188,7 +242,7 @@ func handleOAuthCallback(w http.ResponseWriter, r *http.Request, clientID, clien
 		return
 	}
 
-	fmt.Fprintf(os.Stderr, "Received authorization code, exchanging for token...\n")
+	output.Statusf("Received authorization code, exchanging for token...\n")
 
 	// Exchange code for token
 	tokenResp, err := exchangeCodeForToken(code, clientID, clientSecret, oauthRedirectURI)
@@ -199,15 +253,18 @@ func handleOAuthCallback(w http.ResponseWriter, r *http.Request, clientID, clien
 			"ok":    "false",
 			"error": err.Error(),
 		})
-		fmt.Fprintf(os.Stderr, "Token exchange error: %v\n", err)
+		fmt.Fprintf(output.Stderr(), "Token exchange error: %v\n", err)
 		return
 	}
 
 	if !tokenResp.OK {
+		if state != "" {
+			devices.complete(state, tokenResp, false)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(tokenResp)
-		fmt.Fprintf(os.Stderr, "Slack API error: %s\n", tokenResp.Error)
+		fmt.Fprintf(output.Stderr(), "Slack API error: %s\n", tokenResp.Error)
 		return
 	}
 
@@ -217,32 +274,102 @@ func handleOAuthCallback(w http.ResponseWriter, r *http.Request, clientID, clien
 		token = tokenResp.AccessToken
 	}
 
+	if oauthVault {
+		if stored, err := storeOAuthTokens(r.Context(), tokenResp); err != nil {
+			output.Warnf("failed to store token in vault: %v", err)
+		} else {
+			for _, entry := range stored {
+				output.Statusf("Stored %s token for %s in vault (use with: slk auth tokens use %s)\n", entry.Kind, entry.ID(), entry.ID())
+			}
+		}
+	}
+
 	// Save to config if requested
 	if oauthSaveConfig && token != "" {
 		if err := saveTokenToConfig(token); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save token to config: %v\n", err)
+			output.Warnf("failed to save token to config: %v", err)
 		} else {
-			fmt.Fprintf(os.Stderr, "Token saved to config file\n")
+			output.Statusf("Token saved to config file\n")
 		}
 	}
 
-	// Output success
+	// Output success. Device authorizations hand the token to the polling host
+	// instead of showing it in the browser.
+	if state != "" && devices.complete(state, tokenResp, false) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<!DOCTYPE html>
+<html>
+<head><title>Device authorized</title></head>
+<body><h1>Device authorized</h1><p>You can close this page and return to the device.</p></body>
+</html>`)
+		output.Statusf("Device code %s authorized for %s (%s)\n", state, tokenResp.AuthedUser.ID, tokenResp.Team.ID)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(tokenResp)
 
 	// Also print to stderr for easy copying
-	fmt.Fprintf(os.Stderr, "\n=== OAuth Success ===\n")
-	fmt.Fprintf(os.Stderr, "Team: %s (%s)\n", tokenResp.Team.Name, tokenResp.Team.ID)
+	fmt.Fprintf(output.Stderr(), "\n=== OAuth Success ===\n")
+	fmt.Fprintf(output.Stderr(), "Team: %s (%s)\n", tokenResp.Team.Name, tokenResp.Team.ID)
 	if tokenResp.AuthedUser.ID != "" {
-		fmt.Fprintf(os.Stderr, "User ID: %s\n", tokenResp.AuthedUser.ID)
-		fmt.Fprintf(os.Stderr, "User Token: %s\n", tokenResp.AuthedUser.AccessToken)
-		fmt.Fprintf(os.Stderr, "User Scopes: %s\n", tokenResp.AuthedUser.Scope)
+		fmt.Fprintf(output.Stderr(), "User ID: %s\n", tokenResp.AuthedUser.ID)
+		fmt.Fprintf(output.Stderr(), "User Token: %s\n", tokenResp.AuthedUser.AccessToken)
+		fmt.Fprintf(output.Stderr(), "User Scopes: %s\n", tokenResp.AuthedUser.Scope)
 	}
 	if tokenResp.AccessToken != "" {
-		fmt.Fprintf(os.Stderr, "Bot Token: %s\n", tokenResp.AccessToken)
-		fmt.Fprintf(os.Stderr, "Bot Scopes: %s\n", tokenResp.Scope)
+		fmt.Fprintf(output.Stderr(), "Bot Token: %s\n", tokenResp.AccessToken)
+		fmt.Fprintf(output.Stderr(), "Bot Scopes: %s\n", tokenResp.Scope)
+	}
+	fmt.Fprintf(output.Stderr(), "=====================\n")
+}
+
+// storeOAuthTokens adds the user and bot tokens from an exchange to the vault. Names
+// are looked up with auth.test on a best-effort basis.
+func storeOAuthTokens(ctx context.Context, resp *OAuthTokenResponse) ([]vault.Entry, error) {
+	var entries []vault.Entry
+	now := time.Now().UTC()
+	if resp.AuthedUser.AccessToken != "" {
+		entries = append(entries, vault.Entry{
+			TeamID: resp.Team.ID, Team: resp.Team.Name, UserID: resp.AuthedUser.ID,
+			Kind: config.RoleUser, Token: resp.AuthedUser.AccessToken,
+			Scopes: slack.ParseScopes(resp.AuthedUser.Scope), CreatedAt: now,
+		})
+	}
+	if resp.AccessToken != "" && resp.BotUserID != "" {
+		entries = append(entries, vault.Entry{
+			TeamID: resp.Team.ID, Team: resp.Team.Name, UserID: resp.BotUserID,
+			Kind: config.RoleBot, Token: resp.AccessToken,
+			Scopes: slack.ParseScopes(resp.Scope), CreatedAt: now,
+		})
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	for i := range entries {
+		lookupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		if info, err := slack.New(entries[i].Token).AuthTest(lookupCtx); err == nil {
+			entries[i].User = info.User
+		}
+		cancel()
 	}
-	fmt.Fprintf(os.Stderr, "=====================\n")
+
+	_, configPath, err := config.LoadFile(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	oauthVaultMu.Lock()
+	defer oauthVaultMu.Unlock()
+	v, err := vault.Open(vault.DefaultPath(configPath))
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		v.Put(entry)
+	}
+	if err := v.Save(); err != nil {
+		return nil, err
+	}
+	return entries, nil
 }
 
 func exchangeCodeForToken(code, clientID, clientSecret, redirectURI string) (*OAuthTokenResponse, error) {
@@ -280,13 +407,16 @@ func exchangeCodeForToken(code, clientID, clientSecret, redirectURI string) (*OA
 	return &tokenResp, nil
 }
 
-func buildAuthURL(clientID, redirectURI, scopes string) string {
+func buildAuthURL(clientID, redirectURI, scopes, state string) string {
 	params := url.Values{}
 	params.Set("client_id", clientID)
 	params.Set("user_scope", scopes)
 	if redirectURI != "" {
 		params.Set("redirect_uri", redirectURI)
 	}
+	if state != "" {
+		params.Set("state", state)
+	}
 	return "https://slack.com/oauth/v2/authorize?" + params.Encode()
 }
 
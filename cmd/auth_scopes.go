@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// commandScopes lists the user token scopes a command needs. scopes are required for
+// public channels and IDs; optional scopes extend coverage to private channels, DMs,
+// and group DMs, or let the command resolve #channel and @user names.
+type commandScopes struct {
+	command     string
+	scopes      []string
+	optional    []string
+	unsupported []slack.TokenType
+	note        string
+	// orgAdmin marks commands whose scopes only an Enterprise Grid org admin can
+	// grant. The full preset leaves them out.
+	orgAdmin bool
+}
+
+var (
+	historyOptional = []string{"groups:history", "im:history", "mpim:history", "channels:read", "users:read"}
+	namesOptional   = []string{"channels:read", "groups:read", "users:read"}
+	// emoji:read lets reactions follow custom emoji aliases.
+	reactionsOptional = []string{"channels:read", "groups:read", "users:read", "emoji:read"}
+)
+
+// commandScopeTable covers every command that calls the Slack Web API. Commands not
+// listed (config, messages render, archive read, blocks validate, channels audit-names,
+// events list/next/claim/ack, outbox add/list, schedule add/list, upgrade) only read
+// local files, call auth.test (which needs no scope), or do not call Slack at all.
+var commandScopeTable = []commandScopes{
+	{command: "messages list", scopes: []string{"channels:history"}, optional: historyOptional},
+	{command: "messages next", scopes: []string{"channels:history"}, optional: historyOptional},
+	{command: "messages get", scopes: []string{"channels:history"}, optional: historyOptional},
+	{command: "messages export", scopes: []string{"channels:history"}, optional: historyOptional},
+	{command: "export verify", scopes: []string{"channels:history"}, optional: historyOptional, note: "export verify only calls Slack with --repair"},
+	{command: "messages search", scopes: []string{"search:read"}, optional: []string{"users:read"}, unsupported: []slack.TokenType{slack.TokenBot}, note: "messages search needs a user token; bot tokens cannot call search.messages"},
+	{command: "messages send", scopes: []string{"chat:write"}, optional: namesOptional, note: "messages send --username/--icon-emoji/--icon-url need a bot token with chat:write.customize; --channel @user also needs im:write; --file needs files:write"},
+	{command: "messages edit", scopes: []string{"chat:write"}, optional: namesOptional},
+	{command: "messages delete", scopes: []string{"chat:write"}, optional: namesOptional},
+	{command: "outbox send", scopes: []string{"chat:write"}, optional: namesOptional},
+	{command: "outbox flush", scopes: []string{"chat:write"}, optional: namesOptional},
+	{command: "schedule remove", optional: []string{"chat:write"}, note: "schedule remove needs chat:write only to cancel a message already handed to chat.scheduleMessage"},
+	{command: "messages schedule", scopes: []string{"chat:write", "users:read"}, optional: []string{"im:write", "channels:read"}, note: "messages schedule needs im:write only without --channel, and users:read only for recipient-local times or @names"},
+	{command: "notify", scopes: []string{"chat:write", "users:read", "dnd:read", "im:write"}, optional: []string{"channels:read"}},
+	{command: "channels list", scopes: []string{"channels:read"}, optional: []string{"groups:read", "im:read", "mpim:read"}, note: "channels list --with-activity also needs channels:history (groups:history, im:history, mpim:history for other conversation types)"},
+	{command: "channels stats", scopes: []string{"channels:history"}, optional: historyOptional},
+	{command: "channels stale", scopes: []string{"channels:history"}, optional: []string{"groups:history"}, note: "channels stale --archive also needs channels:manage (bot) or channels:write (user), and groups:write for private channels"},
+	{command: "admin invites send", scopes: []string{"admin.users:write"}, optional: namesOptional, unsupported: []slack.TokenType{slack.TokenBot}, orgAdmin: true, note: "admin invites send falls back to users.admin.invite, which needs a workspace admin's token, outside Enterprise Grid"},
+	{command: "channels history-permissions probe", scopes: []string{"channels:read"}, optional: []string{"groups:read", "im:read", "mpim:read"}, note: "channels history-permissions probe reports missing history, chat:write, and reactions:write scopes in its result instead of failing"},
+	{command: "channels join", scopes: []string{"channels:write"}, optional: namesOptional},
+	{command: "channels leave", scopes: []string{"channels:write"}, optional: []string{"groups:write", "channels:read", "groups:read"}},
+	{command: "dm open", scopes: []string{"im:write"}, optional: []string{"users:read"}},
+	{command: "dm close", scopes: []string{"im:write"}, optional: []string{"users:read"}},
+	{command: "dm create", scopes: []string{"mpim:write", "chat:write"}, optional: []string{"im:write", "users:read"}, note: "dm create with a single user opens a plain DM, which needs im:write"},
+	{command: "dm list", scopes: []string{"im:read", "im:history"}, optional: []string{"users:read", "mpim:read", "mpim:history"}},
+	{command: "files info", scopes: []string{"files:read"}, optional: []string{"channels:read"}},
+	{command: "files fetch", scopes: []string{"files:read"}},
+	{command: "files share", scopes: []string{"files:read", "chat:write"}, optional: namesOptional, note: "files share --public and files revoke-public need files:write and a user token"},
+	{command: "files revoke-public", scopes: []string{"files:write"}, unsupported: []slack.TokenType{slack.TokenBot}, note: "files share --public and files revoke-public need files:write and a user token"},
+	{command: "users list", scopes: []string{"users:read"}},
+	{command: "users info", scopes: []string{"users:read"}},
+	{command: "users presence", scopes: []string{"users:read"}},
+	{command: "board show", scopes: []string{"channels:history"}, optional: historyOptional, note: "board show --state reads a watch --track-state file and needs no history scope"},
+	{command: "users availability", scopes: []string{"users:read", "dnd:read"}},
+	{command: "rotation next", scopes: []string{"usergroups:read", "users:read"}, optional: []string{"usergroups:write", "chat:write", "channels:read"}, note: "rotation next --set-usergroup needs usergroups:write, and --post needs chat:write"},
+	{command: "usergroups members", scopes: []string{"usergroups:read"}, optional: []string{"users:read", "users:read.email"}, note: "usergroups members --resolve needs users:read, plus users:read.email for emails"},
+	{command: "reactions add", scopes: []string{"reactions:write"}, optional: reactionsOptional},
+	{command: "reactions remove", scopes: []string{"reactions:write"}, optional: reactionsOptional},
+	{command: "reactions list", scopes: []string{"reactions:read"}, optional: namesOptional, note: "reactions list --with-message also needs channels:history (groups:history, im:history, mpim:history for other conversation types)"},
+	{command: "pins add", scopes: []string{"pins:write"}, optional: namesOptional},
+	{command: "pins remove", scopes: []string{"pins:write"}, optional: namesOptional},
+	{command: "pins list", scopes: []string{"pins:read"}, optional: namesOptional},
+	{command: "pins sync", scopes: []string{"pins:read", "pins:write"}, optional: namesOptional},
+	{command: "threads list", scopes: []string{"channels:history"}, optional: historyOptional},
+	{command: "threads watch", scopes: []string{"channels:history"}, optional: historyOptional, note: "threads watch --source socket also needs app_token (xapp-) with connections:write; --source poll needs only channels:history"},
+	{command: "standup collect", scopes: []string{"chat:write", "channels:history"}, optional: historyOptional, note: "standup collect --source socket also needs app_token (xapp-) with connections:write"},
+	{command: "threads follow", unsupported: []slack.TokenType{slack.TokenUser, slack.TokenBot}, note: "threads follow/unfollow call subscriptions.thread.add/remove, which only accept browser-session (xoxc) tokens"},
+	{command: "threads unfollow", unsupported: []slack.TokenType{slack.TokenUser, slack.TokenBot}, note: "threads follow/unfollow call subscriptions.thread.add/remove, which only accept browser-session (xoxc) tokens"},
+	{command: "emoji list", scopes: []string{"emoji:read"}},
+	{command: "apphome publish", optional: []string{"users:read"}, unsupported: []slack.TokenType{slack.TokenUser}, note: "apphome publish needs a bot token and the Home tab enabled in the app's App Home settings"},
+	{command: "apphome open", optional: []string{"users:read"}, note: "apphome open looks up the app with bots.info (users:read) unless --app is set"},
+	{command: "views open", unsupported: []slack.TokenType{slack.TokenUser}, note: "views open/push/update need the bot token of the app that received the interaction's trigger_id"},
+	{command: "views push", unsupported: []slack.TokenType{slack.TokenUser}, note: "views open/push/update need the bot token of the app that received the interaction's trigger_id"},
+	{command: "views update", unsupported: []slack.TokenType{slack.TokenUser}, note: "views open/push/update need the bot token of the app that received the interaction's trigger_id"},
+	{command: "scaffold slash-command", scopes: []string{"commands"}, unsupported: []slack.TokenType{slack.TokenUser}, note: "scaffold slash-command --serve also needs app_token (xapp-) with connections:write; without --serve it makes no API call"},
+	{command: "huddles list", scopes: []string{"channels:history"}, optional: historyOptional},
+	{command: "report top-channels", scopes: []string{"channels:history", "channels:read"}, optional: []string{"groups:history", "im:history", "mpim:history", "groups:read"}},
+	{command: "alerts run", scopes: []string{"chat:write"}, optional: []string{"channels:history", "groups:history", "channels:read", "users:read"}, note: "alerts run --source poll needs channels:history; the default daemon source needs daemon run"},
+	{command: "watch", scopes: []string{"channels:history"}, optional: historyOptional, note: "watch --mode socket needs app_token (xapp-) with connections:write; --mode poll needs only channels:history; --track-state in socket mode also needs reactions:read and pins:read event subscriptions"},
+	{command: "cache populate", scopes: []string{"channels:read", "users:read"}, optional: []string{"groups:read", "im:read", "mpim:read"}},
+	{command: "events stream", scopes: []string{"channels:read", "users:read"}, optional: []string{"groups:read", "im:read", "mpim:read", "usergroups:read"}, note: "events stream and daemon run also need app_token (xapp-) with connections:write and event subscriptions in the app manifest"},
+	{command: "daemon run", scopes: []string{"channels:read", "users:read"}, optional: []string{"groups:read", "im:read", "mpim:read", "usergroups:read", "chat:write"}, note: "events stream and daemon run also need app_token (xapp-) with connections:write and event subscriptions in the app manifest"},
+}
+
+// scopePresets group commands into curated scope sets for auth oauth and auth device.
+// Presets grant the optional scopes too, so every conversation type works.
+var scopePresets = map[string][]string{
+	"readonly": {
+		"messages list", "messages next", "messages get", "messages export", "messages search",
+		"channels list", "channels stats", "channels stale", "users list", "users info", "users presence", "users availability",
+		"usergroups members", "reactions list", "board show", "pins list", "threads list", "emoji list", "huddles list", "report top-channels", "cache populate",
+		"dm list", "files info", "files fetch",
+	},
+	"poster": {
+		"messages send", "messages edit", "messages delete", "messages schedule", "notify", "outbox send", "outbox flush",
+		"reactions add", "reactions remove", "channels list", "users list", "dm open", "dm create",
+	},
+	"watch": {"events stream", "daemon run", "alerts run", "watch", "threads watch", "messages list", "channels list", "users list"},
+	"full":  nil, // every command in commandScopeTable except org admin commands
+}
+
+// scopePresetNames returns the preset names in a stable order for help text.
+func scopePresetNames() []string {
+	return []string{"readonly", "poster", "full", "watch"}
+}
+
+// lookupCommandScopes finds the table entry for a command path such as "messages send".
+func lookupCommandScopes(command string) (commandScopes, bool) {
+	command = strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(command), "slk ")), " ")
+	for _, entry := range commandScopeTable {
+		if entry.command == command {
+			return entry, true
+		}
+	}
+	return commandScopes{}, false
+}
+
+// requireScopes fails before any change is made when the token lacks one of scopes,
+// naming the scopes to add. Tokens that report no scopes are not checked.
+func requireScopes(cmdCtx *CommandContext, operation string, scopes ...string) error {
+	if len(cmdCtx.AuthScopes) == 0 {
+		return nil
+	}
+	granted := map[string]bool{}
+	for _, scope := range cmdCtx.AuthScopes {
+		granted[scope] = true
+	}
+	var missing []string
+	for _, scope := range scopes {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return cerrors.ScopeGapError(operation, missing, cmdCtx.AuthScopes)
+	}
+	return nil
+}
+
+// explainScopeGap replaces a raw missing_scope error from the Slack API with one that
+// names the scopes the refused method needs and the command that requests them.
+func explainScopeGap(err error) error {
+	if err == nil || !strings.Contains(err.Error(), "missing_scope") {
+		return err
+	}
+	gap, ok := slack.LastScopeGap()
+	if !ok {
+		return err
+	}
+	return cerrors.MethodScopeError(gap.Method, gap.Needed, gap.Provided)
+}
+
+// presetCommands expands a preset name into its commands.
+func presetCommands(preset string) ([]string, error) {
+	commands, ok := scopePresets[preset]
+	if !ok {
+		return nil, cerrors.ConfigError("unknown scope preset %q (use %s)", preset, strings.Join(scopePresetNames(), ", "))
+	}
+	if commands == nil {
+		for _, entry := range commandScopeTable {
+			if !entry.orgAdmin {
+				commands = append(commands, entry.command)
+			}
+		}
+	}
+	return commands, nil
+}
+
+// presetScopes returns the comma-separated user scopes for a preset, including the
+// optional scopes.
+func presetScopes(preset string) (string, error) {
+	commands, err := presetCommands(preset)
+	if err != nil {
+		return "", err
+	}
+	plan, err := planScopes(commands)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(mergeScopes(plan.Scopes, plan.Optional), ","), nil
+}
+
+// ScopePlanResult is the output of auth plan.
+type ScopePlanResult struct {
+	Commands []string `json:"commands"`
+	Scopes   []string `json:"scopes"`
+	Optional []string `json:"optional,omitempty"`
+	Notes    []string `json:"notes,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ScopePlanResult) Lines() []string {
+	lines := []string{
+		"Commands: " + strings.Join(r.Commands, ", "),
+		"Required scopes: " + strings.Join(r.Scopes, ","),
+	}
+	if len(r.Optional) > 0 {
+		lines = append(lines, "Optional scopes (private channels, DMs, name lookup): "+strings.Join(r.Optional, ","))
+	}
+	for _, note := range r.Notes {
+		lines = append(lines, "Note: "+note)
+	}
+	lines = append(lines, "", "Request them with:", "  slk auth oauth --scopes "+strings.Join(mergeScopes(r.Scopes, r.Optional), ","))
+	return lines
+}
+
+// planScopes computes the minimal required scopes for commands, plus the optional
+// scopes not already required.
+func planScopes(commands []string) (ScopePlanResult, error) {
+	result := ScopePlanResult{Commands: []string{}, Scopes: []string{}}
+	var optional []string
+	seenNotes := map[string]bool{}
+	for _, command := range commands {
+		entry, ok := lookupCommandScopes(command)
+		if !ok {
+			if strings.TrimSpace(command) == "" {
+				continue
+			}
+			return ScopePlanResult{}, cerrors.ConfigError("unknown command %q; commands that call Slack: %s", command, strings.Join(scopedCommandNames(), ", "))
+		}
+		result.Commands = append(result.Commands, entry.command)
+		result.Scopes = mergeScopes(result.Scopes, entry.scopes)
+		optional = mergeScopes(optional, entry.optional)
+		if entry.note != "" && !seenNotes[entry.note] {
+			seenNotes[entry.note] = true
+			result.Notes = append(result.Notes, entry.note)
+		}
+	}
+	required := map[string]bool{}
+	for _, scope := range result.Scopes {
+		required[scope] = true
+	}
+	for _, scope := range optional {
+		if !required[scope] {
+			result.Optional = append(result.Optional, scope)
+		}
+	}
+	return result, nil
+}
+
+func scopedCommandNames() []string {
+	names := make([]string, 0, len(commandScopeTable))
+	for _, entry := range commandScopeTable {
+		names = append(names, entry.command)
+	}
+	return names
+}
+
+// mergeScopes returns the sorted union of scope lists.
+func mergeScopes(lists ...[]string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, list := range lists {
+		for _, scope := range list {
+			if !seen[scope] {
+				seen[scope] = true
+				merged = append(merged, scope)
+			}
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+var authPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Compute the OAuth scopes a set of commands needs",
+	Long: `Compute the minimal user token scopes for the commands an agent will run, so the
+Slack app can request nothing more.
+
+Required scopes cover public channels and commands given IDs. Optional scopes extend
+the same commands to private channels, DMs, and group DMs, and let them resolve
+#channel and @user names. Commands that only read local files (config, messages
+render, archive read, blocks validate, channels audit-names, events list/next/claim/ack,
+outbox add/list, schedule add/list) need no scopes.`,
+	Example: `  slk auth plan --commands "messages send,reactions add"
+  slk auth plan --preset readonly --human`,
+	Args: cobra.NoArgs,
+	RunE: runAuthPlan,
+}
+
+func init() {
+	authCmd.AddCommand(authPlanCmd)
+
+	authPlanCmd.Flags().String("commands", "", `Comma-separated command paths, e.g. "messages send,reactions add"`)
+	authPlanCmd.Flags().String("preset", "", fmt.Sprintf("Plan a scope preset instead (%s)", strings.Join(scopePresetNames(), "|")))
+	authPlanCmd.MarkFlagsMutuallyExclusive("commands", "preset")
+	authPlanCmd.MarkFlagsOneRequired("commands", "preset")
+}
+
+func runAuthPlan(cmd *cobra.Command, args []string) error {
+	commandsFlag, _ := cmd.Flags().GetString("commands")
+	preset, _ := cmd.Flags().GetString("preset")
+
+	commands := strings.Split(commandsFlag, ",")
+	if preset != "" {
+		var err error
+		if commands, err = presetCommands(preset); err != nil {
+			return err
+		}
+	}
+	result, err := planScopes(commands)
+	if err != nil {
+		return err
+	}
+	if len(result.Commands) == 0 {
+		return cerrors.ConfigError("--commands is empty")
+	}
+	return output.Print(cmd, result)
+}
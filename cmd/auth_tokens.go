@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+var authTokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Manage identities in the token vault",
+	Long: `List and switch between the tokens stored in the encrypted token vault.
+
+'slk auth oauth' adds every exchanged user and bot token to the vault, keyed by
+team and user as <team_id>/<user_id>. The vault lives next to the config file
+(vault.json). It is encrypted with a random key in vault.json.key, or with a key
+derived from ` + vault.PassphraseEnv + ` when that variable is set.`,
+}
+
+var authTokensListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored identities",
+	Example: `  slk auth tokens list
+  slk auth tokens list --human`,
+	Args: cobra.NoArgs,
+	RunE: runAuthTokensList,
+}
+
+var authTokensUseCmd = &cobra.Command{
+	Use:   "use <id|user>",
+	Short: "Make a stored identity the active one",
+	Long: `Copy a vault token into the config file and select its role. A user token
+replaces user_token (and clears any cookie) and sets role=user; a bot token replaces
+bot_token and sets role=bot. The identity may be given as <team_id>/<user_id>, or
+as a user ID or name that is unique in the vault.`,
+	Example: `  slk auth tokens use T0123ABCD/U0456EFGH
+  slk auth tokens use @deploy-agent`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthTokensUse,
+}
+
+func init() {
+	authCmd.AddCommand(authTokensCmd)
+	authTokensCmd.AddCommand(authTokensListCmd)
+	authTokensCmd.AddCommand(authTokensUseCmd)
+}
+
+// VaultIdentity describes a vault entry with its token masked.
+type VaultIdentity struct {
+	ID        string   `json:"id"`
+	Team      string   `json:"team,omitempty"`
+	TeamID    string   `json:"team_id"`
+	User      string   `json:"user,omitempty"`
+	UserID    string   `json:"user_id"`
+	Kind      string   `json:"kind"`
+	Token     string   `json:"token"`
+	Scopes    []string `json:"scopes,omitempty"`
+	CreatedAt string   `json:"created_at,omitempty"`
+	Active    bool     `json:"active"`
+}
+
+// TokensListResult is the output of auth tokens list.
+type TokensListResult struct {
+	VaultPath  string          `json:"vault_path"`
+	Identities []VaultIdentity `json:"identities"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r TokensListResult) Lines() []string {
+	if len(r.Identities) == 0 {
+		return []string{"No identities in vault. Add some with: slk auth oauth"}
+	}
+	lines := []string{fmt.Sprintf("Token vault (%s)", r.VaultPath)}
+	for _, id := range r.Identities {
+		marker := " "
+		if id.Active {
+			marker = "*"
+		}
+		name := id.User
+		if name == "" {
+			name = id.UserID
+		}
+		lines = append(lines, fmt.Sprintf("%s %-24s %-4s @%s (%s) %s", marker, id.ID, id.Kind, name, id.Team, id.Token))
+	}
+	return lines
+}
+
+// Ellipsize implements output.Ellipsizer; the rows are a table.
+func (r TokensListResult) Ellipsize() bool {
+	return true
+}
+
+// TokensUseResult is the output of auth tokens use.
+type TokensUseResult struct {
+	Identity   VaultIdentity `json:"identity"`
+	Role       string        `json:"role"`
+	ConfigPath string        `json:"config_path"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r TokensUseResult) Lines() []string {
+	return []string{fmt.Sprintf("Now using %s (%s, role=%s); saved to %s", r.Identity.ID, r.Identity.Kind, r.Role, r.ConfigPath)}
+}
+
+func openConfigVault() (*config.Config, string, *vault.Vault, error) {
+	cfg, path, err := config.LoadFile(cfgFile)
+	if err != nil {
+		return nil, "", nil, cerrors.ConfigError("failed to load config: %w", err)
+	}
+	v, err := vault.Open(vault.DefaultPath(path))
+	if err != nil {
+		return nil, "", nil, cerrors.ConfigError("open token vault: %w", err)
+	}
+	return cfg, path, v, nil
+}
+
+func vaultIdentity(e vault.Entry, cfg *config.Config) VaultIdentity {
+	role := strings.ToLower(strings.TrimSpace(cfg.Role))
+	if role == "" {
+		role = config.RoleUser
+	}
+	active := role == e.Kind &&
+		((e.Kind == config.RoleUser && cfg.UserToken == e.Token) || (e.Kind == config.RoleBot && cfg.BotToken == e.Token))
+	identity := VaultIdentity{
+		ID: e.ID(), Team: e.Team, TeamID: e.TeamID, User: e.User, UserID: e.UserID,
+		Kind: e.Kind, Token: config.MaskSecret(e.Token), Scopes: e.Scopes, Active: active,
+	}
+	if !e.CreatedAt.IsZero() {
+		identity.CreatedAt = e.CreatedAt.Format(time.RFC3339)
+	}
+	return identity
+}
+
+func runAuthTokensList(cmd *cobra.Command, args []string) error {
+	cfg, _, v, err := openConfigVault()
+	if err != nil {
+		return err
+	}
+	result := TokensListResult{VaultPath: v.Path(), Identities: []VaultIdentity{}}
+	for _, e := range v.Entries() {
+		result.Identities = append(result.Identities, vaultIdentity(e, cfg))
+	}
+	return output.Print(cmd, result)
+}
+
+func runAuthTokensUse(cmd *cobra.Command, args []string) error {
+	cfg, path, v, err := openConfigVault()
+	if err != nil {
+		return err
+	}
+	entry, err := v.Find(args[0])
+	if err != nil {
+		return cerrors.NotFoundError("vault identity", args[0], err.Error()+"; list identities with: slk auth tokens list")
+	}
+
+	switch entry.Kind {
+	case config.RoleBot:
+		cfg.BotToken = entry.Token
+	default:
+		cfg.UserToken = entry.Token
+		cfg.Cookie = ""
+		cfg.CookieExpires = ""
+	}
+	cfg.Role = entry.Kind
+	savedPath, err := config.Save(path, cfg)
+	if err != nil {
+		return cerrors.ConfigError("save config: %w", err)
+	}
+	return output.Print(cmd, TokensUseResult{Identity: vaultIdentity(entry, cfg), Role: cfg.Role, ConfigPath: savedPath})
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/vault"
+)
+
+func TestAuthTokensUseSwitchesIdentity(t *testing.T) {
+	t.Setenv(vault.PassphraseEnv, "")
+	path := filepath.Join(t.TempDir(), "config.json")
+	previous := cfgFile
+	cfgFile = path
+	t.Cleanup(func() { cfgFile = previous })
+
+	cfg := config.DefaultConfig()
+	cfg.UserToken = "xoxc-browser-session"
+	cfg.Cookie = "xoxd-cookie"
+	if _, err := config.Save(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+	v, err := vault.Open(vault.DefaultPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.Put(vault.Entry{TeamID: "T1", UserID: "U1", User: "agent-one", Kind: config.RoleUser, Token: "xoxp-agent-one"})
+	v.Put(vault.Entry{TeamID: "T1", UserID: "B1", User: "helper", Kind: config.RoleBot, Token: "xoxb-helper"})
+	if err := v.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runAuthTokensUse(authTokensUseCmd, []string{"@agent-one"}); err != nil {
+		t.Fatalf("use agent-one: %v", err)
+	}
+	got, _, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.UserToken != "xoxp-agent-one" || got.Cookie != "" || got.Role != config.RoleUser {
+		t.Fatalf("after user switch: token=%q cookie=%q role=%q", got.UserToken, got.Cookie, got.Role)
+	}
+
+	if err := runAuthTokensUse(authTokensUseCmd, []string{"T1/B1"}); err != nil {
+		t.Fatalf("use bot: %v", err)
+	}
+	got, _, _ = config.LoadFile(path)
+	if got.BotToken != "xoxb-helper" || got.Role != config.RoleBot || got.UserToken != "xoxp-agent-one" {
+		t.Fatalf("after bot switch: bot=%q role=%q user=%q", got.BotToken, got.Role, got.UserToken)
+	}
+	if identity := vaultIdentity(v.Entries()[0], got); !identity.Active || identity.Token == "xoxb-helper" {
+		t.Fatalf("bot identity = %+v, want active with masked token", identity)
+	}
+
+	if err := runAuthTokensUse(authTokensUseCmd, []string{"U9"}); err == nil {
+		t.Fatal("expected error for unknown identity")
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kehao95/slack-agent-cli/internal/blockkit"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var blocksCmd = &cobra.Command{
+	Use:   "blocks",
+	Short: "Block Kit tools",
+	Long:  "Check Block Kit JSON locally before sending it with messages send --blocks.",
+}
+
+var blocksValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate Block Kit JSON",
+	Long: `Validate Block Kit JSON against the limits chat.postMessage enforces and report
+every problem with its path, instead of Slack's bare invalid_blocks error.
+
+Checks include the 50-block limit, required fields (section text or fields, image
+alt_text, button text), text object types (header text must be plain_text), length
+limits (section text 3000, field 2000, header 150, button 75 characters), and
+duplicate block_id values. The input is a JSON array of blocks or an object with a
+"blocks" array, as Block Kit Builder exports. No Slack API call is made.
+
+messages send --blocks runs the same checks before posting.
+
+Output (JSON):
+  {
+    "valid": false,
+    "blocks": 2,
+    "problems": [
+      {"path": "blocks[0].text.type", "message": "must be plain_text, got \"mrkdwn\""}
+    ]
+  }
+
+Exits non-zero when problems are found.`,
+	Example: `  slk blocks validate --file blocks.json
+  generate-blocks | slk blocks validate --file -`,
+	Args: cobra.NoArgs,
+	RunE: runBlocksValidate,
+}
+
+var blocksPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Preview Block Kit JSON in the terminal",
+	Long: `Render an approximate plain-text preview of a Block Kit payload so the layout
+can be checked before posting. Headers, sections (with two-column fields and
+accessories), dividers, context, actions, images, and rich text are drawn; mrkdwn
+is shown as Markdown and buttons as [ Label ]. The preview is an approximation and
+makes no Slack API call.
+
+Validation problems are listed after the preview but do not fail the command; use
+blocks validate for a strict check.`,
+	Example: `  slk blocks preview --file blocks.json --human
+  slk blocks preview --file blocks.json --width 60 --human`,
+	Args: cobra.NoArgs,
+	RunE: runBlocksPreview,
+}
+
+func init() {
+	rootCmd.AddCommand(blocksCmd)
+	blocksCmd.AddCommand(blocksValidateCmd)
+	blocksCmd.AddCommand(blocksPreviewCmd)
+
+	for _, c := range []*cobra.Command{blocksValidateCmd, blocksPreviewCmd} {
+		c.Flags().StringP("file", "f", "", "Block Kit JSON file, or - for stdin (required)")
+		c.MarkFlagRequired("file")
+	}
+	blocksPreviewCmd.Flags().Int("width", 80, "Preview width in columns")
+}
+
+// BlocksValidateResult is the output of blocks validate.
+type BlocksValidateResult struct {
+	Valid    bool               `json:"valid"`
+	Blocks   int                `json:"blocks"`
+	Problems []blockkit.Problem `json:"problems"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r BlocksValidateResult) Lines() []string {
+	if r.Valid {
+		return []string{fmt.Sprintf("Valid: %d blocks", r.Blocks)}
+	}
+	lines := []string{fmt.Sprintf("Invalid: %d problems in %d blocks", len(r.Problems), r.Blocks)}
+	for _, problem := range r.Problems {
+		lines = append(lines, "  "+problem.String())
+	}
+	return lines
+}
+
+// BlocksPreviewResult is the output of blocks preview.
+type BlocksPreviewResult struct {
+	Preview  []string           `json:"preview"`
+	Problems []blockkit.Problem `json:"problems"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r BlocksPreviewResult) Lines() []string {
+	lines := append([]string(nil), r.Preview...)
+	if len(r.Problems) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Warning: %d validation problems (see slk blocks validate)", len(r.Problems)))
+		for _, problem := range r.Problems {
+			lines = append(lines, "  "+problem.String())
+		}
+	}
+	return lines
+}
+
+// readBlocksFile reads --file, where - means stdin.
+func readBlocksFile(cmd *cobra.Command) ([]byte, error) {
+	file, _ := cmd.Flags().GetString("file")
+	if file == "-" {
+		text, err := readRequiredStdin("file")
+		return []byte(text), err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read blocks file: %w", err)
+	}
+	return data, nil
+}
+
+func runBlocksValidate(cmd *cobra.Command, args []string) error {
+	data, err := readBlocksFile(cmd)
+	if err != nil {
+		return err
+	}
+
+	count, problems := blockkit.Validate(data)
+	result := BlocksValidateResult{Valid: len(problems) == 0, Blocks: count, Problems: []blockkit.Problem(problems)}
+	if result.Problems == nil {
+		result.Problems = []blockkit.Problem{}
+	}
+	if err := output.Print(cmd, result); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return fmt.Errorf("blocks are invalid: %d problems", len(problems))
+	}
+	return nil
+}
+
+func runBlocksPreview(cmd *cobra.Command, args []string) error {
+	width, _ := cmd.Flags().GetInt("width")
+	data, err := readBlocksFile(cmd)
+	if err != nil {
+		return err
+	}
+
+	preview, err := blockkit.Preview(data, width)
+	if err != nil {
+		return err
+	}
+	_, problems := blockkit.Validate(data)
+	result := BlocksPreviewResult{Preview: preview, Problems: []blockkit.Problem(problems)}
+	if result.Problems == nil {
+		result.Problems = []blockkit.Problem{}
+	}
+	return output.Print(cmd, result)
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kehao95/slack-agent-cli/internal/board"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
+	"github.com/kehao95/slack-agent-cli/internal/watch"
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Kanban views over reactions",
+	Long:  "Read a channel as a workflow board, with reactions marking each message's stage.",
+}
+
+var boardShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Group recent messages into columns by their reactions",
+	Long: `List a channel's recent top-level messages grouped into columns by reaction, for
+channels where people mark requests with emoji such as :eyes: (looking),
+:hammer_and_wrench: (working on it), and :white_check_mark: (done).
+
+--columns lists the columns in workflow order as emoji=name pairs. A message goes
+in the last column whose reaction it carries, so one with both :eyes: and
+:white_check_mark: is done. Messages with none of the reactions are listed in an
+"untagged" column first; --untagged=false drops them. Skin tones are ignored.
+
+Messages come from conversations.history, newest --limit since --since. With
+--state, they come from a board file kept by watch --track-state instead, with no
+API calls for the messages themselves.
+
+Output (JSON):
+  {
+    "channel": "#requests",
+    "channel_id": "C123ABC",
+    "source": "history",
+    "scanned": 42,
+    "columns": [
+      {
+        "name": "triage",
+        "emoji": "eyes",
+        "count": 1,
+        "cards": [
+          {
+            "ts": "1705312365.000100",
+            "user": "@alice",
+            "user_id": "U123ABC",
+            "text": "Login fails on Safari",
+            "reactions": {"eyes": 1},
+            "reply_count": 2
+          }
+        ]
+      }
+    ]
+  }
+
+Cards in each column are oldest first. Set your columns once with:
+  slk config set board.show.columns ":eyes:=triage,:white_check_mark:=done"
+
+Required Scopes:
+  - channels:history (groups:history for private channels), except with --state
+  - users:read to resolve @names`,
+	Example: `  # The requests board for the last week
+  slk board show --channel "#requests" --columns ":eyes:=triage,:hammer_and_wrench:=in-progress,:white_check_mark:=done"
+
+  # What is still in progress?
+  slk board show --channel "#requests" --columns ":eyes:=triage,:hammer_and_wrench:=in-progress,:white_check_mark:=done" |
+    jq '.columns[] | select(.name == "in-progress") | .cards[].text'
+
+  # From a live board kept by watch
+  slk board show --channel "#requests" --columns ":eyes:=triage,:white_check_mark:=done" --state board.json`,
+	Args: cobra.NoArgs,
+	RunE: runBoardShow,
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+	boardCmd.AddCommand(boardShowCmd)
+
+	boardShowCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	boardShowCmd.Flags().String("columns", "", `Columns in workflow order, e.g. ":eyes:=triage,:white_check_mark:=done" (required)`)
+	boardShowCmd.Flags().String("since", "7d", "Only messages after this time (ISO or relative like 7d)")
+	boardShowCmd.Flags().IntP("limit", "l", 500, "Maximum messages to read")
+	boardShowCmd.Flags().Bool("untagged", true, "List messages without any column reaction in an untagged column")
+	boardShowCmd.Flags().String("state", "", "Read messages from this watch --track-state file instead of history")
+	boardShowCmd.MarkFlagRequired("channel")
+	boardShowCmd.MarkFlagRequired("columns")
+}
+
+func runBoardShow(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+	columnsSpec, _ := cmd.Flags().GetString("columns")
+	since, _ := cmd.Flags().GetString("since")
+	limit, _ := cmd.Flags().GetInt("limit")
+	untagged, _ := cmd.Flags().GetBool("untagged")
+	statePath, _ := cmd.Flags().GetString("state")
+
+	columns, err := board.ParseColumns(columnsSpec)
+	if err != nil {
+		return cerrors.ConfigError("--columns: %v", err)
+	}
+	if limit <= 0 {
+		return cerrors.ConfigError("--limit must be positive")
+	}
+	oldest, _, err := slack.ParseTimeRange(since, "")
+	if err != nil {
+		return cerrors.ConfigError("--since: %v", err)
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	expanders, err := newLinkExpanders(cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+
+	result := board.Result{Channel: channelInput, ChannelID: channelID, Source: "history"}
+	var cards []board.Card
+	if statePath != "" {
+		result.Source = statePath
+		cards, result.Scanned, err = boardCardsFromState(statePath, channelID, oldest, limit)
+	} else {
+		cards, result.Scanned, err = boardCardsFromHistory(cmdCtx, channelID, since, limit)
+	}
+	if err != nil {
+		return err
+	}
+	for i, card := range cards {
+		// Bot IDs (B...) have no @name; history cards carry the bot's name already.
+		if cmdCtx.UserResolver != nil && card.User == "" && card.UserID != "" && !strings.HasPrefix(card.UserID, "B") {
+			if name := cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, card.UserID); name != "" && name != card.UserID {
+				cards[i].User = "@" + name
+			}
+		}
+	}
+	result.Lanes = board.Build(columns, cards, untagged)
+	return output.Print(cmd, withLinks(result, expanders))
+}
+
+func boardCardsFromHistory(cmdCtx *CommandContext, channelID, since string, limit int) ([]board.Card, int, error) {
+	service := messages.NewService(slack.NewMessageFetcher(cmdCtx.Client))
+	var cards []board.Card
+	scanned := 0
+	cursor := ""
+	for {
+		page, err := service.List(cmdCtx.Ctx, messages.Params{
+			Channel: channelID,
+			Limit:   min(limit-scanned, 200),
+			Since:   since,
+			Cursor:  cursor,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, msg := range page.Messages {
+			scanned++
+			if boardSkipsSubtype(msg.SubType) {
+				continue
+			}
+			card := board.Card{TS: msg.Timestamp, UserID: firstNonEmpty(msg.User, msg.BotID), Text: msg.Text, ReplyCount: msg.ReplyCount}
+			if msg.User == "" {
+				card.User = msg.Username
+				if card.User == "" && msg.BotProfile != nil {
+					card.User = msg.BotProfile.Name
+				}
+			}
+			for _, r := range msg.Reactions {
+				if card.Reactions == nil {
+					card.Reactions = map[string]int{}
+				}
+				card.Reactions[board.NormalizeReaction(r.Name)] += r.Count
+			}
+			cards = append(cards, card)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		if scanned >= limit {
+			warnings.Add(warnings.Truncated, "stopped after --limit %d messages; older messages since --since are not on the board", limit)
+			break
+		}
+		cursor = page.NextCursor
+	}
+	return cards, scanned, nil
+}
+
+func boardCardsFromState(path, channelID, oldest string, limit int) ([]board.Card, int, error) {
+	tracked, err := watch.LoadBoard(path, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	msgs := tracked.Messages(channelID)
+	if len(msgs) == 0 {
+		return nil, 0, cerrors.NotFoundError("channel", channelID, "Hint: the board file has no messages for this channel; run watch --track-state with it")
+	}
+	var cards []board.Card
+	scanned := 0
+	// Newest first, so --limit keeps the most recent messages.
+	for i := len(msgs) - 1; i >= 0 && scanned < limit; i-- {
+		msg := msgs[i]
+		if msg.Deleted || (msg.ThreadTS != "" && msg.ThreadTS != msg.TS) || (oldest != "" && slack.CompareTS(msg.TS, oldest) < 0) {
+			continue
+		}
+		scanned++
+		card := board.Card{TS: msg.TS, UserID: msg.User, Text: msg.Text}
+		for name, r := range msg.Reactions {
+			if card.Reactions == nil {
+				card.Reactions = map[string]int{}
+			}
+			card.Reactions[board.NormalizeReaction(name)] += r.Count
+		}
+		cards = append(cards, card)
+	}
+	return cards, scanned, nil
+}
+
+// boardSkipsSubtype drops join, leave, topic, and similar channel notices.
+func boardSkipsSubtype(subtype string) bool {
+	return strings.HasPrefix(subtype, "channel_") || strings.HasPrefix(subtype, "group_")
+}
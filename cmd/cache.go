@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	slackapi "github.com/slack-go/slack"
@@ -97,7 +96,6 @@ func init() {
 	cachePopulateCmd.Flags().Bool("all", false, "Fetch all pages (with rate limiting)")
 	cachePopulateCmd.Flags().Int("page-size", 200, "Items per page")
 	cachePopulateCmd.Flags().Duration("page-delay", time.Second, "Delay between pages")
-	cachePopulateCmd.Flags().Bool("quiet", false, "Suppress progress output")
 }
 
 // channelFetcherAdapter adapts APIClient to cache.ChannelFetcher interface.
@@ -127,8 +125,6 @@ func runCachePopulate(cmd *cobra.Command, args []string) error {
 	fetchAll, _ := cmd.Flags().GetBool("all")
 	pageSize, _ := cmd.Flags().GetInt("page-size")
 	pageDelay, _ := cmd.Flags().GetDuration("page-delay")
-	quiet, _ := cmd.Flags().GetBool("quiet")
-
 	// Use longer timeout for --all mode
 	timeout := 30 * time.Second
 	if fetchAll {
@@ -145,19 +141,17 @@ func runCachePopulate(cmd *cobra.Command, args []string) error {
 		PageSize:  pageSize,
 		PageDelay: pageDelay,
 		FetchAll:  fetchAll,
-	}
-	if !quiet {
-		popCfg.Output = os.Stderr
+		Output:    output.StatusWriter(),
 	}
 
 	var result cache.PopulateResult
 
 	switch target {
 	case "channels":
-		fmt.Fprintf(os.Stderr, "Populating channels cache...\n")
+		output.Statusf("Populating channels cache...\n")
 		result, err = cmdCtx.CacheStore.PopulateChannels(cmdCtx.Ctx, &channelFetcherAdapter{cmdCtx.Client}, popCfg)
 	case "users":
-		fmt.Fprintf(os.Stderr, "Populating users cache...\n")
+		output.Statusf("Populating users cache...\n")
 		result, err = cmdCtx.CacheStore.PopulateUsers(cmdCtx.Ctx, &userFetcherAdapter{cmdCtx.Client}, popCfg)
 	}
 
@@ -170,13 +164,13 @@ func runCachePopulate(cmd *cobra.Command, args []string) error {
 	if result.Complete {
 		status = "complete"
 	}
-	fmt.Fprintf(os.Stderr, "\nResult: %d %s cached (%s)\n", result.Count, target, status)
+	output.Statusf("\nResult: %d %s cached (%s)\n", result.Count, target, status)
 	if !result.Complete && result.NextCursor != "" {
-		fmt.Fprintf(os.Stderr, "Run again to continue fetching.\n")
+		output.Statusf("Run again to continue fetching.\n")
 	}
 
 	if err == context.DeadlineExceeded {
-		fmt.Fprintf(os.Stderr, "Timeout reached. Progress saved. Run again to continue.\n")
+		output.Statusf("Timeout reached. Progress saved. Run again to continue.\n")
 	}
 
 	return nil
@@ -36,6 +36,21 @@ Output (JSON):
     "next_cursor": "dXNlcl9pZDo..."
   }
 
+With --with-activity, each channel also carries an "activity" object, fetched
+--concurrency channels at a time, so one call is enough to rank where to look next:
+  "activity": {
+    "last_ts": "1705312365.000100",
+    "last_activity": "2024-01-15T10:52:45Z",
+    "last_user": "U123ABC",
+    "preview": "Deploy finished, rolling back the canary...",
+    "last_read": "1705300000.000200",
+    "unread_count": 12
+  }
+last_read and unread_count are only present for user tokens; unread_count stops at
+100 and sets "unread_more": true beyond that. is_member is refreshed from
+conversations.info. A channel that cannot be read gets "activity": {"error": "..."}.
+This costs two or three API calls per channel.
+
 Required Scopes:
   - channels:read for public_channel
   - groups:read for private_channel
@@ -54,7 +69,10 @@ Required Scopes:
   slk channels list --types public_channel,private_channel
 
   # Paginate through results
-  slk channels list --cursor "dXNlcl9pZDo..."`,
+  slk channels list --cursor "dXNlcl9pZDo..."
+
+  # Latest message and unread count per channel
+  slk channels list --with-activity --human`,
 	RunE: runChannelsList,
 }
 
@@ -93,14 +111,18 @@ func init() {
 	channelsListCmd.Flags().String("cursor", "", "Continuation cursor")
 	channelsListCmd.Flags().StringSlice("types", []string{"public_channel"}, "Conversation types to include (public_channel requires channels:read, private_channel requires groups:read)")
 	channelsListCmd.Flags().Bool("refresh-cache", false, "Force refresh of cached channel metadata")
+	channelsListCmd.Flags().Bool("with-activity", false, "Add each channel's latest message, read position, and unread count")
+	channelsListCmd.Flags().Int("concurrency", 4, "Channels whose activity is fetched at once (with --with-activity)")
 
 	// Flags for join command
 	channelsJoinCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
 	channelsJoinCmd.MarkFlagRequired("channel")
+	addQuietHoursFlag(channelsJoinCmd)
 
 	// Flags for leave command
 	channelsLeaveCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
 	channelsLeaveCmd.MarkFlagRequired("channel")
+	addQuietHoursFlag(channelsLeaveCmd)
 }
 
 func runChannelsList(cmd *cobra.Command, args []string) error {
@@ -117,6 +139,11 @@ func runChannelsList(cmd *cobra.Command, args []string) error {
 	cursor, _ := cmd.Flags().GetString("cursor")
 	types, _ := cmd.Flags().GetStringSlice("types")
 	refreshCache, _ := cmd.Flags().GetBool("refresh-cache")
+	withActivity, _ := cmd.Flags().GetBool("with-activity")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency <= 0 {
+		return fmt.Errorf("--concurrency must be positive")
+	}
 
 	// Handle cache refresh - this will also pre-populate the cache
 	if refreshCache {
@@ -134,6 +161,9 @@ func runChannelsList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if withActivity {
+		result.Activity = channels.FetchActivity(cmdCtx.Ctx, cmdCtx.Client, result.Channels, concurrency)
+	}
 	return output.Print(cmd, result)
 }
 
@@ -152,6 +182,11 @@ func runChannelsJoin(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+
 	// Join the channel
 	result, err := cmdCtx.Client.JoinChannel(cmdCtx.Ctx, channelID)
 	if err != nil {
@@ -161,7 +196,7 @@ func runChannelsJoin(cmd *cobra.Command, args []string) error {
 	// Use the original input for display
 	result.Channel = channelInput
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withPolicy(result, decision))
 }
 
 func runChannelsLeave(cmd *cobra.Command, args []string) error {
@@ -179,6 +214,11 @@ func runChannelsLeave(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+
 	// Leave the channel
 	result, err := cmdCtx.Client.LeaveChannel(cmdCtx.Ctx, channelID)
 	if err != nil {
@@ -188,5 +228,5 @@ func runChannelsLeave(cmd *cobra.Command, args []string) error {
 	// Use the original input for display
 	result.Channel = channelInput
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withPolicy(result, decision))
 }
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/channels"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
+	"github.com/spf13/cobra"
+)
+
+var channelsAuditNamesCmd = &cobra.Command{
+	Use:   "audit-names",
+	Short: "Report near-duplicate, temp, and misnamed channels",
+	Long: `Scan the cached channel list and report names that need cleanup:
+
+  near_duplicates  Pairs that differ only by separators or case (dev-ops, devops),
+                   share a name plus a copy suffix (proj-x, proj-x-old, proj-x-2), or
+                   are within --max-distance edits of each other. Numbered siblings
+                   such as incident-101 and incident-102 are not reported.
+  temp_channels    Channels with a temp prefix created more than --temp-age ago.
+  violations       Names that break the channel_naming convention in config.
+
+The convention is read from config:
+
+  slk config set channel_naming.prefixes '["team-","proj-","help-"]'
+  slk config set channel_naming.pattern '^[a-z0-9-]+$'
+  slk config set channel_naming.temp_prefixes '["tmp-","temp-","test-"]'
+
+Names come from the channel cache (run "slk cache populate channels --all" first);
+apart from auth.test to locate the workspace cache, no Slack API call is made.
+Archived channels are ignored.`,
+	Example: `  slk channels audit-names --human
+  slk channels audit-names --max-distance 1 --temp-age 14d`,
+	Args: cobra.NoArgs,
+	RunE: runChannelsAuditNames,
+}
+
+func init() {
+	channelsCmd.AddCommand(channelsAuditNamesCmd)
+
+	channelsAuditNamesCmd.Flags().Int("max-distance", 2, "Largest edit distance reported as a near-duplicate")
+	channelsAuditNamesCmd.Flags().String("temp-age", "30d", "Age after which temp channels are reported as orphaned")
+}
+
+func runChannelsAuditNames(cmd *cobra.Command, args []string) error {
+	maxDistance, _ := cmd.Flags().GetInt("max-distance")
+	tempAge, _ := cmd.Flags().GetString("temp-age")
+	if maxDistance < 1 {
+		return fmt.Errorf("--max-distance must be at least 1")
+	}
+	oldest, _, err := slack.ParseTimeRange(tempAge, "")
+	if err != nil {
+		return err
+	}
+	tempBefore, ok := messages.SlackTime(oldest)
+	if !ok {
+		return fmt.Errorf("--temp-age is required")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	cached, complete, err := cmdCtx.ChannelResolver.CachedChannels(cmdCtx.Ctx)
+	if err != nil {
+		return err
+	}
+	if len(cached) == 0 {
+		return cerrors.ConfigError("channel cache is empty; run 'slk cache populate channels --all' first")
+	}
+	if !complete {
+		warnings.Add(warnings.CachePartial, "channel cache is partial; run 'slk cache populate channels --all' to include every channel")
+	}
+
+	opts := channels.NameAuditOptions{MaxDistance: maxDistance, TempBefore: tempBefore, Now: time.Now()}
+	if naming := cmdCtx.Config.ChannelNaming; naming != nil {
+		opts.Prefixes = naming.Prefixes
+		opts.TempPrefixes = naming.TempPrefixes
+		if naming.Pattern != "" {
+			if opts.Pattern, err = regexp.Compile(naming.Pattern); err != nil {
+				return cerrors.ConfigError("channel_naming.pattern is not a valid regular expression: %v", err)
+			}
+		}
+	}
+	return output.Print(cmd, channels.AuditNames(cached, opts))
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"github.com/kehao95/slack-agent-cli/internal/channels"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var channelsHistoryPermissionsCmd = &cobra.Command{
+	Use:   "history-permissions",
+	Short: "Check what the current token can do in a channel",
+}
+
+var channelsProbeCmd = &cobra.Command{
+	Use:   "probe",
+	Short: "Report which operations the token can perform in a channel",
+	Long: `Report which operations the current token can perform in a channel, so an agent
+learns about permission walls before it starts a task instead of midway through.
+
+read and history are checked by calling conversations.info and conversations.history
+for a single message. post and react are never attempted: they are inferred from the
+token's scopes, whether it is a member, whether the channel is archived, and local
+policy (read-only mode, allowed_channels/denied_channels, user impersonation). Quiet
+hours and #general posting restrictions set by workspace admins are not checked.
+
+A refused operation is a result, not an error: the command exits 0 unless the
+channel cannot be resolved or auth fails.
+
+Output (JSON):
+  {
+    "channel": "C123ABC",
+    "channel_name": "#support",
+    "token_type": "bot",
+    "is_member": false,
+    "is_private": false,
+    "is_archived": false,
+    "operations": [
+      {"operation": "read", "method": "conversations.info", "allowed": true, "checked": "api"},
+      {"operation": "history", "method": "conversations.history", "allowed": false, "checked": "api", "reason": "not_in_channel"},
+      {"operation": "post", "method": "chat.postMessage", "allowed": true, "checked": "inferred", "reason": "not a member; chat:write.public allows posting to public channels"},
+      {"operation": "react", "method": "reactions.add", "allowed": false, "checked": "inferred", "scope": "reactions:write", "reason": "token lacks reactions:write"}
+    ]
+  }
+
+Required Scopes:
+  channels:read (groups:read, im:read, mpim:read for other conversation types)
+  Missing scopes are reported in the result rather than failing the command.`,
+	Example: `  slk channels history-permissions probe --channel "#support"
+  slk channels history-permissions probe --channel C123ABC --human
+
+  # Fail fast in a script when posting is not possible
+  slk channels history-permissions probe -c "#deploys" | jq -e '.operations[] | select(.operation=="post") | .allowed'`,
+	Args: cobra.NoArgs,
+	RunE: runChannelsProbe,
+}
+
+func init() {
+	channelsCmd.AddCommand(channelsHistoryPermissionsCmd)
+	channelsHistoryPermissionsCmd.AddCommand(channelsProbeCmd)
+
+	channelsProbeCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	channelsProbeCmd.MarkFlagRequired("channel")
+}
+
+func runChannelsProbe(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+
+	result := channels.Probe(cmdCtx.Ctx, cmdCtx.Client, channels.ProbeInput{
+		ChannelID: channelID,
+		TokenType: slack.ClassifyToken(cmdCtx.AuthToken).Type,
+		Scopes:    cmdCtx.AuthScopes,
+		CheckWrite: func(method string) error {
+			return cmdCtx.Client.CheckWrite(method, channelID)
+		},
+	})
+	if result.ChannelName == "" {
+		if name := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID); name != channelID {
+			result.ChannelName = name
+		}
+	}
+	return output.Print(cmd, result)
+}
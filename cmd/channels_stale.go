@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/channels"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/policy"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
+	"github.com/spf13/cobra"
+)
+
+var channelsStaleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "Find channels with no recent messages",
+	Long: `List cached channels whose latest message is older than --inactive-for. Each
+channel costs one conversations.history call (limit 1); channels are checked
+--concurrency at a time. Channels that have never had a message are judged by their
+creation time. Channels the token cannot read are listed under "failed".
+
+Channels come from the channel cache (run "slk cache populate channels --all" first).
+
+With --archive --yes, every stale channel is archived after the scan. Archiving
+needs channels:manage (bot) or channels:write (user), plus groups:write for private
+channels. Without --yes, --archive is refused so a scan never archives by accident.
+Each channel is checked against quiet_hours before it is archived; channels outside
+their allowed window are left alone with an error (--override-quiet-hours archives
+them anyway).
+
+Output (JSON):
+  {
+    "cutoff": "2024-01-01T09:00:00Z",
+    "checked": 120,
+    "stale": [
+      {
+        "channel_id": "C123ABC",
+        "channel": "#old-project",
+        "last_activity": "2023-06-02T14:11:05Z",
+        "last_ts": "1685715065.000200",
+        "inactive_days": 213
+      }
+    ]
+  }`,
+	Example: `  # Channels quiet for 90 days
+  slk channels stale --inactive-for 90d --human
+
+  # Archive everything quiet for a year
+  slk channels stale --inactive-for 365d --archive --yes`,
+	Args: cobra.NoArgs,
+	RunE: runChannelsStale,
+}
+
+func init() {
+	channelsCmd.AddCommand(channelsStaleCmd)
+
+	channelsStaleCmd.Flags().String("inactive-for", "90d", "Inactivity threshold (relative like 90d or a duration like 2160h)")
+	channelsStaleCmd.Flags().Int("concurrency", 4, "Channels checked at once")
+	channelsStaleCmd.Flags().Bool("archive", false, "Archive the stale channels (requires --yes)")
+	channelsStaleCmd.Flags().Bool("yes", false, "Confirm --archive")
+	addQuietHoursFlag(channelsStaleCmd)
+}
+
+func runChannelsStale(cmd *cobra.Command, args []string) error {
+	inactiveFor, _ := cmd.Flags().GetString("inactive-for")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	archive, _ := cmd.Flags().GetBool("archive")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if archive && !yes {
+		return cerrors.ConfigError("--archive archives every stale channel; pass --yes to confirm")
+	}
+	if concurrency <= 0 {
+		return fmt.Errorf("--concurrency must be positive")
+	}
+	oldest, _, err := slack.ParseTimeRange(inactiveFor, "")
+	if err != nil {
+		return err
+	}
+	cutoff, ok := messages.SlackTime(oldest)
+	if !ok {
+		return fmt.Errorf("--inactive-for is required")
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	cached, complete, err := cmdCtx.ChannelResolver.CachedChannels(cmdCtx.Ctx)
+	if err != nil {
+		return err
+	}
+	if len(cached) == 0 {
+		return cerrors.ConfigError("channel cache is empty; run 'slk cache populate channels --all' first")
+	}
+	if !complete {
+		warnings.Add(warnings.CachePartial, "channel cache is partial; run 'slk cache populate channels --all' to include every channel")
+	}
+
+	service := messages.NewService(slack.NewMessageFetcher(cmdCtx.Client))
+	latest := func(ctx context.Context, channelID string) (string, error) {
+		page, err := service.List(ctx, messages.Params{Channel: channelID, Limit: 1})
+		if err != nil || len(page.Messages) == 0 {
+			return "", err
+		}
+		return page.Messages[0].Timestamp, nil
+	}
+	result, err := channels.FindStale(cmdCtx.Ctx, cached, latest, cutoff, time.Now(), concurrency)
+	if err != nil {
+		return err
+	}
+
+	if archive {
+		for i, ch := range result.Stale {
+			quiet, err := quietHoursDecision(cmd, cmdCtx, ch.ChannelID, false)
+			if err != nil {
+				return err
+			}
+			if quiet != nil && quiet.Action == policy.ActionReject {
+				result.Stale[i].Error = fmt.Sprintf("quiet hours until %s", quiet.NextAllowed.Format(time.RFC3339))
+				continue
+			}
+			if err := cmdCtx.Client.ArchiveChannel(cmdCtx.Ctx, ch.ChannelID); err != nil {
+				result.Stale[i].Error = err.Error()
+				continue
+			}
+			result.Stale[i].Archived = true
+			result.Archived++
+		}
+	}
+	return output.Print(cmd, result)
+}
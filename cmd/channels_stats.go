@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
+	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+)
+
+var channelsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Compute channel activity metrics",
+	Long: `Compute activity metrics for a channel from its history: top-level messages per
+day, top posters, the share of messages that started a thread, median time to the
+first reply by someone else, and the busiest hours of the day.
+
+Days and hours use the local time zone. Joins, topic changes, and other housekeeping
+subtypes are not counted. Response times need one conversations.replies call per
+thread; skip them with --responses=false on busy channels.
+
+Output (JSON):
+  {
+    "channel": "C123ABC",
+    "channel_name": "#support",
+    "since": "2024-01-01T09:00:00Z",
+    "until": "2024-01-31T09:00:00Z",
+    "timezone": "Local",
+    "messages": 412,
+    "replies": 960,
+    "threads": 180,
+    "thread_ratio": 0.44,
+    "median_response_seconds": 540,
+    "response_samples": 171,
+    "per_day": [{"date": "2024-01-01", "messages": 12}],
+    "top_posters": [{"user_id": "U123", "user": "alice", "messages": 88}],
+    "busiest_hours": [{"hour": 10, "messages": 61}]
+  }`,
+	Example: `  # Last 30 days (default)
+  slk channels stats --channel "#support"
+
+  # One week, without per-thread reply lookups
+  slk channels stats --channel "#support" --since 7d --responses=false --human`,
+	Args: cobra.NoArgs,
+	RunE: runChannelsStats,
+}
+
+func init() {
+	channelsCmd.AddCommand(channelsStatsCmd)
+
+	channelsStatsCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	channelsStatsCmd.Flags().String("since", "30d", "Start of the range (ISO or relative like 30d)")
+	channelsStatsCmd.Flags().String("until", "", "End of the range (default: now)")
+	channelsStatsCmd.Flags().IntP("limit", "l", 10000, "Maximum top-level messages to read")
+	channelsStatsCmd.Flags().Bool("responses", true, "Fetch thread replies to compute median response time")
+	channelsStatsCmd.Flags().Int("top", 10, "Number of top posters to report")
+	channelsStatsCmd.MarkFlagRequired("channel")
+}
+
+func runChannelsStats(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	limit, _ := cmd.Flags().GetInt("limit")
+	withResponses, _ := cmd.Flags().GetBool("responses")
+	top, _ := cmd.Flags().GetInt("top")
+
+	oldest, latest, err := slack.ParseTimeRange(since, until)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	from, ok := messages.SlackTime(oldest)
+	if !ok {
+		return fmt.Errorf("--since is required")
+	}
+	to := now
+	if latest != "" {
+		to, _ = messages.SlackTime(latest)
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+
+	service := messages.NewService(slack.NewMessageFetcher(cmdCtx.Client))
+	var history []slackapi.Message
+	truncated := false
+	cursor := ""
+	for {
+		page, err := service.List(cmdCtx.Ctx, messages.Params{
+			Channel: channelID,
+			Limit:   min(limit-len(history), 200),
+			Since:   since,
+			Until:   until,
+			Cursor:  cursor,
+		})
+		if err != nil {
+			return err
+		}
+		history = append(history, page.Messages...)
+		if page.NextCursor == "" {
+			break
+		}
+		if len(history) >= limit {
+			truncated = true
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	firstReplies := map[string]slackapi.Message{}
+	if withResponses {
+		for _, msg := range history {
+			if msg.ReplyCount == 0 {
+				continue
+			}
+			thread, err := service.List(cmdCtx.Ctx, messages.Params{Channel: channelID, Thread: msg.Timestamp, Limit: 20})
+			if err != nil {
+				warnings.Add(warnings.PartialResults, "skipped thread %s: %v", msg.Timestamp, err)
+				continue
+			}
+			if reply, ok := messages.FirstResponse(thread.Messages); ok {
+				firstReplies[msg.Timestamp] = reply
+			}
+		}
+	}
+
+	stats := messages.ComputeStats(messages.StatsInput{
+		Channel:      channelID,
+		From:         from,
+		To:           to,
+		Messages:     history,
+		FirstReplies: firstReplies,
+		TopN:         top,
+		UserName: func(userID string) string {
+			return cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, userID)
+		},
+	})
+	stats.ChannelName = cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID)
+	stats.Truncated = truncated
+	if truncated {
+		warnings.Add(warnings.Truncated, "history was cut at --limit %d; counts cover the most recent messages only", limit)
+	}
+	return output.Print(cmd, stats)
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage CLI configuration",
+	Long: `Read and write individual keys in the config file instead of editing JSON by hand.
+
+Keys are dotted paths into the config file (role, app_token, defaults.include_bots,
+channels.C123.require_mention) or per-command flag defaults (messages.list.limit).`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show the effective config or one key",
+	Long: `Print the effective configuration, including environment overrides such as
+SLACK_USER_TOKEN and SLACK_CLI_ROLE. Tokens and cookies are masked unless
+--show-secrets is given. Use --file to see only what the config file contains.`,
+	Example: `  # Everything, as JSON
+  slk config get
+
+  # One key
+  slk config get role
+  slk config get messages.list.limit
+
+  # Only values stored in the file (no env overrides)
+  slk config get --file`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a config key or per-command flag default",
+	Long: `Store a value in the config file. Values are validated before saving: numbers and
+booleans must fit their field, role must be user or bot, and tokens must carry the
+right prefix (xoxp-/xoxc-, xoxb-, xapp-).
+
+Flag default keys are the command path and flag name joined by dots; the default
+applies whenever that flag is not passed explicitly. The special key "output" takes
+json or human and applies to every command. List flags take comma-separated values.`,
+	Example: `  # Fetch 100 messages unless --limit is given
+  slk config set messages.list.limit 100
+
+  # Include private channels in channels list by default
+  slk config set channels.list.types public_channel,private_channel
+
+  # Always print human-readable output
+  slk config set output human
+
+  # Plain config keys
+  slk config set role bot
+  slk config set app_token xapp-1-...
+  slk config set defaults.include_bots true`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a config key or flag default",
+	Example: `  slk config unset messages.list.limit
+  slk config unset app_token`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigUnset,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+
+	configGetCmd.Flags().Bool("show-secrets", false, "Print tokens and cookies unmasked")
+	configGetCmd.Flags().Bool("file", false, "Show only values stored in the config file, without env overrides")
+}
+
+// ConfigGetResult is the output of config get.
+type ConfigGetResult struct {
+	Key        string      `json:"key,omitempty"`
+	Value      interface{} `json:"value"`
+	ConfigPath string      `json:"config_path"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ConfigGetResult) Lines() []string {
+	return config.Flatten(r.Key, r.Value)
+}
+
+// ConfigSetResult is the output of config set and config unset.
+type ConfigSetResult struct {
+	Key        string      `json:"key"`
+	Value      interface{} `json:"value"`
+	ConfigPath string      `json:"config_path"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ConfigSetResult) Lines() []string {
+	if r.Value == nil {
+		return []string{fmt.Sprintf("Removed %s from %s", r.Key, r.ConfigPath)}
+	}
+	return []string{fmt.Sprintf("Set %s = %v in %s", r.Key, r.Value, r.ConfigPath)}
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	fileOnly, _ := cmd.Flags().GetBool("file")
+	showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+	load := config.Load
+	if fileOnly {
+		load = config.LoadFile
+	}
+	cfg, path, err := load(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+
+	key := ""
+	if len(args) == 1 {
+		key = strings.TrimSpace(args[0])
+	}
+	var value interface{}
+	if key != "" && isFlagDefaultKey(key) {
+		v, ok := cfg.Defaults.Flags[key]
+		if !ok {
+			return cerrors.NotFoundError("config key", key, "set it with: slk config set "+key+" <value>")
+		}
+		value = v
+	} else {
+		value, err = cfg.Get(key)
+		if err != nil {
+			return cerrors.NotFoundError("config key", key, "run slk config get to list keys")
+		}
+	}
+	if !showSecrets {
+		value = config.Masked(key, value)
+	}
+	return output.Print(cmd, ConfigGetResult{Key: key, Value: value, ConfigPath: path})
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key := strings.TrimSpace(args[0])
+	cfg, path, err := config.LoadFile(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+
+	var value interface{}
+	if isFlagDefaultKey(key) {
+		if value, err = parseConfigFlagDefault(key, args[1]); err != nil {
+			return err
+		}
+		cfg.SetFlagDefault(key, value)
+	} else {
+		if err := cfg.Set(key, args[1]); err != nil {
+			return cerrors.ConfigError("%v", err)
+		}
+		value, _ = cfg.Get(key)
+		value = config.Masked(key, value)
+	}
+	return saveConfigResult(cmd, cfg, path, key, value)
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := strings.TrimSpace(args[0])
+	cfg, path, err := config.LoadFile(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+
+	if _, stored := cfg.Defaults.Flags[key]; stored || isFlagDefaultKey(key) {
+		cfg.SetFlagDefault(key, nil)
+	} else if err := cfg.Unset(key); err != nil {
+		return cerrors.ConfigError("%v", err)
+	}
+	return saveConfigResult(cmd, cfg, path, key, nil)
+}
+
+func saveConfigResult(cmd *cobra.Command, cfg *config.Config, path, key string, value interface{}) error {
+	savedPath, err := config.Save(path, cfg)
+	if err != nil {
+		return cerrors.ConfigError("save config: %w", err)
+	}
+	return output.Print(cmd, ConfigSetResult{Key: key, Value: value, ConfigPath: savedPath})
+}
+
+// isFlagDefaultKey reports whether key names a per-command flag default rather than
+// a path into the config file.
+func isFlagDefaultKey(key string) bool {
+	if key == config.OutputFlagKey {
+		return true
+	}
+	_, err := lookupConfigFlag(key)
+	return err == nil
+}
+
+// parseConfigFlagDefault validates a key against the command tree and converts the
+// value to the JSON type matching the flag (number, bool, list, or string).
+func parseConfigFlagDefault(key, raw string) (interface{}, error) {
+	if key == config.OutputFlagKey {
+		switch raw {
+		case "json", "human":
+			return raw, nil
+		default:
+			return nil, cerrors.ConfigError("output must be json or human, got %q", raw)
+		}
+	}
+
+	flag, err := lookupConfigFlag(key)
+	if err != nil {
+		return nil, err
+	}
+	switch flag.Value.Type() {
+	case "int", "int64":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, cerrors.ConfigError("%s expects an integer, got %q", key, raw)
+		}
+		return n, nil
+	case "bool":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, cerrors.ConfigError("%s expects true or false, got %q", key, raw)
+		}
+		return b, nil
+	case "duration":
+		if _, err := time.ParseDuration(raw); err != nil {
+			return nil, cerrors.ConfigError("%s expects a duration like 5m, got %q", key, raw)
+		}
+		return raw, nil
+	case "stringSlice", "stringArray":
+		parts := []string{}
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return parts, nil
+	default:
+		return raw, nil
+	}
+}
+
+// lookupConfigFlag resolves a dotted key like "messages.list.limit" to its flag.
+func lookupConfigFlag(key string) (*pflag.Flag, error) {
+	parts := strings.Split(key, ".")
+	if len(parts) < 2 {
+		return nil, cerrors.ConfigError("invalid key %q: use <command>.<flag>, e.g. messages.list.limit", key)
+	}
+	path, name := parts[:len(parts)-1], parts[len(parts)-1]
+	target, rest, err := rootCmd.Find(path)
+	if err != nil || len(rest) > 0 || target == rootCmd || target.Name() != path[len(path)-1] {
+		return nil, cerrors.ConfigError("invalid key %q: unknown command %q", key, strings.Join(path, " "))
+	}
+	flag := target.Flags().Lookup(name)
+	if flag == nil {
+		flag = target.InheritedFlags().Lookup(name)
+	}
+	if flag == nil || name == "help" || name == "config" {
+		return nil, cerrors.ConfigError("invalid key %q: %s has no --%s flag", key, target.CommandPath(), name)
+	}
+	return flag, nil
+}
+
+// applyConfigFlagDefaults sets flags the user did not pass from the config file's
+// per-command defaults. A missing or unreadable config is ignored here; commands that
+// need the config report that themselves.
+func applyConfigFlagDefaults(cmd *cobra.Command) error {
+	cfg, _, err := config.LoadFile(cfgFile)
+	if err != nil || len(cfg.Defaults.Flags) == 0 {
+		return nil
+	}
+
+	if value, ok := cfg.Defaults.Flags[config.OutputFlagKey]; ok {
+		if flag := cmd.Flags().Lookup("human"); flag != nil && !flag.Changed {
+			if err := flag.Value.Set(strconv.FormatBool(value == "human")); err != nil {
+				return cerrors.ConfigError("config default output: %w", err)
+			}
+		}
+	}
+
+	path := strings.Fields(cmd.CommandPath())
+	if len(path) < 2 {
+		return nil
+	}
+	for name, value := range cfg.FlagDefaults(strings.Join(path[1:], ".")) {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			output.Warnf("ignoring config default for unknown flag --%s on %s", name, cmd.CommandPath())
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := flag.Value.Set(configFlagValue(value)); err != nil {
+			return cerrors.ConfigError("config default %s.%s: %w", strings.Join(path[1:], "."), name, err)
+		}
+	}
+	return nil
+}
+
+// configFlagValue renders a decoded JSON value in the form pflag expects.
+func configFlagValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// flagEnvPrefix prefixes environment variables that supply flag values.
+const flagEnvPrefix = "SLK_"
+
+// envVarInfo describes an environment variable the CLI reads.
+type envVarInfo struct {
+	Name        string
+	Description string
+	Secret      bool
+}
+
+// knownEnvVars lists every environment variable the CLI honors outside of SLK_* flags.
+var knownEnvVars = []envVarInfo{
+	{"SLACK_USER_TOKEN", "OAuth user token (xoxp-); overrides user_token", true},
+	{"SLACK_BOT_TOKEN", "Bot token (xoxb-); overrides bot_token", true},
+	{"SLACK_APP_TOKEN", "App-level token (xapp-) for Socket Mode events", true},
+	{"SLACK_CLIENT_TOKEN", "Browser client token (xoxc-); lowest-priority user token", true},
+	{"SLACK_CLIENT_COOKIE", "Cookie (d=...) required with xoxc- tokens", true},
+	{"SLACK_CLI_ROLE", "Active auth role: user or bot", false},
+	{"SLACK_CLI_CONFIG", "Config file path", false},
+	{"SLACK_CLI_FORMAT", "Default output format: json or human", false},
+	{"NO_COLOR", "Disable ANSI colors in human output (see --color)", false},
+	{"COLUMNS", "Terminal width for wrapping human output", false},
+	{"SLACK_TEAM_ID", "Workspace ID; skips the auth.test lookup for cache paths", false},
+	{"SLACK_CLIENT_ID", "OAuth client ID for auth oauth", false},
+	{"SLACK_CLIENT_SECRET", "OAuth client secret for auth oauth", true},
+	{"SLACK_CLI_AUTH_RELAY", "Relay base URL for auth device", false},
+	{"SLACK_CLI_VAULT_PASSPHRASE", "Passphrase for the token vault instead of vault.json.key", true},
+	{"HTTPS_PROXY", "Proxy for Slack API and Socket Mode connections", false},
+	{"HTTP_PROXY", "Proxy for plain HTTP requests", false},
+	{"NO_PROXY", "Hosts that bypass the proxy", false},
+	{"OTEL_EXPORTER_OTLP_ENDPOINT", "Enable OpenTelemetry trace export (OTLP/HTTP)", false},
+	{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "Trace-specific OTLP endpoint", false},
+	{"OTEL_EXPORTER_OTLP_HEADERS", "Headers for the OTLP exporter (key=value,...)", true},
+	{"OTEL_SERVICE_NAME", "service.name reported on spans", false},
+}
+
+var configEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "List environment variables the CLI honors",
+	Long: `List every environment variable the CLI reads and whether it is set, plus any
+SLK_* flag overrides in effect. Secret values are masked.
+
+Every flag can be supplied through the environment, which suits containers that
+cannot write a config file:
+
+  SLK_<COMMAND>_<FLAG>   e.g. SLK_MESSAGES_LIST_LIMIT=100
+  SLK_<FLAG>             global flags, e.g. SLK_HUMAN=true, SLK_CONFIG=/etc/slk.json
+
+Precedence: command-line flag > SLK_* variable > config file default > built-in default.`,
+	Example: `  slk config env
+  SLK_MESSAGES_LIST_LIMIT=100 slk messages list --channel "#general"`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEnv,
+}
+
+func init() {
+	configCmd.AddCommand(configEnvCmd)
+}
+
+// EnvVarStatus reports one environment variable.
+type EnvVarStatus struct {
+	Name        string `json:"name"`
+	Set         bool   `json:"set"`
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description"`
+}
+
+// EnvOverride reports one SLK_* variable and the flag it maps to.
+type EnvOverride struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Flag  string `json:"flag,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ConfigEnvResult is the output of config env.
+type ConfigEnvResult struct {
+	Variables []EnvVarStatus `json:"variables"`
+	Overrides []EnvOverride  `json:"flag_overrides"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ConfigEnvResult) Lines() []string {
+	lines := []string{"Environment variables", strings.Repeat("-", 21)}
+	for _, v := range r.Variables {
+		status := "unset"
+		if v.Set {
+			status = "set"
+			if v.Value != "" {
+				status += " (" + v.Value + ")"
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%-36s %-24s %s", v.Name, status, v.Description))
+	}
+	lines = append(lines, "", "Flag overrides (SLK_*)", strings.Repeat("-", 22))
+	if len(r.Overrides) == 0 {
+		return append(lines, "None set.")
+	}
+	for _, o := range r.Overrides {
+		target := o.Flag
+		if o.Error != "" {
+			target = "error: " + o.Error
+		}
+		lines = append(lines, fmt.Sprintf("%s=%s -> %s", o.Name, o.Value, target))
+	}
+	return lines
+}
+
+// Ellipsize implements output.Ellipsizer; the rows are a table.
+func (r ConfigEnvResult) Ellipsize() bool {
+	return true
+}
+
+func runConfigEnv(cmd *cobra.Command, args []string) error {
+	result := ConfigEnvResult{Variables: []EnvVarStatus{}, Overrides: flagEnvOverrides()}
+	for _, v := range knownEnvVars {
+		value, set := os.LookupEnv(v.Name)
+		if !set {
+			// Proxy variables are also honored in lower case.
+			value, set = os.LookupEnv(strings.ToLower(v.Name))
+		}
+		status := EnvVarStatus{Name: v.Name, Set: set, Description: v.Description}
+		if set {
+			status.Value = value
+			if v.Secret {
+				status.Value = config.MaskSecret(value)
+			}
+		}
+		result.Variables = append(result.Variables, status)
+	}
+	return output.Print(cmd, result)
+}
+
+// flagEnvOverrides lists SLK_* variables in the environment and the flags they set.
+func flagEnvOverrides() []EnvOverride {
+	targets := map[string]string{}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			targets[flagEnvName(c, f)] = strings.TrimSpace(c.CommandPath() + " --" + f.Name)
+		})
+		for _, child := range c.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+
+	overrides := []EnvOverride{}
+	for _, entry := range os.Environ() {
+		name, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(name, flagEnvPrefix) {
+			continue
+		}
+		override := EnvOverride{Name: name, Value: value, Flag: targets[name]}
+		if override.Flag == "" {
+			override.Error = "no matching flag"
+		}
+		overrides = append(overrides, override)
+	}
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i].Name < overrides[j].Name })
+	return overrides
+}
+
+// flagEnvName returns the SLK_* variable for a flag: SLK_<FLAG> for flags defined on
+// the root command, SLK_<COMMAND PATH>_<FLAG> otherwise.
+func flagEnvName(owner *cobra.Command, flag *pflag.Flag) string {
+	parts := []string{}
+	if owner.HasParent() {
+		parts = append(parts, strings.Fields(owner.CommandPath())[1:]...)
+	}
+	parts = append(parts, flag.Name)
+	name := strings.ToUpper(strings.Join(parts, "_"))
+	return flagEnvPrefix + strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
+
+// applyEnvFlagOverrides sets flags that were not passed on the command line from
+// SLK_* variables, then applies SLACK_CLI_FORMAT to --human. Values set this way count
+// as explicitly passed, so config file defaults do not override them.
+func applyEnvFlagOverrides(cmd *cobra.Command) error {
+	var err error
+	apply := func(owner *cobra.Command, f *pflag.Flag) {
+		if err != nil || f.Changed || f.Name == "help" || f.Name == "version" {
+			return
+		}
+		name := flagEnvName(owner, f)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return
+		}
+		if setErr := cmd.Flags().Set(f.Name, value); setErr != nil {
+			err = cerrors.ConfigError("invalid %s: %w", name, setErr)
+		}
+	}
+
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) { apply(cmd, f) })
+	for owner := cmd; owner != nil; owner = owner.Parent() {
+		owner.PersistentFlags().VisitAll(func(f *pflag.Flag) { apply(owner, f) })
+	}
+	if err != nil {
+		return err
+	}
+
+	if flag := cmd.Flags().Lookup("human"); flag != nil && !flag.Changed {
+		switch format := strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_CLI_FORMAT"))); format {
+		case "":
+		case "human", "json":
+			if err := cmd.Flags().Set("human", fmt.Sprintf("%t", format == "human")); err != nil {
+				return err
+			}
+		default:
+			return cerrors.ConfigError("invalid SLACK_CLI_FORMAT %q: use json or human", format)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// resetFlags restores flags on the shared command tree after a test parses them.
+func resetFlags(cmd *cobra.Command, names ...string) {
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	}
+}
+
+func TestParseConfigFlagDefault(t *testing.T) {
+	tests := []struct {
+		key  string
+		raw  string
+		want interface{}
+	}{
+		{"messages.list.limit", "100", int64(100)},
+		{"channels.list.types", "public_channel, private_channel", []string{"public_channel", "private_channel"}},
+		{"daemon.run.exclude-self", "true", true},
+		{"output", "json", "json"},
+	}
+	for _, tt := range tests {
+		got, err := parseConfigFlagDefault(tt.key, tt.raw)
+		if err != nil {
+			t.Fatalf("parseConfigFlagDefault(%q, %q) returned error: %v", tt.key, tt.raw, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("parseConfigFlagDefault(%q, %q) = %#v, want %#v", tt.key, tt.raw, got, tt.want)
+		}
+	}
+
+	for _, bad := range [][2]string{
+		{"messages.list.limit", "lots"},
+		{"messages.nope.limit", "1"},
+		{"messages.list.nope", "1"},
+		{"limit", "1"},
+		{"output", "yaml"},
+	} {
+		if _, err := parseConfigFlagDefault(bad[0], bad[1]); err == nil {
+			t.Fatalf("expected error for %s=%s", bad[0], bad[1])
+		}
+	}
+}
+
+func TestApplyConfigFlagDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := config.DefaultConfig()
+	cfg.SetFlagDefault("messages.list.limit", 100)
+	cfg.SetFlagDefault("messages.list.since", "7d")
+	cfg.SetFlagDefault("output", "human")
+	if _, err := config.Save(path, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	previous := cfgFile
+	cfgFile = path
+	t.Cleanup(func() { cfgFile = previous })
+
+	cmd, _, err := rootCmd.Find([]string{"messages", "list"})
+	if err != nil {
+		t.Fatalf("find messages list: %v", err)
+	}
+	if err := cmd.ParseFlags([]string{"--since", "1h"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	t.Cleanup(func() { resetFlags(cmd, "limit", "since", "human") })
+
+	if err := applyConfigFlagDefaults(cmd); err != nil {
+		t.Fatalf("applyConfigFlagDefaults returned error: %v", err)
+	}
+	if limit, _ := cmd.Flags().GetInt("limit"); limit != 100 {
+		t.Fatalf("expected config limit 100, got %d", limit)
+	}
+	if since, _ := cmd.Flags().GetString("since"); since != "1h" {
+		t.Fatalf("expected explicit --since to win, got %q", since)
+	}
+	if human, _ := cmd.Flags().GetBool("human"); !human {
+		t.Fatal("expected output default to enable --human")
+	}
+}
+
+func TestConfigSetRoutesKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	previous := cfgFile
+	cfgFile = path
+	t.Cleanup(func() { cfgFile = previous })
+
+	for _, args := range [][]string{
+		{"messages.list.limit", "100"},
+		{"channels.list.types", "public_channel,private_channel"},
+		{"role", "bot"},
+		{"defaults.include_bots", "true"},
+	} {
+		if err := runConfigSet(configSetCmd, args); err != nil {
+			t.Fatalf("config set %v returned error: %v", args, err)
+		}
+	}
+	if err := runConfigUnset(configUnsetCmd, []string{"defaults.include_bots"}); err != nil {
+		t.Fatalf("config unset returned error: %v", err)
+	}
+
+	cfg, _, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Role != config.RoleBot || cfg.Defaults.IncludeBots {
+		t.Fatalf("unexpected config fields: role=%q include_bots=%v", cfg.Role, cfg.Defaults.IncludeBots)
+	}
+	if got := cfg.FlagDefaults("messages.list")["limit"]; got != float64(100) {
+		t.Fatalf("expected messages.list.limit flag default, got %#v", got)
+	}
+	if got := cfg.FlagDefaults("channels.list")["types"]; !reflect.DeepEqual(got, []interface{}{"public_channel", "private_channel"}) {
+		t.Fatalf("expected channels.list.types flag default, got %#v", got)
+	}
+	if _, ok := cfg.Channels["list"]; ok {
+		t.Fatal("flag default key must not be written into the channels ACL map")
+	}
+	if err := runConfigSet(configSetCmd, []string{"role", "admin"}); err == nil {
+		t.Fatal("expected invalid role to be rejected")
+	}
+}
+
+func TestApplyEnvFlagOverrides(t *testing.T) {
+	t.Setenv("SLK_MESSAGES_LIST_LIMIT", "7")
+	t.Setenv("SLK_MESSAGES_LIST_RENDER_MRKDWN", "true")
+	t.Setenv("SLK_HUMAN", "true")
+
+	cmd, _, err := rootCmd.Find([]string{"messages", "list"})
+	if err != nil {
+		t.Fatalf("find messages list: %v", err)
+	}
+	if err := cmd.ParseFlags([]string{"--render-mrkdwn=false"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	t.Cleanup(func() { resetFlags(cmd, "limit", "render-mrkdwn", "human") })
+
+	if err := applyEnvFlagOverrides(cmd); err != nil {
+		t.Fatalf("applyEnvFlagOverrides returned error: %v", err)
+	}
+	if limit, _ := cmd.Flags().GetInt("limit"); limit != 7 {
+		t.Fatalf("expected SLK_MESSAGES_LIST_LIMIT to set limit 7, got %d", limit)
+	}
+	if render, _ := cmd.Flags().GetBool("render-mrkdwn"); render {
+		t.Fatal("expected explicit --render-mrkdwn=false to win over env")
+	}
+	if human, _ := cmd.Flags().GetBool("human"); !human {
+		t.Fatal("expected SLK_HUMAN to set the global --human flag")
+	}
+
+	t.Setenv("SLK_MESSAGES_LIST_LIMIT", "many")
+	cmd.Flags().Lookup("limit").Changed = false
+	if err := applyEnvFlagOverrides(cmd); err == nil || !strings.Contains(err.Error(), "SLK_MESSAGES_LIST_LIMIT") {
+		t.Fatalf("expected invalid env value error, got %v", err)
+	}
+}
+
+func TestFlagEnvOverridesReportsTargets(t *testing.T) {
+	t.Setenv("SLK_CHANNELS_LIST_TYPES", "private_channel")
+	t.Setenv("SLK_NOT_A_FLAG", "1")
+
+	found := map[string]EnvOverride{}
+	for _, o := range flagEnvOverrides() {
+		found[o.Name] = o
+	}
+	if got := found["SLK_CHANNELS_LIST_TYPES"].Flag; got != "slk channels list --types" {
+		t.Fatalf("unexpected target for SLK_CHANNELS_LIST_TYPES: %q", got)
+	}
+	if found["SLK_NOT_A_FLAG"].Error == "" {
+		t.Fatal("expected unknown SLK_ variable to be reported")
+	}
+}
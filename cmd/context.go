@@ -3,37 +3,53 @@ package cmd
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/kehao95/slack-agent-cli/internal/bots"
+	"github.com/kehao95/slack-agent-cli/internal/breaker"
 	"github.com/kehao95/slack-agent-cli/internal/cache"
 	"github.com/kehao95/slack-agent-cli/internal/channels"
 	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/emoji"
 	"github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/ratelimit"
 	"github.com/kehao95/slack-agent-cli/internal/slack"
 	"github.com/kehao95/slack-agent-cli/internal/usergroups"
 	"github.com/kehao95/slack-agent-cli/internal/users"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
 	"github.com/spf13/cobra"
 )
 
+var userIDPattern = regexp.MustCompile(`^[UW][A-Z0-9]{2,}$`)
+
 // CommandContext encapsulates common dependencies for command handlers.
 // It eliminates boilerplate setup code that was previously duplicated
 // across 20+ command handlers.
 type CommandContext struct {
-	Ctx               context.Context
-	Cancel            context.CancelFunc
-	Config            *config.Config
-	TeamID            string
-	AuthRole          string
-	AuthToken         string
-	AuthCookie        string
-	AuthUserID        string
-	AuthBotID         string
+	Ctx        context.Context
+	Cancel     context.CancelFunc
+	Config     *config.Config
+	TeamID     string
+	AuthRole   string
+	AuthToken  string
+	AuthCookie string
+	AuthUserID string
+	AuthBotID  string
+	// AuthScopes are the token's scopes from auth.test. They are empty for browser
+	// (xoxc) tokens and when SLACK_TEAM_ID skips auth.test.
+	AuthScopes        []string
 	Client            *slack.APIClient
 	CacheStore        *cache.Store
 	ChannelResolver   *channels.Resolver
 	UserResolver      *users.Resolver
 	UserGroupResolver *usergroups.Resolver
+	EmojiResolver     *emoji.Resolver
+	BotResolver       *bots.Resolver
 }
 
 // NewCommandContext initializes all common dependencies needed by commands.
@@ -71,6 +87,15 @@ func newCommandContext(cmd *cobra.Command, timeout time.Duration, noTimeout bool
 	if !validateConfig && strings.TrimSpace(tokenOverride) == "" {
 		return nil, errors.ConfigError("invalid config (%s): token is required", path)
 	}
+	if err := applyRetryPolicy(cfg); err != nil {
+		return nil, errors.ConfigError("invalid config (%s): %w", path, err)
+	}
+	if err := applyCircuitBreaker(cfg, path); err != nil {
+		return nil, errors.ConfigError("invalid config (%s): %w", path, err)
+	}
+	if err := applyBudgets(cmd, cfg, path); err != nil {
+		return nil, errors.ConfigError("invalid config (%s): %w", path, err)
+	}
 
 	apiToken, apiCookie, authRole, err := cfg.ActiveAuth()
 	if err != nil && validateConfig {
@@ -82,7 +107,15 @@ func newCommandContext(cmd *cobra.Command, timeout time.Duration, noTimeout bool
 		authRole = "override"
 	}
 
+	if tokenOverride == "" {
+		if warning := cookieExpiryWarning(cfg, time.Now()); warning != "" {
+			warnings.Add(warnings.CookieExpiring, "%s", warning)
+		}
+	}
+
 	client := slack.NewAuto(apiToken, apiCookie)
+	client.SetReadOnly(readOnlyMode(cmd, cfg))
+	client.SetHumanWritesBlocked(humanWritesBlocked(authRole, cfg))
 	var (
 		ctx    context.Context
 		cancel context.CancelFunc
@@ -109,6 +142,17 @@ func newCommandContext(cmd *cobra.Command, timeout time.Duration, noTimeout bool
 		cancel()
 		return nil, errors.ConfigError("failed to initialize cache: %w", err)
 	}
+	channelResolver := channels.NewCachedResolver(client, cacheStore)
+	channelResolver.SetIncludePrivate(grantsScope(authInfo.Scopes, "groups:read"))
+	userResolver := users.NewCachedResolver(client, cacheStore)
+	userResolver.SetExcludeDeactivated(cfg.ExcludeDeactivatedUsers)
+	if suggest, _ := cmd.Flags().GetBool("suggest"); suggest {
+		channelResolver.SetSuggestions(3)
+	}
+	if err := applyChannelLists(setupCtx, client, channelResolver, cfg); err != nil {
+		cancel()
+		return nil, err
+	}
 
 	return &CommandContext{
 		Ctx:               ctx,
@@ -120,31 +164,36 @@ func newCommandContext(cmd *cobra.Command, timeout time.Duration, noTimeout bool
 		AuthCookie:        apiCookie,
 		AuthUserID:        authInfo.UserID,
 		AuthBotID:         authInfo.BotID,
+		AuthScopes:        authInfo.Scopes,
 		Client:            client,
 		CacheStore:        cacheStore,
-		ChannelResolver:   channels.NewCachedResolver(client, cacheStore),
-		UserResolver:      users.NewCachedResolver(client, cacheStore),
+		ChannelResolver:   channelResolver,
+		UserResolver:      userResolver,
 		UserGroupResolver: usergroups.NewCachedResolver(client, cacheStore),
+		EmojiResolver:     emoji.NewCachedResolver(client, cacheStore),
+		BotResolver:       bots.NewCachedResolver(client, cacheStore),
 	}, nil
 }
 
-// NewCommandContextWithToken creates a minimal context with a provided token.
+// NewCommandContextWithToken creates a minimal context with a provided token and,
+// for xoxc- client tokens, its d cookie.
 // This is useful for verifying tokens before saving them to config.
 // It does not initialize cache or resolvers since those require team ID.
-func NewCommandContextWithToken(cmd *cobra.Command, timeout time.Duration, token string) (*CommandContext, error) {
+func NewCommandContextWithToken(cmd *cobra.Command, timeout time.Duration, token, cookie string) (*CommandContext, error) {
 	if timeout == 0 {
 		timeout = 30 * time.Second
 	}
 
-	client := slack.NewAuto(token, "")
+	client := slack.NewAuto(token, cookie)
 	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
 
 	return &CommandContext{
-		Ctx:       ctx,
-		Cancel:    cancel,
-		AuthRole:  "override",
-		AuthToken: token,
-		Client:    client,
+		Ctx:        ctx,
+		Cancel:     cancel,
+		AuthRole:   "override",
+		AuthToken:  token,
+		AuthCookie: cookie,
+		Client:     client,
 	}, nil
 }
 
@@ -162,6 +211,55 @@ func (c *CommandContext) ResolveChannel(input string) (string, error) {
 	return c.ChannelResolver.ResolveID(c.Ctx, input)
 }
 
+// ResolveUser converts @name, a handle, or a user ID to a user ID using the user cache.
+// A display name shared by several users fails with the candidates listed.
+func (c *CommandContext) ResolveUser(input string) (string, error) {
+	trimmed := strings.TrimSpace(input)
+	if !strings.HasPrefix(trimmed, "@") && userIDPattern.MatchString(trimmed) {
+		return trimmed, nil
+	}
+	u, err := c.UserResolver.ResolveName(c.Ctx, trimmed)
+	if err != nil {
+		return "", err
+	}
+	return u.ID, nil
+}
+
+// ResolveDM returns the ID of the direct message conversation with a user given as
+// @name or user ID, opening it if needed.
+func (c *CommandContext) ResolveDM(input string) (string, error) {
+	userID, err := c.ResolveUser(input)
+	if err != nil {
+		return "", err
+	}
+	return c.Client.OpenDM(c.Ctx, userID)
+}
+
+// ResolveEmoji converts an emoji name, :shortcode:, or Unicode emoji to the name
+// Slack's reactions API expects, following custom emoji aliases.
+func (c *CommandContext) ResolveEmoji(input string) (string, error) {
+	name, err := c.EmojiResolver.Resolve(c.Ctx, input)
+	if err != nil {
+		return "", errors.ConfigError("invalid --emoji: %v", err)
+	}
+	return name, nil
+}
+
+// ResolveMention looks up a plain @user or #channel name for mrkdwn.LinkMentions.
+func (c *CommandContext) ResolveMention(kind byte, name string) (string, bool) {
+	switch kind {
+	case '@':
+		if u, ok := c.UserResolver.FindByName(c.Ctx, name); ok {
+			return u.ID, true
+		}
+	case '#':
+		if id, err := c.ChannelResolver.ResolveID(c.Ctx, "#"+name); err == nil {
+			return id, true
+		}
+	}
+	return "", false
+}
+
 // EnsureAuthIdentity fills in the active Slack user/bot IDs when the context was created with
 // SLACK_TEAM_ID and skipped auth.test during setup.
 func (c *CommandContext) EnsureAuthIdentity(ctx context.Context) error {
@@ -205,6 +303,60 @@ func authInfoForRole(authInfo *slack.AuthTestResponse, role string) *slack.AuthT
 	return authInfo
 }
 
+// readOnlyMode reports whether --read-only or read_only in config blocks changes to
+// the workspace.
+func readOnlyMode(cmd *cobra.Command, cfg *config.Config) bool {
+	if readOnly, _ := cmd.Flags().GetBool("read-only"); readOnly {
+		return true
+	}
+	return cfg != nil && cfg.ReadOnly
+}
+
+// grantsScope reports whether scopes include scope. Tokens that report no scopes,
+// such as browser tokens, are assumed to have it, as in requireScopes.
+func grantsScope(scopes []string, scope string) bool {
+	return len(scopes) == 0 || slices.Contains(scopes, scope)
+}
+
+// humanWritesBlocked reports whether changes must be refused because the token is a
+// user token, which acts as a person, and the config has not set
+// allow_user_impersonation. Bot tokens and explicit token overrides are not affected.
+func humanWritesBlocked(role string, cfg *config.Config) bool {
+	if strings.ToLower(strings.TrimSpace(role)) != config.RoleUser {
+		return false
+	}
+	return cfg == nil || !cfg.AllowUserImpersonation
+}
+
+// applyChannelLists resolves allowed_channels and denied_channels to IDs and installs
+// them on the client, so every channel call is checked however it was reached.
+func applyChannelLists(ctx context.Context, client *slack.APIClient, resolver *channels.Resolver, cfg *config.Config) error {
+	if cfg == nil || (len(cfg.AllowedChannels) == 0 && len(cfg.DeniedChannels) == 0) {
+		return nil
+	}
+	resolve := func(key string, entries []string) ([]string, error) {
+		ids := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			id, err := resolver.ResolveID(ctx, entry)
+			if err != nil {
+				return nil, errors.ConfigError("%s: cannot resolve %q: %v", key, entry, err)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+	allowed, err := resolve("allowed_channels", cfg.AllowedChannels)
+	if err != nil {
+		return err
+	}
+	denied, err := resolve("denied_channels", cfg.DeniedChannels)
+	if err != nil {
+		return err
+	}
+	client.SetChannelLists(allowed, denied)
+	return nil
+}
+
 func sanitizeRuntimeConfigForRole(cfg *config.Config, role string) {
 	if cfg == nil {
 		return
@@ -241,3 +393,65 @@ func resolveAuthInfo(ctx context.Context, client *slack.APIClient) (*slack.AuthT
 	}
 	return resp, nil
 }
+
+// applyRetryPolicy installs the configured retries on the shared Slack transport.
+func applyRetryPolicy(cfg *config.Config) error {
+	settings, err := cfg.RetrySettings()
+	if err != nil {
+		return err
+	}
+	policy := slack.RetryPolicy{
+		Max:         settings.Max,
+		BaseBackoff: settings.BaseBackoff,
+		MaxBackoff:  settings.MaxBackoff,
+	}
+	for _, class := range settings.RetryOn {
+		switch class {
+		case config.RetryOnRateLimit:
+			policy.RateLimit = true
+		case config.RetryOnNetwork:
+			policy.Network = true
+		case config.RetryOnServer:
+			policy.Server = true
+		}
+	}
+	slack.SetRetryPolicy(policy)
+	return nil
+}
+
+// applyCircuitBreaker installs the per-method circuit breakers on the shared Slack
+// transport, with state kept next to the config file.
+func applyCircuitBreaker(cfg *config.Config, configPath string) error {
+	b, err := loadCircuitBreaker(cfg, configPath)
+	if err != nil {
+		return err
+	}
+	slack.SetCircuitBreaker(b)
+	return nil
+}
+
+// applyBudgets installs the budgets in config on the shared Slack transport, with call
+// times kept next to the config file. --no-wait makes calls over budget fail at once.
+func applyBudgets(cmd *cobra.Command, cfg *config.Config, configPath string) error {
+	settings, err := cfg.BudgetSettings()
+	if err != nil || len(settings) == 0 {
+		slack.SetBudgets(nil)
+		return err
+	}
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	b := ratelimit.NewBudgets(filepath.Join(filepath.Dir(configPath), "budgets.json"), settings, noWait)
+	b.OnWait = func(group string, d time.Duration) {
+		output.Statusf("%s budget (%s) used up; waiting %s\n", group, settings[group], d.Round(time.Second))
+	}
+	slack.SetBudgets(b)
+	return nil
+}
+
+// loadCircuitBreaker reads the breaker state for the config at configPath.
+func loadCircuitBreaker(cfg *config.Config, configPath string) (*breaker.Breaker, error) {
+	threshold, cooldown, err := cfg.BreakerSettings()
+	if err != nil {
+		return nil, err
+	}
+	return breaker.Load(filepath.Join(filepath.Dir(configPath), "breakers.json"), threshold, cooldown)
+}
@@ -131,6 +131,37 @@ func TestSanitizeRuntimeContextForUserRole(t *testing.T) {
 	}
 }
 
+func TestHumanWritesBlocked(t *testing.T) {
+	tests := []struct {
+		role string
+		cfg  *config.Config
+		want bool
+	}{
+		{config.RoleUser, &config.Config{}, true},
+		{config.RoleUser, nil, true},
+		{config.RoleUser, &config.Config{AllowUserImpersonation: true}, false},
+		{config.RoleBot, &config.Config{}, false},
+		{"override", &config.Config{}, false},
+	}
+	for _, tt := range tests {
+		if got := humanWritesBlocked(tt.role, tt.cfg); got != tt.want {
+			t.Errorf("humanWritesBlocked(%q, %+v) = %v, want %v", tt.role, tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestGrantsScope(t *testing.T) {
+	if !grantsScope(nil, "groups:read") {
+		t.Error("tokens that report no scopes should be assumed to have the scope")
+	}
+	if !grantsScope([]string{"channels:read", "groups:read"}, "groups:read") {
+		t.Error("granted scope not found")
+	}
+	if grantsScope([]string{"channels:read"}, "groups:read") {
+		t.Error("missing scope reported as granted")
+	}
+}
+
 func TestSanitizeRuntimeContextForBotRole(t *testing.T) {
 	cmdCtx := &CommandContext{
 		AuthRole:   config.RoleBot,
@@ -337,12 +368,12 @@ func TestCommandContext_ResolveChannel(t *testing.T) {
 	defer cmdCtx.Close()
 
 	// Test with channel ID (should pass through)
-	channelID, err := cmdCtx.ResolveChannel("C123ABC")
+	channelID, err := cmdCtx.ResolveChannel("C0123ABCD")
 	if err != nil {
 		t.Errorf("ResolveChannel returned error for valid ID: %v", err)
 	}
-	if channelID != "C123ABC" {
-		t.Errorf("expected 'C123ABC', got '%s'", channelID)
+	if channelID != "C0123ABCD" {
+		t.Errorf("expected 'C0123ABCD', got '%s'", channelID)
 	}
 
 	// Test with empty input (should error)
@@ -5,12 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/kehao95/slack-agent-cli/internal/config"
 	"github.com/kehao95/slack-agent-cli/internal/eventstore"
+	"github.com/kehao95/slack-agent-cli/internal/output"
 	"github.com/kehao95/slack-agent-cli/internal/slack"
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
@@ -28,12 +28,18 @@ var daemonRunCmd = &cobra.Command{
 	Short: "Run the event cache daemon",
 	Long: `Open a Slack Socket Mode connection and append matching events to the local SQLite cache.
 
-The command runs in the foreground by design so it can be supervised by launchd, systemd, tmux, or an agent runner.`,
+The command runs in the foreground by design so it can be supervised by launchd, systemd, tmux, or an agent runner.
+While running it also delivers pending 'slk outbox' messages every --outbox-interval
+and runs due 'slk schedule' messages every --schedule-interval.
+Send SIGHUP to re-read the config file and --filter-file without reconnecting.`,
 	Example: `  # Cache all visible events for 24h
   SLACK_CLI_ROLE=bot slk daemon run
 
   # Cache only a test channel and skip the bot's own messages
-  SLACK_CLI_ROLE=bot slk daemon run --channel "#_bot-testing" --exclude-self`,
+  SLACK_CLI_ROLE=bot slk daemon run --channel "#_bot-testing" --exclude-self
+
+  # Load filters from a file; edit it and run: systemctl reload slk-daemon (or kill -HUP)
+  SLACK_CLI_ROLE=bot slk daemon run --filter-file /etc/slk/filters.json`,
 	RunE: runDaemonRun,
 }
 
@@ -54,7 +60,11 @@ func init() {
 	daemonRunCmd.Flags().Bool("threads-only", false, "Only cache thread-related message events")
 	daemonRunCmd.Flags().Bool("exclude-self", false, "Do not cache events produced by the active auth identity")
 	daemonRunCmd.Flags().Bool("raw", false, "Store the raw Slack payload for each event")
+	daemonRunCmd.Flags().String("filter-file", "", "JSON file of filter flags (e.g. {\"channel\": \"#support\"}); re-read on SIGHUP")
 	daemonRunCmd.Flags().Duration("retention", 24*time.Hour, "How long to retain cached events")
+	daemonRunCmd.Flags().Duration("outbox-interval", 30*time.Second, "How often to deliver pending outbox messages (0 disables)")
+	daemonRunCmd.Flags().Duration("schedule-interval", 30*time.Second, "How often to run due schedule messages (0 disables)")
+	addSubtypeFlags(daemonRunCmd, "cached message events")
 }
 
 func runDaemonRun(cmd *cobra.Command, args []string) error {
@@ -82,6 +92,11 @@ func runDaemonRun(cmd *cobra.Command, args []string) error {
 	}
 	defer store.Close()
 
+	reloader, err := newLiveReloader(cmd)
+	if err != nil {
+		return err
+	}
+	defer reloader.Stop()
 	filter, err := buildDaemonStreamFilter(cmd, cmdCtx)
 	if err != nil {
 		return err
@@ -92,8 +107,22 @@ func runDaemonRun(cmd *cobra.Command, args []string) error {
 		retention = 24 * time.Hour
 	}
 
-	fmt.Fprintf(os.Stderr, "Caching Slack events in %s (retention %s)\n", store.Path(), retention)
-	return runEventCacheLoop(cmd, cmdCtx, store, filter, includeRaw, retention)
+	outboxInterval, _ := cmd.Flags().GetDuration("outbox-interval")
+	pending, err := newDaemonOutbox(configPath)
+	if err != nil {
+		return err
+	}
+	defer pending.Close()
+
+	scheduleInterval, _ := cmd.Flags().GetDuration("schedule-interval")
+	schedules, err := newDaemonSchedules(configPath)
+	if err != nil {
+		return err
+	}
+	defer schedules.Close()
+
+	output.Statusf("Caching Slack events in %s (retention %s)\n", store.Path(), retention)
+	return runEventCacheLoop(cmd, cmdCtx, store, filter, reloader, includeRaw, retention, pending, outboxInterval, schedules, scheduleInterval)
 }
 
 func runDaemonStatus(cmd *cobra.Command, args []string) error {
@@ -162,20 +191,37 @@ func buildDaemonStreamFilter(cmd *cobra.Command, cmdCtx *CommandContext) (stream
 	threadTS, _ := cmd.Flags().GetString("thread")
 	threadsOnly, _ := cmd.Flags().GetBool("threads-only")
 	excludeSelf, _ := cmd.Flags().GetBool("exclude-self")
+	subtypes, err := parseSubtypeFlags(cmd)
+	if err != nil {
+		return streamFilter{}, err
+	}
 	return streamFilter{
 		ChannelID:         channelID,
 		ConversationTypes: conversationTypes,
 		ThreadTS:          strings.TrimSpace(threadTS),
 		ThreadsOnly:       threadsOnly,
 		ExcludeSelf:       excludeSelf,
+		Subtypes:          subtypes,
 	}, nil
 }
 
-func runEventCacheLoop(cmd *cobra.Command, cmdCtx *CommandContext, store *eventstore.Store, filter streamFilter, includeRaw bool, retention time.Duration) error {
+func runEventCacheLoop(cmd *cobra.Command, cmdCtx *CommandContext, store *eventstore.Store, filter streamFilter, reloader *liveReloader, includeRaw bool, retention time.Duration, pending *daemonOutbox, outboxInterval time.Duration, schedules *daemonSchedules, scheduleInterval time.Duration) error {
 	normalizer := newEventNormalizer(cmdCtx)
 	socketClient := slack.NewSocketModeClient(cmdCtx.AuthToken, cmdCtx.AuthCookie, cmdCtx.Config.AppToken)
 	pruneTicker := time.NewTicker(time.Minute)
 	defer pruneTicker.Stop()
+	var outboxTick <-chan time.Time
+	if outboxInterval > 0 {
+		outboxTicker := time.NewTicker(outboxInterval)
+		defer outboxTicker.Stop()
+		outboxTick = outboxTicker.C
+	}
+	var scheduleTick <-chan time.Time
+	if scheduleInterval > 0 {
+		scheduleTicker := time.NewTicker(scheduleInterval)
+		defer scheduleTicker.Stop()
+		scheduleTick = scheduleTicker.C
+	}
 
 	if _, err := store.PruneOlderThan(cmdCtx.Ctx, time.Now().Add(-retention)); err != nil {
 		return err
@@ -190,10 +236,18 @@ func runEventCacheLoop(cmd *cobra.Command, cmdCtx *CommandContext, store *events
 		select {
 		case <-cmdCtx.Ctx.Done():
 			return nil
+		case <-reloader.C():
+			filter = reloadStreamFilter(cmdCtx, reloader, func() (streamFilter, error) {
+				return buildDaemonStreamFilter(cmd, cmdCtx)
+			}, filter)
 		case <-pruneTicker.C:
 			if _, err := store.PruneOlderThan(cmdCtx.Ctx, time.Now().Add(-retention)); err != nil {
-				fmt.Fprintf(os.Stderr, "failed to prune event cache: %v\n", err)
+				fmt.Fprintf(output.Stderr(), "failed to prune event cache: %v\n", err)
 			}
+		case <-outboxTick:
+			pending.Flush(cmdCtx)
+		case <-scheduleTick:
+			schedules.Tick(cmd, cmdCtx)
 		case err := <-errCh:
 			if err == nil || errors.Is(err, context.Canceled) {
 				return nil
@@ -205,11 +259,11 @@ func runEventCacheLoop(cmd *cobra.Command, cmdCtx *CommandContext, store *events
 			}
 			switch evt.Type {
 			case socketmode.EventTypeConnecting:
-				fmt.Fprintln(os.Stderr, "Connecting to Slack Socket Mode...")
+				output.Statusf("Connecting to Slack Socket Mode...\n")
 			case socketmode.EventTypeConnected:
-				fmt.Fprintln(os.Stderr, "Connected to Slack Socket Mode.")
+				output.Statusf("Connected to Slack Socket Mode.\n")
 			case socketmode.EventTypeConnectionError:
-				fmt.Fprintln(os.Stderr, "Slack Socket Mode connection error. Waiting for reconnect...")
+				output.Statusf("Slack Socket Mode connection error. Waiting for reconnect...\n")
 			case socketmode.EventTypeEventsAPI:
 				if evt.Request != nil {
 					socketClient.Ack(*evt.Request)
@@ -220,7 +274,7 @@ func runEventCacheLoop(cmd *cobra.Command, cmdCtx *CommandContext, store *events
 				}
 				normalized, emit, err := normalizer.Normalize(eventsAPIEvent, evt.Request, includeRaw)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "failed to normalize event: %v\n", err)
+					fmt.Fprintf(output.Stderr(), "failed to normalize event: %v\n", err)
 					continue
 				}
 				if !emit || !filter.Match(normalized) {
@@ -228,10 +282,10 @@ func runEventCacheLoop(cmd *cobra.Command, cmdCtx *CommandContext, store *events
 				}
 				cursor, err := store.Insert(cmdCtx.Ctx, streamEventToStore(normalized))
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "failed to cache event: %v\n", err)
+					fmt.Fprintf(output.Stderr(), "failed to cache event: %v\n", err)
 					continue
 				}
-				fmt.Fprintf(os.Stderr, "cached event cursor=%d type=%s channel=%s ts=%s\n", cursor, normalized.Type, normalized.ChannelID, normalized.TS)
+				output.Statusf("cached event cursor=%d type=%s channel=%s ts=%s\n", cursor, normalized.Type, normalized.ChannelID, normalized.TS)
 			}
 		}
 	}
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/dms"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/idempotency"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var dmCmd = &cobra.Command{
+	Use:   "dm",
+	Short: "Direct message operations",
+	Long:  "Open, close, and list direct message conversations, and start group DMs.",
+}
+
+var dmOpenCmd = &cobra.Command{
+	Use:   "open",
+	Short: "Open a DM with a user",
+	Long: `Open the direct message conversation with a user (conversations.open) and print
+its channel ID. Opening a DM that already exists returns the same ID, so this is
+safe to call before every send. messages send --channel @user does the same.
+
+Output (JSON):
+  {
+    "ok": true,
+    "user": "@alice",
+    "user_id": "U123ABC",
+    "channel_id": "D123ABC",
+    "already_open": true
+  }
+
+Required Scopes:
+  - im:write`,
+	Example: `  # Get the DM channel ID for a user
+  slk dm open --user @alice | jq -r .channel_id`,
+	Args: cobra.NoArgs,
+	RunE: runDMOpen,
+}
+
+var dmCloseCmd = &cobra.Command{
+	Use:   "close",
+	Short: "Close a DM",
+	Long: `Close a direct message conversation (conversations.close), removing it from the
+sidebar. The history is kept, and the DM reopens when either person posts to it.
+
+Output (JSON):
+  {
+    "ok": true,
+    "user": "@alice",
+    "user_id": "U123ABC",
+    "channel_id": "D123ABC",
+    "already_closed": false
+  }
+
+Required Scopes:
+  - im:write`,
+	Example: `  # Close the DM with a user
+  slk dm close --user @alice
+
+  # Close a DM by channel ID
+  slk dm close --channel D123ABC`,
+	Args: cobra.NoArgs,
+	RunE: runDMClose,
+}
+
+var dmCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Open a group DM and post its first message",
+	Long: `Open the group DM (MPIM) between you and up to 8 people and post the first
+message in one step, returning the conversation ID for follow-ups. Slack keeps one
+group DM per set of people, so running this again with the same users posts to the
+same conversation (already_open is true). With one user it opens the plain DM.
+
+The message is checked against quiet_hours (without queueing) and masked by the
+redaction patterns before it is sent. With --idempotency-key, a retry returns the
+original result instead of posting again.
+
+Output (JSON):
+  {
+    "ok": true,
+    "users": ["@alice", "@bob", "@carol"],
+    "user_ids": ["U123ABC", "U234BCD", "U345CDE"],
+    "channel_id": "C0123MPIM",
+    "already_open": false,
+    "ts": "1705312365.000100",
+    "text": "Kicking off incident bridge"
+  }
+
+Required Scopes:
+  - mpim:write (im:write for a single user)
+  - chat:write
+  - users:read to resolve @names`,
+	Example: `  # Incident bridge with the on-call trio
+  slk dm create --users "@alice,@bob,@carol" --text "Kicking off incident bridge"
+
+  # Keep the ID for follow-ups
+  bridge=$(slk dm create --users "@alice,@bob" --text "Paging you both" | jq -r .channel_id)
+  slk messages send --channel "$bridge" --text "Dashboard: https://status.example.com"`,
+	Args: cobra.NoArgs,
+	RunE: runDMCreate,
+}
+
+var dmListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List open DMs with their latest message",
+	Long: `List the user's open direct message conversations, most recently active first,
+with the latest message of each.
+
+Slack lists every DM a user has ever had; each one's conversations.info decides
+whether it is open, so closed DMs are skipped unless --include-closed is set.
+Lookups run --concurrency DMs at a time, costing two or three API calls per DM.
+
+Output (JSON):
+  {
+    "ok": true,
+    "dms": [
+      {
+        "channel_id": "D123ABC",
+        "user_id": "U123ABC",
+        "user": "@alice",
+        "is_open": true,
+        "activity": {
+          "last_ts": "1705312365.000100",
+          "last_activity": "2024-01-15T10:52:45Z",
+          "last_user": "U123ABC",
+          "preview": "Can you look at the failing deploy?",
+          "last_read": "1705300000.000200",
+          "unread_count": 1
+        }
+      }
+    ]
+  }
+
+The activity object is the one channels list --with-activity returns. Group DMs
+(--include-groups) have "name" and "is_group": true instead of a user.
+
+Required Scopes:
+  - im:read, plus mpim:read for --include-groups
+  - im:history, plus mpim:history for --include-groups`,
+	Example: `  # Open DMs with previews
+  slk dm list --human
+
+  # DMs with unread messages
+  slk dm list | jq '.dms[] | select(.activity.unread_count > 0) | .user'`,
+	Args: cobra.NoArgs,
+	RunE: runDMList,
+}
+
+func init() {
+	rootCmd.AddCommand(dmCmd)
+	dmCmd.AddCommand(dmOpenCmd)
+	dmCmd.AddCommand(dmCloseCmd)
+	dmCmd.AddCommand(dmCreateCmd)
+	dmCmd.AddCommand(dmListCmd)
+
+	dmOpenCmd.Flags().StringP("user", "u", "", "User as @name or ID (required)")
+	dmOpenCmd.MarkFlagRequired("user")
+
+	dmCloseCmd.Flags().StringP("user", "u", "", "User as @name or ID")
+	dmCloseCmd.Flags().StringP("channel", "c", "", "DM channel ID")
+	dmCloseCmd.MarkFlagsOneRequired("user", "channel")
+	dmCloseCmd.MarkFlagsMutuallyExclusive("user", "channel")
+
+	dmCreateCmd.Flags().String("users", "", "Comma-separated users as @name or ID, up to 8 (required)")
+	dmCreateCmd.Flags().StringP("text", "t", "", "First message (- reads stdin) (required)")
+	dmCreateCmd.Flags().String("idempotency-key", "", "Post at most once per key; repeating a key returns the original result")
+	dmCreateCmd.MarkFlagRequired("users")
+	dmCreateCmd.MarkFlagRequired("text")
+	addQuietHoursFlag(dmCreateCmd)
+	addRedactFlag(dmCreateCmd)
+
+	dmListCmd.Flags().Bool("include-closed", false, "Also list DMs that are not open")
+	dmListCmd.Flags().Bool("include-groups", false, "Also list group DMs (requires mpim:read)")
+	dmListCmd.Flags().Int("concurrency", 4, "DMs whose activity is fetched at once")
+}
+
+func runDMOpen(cmd *cobra.Command, args []string) error {
+	userInput, _ := cmd.Flags().GetString("user")
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	userID, err := cmdCtx.ResolveUser(userInput)
+	if err != nil {
+		return err
+	}
+	channelID, alreadyOpen, err := cmdCtx.Client.OpenDMConversation(cmdCtx.Ctx, userID)
+	if err != nil {
+		return err
+	}
+	return output.Print(cmd, dms.OpenResult{
+		OK:          true,
+		User:        "@" + cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, userID),
+		UserID:      userID,
+		ChannelID:   channelID,
+		AlreadyOpen: alreadyOpen,
+	})
+}
+
+func runDMClose(cmd *cobra.Command, args []string) error {
+	userInput, _ := cmd.Flags().GetString("user")
+	channelID, _ := cmd.Flags().GetString("channel")
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	result := dms.CloseResult{OK: true, ChannelID: channelID}
+	if userInput != "" {
+		if result.UserID, err = cmdCtx.ResolveUser(userInput); err != nil {
+			return err
+		}
+		result.User = "@" + cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, result.UserID)
+		// conversations.open returns the existing DM's ID without notifying anyone.
+		if result.ChannelID, err = cmdCtx.Client.OpenDM(cmdCtx.Ctx, result.UserID); err != nil {
+			return err
+		}
+	}
+	if result.AlreadyClosed, err = cmdCtx.Client.CloseDM(cmdCtx.Ctx, result.ChannelID); err != nil {
+		return err
+	}
+	return output.Print(cmd, result)
+}
+
+// maxGroupDMUsers is the most people conversations.open accepts besides the caller.
+const maxGroupDMUsers = 8
+
+func runDMCreate(cmd *cobra.Command, args []string) error {
+	usersFlag, _ := cmd.Flags().GetString("users")
+	text, _ := cmd.Flags().GetString("text")
+	idempotencyKey, _ := cmd.Flags().GetString("idempotency-key")
+
+	inputs := splitList(usersFlag)
+	if len(inputs) == 0 {
+		return cerrors.ConfigError("--users is empty")
+	}
+	var err error
+	if text == "-" {
+		if text, err = readRequiredStdin("text"); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(text) == "" {
+		return cerrors.ConfigError("--text must not be empty")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	return createGroupDM(cmd, cmdCtx, inputs, text, idempotencyKey)
+}
+
+// createGroupDM opens the group DM with inputs and posts text to it. The post is checked
+// against the write policies first, so a refused post never leaves a new group DM behind.
+func createGroupDM(cmd *cobra.Command, cmdCtx *CommandContext, inputs []string, text, idempotencyKey string) error {
+	if err := cmdCtx.Client.CheckWrite("chat.postMessage", ""); err != nil {
+		return err
+	}
+	text, _, redactions, err := redactMessage(cmd, cmdCtx.Config, text, "")
+	if err != nil {
+		return err
+	}
+	quiet, err := checkQuietHours(cmd, cmdCtx, "", false)
+	if err != nil {
+		return err
+	}
+
+	result := dms.CreateResult{OK: true, Text: text}
+	for _, input := range inputs {
+		userID, err := cmdCtx.ResolveUser(input)
+		if err != nil {
+			return err
+		}
+		// The caller is always in the conversation; listing them again is an error
+		// to conversations.open.
+		if userID == cmdCtx.AuthUserID || slices.Contains(result.UserIDs, userID) {
+			continue
+		}
+		result.UserIDs = append(result.UserIDs, userID)
+		result.Users = append(result.Users, "@"+cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, userID))
+	}
+	switch {
+	case len(result.UserIDs) == 0:
+		return cerrors.ConfigError("--users lists only yourself")
+	case len(result.UserIDs) > maxGroupDMUsers:
+		return cerrors.ConfigError("--users lists %d people; a group DM holds at most %d besides you", len(result.UserIDs), maxGroupDMUsers)
+	}
+
+	if result.ChannelID, result.AlreadyOpen, err = cmdCtx.Client.OpenGroupDM(cmdCtx.Ctx, result.UserIDs); err != nil {
+		return err
+	}
+
+	guard, replay, err := reserveSend(cmdCtx, idempotencyKey, idempotency.Fingerprint(result.ChannelID, text))
+	if err != nil {
+		return err
+	}
+	if replay != nil {
+		return output.Print(cmd, replay)
+	}
+	defer guard.Close()
+
+	posted, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, result.ChannelID, slack.PostMessageOptions{
+		Text:   text,
+		AsUser: cmdCtx.AuthRole == config.RoleUser,
+	})
+	if err != nil {
+		guard.Abandon(cmdCtx, err)
+		return err
+	}
+	result.TS = posted.Timestamp
+	guard.Complete(cmdCtx, result)
+	return output.Print(cmd, withRedactions(withPolicy(result, quiet), redactions))
+}
+
+func runDMList(cmd *cobra.Command, args []string) error {
+	includeClosed, _ := cmd.Flags().GetBool("include-closed")
+	includeGroups, _ := cmd.Flags().GetBool("include-groups")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency <= 0 {
+		return fmt.Errorf("--concurrency must be positive")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	result, err := dms.List(cmdCtx.Ctx, cmdCtx.Client, dms.ListParams{
+		IncludeClosed: includeClosed,
+		IncludeGroups: includeGroups,
+		Concurrency:   concurrency,
+	})
+	if err != nil {
+		return err
+	}
+	for i := range result.DMs {
+		if id := result.DMs[i].UserID; id != "" {
+			result.DMs[i].User = "@" + cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, id)
+		}
+	}
+	return output.Print(cmd, result)
+}
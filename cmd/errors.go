@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var errorsCmd = &cobra.Command{
+	Use:   "errors",
+	Short: "Document the CLI's stable error codes",
+	Long:  "Document the stable error codes slk attaches to every failure.",
+}
+
+var errorsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stable error codes with remediation",
+	Long: `List every stable error code, the exit code it comes with, and how to fix it.
+
+Every failing command prints its code before the message, so agents can branch
+on the code instead of parsing English text:
+
+  Error [SLK_E_CHANNEL_NOT_FOUND]: channel not found: #deplyos
+
+Codes are more specific than exit codes: SLK_E_CHANNEL_NOT_FOUND and
+SLK_E_USER_NOT_FOUND both exit with 7. A code never changes meaning once released.
+
+Output (JSON):
+  {"errors": [{"code": "SLK_E_CHANNEL_NOT_FOUND", "exit_code": 7,
+    "description": "...", "remediation": "..."}]}`,
+	Example: `  slk errors list
+  slk errors list --exit-code 8 --human`,
+	Args: cobra.NoArgs,
+	RunE: runErrorsList,
+}
+
+func init() {
+	rootCmd.AddCommand(errorsCmd)
+	errorsCmd.AddCommand(errorsListCmd)
+	errorsListCmd.Flags().Int("exit-code", -1, "only list codes that exit with this status")
+}
+
+// ErrorsListResult is the output of errors list.
+type ErrorsListResult struct {
+	Errors []cerrors.CatalogEntry `json:"errors"`
+}
+
+// Lines implements output.Printable.
+func (r ErrorsListResult) Lines() []string {
+	lines := []string{fmt.Sprintf("%-28s %-4s %s", "CODE", "EXIT", "DESCRIPTION")}
+	for _, entry := range r.Errors {
+		lines = append(lines,
+			fmt.Sprintf("%-28s %-4d %s", entry.Code, entry.ExitCode, entry.Description),
+			fmt.Sprintf("%-33s Fix: %s", "", entry.Remediation))
+	}
+	return lines
+}
+
+func runErrorsList(cmd *cobra.Command, args []string) error {
+	exitCode, _ := cmd.Flags().GetInt("exit-code")
+	result := ErrorsListResult{Errors: []cerrors.CatalogEntry{}}
+	for _, entry := range cerrors.Catalog() {
+		if exitCode < 0 || entry.ExitCode == exitCode {
+			result.Errors = append(result.Errors, entry)
+		}
+	}
+	return output.Print(cmd, result)
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/dedupe"
 	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
 	"github.com/kehao95/slack-agent-cli/internal/slack"
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
@@ -32,7 +34,18 @@ var eventsStreamCmd = &cobra.Command{
 	Long: `Open a Socket Mode connection and emit one JSON event per line on stdout.
 
 This command is blocking by design, similar to tail -f.
-Connection status and reconnect messages are written to stderr.`,
+Connection status and reconnect messages are written to stderr.
+
+Send SIGHUP to re-read the config file and --filter-file without reconnecting.
+
+Slack delivers an event again when it is not acknowledged in time or the connection
+drops; such deliveries carry retry_attempt and retry_reason, the Socket Mode
+counterparts of the Events API's X-Slack-Retry-Num and X-Slack-Retry-Reason headers.
+Events whose event_id was already emitted within --dedupe-window are dropped, so each
+event reaches downstream handlers once. The window is kept in events-seen.json in the
+team cache directory, so it survives restarts; --dedupe-window 0 turns it off. An
+event is remembered once it has been written, so one that fails to write is accepted
+again when Slack redelivers it.`,
 	Example: `  # Stream all visible message events
   slk events stream
 
@@ -48,9 +61,24 @@ Connection status and reconnect messages are written to stderr.`,
   # Stream multiple event types
   slk events stream --channel "#support" --event-type message,reaction_added
 
+  # Include only ordinary messages and channel joins
+  slk events stream --event-type message --subtypes none,channel_join
+
+  # Drop bot chatter
+  slk events stream --exclude-subtypes bot_message
+
+  # Keep filters in a file and adjust them live with: kill -HUP <pid>
+  slk events stream --filter-file ~/.config/slack-cli/stream-filters.json
+
   # Stream one thread
   slk events stream --channel "#support" --thread "1705312365.000100"
 
+  # Collapse emoji storms into one reaction_summary per message and emoji
+  slk events stream --event-type reaction_added,reaction_removed --aggregate 5s
+
+  # Remember delivered events for an hour across restarts
+  slk events stream --dedupe-window 1h
+
   # Include raw Slack payloads for debugging
   slk events stream --raw`,
 	RunE: runEventsStream,
@@ -61,6 +89,7 @@ func init() {
 	eventsCmd.AddCommand(eventsStreamCmd)
 
 	addEventsStreamFlags(eventsStreamCmd)
+	eventsStreamCmd.Flags().Duration("dedupe-window", 10*time.Minute, "Drop events whose event_id was emitted within this window (0 disables)")
 }
 
 func addEventsStreamFlags(cmd *cobra.Command) {
@@ -69,9 +98,12 @@ func addEventsStreamFlags(cmd *cobra.Command) {
 	cmd.Flags().String("event-type", "", "Restrict to Slack event types, comma-separated (for example message,reaction_added)")
 	cmd.Flags().String("thread", "", "Restrict to a specific thread_ts")
 	cmd.Flags().StringP("file", "f", "", "Also append each matching event to this file (open/write/close per event)")
+	cmd.Flags().String("filter-file", "", "JSON file of filter flags (e.g. {\"channel\": \"#support\"}); re-read on SIGHUP")
 	cmd.Flags().Bool("threads-only", false, "Only emit thread-related message events")
 	cmd.Flags().Bool("exclude-self", false, "Exclude events produced by the active auth identity")
 	cmd.Flags().Bool("raw", false, "Include the raw Slack payload in each emitted event")
+	cmd.Flags().Duration("aggregate", 0, "Coalesce bursts of the same reaction on the same message within this window (e.g. 5s) into one reaction_summary event")
+	addSubtypeFlags(cmd, "message events")
 }
 
 func loadConfigForEvents() (*config.Config, string, string, string, string, error) {
@@ -122,6 +154,10 @@ func buildEventsStreamFilter(cmd *cobra.Command, resolveChannel func(string) (st
 	if err := validateThreadsOnlyEventTypes(threadsOnly, eventTypes); err != nil {
 		return streamFilter{}, err
 	}
+	subtypes, err := parseSubtypeFlags(cmd)
+	if err != nil {
+		return streamFilter{}, err
+	}
 
 	return streamFilter{
 		ChannelID:         channelID,
@@ -130,13 +166,27 @@ func buildEventsStreamFilter(cmd *cobra.Command, resolveChannel func(string) (st
 		ThreadTS:          strings.TrimSpace(threadTS),
 		ThreadsOnly:       threadsOnly,
 		ExcludeSelf:       excludeSelf,
+		Subtypes:          subtypes,
 	}, nil
 }
 
 func runEventsStream(cmd *cobra.Command, args []string) error {
+	reloader, err := newLiveReloader(cmd)
+	if err != nil {
+		return err
+	}
+	defer reloader.Stop()
 	if _, err := buildEventsStreamFilter(cmd, nil); err != nil {
 		return err
 	}
+	aggregateWindow, _ := cmd.Flags().GetDuration("aggregate")
+	if err := validateAggregateWindow(aggregateWindow); err != nil {
+		return err
+	}
+	dedupeWindow, _ := cmd.Flags().GetDuration("dedupe-window")
+	if dedupeWindow < 0 {
+		return cerrors.ConfigError("--dedupe-window must not be negative")
+	}
 
 	cfg, token, cookie, role, _, err := loadConfigForEvents()
 	if err != nil {
@@ -171,6 +221,46 @@ func runEventsStream(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	var seen *dedupe.Window
+	if dedupeWindow > 0 {
+		if seen, err = dedupe.Open(dedupe.DefaultPath(cmdCtx.CacheStore.BasePath), dedupeWindow, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	emit := func(event streamEvent) error {
+		line, err := formatStreamEventLine(event, human)
+		if err != nil {
+			return err
+		}
+		if err := sink.WriteLine(line); err != nil {
+			return fmt.Errorf("write event: %w", err)
+		}
+		return nil
+	}
+	emitAll := func(events []streamEvent) error {
+		for _, event := range events {
+			if err := emit(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var aggregator *reactionAggregator
+	var flushTick <-chan time.Time
+	if aggregateWindow > 0 {
+		aggregator = newReactionAggregator(aggregateWindow)
+		ticker := time.NewTicker(aggregateFlushInterval(aggregateWindow))
+		defer ticker.Stop()
+		flushTick = ticker.C
+	}
+	flushAggregated := func() error {
+		if aggregator == nil {
+			return nil
+		}
+		return emitAll(aggregator.Flush(time.Now(), true))
+	}
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -180,23 +270,31 @@ func runEventsStream(cmd *cobra.Command, args []string) error {
 	for {
 		select {
 		case <-cmdCtx.Ctx.Done():
-			return nil
+			return flushAggregated()
+		case now := <-flushTick:
+			if err := emitAll(aggregator.Flush(now, false)); err != nil {
+				return err
+			}
+		case <-reloader.C():
+			filter = reloadStreamFilter(cmdCtx, reloader, func() (streamFilter, error) {
+				return buildEventsStreamFilter(cmd, cmdCtx.ResolveChannel)
+			}, filter)
 		case err := <-errCh:
 			if err == nil || errors.Is(err, context.Canceled) {
-				return nil
+				return flushAggregated()
 			}
 			return err
 		case evt, ok := <-socketClient.Events:
 			if !ok {
-				return nil
+				return flushAggregated()
 			}
 			switch evt.Type {
 			case socketmode.EventTypeConnecting:
-				fmt.Fprintln(os.Stderr, "Connecting to Slack Socket Mode...")
+				output.Statusf("Connecting to Slack Socket Mode...\n")
 			case socketmode.EventTypeConnected:
-				fmt.Fprintln(os.Stderr, "Connected to Slack Socket Mode.")
+				output.Statusf("Connected to Slack Socket Mode.\n")
 			case socketmode.EventTypeConnectionError:
-				fmt.Fprintln(os.Stderr, "Slack Socket Mode connection error. Waiting for reconnect...")
+				output.Statusf("Slack Socket Mode connection error. Waiting for reconnect...\n")
 			case socketmode.EventTypeEventsAPI:
 				if evt.Request != nil {
 					socketClient.Ack(*evt.Request)
@@ -207,20 +305,27 @@ func runEventsStream(cmd *cobra.Command, args []string) error {
 					continue
 				}
 
-				normalized, emit, err := normalizer.Normalize(eventsAPIEvent, evt.Request, includeRaw)
+				normalized, matched, err := normalizer.Normalize(eventsAPIEvent, evt.Request, includeRaw)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "failed to normalize event: %v\n", err)
+					fmt.Fprintf(output.Stderr(), "failed to normalize event: %v\n", err)
 					continue
 				}
-				if !emit || !filter.Match(normalized) {
+				if !matched || !filter.Match(normalized) {
 					continue
 				}
-				line, err := formatStreamEventLine(normalized, human)
-				if err != nil {
-					return err
+				if seen != nil && seen.Seen(normalized.EventID, time.Now()) {
+					output.Statusf("Dropped redelivered event %s (retry %d: %s)\n", normalized.EventID, normalized.RetryAttempt, firstNonEmpty(normalized.RetryReason, "unknown"))
+					continue
+				}
+				if aggregator == nil || !aggregator.Add(normalized, time.Now()) {
+					if err := emit(normalized); err != nil {
+						return err
+					}
 				}
-				if err := sink.WriteLine(line); err != nil {
-					return fmt.Errorf("write event: %w", err)
+				if seen != nil && normalized.EventID != "" {
+					if err := seen.Save(time.Now()); err != nil {
+						output.Warnf("save event dedupe window: %v", err)
+					}
 				}
 			}
 		}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// reactionSummaryType is the event type emitted for a coalesced burst of reactions.
+const reactionSummaryType = "reaction_summary"
+
+// reactionAggregate summarizes the reaction events coalesced into one reaction_summary.
+type reactionAggregate struct {
+	Added   int       `json:"added"`
+	Removed int       `json:"removed"`
+	Users   []string  `json:"users"`
+	UserIDs []string  `json:"user_ids"`
+	First   time.Time `json:"first"`
+	Last    time.Time `json:"last"`
+}
+
+// reactionAggregator coalesces reaction_added/reaction_removed events for the same
+// emoji on the same message. The first event opens a window; when it closes, a lone
+// event is emitted unchanged and a burst becomes one reaction_summary with counts.
+type reactionAggregator struct {
+	window  time.Duration
+	pending map[string]*pendingReaction
+}
+
+type pendingReaction struct {
+	event    streamEvent
+	count    int
+	deadline time.Time
+	agg      reactionAggregate
+	seen     map[string]bool
+}
+
+func newReactionAggregator(window time.Duration) *reactionAggregator {
+	return &reactionAggregator{window: window, pending: map[string]*pendingReaction{}}
+}
+
+// Add buffers a reaction event and reports true. Other events are not buffered and
+// Add reports false; the caller emits them directly.
+func (a *reactionAggregator) Add(event streamEvent, now time.Time) bool {
+	if event.Type != "reaction_added" && event.Type != "reaction_removed" {
+		return false
+	}
+	key := event.ChannelID + "\x00" + event.TS + "\x00" + event.Reaction
+	p, ok := a.pending[key]
+	if !ok {
+		p = &pendingReaction{event: event, deadline: now.Add(a.window), seen: map[string]bool{}}
+		p.agg.First = now
+		a.pending[key] = p
+	}
+	p.count++
+	p.agg.Last = now
+	if event.Type == "reaction_added" {
+		p.agg.Added++
+	} else {
+		p.agg.Removed++
+	}
+	if event.UserID != "" && !p.seen[event.UserID] {
+		p.seen[event.UserID] = true
+		p.agg.UserIDs = append(p.agg.UserIDs, event.UserID)
+		p.agg.Users = append(p.agg.Users, firstNonEmpty(event.User, event.UserID))
+	}
+	return true
+}
+
+// Flush returns the events whose window has closed by now, or every buffered event
+// when all is set, in the order their windows opened.
+func (a *reactionAggregator) Flush(now time.Time, all bool) []streamEvent {
+	var due []*pendingReaction
+	for key, p := range a.pending {
+		if all || !now.Before(p.deadline) {
+			due = append(due, p)
+			delete(a.pending, key)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].agg.First.Before(due[j].agg.First) })
+
+	events := make([]streamEvent, 0, len(due))
+	for _, p := range due {
+		if p.count == 1 {
+			events = append(events, p.event)
+			continue
+		}
+		summary := p.event
+		summary.Type = reactionSummaryType
+		summary.EnvelopeID = ""
+		summary.EventID = ""
+		summary.User = ""
+		summary.UserID = ""
+		summary.IsSelf = false
+		summary.Raw = nil
+		agg := p.agg
+		summary.Aggregate = &agg
+		events = append(events, summary)
+	}
+	return events
+}
+
+// aggregateFlushInterval is how often buffered reactions are checked against their window.
+func aggregateFlushInterval(window time.Duration) time.Duration {
+	return min(max(window/4, 100*time.Millisecond), time.Second)
+}
+
+func validateAggregateWindow(window time.Duration) error {
+	if window < 0 {
+		return fmt.Errorf("--aggregate must not be negative")
+	}
+	return nil
+}
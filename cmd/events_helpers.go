@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/kehao95/slack-agent-cli/internal/bots"
 	"github.com/kehao95/slack-agent-cli/internal/config"
 	"github.com/kehao95/slack-agent-cli/internal/eventstore"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
 	slackapi "github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
@@ -23,6 +27,7 @@ type streamFilter struct {
 	UserID            string
 	ThreadsOnly       bool
 	ExcludeSelf       bool
+	Subtypes          messages.SubtypeFilter
 }
 
 func (f streamFilter) Match(event streamEvent) bool {
@@ -65,42 +70,63 @@ func (f streamFilter) Match(event streamEvent) bool {
 		return false
 	}
 
+	if event.Type == "message" && !f.Subtypes.Allows(event.Subtype) {
+		return false
+	}
+
 	return true
 }
 
 type streamEvent struct {
-	Cursor           int64           `json:"cursor,omitempty"`
-	ReceivedAt       time.Time       `json:"received_at,omitempty"`
-	Kind             string          `json:"kind"`
-	EnvelopeID       string          `json:"envelope_id,omitempty"`
-	EventID          string          `json:"event_id,omitempty"`
-	EventTime        int             `json:"event_time,omitempty"`
-	Type             string          `json:"type"`
-	Subtype          string          `json:"subtype,omitempty"`
-	Channel          string          `json:"channel,omitempty"`
-	ChannelID        string          `json:"channel_id,omitempty"`
-	ConversationType string          `json:"conversation_type,omitempty"`
-	User             string          `json:"user,omitempty"`
-	UserID           string          `json:"user_id,omitempty"`
-	BotID            string          `json:"bot_id,omitempty"`
-	ItemUser         string          `json:"item_user,omitempty"`
-	ItemUserID       string          `json:"item_user_id,omitempty"`
-	Reaction         string          `json:"reaction,omitempty"`
-	TS               string          `json:"ts,omitempty"`
-	ThreadTS         string          `json:"thread_ts,omitempty"`
-	Text             string          `json:"text,omitempty"`
-	IsThreadReply    bool            `json:"is_thread_reply,omitempty"`
-	IsThreadRoot     bool            `json:"is_thread_root,omitempty"`
-	IsSelf           bool            `json:"is_self,omitempty"`
-	Raw              json.RawMessage `json:"raw,omitempty"`
+	Cursor           int64              `json:"cursor,omitempty"`
+	ReceivedAt       time.Time          `json:"received_at,omitempty"`
+	Kind             string             `json:"kind"`
+	EnvelopeID       string             `json:"envelope_id,omitempty"`
+	EventID          string             `json:"event_id,omitempty"`
+	EventTime        int                `json:"event_time,omitempty"`
+	RetryAttempt     int                `json:"retry_attempt,omitempty"`
+	RetryReason      string             `json:"retry_reason,omitempty"`
+	Type             string             `json:"type"`
+	Subtype          string             `json:"subtype,omitempty"`
+	Channel          string             `json:"channel,omitempty"`
+	ChannelID        string             `json:"channel_id,omitempty"`
+	ConversationType string             `json:"conversation_type,omitempty"`
+	User             string             `json:"user,omitempty"`
+	UserID           string             `json:"user_id,omitempty"`
+	BotID            string             `json:"bot_id,omitempty"`
+	BotName          string             `json:"bot_name,omitempty"`
+	AppID            string             `json:"app_id,omitempty"`
+	ItemUser         string             `json:"item_user,omitempty"`
+	ItemUserID       string             `json:"item_user_id,omitempty"`
+	Reaction         string             `json:"reaction,omitempty"`
+	TS               string             `json:"ts,omitempty"`
+	ThreadTS         string             `json:"thread_ts,omitempty"`
+	DeletedTS        string             `json:"deleted_ts,omitempty"`
+	Text             string             `json:"text,omitempty"`
+	TextResolved     string             `json:"text_resolved,omitempty"`
+	IsThreadReply    bool               `json:"is_thread_reply,omitempty"`
+	IsThreadRoot     bool               `json:"is_thread_root,omitempty"`
+	IsSelf           bool               `json:"is_self,omitempty"`
+	Aggregate        *reactionAggregate `json:"aggregate,omitempty"`
+	Raw              json.RawMessage    `json:"raw,omitempty"`
 }
 
+// displayText prefers the mention-resolved text for human output.
+func (e streamEvent) displayText() string {
+	return firstNonEmpty(e.TextResolved, e.Text)
+}
+
+// eventNormalizer turns Socket Mode payloads into streamEvents. Names come from the same
+// disk-cached resolvers the rest of the CLI uses; unresolved remembers IDs that failed to
+// resolve so an unknown ID costs at most one API lookup per session.
 type eventNormalizer struct {
 	ctx                  context.Context
 	channelResolver      streamChannelResolver
 	userResolver         streamUserResolver
+	userGroupResolver    streamUserGroupResolver
+	botResolver          streamBotResolver
 	conversationProvider streamConversationInfoProvider
-	conversationTypeByID map[string]string
+	unresolved           map[string]struct{}
 	selfIdentity         eventstore.SelfIdentity
 }
 
@@ -112,6 +138,14 @@ type streamUserResolver interface {
 	GetMentionName(ctx context.Context, userID string) string
 }
 
+type streamUserGroupResolver interface {
+	GetHandle(ctx context.Context, groupID string) string
+}
+
+type streamBotResolver interface {
+	Lookup(ctx context.Context, botID string) (bots.CachedBot, bool)
+}
+
 type streamConversationInfoProvider interface {
 	GetConversationInfo(ctx context.Context, channelID string) (*slackapi.Channel, error)
 }
@@ -121,8 +155,10 @@ func newEventNormalizer(cmdCtx *CommandContext) *eventNormalizer {
 		ctx:                  cmdCtx.Ctx,
 		channelResolver:      cmdCtx.ChannelResolver,
 		userResolver:         cmdCtx.UserResolver,
-		conversationProvider: cmdCtx.Client,
-		conversationTypeByID: map[string]string{},
+		userGroupResolver:    cmdCtx.UserGroupResolver,
+		botResolver:          cmdCtx.BotResolver,
+		conversationProvider: cmdCtx.ChannelResolver,
+		unresolved:           map[string]struct{}{},
 		selfIdentity:         activeSelfIdentity(cmdCtx),
 	}
 }
@@ -213,6 +249,7 @@ func (n *eventNormalizer) Normalize(eventsAPIEvent slackevents.EventsAPIEvent, r
 	if req != nil {
 		event.EnvelopeID = req.EnvelopeID
 		event.EventID, event.EventTime = extractEventMetadata(req.Payload)
+		event.RetryAttempt, event.RetryReason = req.RetryAttempt, req.RetryReason
 		if includeRaw {
 			event.Raw = append(json.RawMessage(nil), req.Payload...)
 		}
@@ -279,16 +316,47 @@ func (n *eventNormalizer) normalizeMessageEvent(base streamEvent, eventType stri
 	base.UserID = userID
 	base.User = n.resolveUserRef(userID)
 	base.BotID = botID
+	base.BotName, base.AppID = n.resolveBot(botID, nil)
 	base.IsSelf = n.isSelf(userID, botID)
 	base.TS = ts
 	base.ThreadTS = threadTS
 	base.Text = firstNonEmpty(payload.Text, evt.Text)
+	base.TextResolved = n.resolveText(base.Text)
 	base.IsThreadReply = threadTS != "" && ts != "" && threadTS != ts
 	base.IsThreadRoot = threadTS != "" && ts != "" && threadTS == ts
+	if subtype == "message_deleted" && evt.PreviousMessage != nil {
+		base.DeletedTS = evt.PreviousMessage.TimeStamp
+	}
 
 	return base
 }
 
+// normalizeHistoryMessage builds a message event from a message fetched over the Web
+// API, for commands that poll instead of holding a Socket Mode connection.
+func (n *eventNormalizer) normalizeHistoryMessage(channelID string, msg slackapi.Message) streamEvent {
+	conversationType := n.resolveConversationType(channelID)
+	event := streamEvent{
+		Kind:             "slack.poll",
+		Type:             "message",
+		Subtype:          msg.SubType,
+		ChannelID:        channelID,
+		Channel:          n.resolveChannelRef(channelID, conversationType),
+		ConversationType: conversationType,
+		UserID:           msg.User,
+		User:             n.resolveUserRef(msg.User),
+		BotID:            msg.BotID,
+		IsSelf:           n.isSelf(msg.User, msg.BotID),
+		TS:               msg.Timestamp,
+		ThreadTS:         msg.ThreadTimestamp,
+		Text:             msg.Text,
+	}
+	event.BotName, event.AppID = n.resolveBot(msg.BotID, msg.BotProfile)
+	event.TextResolved = n.resolveText(event.Text)
+	event.IsThreadReply = event.ThreadTS != "" && event.TS != "" && event.ThreadTS != event.TS
+	event.IsThreadRoot = event.ThreadTS != "" && event.TS != "" && event.ThreadTS == event.TS
+	return event
+}
+
 func (n *eventNormalizer) normalizeReactionEvent(base streamEvent, eventType, userID, itemUserID, reaction string, item slackevents.Item) streamEvent {
 	channelID := strings.TrimSpace(item.Channel)
 	conversationType := n.resolveConversationType(channelID)
@@ -306,6 +374,7 @@ func (n *eventNormalizer) normalizeReactionEvent(base streamEvent, eventType, us
 	base.Reaction = reaction
 	base.TS = ts
 	base.Text = messageText(item.Message)
+	base.TextResolved = n.resolveText(base.Text)
 
 	return base
 }
@@ -324,6 +393,7 @@ func (n *eventNormalizer) normalizePinEvent(base streamEvent, eventType, userID,
 	base.IsSelf = n.isSelf(userID, "")
 	base.TS = ts
 	base.Text = messageText(item.Message)
+	base.TextResolved = n.resolveText(base.Text)
 
 	return base
 }
@@ -333,11 +403,12 @@ func (n *eventNormalizer) resolveUserRef(userID string) string {
 	if userID == "" {
 		return ""
 	}
-	if n.userResolver == nil || n.ctx == nil {
+	if n.userResolver == nil || n.ctx == nil || n.isUnresolved("user:"+userID) {
 		return userID
 	}
 	resolved := strings.TrimSpace(n.userResolver.GetMentionName(n.ctx, userID))
 	if resolved == "" || resolved == userID {
+		n.markUnresolved("user:" + userID)
 		return userID
 	}
 	if strings.HasPrefix(resolved, "@") {
@@ -346,6 +417,27 @@ func (n *eventNormalizer) resolveUserRef(userID string) string {
 	return "@" + resolved
 }
 
+// resolveBot returns the name and app of the bot that posted a message, preferring
+// the bot_profile embedded in the message over a bots.info lookup.
+func (n *eventNormalizer) resolveBot(botID string, profile *slackapi.BotProfile) (string, string) {
+	botID = strings.TrimSpace(botID)
+	if botID == "" {
+		return "", ""
+	}
+	if profile != nil && profile.Name != "" {
+		return profile.Name, profile.AppID
+	}
+	if n.botResolver == nil || n.ctx == nil || n.isUnresolved("bot:"+botID) {
+		return "", ""
+	}
+	bot, ok := n.botResolver.Lookup(n.ctx, botID)
+	if !ok {
+		n.markUnresolved("bot:" + botID)
+		return "", ""
+	}
+	return bot.Name, bot.AppID
+}
+
 func (n *eventNormalizer) isSelf(userID, botID string) bool {
 	return n.selfIdentity.Matches(userID, botID)
 }
@@ -355,11 +447,12 @@ func (n *eventNormalizer) resolveChannelRef(channelID, conversationType string)
 	if channelID == "" {
 		return ""
 	}
-	if n.channelResolver == nil || n.ctx == nil {
+	if n.channelResolver == nil || n.ctx == nil || n.isUnresolved("channel:"+channelID) {
 		return channelID
 	}
 	resolved := strings.TrimSpace(n.channelResolver.ResolveName(n.ctx, channelID))
 	if resolved == "" || resolved == channelID {
+		n.markUnresolved("channel:" + channelID)
 		return channelID
 	}
 	if strings.HasPrefix(resolved, "#") || strings.HasPrefix(resolved, "@") {
@@ -375,41 +468,99 @@ func (n *eventNormalizer) resolveChannelRef(channelID, conversationType string)
 
 func (n *eventNormalizer) resolveConversationType(channelID string) string {
 	channelID = strings.TrimSpace(channelID)
-	if channelID == "" {
-		return ""
-	}
-	if cached := n.conversationTypeByID[channelID]; cached != "" {
-		return cached
-	}
-
 	switch {
+	case channelID == "":
+		return ""
 	case strings.HasPrefix(channelID, "C"):
-		n.conversationTypeByID[channelID] = "channel"
 		return "channel"
 	case strings.HasPrefix(channelID, "D"):
-		n.conversationTypeByID[channelID] = "dm"
 		return "dm"
 	case strings.HasPrefix(channelID, "G"):
-		if n.conversationProvider != nil && n.ctx != nil {
+		if n.conversationProvider != nil && n.ctx != nil && !n.isUnresolved("conversation:"+channelID) {
 			info, err := n.conversationProvider.GetConversationInfo(n.ctx, channelID)
-			if err == nil && info != nil {
-				switch {
-				case info.IsMpIM:
-					n.conversationTypeByID[channelID] = "mpdm"
-					return "mpdm"
-				case info.IsPrivate || info.IsGroup:
-					n.conversationTypeByID[channelID] = "private"
-					return "private"
-				}
+			if err == nil && info != nil && info.IsMpIM {
+				return "mpdm"
+			}
+			if err != nil || info == nil {
+				n.markUnresolved("conversation:" + channelID)
 			}
 		}
-		n.conversationTypeByID[channelID] = "private"
 		return "private"
 	default:
 		return ""
 	}
 }
 
+var (
+	streamUserMentionPattern      = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+	streamUserGroupMentionPattern = regexp.MustCompile(`<!subteam\^([A-Z0-9]+)(?:\|([^>]*))?>`)
+	streamChannelMentionPattern   = regexp.MustCompile(`<#([CG][A-Z0-9]+)(?:\|([^>]*))?>`)
+)
+
+// resolveText replaces user, usergroup, and channel mentions in message text with
+// @handle and #name references. It returns "" when nothing changed so text_resolved
+// is only emitted when it adds information; text itself stays raw for mention filters.
+func (n *eventNormalizer) resolveText(text string) string {
+	if text == "" || !strings.Contains(text, "<") {
+		return ""
+	}
+	resolved := streamUserMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		userID := streamUserMentionPattern.FindStringSubmatch(match)[1]
+		if ref := n.resolveUserRef(userID); ref != userID {
+			return ref
+		}
+		return match
+	})
+	resolved = streamUserGroupMentionPattern.ReplaceAllStringFunc(resolved, func(match string) string {
+		parts := streamUserGroupMentionPattern.FindStringSubmatch(match)
+		if handle := n.resolveUserGroupHandle(parts[1]); handle != "" {
+			return "@" + handle
+		}
+		if label := strings.TrimPrefix(parts[2], "@"); label != "" {
+			return "@" + label
+		}
+		return match
+	})
+	resolved = streamChannelMentionPattern.ReplaceAllStringFunc(resolved, func(match string) string {
+		parts := streamChannelMentionPattern.FindStringSubmatch(match)
+		if parts[2] != "" {
+			return "#" + parts[2]
+		}
+		if ref := n.resolveChannelRef(parts[1], "channel"); ref != parts[1] {
+			return ref
+		}
+		return match
+	})
+	if resolved == text {
+		return ""
+	}
+	return resolved
+}
+
+func (n *eventNormalizer) resolveUserGroupHandle(groupID string) string {
+	if n.userGroupResolver == nil || n.ctx == nil || n.isUnresolved("usergroup:"+groupID) {
+		return ""
+	}
+	handle := strings.TrimPrefix(strings.TrimSpace(n.userGroupResolver.GetHandle(n.ctx, groupID)), "@")
+	if handle == "" || handle == groupID {
+		n.markUnresolved("usergroup:" + groupID)
+		return ""
+	}
+	return handle
+}
+
+func (n *eventNormalizer) isUnresolved(key string) bool {
+	_, ok := n.unresolved[key]
+	return ok
+}
+
+func (n *eventNormalizer) markUnresolved(key string) {
+	if n.unresolved == nil {
+		n.unresolved = map[string]struct{}{}
+	}
+	n.unresolved[key] = struct{}{}
+}
+
 func parseConversationTypes(raw string) (map[string]struct{}, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -540,6 +691,8 @@ func formatHumanStreamEvent(event streamEvent) string {
 
 	if event.User != "" {
 		parts = append(parts, event.User)
+	} else if event.BotName != "" {
+		parts = append(parts, event.BotName)
 	}
 
 	switch event.Type {
@@ -553,8 +706,10 @@ func formatHumanStreamEvent(event streamEvent) string {
 		} else if event.IsThreadRoot {
 			label += " thread-root"
 		}
-		body := strings.TrimSpace(event.Text)
-		if body == "" {
+		body := strings.TrimSpace(event.displayText())
+		if body == "" && event.Subtype == slack.HuddleSubtype {
+			body = "(huddle started)"
+		} else if body == "" {
 			body = "(no text)"
 		}
 		return strings.Join(parts, " ") + ": " + label + " - " + body
@@ -570,14 +725,26 @@ func formatHumanStreamEvent(event streamEvent) string {
 		if target != "" {
 			body += " on " + target
 		}
-		if event.Text != "" {
-			body += " - " + event.Text
+		if text := event.displayText(); text != "" {
+			body += " - " + text
 		}
 		return strings.Join(parts, " ") + ": " + body
+	case reactionSummaryType:
+		body := ":" + event.Reaction + ":"
+		if agg := event.Aggregate; agg != nil {
+			body += fmt.Sprintf(" +%d -%d", agg.Added, agg.Removed)
+			if len(agg.Users) > 0 {
+				body += " by " + strings.Join(agg.Users, ", ")
+			}
+		}
+		if text := event.displayText(); text != "" {
+			body += " - " + text
+		}
+		return strings.Join(parts, " ") + ": reactions " + body
 	case "pin_added", "pin_removed":
 		body := event.Type
-		if event.Text != "" {
-			body += " - " + event.Text
+		if text := event.displayText(); text != "" {
+			body += " - " + text
 		}
 		return strings.Join(parts, " ") + ": " + body
 	default:
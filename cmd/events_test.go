@@ -9,7 +9,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/kehao95/slack-agent-cli/internal/bots"
 	"github.com/kehao95/slack-agent-cli/internal/config"
 	"github.com/kehao95/slack-agent-cli/internal/eventstore"
 	slackapi "github.com/slack-go/slack"
@@ -104,7 +106,6 @@ func TestEventNormalizerMessageThread(t *testing.T) {
 		conversationProvider: testConversationProvider{
 			info: map[string]*slackapi.Channel{},
 		},
-		conversationTypeByID: map[string]string{},
 	}
 
 	event, emit, err := normalizer.Normalize(slackevents.EventsAPIEvent{
@@ -121,7 +122,7 @@ func TestEventNormalizerMessageThread(t *testing.T) {
 				ChannelType:     "channel",
 			},
 		},
-	}, &socketmode.Request{EnvelopeID: "env-1", Payload: raw}, true)
+	}, &socketmode.Request{EnvelopeID: "env-1", Payload: raw, RetryAttempt: 1, RetryReason: "timeout"}, true)
 	if err != nil {
 		t.Fatalf("Normalize returned error: %v", err)
 	}
@@ -140,6 +141,9 @@ func TestEventNormalizerMessageThread(t *testing.T) {
 	if string(event.Raw) != string(raw) {
 		t.Fatalf("expected raw payload to round-trip")
 	}
+	if event.RetryAttempt != 1 || event.RetryReason != "timeout" {
+		t.Fatalf("retry = %d %q, want 1 timeout", event.RetryAttempt, event.RetryReason)
+	}
 }
 
 func TestEventNormalizerReactionConversationType(t *testing.T) {
@@ -152,7 +156,6 @@ func TestEventNormalizerReactionConversationType(t *testing.T) {
 				"G123": {GroupConversation: slackapi.GroupConversation{Conversation: slackapi.Conversation{IsPrivate: true}}},
 			},
 		},
-		conversationTypeByID: map[string]string{},
 	}
 
 	event, emit, err := normalizer.Normalize(slackevents.EventsAPIEvent{
@@ -187,7 +190,6 @@ func TestEventNormalizerReactionConversationType(t *testing.T) {
 
 func TestEventNormalizerIsSelfUsesUserRoleIdentity(t *testing.T) {
 	normalizer := &eventNormalizer{
-		conversationTypeByID: map[string]string{},
 		selfIdentity: eventstore.SelfIdentity{
 			Role:   config.RoleUser,
 			UserID: "USELF",
@@ -215,7 +217,6 @@ func TestEventNormalizerIsSelfUsesUserRoleIdentity(t *testing.T) {
 
 func TestEventNormalizerIsSelfUsesBotRoleIdentity(t *testing.T) {
 	normalizer := &eventNormalizer{
-		conversationTypeByID: map[string]string{},
 		selfIdentity: eventstore.SelfIdentity{
 			Role:   config.RoleBot,
 			UserID: "UBOT",
@@ -299,6 +300,31 @@ func TestStreamFilterEventTypes(t *testing.T) {
 	}
 }
 
+func TestBuildEventsStreamFilterSubtypes(t *testing.T) {
+	cmd := &cobra.Command{Use: "stream"}
+	addEventsStreamFlags(cmd)
+	if err := cmd.Flags().Set("subtypes", "none,channel_join"); err != nil {
+		t.Fatalf("set subtypes: %v", err)
+	}
+
+	filter, err := buildEventsStreamFilter(cmd, nil)
+	if err != nil {
+		t.Fatalf("buildEventsStreamFilter returned error: %v", err)
+	}
+	if !filter.Match(streamEvent{Type: "message"}) {
+		t.Fatal("expected ordinary message to match none")
+	}
+	if !filter.Match(streamEvent{Type: "message", Subtype: "channel_join"}) {
+		t.Fatal("expected channel_join to match")
+	}
+	if filter.Match(streamEvent{Type: "message", Subtype: "bot_message"}) {
+		t.Fatal("did not expect bot_message to match")
+	}
+	if !filter.Match(streamEvent{Type: "reaction_added"}) {
+		t.Fatal("subtype filter should not apply to non-message events")
+	}
+}
+
 func TestBuildEventsStreamFilterRejectsThreadsOnlyWithoutMessageEventType(t *testing.T) {
 	cmd := &cobra.Command{Use: "stream"}
 	addEventsStreamFlags(cmd)
@@ -433,6 +459,46 @@ func TestFormatHumanStreamEventReaction(t *testing.T) {
 	}
 }
 
+func TestReactionAggregator(t *testing.T) {
+	start := time.Unix(1705312365, 0)
+	agg := newReactionAggregator(5 * time.Second)
+	reaction := func(eventType, userID, emoji string) streamEvent {
+		return streamEvent{Type: eventType, ChannelID: "C1", TS: "1705312365.000100", Reaction: emoji, UserID: userID, User: "@" + userID}
+	}
+
+	if agg.Add(streamEvent{Type: "message", ChannelID: "C1"}, start) {
+		t.Fatal("message events must not be buffered")
+	}
+	agg.Add(reaction("reaction_added", "alice", "tada"), start)
+	agg.Add(reaction("reaction_added", "bob", "tada"), start.Add(time.Second))
+	agg.Add(reaction("reaction_removed", "alice", "tada"), start.Add(2*time.Second))
+	agg.Add(reaction("reaction_added", "carol", "eyes"), start.Add(3*time.Second))
+
+	if got := agg.Flush(start.Add(4*time.Second), false); len(got) != 0 {
+		t.Fatalf("flushed %d events before the window closed", len(got))
+	}
+	got := agg.Flush(start.Add(5*time.Second), false)
+	if len(got) != 1 || got[0].Type != reactionSummaryType {
+		t.Fatalf("expected one reaction_summary, got %+v", got)
+	}
+	summary := got[0]
+	if summary.Aggregate.Added != 2 || summary.Aggregate.Removed != 1 || summary.UserID != "" {
+		t.Fatalf("unexpected summary %+v / %+v", summary, summary.Aggregate)
+	}
+	if strings.Join(summary.Aggregate.UserIDs, ",") != "alice,bob" {
+		t.Fatalf("expected unique users alice,bob, got %v", summary.Aggregate.UserIDs)
+	}
+	if line := formatHumanStreamEvent(summary); !strings.Contains(line, ":tada: +2 -1 by @alice, @bob") {
+		t.Fatalf("unexpected human summary %q", line)
+	}
+
+	// A lone reaction is emitted unchanged when flushed.
+	rest := agg.Flush(start.Add(6*time.Second), true)
+	if len(rest) != 1 || rest[0].Type != "reaction_added" || rest[0].UserID != "carol" || rest[0].Aggregate != nil {
+		t.Fatalf("expected the lone reaction unchanged, got %+v", rest)
+	}
+}
+
 type mockFileAppenderOpener struct {
 	openCount  int
 	closeCount int
@@ -523,3 +589,163 @@ func TestNewEventsStreamSinkWritesToStdoutAndFile(t *testing.T) {
 		t.Fatalf("unexpected file output %q", got)
 	}
 }
+
+type testUserGroupResolver struct {
+	handles map[string]string
+}
+
+func (r testUserGroupResolver) GetHandle(ctx context.Context, groupID string) string {
+	if handle, ok := r.handles[groupID]; ok {
+		return handle
+	}
+	return groupID
+}
+
+type countingUserResolver struct {
+	calls int
+}
+
+func (r *countingUserResolver) GetMentionName(ctx context.Context, userID string) string {
+	r.calls++
+	return userID
+}
+
+func TestEventNormalizerResolvesMentionsInText(t *testing.T) {
+	normalizer := &eventNormalizer{
+		ctx:               context.Background(),
+		channelResolver:   testChannelResolver{names: map[string]string{"C9": "ops"}},
+		userResolver:      testUserResolver{names: map[string]string{"U123": "alice"}},
+		userGroupResolver: testUserGroupResolver{handles: map[string]string{"S1": "oncall"}},
+	}
+
+	event := normalizer.normalizeMessageEvent(streamEvent{}, "message", &slackevents.MessageEvent{
+		Type:        "message",
+		User:        "U123",
+		Text:        "<@U123> ping <!subteam^S1> in <#C9> and <@U999>",
+		TimeStamp:   "1705312365.000100",
+		Channel:     "C123",
+		ChannelType: "channel",
+	})
+	if event.Text != "<@U123> ping <!subteam^S1> in <#C9> and <@U999>" {
+		t.Fatalf("expected raw text to be preserved, got %q", event.Text)
+	}
+	if want := "@alice ping @oncall in #ops and <@U999>"; event.TextResolved != want {
+		t.Fatalf("text_resolved = %q, want %q", event.TextResolved, want)
+	}
+	if got := formatHumanStreamEvent(event); !strings.Contains(got, "@alice ping @oncall") {
+		t.Fatalf("expected human output to use resolved text, got %q", got)
+	}
+
+	plain := normalizer.normalizeMessageEvent(streamEvent{}, "message", &slackevents.MessageEvent{Text: "no mentions"})
+	if plain.TextResolved != "" {
+		t.Fatalf("expected no text_resolved for plain text, got %q", plain.TextResolved)
+	}
+}
+
+func TestEventNormalizerLooksUpUnknownUserOnce(t *testing.T) {
+	users := &countingUserResolver{}
+	normalizer := &eventNormalizer{ctx: context.Background(), userResolver: users}
+	for i := 0; i < 3; i++ {
+		if ref := normalizer.resolveUserRef("UGONE"); ref != "UGONE" {
+			t.Fatalf("expected unresolved ID, got %q", ref)
+		}
+	}
+	if users.calls != 1 {
+		t.Fatalf("expected 1 lookup for an unresolvable user, got %d", users.calls)
+	}
+}
+
+func TestLiveReloaderAppliesFilterFile(t *testing.T) {
+	cmd := &cobra.Command{Use: "stream"}
+	addEventsStreamFlags(cmd)
+	path := filepath.Join(t.TempDir(), "filters.json")
+	if err := os.WriteFile(path, []byte(`{"event-type": ["message", "reaction_added"], "exclude-self": true}`), 0o600); err != nil {
+		t.Fatalf("write filter file: %v", err)
+	}
+	if err := cmd.Flags().Set("filter-file", path); err != nil {
+		t.Fatalf("set filter-file: %v", err)
+	}
+	if err := cmd.Flags().Set("thread", "1705312365.000100"); err != nil {
+		t.Fatalf("set thread: %v", err)
+	}
+
+	reloader, err := newLiveReloader(cmd)
+	if err != nil {
+		t.Fatalf("newLiveReloader returned error: %v", err)
+	}
+	defer reloader.Stop()
+	filter, err := buildEventsStreamFilter(cmd, nil)
+	if err != nil {
+		t.Fatalf("buildEventsStreamFilter returned error: %v", err)
+	}
+	if len(filter.EventTypes) != 2 || !filter.ExcludeSelf || filter.ThreadTS != "1705312365.000100" {
+		t.Fatalf("unexpected filter from file: %+v", filter)
+	}
+
+	// Removing a key on reload falls back to the command-line value.
+	if err := os.WriteFile(path, []byte(`{"subtypes": "none"}`), 0o600); err != nil {
+		t.Fatalf("rewrite filter file: %v", err)
+	}
+	if err := reloader.applyFilterFile(); err != nil {
+		t.Fatalf("applyFilterFile returned error: %v", err)
+	}
+	filter, err = buildEventsStreamFilter(cmd, nil)
+	if err != nil {
+		t.Fatalf("buildEventsStreamFilter returned error: %v", err)
+	}
+	if len(filter.EventTypes) != 0 || filter.ExcludeSelf || filter.Subtypes.IsZero() || filter.ThreadTS != "1705312365.000100" {
+		t.Fatalf("unexpected filter after reload: %+v", filter)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"retention": "1h"}`), 0o600); err != nil {
+		t.Fatalf("rewrite filter file: %v", err)
+	}
+	if err := reloader.applyFilterFile(); err == nil || !strings.Contains(err.Error(), "unsupported key") {
+		t.Fatalf("expected unsupported key error, got %v", err)
+	}
+}
+
+type countingBotResolver struct {
+	bots  map[string]bots.CachedBot
+	calls int
+}
+
+func (r *countingBotResolver) Lookup(ctx context.Context, botID string) (bots.CachedBot, bool) {
+	r.calls++
+	b, ok := r.bots[botID]
+	return b, ok
+}
+
+func TestEventNormalizerNamesBots(t *testing.T) {
+	resolver := &countingBotResolver{bots: map[string]bots.CachedBot{"B1": {ID: "B1", Name: "deploybot", AppID: "A1"}}}
+	normalizer := &eventNormalizer{ctx: context.Background(), botResolver: resolver, unresolved: map[string]struct{}{}}
+
+	event := normalizer.normalizeMessageEvent(streamEvent{}, "message", &slackevents.MessageEvent{
+		Type:      "message",
+		BotID:     "B1",
+		Text:      "deployed",
+		TimeStamp: "1705312365.000100",
+		Channel:   "C123",
+	})
+	if event.BotName != "deploybot" || event.AppID != "A1" {
+		t.Fatalf("bot fields = %q, %q", event.BotName, event.AppID)
+	}
+	if got := formatHumanStreamEvent(event); !strings.Contains(got, "deploybot: message - deployed") {
+		t.Fatalf("human output = %q", got)
+	}
+
+	polled := normalizer.normalizeHistoryMessage("C123", slackapi.Message{Msg: slackapi.Msg{
+		BotID:      "B2",
+		BotProfile: &slackapi.BotProfile{Name: "pagerbot", AppID: "A2"},
+	}})
+	if polled.BotName != "pagerbot" || polled.AppID != "A2" {
+		t.Fatalf("bot_profile fields = %q, %q", polled.BotName, polled.AppID)
+	}
+
+	for i := 0; i < 2; i++ {
+		normalizer.resolveBot("BGONE", nil)
+	}
+	if resolver.calls != 2 {
+		t.Fatalf("expected one lookup each for B1 and BGONE, got %d", resolver.calls)
+	}
+}
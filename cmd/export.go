@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/ratelimit"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Check archives written by messages export",
+	Long:  "Work with archives written by messages export. Use messages export to create them.",
+}
+
+var exportVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Re-check an export against its manifest",
+	Long: `Re-check an export against its manifest and report every file that does not
+match. --manifest takes either a segment manifest (general.manifest.json, written by
+messages export --compress or --rotate-size/--rotate-every) or the manifest.json of
+an --out-dir export.
+
+Checks:
+  - each closed segment exists with the size and SHA-256 checksum in its manifest
+  - every file decompresses and holds one JSON document per line
+  - line counts match the segment manifest and, for --out-dir exports of channels
+    that finished, the message and thread reply counts in manifest.json
+  - messages are newest first with no repeated ts, the order exports write
+
+A segment an unfinished export is still writing is reported as "open" and only its
+checkpointed part is checked. Uncompressed --out-dir files have no checksum, so only
+their contents and counts are checked.
+
+--repair fetches the ts range of each damaged message segment from Slack again and
+rewrites the segment and its manifest entry, applying the export's --scrub mode. Slack
+returns the messages as they are now, so edits and deletions since the export show up
+as a different checksum or count. Thread reply segments are reported but not repaired;
+export the channel again with --threads.
+
+Verification of a large archive can be interrupted: verified segments are recorded in
+<manifest>.verify.json, and --resume skips them if their size and checksum have not
+changed. The file is removed when a run finishes.
+
+Exits non-zero when a file fails and was not repaired.
+
+Output (JSON):
+  {
+    "ok": false,
+    "manifest": "archive/general.manifest.json",
+    "checked": 3,
+    "skipped": 1,
+    "failed": 1,
+    "repaired": 1,
+    "files": [
+      {"file": "archive/general.000002.ndjson.gz", "channel": "C123ABC", "kind": "messages",
+       "status": "checksum_mismatch", "lines": 0, "expected_lines": 850,
+       "problem": "SHA-256 differs from the manifest", "repaired": true, "refetched": 850}
+    ]
+  }
+
+Statuses: ok, open, skipped, missing, size_mismatch, checksum_mismatch, corrupt,
+line_count_mismatch, out_of_order.
+
+Required Scopes:
+  - none without --repair
+  - channels:history (groups:history, im:history, mpim:history for other conversation types) for --repair`,
+	Example: `  # Check a compressed, rotated archive
+  slk export verify --manifest archive/general.manifest.json
+
+  # Check every channel of an --out-dir export
+  slk export verify --manifest ./export/manifest.json --human
+
+  # Fetch damaged segments again, continuing an interrupted run
+  slk export verify --manifest archive/general.manifest.json --repair --resume`,
+	Args: cobra.NoArgs,
+	RunE: runExportVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportVerifyCmd)
+
+	exportVerifyCmd.Flags().String("manifest", "", "Segment manifest or --out-dir manifest.json to verify (required)")
+	exportVerifyCmd.Flags().Bool("repair", false, "Fetch damaged message segments from Slack again and rewrite them")
+	exportVerifyCmd.Flags().Bool("resume", false, "Skip segments an interrupted earlier run already verified")
+	exportVerifyCmd.Flags().Duration("page-delay", time.Second, "Delay between API calls made by --repair")
+	exportVerifyCmd.MarkFlagRequired("manifest")
+}
+
+func runExportVerify(cmd *cobra.Command, args []string) error {
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+	repair, _ := cmd.Flags().GetBool("repair")
+	resume, _ := cmd.Flags().GetBool("resume")
+	pageDelay, _ := cmd.Flags().GetDuration("page-delay")
+
+	if _, err := os.Stat(manifestPath); err != nil {
+		return cerrors.NotFoundError("manifest", manifestPath, "Hint: pass the general.manifest.json or manifest.json written by messages export")
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	verifyCfg := messages.VerifyConfig{Resume: resume}
+	if repair {
+		cmdCtx, err := NewStreamingCommandContext(cmd)
+		if err != nil {
+			return err
+		}
+		defer cmdCtx.Close()
+		ctx = cmdCtx.Ctx
+		verifyCfg.Fetcher = slack.NewMessageFetcher(cmdCtx.Client)
+		verifyCfg.Scrub = func(mode string) (func([]byte) ([]byte, error), error) {
+			scrubber, err := newScrubberMode(mode, cmdCtx.Config)
+			if err != nil {
+				return nil, err
+			}
+			return scrubJSON(scrubber), nil
+		}
+		verifyCfg.Export = messages.ExportConfig{
+			Limiter: ratelimit.New(pageDelay),
+			Output:  output.StatusWriter(),
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	result, err := messages.VerifyExport(ctx, manifestPath, verifyCfg)
+	if errors.Is(err, context.Canceled) {
+		cmd.SilenceUsage = true
+		output.Statusf("Verification stopped; continue with: slk export verify --manifest %s --resume\n", manifestPath)
+		return cerrors.NewErrorWithCode(cerrors.ExitGeneral, "verification interrupted")
+	}
+	if err != nil {
+		return err
+	}
+	if err := output.Print(cmd, result); err != nil {
+		return err
+	}
+	if !result.OK {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("export verification failed: %d files", result.Failed)
+	}
+	return nil
+}
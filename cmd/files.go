@@ -0,0 +1,397 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/idempotency"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/policy"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+var filesCmd = &cobra.Command{
+	Use:   "files",
+	Short: "File operations",
+	Long:  "Inspect uploaded files and control where they are visible.",
+}
+
+var filesInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show a file's metadata and where it is shared",
+	Long: `Show a file's metadata, the conversations it is shared in, and whether it has a
+public URL.
+
+Output (JSON):
+  {
+    "ok": true,
+    "file": {
+      "id": "F123ABC",
+      "name": "report.pdf",
+      "title": "Q3 report",
+      "mimetype": "application/pdf",
+      "filetype": "pdf",
+      "size": 48213,
+      "user": "U123ABC",
+      "created": "2024-01-15T10:52:45Z",
+      "permalink": "https://example.slack.com/files/U123ABC/F123ABC/report.pdf",
+      "public_url_shared": true,
+      "permalink_public": "https://slack-files.com/T123-F123ABC-0a1b2c3d4e",
+      "shares": [
+        {"channel_id": "C123ABC", "channel": "#reports", "ts": "1705312365.000100"}
+      ]
+    }
+  }
+
+Required Scopes:
+  - files:read`,
+	Example: `  # Where is this file visible?
+  slk files info --file F123ABC --human`,
+	Args: cobra.NoArgs,
+	RunE: runFilesInfo,
+}
+
+var filesShareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Share a file in a channel or create its public URL",
+	Long: `Share an uploaded file in another conversation, create a public URL for it, or
+both.
+
+--channel posts a message with the file's permalink (and --text, if given), which
+Slack shows with the file attached; Slack's API has no other way to share an
+existing file. --public creates a public URL (files.sharedPublicURL) that anyone
+with the link can open without signing in; only user tokens can do this. Undo it
+with files revoke-public.
+
+The token's scopes are checked before anything is posted or published. --text
+goes through the same redaction, quiet_hours, and dedupe_window checks as
+messages send; --no-redact, --override-quiet-hours, and --allow-duplicate skip
+them.
+
+Output (JSON):
+  {
+    "ok": true,
+    "file_id": "F123ABC",
+    "channel": "#reports",
+    "channel_id": "C123ABC",
+    "ts": "1705312365.000100",
+    "permalink_public": "https://slack-files.com/T123-F123ABC-0a1b2c3d4e"
+  }
+
+Required Scopes:
+  - files:read and chat:write for --channel
+  - files:write for --public`,
+	Example: `  # Share a file in another channel with a note
+  slk files share --file F123ABC --channel "#reports" --text "Q3 numbers"
+
+  # Create a public link for someone outside the workspace
+  slk files share --file F123ABC --public | jq -r .permalink_public`,
+	Args: cobra.NoArgs,
+	RunE: runFilesShare,
+}
+
+var filesRevokePublicCmd = &cobra.Command{
+	Use:   "revoke-public",
+	Short: "Disable a file's public URL",
+	Long: `Disable the public URL of a file (files.revokePublicURL). Links already handed out
+stop working; the file stays shared wherever it was posted in Slack. Only user tokens
+can do this.
+
+Output (JSON):
+  {
+    "ok": true,
+    "file_id": "F123ABC",
+    "public_url_shared": false
+  }
+
+Required Scopes:
+  - files:write`,
+	Example: `  slk files revoke-public --file F123ABC`,
+	Args:    cobra.NoArgs,
+	RunE:    runFilesRevokePublic,
+}
+
+func init() {
+	rootCmd.AddCommand(filesCmd)
+	filesCmd.AddCommand(filesInfoCmd)
+	filesCmd.AddCommand(filesShareCmd)
+	filesCmd.AddCommand(filesRevokePublicCmd)
+
+	filesInfoCmd.Flags().String("file", "", "File ID (required)")
+	filesInfoCmd.MarkFlagRequired("file")
+
+	filesShareCmd.Flags().String("file", "", "File ID (required)")
+	filesShareCmd.Flags().StringP("channel", "c", "", "Channel or @user to share the file in")
+	filesShareCmd.Flags().String("thread", "", "Thread timestamp to share the file in (with --channel)")
+	filesShareCmd.Flags().StringP("text", "t", "", "Message to post with the file (with --channel)")
+	filesShareCmd.Flags().Bool("public", false, "Create a public URL for the file (user token only)")
+	filesShareCmd.Flags().Bool("allow-duplicate", false, "Share even if dedupe_window finds an identical recent message")
+	filesShareCmd.MarkFlagRequired("file")
+	addQuietHoursFlag(filesShareCmd)
+	addRedactFlag(filesShareCmd)
+	filesShareCmd.MarkFlagsOneRequired("channel", "public")
+
+	filesRevokePublicCmd.Flags().String("file", "", "File ID (required)")
+	filesRevokePublicCmd.MarkFlagRequired("file")
+}
+
+// FileShare is a message a file is shared in.
+type FileShare struct {
+	ChannelID string `json:"channel_id"`
+	Channel   string `json:"channel,omitempty"`
+	TS        string `json:"ts"`
+}
+
+// FileDetails is the metadata files info reports.
+type FileDetails struct {
+	ID              string      `json:"id"`
+	Name            string      `json:"name"`
+	Title           string      `json:"title,omitempty"`
+	Mimetype        string      `json:"mimetype,omitempty"`
+	Filetype        string      `json:"filetype,omitempty"`
+	Size            int         `json:"size"`
+	User            string      `json:"user,omitempty"`
+	Created         string      `json:"created,omitempty"`
+	Permalink       string      `json:"permalink,omitempty"`
+	PublicURLShared bool        `json:"public_url_shared"`
+	PermalinkPublic string      `json:"permalink_public,omitempty"`
+	Shares          []FileShare `json:"shares"`
+}
+
+// FileInfoResult is the result of files info.
+type FileInfoResult struct {
+	OK   bool        `json:"ok"`
+	File FileDetails `json:"file"`
+}
+
+// Lines implements output.Printable.
+func (r FileInfoResult) Lines() []string {
+	f := r.File
+	lines := []string{
+		fmt.Sprintf("%s (%s)", f.Name, f.ID),
+		fmt.Sprintf("Type: %s, %d bytes, uploaded %s by %s", f.Filetype, f.Size, f.Created, f.User),
+		"Permalink: " + f.Permalink,
+	}
+	if f.PublicURLShared {
+		lines = append(lines, "Public URL: "+f.PermalinkPublic)
+	} else {
+		lines = append(lines, "Public URL: none")
+	}
+	if len(f.Shares) == 0 {
+		return append(lines, "Not shared in any conversation")
+	}
+	lines = append(lines, "Shared in:")
+	for _, s := range f.Shares {
+		where := s.ChannelID
+		if s.Channel != "" {
+			where = s.Channel
+		}
+		lines = append(lines, fmt.Sprintf("  %s at %s", where, s.TS))
+	}
+	return lines
+}
+
+// FileShareResult is the result of files share and files revoke-public.
+type FileShareResult struct {
+	OK              bool   `json:"ok"`
+	FileID          string `json:"file_id"`
+	Channel         string `json:"channel,omitempty"`
+	ChannelID       string `json:"channel_id,omitempty"`
+	TS              string `json:"ts,omitempty"`
+	PublicURLShared *bool  `json:"public_url_shared,omitempty"`
+	PermalinkPublic string `json:"permalink_public,omitempty"`
+}
+
+// Lines implements output.Printable.
+func (r FileShareResult) Lines() []string {
+	var lines []string
+	if r.ChannelID != "" {
+		lines = append(lines, fmt.Sprintf("✓ Shared %s in %s (ts %s)", r.FileID, r.Channel, r.TS))
+	}
+	if r.PermalinkPublic != "" {
+		lines = append(lines, fmt.Sprintf("✓ Public URL for %s: %s", r.FileID, r.PermalinkPublic))
+	} else if r.PublicURLShared != nil && !*r.PublicURLShared {
+		lines = append(lines, fmt.Sprintf("✓ Public URL for %s revoked", r.FileID))
+	}
+	return lines
+}
+
+func runFilesInfo(cmd *cobra.Command, args []string) error {
+	fileID, _ := cmd.Flags().GetString("file")
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	if err := requireScopes(cmdCtx, "files info", "files:read"); err != nil {
+		return err
+	}
+	file, err := cmdCtx.Client.GetFileInfo(cmdCtx.Ctx, fileID)
+	if err != nil {
+		return err
+	}
+	details := fileDetails(file)
+	for i, s := range details.Shares {
+		if name := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, s.ChannelID); name != s.ChannelID {
+			details.Shares[i].Channel = "#" + strings.TrimPrefix(name, "#")
+		}
+	}
+	return output.Print(cmd, FileInfoResult{OK: true, File: details})
+}
+
+func runFilesShare(cmd *cobra.Command, args []string) error {
+	fileID, _ := cmd.Flags().GetString("file")
+	channelInput, _ := cmd.Flags().GetString("channel")
+	thread, _ := cmd.Flags().GetString("thread")
+	text, _ := cmd.Flags().GetString("text")
+	public, _ := cmd.Flags().GetBool("public")
+	if channelInput == "" && (thread != "" || text != "") {
+		return cerrors.ConfigError("--thread and --text apply to --channel")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	// Check everything up front so a missing scope cannot leave the file half shared.
+	var scopes []string
+	if channelInput != "" {
+		scopes = append(scopes, "files:read", "chat:write")
+	}
+	if public {
+		if cmdCtx.AuthRole == config.RoleBot {
+			return cerrors.ConfigError("--public needs a user token; bot tokens cannot create public file URLs")
+		}
+		scopes = append(scopes, "files:write")
+	}
+	if err := requireScopes(cmdCtx, "files share", scopes...); err != nil {
+		return err
+	}
+
+	text, _, redactions, err := redactMessage(cmd, cmdCtx.Config, text, "")
+	if err != nil {
+		return err
+	}
+
+	result := FileShareResult{OK: true, FileID: fileID}
+	if channelInput != "" {
+		resolve := cmdCtx.ResolveChannel
+		if strings.HasPrefix(channelInput, "@") {
+			resolve = cmdCtx.ResolveDM
+		}
+		if result.ChannelID, err = resolve(channelInput); err != nil {
+			return err
+		}
+	}
+	quiet, err := checkQuietHours(cmd, cmdCtx, result.ChannelID, false)
+	if err != nil {
+		return err
+	}
+	var dedupe *policy.Decision
+	if channelInput != "" {
+		file, err := cmdCtx.Client.GetFileInfo(cmdCtx.Ctx, fileID)
+		if err != nil {
+			return err
+		}
+		message := file.Permalink
+		if text != "" {
+			message = text + "\n" + file.Permalink
+		}
+		history, decision, err := checkDedupe(cmd, cmdCtx, result.ChannelID, idempotency.Fingerprint(result.ChannelID, thread, message))
+		if err != nil {
+			return err
+		}
+		defer history.Close()
+		dedupe = decision
+		posted, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, result.ChannelID, slack.PostMessageOptions{
+			Text:        message,
+			ThreadTS:    thread,
+			UnfurlLinks: true,
+			UnfurlMedia: true,
+			AsUser:      cmdCtx.AuthRole == config.RoleUser,
+		})
+		if err != nil {
+			return err
+		}
+		history.Record(cmdCtx, posted.Timestamp)
+		result.Channel = channelInput
+		result.TS = posted.Timestamp
+	}
+	if public {
+		file, err := cmdCtx.Client.ShareFilePublicURL(cmdCtx.Ctx, fileID)
+		if err != nil {
+			return err
+		}
+		shared := true
+		result.PublicURLShared = &shared
+		result.PermalinkPublic = file.PermalinkPublic
+	}
+	return output.Print(cmd, withRedactions(withDecision(withPolicy(result, quiet), "dedupe", dedupe), redactions))
+}
+
+func runFilesRevokePublic(cmd *cobra.Command, args []string) error {
+	fileID, _ := cmd.Flags().GetString("file")
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	if cmdCtx.AuthRole == config.RoleBot {
+		return cerrors.ConfigError("files revoke-public needs a user token; bot tokens cannot manage public file URLs")
+	}
+	if err := requireScopes(cmdCtx, "files revoke-public", "files:write"); err != nil {
+		return err
+	}
+	file, err := cmdCtx.Client.RevokeFilePublicURL(cmdCtx.Ctx, fileID)
+	if err != nil {
+		return err
+	}
+	shared := file.PublicURLShared
+	return output.Print(cmd, FileShareResult{OK: true, FileID: fileID, PublicURLShared: &shared})
+}
+
+// fileDetails summarizes a files.info response, listing shares oldest first.
+func fileDetails(file *slackapi.File) FileDetails {
+	details := FileDetails{
+		ID:              file.ID,
+		Name:            file.Name,
+		Title:           file.Title,
+		Mimetype:        file.Mimetype,
+		Filetype:        file.Filetype,
+		Size:            file.Size,
+		User:            file.User,
+		Permalink:       file.Permalink,
+		PublicURLShared: file.PublicURLShared,
+		Shares:          []FileShare{},
+	}
+	if file.PublicURLShared {
+		details.PermalinkPublic = file.PermalinkPublic
+	}
+	if file.Created != 0 {
+		details.Created = file.Created.Time().UTC().Format(time.RFC3339)
+	}
+	for _, shares := range []map[string][]slackapi.ShareFileInfo{file.Shares.Public, file.Shares.Private} {
+		for channelID, infos := range shares {
+			for _, info := range infos {
+				details.Shares = append(details.Shares, FileShare{ChannelID: channelID, TS: info.Ts})
+			}
+		}
+	}
+	sort.Slice(details.Shares, func(i, j int) bool {
+		return slack.CompareTS(details.Shares[i].TS, details.Shares[j].TS) < 0
+	})
+	return details
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/ocr"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+var filesFetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Download a file, optionally extracting text from images",
+	Long: `Download a file's content with the token's credentials and save it locally.
+
+--thumbnail SIZE downloads one of the thumbnails Slack renders for images
+(64, 80, 160, 360, 480, 720, 960, or 1024 pixels) instead of the original, which
+keeps large screenshots small enough for vision models.
+
+--ocr runs an OCR program over the downloaded image and returns the text, so text-only
+agents can read screenshots. The program is ocr_command in config, an argument list
+where {file} is replaced by the image path, for example:
+  slk config set ocr_command '["tesseract", "{file}", "stdout", "-l", "eng"]'
+Without it, tesseract must be on PATH. The text is read from the program's stdout.
+
+--out defaults to the file's name in the current directory. Existing files are not
+overwritten unless --force is set.
+
+External files (Google Drive, Dropbox, and other remote files) are refused: the
+download sends the token, so slk only fetches files from Slack's own file hosts.
+
+Output (JSON):
+  {
+    "ok": true,
+    "file_id": "F123ABC",
+    "name": "screenshot.png",
+    "mimetype": "image/png",
+    "path": "screenshot.png",
+    "bytes": 48213,
+    "thumbnail": 720,
+    "text": "ERROR: connection refused (db-primary:5432)"
+  }
+
+Required Scopes:
+  - files:read`,
+	Example: `  # Save a file
+  slk files fetch --file F123ABC --out ./report.pdf
+
+  # Read the text in a screenshot
+  slk files fetch --file F123ABC --out ./f.png --ocr | jq -r .text
+
+  # A 720px thumbnail for a vision model
+  slk files fetch --file F123ABC --thumbnail 720 --out ./thumb.png`,
+	Args: cobra.NoArgs,
+	RunE: runFilesFetch,
+}
+
+func init() {
+	filesCmd.AddCommand(filesFetchCmd)
+
+	filesFetchCmd.Flags().String("file", "", "File ID (required)")
+	filesFetchCmd.Flags().StringP("out", "o", "", "Where to save the file (default: its name in the current directory)")
+	filesFetchCmd.Flags().Int("thumbnail", 0, "Download the thumbnail of this size instead (64, 80, 160, 360, 480, 720, 960, 1024)")
+	filesFetchCmd.Flags().Bool("ocr", false, "Extract text from the image with ocr_command (default: tesseract)")
+	filesFetchCmd.Flags().String("max-bytes", "100m", "Largest download to accept (e.g. 20m)")
+	filesFetchCmd.Flags().Bool("force", false, "Overwrite --out if it exists")
+	filesFetchCmd.MarkFlagRequired("file")
+}
+
+// FileFetchResult is the result of files fetch.
+type FileFetchResult struct {
+	OK        bool   `json:"ok"`
+	FileID    string `json:"file_id"`
+	Name      string `json:"name"`
+	Mimetype  string `json:"mimetype,omitempty"`
+	Path      string `json:"path"`
+	Bytes     int    `json:"bytes"`
+	Thumbnail int    `json:"thumbnail,omitempty"`
+	Text      string `json:"text,omitempty"`
+	OCR       bool   `json:"-"`
+}
+
+// Lines implements output.Printable.
+func (r FileFetchResult) Lines() []string {
+	lines := []string{fmt.Sprintf("Saved %s (%d bytes) to %s", r.Name, r.Bytes, r.Path)}
+	if !r.OCR {
+		return lines
+	}
+	if r.Text == "" {
+		return append(lines, "No text found")
+	}
+	return append(lines, "", r.Text)
+}
+
+func runFilesFetch(cmd *cobra.Command, args []string) error {
+	fileID, _ := cmd.Flags().GetString("file")
+	out, _ := cmd.Flags().GetString("out")
+	thumbnail, _ := cmd.Flags().GetInt("thumbnail")
+	runOCR, _ := cmd.Flags().GetBool("ocr")
+	force, _ := cmd.Flags().GetBool("force")
+	maxBytesInput, _ := cmd.Flags().GetString("max-bytes")
+	maxBytes, err := parseByteSize(maxBytesInput)
+	if err != nil {
+		return cerrors.ConfigError("invalid --max-bytes: %v", err)
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	if err := requireScopes(cmdCtx, "files fetch", "files:read"); err != nil {
+		return err
+	}
+	file, err := cmdCtx.Client.GetFileInfo(cmdCtx.Ctx, fileID)
+	if err != nil {
+		return err
+	}
+	source, err := downloadSource(file, thumbnail)
+	if err != nil {
+		return err
+	}
+	if runOCR && !strings.HasPrefix(file.Mimetype, "image/") {
+		return cerrors.ConfigError("--ocr reads images, but %s is %s", file.Name, file.Mimetype)
+	}
+
+	if out == "" {
+		out = downloadName(source, file.Name)
+	}
+	if _, err := os.Stat(out); err == nil && !force {
+		return cerrors.ConfigError("%s already exists; pass --force to overwrite it", out)
+	}
+
+	data, err := cmdCtx.Client.DownloadFile(cmdCtx.Ctx, source, maxBytes)
+	if errors.Is(err, slack.ErrFileTooLarge) {
+		return cerrors.ConfigError("%s is larger than --max-bytes %s", file.Name, maxBytesInput)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, data, 0o644); err != nil {
+		return fmt.Errorf("save file: %w", err)
+	}
+
+	result := FileFetchResult{
+		OK:        true,
+		FileID:    file.ID,
+		Name:      file.Name,
+		Mimetype:  file.Mimetype,
+		Path:      out,
+		Bytes:     len(data),
+		Thumbnail: thumbnail,
+		OCR:       runOCR,
+	}
+	if runOCR {
+		if result.Text, err = ocr.Run(cmdCtx.Ctx, cmdCtx.Config.OCRCommand, out); err != nil {
+			return cerrors.WrapWithCode(cerrors.ExitGeneral, err, "extract text from %s", out)
+		}
+	}
+	return output.Print(cmd, result)
+}
+
+// downloadSource picks the URL files fetch downloads: the thumbnail of size pixels, or
+// the file itself. The download carries the token, so external files and URLs outside
+// Slack's file hosts are refused rather than fetched.
+func downloadSource(file *slackapi.File, thumbnail int) (string, error) {
+	if file.IsExternal {
+		return "", cerrors.NewErrorWithCode(cerrors.ExitGeneral, "%s is an external file (%s); slk only downloads files stored in Slack, so open it at its own URL instead",
+			file.ID, file.ExternalType)
+	}
+	source := file.URLPrivateDownload
+	if source == "" {
+		source = file.URLPrivate
+	}
+	if thumbnail != 0 {
+		var err error
+		if source, err = thumbnailURL(file, thumbnail); err != nil {
+			return "", err
+		}
+	}
+	if err := slack.CheckFileURL(source); err != nil {
+		return "", cerrors.WrapWithCode(cerrors.ExitGeneral, err, "refusing to send the token to download %s", file.ID)
+	}
+	return source, nil
+}
+
+// thumbnailURL returns the URL of the thumbnail Slack rendered at size pixels.
+func thumbnailURL(file *slackapi.File, size int) (string, error) {
+	thumbs := map[int]string{
+		64: file.Thumb64, 80: file.Thumb80, 160: file.Thumb160, 360: file.Thumb360, 480: file.Thumb480,
+		720: file.Thumb720, 960: file.Thumb960, 1024: file.Thumb1024,
+	}
+	u, ok := thumbs[size]
+	if !ok {
+		return "", cerrors.ConfigError("invalid --thumbnail %d (use 64, 80, 160, 360, 480, 720, 960, or 1024)", size)
+	}
+	if u == "" {
+		return "", cerrors.NotFoundError("thumbnail", fmt.Sprintf("%dpx for %s", size, file.ID), "Hint: Slack only renders thumbnails for images, and none larger than the image itself")
+	}
+	return u, nil
+}
+
+// downloadName is the default --out: the last path element of the download URL,
+// which carries the thumbnail's own extension, or the file's name.
+func downloadName(source, name string) string {
+	if u, err := url.Parse(source); err == nil {
+		if base := path.Base(u.Path); base != "." && base != "/" {
+			return base
+		}
+	}
+	return path.Base("/" + name)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+func TestFileDetails(t *testing.T) {
+	var file slackapi.File
+	if err := json.Unmarshal([]byte(`{
+		"id": "F1", "name": "report.pdf", "filetype": "pdf", "size": 42, "created": 1705315965,
+		"public_url_shared": false, "permalink_public": "https://slack-files.com/T1-F1-abc",
+		"shares": {
+			"public": {"C1": [{"ts": "1705316000.000200"}], "C2": [{"ts": "1705315999.000100"}]},
+			"private": {"G1": [{"ts": "1705316100.000300"}]}
+		}
+	}`), &file); err != nil {
+		t.Fatal(err)
+	}
+
+	details := fileDetails(&file)
+	if details.Created != "2024-01-15T10:52:45Z" {
+		t.Fatalf("created = %s", details.Created)
+	}
+	// Slack always returns permalink_public; it is only usable once shared.
+	if details.PermalinkPublic != "" {
+		t.Fatalf("permalink_public = %q for an unshared file", details.PermalinkPublic)
+	}
+	var order []string
+	for _, s := range details.Shares {
+		order = append(order, s.ChannelID)
+	}
+	if len(order) != 3 || order[0] != "C2" || order[1] != "C1" || order[2] != "G1" {
+		t.Fatalf("shares = %v, want oldest first", order)
+	}
+}
+
+func TestThumbnailURLAndDownloadName(t *testing.T) {
+	file := &slackapi.File{ID: "F1", Name: "Screen Shot.png"}
+	file.Thumb720 = "https://files.slack.com/files-tmb/T1-F1-abc/screen_shot_720.png"
+
+	u, err := thumbnailURL(file, 720)
+	if err != nil || u != file.Thumb720 {
+		t.Fatalf("thumbnailURL(720) = %q, %v", u, err)
+	}
+	if _, err := thumbnailURL(file, 1024); err == nil {
+		t.Fatal("expected an error for a size Slack did not render")
+	}
+	if _, err := thumbnailURL(file, 500); err == nil {
+		t.Fatal("expected an error for an unknown size")
+	}
+
+	if got := downloadName(u, file.Name); got != "screen_shot_720.png" {
+		t.Fatalf("downloadName(thumbnail) = %q", got)
+	}
+	if got := downloadName("", "../../etc/passwd"); got != "passwd" {
+		t.Fatalf("downloadName(no url) = %q", got)
+	}
+}
+
+func TestDownloadSourceRefusesFilesOutsideSlack(t *testing.T) {
+	file := &slackapi.File{ID: "F1", URLPrivate: "https://files.slack.com/files-pri/T1-F1/notes.txt"}
+	if u, err := downloadSource(file, 0); err != nil || u != file.URLPrivate {
+		t.Fatalf("downloadSource(slack file) = %q, %v", u, err)
+	}
+
+	external := &slackapi.File{ID: "F2", IsExternal: true, ExternalType: "gdrive", URLPrivate: "https://docs.google.com/document/d/1"}
+	if _, err := downloadSource(external, 0); err == nil || !strings.Contains(err.Error(), "external file") {
+		t.Fatalf("downloadSource(external) = %v, want an external file error", err)
+	}
+
+	offSlack := &slackapi.File{ID: "F3", URLPrivate: "https://attacker.example.com/notes.txt"}
+	offSlack.Thumb720 = "https://attacker.example.com/thumb_720.png"
+	for _, size := range []int{0, 720} {
+		if _, err := downloadSource(offSlack, size); !errors.Is(err, slack.ErrNotSlackFileURL) {
+			t.Fatalf("downloadSource(off-Slack, %d) = %v, want ErrNotSlackFileURL", size, err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/breaker"
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Inspect the CLI's view of Slack health",
+	Long:  "Inspect local state that affects how the CLI calls Slack.",
+}
+
+var healthAPICmd = &cobra.Command{
+	Use:   "api",
+	Short: "Show per-method circuit breaker states",
+	Long: `Show the circuit breaker of every Slack API method that has failed recently.
+
+A method's breaker opens after circuit_breaker.threshold consecutive failures
+(connection errors, HTTP 5xx, and Slack server errors such as internal_error;
+rate limits, auth, and permission errors do not count). While open, calls to that
+method fail immediately with exit code 9 instead of reaching Slack. Once
+circuit_breaker.cooldown has passed the breaker is half open: the next call is let
+through, and its outcome closes or reopens the breaker.
+
+This command reads local state only and does not call Slack.
+
+Output (JSON):
+  {
+    "ok": true,
+    "threshold": 5,
+    "cooldown": "1m0s",
+    "methods": [
+      {
+        "method": "conversations.history",
+        "state": "open",
+        "failures": 5,
+        "last_error": "502 Bad Gateway",
+        "opened_at": "2024-01-15T10:00:00Z",
+        "retry_at": "2024-01-15T10:01:00Z"
+      }
+    ]
+  }
+
+state is open, half_open, or closed (failing, but below the threshold).`,
+	Example: `  # Which endpoints are being short-circuited?
+  slk health api --human
+
+  # Close one breaker, or all of them, after an outage is over
+  slk health api --reset conversations.history
+  slk health api --reset-all`,
+	Args: cobra.NoArgs,
+	RunE: runHealthAPI,
+}
+
+func init() {
+	rootCmd.AddCommand(healthCmd)
+	healthCmd.AddCommand(healthAPICmd)
+
+	healthAPICmd.Flags().String("reset", "", "Close the breaker of this API method (e.g. chat.postMessage)")
+	healthAPICmd.Flags().Bool("reset-all", false, "Close every breaker")
+	healthAPICmd.MarkFlagsMutuallyExclusive("reset", "reset-all")
+}
+
+// HealthAPIResult lists circuit breaker states.
+type HealthAPIResult struct {
+	OK        bool             `json:"ok"`
+	Threshold int              `json:"threshold"`
+	Cooldown  string           `json:"cooldown"`
+	Methods   []breaker.Status `json:"methods"`
+}
+
+// Lines implements output.Printable.
+func (r *HealthAPIResult) Lines() []string {
+	if r.Threshold == 0 {
+		return []string{"Circuit breakers are disabled (circuit_breaker.threshold is 0)."}
+	}
+	if len(r.Methods) == 0 {
+		return []string{"All API methods healthy."}
+	}
+	title := fmt.Sprintf("API Circuit Breakers (threshold %d, cooldown %s)", r.Threshold, r.Cooldown)
+	lines := []string{title, strings.Repeat("-", len(title))}
+	for _, m := range r.Methods {
+		line := fmt.Sprintf("%-10s %s: %d failures", m.State, m.Method, m.Failures)
+		if m.LastError != "" {
+			line += ", last: " + m.LastError
+		}
+		if m.State == breaker.StateOpen && m.RetryAt != nil {
+			line += ", retry at " + m.RetryAt.Local().Format(time.RFC3339)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func runHealthAPI(cmd *cobra.Command, args []string) error {
+	cfg, path, err := config.Load(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+	b, err := loadCircuitBreaker(cfg, path)
+	if err != nil {
+		return cerrors.ConfigError("%v", err)
+	}
+
+	reset, _ := cmd.Flags().GetString("reset")
+	resetAll, _ := cmd.Flags().GetBool("reset-all")
+	if reset != "" || resetAll {
+		if err := b.Reset(reset); err != nil {
+			return err
+		}
+	}
+
+	return output.Print(cmd, &HealthAPIResult{
+		OK:        true,
+		Threshold: b.Threshold(),
+		Cooldown:  b.Cooldown().String(),
+		Methods:   b.Statuses(),
+	})
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var huddlesCmd = &cobra.Command{
+	Use:   "huddles",
+	Short: "Huddle operations",
+	Long:  "View huddles held in Slack channels.",
+}
+
+var huddlesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recent huddles in a channel",
+	Long: `List recent huddles in a channel, newest first.
+
+Huddles appear in channel history as messages with subtype "huddle_thread". This
+command scans recent history for them and reports when each huddle ran, who started
+it, and everyone who joined. Chat from the huddle is the thread on that message;
+read it with: slk messages list --channel <channel> --thread <ts>
+
+Output (JSON):
+  {
+    "channel": "#general",
+    "channel_id": "C123ABC",
+    "huddles": [
+      {
+        "id": "R123ABC",
+        "channel": "C123ABC",
+        "ts": "1705312365.000100",
+        "created_by": "@alice",
+        "created_by_id": "U123ABC",
+        "date_start": 1705312365,
+        "date_end": 1705314165,
+        "has_ended": true,
+        "participants": ["@alice", "@bob"],
+        "participant_ids": ["U123ABC", "U456DEF"],
+        "duration_seconds": 1800
+      }
+    ],
+    "has_more": false
+  }`,
+	Example: `  # Recent huddles in #general
+  slk huddles list --channel "#general"
+
+  # Huddles from the last week
+  slk huddles list --channel "#general" --since 7d`,
+	RunE: runHuddlesList,
+}
+
+func init() {
+	rootCmd.AddCommand(huddlesCmd)
+	huddlesCmd.AddCommand(huddlesListCmd)
+
+	huddlesListCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	huddlesListCmd.Flags().IntP("limit", "l", 20, "Maximum huddles to return")
+	huddlesListCmd.Flags().String("since", "", "Huddles after this time (ISO or relative like 7d)")
+	huddlesListCmd.Flags().String("until", "", "Huddles before this time")
+	huddlesListCmd.Flags().Int("max-messages", 1000, "Maximum history messages to scan for huddles")
+	huddlesListCmd.Flags().Bool("raw-json", false, "Preserve raw Slack user IDs in JSON output")
+	huddlesListCmd.MarkFlagRequired("channel")
+}
+
+func runHuddlesList(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	channelInput, _ := cmd.Flags().GetString("channel")
+	limit, _ := cmd.Flags().GetInt("limit")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	maxMessages, _ := cmd.Flags().GetInt("max-messages")
+	rawJSON, _ := cmd.Flags().GetBool("raw-json")
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+	oldest, latest, err := slack.ParseTimeRange(since, until)
+	if err != nil {
+		return err
+	}
+
+	huddles, hasMore, err := cmdCtx.Client.ListHuddles(cmdCtx.Ctx, slack.HuddleParams{
+		Channel:     channelID,
+		Oldest:      oldest,
+		Latest:      latest,
+		Limit:       limit,
+		MaxMessages: maxMessages,
+	})
+	if err != nil {
+		return err
+	}
+
+	channelName := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID)
+	if channelName == "" || channelName == channelID {
+		channelName = channelInput
+	}
+	if !strings.HasPrefix(channelName, "#") && !strings.HasPrefix(channelName, "@") && !isChannelID(channelName) {
+		channelName = "#" + channelName
+	}
+	result := &slack.HuddleListResult{
+		Channel:   channelName,
+		ChannelID: channelID,
+		Huddles:   huddles,
+		HasMore:   hasMore,
+	}
+	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
+	result.SetRawJSON(rawJSON)
+
+	if len(huddles) == 0 && hasMore {
+		output.Statusf("No huddles in the last %d messages; raise --max-messages to scan further\n", maxMessages)
+	}
+	return output.Print(cmd, result)
+}
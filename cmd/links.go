@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/links"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+)
+
+// newLinkExpanders compiles the expanders in config, or returns nil when none are set.
+func newLinkExpanders(cfg *config.Config) (*links.Set, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	expanders, err := links.New(cfg.Expanders)
+	if err != nil {
+		return nil, cerrors.ConfigError("expanders: %v", err)
+	}
+	return expanders, nil
+}
+
+// withLinks expands ticket references in a result's human-readable output. JSON output
+// is left as Slack returned it. A nil set returns the result unchanged.
+func withLinks(result output.Printable, expanders *links.Set) output.Printable {
+	if expanders == nil {
+		return result
+	}
+	return linkedResult{result: result, expanders: expanders}
+}
+
+// linkedResult adds links after ticket references in human-readable lines.
+type linkedResult struct {
+	result    output.Printable
+	expanders *links.Set
+}
+
+func (r linkedResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.result)
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r linkedResult) Lines() []string {
+	lines := r.result.Lines()
+	for i, line := range lines {
+		lines[i] = r.expanders.Plain(line)
+	}
+	return lines
+}
@@ -1,17 +1,25 @@
 package cmd
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kehao95/slack-agent-cli/internal/config"
 	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
 	"github.com/kehao95/slack-agent-cli/internal/eventstore"
+	"github.com/kehao95/slack-agent-cli/internal/idempotency"
 	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/mrkdwn"
 	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/policy"
 	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -43,6 +51,15 @@ Output (JSON):
         "edited": {"user": "@alice", "user_id": "U123ABC", "ts": "..."},
         "reactions": [{"name": "thumbsup", "count": 2, "users": ["@alice"], "user_ids": ["U123ABC"]}],
         "reply_count": 5  // Number of replies in thread
+      },
+      {
+        "type": "message",
+        "bot_id": "B123ABC",
+        "bot_name": "deploybot",   // Bot messages: name and app from bots.info (cached)
+        "app_id": "A123ABC",
+        "username": "deploybot",
+        "text": "deploy finished",
+        "ts": "1705312400.000200"
       }
     ],
     "has_more": true,
@@ -54,7 +71,12 @@ By default JSON resolves channel and user references for readability while prese
 Channel Resolution:
   - Channel IDs (C123ABC) work directly without cache lookup
   - Channel names (#general) use cache, fallback to API if not found
-  - Use 'cache populate channels' to pre-warm cache and avoid API calls`,
+  - Use 'cache populate channels' to pre-warm cache and avoid API calls
+
+Scrubbing:
+  - --scrub pii replaces emails, phone numbers, and any scrub.patterns from config with
+    [REDACTED:<pattern>] everywhere in the output, including names and blocks
+  - Fetched file content (--fetch-files) is not scrubbed`,
 	Example: `  # Get last 20 messages
   slk messages list --channel "#general" --limit 20
 
@@ -64,6 +86,24 @@ Channel Resolution:
   # Get thread replies
   slk messages list --channel "#general" --thread "1705312365.000100"
   
+  # Only ordinary messages and bot posts (subtype filtering applies after fetching --limit)
+  slk messages list --channel "#general" --subtypes none,bot_message
+
+  # Only what people wrote, skipping bot and integration posts
+  slk messages list --channel "#incidents" --humans-only
+
+  # Structured URLs, mentions, and emoji per message
+  slk messages list --channel "#general" --extract entities
+
+  # Inline small text/csv/log attachments so their content is readable directly
+  slk messages list --channel "#alerts" --fetch-files --max-file-bytes 64k
+
+  # Message text as standard Markdown instead of Slack mrkdwn
+  slk messages list --channel "#general" --render-mrkdwn
+
+  # Mask emails and phone numbers before handing messages to another service
+  slk messages list --channel "#support" --scrub pii
+
   # Force refresh cached channel/user metadata
   slk messages list --channel "#general" --refresh-cache
 
@@ -113,6 +153,12 @@ Search Syntax:
   # Search and sort by timestamp
   slk messages search --query "error" --sort timestamp --limit 20
 
+  # Include parsed URLs, mentions, and emoji for each match
+  slk messages search --query "in:#ops deploy" --extract entities
+
+  # Mask emails and phone numbers in the matches
+  slk messages search --query "in:#support refund" --scrub pii
+
   # Search and sort by relevance
   slk messages search --query "bug" --sort score`,
 	RunE: runMessagesSearch,
@@ -142,11 +188,72 @@ Text Input:
   - Use --text only for plain text when you intentionally do not want Slack formatting
   - Use --mrkdwn - or --text - to read that format from stdin
   - The CLI does not validate or convert formatting; Slack receives the text as-is
+  - Add --convert-markdown to convert GitHub-flavored Markdown (**bold**, [text](url), # headings, - lists) to mrkdwn first
   - Slack mrkdwn is not GitHub/CommonMark Markdown
   - Slack mrkdwn examples: *bold*, _italic_, ~strike~, inline code with backticks, triple-backtick code blocks, <https://example.com|link text>, <@USERID>
   - Slack top-level message text has no real bullet-list syntax; mimic lists with plain lines like "- item"
   - Use --blocks for true rich lists, headings, or more structured layouts
-  - Slack message text does not support Markdown headings or tables`,
+  - Larger payloads can come from --blocks - (stdin), --blocks-file, or --blocks-template
+    with --var key=value; in templates, {{.key}} inserts a value and {{json .key}} inserts
+    it as a quoted JSON string
+  - Slack message text does not support Markdown headings or tables
+
+Mentions:
+  - Slack only notifies people for <@U123> mentions; plain "@alice" in text is not a mention
+  - --resolve-mentions rewrites @name, #channel, @here, @channel, and @everyone into Slack syntax
+    using the cached user and channel lists; unknown names are left as written and reported
+    in "unresolved_mentions"
+  - --link-names instead asks Slack to link names server-side (link_names=1)
+
+Rendering:
+  - --no-preview turns off link and media unfurls, so URLs and GIF links stay one line
+  - --parse full has Slack link URLs, @names, and #channels in the text itself; --parse none
+    leaves the text as written
+  - --no-mrkdwn sends mrkdwn=false: *bold*, _italic_, and backticks appear literally, which
+    suits logs and command output (the --mrkdwn flag is the message text, hence the name)
+  - --parse and --no-mrkdwn do not apply to messages sent with --file
+
+Traceability:
+  - --trace-id stores the ID of the job or run that sent the message in Slack message
+    metadata (event_type "slk_trace", event_payload {"trace_id": ...}), echoes it as
+    "trace_id" in the output, and records it as slk.trace_id on the command's trace span
+  - --context adds a JSON object's fields to the same payload, e.g. '{"job":"deploy","run":42}'
+  - Read it back with conversations.history include_all_metadata=true, or from any tool that
+    receives message events; metadata is not shown in the Slack client
+  - Not available with --file
+
+Bot Identity:
+  - --username and --icon-emoji or --icon-url override the bot's name and icon for one message
+  - They need role=bot (SLACK_CLI_ROLE=bot) and the chat:write.customize bot scope, so several agent personas can share one app
+
+Retries:
+  - --idempotency-key records the key in a ledger in the cache directory (kept 7 days)
+  - Repeating a key returns the original result with "replayed": true and posts nothing
+  - Reusing a key for a different channel or message text is an error
+  - If an earlier send with the key died before Slack confirmed it, the command fails
+    rather than risk a duplicate; check the channel before sending again with a new key
+
+Duplicates:
+  - With dedupe_window set in config (e.g. "5m"), text identical to a message this CLI
+    sent to the same channel and thread within the window is refused with exit code 8
+  - dedupe_action "warn" posts anyway and prints a warning; --allow-duplicate skips the check
+  - When a window is set, the output includes a "dedupe" decision
+
+Secrets:
+  - API keys, AWS credentials, JWTs, and email addresses in the text or blocks are replaced
+    with [REDACTED:<pattern>] before posting, and "redacted" reports what was masked
+  - Configure patterns under redaction in config; --no-redact posts the content as-is
+  - Files attached with --file are uploaded unchanged
+
+Files:
+  - --file uploads a file (files.getUploadURLExternal and files.completeUploadExternal,
+    like files.uploadV2) and shares it in the channel or --thread, with --mrkdwn, --text,
+    or --blocks as the message; the message text is optional
+  - The output adds "file" with its id, name, size, and permalink; "ts" is the message
+    the file was shared in, or empty if Slack had not finished sharing it within a few seconds
+  - --broadcast, --username, --icon-emoji, and --icon-url do not apply to uploads, and a
+    quiet-hours "queue" decision is refused because uploads cannot be scheduled
+  - Needs the files:write scope`,
 	Example: `  # Simple message
   slk messages send --channel "#general" --mrkdwn "Hello from CLI!"
 
@@ -156,14 +263,36 @@ Text Input:
   # Reply in thread
   slk messages send --channel "#general" --thread "1705312365.000100" --mrkdwn "Thread reply"
 
+  # Reply in thread and also show the reply in the channel
+  slk messages send --channel "#general" --thread "1705312365.000100" --broadcast --mrkdwn "Fixed, see thread"
+
   # Read Slack mrkdwn from stdin
   printf '*Done:* see <https://example.com|docs>\n' | slk messages send --channel "#general" --mrkdwn -
 
+  # Convert agent-written Markdown to Slack mrkdwn
+  printf '**Done:** see [docs](https://example.com)\n' | slk messages send --channel "#general" --mrkdwn - --convert-markdown
+
   # Mimic a list in Slack mrkdwn
   printf '*Plan:*\n- claim root messages\n- route thread replies\n' | slk messages send --channel "#general" --mrkdwn -
 
+  # Turn plain @names and #channels into real mentions
+  slk messages send --channel "#ops" --text "@alice can you check #incidents?" --resolve-mentions
+
+  # Block Kit from a file, or rendered from a template
+  slk messages send --channel "#deploys" --blocks-file deploy.json
+  slk messages send --channel "#deploys" --blocks-template deploy.tmpl --var version=v1.2.3 --var "summary=All checks green"
+
+  # Attach a report to the message
+  slk messages send --channel "#reports" --text "report attached" --file ./report.pdf
+
   # Send to user DM
-  slk messages send --channel "@alice" --mrkdwn "Private message"`,
+  slk messages send --channel "@alice" --mrkdwn "Private message"
+
+  # Safe to retry: a second run with the same key does not post again
+  slk messages send --channel "#deploys" --mrkdwn "Deployed v1.2.3" --idempotency-key deploy-v1.2.3
+
+  # Post as a named bot persona (bot token with chat:write.customize)
+  SLACK_CLI_ROLE=bot slk messages send --channel "#deploys" --username "Deploy Bot" --icon-emoji :rocket: --mrkdwn "Deployed v1.2.3"`,
 	RunE: runMessagesSend,
 }
 
@@ -247,6 +376,14 @@ func init() {
 	messagesListCmd.Flags().Bool("refresh-cache", false, "Force refresh of cached channel/user metadata")
 	messagesListCmd.Flags().Bool("resolved-json", true, "Resolve channel and user references in JSON output")
 	messagesListCmd.Flags().Bool("raw-json", false, "Preserve raw Slack IDs in JSON output")
+	messagesListCmd.Flags().Bool("render-mrkdwn", false, "Convert message text from Slack mrkdwn to Markdown")
+	messagesListCmd.Flags().String("extract", "", "Add structured data to each message in JSON output: entities")
+	addSubtypeFlags(messagesListCmd, "messages")
+	addAuthorFlags(messagesListCmd, "messages")
+	messagesListCmd.Flags().Bool("fetch-files", false, "Download small text attachments (text, csv, log, json) and inline their content")
+	messagesListCmd.Flags().String("max-file-bytes", "64k", "Largest attachment to fetch with --fetch-files (e.g. 64k, 1m)")
+	messagesListCmd.Flags().String("files-dir", "", "Save fetched attachments here and output content_path instead of inline content")
+	addScrubFlag(messagesListCmd)
 	messagesListCmd.MarkFlagRequired("channel")
 
 	messagesSearchCmd.Flags().StringP("query", "q", "", "Search query (required)")
@@ -255,27 +392,57 @@ func init() {
 	messagesSearchCmd.Flags().String("sort-dir", "desc", "Sort direction 'asc' or 'desc'")
 	messagesSearchCmd.Flags().Bool("resolved-json", true, "Resolve channel and user references in JSON output")
 	messagesSearchCmd.Flags().Bool("raw-json", false, "Preserve raw Slack IDs in JSON output")
+	messagesSearchCmd.Flags().String("extract", "", "Add structured data to each match in JSON output: entities")
+	addScrubFlag(messagesSearchCmd)
 	messagesSearchCmd.MarkFlagRequired("query")
 
 	messagesSendCmd.Flags().StringP("channel", "c", "", "Target channel or @user (required)")
 	messagesSendCmd.Flags().StringP("mrkdwn", "m", "", "Slack mrkdwn message text (sent as-is)")
 	messagesSendCmd.Flags().StringP("text", "t", "", "Plain message text (sent as-is; no Slack formatting intent)")
 	messagesSendCmd.Flags().String("thread", "", "Thread timestamp to reply in")
-	messagesSendCmd.Flags().String("blocks", "", "Block Kit JSON")
+	messagesSendCmd.Flags().String("blocks", "", "Block Kit JSON, or - to read it from stdin")
+	messagesSendCmd.Flags().String("blocks-file", "", "Read Block Kit JSON from a file")
+	messagesSendCmd.Flags().String("blocks-template", "", "Render Block Kit JSON from a Go template file (see --var)")
+	messagesSendCmd.Flags().StringArray("var", nil, "Template variable key=value for --blocks-template (repeatable)")
+	messagesSendCmd.MarkFlagsMutuallyExclusive("blocks", "blocks-file", "blocks-template")
+	messagesSendCmd.Flags().Bool("broadcast", false, "Also show the thread reply in the channel (requires --thread)")
 	messagesSendCmd.Flags().Bool("unfurl-links", true, "Unfurl URLs in message")
 	messagesSendCmd.Flags().Bool("unfurl-media", true, "Unfurl media in message")
+	messagesSendCmd.Flags().Bool("no-preview", false, "Suppress link and media previews, including GIFs (same as --unfurl-links=false --unfurl-media=false)")
+	messagesSendCmd.MarkFlagsMutuallyExclusive("no-preview", "unfurl-links")
+	messagesSendCmd.MarkFlagsMutuallyExclusive("no-preview", "unfurl-media")
+	messagesSendCmd.Flags().String("parse", "", "How Slack parses the text: full (link URLs, names, and channels itself) or none")
+	messagesSendCmd.Flags().Bool("no-mrkdwn", false, "Send mrkdwn=false so Slack shows formatting characters literally")
+	messagesSendCmd.Flags().Bool("convert-markdown", false, "Convert GitHub-flavored Markdown in --mrkdwn/--text to Slack mrkdwn")
+	messagesSendCmd.Flags().Bool("resolve-mentions", false, "Rewrite plain @user and #channel in --mrkdwn/--text into Slack mentions using the cache")
+	messagesSendCmd.Flags().Bool("link-names", false, "Ask Slack to link @names and #channels itself (link_names=1)")
+	messagesSendCmd.Flags().String("username", "", "Display name to post under (bot token with chat:write.customize)")
+	messagesSendCmd.Flags().String("icon-emoji", "", "Emoji to use as the message icon, e.g. :rocket: (bot token with chat:write.customize)")
+	messagesSendCmd.Flags().String("icon-url", "", "Image URL to use as the message icon (bot token with chat:write.customize)")
+	messagesSendCmd.MarkFlagsMutuallyExclusive("icon-emoji", "icon-url")
+	messagesSendCmd.Flags().String("file", "", "Upload this file and share it in the channel or thread with the message")
+	messagesSendCmd.Flags().String("file-title", "", "Title for --file (default: the file name)")
+	messagesSendCmd.Flags().Bool("allow-duplicate", false, "Send even if dedupe_window finds an identical recent message")
+	messagesSendCmd.Flags().String("idempotency-key", "", "Post at most once per key; repeating a key returns the original ts without reposting")
+	messagesSendCmd.Flags().String("trace-id", "", "ID of the job or run that sent the message, stored in the message metadata")
+	messagesSendCmd.Flags().String("context", "", "JSON object stored in the message metadata alongside --trace-id")
 	messagesSendCmd.MarkFlagRequired("channel")
+	addQuietHoursFlag(messagesSendCmd)
+	addRedactFlag(messagesSendCmd)
 
 	messagesEditCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
 	messagesEditCmd.Flags().String("ts", "", "Message timestamp (required)")
 	messagesEditCmd.Flags().StringP("text", "t", "", "New message text (required)")
 	messagesEditCmd.MarkFlagRequired("channel")
+	addQuietHoursFlag(messagesEditCmd)
+	addRedactFlag(messagesEditCmd)
 	messagesEditCmd.MarkFlagRequired("ts")
 	messagesEditCmd.MarkFlagRequired("text")
 
 	messagesDeleteCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
 	messagesDeleteCmd.Flags().String("ts", "", "Message timestamp (required)")
 	messagesDeleteCmd.MarkFlagRequired("channel")
+	addQuietHoursFlag(messagesDeleteCmd)
 	messagesDeleteCmd.MarkFlagRequired("ts")
 
 	messagesNextCmd.Flags().StringP("channel", "c", "", "Channel name or ID")
@@ -304,6 +471,26 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 	refreshCache, _ := cmd.Flags().GetBool("refresh-cache")
 	rawJSON, _ := cmd.Flags().GetBool("raw-json")
 	resolvedJSON, _ := cmd.Flags().GetBool("resolved-json")
+	renderMrkdwn, _ := cmd.Flags().GetBool("render-mrkdwn")
+	extractEntities, err := parseExtractFlag(cmd)
+	if err != nil {
+		return err
+	}
+	subtypes, err := parseSubtypeFlags(cmd)
+	if err != nil {
+		return err
+	}
+	authors, err := parseAuthorFlags(cmd)
+	if err != nil {
+		return err
+	}
+	fetchFiles, _ := cmd.Flags().GetBool("fetch-files")
+	maxFileBytesInput, _ := cmd.Flags().GetString("max-file-bytes")
+	filesDir, _ := cmd.Flags().GetString("files-dir")
+	maxFileBytes, err := parseByteSize(maxFileBytesInput)
+	if err != nil {
+		return fmt.Errorf("invalid --max-file-bytes: %w", err)
+	}
 
 	// Handle cache refresh
 	if refreshCache {
@@ -316,6 +503,18 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 		if err := cmdCtx.UserGroupResolver.RefreshCache(cmdCtx.Ctx); err != nil {
 			return fmt.Errorf("refresh usergroup cache: %w", err)
 		}
+		if err := cmdCtx.BotResolver.RefreshCache(cmdCtx.Ctx); err != nil {
+			return fmt.Errorf("refresh bot cache: %w", err)
+		}
+	}
+
+	scrubber, err := newScrubber(cmd, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	expanders, err := newLinkExpanders(cmdCtx.Config)
+	if err != nil {
+		return err
 	}
 
 	channelID, err := cmdCtx.ResolveChannel(channelInput)
@@ -333,6 +532,9 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	result.Messages = subtypes.Apply(result.Messages)
+	result.Messages = authors.Apply(result.Messages)
+
 	// Set display metadata
 	result.Channel = channelID
 	// Resolve channel name for both JSON and human-readable output
@@ -344,9 +546,17 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 	}
 	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
 	result.SetUserGroupResolver(cmdCtx.Ctx, cmdCtx.UserGroupResolver)
+	result.SetBotResolver(cmdCtx.Ctx, cmdCtx.BotResolver)
 	result.SetRawJSON(rawJSON || !resolvedJSON)
+	result.SetRenderMarkdown(renderMrkdwn)
+	result.SetExtractEntities(extractEntities)
+	if fetchFiles {
+		if err := result.FetchFiles(cmdCtx.Ctx, cmdCtx.Client, messages.FetchFilesOptions{MaxBytes: maxFileBytes, Dir: filesDir}); err != nil {
+			return err
+		}
+	}
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withLinks(withScrub(result, scrubber), expanders))
 }
 
 // isChannelID checks if a string looks like a channel ID (starts with C, D, or G followed by alphanumerics)
@@ -371,6 +581,10 @@ func runMessagesSearch(cmd *cobra.Command, args []string) error {
 	sortDir, _ := cmd.Flags().GetString("sort-dir")
 	rawJSON, _ := cmd.Flags().GetBool("raw-json")
 	resolvedJSON, _ := cmd.Flags().GetBool("resolved-json")
+	extractEntities, err := parseExtractFlag(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Validate sort parameters
 	if sortBy != "score" && sortBy != "timestamp" {
@@ -379,6 +593,14 @@ func runMessagesSearch(cmd *cobra.Command, args []string) error {
 	if sortDir != "asc" && sortDir != "desc" {
 		return fmt.Errorf("invalid sort-dir value '%s': must be 'asc' or 'desc'", sortDir)
 	}
+	scrubber, err := newScrubber(cmd, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	expanders, err := newLinkExpanders(cmdCtx.Config)
+	if err != nil {
+		return err
+	}
 
 	userClient := slack.NewUserClient(cmdCtx.AuthToken)
 	result, err := userClient.SearchMessages(cmdCtx.Ctx, query, slack.SearchParams{
@@ -394,27 +616,68 @@ func runMessagesSearch(cmd *cobra.Command, args []string) error {
 	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
 	result.SetChannelResolver(cmdCtx.Ctx, cmdCtx.ChannelResolver)
 	result.SetRawJSON(rawJSON || !resolvedJSON)
+	result.SetExtractEntities(extractEntities)
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withLinks(withScrub(result, scrubber), expanders))
 }
 
 func runMessagesSend(cmd *cobra.Command, args []string) error {
 	channelInput, _ := cmd.Flags().GetString("channel")
 	text, _ := cmd.Flags().GetString("text")
-	mrkdwn, _ := cmd.Flags().GetString("mrkdwn")
+	mrkdwnText, _ := cmd.Flags().GetString("mrkdwn")
 	thread, _ := cmd.Flags().GetString("thread")
-	blocksJSON, _ := cmd.Flags().GetString("blocks")
+	broadcast, _ := cmd.Flags().GetBool("broadcast")
 	unfurlLinks, _ := cmd.Flags().GetBool("unfurl-links")
 	unfurlMedia, _ := cmd.Flags().GetBool("unfurl-media")
+	noPreview, _ := cmd.Flags().GetBool("no-preview")
+	parse, _ := cmd.Flags().GetString("parse")
+	noMrkdwn, _ := cmd.Flags().GetBool("no-mrkdwn")
+	convertMarkdown, _ := cmd.Flags().GetBool("convert-markdown")
+	resolveMentions, _ := cmd.Flags().GetBool("resolve-mentions")
+	linkNames, _ := cmd.Flags().GetBool("link-names")
+	username, _ := cmd.Flags().GetString("username")
+	iconEmoji, _ := cmd.Flags().GetString("icon-emoji")
+	iconURL, _ := cmd.Flags().GetString("icon-url")
+	filePath, _ := cmd.Flags().GetString("file")
+	fileTitle, _ := cmd.Flags().GetString("file-title")
+	traceID, _ := cmd.Flags().GetString("trace-id")
+	contextJSON, _ := cmd.Flags().GetString("context")
+
+	if broadcast && thread == "" {
+		return fmt.Errorf("--broadcast applies to thread replies; add --thread")
+	}
+	if parse != "" && parse != "full" && parse != "none" {
+		return cerrors.ConfigError("invalid --parse %q (use full or none)", parse)
+	}
+	if noPreview {
+		unfurlLinks, unfurlMedia = false, false
+	}
+	metadata, err := traceMetadata(traceID, contextJSON)
+	if err != nil {
+		return cerrors.ConfigError("%v", err)
+	}
+	if traceID != "" {
+		if span := tracing.SpanFromContext(cmd.Context()); span != nil {
+			span.SetAttribute("slk.trace_id", traceID)
+		}
+	}
+
+	if blocksArg, _ := cmd.Flags().GetString("blocks"); blocksArg == "-" && (mrkdwnText == "-" || text == "-") {
+		return fmt.Errorf("only one of --mrkdwn, --text, or --blocks can read stdin")
+	}
 
 	// Parse blocks if provided
+	blocksJSON, err := readBlocksFlags(cmd)
+	if err != nil {
+		return err
+	}
 	blocks, err := parseBlocksJSON(blocksJSON)
 	if err != nil {
 		return err
 	}
 
-	if mrkdwn == "-" {
-		mrkdwn, err = readRequiredStdin("mrkdwn")
+	if mrkdwnText == "-" {
+		mrkdwnText, err = readRequiredStdin("mrkdwn")
 		if err != nil {
 			return err
 		}
@@ -426,19 +689,51 @@ func runMessagesSend(cmd *cobra.Command, args []string) error {
 		}
 	}
 	inputCount := 0
-	if mrkdwn != "" {
+	if mrkdwnText != "" {
 		inputCount++
-		text = mrkdwn
+		text = mrkdwnText
 	}
-	if text != "" && mrkdwn == "" {
+	if text != "" && mrkdwnText == "" {
 		inputCount++
 	}
 	if len(blocks) > 0 {
 		inputCount++
 	}
-	if inputCount != 1 {
+	if inputCount > 1 || (inputCount == 0 && filePath == "") {
 		return fmt.Errorf("choose exactly one message input: --mrkdwn, --text, or --blocks")
 	}
+	var fileContent []byte
+	fileHash := ""
+	if filePath != "" {
+		if broadcast {
+			return cerrors.ConfigError("--broadcast cannot be combined with --file")
+		}
+		if username != "" || iconEmoji != "" || iconURL != "" {
+			return cerrors.ConfigError("--username, --icon-emoji, and --icon-url cannot be combined with --file")
+		}
+		if parse != "" || noMrkdwn {
+			return cerrors.ConfigError("--parse and --no-mrkdwn cannot be combined with --file")
+		}
+		if metadata != nil {
+			return cerrors.ConfigError("--trace-id and --context cannot be combined with --file; file uploads carry no message metadata")
+		}
+		if fileContent, err = os.ReadFile(filePath); err != nil {
+			return cerrors.ConfigError("read --file: %v", err)
+		}
+		if len(fileContent) == 0 {
+			return cerrors.ConfigError("--file %s is empty", filePath)
+		}
+		fileHash = fmt.Sprintf("%x", sha256.Sum256(fileContent))
+	}
+	if convertMarkdown {
+		if len(blocks) > 0 {
+			return fmt.Errorf("--convert-markdown applies to --mrkdwn or --text, not --blocks")
+		}
+		text = mrkdwn.FromMarkdown(text)
+	}
+	if resolveMentions && len(blocks) > 0 {
+		return fmt.Errorf("--resolve-mentions applies to --mrkdwn or --text, not --blocks")
+	}
 
 	cmdCtx, err := NewCommandContext(cmd, 0)
 	if err != nil {
@@ -446,29 +741,150 @@ func runMessagesSend(cmd *cobra.Command, args []string) error {
 	}
 	defer cmdCtx.Close()
 
-	// Resolve channel name to ID
-	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	// Mask secrets before anything is fingerprinted, recorded, or posted.
+	text, redactedBlocks, redactions, err := redactMessage(cmd, cmdCtx.Config, text, blocksJSON)
+	if err != nil {
+		return err
+	}
+	if redactedBlocks != blocksJSON {
+		blocksJSON = redactedBlocks
+		if blocks, err = parseBlocksJSON(blocksJSON); err != nil {
+			return err
+		}
+	}
+
+	customized := username != "" || iconEmoji != "" || iconURL != ""
+	if customized && cmdCtx.AuthRole != config.RoleBot {
+		return cerrors.ConfigError("--username, --icon-emoji, and --icon-url need a bot token; set SLACK_CLI_ROLE=bot or role=bot in config (the app needs chat:write.customize)")
+	}
+
+	// Resolve channel name to ID; @user sends to the DM with that user.
+	resolve := cmdCtx.ResolveChannel
+	if strings.HasPrefix(channelInput, "@") {
+		resolve = cmdCtx.ResolveDM
+	}
+	channelID, err := resolve(channelInput)
+	if err != nil {
+		return err
+	}
+
+	// A reused idempotency key replays the original result instead of posting again.
+	idempotencyKey, _ := cmd.Flags().GetString("idempotency-key")
+	guard, replay, err := reserveSend(cmdCtx, idempotencyKey, idempotency.Fingerprint(channelID, thread, strconv.FormatBool(broadcast), text, blocksJSON, fileHash))
+	if err != nil {
+		return err
+	}
+	if replay != nil {
+		return output.Print(cmd, replay)
+	}
+	defer guard.Close()
+
+	// With dedupe_window set, refuse text this CLI just posted to the same place.
+	history, dedupe, err := checkDedupe(cmd, cmdCtx, channelID, idempotency.Fingerprint(channelID, thread, text, blocksJSON, fileHash))
+	if err != nil {
+		guard.Abandon(cmdCtx, nil)
+		return err
+	}
+	defer history.Close()
+
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, true)
 	if err != nil {
+		guard.Abandon(cmdCtx, nil)
 		return err
 	}
 
-	// Send the message
-	result, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, channelID, slack.PostMessageOptions{
+	var unresolved []string
+	if resolveMentions {
+		text, unresolved = mrkdwn.LinkMentions(text, cmdCtx.ResolveMention)
+	}
+
+	msgOpts := slack.PostMessageOptions{
 		Text:        text,
 		ThreadTS:    thread,
+		Broadcast:   broadcast,
+		LinkNames:   linkNames,
 		Blocks:      blocks,
 		UnfurlLinks: unfurlLinks,
 		UnfurlMedia: unfurlMedia,
+		Parse:       parse,
+		NoMrkdwn:    noMrkdwn,
+		Metadata:    metadata,
 		AsUser:      cmdCtx.AuthRole == config.RoleUser,
-	})
+		Username:    username,
+		IconEmoji:   iconEmoji,
+		IconURL:     iconURL,
+	}
+
+	if filePath != "" && decision != nil && decision.Action == policy.ActionQueue {
+		guard.Abandon(cmdCtx, nil)
+		return cerrors.WithCode(cerrors.CodeQuietHours, cerrors.PolicyError("quiet hours would queue this message until %s, but file uploads cannot be scheduled", decision.NextAllowed.Format(time.RFC3339)))
+	}
+
+	// Outside quiet hours with action "queue", schedule the message for the next window.
+	if decision != nil && decision.Action == policy.ActionQueue {
+		postAt := *decision.NextAllowed
+		if lead := time.Now().Add(minScheduleLead); postAt.Before(lead) {
+			postAt = lead
+		}
+		scheduledID, err := cmdCtx.Client.ScheduleMessage(cmdCtx.Ctx, channelID, postAt, msgOpts)
+		if err != nil {
+			guard.Abandon(cmdCtx, err)
+			return err
+		}
+		queued := &QueuedMessageResult{
+			OK:                 true,
+			Channel:            channelInput,
+			ChannelID:          channelID,
+			ScheduledMessageID: scheduledID,
+			PostAt:             postAt.In(decision.NextAllowed.Location()).Format(time.RFC3339),
+			Text:               text,
+			IdempotencyKey:     idempotencyKey,
+			TraceID:            traceID,
+		}
+		guard.Complete(cmdCtx, queued)
+		history.Record(cmdCtx, "")
+		return output.Print(cmd, withRedactions(withDecision(withPolicy(queued, decision), "dedupe", dedupe), redactions))
+	}
+
+	// Send the message, or share the file with it as the message.
+	var result *slack.PostMessageResult
+	if filePath != "" {
+		result, err = uploadMessageFile(cmdCtx, channelID, slack.UploadFileOptions{
+			ThreadTS: thread,
+			Filename: filepath.Base(filePath),
+			Title:    fileTitle,
+			Content:  fileContent,
+			Text:     text,
+			Blocks:   blocksJSON,
+		})
+	} else {
+		result, err = cmdCtx.Client.PostMessage(cmdCtx.Ctx, channelID, msgOpts)
+	}
 	if err != nil {
+		guard.Abandon(cmdCtx, err)
 		return err
 	}
 
 	// Set the channel name in the result for human-readable output
 	result.Channel = channelInput
+	result.UnresolvedMentions = unresolved
+	result.IdempotencyKey = idempotencyKey
+	result.TraceID = traceID
+	guard.Complete(cmdCtx, result)
+	history.Record(cmdCtx, result.Timestamp)
+
+	return output.Print(cmd, withRedactions(withDecision(withPolicy(result, decision), "dedupe", dedupe), redactions))
+}
 
-	return output.Print(cmd, result)
+// uploadMessageFile shares a file in channelID with the message text or blocks in
+// opts, reporting it like a posted message.
+func uploadMessageFile(cmdCtx *CommandContext, channelID string, opts slack.UploadFileOptions) (*slack.PostMessageResult, error) {
+	opts.Channel = channelID
+	file, ts, err := cmdCtx.Client.UploadFile(cmdCtx.Ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &slack.PostMessageResult{OK: true, Channel: channelID, Timestamp: ts, Text: opts.Text, File: file}, nil
 }
 
 func runMessagesEdit(cmd *cobra.Command, args []string) error {
@@ -481,6 +897,10 @@ func runMessagesEdit(cmd *cobra.Command, args []string) error {
 	channelInput, _ := cmd.Flags().GetString("channel")
 	timestamp, _ := cmd.Flags().GetString("ts")
 	text, _ := cmd.Flags().GetString("text")
+	text, _, redactions, err := redactMessage(cmd, cmdCtx.Config, text, "")
+	if err != nil {
+		return err
+	}
 
 	// Resolve channel name to ID
 	channelID, err := cmdCtx.ResolveChannel(channelInput)
@@ -488,6 +908,11 @@ func runMessagesEdit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+
 	// Edit the message
 	result, err := cmdCtx.Client.EditMessage(cmdCtx.Ctx, channelID, timestamp, text)
 	if err != nil {
@@ -497,7 +922,7 @@ func runMessagesEdit(cmd *cobra.Command, args []string) error {
 	// Set the channel name in the result for human-readable output
 	result.Channel = channelInput
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withRedactions(withPolicy(result, decision), redactions))
 }
 
 func runMessagesDelete(cmd *cobra.Command, args []string) error {
@@ -516,6 +941,11 @@ func runMessagesDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+
 	// Delete the message
 	result, err := cmdCtx.Client.DeleteMessage(cmdCtx.Ctx, channelID, timestamp)
 	if err != nil {
@@ -525,7 +955,7 @@ func runMessagesDelete(cmd *cobra.Command, args []string) error {
 	// Set the channel name in the result for human-readable output
 	result.Channel = channelInput
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withPolicy(result, decision))
 }
 
 func runMessagesNext(cmd *cobra.Command, args []string) error {
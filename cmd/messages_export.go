@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/ratelimit"
+	"github.com/kehao95/slack-agent-cli/internal/segment"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var messagesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export channel history to NDJSON files",
+	Long: `Export the full message history of a channel to a newline-delimited JSON file,
+one Slack message object per line, in the order Slack returns them (newest first).
+
+Multiple channels can be exported at once with --out-dir. Channels are fetched
+concurrently (--concurrency) while all workers share one request pacer, so API calls
+are spaced by --page-delay across the whole export. Each channel gets its own files:
+
+  <out-dir>/<channel>.ndjson          messages
+  <out-dir>/<channel>.threads.ndjson  thread replies (--threads)
+  <out-dir>/<channel>.pins.json       pinned items (--pins)
+  <out-dir>/manifest.json             channel metadata, file names, and counts
+
+--out-dir also accepts a file pattern such as ./export/{channel}.ndjson. Re-running the
+same --out-dir export resumes any channel that still has a checkpoint file.
+
+Large exports can take hours and hit rate limits. Progress is written to a checkpoint
+file (default: <out>.checkpoint.json) after every --checkpoint-every pages and whenever
+the export is interrupted (Ctrl-C, SIGTERM, or an API error). Re-run with
+--resume <checkpoint> to continue from the last saved cursor instead of starting over.
+The checkpoint is removed once the export completes.
+
+--scrub pii masks emails, phone numbers, and scrub.patterns from config in every
+exported message, thread reply, and pin. A resumed export keeps the original --scrub.
+
+--compress gzip or zstd compresses the messages and thread replies files (zstd needs
+the zstd command installed). --rotate-size and --rotate-every split them into numbered
+segments once a segment reaches a size (uncompressed) or has been open for a while:
+
+  general.000001.ndjson.gz, general.000002.ndjson.gz, ...
+  general.manifest.json    segments with line counts, sizes, and SHA-256 checksums
+
+Segments are valid concatenated gzip or zstd streams, so zcat and zstdcat read them as
+usual. The manifest is rewritten at every checkpoint and marked "complete" when the
+export finishes. A resumed export keeps the original compression and rotation.
+
+Checkpoint (JSON):
+  {
+    "version": 1,
+    "channel": "C123ABC",
+    "output": "general.ndjson",
+    "cursor": "bmV4dF90czox...",
+    "last_ts": "1705312365.000100",
+    "exported": 4200,
+    "pages": 21,
+    "output_bytes": 1048576,
+    "output_segments": {"compression": "gzip", "segments": [...]},
+    "complete": false,
+    "updated_at": "2024-01-15T10:30:00Z"
+  }
+
+Output (JSON):
+  {
+    "channel": "#general",
+    "channel_id": "C123ABC",
+    "output": "general.ndjson",
+    "checkpoint": "general.checkpoint.json",
+    "exported": 4200,
+    "pages": 21,
+    "resumed": false,
+    "complete": true,
+    "manifest": "general.manifest.json",
+    "segments": 3
+  }`,
+	Example: `  # Export all history of #general
+  slk messages export --channel "#general" --out general.ndjson
+
+  # Export the last 30 days, checkpointing every 5 pages
+  slk messages export --channel "#general" --out general.ndjson --since 720h --checkpoint-every 5
+
+  # Resume an interrupted export
+  slk messages export --resume general.checkpoint.json
+
+  # Export several channels in parallel, with threads and pins
+  slk messages export --channel "#general,#random,#ops" --out-dir ./export --threads --pins
+
+  # Export with personal data masked
+  slk messages export --channel "#support" --out support.ndjson --scrub pii
+
+  # Archive as zstd segments of at most 1 GiB, with a checksum manifest
+  slk messages export --channel "#general" --out archive/general.ndjson --compress zstd --rotate-size 1g`,
+	RunE: runMessagesExport,
+}
+
+func init() {
+	messagesCmd.AddCommand(messagesExportCmd)
+
+	messagesExportCmd.Flags().StringSliceP("channel", "c", nil, "Channel name or ID; repeat or comma-separate with --out-dir (required unless --resume)")
+	messagesExportCmd.Flags().StringP("out", "o", "", "Output NDJSON file for a single channel")
+	messagesExportCmd.Flags().String("out-dir", "", "Output directory (or {channel} pattern) for multi-channel exports")
+	messagesExportCmd.Flags().Int("concurrency", 4, "Channels exported at once with --out-dir")
+	messagesExportCmd.Flags().Bool("threads", false, "Also export thread replies (--out-dir only)")
+	messagesExportCmd.Flags().Bool("pins", false, "Also export pinned items (--out-dir only)")
+	messagesExportCmd.Flags().String("since", "", "Messages after this time (ISO or relative like 24h)")
+	messagesExportCmd.Flags().String("until", "", "Messages before this time")
+	messagesExportCmd.Flags().String("checkpoint", "", "Checkpoint file path (default: <out>.checkpoint.json)")
+	messagesExportCmd.Flags().Int("checkpoint-every", 1, "Pages between checkpoint writes")
+	messagesExportCmd.Flags().String("resume", "", "Resume an interrupted export from a checkpoint file")
+	messagesExportCmd.Flags().Int("page-size", 200, "Messages per API call")
+	messagesExportCmd.Flags().Duration("page-delay", time.Second, "Delay between API calls to avoid rate limits (shared by all workers)")
+	messagesExportCmd.Flags().String("compress", "", "Compress messages and thread replies: gzip or zstd")
+	messagesExportCmd.Flags().String("rotate-size", "", "Start a new segment before one exceeds this uncompressed size, e.g. 512m or 1g")
+	messagesExportCmd.Flags().Duration("rotate-every", 0, "Start a new segment after this long, e.g. 1h")
+	addScrubFlag(messagesExportCmd)
+}
+
+// parseSegmentFlags reads --compress, --rotate-size, and --rotate-every.
+func parseSegmentFlags(cmd *cobra.Command) (segment.Options, error) {
+	compress, _ := cmd.Flags().GetString("compress")
+	rotateSize, _ := cmd.Flags().GetString("rotate-size")
+	rotateEvery, _ := cmd.Flags().GetDuration("rotate-every")
+	opts := segment.Options{Compress: strings.ToLower(strings.TrimSpace(compress)), MaxAge: rotateEvery}
+	if rotateSize != "" {
+		size, err := parseByteSize(rotateSize)
+		if err != nil {
+			return opts, errors.ConfigError("--rotate-size: %v", err)
+		}
+		opts.MaxBytes = size
+	}
+	if rotateEvery < 0 {
+		return opts, errors.ConfigError("--rotate-every must not be negative")
+	}
+	if err := opts.Validate(); err != nil {
+		return opts, errors.ConfigError("--compress: %v", err)
+	}
+	return opts, nil
+}
+
+func runMessagesExport(cmd *cobra.Command, args []string) error {
+	channelInputs, _ := cmd.Flags().GetStringSlice("channel")
+	outPath, _ := cmd.Flags().GetString("out")
+	outDir, _ := cmd.Flags().GetString("out-dir")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+	checkpointEvery, _ := cmd.Flags().GetInt("checkpoint-every")
+	resumePath, _ := cmd.Flags().GetString("resume")
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	pageDelay, _ := cmd.Flags().GetDuration("page-delay")
+	segmentOpts, err := parseSegmentFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if outDir != "" {
+		return runMessagesExportDir(cmd, channelInputs, outDir, segmentOpts)
+	}
+	threads, _ := cmd.Flags().GetBool("threads")
+	pins, _ := cmd.Flags().GetBool("pins")
+	if threads || pins {
+		return fmt.Errorf("--threads and --pins require --out-dir")
+	}
+	if len(channelInputs) > 1 {
+		return fmt.Errorf("exporting multiple channels requires --out-dir")
+	}
+	channelInput := ""
+	if len(channelInputs) == 1 {
+		channelInput = channelInputs[0]
+	}
+
+	resume := resumePath != ""
+	if !resume && (channelInput == "" || outPath == "") {
+		return fmt.Errorf("--channel and --out (or --out-dir) are required unless --resume is set")
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	var cp *messages.Checkpoint
+	if resume {
+		cp, err = messages.LoadCheckpoint(resumePath)
+		if err != nil {
+			return errors.NewErrorWithCode(errors.ExitGeneral, "%v", err)
+		}
+		checkpointPath = resumePath
+		if channelInput == "" {
+			channelInput = cp.Channel
+		}
+		// Keep scrubbing on resume so the file is not half masked.
+		if cp.Scrub != "" && !cmd.Flags().Changed("scrub") {
+			if err := cmd.Flags().Set("scrub", cp.Scrub); err != nil {
+				return err
+			}
+		}
+	} else {
+		channelID, err := cmdCtx.ResolveChannel(channelInput)
+		if err != nil {
+			return err
+		}
+		oldest, latest, err := slack.ParseTimeRange(since, until)
+		if err != nil {
+			return err
+		}
+		cp = &messages.Checkpoint{
+			Channel: channelID,
+			Output:  outPath,
+			Oldest:  oldest,
+			Latest:  latest,
+		}
+		if checkpointPath == "" {
+			checkpointPath = messages.DefaultCheckpointPath(outPath)
+		}
+	}
+
+	scrubber, err := newScrubber(cmd, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	cp.Scrub, _ = cmd.Flags().GetString("scrub")
+	if !resume {
+		messages.SegmentOutputs(cp, segmentOpts)
+	}
+
+	file, err := messages.PrepareOutput(cp, resume)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	ctx, stop := signal.NotifyContext(cmdCtx.Ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exportCfg := messages.ExportConfig{
+		PageSize:        pageSize,
+		PageDelay:       pageDelay,
+		CheckpointEvery: checkpointEvery,
+		SaveCheckpoint: func(cp *messages.Checkpoint) error {
+			return messages.SaveCheckpoint(checkpointPath, cp)
+		},
+		Output: output.StatusWriter(),
+	}
+	exportCfg.Scrub = scrubJSON(scrubber)
+
+	exporter := messages.NewExporter(slack.NewMessageFetcher(cmdCtx.Client))
+	exportErr := exporter.Export(ctx, cp, file, exportCfg)
+
+	result := messages.ExportResult{
+		Channel:     channelInput,
+		ChannelID:   cp.Channel,
+		Output:      cp.Output,
+		Checkpoint:  checkpointPath,
+		Exported:    cp.Exported,
+		Pages:       cp.Pages,
+		Resumed:     resume,
+		Complete:    cp.Complete,
+		NextCursor:  cp.Cursor,
+		OldestTS:    cp.Oldest,
+		LatestTS:    cp.Latest,
+		LastTS:      cp.LastTS,
+		OutputBytes: cp.OutputBytes,
+	}
+	if cp.OutputSegments != nil {
+		result.Manifest = segment.ManifestPath(cp.Output)
+		result.Segments = len(cp.OutputSegments.Segments)
+	}
+	if exportErr != nil {
+		output.Statusf("Export stopped; resume with: slk messages export --resume %s\n", checkpointPath)
+		if err := output.Print(cmd, result); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return errors.NewErrorWithCode(errors.ExitGeneral, "export interrupted")
+		}
+		return errors.WrapWithCode(errors.ClassifySlackError(exportErr), exportErr, "export failed")
+	}
+
+	if cp.Complete {
+		if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove checkpoint: %w", err)
+		}
+		result.Checkpoint = ""
+	}
+	return output.Print(cmd, result)
+}
+
+func runMessagesExportDir(cmd *cobra.Command, channelInputs []string, outDir string, segmentOpts segment.Options) error {
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	checkpointEvery, _ := cmd.Flags().GetInt("checkpoint-every")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	threads, _ := cmd.Flags().GetBool("threads")
+	pins, _ := cmd.Flags().GetBool("pins")
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	pageDelay, _ := cmd.Flags().GetDuration("page-delay")
+
+	if len(channelInputs) == 0 {
+		return fmt.Errorf("at least one --channel is required")
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	channelIDs := make([]string, 0, len(channelInputs))
+	for _, input := range channelInputs {
+		channelID, err := cmdCtx.ResolveChannel(input)
+		if err != nil {
+			return err
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+	oldest, latest, err := slack.ParseTimeRange(since, until)
+	if err != nil {
+		return err
+	}
+
+	scrubber, err := newScrubber(cmd, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	scrubMode, _ := cmd.Flags().GetString("scrub")
+
+	ctx, stop := signal.NotifyContext(cmdCtx.Ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	batchCfg := messages.BatchExportConfig{
+		OutputPattern: outDir,
+		Oldest:        oldest,
+		Latest:        latest,
+		Concurrency:   concurrency,
+		Threads:       threads,
+		Pins:          pins,
+		Segments:      segmentOpts,
+		ScrubMode:     scrubMode,
+		Export: messages.ExportConfig{
+			PageSize:        pageSize,
+			Limiter:         ratelimit.New(pageDelay),
+			CheckpointEvery: checkpointEvery,
+			Scrub:           scrubJSON(scrubber),
+			Output:          output.StatusWriter(),
+		},
+	}
+
+	exporter := messages.NewBatchExporter(slack.NewMessageFetcher(cmdCtx.Client), cmdCtx.Client)
+	manifest, exportErr := exporter.Run(ctx, channelIDs, batchCfg)
+	if manifest == nil {
+		return exportErr
+	}
+	if err := output.Print(cmd, manifest); err != nil {
+		return err
+	}
+	if exportErr != nil {
+		output.Statusf("Export incomplete; re-run the same command to resume unfinished channels\n")
+		if ctx.Err() != nil {
+			return errors.NewErrorWithCode(errors.ExitGeneral, "export interrupted")
+		}
+		return errors.WrapWithCode(errors.ClassifySlackError(exportErr), exportErr, "export failed")
+	}
+	return nil
+}
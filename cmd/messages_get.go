@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+var messagesGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Fetch a single message by ts or client_msg_id",
+	Long: `Fetch one message, with its permalink, by timestamp or by client_msg_id.
+
+--ts fetches the message with exactly that timestamp, including thread replies.
+With --inclusive, a timestamp that matches no message returns the newest message
+posted at or before it instead; matched_by is then "inclusive".
+
+--client-msg-id finds a message by the client_msg_id Slack clients attach when
+posting, e.g. one recorded from an event. Slack cannot look messages up by this ID,
+so the channel history is read newest first until the message is found, stopping
+after --scan-limit messages or at --since. Thread replies are only found by --ts.
+
+The message object is the same as in messages list: users resolved to @names
+(use --raw-json to keep IDs), plus every field Slack returned.
+
+Output (JSON):
+  {
+    "ok": true,
+    "channel": "#general",
+    "channel_id": "C123ABC",
+    "ts": "1705312365.000100",
+    "permalink": "https://example.slack.com/archives/C123ABC/p1705312365000100",
+    "matched_by": "ts",
+    "message": {
+      "type": "message",
+      "user": "@alice",
+      "user_id": "U123ABC",
+      "text": "Deploy finished",
+      "ts": "1705312365.000100",
+      "client_msg_id": "4c1b2f0e-8d0a-4f57-9a5e-2b7c3e1d9f10"
+    }
+  }
+
+scanned (client_msg_id lookups only) is how many messages were read.`,
+	Example: `  # Re-hydrate a message from its ts
+  slk messages get --channel "#general" --ts 1705312365.000100
+
+  # Find a message by the client_msg_id seen in an event
+  slk messages get --channel "#general" --client-msg-id 4c1b2f0e-8d0a-4f57-9a5e-2b7c3e1d9f10 --since 1d
+
+  # Closest message at or before a point in time
+  slk messages get --channel "#general" --ts 1705312400.000000 --inclusive`,
+	Args: cobra.NoArgs,
+	RunE: runMessagesGet,
+}
+
+func init() {
+	messagesCmd.AddCommand(messagesGetCmd)
+
+	messagesGetCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	messagesGetCmd.Flags().String("ts", "", "Message timestamp")
+	messagesGetCmd.Flags().Bool("inclusive", false, "Without an exact --ts match, return the newest message at or before --ts")
+	messagesGetCmd.Flags().String("client-msg-id", "", "Find the message with this client_msg_id")
+	messagesGetCmd.Flags().String("since", "", "Stop a --client-msg-id scan at this time (ISO or relative like 1d)")
+	messagesGetCmd.Flags().Int("scan-limit", messages.DefaultScanLimit, "Maximum messages a --client-msg-id scan reads")
+	messagesGetCmd.Flags().Bool("raw-json", false, "Preserve raw Slack user IDs in JSON output")
+	addScrubFlag(messagesGetCmd)
+	messagesGetCmd.MarkFlagRequired("channel")
+	messagesGetCmd.MarkFlagsOneRequired("ts", "client-msg-id")
+	messagesGetCmd.MarkFlagsMutuallyExclusive("ts", "client-msg-id")
+}
+
+func runMessagesGet(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+	ts, _ := cmd.Flags().GetString("ts")
+	inclusive, _ := cmd.Flags().GetBool("inclusive")
+	clientMsgID, _ := cmd.Flags().GetString("client-msg-id")
+	since, _ := cmd.Flags().GetString("since")
+	scanLimit, _ := cmd.Flags().GetInt("scan-limit")
+	rawJSON, _ := cmd.Flags().GetBool("raw-json")
+	if inclusive && ts == "" {
+		return cerrors.ConfigError("--inclusive requires --ts")
+	}
+	if scanLimit <= 0 {
+		return cerrors.ConfigError("--scan-limit must be positive")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	scrubber, err := newScrubber(cmd, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	expanders, err := newLinkExpanders(cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+
+	result, err := messages.Get(cmdCtx.Ctx, cmdCtx.Client, messages.GetParams{
+		Channel:     channelID,
+		TS:          ts,
+		Inclusive:   inclusive,
+		ClientMsgID: clientMsgID,
+		Since:       since,
+		ScanLimit:   scanLimit,
+	})
+	if errors.Is(err, slack.ErrNotFound) {
+		if clientMsgID != "" {
+			return cerrors.NotFoundError("message", "client_msg_id "+clientMsgID, "Hint: check the channel, or widen the search with --since and --scan-limit")
+		}
+		hint := "Hint: pass --inclusive to get the closest earlier message"
+		if inclusive {
+			hint = ""
+		}
+		return cerrors.NotFoundError("message", ts, hint)
+	}
+	if err != nil {
+		return err
+	}
+
+	channelName := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID)
+	if channelName != "" && channelName != channelID {
+		result.ChannelName = strings.TrimPrefix(channelName, "#")
+	} else {
+		result.ChannelName = strings.TrimPrefix(channelInput, "#")
+	}
+	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
+	result.SetUserGroupResolver(cmdCtx.Ctx, cmdCtx.UserGroupResolver)
+	result.SetRawJSON(rawJSON)
+
+	return output.Print(cmd, withLinks(withScrub(result, scrubber), expanders))
+}
@@ -1,12 +1,20 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+
+	"github.com/kehao95/slack-agent-cli/internal/blockkit"
+	"github.com/kehao95/slack-agent-cli/internal/messages"
 )
 
 // readStdinIfPiped reads from stdin if data is being piped in.
@@ -34,15 +42,157 @@ func readRequiredStdin(format string) (string, error) {
 	return text, nil
 }
 
-// parseBlocksJSON parses a JSON array of Slack Block Kit blocks.
-// Returns nil if blocksJSON is empty.
+// parseExtractFlag validates --extract and reports whether entity extraction was requested.
+func parseExtractFlag(cmd *cobra.Command) (bool, error) {
+	extract, _ := cmd.Flags().GetString("extract")
+	switch strings.ToLower(strings.TrimSpace(extract)) {
+	case "":
+		return false, nil
+	case "entities":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid --extract value %q: must be 'entities'", extract)
+	}
+}
+
+// parseByteSize parses sizes like "65536", "64k", "64KB", or "1m" (binary units).
+func parseByteSize(input string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	s = strings.TrimSuffix(s, "b")
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(s, "k"):
+		multiplier, s = 1<<10, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "m"):
+		multiplier, s = 1<<20, strings.TrimSuffix(s, "m")
+	case strings.HasSuffix(s, "g"):
+		multiplier, s = 1<<30, strings.TrimSuffix(s, "g")
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%q is not a positive size like 64k or 1m", input)
+	}
+	return n * multiplier, nil
+}
+
+// parseSubtypeFlags reads --subtypes and --exclude-subtypes.
+func parseSubtypeFlags(cmd *cobra.Command) (messages.SubtypeFilter, error) {
+	include, _ := cmd.Flags().GetString("subtypes")
+	exclude, _ := cmd.Flags().GetString("exclude-subtypes")
+	return messages.ParseSubtypeFilter(include, exclude)
+}
+
+// addSubtypeFlags registers --subtypes and --exclude-subtypes.
+func addSubtypeFlags(cmd *cobra.Command, scope string) {
+	cmd.Flags().String("subtypes", "", "Only include "+scope+" with these subtypes, comma-separated (none = ordinary messages; e.g. none,bot_message,channel_join)")
+	cmd.Flags().String("exclude-subtypes", "", "Exclude "+scope+" with these subtypes, comma-separated")
+}
+
+// parseAuthorFlags reads --bots-only and --humans-only.
+func parseAuthorFlags(cmd *cobra.Command) (messages.AuthorFilter, error) {
+	botsOnly, _ := cmd.Flags().GetBool("bots-only")
+	humansOnly, _ := cmd.Flags().GetBool("humans-only")
+	return messages.ParseAuthorFilter(botsOnly, humansOnly)
+}
+
+// addAuthorFlags registers --bots-only and --humans-only.
+func addAuthorFlags(cmd *cobra.Command, scope string) {
+	cmd.Flags().Bool("bots-only", false, "Only include "+scope+" posted by bots and integrations")
+	cmd.Flags().Bool("humans-only", false, "Only include "+scope+" posted by people")
+	cmd.MarkFlagsMutuallyExclusive("bots-only", "humans-only")
+}
+
+// readBlocksFlags returns Block Kit JSON from --blocks (inline, or - for stdin),
+// --blocks-file, or --blocks-template rendered with --var. Returns "" if none is set.
+func readBlocksFlags(cmd *cobra.Command) (string, error) {
+	blocksJSON, _ := cmd.Flags().GetString("blocks")
+	blocksFile, _ := cmd.Flags().GetString("blocks-file")
+	templateFile, _ := cmd.Flags().GetString("blocks-template")
+	varPairs, _ := cmd.Flags().GetStringArray("var")
+
+	if len(varPairs) > 0 && templateFile == "" {
+		return "", fmt.Errorf("--var applies to --blocks-template")
+	}
+	switch {
+	case blocksJSON == "-":
+		return readRequiredStdin("blocks")
+	case blocksFile != "":
+		data, err := os.ReadFile(blocksFile)
+		if err != nil {
+			return "", fmt.Errorf("read blocks file: %w", err)
+		}
+		return string(data), nil
+	case templateFile != "":
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			return "", fmt.Errorf("read blocks template: %w", err)
+		}
+		vars, err := blockkit.ParseVars(varPairs)
+		if err != nil {
+			return "", err
+		}
+		rendered, err := blockkit.Render(filepath.Base(templateFile), string(data), vars)
+		if err != nil {
+			return "", err
+		}
+		return string(rendered), nil
+	}
+	return blocksJSON, nil
+}
+
+// traceEventType is the metadata event_type of messages sent with --trace-id or --context.
+const traceEventType = "slk_trace"
+
+// traceMetadata builds the message metadata for --trace-id and --context: the
+// context object's fields, plus trace_id when set. It returns nil when both are empty.
+func traceMetadata(traceID, contextJSON string) (*slackapi.SlackMetadata, error) {
+	if traceID == "" && contextJSON == "" {
+		return nil, nil
+	}
+	payload := map[string]interface{}{}
+	if contextJSON != "" {
+		if err := json.Unmarshal([]byte(contextJSON), &payload); err != nil || payload == nil {
+			return nil, fmt.Errorf("--context must be a JSON object, e.g. '{\"job\":\"deploy\",\"run\":42}'")
+		}
+	}
+	if traceID != "" {
+		if existing, ok := payload["trace_id"]; ok && existing != traceID {
+			return nil, fmt.Errorf("--context has trace_id %v, which conflicts with --trace-id %s", existing, traceID)
+		}
+		payload["trace_id"] = traceID
+	}
+	return &slackapi.SlackMetadata{EventType: traceEventType, EventPayload: payload}, nil
+}
+
+// parseBlocksJSON validates and parses a JSON array of Slack Block Kit blocks, or an
+// object with a "blocks" array as Block Kit Builder exports. Returns nil if blocksJSON
+// is empty.
 func parseBlocksJSON(blocksJSON string) ([]slackapi.Block, error) {
 	if blocksJSON == "" {
 		return nil, nil
 	}
 
+	if _, problems := blockkit.Validate([]byte(blocksJSON)); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid blocks: %w", problems)
+	}
+	return decodeBlocks([]byte(blocksJSON))
+}
+
+// decodeBlocks parses already validated Block Kit JSON, either a block array or an
+// object with a "blocks" array, into slack-go blocks.
+func decodeBlocks(data []byte) ([]slackapi.Block, error) {
+	data = bytes.TrimSpace(data)
+	if data[0] == '{' {
+		var payload struct {
+			Blocks json.RawMessage `json:"blocks"`
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("invalid blocks JSON: %w", err)
+		}
+		data = payload.Blocks
+	}
 	var rawBlocks []json.RawMessage
-	if err := json.Unmarshal([]byte(blocksJSON), &rawBlocks); err != nil {
+	if err := json.Unmarshal(data, &rawBlocks); err != nil {
 		return nil, fmt.Errorf("invalid blocks JSON array: %w", err)
 	}
 
@@ -103,6 +253,12 @@ func parseBlock(raw json.RawMessage) (slackapi.Block, error) {
 			return nil, fmt.Errorf("parse image block: %w", err)
 		}
 		return &b, nil
+	case "input":
+		var b slackapi.InputBlock
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, fmt.Errorf("parse input block: %w", err)
+		}
+		return &b, nil
 	default:
 		return nil, fmt.Errorf("unsupported block type: %s", blockType.Type)
 	}
@@ -1,6 +1,13 @@
 package cmd
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
 
 func TestParseBlocksJSON_Empty(t *testing.T) {
 	blocks, err := parseBlocksJSON("")
@@ -52,3 +59,106 @@ func TestParseBlocksJSON_MultipleBlocks(t *testing.T) {
 		t.Errorf("expected 3 blocks, got %d", len(blocks))
 	}
 }
+
+func TestReadBlocksFlags(t *testing.T) {
+	dir := t.TempDir()
+	blocksFile := filepath.Join(dir, "blocks.json")
+	templateFile := filepath.Join(dir, "blocks.tmpl")
+	os.WriteFile(blocksFile, []byte(`[{"type": "divider"}]`), 0o600)
+	os.WriteFile(templateFile, []byte(`[{"type": "section", "text": {"type": "mrkdwn", "text": {{json .summary}}}}]`), 0o600)
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("blocks", "", "")
+		cmd.Flags().String("blocks-file", "", "")
+		cmd.Flags().String("blocks-template", "", "")
+		cmd.Flags().StringArray("var", nil, "")
+		return cmd
+	}
+
+	cmd := newCmd()
+	cmd.Flags().Set("blocks-file", blocksFile)
+	if got, err := readBlocksFlags(cmd); err != nil || got != `[{"type": "divider"}]` {
+		t.Fatalf("--blocks-file = %q, %v", got, err)
+	}
+
+	cmd = newCmd()
+	cmd.Flags().Set("blocks-template", templateFile)
+	cmd.Flags().Set("var", `summary=Deployed "v2"`)
+	got, err := readBlocksFlags(cmd)
+	if err != nil {
+		t.Fatalf("--blocks-template: %v", err)
+	}
+	if blocks, err := parseBlocksJSON(got); err != nil || len(blocks) != 1 {
+		t.Fatalf("rendered template %q parsed to %d blocks, %v", got, len(blocks), err)
+	}
+
+	cmd = newCmd()
+	cmd.Flags().Set("blocks", `[{"type": "divider"}]`)
+	cmd.Flags().Set("var", "summary=x")
+	if _, err := readBlocksFlags(cmd); err == nil {
+		t.Fatal("expected error for --var without --blocks-template")
+	}
+}
+
+func TestParseExtractFlag(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("extract", "", "")
+
+	if extract, err := parseExtractFlag(cmd); err != nil || extract {
+		t.Fatalf("empty --extract = %v, %v; want false, nil", extract, err)
+	}
+	cmd.Flags().Set("extract", "entities")
+	if extract, err := parseExtractFlag(cmd); err != nil || !extract {
+		t.Fatalf("--extract entities = %v, %v; want true, nil", extract, err)
+	}
+	cmd.Flags().Set("extract", "links")
+	if _, err := parseExtractFlag(cmd); err == nil {
+		t.Fatal("expected error for unknown --extract value")
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{"65536": 65536, "64k": 64 << 10, "64KB": 64 << 10, "1m": 1 << 20, " 2MB ": 2 << 20}
+	for input, want := range tests {
+		got, err := parseByteSize(input)
+		if err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "k", "-1k", "lots"} {
+		if _, err := parseByteSize(input); err == nil {
+			t.Errorf("parseByteSize(%q) expected error", input)
+		}
+	}
+}
+
+func TestTraceMetadata(t *testing.T) {
+	meta, err := traceMetadata("", "")
+	if err != nil || meta != nil {
+		t.Fatalf("traceMetadata(empty) = %v, %v; want nil", meta, err)
+	}
+
+	meta, err = traceMetadata("run-42", `{"job":"deploy","attempt":2}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.EventType != traceEventType {
+		t.Errorf("event_type = %q", meta.EventType)
+	}
+	want := map[string]interface{}{"trace_id": "run-42", "job": "deploy", "attempt": float64(2)}
+	if !reflect.DeepEqual(meta.EventPayload, want) {
+		t.Errorf("payload = %v, want %v", meta.EventPayload, want)
+	}
+
+	for _, tc := range []struct{ traceID, context string }{
+		{"", "[1,2]"},
+		{"", "null"},
+		{"", "not json"},
+		{"run-1", `{"trace_id":"run-2"}`},
+	} {
+		if _, err := traceMetadata(tc.traceID, tc.context); err == nil {
+			t.Errorf("traceMetadata(%q, %q) succeeded, want error", tc.traceID, tc.context)
+		}
+	}
+}
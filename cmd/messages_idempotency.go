@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/idempotency"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+// sendGuard holds an idempotency key reserved for one send. A nil guard (no
+// --idempotency-key) does nothing.
+type sendGuard struct {
+	ledger *idempotency.Ledger
+	key    string
+}
+
+// reserveSend reserves key in the team's idempotency ledger. When the key was already
+// sent, it returns the original result to print instead of a guard.
+func reserveSend(cmdCtx *CommandContext, key, fingerprint string) (*sendGuard, *replayedSend, error) {
+	if key == "" {
+		return nil, nil, nil
+	}
+	ledger, err := idempotency.Open(idempotency.DefaultPath(cmdCtx.CacheStore.BasePath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("open idempotency ledger: %w", err)
+	}
+	state, entry, err := ledger.Reserve(cmdCtx.Ctx, key, fingerprint)
+	if err != nil {
+		ledger.Close()
+		return nil, nil, err
+	}
+	switch state {
+	case idempotency.StateReserved:
+		return &sendGuard{ledger: ledger, key: key}, nil, nil
+	case idempotency.StateCompleted:
+		ledger.Close()
+		return nil, &replayedSend{key: key, result: entry.Result}, nil
+	case idempotency.StateConflict:
+		ledger.Close()
+		return nil, nil, cerrors.ConfigError("idempotency key %q was already used for a different message on %s", key, entry.ReservedAt.Local().Format(time.RFC3339))
+	default:
+		ledger.Close()
+		return nil, nil, cerrors.NewErrorWithCode(cerrors.ExitGeneral,
+			"a send with idempotency key %q started at %s has not confirmed; it may have posted, so check the channel before retrying with a new key",
+			key, entry.ReservedAt.Local().Format(time.RFC3339))
+	}
+}
+
+// Complete records the sent result so retries replay it.
+func (g *sendGuard) Complete(cmdCtx *CommandContext, result any) {
+	if g == nil {
+		return
+	}
+	if err := g.ledger.Complete(cmdCtx.Ctx, g.key, result); err != nil {
+		output.Warnf("message sent but idempotency key %q not recorded: %v", g.key, err)
+	}
+}
+
+// Abandon releases the key when err shows nothing was posted, so the send can be
+// retried. Otherwise the key stays reserved and a retry reports it as unconfirmed.
+func (g *sendGuard) Abandon(cmdCtx *CommandContext, err error) {
+	if g == nil || (err != nil && !idempotency.NotSent(err)) {
+		return
+	}
+	if releaseErr := g.ledger.Release(cmdCtx.Ctx, g.key); releaseErr != nil {
+		output.Warnf("%v", releaseErr)
+	}
+}
+
+// Close closes the ledger.
+func (g *sendGuard) Close() {
+	if g != nil {
+		g.ledger.Close()
+	}
+}
+
+// replayedSend is the stored result of a send whose idempotency key was reused.
+type replayedSend struct {
+	key    string
+	result json.RawMessage
+}
+
+func (r *replayedSend) MarshalJSON() ([]byte, error) {
+	return addJSONFields(r.result, map[string]any{"replayed": true})
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *replayedSend) Lines() []string {
+	var fields struct {
+		Channel   string `json:"channel"`
+		Timestamp string `json:"ts"`
+		PostAt    string `json:"post_at"`
+	}
+	_ = json.Unmarshal(r.result, &fields)
+	lines := []string{fmt.Sprintf("Already sent with idempotency key %q; not posted again", r.key), fmt.Sprintf("Channel: %s", fields.Channel)}
+	if fields.Timestamp != "" {
+		lines = append(lines, fmt.Sprintf("Timestamp: %s", fields.Timestamp))
+	}
+	if fields.PostAt != "" {
+		lines = append(lines, fmt.Sprintf("Post at: %s", fields.PostAt))
+	}
+	return lines
+}
+
+// sendHistory records posted content for the dedupe_window check. A nil history (no
+// dedupe_window) does nothing.
+type sendHistory struct {
+	ledger      *idempotency.Ledger
+	channelID   string
+	fingerprint string
+}
+
+// checkDedupe compares a send with the posts this CLI made to channelID within the
+// configured dedupe_window. It returns nils when no window is configured. A rejected
+// send prints the decision and returns a policy error.
+func checkDedupe(cmd *cobra.Command, cmdCtx *CommandContext, channelID, fingerprint string) (*sendHistory, *policy.Decision, error) {
+	if cmdCtx.Config == nil {
+		return nil, nil, nil
+	}
+	window, action, err := cmdCtx.Config.DedupeSettings()
+	if err != nil {
+		return nil, nil, cerrors.ConfigError("%v", err)
+	}
+	if window == 0 {
+		return nil, nil, nil
+	}
+	ledger, err := idempotency.Open(idempotency.DefaultPath(cmdCtx.CacheStore.BasePath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("open idempotency ledger: %w", err)
+	}
+	now := time.Now()
+	lastTS, lastAt, _, err := ledger.LastPost(cmdCtx.Ctx, channelID, fingerprint, now.Add(-window))
+	if err != nil {
+		ledger.Close()
+		return nil, nil, err
+	}
+	allowDuplicate, _ := cmd.Flags().GetBool("allow-duplicate")
+	decision := policy.Dedupe(policy.DedupeRequest{
+		Window:   window,
+		Action:   action,
+		LastTS:   lastTS,
+		LastAt:   lastAt,
+		Override: allowDuplicate,
+		Now:      now,
+	})
+	switch decision.Action {
+	case policy.ActionReject:
+		ledger.Close()
+		if err := output.Print(cmd, decision); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, cerrors.WithCode(cerrors.CodeDuplicateMessage, cerrors.PolicyError("an identical message was sent to this channel at %s (dedupe_window %s); pass --allow-duplicate to send it anyway",
+			lastAt.Local().Format(time.RFC3339), decision.Window))
+	case policy.ActionWarn:
+		output.Warnf("an identical message was sent to this channel at %s (dedupe_window %s)",
+			lastAt.Local().Format(time.RFC3339), decision.Window)
+	}
+	return &sendHistory{ledger: ledger, channelID: channelID, fingerprint: fingerprint}, &decision, nil
+}
+
+// Record remembers that the content was posted as ts.
+func (h *sendHistory) Record(cmdCtx *CommandContext, ts string) {
+	if h == nil {
+		return
+	}
+	if err := h.ledger.RecordPost(cmdCtx.Ctx, h.channelID, h.fingerprint, ts, time.Now()); err != nil {
+		output.Warnf("message sent but not recorded for dedupe_window: %v", err)
+	}
+}
+
+// Close closes the ledger.
+func (h *sendHistory) Close() {
+	if h != nil {
+		h.ledger.Close()
+	}
+}
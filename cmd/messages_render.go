@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+var messagesRenderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render channel history as a Markdown or HTML transcript",
+	Long: `Render channel history into a readable document for sharing outside Slack.
+
+The transcript is chronological (oldest first) and includes resolved user names and
+mentions, thread replies nested under their root message, reactions, and links to
+shared files. Ticket references such as ABC-123 are linked with the expanders in
+config.
+
+Without --out the document is written to stdout. With --out it is written to the file
+and a JSON summary is printed instead:
+  {
+    "channel": "#general",
+    "channel_id": "C123ABC",
+    "format": "markdown",
+    "out": "transcript.md",
+    "messages": 42,
+    "threads": 3
+  }
+
+Channel history and every thread are paged through up to --limit messages each. When
+a channel or thread has more, a warning is printed and the summary lists it under
+"truncated".`,
+	Example: `  # Last week of #general as Markdown
+  slk messages render --channel "#general" --since 7d --format markdown --out transcript.md
+
+  # One thread as HTML on stdout
+  slk messages render --channel "#general" --thread 1705312365.000100 --format html`,
+	RunE: runMessagesRender,
+}
+
+// RenderResult summarizes a transcript written to a file.
+type RenderResult struct {
+	Channel   string `json:"channel"`
+	ChannelID string `json:"channel_id"`
+	Format    string `json:"format"`
+	Out       string `json:"out"`
+	Messages  int    `json:"messages"`
+	Threads   int    `json:"threads"`
+	// Truncated lists the channel or thread timestamps whose messages stopped at --limit.
+	Truncated []string `json:"truncated,omitempty"`
+}
+
+// Lines implements output.Printable.
+func (r RenderResult) Lines() []string {
+	lines := []string{fmt.Sprintf("Rendered %d messages (%d threads) from %s to %s (%s)", r.Messages, r.Threads, r.Channel, r.Out, r.Format)}
+	if len(r.Truncated) > 0 {
+		lines = append(lines, "Stopped at --limit in "+strings.Join(r.Truncated, ", "))
+	}
+	return lines
+}
+
+func init() {
+	messagesCmd.AddCommand(messagesRenderCmd)
+
+	messagesRenderCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	messagesRenderCmd.Flags().String("since", "", "Messages after this time (ISO or relative like 7d)")
+	messagesRenderCmd.Flags().String("until", "", "Messages before this time")
+	messagesRenderCmd.Flags().String("thread", "", "Render a single thread")
+	messagesRenderCmd.Flags().IntP("limit", "l", 1000, "Maximum top-level messages to include, and replies per thread")
+	messagesRenderCmd.Flags().String("format", messages.FormatMarkdown, "Output format: markdown or html")
+	messagesRenderCmd.Flags().StringP("out", "o", "", "Write the transcript to this file instead of stdout")
+	messagesRenderCmd.Flags().Bool("threads", true, "Include thread replies under their root message")
+	messagesRenderCmd.MarkFlagRequired("channel")
+}
+
+func runMessagesRender(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	thread, _ := cmd.Flags().GetString("thread")
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, _ := cmd.Flags().GetString("format")
+	outPath, _ := cmd.Flags().GetString("out")
+	includeThreads, _ := cmd.Flags().GetBool("threads")
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "md" {
+		format = messages.FormatMarkdown
+	}
+	if format != messages.FormatMarkdown && format != messages.FormatHTML {
+		return fmt.Errorf("unsupported format %q (use markdown or html)", format)
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	expanders, err := newLinkExpanders(cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+
+	fetcher := slack.NewMessageFetcher(cmdCtx.Client)
+	service := messages.NewService(fetcher)
+	result := messages.Result{Channel: channelID}
+	var truncated []string
+	var more bool
+	result.Messages, more, err = listPages(cmdCtx, service, messages.Params{
+		Channel: channelID,
+		Since:   since,
+		Until:   until,
+		Thread:  thread,
+	}, limit)
+	if err != nil {
+		return err
+	}
+	if more {
+		source := channelID
+		if thread != "" {
+			source = thread
+		}
+		truncated = append(truncated, source)
+	}
+
+	replies := map[string][]slackapi.Message{}
+	if includeThreads && thread == "" {
+		for _, msg := range result.Messages {
+			if msg.ReplyCount == 0 {
+				continue
+			}
+			// The root comes back with its replies, so allow one more than --limit.
+			threadMsgs, more, err := listPages(cmdCtx, service, messages.Params{Channel: channelID, Thread: msg.Timestamp}, limit+1)
+			if err != nil {
+				return err
+			}
+			replies[msg.Timestamp] = threadMsgs
+			if more {
+				truncated = append(truncated, msg.Timestamp)
+			}
+		}
+	}
+	if len(truncated) > 0 {
+		output.Warnf("transcript stopped at --limit %d in %s; raise --limit to include the rest", limit, strings.Join(truncated, ", "))
+	}
+
+	channelName := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID)
+	if channelName != "" && channelName != channelID {
+		result.ChannelName = strings.TrimPrefix(channelName, "#")
+	} else {
+		result.ChannelName = strings.TrimPrefix(channelInput, "#")
+	}
+	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
+	result.SetUserGroupResolver(cmdCtx.Ctx, cmdCtx.UserGroupResolver)
+	transcript := result.Transcript(replies)
+	transcript.Links = expanders
+
+	var w io.Writer = output.Stdout()
+	if outPath != "" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", outPath, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := transcript.Render(w, format); err != nil {
+		return fmt.Errorf("render transcript: %w", err)
+	}
+	if outPath == "" {
+		return nil
+	}
+	return output.Print(cmd, RenderResult{
+		Channel:   transcript.Channel,
+		ChannelID: channelID,
+		Format:    format,
+		Out:       outPath,
+		Messages:  transcript.MessageCount(),
+		Threads:   len(replies),
+		Truncated: truncated,
+	})
+}
+
+// listPages follows params' cursor until limit messages are fetched or the history ends,
+// and reports whether messages were left behind. Messages Slack repeats across pages
+// (a thread's root heads every page of conversations.replies) are kept once.
+func listPages(cmdCtx *CommandContext, service *messages.Service, params messages.Params, limit int) ([]slackapi.Message, bool, error) {
+	var msgs []slackapi.Message
+	seen := map[string]bool{}
+	// Full pages keep a repeated thread root from crowding out the replies; the extra
+	// messages on the last page are dropped.
+	params.Limit = 200
+	for {
+		page, err := service.List(cmdCtx.Ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+		added := 0
+		for _, msg := range page.Messages {
+			if seen[msg.Timestamp] {
+				continue
+			}
+			if len(msgs) >= limit {
+				return msgs, true, nil
+			}
+			seen[msg.Timestamp] = true
+			msgs = append(msgs, msg)
+			added++
+		}
+		if page.NextCursor == "" || added == 0 {
+			return msgs, page.NextCursor != "", nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/messages"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// pagedThread serves a thread of replies in cursor pages, repeating the root on every
+// page as conversations.replies does.
+type pagedThread struct {
+	replies int
+}
+
+func (p pagedThread) ListMessages(ctx context.Context, params slack.HistoryParams) ([]slackapi.Message, string, bool, error) {
+	return nil, "", false, nil
+}
+
+func (p pagedThread) ListThread(ctx context.Context, params slack.ThreadParams) ([]slackapi.Message, string, bool, error) {
+	start, _ := strconv.Atoi(params.Cursor)
+	msgs := []slackapi.Message{{Msg: slackapi.Msg{Timestamp: params.Thread}}}
+	end := min(start+params.Limit-1, p.replies)
+	for i := start; i < end; i++ {
+		msgs = append(msgs, slackapi.Message{Msg: slackapi.Msg{Timestamp: fmt.Sprintf("1700000000.%06d", i+1)}})
+	}
+	if end >= p.replies {
+		return msgs, "", false, nil
+	}
+	return msgs, strconv.Itoa(end), true, nil
+}
+
+func TestListPagesFollowsThreadCursor(t *testing.T) {
+	cmdCtx := &CommandContext{Ctx: context.Background()}
+	service := messages.NewService(pagedThread{replies: 450})
+	params := messages.Params{Channel: "C1", Thread: "1700000000.000000"}
+
+	msgs, more, err := listPages(cmdCtx, service, params, 1001)
+	if err != nil || more || len(msgs) != 451 {
+		t.Fatalf("listPages = %d messages, more %v, %v; want the root and all 450 replies", len(msgs), more, err)
+	}
+
+	msgs, more, err = listPages(cmdCtx, service, params, 301)
+	if err != nil || !more || len(msgs) != 301 {
+		t.Fatalf("listPages(limit 301) = %d messages, more %v, %v; want 301 and truncated", len(msgs), more, err)
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/notify"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/users"
+)
+
+var messagesScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Schedule a message for a time in the recipient's time zone",
+	Long: `Schedule a message with chat.scheduleMessage for a clock time that may be read in
+the recipient's own time zone, so "09:00" means nine in the morning where they are.
+
+--at accepts:
+  HH:MM [zone]             the next time the clock reads HH:MM in zone
+  YYYY-MM-DD HH:MM [zone]  that date and time in zone
+  RFC 3339                 an absolute time, e.g. 2024-01-16T09:00:00-08:00
+
+zone is recipient-local (the --to user's time zone from users.info), local (yours,
+the default), or an IANA name such as Europe/Berlin. With --weekdays, an HH:MM time
+that would land on a Saturday or Sunday in that zone moves to Monday.
+
+The message goes to a DM with --to unless --channel is given, in which case --to
+only supplies the time zone. Times less than a minute away are moved a minute
+ahead, since Slack rejects messages scheduled in the past.
+
+Output (JSON):
+  {
+    "ok": true,
+    "channel": "@alice",
+    "channel_id": "D123ABC",
+    "user": "@alice",
+    "user_id": "U123ABC",
+    "post_at": "2024-01-16T09:00:00-08:00",
+    "timezone": "America/Los_Angeles",
+    "local_post_at": "2024-01-16T18:00:00+01:00",
+    "scheduled_message_id": "Q1298393284",
+    "text": "Morning! The report is ready."
+  }
+
+post_at is in timezone, the zone --at was read in; local_post_at is the same moment
+in your time zone.
+
+Required Scopes:
+  - chat:write
+  - users:read for recipient-local and to resolve @names
+  - im:write for DMs`,
+	Example: `  # 9am where Alice is, whatever the time here
+  slk messages schedule --to @alice --at "09:00 recipient-local" --text "Morning! The report is ready."
+
+  # Skip her weekend, and post in a channel instead of a DM
+  slk messages schedule --to @alice --channel "#team" --at "09:00 recipient-local" --weekdays --text "<@alice> standup notes are up"
+
+  # A specific date in a named zone
+  slk messages schedule --channel "#berlin" --at "2024-03-01 10:00 Europe/Berlin" --text "Office opens today"`,
+	Args: cobra.NoArgs,
+	RunE: runMessagesSchedule,
+}
+
+func init() {
+	messagesCmd.AddCommand(messagesScheduleCmd)
+
+	messagesScheduleCmd.Flags().String("at", "", `When to post: "HH:MM [zone]", "YYYY-MM-DD HH:MM [zone]", or RFC 3339 (required)`)
+	messagesScheduleCmd.Flags().String("to", "", "Recipient: user ID, @username, or @display name; a DM unless --channel is set")
+	messagesScheduleCmd.Flags().StringP("channel", "c", "", "Channel name or ID to post in instead of a DM")
+	messagesScheduleCmd.Flags().StringP("text", "t", "", "Message text, or - to read stdin (required)")
+	messagesScheduleCmd.Flags().Bool("weekdays", false, "Move HH:MM times that fall on a weekend to Monday")
+	messagesScheduleCmd.MarkFlagRequired("at")
+	messagesScheduleCmd.MarkFlagRequired("text")
+	messagesScheduleCmd.MarkFlagsOneRequired("to", "channel")
+	addRedactFlag(messagesScheduleCmd)
+}
+
+// MessagesScheduleResult is the output of messages schedule.
+type MessagesScheduleResult struct {
+	OK                 bool      `json:"ok"`
+	Channel            string    `json:"channel"`
+	ChannelID          string    `json:"channel_id"`
+	User               string    `json:"user,omitempty"`
+	UserID             string    `json:"user_id,omitempty"`
+	PostAt             time.Time `json:"post_at"`
+	Timezone           string    `json:"timezone"`
+	LocalPostAt        time.Time `json:"local_post_at"`
+	ScheduledMessageID string    `json:"scheduled_message_id,omitempty"`
+	Text               string    `json:"text"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r MessagesScheduleResult) Lines() []string {
+	const layout = "Mon 2006-01-02 15:04 MST"
+	lines := []string{fmt.Sprintf("✓ Scheduled for %s (%s) in %s", r.PostAt.Format(layout), r.Timezone, r.Channel)}
+	_, offset := r.PostAt.Zone()
+	if _, local := r.LocalPostAt.Zone(); local != offset {
+		lines = append(lines, "  your time: "+r.LocalPostAt.Format(layout))
+	}
+	if r.ScheduledMessageID != "" {
+		lines = append(lines, "  scheduled message: "+r.ScheduledMessageID)
+	}
+	return lines
+}
+
+func runMessagesSchedule(cmd *cobra.Command, args []string) error {
+	atInput, _ := cmd.Flags().GetString("at")
+	userInput, _ := cmd.Flags().GetString("to")
+	channelInput, _ := cmd.Flags().GetString("channel")
+	text, _ := cmd.Flags().GetString("text")
+	weekdays, _ := cmd.Flags().GetBool("weekdays")
+
+	at, err := notify.ParseAt(atInput)
+	if err != nil {
+		return cerrors.ConfigError("--at: %v", err)
+	}
+	if at.Zone == notify.ZoneRecipient && userInput == "" {
+		return cerrors.ConfigError("--at %q needs --to to know whose time zone to use", atInput)
+	}
+	if text == "-" {
+		if text, err = readRequiredStdin("text"); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(text) == "" {
+		return cerrors.ConfigError("--text must not be empty")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	text, _, redactions, err := redactMessage(cmd, cmdCtx.Config, text, "")
+	if err != nil {
+		return err
+	}
+
+	result := MessagesScheduleResult{OK: true, Channel: channelInput, User: userInput, Text: text}
+	var recipient *time.Location
+	if userInput != "" {
+		if result.UserID, err = cmdCtx.ResolveUser(userInput); err != nil {
+			return fmt.Errorf("resolve user: %w", err)
+		}
+		if at.Zone == notify.ZoneRecipient {
+			info, err := cmdCtx.Client.GetUserInfo(cmdCtx.Ctx, result.UserID)
+			if err != nil {
+				return err
+			}
+			recipient = users.Location(info)
+		}
+	}
+
+	now := time.Now()
+	postAt, err := at.Resolve(now, recipient, weekdays)
+	if err != nil {
+		return cerrors.ConfigError("--at: %v", err)
+	}
+	if lead := now.Add(minScheduleLead).Truncate(time.Second).Add(time.Second); postAt.Before(lead) {
+		postAt = lead.In(postAt.Location())
+	}
+
+	if channelInput != "" {
+		result.ChannelID, err = cmdCtx.ResolveChannel(channelInput)
+	} else {
+		result.Channel = userInput
+		result.ChannelID, err = cmdCtx.Client.OpenDM(cmdCtx.Ctx, result.UserID)
+	}
+	if err != nil {
+		return err
+	}
+
+	result.ScheduledMessageID, err = cmdCtx.Client.ScheduleMessage(cmdCtx.Ctx, result.ChannelID, postAt, slack.PostMessageOptions{
+		Text:   text,
+		AsUser: cmdCtx.AuthRole == config.RoleUser,
+	})
+	if err != nil {
+		return err
+	}
+	result.PostAt = postAt
+	result.Timezone = zoneName(postAt)
+	result.LocalPostAt = postAt.Local()
+	return output.Print(cmd, withRedactions(result, redactions))
+}
+
+// zoneName names t's time zone: its IANA name when it has one, else its abbreviation
+// or UTC offset.
+func zoneName(t time.Time) string {
+	if name := t.Location().String(); name != "" && name != "Local" {
+		return name
+	}
+	if abbrev, _ := t.Zone(); abbrev != "" {
+		return abbrev
+	}
+	return t.Format("-07:00")
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/notify"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/users"
+	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+)
+
+// minScheduleLead keeps scheduled messages far enough ahead that chat.scheduleMessage
+// does not reject them as being in the past.
+const minScheduleLead = time.Minute
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "DM someone when they are available",
+	Long: `Send a direct message that respects the recipient's availability. Before sending,
+slk checks their Do Not Disturb status (dnd.info) and time zone (users.info):
+
+  sent       They are available; the DM is sent now.
+  scheduled  They are snoozed, in a DND window, or outside working hours; the DM
+             is scheduled (chat.scheduleMessage) for when all of those end.
+  fallback   With --fallback-channel, a wait longer than --max-delay posts the
+             message now in that channel with an @mention instead.
+
+Working hours are read in the recipient's own time zone; weekends count as outside
+working hours unless --weekends is set. --urgent skips every check and sends now.
+
+Output (JSON):
+  {
+    "user": "@alice",
+    "user_id": "U123ABC",
+    "action": "scheduled",
+    "reason": "outside working hours",
+    "channel": "D123ABC",
+    "post_at": "2024-01-16T09:00:00-08:00",
+    "timezone": "America/Los_Angeles",
+    "scheduled_message_id": "Q1298393284"
+  }
+
+action is one of sent, scheduled, or fallback.`,
+	Example: `  # DM now, or at the start of Alice's next working day
+  slk notify --user @alice --text "The nightly export finished"
+
+  # Never wait more than 2 hours; otherwise ping the team channel
+  slk notify --user @alice --text "Deploy needs approval" --fallback-channel "#deploys" --max-delay 2h
+
+  # Custom hours, and page regardless of DND when it matters
+  slk notify --user U123ABC --text "Disk full" --working-hours 07:00-22:00 --weekends
+  slk notify --user @oncall --text "Prod is down" --urgent`,
+	Args: cobra.NoArgs,
+	RunE: runNotify,
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+
+	notifyCmd.Flags().StringP("user", "u", "", "Recipient: user ID, @username, or @display name (required)")
+	notifyCmd.Flags().StringP("text", "t", "", "Message text (required)")
+	notifyCmd.Flags().String("working-hours", "09:00-18:00", "Recipient's working hours in their time zone, or \"any\"")
+	notifyCmd.Flags().Bool("weekends", false, "Treat Saturday and Sunday as working days")
+	notifyCmd.Flags().String("fallback-channel", "", "Channel to post in when delivery would wait longer than --max-delay")
+	notifyCmd.Flags().Duration("max-delay", 4*time.Hour, "Longest wait before using --fallback-channel")
+	notifyCmd.Flags().Bool("urgent", false, "Send now, ignoring DND and working hours")
+	notifyCmd.MarkFlagRequired("user")
+	notifyCmd.MarkFlagRequired("text")
+	addQuietHoursFlag(notifyCmd)
+	addRedactFlag(notifyCmd)
+}
+
+// NotifyResult is the output of notify.
+type NotifyResult struct {
+	User               string `json:"user"`
+	UserID             string `json:"user_id"`
+	Action             string `json:"action"`
+	Reason             string `json:"reason"`
+	Channel            string `json:"channel"`
+	TS                 string `json:"ts,omitempty"`
+	PostAt             string `json:"post_at,omitempty"`
+	Timezone           string `json:"timezone,omitempty"`
+	ScheduledMessageID string `json:"scheduled_message_id,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r NotifyResult) Lines() []string {
+	switch r.Action {
+	case notify.ActionSchedule:
+		return []string{fmt.Sprintf("✓ Scheduled DM to %s for %s (%s; %s)", r.User, r.PostAt, r.Timezone, r.Reason)}
+	case notify.ActionFallback:
+		return []string{fmt.Sprintf("✓ Posted to %s instead of DMing %s (%s)", r.Channel, r.User, r.Reason)}
+	default:
+		return []string{fmt.Sprintf("✓ Sent DM to %s (%s)", r.User, r.Reason)}
+	}
+}
+
+func runNotify(cmd *cobra.Command, args []string) error {
+	userInput, _ := cmd.Flags().GetString("user")
+	text, _ := cmd.Flags().GetString("text")
+	workingHours, _ := cmd.Flags().GetString("working-hours")
+	weekends, _ := cmd.Flags().GetBool("weekends")
+	fallback, _ := cmd.Flags().GetString("fallback-channel")
+	maxDelay, _ := cmd.Flags().GetDuration("max-delay")
+	urgent, _ := cmd.Flags().GetBool("urgent")
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("--text must not be empty")
+	}
+	hours, err := notify.ParseWorkingHours(workingHours, weekends)
+	if err != nil {
+		return fmt.Errorf("--working-hours: %w", err)
+	}
+	if maxDelay < 0 {
+		return fmt.Errorf("--max-delay must not be negative")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	text, _, redactions, err := redactMessage(cmd, cmdCtx.Config, text, "")
+	if err != nil {
+		return err
+	}
+	quiet, err := checkQuietHours(cmd, cmdCtx, "", false)
+	if err != nil {
+		return err
+	}
+
+	userID, err := cmdCtx.ResolveUser(userInput)
+	if err != nil {
+		return fmt.Errorf("resolve user: %w", err)
+	}
+	result := NotifyResult{User: userInput, UserID: userID}
+
+	now := time.Now()
+	decision := notify.Decision{Action: notify.ActionSend, Reason: "urgent"}
+	if !urgent {
+		info, err := cmdCtx.Client.GetUserInfo(cmdCtx.Ctx, userID)
+		if err != nil {
+			return err
+		}
+		loc := users.Location(info)
+		result.Timezone = loc.String()
+		dnd, err := cmdCtx.Client.GetDNDInfo(cmdCtx.Ctx, userID)
+		if err != nil {
+			return err
+		}
+		decision = notify.Decide(now, loc, availabilityFromDND(dnd), hours, maxDelay, fallback != "")
+		if decision.Action == notify.ActionSchedule {
+			result.PostAt = decision.At.Format(time.RFC3339)
+		}
+	}
+	result.Action = decision.Action
+	result.Reason = decision.Reason
+
+	if decision.Action == notify.ActionFallback {
+		channelID, err := cmdCtx.ResolveChannel(fallback)
+		if err != nil {
+			return err
+		}
+		posted, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, channelID, slack.PostMessageOptions{Text: fmt.Sprintf("<@%s> %s", userID, text)})
+		if err != nil {
+			return err
+		}
+		result.Channel = fallback
+		result.TS = posted.Timestamp
+		return output.Print(cmd, withRedactions(withPolicy(result, quiet), redactions))
+	}
+
+	dmID, err := cmdCtx.Client.OpenDM(cmdCtx.Ctx, userID)
+	if err != nil {
+		return err
+	}
+	result.Channel = dmID
+	if decision.Action == notify.ActionSchedule {
+		postAt := decision.At
+		if postAt.Before(now.Add(minScheduleLead)) {
+			postAt = now.Add(minScheduleLead)
+			result.PostAt = postAt.In(decision.At.Location()).Format(time.RFC3339)
+		}
+		if result.ScheduledMessageID, err = cmdCtx.Client.ScheduleMessage(cmdCtx.Ctx, dmID, postAt, slack.PostMessageOptions{Text: text}); err != nil {
+			return err
+		}
+		return output.Print(cmd, withRedactions(withPolicy(result, quiet), redactions))
+	}
+	posted, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, dmID, slack.PostMessageOptions{Text: text})
+	if err != nil {
+		return err
+	}
+	result.TS = posted.Timestamp
+	return output.Print(cmd, withRedactions(withPolicy(result, quiet), redactions))
+}
+
+func availabilityFromDND(status *slackapi.DNDStatus) notify.Availability {
+	var avail notify.Availability
+	if status == nil {
+		return avail
+	}
+	if status.SnoozeEnabled && status.SnoozeEndTime > 0 {
+		avail.SnoozeUntil = time.Unix(int64(status.SnoozeEndTime), 0)
+	}
+	if status.Enabled && status.NextStartTimestamp > 0 && status.NextEndTimestamp > 0 {
+		avail.DNDStart = time.Unix(int64(status.NextStartTimestamp), 0)
+		avail.DNDEnd = time.Unix(int64(status.NextEndTimestamp), 0)
+	}
+	return avail
+}
@@ -0,0 +1,602 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/blockkit"
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/outbox"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/policy"
+	"github.com/kehao95/slack-agent-cli/internal/redact"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// outboxLease is how long a delivery attempt holds a message before another worker may
+// retry it.
+const outboxLease = 2 * time.Minute
+
+var outboxCmd = &cobra.Command{
+	Use:   "outbox",
+	Short: "Queue messages locally and deliver them with retries",
+	Long: `The outbox is a local SQLite queue (outbox/outbox.db next to the config file) for
+messages that must not be lost. Messages are stored before any delivery attempt and
+stay pending until Slack accepts them, so they survive network loss, rate limits,
+and crashes. Delivery is at least once: a message may be posted twice if slk dies
+between posting it and recording the result.
+
+Pending messages are delivered by 'slk outbox flush', by 'slk outbox send' for the
+message it adds, and every --outbox-interval by 'slk daemon run'. Failed attempts
+are retried with exponential backoff (30s doubling to 1h), or after the wait Slack
+asks for when rate limited. Messages Slack rejects outright (unknown channel,
+missing permission, invalid blocks) are marked failed; 'slk outbox flush
+--include-failed' queues them again.`,
+}
+
+var outboxAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Queue a message without sending it",
+	Long: `Store a message in the outbox without contacting Slack. This works offline; the channel
+is resolved when the message is delivered.
+
+--key makes queueing idempotent: adding a message with a key that is already in the
+outbox returns the existing message instead of a second copy.
+
+Output (JSON):
+  {
+    "ok": true,
+    "id": 12,
+    "status": "pending",
+    "channel": "#alerts",
+    "duplicate": false
+  }`,
+	Example: `  slk outbox add --channel "#alerts" --text "Backup failed on db-2"
+  slk outbox add --channel "#alerts" --text "Backup failed on db-2" --key backup-db-2-2024-01-15`,
+	Args: cobra.NoArgs,
+	RunE: runOutboxAdd,
+}
+
+var outboxSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Queue a message and try to deliver it now",
+	Long: `Store a message in the outbox, then try to post it right away. If Slack cannot be
+reached or is rate limiting, the message stays queued, the command still succeeds,
+and status is "pending"; it is delivered by a later flush. If Slack rejects it
+outright the command fails and the message is marked failed.
+
+Output (JSON):
+  {
+    "id": 12,
+    "channel": "#alerts",
+    "channel_id": "C123ABC",
+    "status": "sent",
+    "ts": "1705312365.000100",
+    "attempts": 1
+  }
+
+status is sent, pending (with error and next_attempt_at), or failed.`,
+	Example: `  slk outbox send --channel "#alerts" --text "Nightly export finished"
+  slk outbox send --channel "#alerts" --blocks-file report.json --key report-2024-01-15`,
+	Args: cobra.NoArgs,
+	RunE: runOutboxSend,
+}
+
+var outboxFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Deliver pending messages that are due",
+	Long: `Deliver pending messages whose next attempt is due, oldest first. The flush stops
+early when Slack is unreachable or rate limiting, leaving the rest queued.
+
+Output (JSON):
+  {
+    "sent": 2,
+    "pending": 1,
+    "failed": 0,
+    "deliveries": [
+      {"id": 12, "channel": "#alerts", "channel_id": "C123ABC", "status": "sent", "ts": "1705312365.000100", "attempts": 1}
+    ]
+  }
+
+pending and failed count the whole outbox after the flush.`,
+	Example: `  slk outbox flush
+  slk outbox flush --include-failed`,
+	Args: cobra.NoArgs,
+	RunE: runOutboxFlush,
+}
+
+var outboxListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List queued messages",
+	Long: `List outbox messages, oldest first. Works offline.
+
+Output (JSON):
+  {
+    "path": "/home/me/.config/slack-cli/outbox/outbox.db",
+    "counts": {"pending": 1, "sent": 2, "failed": 0},
+    "messages": [
+      {"id": 13, "status": "pending", "created_at": "2024-01-15T09:30:00Z", "channel": "#alerts",
+       "text": "Backup failed on db-2", "attempts": 2, "last_error": "post message: dial tcp: ...",
+       "next_attempt_at": "2024-01-15T09:31:00Z"}
+    ]
+  }`,
+	Args: cobra.NoArgs,
+	RunE: runOutboxList,
+}
+
+func init() {
+	rootCmd.AddCommand(outboxCmd)
+	outboxCmd.AddCommand(outboxAddCmd)
+	outboxCmd.AddCommand(outboxSendCmd)
+	outboxCmd.AddCommand(outboxFlushCmd)
+	outboxCmd.AddCommand(outboxListCmd)
+
+	for _, c := range []*cobra.Command{outboxAddCmd, outboxSendCmd} {
+		c.Flags().StringP("channel", "c", "", "Target channel or @user (required)")
+		c.Flags().StringP("mrkdwn", "m", "", "Slack mrkdwn message text (sent as-is)")
+		c.Flags().StringP("text", "t", "", "Plain message text (sent as-is)")
+		c.Flags().String("thread", "", "Thread timestamp to reply in")
+		c.Flags().String("blocks", "", "Block Kit JSON")
+		c.Flags().String("blocks-file", "", "Read Block Kit JSON from a file")
+		c.Flags().String("key", "", "Idempotency key; a message with the same key is only queued once")
+		c.MarkFlagsMutuallyExclusive("blocks", "blocks-file")
+		c.MarkFlagRequired("channel")
+	}
+	addQuietHoursFlag(outboxSendCmd)
+	addRedactFlag(outboxAddCmd)
+	addRedactFlag(outboxSendCmd)
+
+	outboxFlushCmd.Flags().Int("limit", 0, "Deliver at most this many messages (0 for all due)")
+	outboxFlushCmd.Flags().Bool("include-failed", false, "Queue failed messages again before flushing")
+	addQuietHoursFlag(outboxFlushCmd)
+
+	outboxListCmd.Flags().String("status", "pending", "Messages to list: pending, sent, failed, or all")
+	outboxListCmd.Flags().IntP("limit", "l", 100, "Maximum messages to return (0 for all)")
+}
+
+// OutboxDelivery is the result of one delivery attempt.
+type OutboxDelivery struct {
+	ID          int64      `json:"id"`
+	Channel     string     `json:"channel"`
+	ChannelID   string     `json:"channel_id,omitempty"`
+	Status      string     `json:"status"`
+	TS          string     `json:"ts,omitempty"`
+	Attempts    int        `json:"attempts"`
+	Error       string     `json:"error,omitempty"`
+	NextAttempt *time.Time `json:"next_attempt_at,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (d OutboxDelivery) Lines() []string {
+	switch d.Status {
+	case outbox.StatusSent:
+		return []string{fmt.Sprintf("✓ #%d sent to %s (ts %s)", d.ID, d.Channel, d.TS)}
+	case outbox.StatusFailed:
+		return []string{fmt.Sprintf("✗ #%d to %s failed: %s", d.ID, d.Channel, d.Error)}
+	default:
+		line := fmt.Sprintf("… #%d to %s queued", d.ID, d.Channel)
+		if d.NextAttempt != nil {
+			line += ", next attempt " + d.NextAttempt.Local().Format(time.RFC3339)
+		}
+		if d.Error != "" {
+			line += ": " + d.Error
+		}
+		return []string{line}
+	}
+}
+
+// OutboxQueued is the result of outbox add.
+type OutboxQueued struct {
+	OK        bool   `json:"ok"`
+	ID        int64  `json:"id"`
+	Status    string `json:"status"`
+	Channel   string `json:"channel"`
+	Duplicate bool   `json:"duplicate"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (q OutboxQueued) Lines() []string {
+	if q.Duplicate {
+		return []string{fmt.Sprintf("Already queued as #%d (%s)", q.ID, q.Status)}
+	}
+	return []string{fmt.Sprintf("Queued #%d for %s", q.ID, q.Channel)}
+}
+
+// OutboxFlushResult summarizes a flush.
+type OutboxFlushResult struct {
+	Sent       int              `json:"sent"`
+	Pending    int              `json:"pending"`
+	Failed     int              `json:"failed"`
+	Deliveries []OutboxDelivery `json:"deliveries"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r OutboxFlushResult) Lines() []string {
+	var lines []string
+	for _, d := range r.Deliveries {
+		lines = append(lines, d.Lines()...)
+	}
+	return append(lines, fmt.Sprintf("Sent %d; %d pending, %d failed in the outbox", r.Sent, r.Pending, r.Failed))
+}
+
+// OutboxListResult is the result of outbox list.
+type OutboxListResult struct {
+	Path     string           `json:"path"`
+	Counts   map[string]int   `json:"counts"`
+	Messages []outbox.Message `json:"messages"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r OutboxListResult) Lines() []string {
+	lines := []string{fmt.Sprintf("%d pending, %d sent, %d failed", r.Counts[outbox.StatusPending], r.Counts[outbox.StatusSent], r.Counts[outbox.StatusFailed])}
+	for _, m := range r.Messages {
+		body := m.Text
+		if body == "" {
+			body = "(blocks)"
+		}
+		line := fmt.Sprintf("#%-4d %-7s %s %s: %s", m.ID, m.Status, m.CreatedAt.Local().Format("2006-01-02 15:04"), m.Channel, output.Truncate(strings.ReplaceAll(body, "\n", " "), 60))
+		if m.LastError != "" {
+			line += fmt.Sprintf(" [%d attempts: %s]", m.Attempts, m.LastError)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func runOutboxAdd(cmd *cobra.Command, args []string) error {
+	msg, redactions, err := outboxMessageFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	store, err := openOutbox()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	id, duplicate, err := store.Add(cmd.Context(), msg)
+	if err != nil {
+		return err
+	}
+	result := OutboxQueued{OK: true, ID: id, Status: outbox.StatusPending, Channel: msg.Channel, Duplicate: duplicate}
+	if duplicate {
+		existing, err := findOutboxMessage(cmd, store, id)
+		if err != nil {
+			return err
+		}
+		result.Status = existing.Status
+	}
+	return output.Print(cmd, withRedactions(result, redactions))
+}
+
+func runOutboxSend(cmd *cobra.Command, args []string) error {
+	msg, redactions, err := outboxMessageFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	store, err := openOutbox()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	id, duplicate, err := store.Add(cmd.Context(), msg)
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		existing, err := findOutboxMessage(cmd, store, id)
+		if err != nil {
+			return err
+		}
+		if existing.Status != outbox.StatusPending {
+			return output.Print(cmd, withRedactions(deliveryFromMessage(existing), redactions))
+		}
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		if outcome := outbox.Classify(err); outcome.Offline {
+			next := outcome.NextAttempt(time.Now(), 1)
+			if retryErr := store.Retry(cmd.Context(), id, err.Error(), next); retryErr != nil {
+				return retryErr
+			}
+			return output.Print(cmd, withRedactions(OutboxDelivery{ID: id, Channel: msg.Channel, Status: outbox.StatusPending, Error: err.Error(), NextAttempt: &next}, redactions))
+		}
+		return err
+	}
+	defer cmdCtx.Close()
+
+	override, _ := cmd.Flags().GetBool("override-quiet-hours")
+	claimed, ok, err := store.Claim(cmdCtx.Ctx, id, time.Now(), outboxLease)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Another worker holds the lease; it will deliver or release the message.
+		return output.Print(cmd, withRedactions(OutboxDelivery{ID: id, Channel: msg.Channel, Status: outbox.StatusPending, Error: "delivery already in progress"}, redactions))
+	}
+	delivery, _ := deliverOutboxMessage(cmdCtx, store, claimed, override)
+	if err := output.Print(cmd, withRedactions(delivery, redactions)); err != nil {
+		return err
+	}
+	if delivery.Status == outbox.StatusFailed {
+		return cerrors.NewErrorWithCode(cerrors.ExitGeneral, "outbox message %d failed: %s", delivery.ID, delivery.Error)
+	}
+	return nil
+}
+
+func runOutboxFlush(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	includeFailed, _ := cmd.Flags().GetBool("include-failed")
+	override, _ := cmd.Flags().GetBool("override-quiet-hours")
+
+	store, err := openOutbox()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	if includeFailed {
+		if _, err := store.Requeue(cmd.Context(), 0); err != nil {
+			return err
+		}
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	deliveries, err := flushOutbox(cmdCtx, store, limit, override)
+	if err != nil {
+		return err
+	}
+	result := OutboxFlushResult{Deliveries: deliveries}
+	for _, d := range deliveries {
+		if d.Status == outbox.StatusSent {
+			result.Sent++
+		}
+	}
+	counts, err := store.Counts(cmdCtx.Ctx)
+	if err != nil {
+		return err
+	}
+	result.Pending, result.Failed = counts[outbox.StatusPending], counts[outbox.StatusFailed]
+	return output.Print(cmd, result)
+}
+
+func runOutboxList(cmd *cobra.Command, args []string) error {
+	status, _ := cmd.Flags().GetString("status")
+	limit, _ := cmd.Flags().GetInt("limit")
+	switch status {
+	case outbox.StatusPending, outbox.StatusSent, outbox.StatusFailed:
+	case "all":
+		status = ""
+	default:
+		return fmt.Errorf("invalid --status %q: must be pending, sent, failed, or all", status)
+	}
+
+	store, err := openOutbox()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	messages, err := store.List(cmd.Context(), status, limit)
+	if err != nil {
+		return err
+	}
+	counts, err := store.Counts(cmd.Context())
+	if err != nil {
+		return err
+	}
+	return output.Print(cmd, OutboxListResult{Path: store.Path(), Counts: counts, Messages: messages})
+}
+
+// flushOutbox delivers due messages until none are left, limit is reached, or Slack
+// is unreachable.
+func flushOutbox(cmdCtx *CommandContext, store *outbox.Store, limit int, override bool) ([]OutboxDelivery, error) {
+	deliveries := []OutboxDelivery{}
+	for limit <= 0 || len(deliveries) < limit {
+		msg, ok, err := store.Claim(cmdCtx.Ctx, 0, time.Now(), outboxLease)
+		if err != nil {
+			return deliveries, err
+		}
+		if !ok {
+			break
+		}
+		delivery, offline := deliverOutboxMessage(cmdCtx, store, msg, override)
+		deliveries = append(deliveries, delivery)
+		if offline {
+			break
+		}
+	}
+	return deliveries, nil
+}
+
+// deliverOutboxMessage posts a claimed message and records the outcome. It reports
+// offline when Slack is unreachable or rate limiting, so callers stop flushing.
+func deliverOutboxMessage(cmdCtx *CommandContext, store *outbox.Store, msg outbox.Message, override bool) (OutboxDelivery, bool) {
+	delivery := OutboxDelivery{ID: msg.ID, Channel: msg.Channel, Status: outbox.StatusPending, Attempts: msg.Attempts}
+	fail := func(err error) (OutboxDelivery, bool) {
+		delivery.Error = err.Error()
+		outcome := outbox.Classify(err)
+		if outcome.Permanent {
+			delivery.Status = outbox.StatusFailed
+			if markErr := store.MarkFailed(cmdCtx.Ctx, msg.ID, delivery.Error); markErr != nil {
+				output.Warnf("%v", markErr)
+			}
+			return delivery, false
+		}
+		next := outcome.NextAttempt(time.Now(), msg.Attempts)
+		delivery.NextAttempt = &next
+		if retryErr := store.Retry(cmdCtx.Ctx, msg.ID, delivery.Error, next); retryErr != nil {
+			output.Warnf("%v", retryErr)
+		}
+		return delivery, outcome.Offline
+	}
+
+	channelID, err := cmdCtx.ResolveChannel(msg.Channel)
+	if err != nil {
+		return fail(err)
+	}
+	delivery.ChannelID = channelID
+
+	if cmdCtx.Config != nil && cmdCtx.Config.QuietHours != nil {
+		keys := []string{channelID}
+		if name := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID); name != "" && name != channelID {
+			keys = append(keys, "#"+name, name)
+		}
+		decision, err := policy.QuietHours(cmdCtx.Config.QuietHours, policy.QuietHoursRequest{Keys: keys, Override: override, Now: time.Now()})
+		if err != nil {
+			return fail(err)
+		}
+		if decision.Blocked() {
+			// Held for the next window; not a failed attempt.
+			delivery.Error = "quiet hours"
+			delivery.NextAttempt = decision.NextAllowed
+			if err := store.Retry(cmdCtx.Ctx, msg.ID, delivery.Error, *decision.NextAllowed); err != nil {
+				output.Warnf("%v", err)
+			}
+			return delivery, false
+		}
+	}
+
+	blocks, err := parseBlocksJSON(msg.Blocks)
+	if err != nil {
+		return fail(fmt.Errorf("invalid_blocks: %w", err))
+	}
+	posted, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, channelID, slack.PostMessageOptions{
+		Text:        msg.Text,
+		ThreadTS:    msg.ThreadTS,
+		Blocks:      blocks,
+		UnfurlLinks: true,
+		UnfurlMedia: true,
+		AsUser:      cmdCtx.AuthRole == config.RoleUser,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	delivery.Status = outbox.StatusSent
+	delivery.TS = posted.Timestamp
+	if err := store.MarkSent(cmdCtx.Ctx, msg.ID, channelID, posted.Timestamp); err != nil {
+		// The message is posted; the lease expiring would post it again.
+		output.Warnf("message %d was posted but not recorded: %v", msg.ID, err)
+	}
+	return delivery, false
+}
+
+// outboxMessageFromFlags builds a message from the add/send flags, with secrets masked
+// before it is stored.
+func outboxMessageFromFlags(cmd *cobra.Command) (outbox.Message, []redact.Finding, error) {
+	channel, _ := cmd.Flags().GetString("channel")
+	text, _ := cmd.Flags().GetString("text")
+	mrkdwnText, _ := cmd.Flags().GetString("mrkdwn")
+	thread, _ := cmd.Flags().GetString("thread")
+	key, _ := cmd.Flags().GetString("key")
+	blocksJSON, err := readBlocksFlags(cmd)
+	if err != nil {
+		return outbox.Message{}, nil, err
+	}
+	if text != "" && mrkdwnText != "" {
+		return outbox.Message{}, nil, fmt.Errorf("choose one of --mrkdwn or --text")
+	}
+	if mrkdwnText != "" {
+		text = mrkdwnText
+	}
+	if text == "" && blocksJSON == "" {
+		return outbox.Message{}, nil, fmt.Errorf("choose a message input: --mrkdwn, --text, --blocks, or --blocks-file")
+	}
+	if blocksJSON != "" {
+		if _, problems := blockkit.Validate([]byte(blocksJSON)); len(problems) > 0 {
+			return outbox.Message{}, nil, fmt.Errorf("invalid blocks: %w", problems)
+		}
+	}
+	cfg, _, err := config.Load(cfgFile)
+	if err != nil {
+		return outbox.Message{}, nil, cerrors.ConfigError("failed to load config: %w", err)
+	}
+	text, blocksJSON, findings, err := redactMessage(cmd, cfg, text, blocksJSON)
+	if err != nil {
+		return outbox.Message{}, nil, err
+	}
+	return outbox.Message{Key: key, Channel: channel, Text: text, ThreadTS: thread, Blocks: blocksJSON}, findings, nil
+}
+
+func openOutbox() (*outbox.Store, error) {
+	_, configPath, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, cerrors.ConfigError("failed to load config: %w", err)
+	}
+	path, err := outbox.DefaultPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	store, err := outbox.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open outbox: %w", err)
+	}
+	return store, nil
+}
+
+func findOutboxMessage(cmd *cobra.Command, store *outbox.Store, id int64) (outbox.Message, error) {
+	msg, ok, err := store.Get(cmd.Context(), id)
+	if err != nil {
+		return outbox.Message{}, err
+	}
+	if !ok {
+		return outbox.Message{}, cerrors.NotFoundError("outbox message", fmt.Sprint(id), "")
+	}
+	return msg, nil
+}
+
+func deliveryFromMessage(m outbox.Message) OutboxDelivery {
+	return OutboxDelivery{ID: m.ID, Channel: m.Channel, ChannelID: m.ChannelID, Status: m.Status, TS: m.TS, Attempts: m.Attempts, Error: m.LastError, NextAttempt: m.NextAttempt}
+}
+
+// daemonOutbox delivers pending outbox messages from daemon run. The outbox is opened
+// on the first flush after it has been created, so daemons that never use it do not
+// create one.
+type daemonOutbox struct {
+	path  string
+	store *outbox.Store
+}
+
+func newDaemonOutbox(configPath string) (*daemonOutbox, error) {
+	path, err := outbox.DefaultPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonOutbox{path: path}, nil
+}
+
+// Flush delivers due messages and logs the outcome to stderr.
+func (d *daemonOutbox) Flush(cmdCtx *CommandContext) {
+	if d.store == nil {
+		if _, err := os.Stat(d.path); err != nil {
+			return
+		}
+		store, err := outbox.Open(d.path)
+		if err != nil {
+			fmt.Fprintf(output.Stderr(), "failed to open outbox: %v\n", err)
+			return
+		}
+		d.store = store
+	}
+	deliveries, err := flushOutbox(cmdCtx, d.store, 0, false)
+	if err != nil {
+		fmt.Fprintf(output.Stderr(), "failed to flush outbox: %v\n", err)
+	}
+	for _, delivery := range deliveries {
+		output.Statusf("outbox %s\n", delivery.Lines()[0])
+	}
+}
+
+// Close closes the outbox if it was opened.
+func (d *daemonOutbox) Close() error {
+	return d.store.Close()
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
 	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/pins"
 	"github.com/kehao95/slack-agent-cli/internal/slack"
 	"github.com/spf13/cobra"
 )
@@ -17,10 +19,13 @@ var pinsCmd = &cobra.Command{
 var pinsAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Pin a message",
-	Long:  "Pin a message to a Slack channel.",
+	Long:  "Pin a message to a Slack channel, identified by --channel and --ts or by its --permalink.",
 	Example: `  # Pin a message
   slk pins add --channel "#general" --ts "1705312365.000100"
 
+  # Pin a message by its permalink
+  slk pins add --permalink "https://acme.slack.com/archives/C0123ABC/p1705312365000100"
+
   # Pin with human-readable output
   slk pins add --channel "#general" --ts "1705312365.000100" --human`,
 	RunE: runPinsAdd,
@@ -29,10 +34,13 @@ var pinsAddCmd = &cobra.Command{
 var pinsRemoveCmd = &cobra.Command{
 	Use:   "remove",
 	Short: "Unpin a message",
-	Long:  "Remove a pinned message from a Slack channel.",
+	Long:  "Remove a pinned message from a Slack channel, identified by --channel and --ts or by its --permalink.",
 	Example: `  # Unpin a message
   slk pins remove --channel "#general" --ts "1705312365.000100"
 
+  # Unpin a message by its permalink
+  slk pins remove --permalink "https://acme.slack.com/archives/C0123ABC/p1705312365000100"
+
   # Unpin with human-readable output
   slk pins remove --channel "#general" --ts "1705312365.000100" --human`,
 	RunE: runPinsRemove,
@@ -41,7 +49,32 @@ var pinsRemoveCmd = &cobra.Command{
 var pinsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List pinned messages",
-	Long:  "List all pinned messages in a Slack channel.",
+	Long: `List all pinned messages in a Slack channel, with who pinned each one and when.
+
+Output (JSON):
+  {
+    "ok": true,
+    "channel": "#general",
+    "items": [
+      {
+        "type": "message",
+        "channel": "C123ABC",
+        "created_by": "@bob",
+        "created": 1705400000,
+        "message": {
+          "ts": "1705312365.000100",
+          "text": "Incident runbook: ...",
+          "user": "@alice",
+          "permalink": "https://workspace.slack.com/archives/C123ABC/p1705312365000100",
+          "user_id": "U123ABC"
+        },
+        "created_by_id": "U456DEF"
+      }
+    ]
+  }
+
+JSON resolves the author and pinner to @names, keeping IDs in *_id fields. Use
+--raw-json to keep Slack IDs in their original fields.`,
 	Example: `  # List pinned messages
   slk pins list --channel "#general"
 
@@ -50,27 +83,97 @@ var pinsListCmd = &cobra.Command{
 	RunE: runPinsList,
 }
 
+var pinsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Converge pinned messages to a declared list",
+	Long: `Pin every message listed in a file and unpin every other pinned message in the
+channel, so automation can keep a channel's runbook links pinned. Running it again
+with the same file changes nothing. Pinned files and other non-message items are
+left alone.
+
+The file is YAML (or JSON) with a "pins" list. Entries are permalinks or message
+timestamps, either bare or as mappings with an optional note. Permalinks must point
+to the --channel channel. An empty list unpins every message.
+
+  pins:
+    - permalink: https://acme.slack.com/archives/C0123ABC/p1705312365000100
+      note: Incident runbook
+    - ts: "1705312400.000200"
+    - https://acme.slack.com/archives/C0123ABC/p1705312500000300
+
+Unpins are applied before pins, so a channel at Slack's pin limit can still be
+converged. Use --dry-run to see the changes without making them.
+
+Output (JSON):
+  {
+    "ok": true,
+    "channel": "#ops",
+    "channel_id": "C0123ABC",
+    "added": ["1705312500.000300"],
+    "removed": ["1705300000.000100"],
+    "unchanged": ["1705312365.000100", "1705312400.000200"]
+  }`,
+	Example: `  # Keep #ops pins in line with a checked-in file
+  slk pins sync --channel "#ops" --file pins.yaml
+
+  # Preview the changes
+  slk pins sync --channel "#ops" --file pins.yaml --dry-run --human`,
+	RunE: runPinsSync,
+}
+
 func init() {
 	rootCmd.AddCommand(pinsCmd)
 	pinsCmd.AddCommand(pinsAddCmd)
 	pinsCmd.AddCommand(pinsRemoveCmd)
 	pinsCmd.AddCommand(pinsListCmd)
+	pinsCmd.AddCommand(pinsSyncCmd)
 
-	// Flags for add command
-	pinsAddCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
-	pinsAddCmd.Flags().String("ts", "", "Message timestamp (required)")
-	pinsAddCmd.MarkFlagRequired("channel")
-	pinsAddCmd.MarkFlagRequired("ts")
-
-	// Flags for remove command
-	pinsRemoveCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
-	pinsRemoveCmd.Flags().String("ts", "", "Message timestamp (required)")
-	pinsRemoveCmd.MarkFlagRequired("channel")
-	pinsRemoveCmd.MarkFlagRequired("ts")
+	// Flags for add and remove commands
+	for _, c := range []*cobra.Command{pinsAddCmd, pinsRemoveCmd} {
+		c.Flags().StringP("channel", "c", "", "Channel name or ID (required unless --permalink is set)")
+		c.Flags().String("ts", "", "Message timestamp (required unless --permalink is set)")
+		c.Flags().String("permalink", "", "Message permalink, instead of --channel and --ts")
+		addQuietHoursFlag(c)
+		c.MarkFlagsOneRequired("channel", "permalink")
+		c.MarkFlagsOneRequired("ts", "permalink")
+		c.MarkFlagsMutuallyExclusive("channel", "permalink")
+		c.MarkFlagsMutuallyExclusive("ts", "permalink")
+	}
 
 	// Flags for list command
 	pinsListCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	pinsListCmd.Flags().Bool("raw-json", false, "Preserve raw Slack user IDs in JSON output")
 	pinsListCmd.MarkFlagRequired("channel")
+
+	// Flags for sync command
+	pinsSyncCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	pinsSyncCmd.Flags().String("file", "", "YAML or JSON file listing the messages to keep pinned (required)")
+	pinsSyncCmd.Flags().Bool("dry-run", false, "Report the changes without making them")
+	addQuietHoursFlag(pinsSyncCmd)
+	pinsSyncCmd.MarkFlagRequired("channel")
+	pinsSyncCmd.MarkFlagRequired("file")
+}
+
+// pinTarget returns the channel and message named by --channel and --ts, or by
+// --permalink.
+func pinTarget(cmd *cobra.Command, cmdCtx *CommandContext) (channelInput, channelID, timestamp string, err error) {
+	if link, _ := cmd.Flags().GetString("permalink"); link != "" {
+		channelID, timestamp, err = slack.ParsePermalink(link)
+		if err != nil {
+			return "", "", "", err
+		}
+		return channelID, channelID, timestamp, nil
+	}
+
+	channelInput, _ = cmd.Flags().GetString("channel")
+	timestamp, _ = cmd.Flags().GetString("ts")
+
+	// Resolve channel name to ID
+	channelID, err = cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return "", "", "", err
+	}
+	return channelInput, channelID, timestamp, nil
 }
 
 func runPinsAdd(cmd *cobra.Command, args []string) error {
@@ -80,11 +183,12 @@ func runPinsAdd(cmd *cobra.Command, args []string) error {
 	}
 	defer cmdCtx.Close()
 
-	channelInput, _ := cmd.Flags().GetString("channel")
-	timestamp, _ := cmd.Flags().GetString("ts")
+	channelInput, channelID, timestamp, err := pinTarget(cmd, cmdCtx)
+	if err != nil {
+		return err
+	}
 
-	// Resolve channel name to ID
-	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
 	if err != nil {
 		return err
 	}
@@ -102,7 +206,7 @@ func runPinsAdd(cmd *cobra.Command, args []string) error {
 		Timestamp: timestamp,
 	}
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withPolicy(result, decision))
 }
 
 func runPinsRemove(cmd *cobra.Command, args []string) error {
@@ -112,11 +216,12 @@ func runPinsRemove(cmd *cobra.Command, args []string) error {
 	}
 	defer cmdCtx.Close()
 
-	channelInput, _ := cmd.Flags().GetString("channel")
-	timestamp, _ := cmd.Flags().GetString("ts")
+	channelInput, channelID, timestamp, err := pinTarget(cmd, cmdCtx)
+	if err != nil {
+		return err
+	}
 
-	// Resolve channel name to ID
-	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
 	if err != nil {
 		return err
 	}
@@ -134,7 +239,7 @@ func runPinsRemove(cmd *cobra.Command, args []string) error {
 		Timestamp: timestamp,
 	}
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withPolicy(result, decision))
 }
 
 func runPinsList(cmd *cobra.Command, args []string) error {
@@ -145,6 +250,7 @@ func runPinsList(cmd *cobra.Command, args []string) error {
 	defer cmdCtx.Close()
 
 	channelInput, _ := cmd.Flags().GetString("channel")
+	rawJSON, _ := cmd.Flags().GetBool("raw-json")
 
 	// Resolve channel name to ID
 	channelID, err := cmdCtx.ResolveChannel(channelInput)
@@ -160,6 +266,83 @@ func runPinsList(cmd *cobra.Command, args []string) error {
 
 	// Set the channel name in the result for human-readable output
 	result.Channel = channelInput
+	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
+	result.SetRawJSON(rawJSON)
 
 	return output.Print(cmd, result)
 }
+
+func runPinsSync(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+	path, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	spec, err := pins.LoadSpec(path)
+	if err != nil {
+		return cerrors.ConfigError("%v", err)
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+	desired, err := spec.Timestamps(channelID)
+	if err != nil {
+		return cerrors.ConfigError("%v", err)
+	}
+
+	listed, err := cmdCtx.Client.ListPins(cmdCtx.Ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("list pins: %w", err)
+	}
+	var current []string
+	for _, item := range listed.Items {
+		if item.Type == "message" && item.Message != nil {
+			current = append(current, item.Message.Timestamp)
+		}
+	}
+	plan := pins.Diff(desired, current)
+
+	result := &pins.SyncResult{
+		OK:        true,
+		Channel:   channelInput,
+		ChannelID: channelID,
+		DryRun:    dryRun,
+		Added:     []string{},
+		Removed:   []string{},
+		Unchanged: append([]string{}, plan.Unchanged...),
+	}
+	if dryRun {
+		result.Added = append(result.Added, plan.Add...)
+		result.Removed = append(result.Removed, plan.Remove...)
+		return output.Print(cmd, result)
+	}
+	if len(plan.Add) == 0 && len(plan.Remove) == 0 {
+		return output.Print(cmd, result)
+	}
+
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+	for _, ts := range plan.Remove {
+		if err := cmdCtx.Client.RemovePin(cmdCtx.Ctx, channelID, ts); err != nil {
+			return fmt.Errorf("remove pin %s: %w", ts, err)
+		}
+		result.Removed = append(result.Removed, ts)
+	}
+	for _, ts := range plan.Add {
+		if err := cmdCtx.Client.AddPin(cmdCtx.Ctx, channelID, ts); err != nil {
+			return fmt.Errorf("add pin %s: %w", ts, err)
+		}
+		result.Added = append(result.Added, ts)
+	}
+
+	return output.Print(cmd, withPolicy(result, decision))
+}
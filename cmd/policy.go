@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+// addQuietHoursFlag registers --override-quiet-hours on a mutating command.
+func addQuietHoursFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("override-quiet-hours", false, "Run even outside the configured quiet_hours window")
+}
+
+// checkQuietHours evaluates the quiet_hours policy for a command targeting channelID
+// (empty for commands without a channel). It returns nil when no quiet hours are
+// configured. A rejected command prints the decision and returns a policy error; a
+// queued decision is returned for the caller to schedule.
+func checkQuietHours(cmd *cobra.Command, cmdCtx *CommandContext, channelID string, canQueue bool) (*policy.Decision, error) {
+	decision, err := quietHoursDecision(cmd, cmdCtx, channelID, canQueue)
+	if err != nil || decision == nil {
+		return nil, err
+	}
+	if decision.Action == policy.ActionReject {
+		if err := output.Print(cmd, decision); err != nil {
+			return nil, err
+		}
+		return nil, quietHoursError(cmd, decision)
+	}
+	return decision, nil
+}
+
+// quietHoursError is the policy error for a command rejected by quiet hours.
+func quietHoursError(cmd *cobra.Command, decision *policy.Decision) error {
+	return cerrors.WithCode(cerrors.CodeQuietHours, cerrors.PolicyError("%s is blocked by quiet hours until %s; pass --override-quiet-hours to run it anyway",
+		cmd.CommandPath(), decision.NextAllowed.Format(time.RFC3339)))
+}
+
+// quietHoursDecision evaluates the quiet_hours policy for channelID without acting on
+// it, for commands that check several targets. It returns nil when no quiet hours are
+// configured.
+func quietHoursDecision(cmd *cobra.Command, cmdCtx *CommandContext, channelID string, canQueue bool) (*policy.Decision, error) {
+	if cmdCtx.Config == nil || cmdCtx.Config.QuietHours == nil {
+		return nil, nil
+	}
+	override, _ := cmd.Flags().GetBool("override-quiet-hours")
+	var keys []string
+	if channelID != "" {
+		keys = append(keys, channelID)
+		if cmdCtx.ChannelResolver != nil {
+			if name := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID); name != "" && name != channelID {
+				keys = append(keys, "#"+name, name)
+			}
+		}
+	}
+	decision, err := policy.QuietHours(cmdCtx.Config.QuietHours, policy.QuietHoursRequest{
+		Keys:     keys,
+		CanQueue: canQueue,
+		Override: override,
+		Now:      time.Now(),
+	})
+	if err != nil {
+		return nil, cerrors.ConfigError("%v", err)
+	}
+	return &decision, nil
+}
+
+// withPolicy prints a command result with the policy decision that allowed it. A nil
+// decision prints the result unchanged.
+func withPolicy(result output.Printable, decision *policy.Decision) output.Printable {
+	return withDecision(result, "policy", decision)
+}
+
+// withDecision adds decision to result under field. A nil decision returns the result
+// unchanged.
+func withDecision(result output.Printable, field string, decision *policy.Decision) output.Printable {
+	if decision == nil {
+		return result
+	}
+	return policyResult{result: result, field: field, decision: decision}
+}
+
+// policyResult adds a policy decision field to a result's JSON object.
+type policyResult struct {
+	result   output.Printable
+	field    string
+	decision *policy.Decision
+}
+
+func (r policyResult) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(r.result)
+	if err != nil {
+		return nil, err
+	}
+	return addJSONFields(data, map[string]any{r.field: r.decision})
+}
+
+// addJSONFields adds fields to an encoded JSON object.
+func addJSONFields(data []byte, extra map[string]any) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("add result fields: %w", err)
+	}
+	for name, value := range extra {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = encoded
+	}
+	return json.Marshal(fields)
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r policyResult) Lines() []string {
+	return append(r.result.Lines(), r.decision.Lines()...)
+}
+
+// QueuedMessageResult is a message deferred to the next quiet-hours window.
+type QueuedMessageResult struct {
+	OK                 bool   `json:"ok"`
+	Channel            string `json:"channel"`
+	ChannelID          string `json:"channel_id"`
+	ScheduledMessageID string `json:"scheduled_message_id,omitempty"`
+	PostAt             string `json:"post_at"`
+	Text               string `json:"text,omitempty"`
+	IdempotencyKey     string `json:"idempotency_key,omitempty"`
+	TraceID            string `json:"trace_id,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *QueuedMessageResult) Lines() []string {
+	lines := []string{
+		"Message queued for quiet hours",
+		fmt.Sprintf("Channel: %s", r.Channel),
+		fmt.Sprintf("Post at: %s", r.PostAt),
+	}
+	if r.ScheduledMessageID != "" {
+		lines = append(lines, fmt.Sprintf("Scheduled message: %s", r.ScheduledMessageID))
+	}
+	return lines
+}
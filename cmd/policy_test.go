@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/policy"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+func TestWithPolicyAddsDecision(t *testing.T) {
+	result := &slack.ReactionResult{OK: true, Action: "add", ChannelID: "C1", Emoji: "eyes"}
+	if got := withPolicy(result, nil); got != result {
+		t.Fatalf("withPolicy without a decision should return the result unchanged")
+	}
+
+	decision := &policy.Decision{Policy: policy.QuietHoursPolicy, Action: policy.ActionOverride, Reason: "overridden"}
+	data, err := json.Marshal(withPolicy(result, decision))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["emoji"] != "eyes" {
+		t.Fatalf("result fields missing: %s", data)
+	}
+	p, ok := got["policy"].(map[string]any)
+	if !ok || p["action"] != policy.ActionOverride {
+		t.Fatalf("policy missing: %s", data)
+	}
+}
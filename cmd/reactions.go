@@ -17,10 +17,17 @@ var reactionsCmd = &cobra.Command{
 var reactionsAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add reaction to message",
-	Long:  "Add an emoji reaction to a Slack message.",
+	Long: `Add an emoji reaction to a Slack message.
+
+--emoji accepts a name (thumbsup), a shortcode (:thumbsup:, :+1::skin-tone-3:), or
+a Unicode emoji (👍, 👍🏽). Custom emoji that are aliases of another emoji are
+resolved to their target, using the emoji cache when the token has emoji:read.`,
 	Example: `  # Add thumbsup reaction
   slk reactions add --channel "#general" --ts "1705312365.000100" --emoji "thumbsup"
 
+  # The same reaction, pasted as Unicode
+  slk reactions add --channel "#general" --ts "1705312365.000100" --emoji "👍"
+
   # Add custom emoji
   slk reactions add --channel "#general" --ts "1705312365.000100" --emoji "custom_emoji"`,
 	RunE: runReactionsAdd,
@@ -29,7 +36,10 @@ var reactionsAddCmd = &cobra.Command{
 var reactionsRemoveCmd = &cobra.Command{
 	Use:   "remove",
 	Short: "Remove reaction from message",
-	Long:  "Remove an emoji reaction from a Slack message.",
+	Long: `Remove an emoji reaction from a Slack message.
+
+--emoji accepts the same forms as reactions add: a name, a :shortcode:, or a
+Unicode emoji.`,
 	Example: `  # Remove thumbsup reaction
   slk reactions remove --channel "#general" --ts "1705312365.000100" --emoji "thumbsup"
 
@@ -41,10 +51,29 @@ var reactionsRemoveCmd = &cobra.Command{
 var reactionsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List reactions on a message",
-	Long:  "List all emoji reactions on a Slack message.",
+	Long: `List all emoji reactions on a Slack message.
+
+With --with-message the output also carries the message's text and author, so the
+reactions can be understood without a second call.
+
+Output (JSON):
+  {
+    "ok": true,
+    "channel": "#general",
+    "channel_id": "C123ABC",
+    "ts": "1705312365.000100",
+    "message": {"ts": "1705312365.000100", "text": "Deploy finished", "user": "@alice", "user_id": "U123ABC"},
+    "reactions": [{"name": "tada", "count": 2, "users": ["U456DEF", "U789GHI"]}]
+  }
+
+JSON resolves the author to an @name, keeping the ID in user_id. Use --raw-json to
+keep the Slack ID in the user field.`,
 	Example: `  # List reactions on a message
   slk reactions list --channel "#general" --ts "1705312365.000100"
 
+  # Include the message text and author
+  slk reactions list --channel "#general" --ts "1705312365.000100" --with-message
+
   # List with human-readable output
   slk reactions list --channel "#general" --ts "1705312365.000100" --human`,
 	RunE: runReactionsList,
@@ -59,22 +88,26 @@ func init() {
 	// Flags for add command
 	reactionsAddCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
 	reactionsAddCmd.Flags().String("ts", "", "Message timestamp (required)")
-	reactionsAddCmd.Flags().StringP("emoji", "e", "", "Emoji name without colons (required)")
+	reactionsAddCmd.Flags().StringP("emoji", "e", "", "Emoji name, :shortcode:, or Unicode emoji (required)")
 	reactionsAddCmd.MarkFlagRequired("channel")
+	addQuietHoursFlag(reactionsAddCmd)
 	reactionsAddCmd.MarkFlagRequired("ts")
 	reactionsAddCmd.MarkFlagRequired("emoji")
 
 	// Flags for remove command
 	reactionsRemoveCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
 	reactionsRemoveCmd.Flags().String("ts", "", "Message timestamp (required)")
-	reactionsRemoveCmd.Flags().StringP("emoji", "e", "", "Emoji name without colons (required)")
+	reactionsRemoveCmd.Flags().StringP("emoji", "e", "", "Emoji name, :shortcode:, or Unicode emoji (required)")
 	reactionsRemoveCmd.MarkFlagRequired("channel")
+	addQuietHoursFlag(reactionsRemoveCmd)
 	reactionsRemoveCmd.MarkFlagRequired("ts")
 	reactionsRemoveCmd.MarkFlagRequired("emoji")
 
 	// Flags for list command
 	reactionsListCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
 	reactionsListCmd.Flags().String("ts", "", "Message timestamp (required)")
+	reactionsListCmd.Flags().Bool("with-message", false, "Include the message's text and author")
+	reactionsListCmd.Flags().Bool("raw-json", false, "Preserve raw Slack user IDs in JSON output")
 	reactionsListCmd.MarkFlagRequired("channel")
 	reactionsListCmd.MarkFlagRequired("ts")
 }
@@ -88,7 +121,7 @@ func runReactionsAdd(cmd *cobra.Command, args []string) error {
 
 	channelInput, _ := cmd.Flags().GetString("channel")
 	timestamp, _ := cmd.Flags().GetString("ts")
-	emoji, _ := cmd.Flags().GetString("emoji")
+	emojiInput, _ := cmd.Flags().GetString("emoji")
 
 	// Resolve channel name to ID
 	channelID, err := cmdCtx.ResolveChannel(channelInput)
@@ -96,6 +129,16 @@ func runReactionsAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	emoji, err := cmdCtx.ResolveEmoji(emojiInput)
+	if err != nil {
+		return err
+	}
+
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+
 	// Add the reaction
 	if err := cmdCtx.Client.AddReaction(cmdCtx.Ctx, channelID, timestamp, emoji); err != nil {
 		return fmt.Errorf("add reaction: %w", err)
@@ -110,7 +153,7 @@ func runReactionsAdd(cmd *cobra.Command, args []string) error {
 		Emoji:     emoji,
 	}
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withPolicy(result, decision))
 }
 
 func runReactionsRemove(cmd *cobra.Command, args []string) error {
@@ -122,7 +165,7 @@ func runReactionsRemove(cmd *cobra.Command, args []string) error {
 
 	channelInput, _ := cmd.Flags().GetString("channel")
 	timestamp, _ := cmd.Flags().GetString("ts")
-	emoji, _ := cmd.Flags().GetString("emoji")
+	emojiInput, _ := cmd.Flags().GetString("emoji")
 
 	// Resolve channel name to ID
 	channelID, err := cmdCtx.ResolveChannel(channelInput)
@@ -130,6 +173,16 @@ func runReactionsRemove(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	emoji, err := cmdCtx.ResolveEmoji(emojiInput)
+	if err != nil {
+		return err
+	}
+
+	decision, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+
 	// Remove the reaction
 	if err := cmdCtx.Client.RemoveReaction(cmdCtx.Ctx, channelID, timestamp, emoji); err != nil {
 		return fmt.Errorf("remove reaction: %w", err)
@@ -144,7 +197,7 @@ func runReactionsRemove(cmd *cobra.Command, args []string) error {
 		Emoji:     emoji,
 	}
 
-	return output.Print(cmd, result)
+	return output.Print(cmd, withPolicy(result, decision))
 }
 
 func runReactionsList(cmd *cobra.Command, args []string) error {
@@ -156,6 +209,8 @@ func runReactionsList(cmd *cobra.Command, args []string) error {
 
 	channelInput, _ := cmd.Flags().GetString("channel")
 	timestamp, _ := cmd.Flags().GetString("ts")
+	withMessage, _ := cmd.Flags().GetBool("with-message")
+	rawJSON, _ := cmd.Flags().GetBool("raw-json")
 
 	// Resolve channel name to ID
 	channelID, err := cmdCtx.ResolveChannel(channelInput)
@@ -164,13 +219,19 @@ func runReactionsList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get reactions
-	result, err := cmdCtx.Client.GetReactions(cmdCtx.Ctx, channelID, timestamp)
+	result, err := cmdCtx.Client.GetReactions(cmdCtx.Ctx, slack.ReactionParams{
+		Channel:        channelID,
+		Timestamp:      timestamp,
+		IncludeMessage: withMessage,
+	})
 	if err != nil {
 		return fmt.Errorf("get reactions: %w", err)
 	}
 
 	// Set the channel name in the result for human-readable output
 	result.Channel = channelInput
+	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
+	result.SetRawJSON(rawJSON)
 
 	return output.Print(cmd, result)
 }
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/redact"
+	"github.com/spf13/cobra"
+)
+
+// addRedactFlag registers --no-redact on a command that posts message content.
+func addRedactFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-redact", false, "Post as-is without masking secrets such as API keys and emails")
+}
+
+// newRedactor returns the configured secrets filter, or nil when redaction is disabled
+// in config or with --no-redact.
+func newRedactor(cmd *cobra.Command, cfg *config.Config) (*redact.Redactor, error) {
+	if noRedact, _ := cmd.Flags().GetBool("no-redact"); noRedact {
+		return nil, nil
+	}
+	var settings config.Redaction
+	if cfg != nil && cfg.Redaction != nil {
+		settings = *cfg.Redaction
+	}
+	if settings.Disabled {
+		return nil, nil
+	}
+	r, err := redact.New(settings.Skip, settings.Patterns)
+	if err != nil {
+		return nil, cerrors.ConfigError("redaction: %v", err)
+	}
+	return r, nil
+}
+
+// redactMessage masks secrets in a message's text and Block Kit JSON.
+func redactMessage(cmd *cobra.Command, cfg *config.Config, text, blocksJSON string) (string, string, []redact.Finding, error) {
+	r, err := newRedactor(cmd, cfg)
+	if err != nil || r == nil {
+		return text, blocksJSON, nil, err
+	}
+	text, textFindings := r.Text(text)
+	var blockFindings []redact.Finding
+	if blocksJSON != "" {
+		if blocksJSON, blockFindings, err = r.JSON(blocksJSON); err != nil {
+			return "", "", nil, err
+		}
+	}
+	return text, blocksJSON, redact.Merge(textFindings, blockFindings), nil
+}
+
+// withRedactions adds a "redacted" report to a result. No findings return the result
+// unchanged.
+func withRedactions(result output.Printable, findings []redact.Finding) output.Printable {
+	if len(findings) == 0 {
+		return result
+	}
+	return redactedResult{result: result, findings: findings}
+}
+
+// redactedResult adds the masked patterns to a result's JSON object.
+type redactedResult struct {
+	result   output.Printable
+	findings []redact.Finding
+}
+
+func (r redactedResult) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(r.result)
+	if err != nil {
+		return nil, err
+	}
+	return addJSONFields(data, map[string]any{"redacted": r.findings})
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r redactedResult) Lines() []string {
+	parts := make([]string, 0, len(r.findings))
+	for _, f := range r.findings {
+		parts = append(parts, fmt.Sprintf("%d %s", f.Count, f.Pattern))
+	}
+	return append(r.result.Lines(), "Redacted: "+strings.Join(parts, ", "))
+}
+
+// addIncludePIIFlag registers --include-pii on a command that returns user profiles.
+func addIncludePIIFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("include-pii", false, "Include email addresses (also expose_emails in config)")
+}
+
+// includePII reports whether --include-pii or expose_emails in config lets a command
+// print email addresses from user profiles.
+func includePII(cmd *cobra.Command, cfg *config.Config) bool {
+	if include, _ := cmd.Flags().GetBool("include-pii"); include {
+		return true
+	}
+	return cfg != nil && cfg.ExposeEmails
+}
+
+// scrubPII is the --scrub mode that masks emails, phone numbers, and scrub.patterns.
+const scrubPII = "pii"
+
+// addScrubFlag registers --scrub on a command that returns fetched messages.
+func addScrubFlag(cmd *cobra.Command) {
+	cmd.Flags().String("scrub", "", "Mask personal data in returned messages: pii (emails, phone numbers, and scrub.patterns in config)")
+}
+
+// newScrubber returns the --scrub filter, or nil when --scrub is not set.
+func newScrubber(cmd *cobra.Command, cfg *config.Config) (*redact.Redactor, error) {
+	mode, _ := cmd.Flags().GetString("scrub")
+	return newScrubberMode(mode, cfg)
+}
+
+// newScrubberMode builds the scrubber for a --scrub mode recorded by an earlier run.
+func newScrubberMode(mode string, cfg *config.Config) (*redact.Redactor, error) {
+	switch mode {
+	case "":
+		return nil, nil
+	case scrubPII:
+	default:
+		return nil, fmt.Errorf("invalid --scrub value %q: must be %q", mode, scrubPII)
+	}
+	var patterns map[string]string
+	if cfg != nil && cfg.Scrub != nil {
+		patterns = cfg.Scrub.Patterns
+	}
+	r, err := redact.NewPII(patterns)
+	if err != nil {
+		return nil, cerrors.ConfigError("scrub: %v", err)
+	}
+	return r, nil
+}
+
+// withScrub masks personal data in a result's JSON and human-readable output. A nil
+// scrubber returns the result unchanged.
+func withScrub(result output.Printable, scrubber *redact.Redactor) output.Printable {
+	if scrubber == nil {
+		return result
+	}
+	return scrubbedResult{result: result, scrubber: scrubber}
+}
+
+// scrubbedResult masks every string in a result before it is printed.
+type scrubbedResult struct {
+	result   output.Printable
+	scrubber *redact.Redactor
+}
+
+func (r scrubbedResult) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(r.result)
+	if err != nil {
+		return nil, err
+	}
+	return scrubJSON(r.scrubber)(data)
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r scrubbedResult) Lines() []string {
+	lines := r.result.Lines()
+	for i, line := range lines {
+		lines[i], _ = r.scrubber.Text(line)
+	}
+	return lines
+}
+
+// scrubJSON adapts a scrubber to rewrite JSON documents, as messages.ExportConfig.Scrub
+// expects. A nil scrubber returns nil.
+func scrubJSON(scrubber *redact.Redactor) func([]byte) ([]byte, error) {
+	if scrubber == nil {
+		return nil
+	}
+	return func(data []byte) ([]byte, error) {
+		scrubbed, _, err := scrubber.JSON(string(data))
+		return []byte(scrubbed), err
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// filterFileFlags are the stream filter flags a --filter-file may set.
+var filterFileFlags = []string{
+	"channel", "conversation-type", "event-type", "thread",
+	"threads-only", "exclude-self", "subtypes", "exclude-subtypes",
+}
+
+// liveReloader re-reads configuration and the optional filter file when the process
+// receives SIGHUP, so long-running stream and daemon loops can pick up new filters
+// without dropping their Socket Mode connection.
+type liveReloader struct {
+	cmd        *cobra.Command
+	filterFile string
+	defaults   map[string]string
+	signals    chan os.Signal
+}
+
+// newLiveReloader snapshots the command-line filter values, applies the filter file
+// once, and starts listening for SIGHUP. Call Stop when the loop exits.
+func newLiveReloader(cmd *cobra.Command) (*liveReloader, error) {
+	filterFile, _ := cmd.Flags().GetString("filter-file")
+	r := &liveReloader{
+		cmd:        cmd,
+		filterFile: strings.TrimSpace(filterFile),
+		defaults:   map[string]string{},
+		signals:    make(chan os.Signal, 1),
+	}
+	for _, name := range filterFileFlags {
+		if flag := cmd.Flags().Lookup(name); flag != nil {
+			r.defaults[name] = flag.Value.String()
+		}
+	}
+	if err := r.applyFilterFile(); err != nil {
+		return nil, err
+	}
+	signal.Notify(r.signals, syscall.SIGHUP)
+	return r, nil
+}
+
+// C delivers a value for every SIGHUP received.
+func (r *liveReloader) C() <-chan os.Signal {
+	return r.signals
+}
+
+// Stop stops SIGHUP delivery.
+func (r *liveReloader) Stop() {
+	signal.Stop(r.signals)
+}
+
+// applyFilterFile resets filter flags to their command-line values and then overlays
+// the filter file. Keys are flag names; values may be strings, booleans, or string lists.
+func (r *liveReloader) applyFilterFile() error {
+	for name, value := range r.defaults {
+		if err := r.cmd.Flags().Set(name, value); err != nil {
+			return err
+		}
+	}
+	if r.filterFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.filterFile)
+	if err != nil {
+		return cerrors.ConfigError("read filter file: %w", err)
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return cerrors.ConfigError("parse filter file %s: %w", r.filterFile, err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := r.defaults[name]; !ok {
+			return cerrors.ConfigError("filter file %s: unsupported key %q (supported: %s)", r.filterFile, name, strings.Join(r.supportedKeys(), ", "))
+		}
+		value, err := filterFileValue(values[name])
+		if err != nil {
+			return cerrors.ConfigError("filter file %s: %s: %w", r.filterFile, name, err)
+		}
+		if err := r.cmd.Flags().Set(name, value); err != nil {
+			return cerrors.ConfigError("filter file %s: %s: %w", r.filterFile, name, err)
+		}
+	}
+	return nil
+}
+
+func (r *liveReloader) supportedKeys() []string {
+	keys := make([]string, 0, len(r.defaults))
+	for _, name := range filterFileFlags {
+		if _, ok := r.defaults[name]; ok {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+func filterFileValue(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return fmt.Sprintf("%t", value), nil
+	case nil:
+		return "", nil
+	case []interface{}:
+		parts := make([]string, 0, len(value))
+		for _, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return "", fmt.Errorf("list entries must be strings")
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+// reloadStreamConfig re-reads the config file into the running context. Token changes
+// cannot be applied to an open connection, so they are reported and left for a restart.
+func reloadStreamConfig(cmdCtx *CommandContext) error {
+	cfg, token, cookie, role, _, err := loadConfigForEvents()
+	if err != nil {
+		return err
+	}
+	if token != cmdCtx.AuthToken || cookie != cmdCtx.AuthCookie || role != cmdCtx.AuthRole || cfg.AppToken != cmdCtx.Config.AppToken {
+		output.Statusf("Credential or role changes take effect after a restart; keeping the current connection.\n")
+		cfg.Role = cmdCtx.Config.Role
+		cfg.UserToken = cmdCtx.Config.UserToken
+		cfg.BotToken = cmdCtx.Config.BotToken
+		cfg.AppToken = cmdCtx.Config.AppToken
+		cfg.Cookie = cmdCtx.Config.Cookie
+	}
+	cmdCtx.Config = cfg
+	sanitizeRuntimeContextForRole(cmdCtx)
+	return nil
+}
+
+// reloadStreamFilter applies a SIGHUP: config first, then the filter file and flags.
+// On any error the previous filter stays in effect.
+func reloadStreamFilter(cmdCtx *CommandContext, reloader *liveReloader, build func() (streamFilter, error), current streamFilter) streamFilter {
+	if err := reloadStreamConfig(cmdCtx); err != nil {
+		fmt.Fprintf(output.Stderr(), "Reload failed, keeping previous configuration: %v\n", err)
+		return current
+	}
+	if err := reloader.applyFilterFile(); err != nil {
+		fmt.Fprintf(output.Stderr(), "Reload failed, keeping previous filters: %v\n", err)
+		return current
+	}
+	next, err := build()
+	if err != nil {
+		fmt.Fprintf(output.Stderr(), "Reload failed, keeping previous filters: %v\n", err)
+		return current
+	}
+	output.Statusf("Reloaded configuration and filters.\n")
+	return next
+}
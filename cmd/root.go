@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/kehao95/slack-agent-cli/internal/config"
 	"github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/tracing"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"golang.org/x/term"
 )
 
 var (
@@ -45,10 +49,12 @@ Exit Codes:
   1 - General error
   2 - Configuration error (missing config, invalid tokens)
   3 - Authentication error (invalid/expired tokens)
-  4 - Rate limit exceeded
+  4 - Rate limit exceeded (or a budgets entry used up under --no-wait)
   5 - Network error
   6 - Permission denied (missing OAuth scopes)
   7 - Resource not found (channel, user, message)
+  8 - Blocked by policy (quiet hours, dedupe window, read-only mode, channel lists)
+  9 - Refused by an open circuit breaker (see slk health api)
   124 - Wait timeout (for example events next --timeout)
 
 Environment Variables:
@@ -57,39 +63,100 @@ Environment Variables:
   SLACK_BOT_TOKEN      Override bot token from config
   SLACK_APP_TOKEN      App-level token for Socket Mode events
   SLACK_CLI_CONFIG     Custom config file path
-  SLACK_CLI_FORMAT     Default output format (json or human)`,
+  SLACK_CLI_FORMAT     Default output format (json or human)
+  NO_COLOR             Disable colors in --human output (same as --no-color)
+  SLK_<COMMAND>_<FLAG> Supply any flag, e.g. SLK_MESSAGES_LIST_LIMIT=100 (see slk config env)
+  OTEL_EXPORTER_OTLP_ENDPOINT
+                       Export OpenTelemetry traces (OTLP/HTTP) for commands and API calls`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			annotateCommandSpan(cmd)
+			if err := applyEnvFlagOverrides(cmd); err != nil {
+				return err
+			}
+			colorMode, err := output.ColorMode(cmd)
+			if err != nil {
+				return errors.ConfigError("%v", err)
+			}
+			output.SetColorMode(colorMode)
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			output.SetQuiet(quiet)
+			portable, _ := cmd.Flags().GetBool("portable")
+			config.SetPortable(portable)
+			return applyConfigFlagDefaults(cmd)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// Easter egg: Warn biological users about JSON output
-			if term.IsTerminal(int(os.Stdout.Fd())) {
+			if output.IsTerminal(output.Stdout()) && !output.Quiet() {
 				// ANSI color codes: Yellow background + Black text for warning
 				yellow := "\033[43m"
 				black := "\033[30m"
 				bold := "\033[1m"
 				reset := "\033[0m"
 				red := "\033[31m"
+				if !output.TerminalFor(output.Stderr()).Color {
+					yellow, black, bold, reset, red = "", "", "", "", ""
+				}
 
-				fmt.Fprintf(os.Stderr, "\n%s%s%s ⚠️  WARNING ⚠️  %s\n", yellow, black, bold, reset)
-				fmt.Fprintf(os.Stderr, "%s%sDetected biological presence.%s\n", red, bold, reset)
-				fmt.Fprintf(os.Stderr, "Use %s--human%s if you can't read JSON.\n\n", bold, reset)
+				fmt.Fprintf(output.Stderr(), "\n%s%s%s ⚠️  WARNING ⚠️  %s\n", yellow, black, bold, reset)
+				fmt.Fprintf(output.Stderr(), "%s%sDetected biological presence.%s\n", red, bold, reset)
+				fmt.Fprintf(output.Stderr(), "Use %s--human%s if you can't read JSON.\n\n", bold, reset)
 			}
 			cmd.Help()
 		},
 	}
 )
 
+// Build information injected by GoReleaser; buildVersion is "dev" for local builds.
+var (
+	buildVersion = "dev"
+	buildCommit  = "none"
+	buildDate    = "unknown"
+)
+
 // SetVersionInfo sets version information for the CLI.
 // This is called from main.go with values injected by GoReleaser.
 func SetVersionInfo(version, commit, date string) {
+	buildVersion, buildCommit, buildDate = version, commit, date
 	rootCmd.Version = fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date)
 }
 
 // Execute runs the root command with proper exit code handling.
 func Execute() {
-	errors.Execute(rootCmd)
+	shutdownTracing := tracing.Setup()
+	ctx, span := startCommandSpan(context.Background())
+
+	rootCmd.SetOut(output.Stdout())
+	rootCmd.SetErr(output.Stderr())
+	started := time.Now()
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	err = explainScopeGap(err)
+	if err != nil {
+		classified := errors.Classify(err)
+		rootCmd.PrintErrln(fmt.Sprintf("Error [%s]:", classified.Code), err.Error())
+		err = classified
+	}
+	output.FlushWarnings()
+	writeCommandStats(output.Stderr(), cmd, time.Since(started))
+	exitCode := errors.ExitCode(err)
+	finishCommandSpan(span, exitCode, err, shutdownTracing)
+	os.Exit(exitCode)
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/slack-cli/config.json)")
+	// Execute prints command errors itself, to explain missing scopes and add the
+	// stable error code.
+	rootCmd.SilenceErrors = true
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $XDG_CONFIG_HOME/slack-cli/config.json, %APPDATA%\\slack-cli\\config.json on Windows, or $HOME/.config/slack-cli/config.json)")
+	rootCmd.PersistentFlags().Bool("portable", false, "keep config, cache, and state in a slack-cli directory next to the slk binary (also SLK_PORTABLE)")
 	rootCmd.PersistentFlags().BoolP("human", "H", false, "human-readable output with tables and colors")
+	rootCmd.PersistentFlags().String("color", output.ColorAuto, "color human output and stderr markers: auto (terminals only), always, or never")
+	rootCmd.PersistentFlags().Bool("quiet", false, "suppress progress, status, and warning messages on stderr; errors are still printed")
+	rootCmd.PersistentFlags().Bool("no-color", false, "never use ANSI colors (same as --color=never; NO_COLOR is also honored)")
+	rootCmd.PersistentFlags().Bool("estimate-tokens", false, "print the output's approximate LLM token count to stderr")
+	rootCmd.PersistentFlags().Int("max-output-tokens", 0, "trim output to about this many LLM tokens (0 = no limit)")
+	rootCmd.PersistentFlags().Bool("stats", false, "after the command, print API calls, retries, rate limits, and wall time to stderr")
+	rootCmd.PersistentFlags().Bool("no-wait", false, "fail instead of queuing when a budgets entry in config is used up")
+	rootCmd.PersistentFlags().Bool("read-only", false, "refuse every command that would change the workspace (also read_only in config)")
+	rootCmd.PersistentFlags().Bool("suggest", false, "when a #channel name is not found, list the 3 closest channel names in the error")
 	viper.BindPFlag("output.human", rootCmd.PersistentFlags().Lookup("human"))
 }
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/notify"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/rotation"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/users"
+)
+
+const defaultRotationText = "{user} is on first response for {group}"
+
+var rotationCmd = &cobra.Command{
+	Use:   "rotation",
+	Short: "Round-robin rotations over usergroups",
+	Long:  "Take turns through the members of a usergroup, such as a first-responder rotation for triage.",
+}
+
+var rotationNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Pick the next member of a usergroup rotation",
+	Long: `Pick the member of --usergroup who is up next. Members are taken in user ID
+order, starting after the last recorded assignee and wrapping around, so the pick
+is predictable and the order stays stable as people join or leave the group.
+
+Deactivated members and members whose status says they are out of office are
+passed over, as are members named in --skip; the result lists who was skipped and
+why. Skipped members keep their place in the ID order.
+
+Nothing changes until you ask:
+  --record           save the pick as the latest assignment, which advances the rotation
+  --set-usergroup    replace the members of another usergroup (e.g. @support-primary) with the pick
+  --post             announce the pick in a channel; --text sets the message, where
+                     {user} is the pick's mention and {group} the rotation's handle
+
+The rotation state lives in the team cache directory (rotation.json) with the last
+20 assignments per usergroup. The pick is recorded only after --set-usergroup and
+--post succeed, so a failed run can be retried without skipping anyone.
+
+Output (JSON):
+  {
+    "ok": true,
+    "usergroup": "@support-rotation",
+    "usergroup_id": "S0123ROTA",
+    "user": "@carol",
+    "user_id": "U03CAROL",
+    "previous": "@alice",
+    "previous_id": "U01ALICE",
+    "previous_at": "2024-01-15T09:00:00Z",
+    "position": 3,
+    "members": 4,
+    "skipped": [{"user": "@bob", "user_id": "U02BOB", "reason": "out_of_office"}],
+    "recorded": true,
+    "assigned_group": "@support-primary",
+    "posted": {"channel": "#support", "channel_id": "C123ABC", "ts": "1705309200.000100"}
+  }
+
+Required Scopes:
+  - usergroups:read
+  - users:read
+  - usergroups:write for --set-usergroup
+  - chat:write for --post`,
+	Example: `  # Who is up next? (does not advance the rotation)
+  slk rotation next --usergroup @support-rotation
+
+  # Assign, hand over the @support-primary alias, and announce it
+  slk rotation next --usergroup @support-rotation --record \
+    --set-usergroup @support-primary --post "#support"
+
+  # Skip someone this round
+  slk rotation next --usergroup @support-rotation --skip @bob --record`,
+	Args: cobra.NoArgs,
+	RunE: runRotationNext,
+}
+
+func init() {
+	rootCmd.AddCommand(rotationCmd)
+	rotationCmd.AddCommand(rotationNextCmd)
+
+	rotationNextCmd.Flags().String("usergroup", "", "Usergroup handle (@support-rotation) or ID to rotate through (required)")
+	rotationNextCmd.Flags().Bool("record", false, "Save the pick as the latest assignment, advancing the rotation")
+	rotationNextCmd.Flags().String("skip", "", "Comma-separated members to pass over this time, as @name or ID")
+	rotationNextCmd.Flags().String("set-usergroup", "", "Usergroup whose members are replaced with the pick")
+	rotationNextCmd.Flags().StringP("post", "c", "", "Channel to announce the pick in")
+	rotationNextCmd.Flags().String("text", defaultRotationText, "Announcement text for --post; {user} and {group} are filled in")
+	rotationNextCmd.MarkFlagRequired("usergroup")
+	addQuietHoursFlag(rotationNextCmd)
+	addRedactFlag(rotationNextCmd)
+}
+
+func runRotationNext(cmd *cobra.Command, args []string) error {
+	groupInput, _ := cmd.Flags().GetString("usergroup")
+	record, _ := cmd.Flags().GetBool("record")
+	skipFlag, _ := cmd.Flags().GetString("skip")
+	assignInput, _ := cmd.Flags().GetString("set-usergroup")
+	postInput, _ := cmd.Flags().GetString("post")
+	text, _ := cmd.Flags().GetString("text")
+
+	if postInput != "" && strings.TrimSpace(text) == "" {
+		return cerrors.ConfigError("--text must not be empty")
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	group, found, err := cmdCtx.UserGroupResolver.FindByHandle(cmdCtx.Ctx, groupInput)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return cerrors.NotFoundError("usergroup", groupInput, "Hint: Run 'slk cache clear' if the usergroup was created recently")
+	}
+	var assignID, assignHandle string
+	if assignInput != "" {
+		g, found, err := cmdCtx.UserGroupResolver.FindByHandle(cmdCtx.Ctx, assignInput)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return cerrors.NotFoundError("usergroup", assignInput, "Hint: Run 'slk cache clear' if the usergroup was created recently")
+		}
+		assignID, assignHandle = g.ID, "@"+g.Handle
+	}
+	var skip []string
+	for _, input := range splitList(skipFlag) {
+		userID, err := cmdCtx.ResolveUser(input)
+		if err != nil {
+			return err
+		}
+		skip = append(skip, userID)
+	}
+	var channelID string
+	if postInput != "" {
+		if channelID, err = cmdCtx.ResolveChannel(postInput); err != nil {
+			return err
+		}
+	}
+
+	members, err := cmdCtx.Client.GetUserGroupMembers(cmdCtx.Ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return cerrors.ConfigError("usergroup @%s has no members", group.Handle)
+	}
+	state, err := rotation.Load(rotation.DefaultPath(cmdCtx.CacheStore.BasePath))
+	if err != nil {
+		return err
+	}
+
+	sorted := rotation.Order(members, "")
+	result := &rotation.NextResult{OK: true, Usergroup: "@" + group.Handle, UsergroupID: group.ID, Members: len(sorted)}
+	last, hasLast := state.Group(group.ID).Last()
+	if hasLast {
+		at := last.At
+		result.PreviousID, result.PreviousAt = last.UserID, &at
+		result.Previous = rotationUserName(cmdCtx, last.UserID)
+	}
+
+	now := time.Now()
+	for _, userID := range rotation.Order(members, last.UserID) {
+		if slices.Contains(skip, userID) {
+			result.Skipped = append(result.Skipped, rotation.Skip{User: rotationUserName(cmdCtx, userID), UserID: userID, Reason: "skipped"})
+			continue
+		}
+		info, err := cmdCtx.Client.GetUserInfo(cmdCtx.Ctx, userID)
+		if err != nil {
+			return err
+		}
+		name := "@" + info.Name
+		if info.Name == "" {
+			name = userID
+		}
+		// Working hours and DND decide when the pick sees the assignment, not whether
+		// they get it, so only deactivation and out-of-office statuses pass them over.
+		if a := users.Assess(users.Signals{User: info}, notify.WorkingHours{}, now); !a.Assignable {
+			result.Skipped = append(result.Skipped, rotation.Skip{User: name, UserID: userID, Reason: a.Verdict})
+			continue
+		}
+		result.User, result.UserID = name, userID
+		break
+	}
+	if result.UserID == "" {
+		return cerrors.NotFoundError("assignable member", result.Usergroup, "Hint: every member was skipped, deactivated, or out of office")
+	}
+	result.Position = slices.Index(sorted, result.UserID) + 1
+
+	if assignID != "" {
+		if err := cmdCtx.Client.UpdateUserGroupMembers(cmdCtx.Ctx, assignID, []string{result.UserID}); err != nil {
+			return err
+		}
+		result.AssignedGroup = assignHandle
+	}
+
+	var out output.Printable = result
+	if channelID != "" {
+		message := strings.NewReplacer("{user}", "<@"+result.UserID+">", "{group}", result.Usergroup).Replace(text)
+		message, _, redactions, err := redactMessage(cmd, cmdCtx.Config, message, "")
+		if err != nil {
+			return err
+		}
+		quiet, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+		if err != nil {
+			return err
+		}
+		posted, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, channelID, slack.PostMessageOptions{
+			Text:   message,
+			AsUser: cmdCtx.AuthRole == config.RoleUser,
+		})
+		if err != nil {
+			return err
+		}
+		result.Posted = &rotation.Posted{Channel: postInput, ChannelID: channelID, TS: posted.Timestamp}
+		if name := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID); name != "" && name != channelID {
+			result.Posted.Channel = "#" + strings.TrimPrefix(name, "#")
+		}
+		out = withRedactions(withPolicy(result, quiet), redactions)
+	}
+
+	if record {
+		state.Record(group.ID, group.Handle, result.UserID, now)
+		if err := state.Save(); err != nil {
+			return err
+		}
+		result.Recorded = true
+	}
+	return output.Print(cmd, out)
+}
+
+// rotationUserName returns "@name" for a user ID, or the ID when it cannot be resolved.
+func rotationUserName(cmdCtx *CommandContext, userID string) string {
+	if cmdCtx.UserResolver == nil {
+		return userID
+	}
+	if name := cmdCtx.UserResolver.GetMentionName(cmdCtx.Ctx, userID); name != "" && name != userID {
+		return "@" + name
+	}
+	return userID
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/spf13/cobra"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/scaffold"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold",
+	Short: "Generate Slack app setup for existing scripts",
+	Long:  "Generate app configuration that exposes existing scripts in Slack, and serve it.",
+}
+
+var scaffoldSlashCommandCmd = &cobra.Command{
+	Use:   "slash-command",
+	Short: "Wrap a script as a Slack slash command",
+	Long: `Print the app manifest snippet and setup steps for a slash command that runs
+--handler, and with --serve, run the handler for each invocation.
+
+The app uses Socket Mode, so no public URL is needed. Paste the manifest snippet
+into the app's manifest (app settings > App Manifest), reinstall the app, and set
+app_token (xapp- with connections:write) and a bot token in config.
+
+--serve holds a Socket Mode connection. Each /command is acknowledged immediately,
+then the handler runs with the payload JSON on stdin and these variables set:
+
+  SLK_EVENT_TYPE    slash_command, or the interaction type (block_actions, view_submission, ...)
+  SLK_COMMAND       the command, e.g. /triage
+  SLK_TEXT          text after the command
+  SLK_USER_ID       invoking user
+  SLK_CHANNEL_ID    channel it was run in
+  SLK_TRIGGER_ID    for views open within 3 seconds
+  SLK_RESPONSE_URL  where replies go
+
+Interactions with the app (button clicks, modal submissions) go to the same handler.
+Whatever the handler prints is sent to the response URL: a JSON object as is (for
+blocks or "response_type": "in_channel"), other text as a message only the invoking
+user sees. When the handler fails, the user is told so. One JSON line per
+invocation is written to stdout.
+
+Output (JSON):
+  {
+    "command": "/triage",
+    "handler": "./triage.sh",
+    "scopes": ["commands"],
+    "manifest": {"features": {"slash_commands": [...]}, "oauth_config": {...}, "settings": {...}},
+    "manifest_yaml": "features:\n  slash_commands: ...",
+    "next_steps": ["..."]
+  }
+
+Output with --serve (one line per invocation):
+  {"type": "slash_command", "command": "/triage", "user_id": "U123ABC", "channel_id": "C123ABC", "exit_code": 0, "duration_ms": 840, "responded": true}
+
+Required Scopes:
+  commands (bot); --serve also needs app_token (xapp-) with connections:write`,
+	Example: `  # Manifest snippet and setup steps
+  slk scaffold slash-command --name /triage --handler ./triage.sh --description "Triage an incident" --usage-hint "[incident id]"
+
+  # Paste-ready YAML only
+  slk scaffold slash-command --name /triage --handler ./triage.sh --human
+
+  # Run ./triage.sh for every /triage
+  slk scaffold slash-command --name /triage --handler ./triage.sh --serve`,
+	Args: cobra.NoArgs,
+	RunE: runScaffoldSlashCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(scaffoldCmd)
+	scaffoldCmd.AddCommand(scaffoldSlashCommandCmd)
+
+	scaffoldSlashCommandCmd.Flags().String("name", "", "Slash command, e.g. /triage (required)")
+	scaffoldSlashCommandCmd.Flags().String("handler", "", "Executable to run for each invocation (required)")
+	scaffoldSlashCommandCmd.Flags().String("description", "", "Description shown in Slack's command menu")
+	scaffoldSlashCommandCmd.Flags().String("usage-hint", "", "Argument hint shown in Slack's command menu, e.g. \"[incident id]\"")
+	scaffoldSlashCommandCmd.Flags().Bool("serve", false, "Run the handler for each invocation over Socket Mode until interrupted")
+	scaffoldSlashCommandCmd.Flags().Duration("handler-timeout", 5*time.Minute, "Kill a handler that runs longer than this")
+	scaffoldSlashCommandCmd.MarkFlagRequired("name")
+	scaffoldSlashCommandCmd.MarkFlagRequired("handler")
+}
+
+// ScaffoldSlashCommandResult is the output of scaffold slash-command.
+type ScaffoldSlashCommandResult struct {
+	Command      string            `json:"command"`
+	Handler      string            `json:"handler"`
+	Scopes       []string          `json:"scopes"`
+	Manifest     scaffold.Manifest `json:"manifest"`
+	ManifestYAML string            `json:"manifest_yaml"`
+	NextSteps    []string          `json:"next_steps"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ScaffoldSlashCommandResult) Lines() []string {
+	lines := []string{"# App manifest snippet for " + r.Command}
+	lines = append(lines, strings.Split(strings.TrimRight(r.ManifestYAML, "\n"), "\n")...)
+	lines = append(lines, "", "Next steps:")
+	for i, step := range r.NextSteps {
+		lines = append(lines, fmt.Sprintf("  %d. %s", i+1, step))
+	}
+	return lines
+}
+
+// scaffoldInvocationRecord is the line --serve writes for each invocation.
+type scaffoldInvocationRecord struct {
+	Type       string `json:"type"`
+	Command    string `json:"command,omitempty"`
+	UserID     string `json:"user_id,omitempty"`
+	ChannelID  string `json:"channel_id,omitempty"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+	Responded  bool   `json:"responded"`
+	Error      string `json:"error,omitempty"`
+}
+
+func runScaffoldSlashCommand(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	handler, _ := cmd.Flags().GetString("handler")
+	description, _ := cmd.Flags().GetString("description")
+	usageHint, _ := cmd.Flags().GetString("usage-hint")
+	serve, _ := cmd.Flags().GetBool("serve")
+	timeout, _ := cmd.Flags().GetDuration("handler-timeout")
+
+	command := scaffold.SlashCommand{Name: name, Description: description, UsageHint: usageHint}
+	if err := command.Validate(); err != nil {
+		return err
+	}
+	if _, err := exec.LookPath(handler); err != nil {
+		return fmt.Errorf("--handler %s is not an executable file: %w", handler, err)
+	}
+
+	if serve {
+		if timeout <= 0 {
+			return fmt.Errorf("--handler-timeout must be positive")
+		}
+		return serveSlashCommand(cmd, command.Name, handler, timeout)
+	}
+
+	manifest := scaffold.NewManifest(command)
+	manifestYAML, err := manifest.YAML()
+	if err != nil {
+		return err
+	}
+	return output.Print(cmd, ScaffoldSlashCommandResult{
+		Command:      command.Name,
+		Handler:      handler,
+		Scopes:       scaffold.RequiredBotScopes,
+		Manifest:     manifest,
+		ManifestYAML: manifestYAML,
+		NextSteps: []string{
+			"Merge the snippet into the app manifest (app settings > App Manifest) and reinstall the app",
+			"Create an app-level token with connections:write and save it: slk config set app_token xapp-...",
+			"Save the bot token and use it: slk config set bot_token xoxb-... && slk config set role bot",
+			fmt.Sprintf("Serve the command: slk scaffold slash-command --name %s --handler %s --serve", command.Name, handler),
+		},
+	})
+}
+
+// serveSlashCommand routes slash commands and interactions from Socket Mode to the
+// handler until interrupted, waiting for running handlers before returning.
+func serveSlashCommand(cmd *cobra.Command, name, handler string, timeout time.Duration) error {
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	if strings.TrimSpace(cmdCtx.Config.AppToken) == "" {
+		return cerrors.ConfigError("--serve needs an app token: set SLACK_APP_TOKEN or add app_token to config")
+	}
+
+	ctx, stop := signal.NotifyContext(cmdCtx.Ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	human, _ := cmd.Flags().GetBool("human")
+	out := cmd.OutOrStdout()
+	var (
+		mu      sync.Mutex
+		running sync.WaitGroup
+	)
+	defer running.Wait()
+	record := func(rec scaffoldInvocationRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+		if human {
+			fmt.Fprintf(out, "%s %s by %s: exit %d (%dms)\n", rec.Type, rec.Command, rec.UserID, rec.ExitCode, rec.DurationMS)
+			return
+		}
+		line, _ := json.Marshal(rec)
+		fmt.Fprintln(out, string(line))
+	}
+	dispatch := func(inv scaffold.Invocation) {
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			record(runSlashHandler(ctx, handler, inv, timeout))
+		}()
+	}
+
+	socketClient := slack.NewSocketModeClient(cmdCtx.AuthToken, cmdCtx.AuthCookie, cmdCtx.Config.AppToken)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- socketClient.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		case evt, ok := <-socketClient.Events:
+			if !ok {
+				return nil
+			}
+			switch evt.Type {
+			case socketmode.EventTypeConnecting:
+				output.Statusf("Connecting to Slack Socket Mode...\n")
+			case socketmode.EventTypeConnected:
+				output.Statusf("Connected to Slack Socket Mode. Serving %s with %s.\n", name, handler)
+			case socketmode.EventTypeConnectionError:
+				output.Statusf("Slack Socket Mode connection error. Waiting for reconnect...\n")
+			case socketmode.EventTypeSlashCommand:
+				if evt.Request != nil {
+					socketClient.Ack(*evt.Request)
+				}
+				command, ok := evt.Data.(slackapi.SlashCommand)
+				if !ok {
+					continue
+				}
+				if command.Command != name {
+					output.Statusf("Ignoring %s: only %s is served.\n", command.Command, name)
+					continue
+				}
+				payload, _ := json.Marshal(command)
+				dispatch(scaffold.Invocation{
+					Type:        "slash_command",
+					Command:     command.Command,
+					Text:        command.Text,
+					UserID:      command.UserID,
+					ChannelID:   command.ChannelID,
+					TriggerID:   command.TriggerID,
+					ResponseURL: command.ResponseURL,
+					Payload:     payload,
+				})
+			case socketmode.EventTypeInteractive:
+				if evt.Request != nil {
+					socketClient.Ack(*evt.Request)
+				}
+				callback, ok := evt.Data.(slackapi.InteractionCallback)
+				if !ok || evt.Request == nil {
+					continue
+				}
+				dispatch(scaffold.Invocation{
+					Type:        string(callback.Type),
+					Command:     name,
+					UserID:      callback.User.ID,
+					ChannelID:   callback.Channel.ID,
+					TriggerID:   callback.TriggerID,
+					ResponseURL: callback.ResponseURL,
+					Payload:     evt.Request.Payload,
+				})
+			}
+		}
+	}
+}
+
+// runSlashHandler runs the handler for one invocation and posts its output, or a
+// failure notice, to the response URL.
+func runSlashHandler(ctx context.Context, handler string, inv scaffold.Invocation, timeout time.Duration) scaffoldInvocationRecord {
+	start := time.Now()
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stdout, err := scaffold.Run(runCtx, handler, inv, output.Stderr())
+	rec := scaffoldInvocationRecord{
+		Type:       inv.Type,
+		Command:    inv.Command,
+		UserID:     inv.UserID,
+		ChannelID:  inv.ChannelID,
+		ExitCode:   scaffold.ExitCode(err),
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	reply := stdout
+	if err != nil {
+		rec.Error = err.Error()
+		reply = fmt.Sprintf("%s failed (exit %d)", inv.Command, rec.ExitCode)
+	}
+	if reply == "" || inv.ResponseURL == "" {
+		return rec
+	}
+	if err := scaffold.Respond(ctx, http.DefaultClient, inv.ResponseURL, scaffold.ResponseBody(reply)); err != nil {
+		fmt.Fprintf(output.Stderr(), "%s: %v\n", inv.Command, err)
+		return rec
+	}
+	rec.Responded = true
+	return rec
+}
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/redact"
+	"github.com/kehao95/slack-agent-cli/internal/schedule"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// scheduleUpcoming is how many occurrences schedule add previews.
+const scheduleUpcoming = 3
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Recurring messages on a local cron schedule",
+	Long: `Schedules are recurring messages kept in a local SQLite database
+(schedule/schedules.db next to the config file). Their cron expressions are evaluated
+by 'slk daemon run' every --schedule-interval; nothing is sent unless a daemon is
+running.
+
+Each schedule delivers in one of two ways:
+  post   the daemon posts each message when it is due (default)
+  slack  the daemon hands each upcoming message to chat.scheduleMessage as soon as
+         the previous one is out, so Slack posts it even if the daemon is down then
+
+An occurrence is posted at most once, even with several daemons. Occurrences more
+than 15 minutes overdue when the daemon sees them, because it was not running, are
+skipped rather than posted late.`,
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a recurring message",
+	Long: `Add a recurring message. Works offline; the channel is resolved at delivery.
+
+--cron takes the standard five fields (minute hour day-of-month month day-of-week)
+with *, lists, ranges, steps, and JAN-DEC/SUN-SAT names, or @hourly, @daily,
+@weekly, @monthly, @yearly. It is evaluated in --tz (default: the daemon's local
+time zone).
+
+--template is a Go text/template file read at each delivery, so edits apply to the
+next message. It can use {{.Date}} (2006-01-02), {{.Weekday}}, {{.Channel}}, {{.Run}}
+(1 for the first message), and {{.Time}}, e.g. {{.Time.Format "Jan 2"}}. It is
+checked when the schedule is added.
+
+Output (JSON):
+  {
+    "ok": true,
+    "schedule": {
+      "id": 3,
+      "cron": "0 9 * * MON",
+      "timezone": "Europe/Berlin",
+      "channel": "#team",
+      "template": "/home/me/standup.tmpl",
+      "delivery": "post",
+      "created_at": "2024-01-12T16:20:00+01:00",
+      "next_run": "2024-01-15T09:00:00+01:00",
+      "runs": 0
+    },
+    "upcoming": ["2024-01-15T09:00:00+01:00", "2024-01-22T09:00:00+01:00", "2024-01-29T09:00:00+01:00"]
+  }`,
+	Example: `  slk schedule add --cron "0 9 * * MON" --channel "#team" --template standup.tmpl
+
+  # Weekdays at 17:30 Berlin time, posted by Slack even if the daemon is down
+  slk schedule add --cron "30 17 * * MON-FRI" --tz Europe/Berlin --channel "#ops" \
+    --text "Reminder: hand over the pager" --delivery slack`,
+	Args: cobra.NoArgs,
+	RunE: runScheduleAdd,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring messages",
+	Long: `List schedules with their next occurrence, run count, and last error. Works offline.
+
+Output (JSON):
+  {
+    "path": "/home/me/.config/slack-cli/schedule/schedules.db",
+    "schedules": [
+      {"id": 3, "cron": "0 9 * * MON", "channel": "#team", "template": "/home/me/standup.tmpl",
+       "delivery": "post", "created_at": "2024-01-12T16:20:00+01:00", "next_run": "2024-01-22T09:00:00+01:00",
+       "runs": 1, "last_run": "2024-01-15T08:00:00Z", "last_ts": "1705305600.000100"}
+    ]
+  }`,
+	Args: cobra.NoArgs,
+	RunE: runScheduleList,
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a recurring message",
+	Long: `Remove a schedule. When its next message has already been handed to
+chat.scheduleMessage, it is deleted from Slack too.
+
+Output (JSON):
+  {
+    "ok": true,
+    "id": 3,
+    "channel": "#team",
+    "canceled_scheduled_message_id": "Q1298393284"
+  }
+
+Required Scopes:
+  chat:write, only to cancel a message already scheduled in Slack`,
+	Example: `  slk schedule remove 3`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runScheduleRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+
+	scheduleAddCmd.Flags().String("cron", "", `Five-field cron expression, e.g. "0 9 * * MON" (required)`)
+	scheduleAddCmd.Flags().StringP("channel", "c", "", "Target channel or @user (required)")
+	scheduleAddCmd.Flags().String("template", "", "Message template file (Go text/template)")
+	scheduleAddCmd.Flags().StringP("text", "t", "", "Fixed message text")
+	scheduleAddCmd.Flags().String("tz", "", "IANA time zone for --cron, e.g. Europe/Berlin (default: local)")
+	scheduleAddCmd.Flags().String("delivery", schedule.DeliveryPost, "How messages are sent: post (by the daemon) or slack (chat.scheduleMessage)")
+	scheduleAddCmd.MarkFlagRequired("cron")
+	scheduleAddCmd.MarkFlagRequired("channel")
+	scheduleAddCmd.MarkFlagsOneRequired("template", "text")
+	scheduleAddCmd.MarkFlagsMutuallyExclusive("template", "text")
+	addRedactFlag(scheduleAddCmd)
+}
+
+func runScheduleAdd(cmd *cobra.Command, args []string) error {
+	cronExpr, _ := cmd.Flags().GetString("cron")
+	channel, _ := cmd.Flags().GetString("channel")
+	templatePath, _ := cmd.Flags().GetString("template")
+	text, _ := cmd.Flags().GetString("text")
+	tz, _ := cmd.Flags().GetString("tz")
+	delivery, _ := cmd.Flags().GetString("delivery")
+
+	switch delivery {
+	case schedule.DeliveryPost, schedule.DeliverySlack:
+	default:
+		return cerrors.ConfigError("--delivery must be post or slack, got %q", delivery)
+	}
+	sched := schedule.Schedule{Cron: cronExpr, Timezone: tz, Channel: channel, Delivery: delivery}
+	if _, err := schedule.ParseCron(cronExpr); err != nil {
+		return cerrors.ConfigError("--cron: %v", err)
+	}
+	if _, err := sched.Location(); err != nil {
+		return cerrors.ConfigError("--tz: %v", err)
+	}
+
+	cfg, _, err := config.Load(cfgFile)
+	if err != nil {
+		return cerrors.ConfigError("failed to load config: %w", err)
+	}
+	var redactions []redact.Finding
+	if templatePath != "" {
+		if sched.Template, err = filepath.Abs(templatePath); err != nil {
+			return cerrors.ConfigError("--template: %v", err)
+		}
+	} else {
+		if strings.TrimSpace(text) == "" {
+			return cerrors.ConfigError("--text must not be empty")
+		}
+		if sched.Text, _, redactions, err = redactMessage(cmd, cfg, text, ""); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	first, err := sched.NextAfter(now)
+	if err != nil {
+		return cerrors.ConfigError("--cron: %v", err)
+	}
+	// Catch template mistakes now rather than at 9am on Monday.
+	if _, err := schedule.Render(sched, first); err != nil {
+		return cerrors.ConfigError("--template %s: %v", templatePath, err)
+	}
+
+	store, err := openSchedules()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	if sched, err = store.Add(cmd.Context(), sched, now); err != nil {
+		return err
+	}
+	result := schedule.AddResult{OK: true, Schedule: sched, Upcoming: []time.Time{sched.NextRun}}
+	for len(result.Upcoming) < scheduleUpcoming {
+		next, err := sched.NextAfter(result.Upcoming[len(result.Upcoming)-1])
+		if err != nil {
+			break
+		}
+		result.Upcoming = append(result.Upcoming, next)
+	}
+	return output.Print(cmd, withRedactions(result, redactions))
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	store, err := openSchedules()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	schedules, err := store.List(cmd.Context())
+	if err != nil {
+		return err
+	}
+	return output.Print(cmd, schedule.ListResult{Path: store.Path(), Schedules: schedules})
+}
+
+func runScheduleRemove(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || id <= 0 {
+		return cerrors.ConfigError("invalid schedule id %q", args[0])
+	}
+	store, err := openSchedules()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	sched, ok, err := store.Get(cmd.Context(), id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return cerrors.NotFoundError("schedule", args[0], "")
+	}
+
+	result := schedule.RemoveResult{OK: true, ID: id, Channel: sched.Channel}
+	if sched.HandedOff() && sched.NextRun.After(time.Now()) {
+		if sched.ScheduledMessageID == "" {
+			output.Warnf("the message for %s was scheduled in Slack but its ID is unknown; delete it in Slack if it should not be posted",
+				sched.NextRun.Format(time.RFC3339))
+		} else {
+			cmdCtx, err := NewCommandContext(cmd, 0)
+			if err != nil {
+				return err
+			}
+			defer cmdCtx.Close()
+			if err := cmdCtx.Client.DeleteScheduledMessage(cmdCtx.Ctx, sched.ScheduledChannelID, sched.ScheduledMessageID); err != nil {
+				return err
+			}
+			result.CanceledScheduledMessageID = sched.ScheduledMessageID
+		}
+	}
+	if _, err := store.Remove(cmd.Context(), id); err != nil {
+		return err
+	}
+	return output.Print(cmd, result)
+}
+
+func openSchedules() (*schedule.Store, error) {
+	_, configPath, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, cerrors.ConfigError("failed to load config: %w", err)
+	}
+	path, err := schedule.DefaultPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	store, err := schedule.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open schedules: %w", err)
+	}
+	return store, nil
+}
+
+// scheduleSender delivers schedule messages for daemon run, with secrets masked.
+type scheduleSender struct {
+	cmd    *cobra.Command
+	cmdCtx *CommandContext
+}
+
+func (s scheduleSender) ResolveChannel(channel string) (string, error) {
+	return s.cmdCtx.ResolveChannel(channel)
+}
+
+func (s scheduleSender) Post(ctx context.Context, channelID, text string) (string, error) {
+	opts, err := s.options(text)
+	if err != nil {
+		return "", err
+	}
+	posted, err := s.cmdCtx.Client.PostMessage(ctx, channelID, opts)
+	if err != nil {
+		return "", err
+	}
+	return posted.Timestamp, nil
+}
+
+func (s scheduleSender) Schedule(ctx context.Context, channelID, text string, at time.Time) (string, error) {
+	opts, err := s.options(text)
+	if err != nil {
+		return "", err
+	}
+	return s.cmdCtx.Client.ScheduleMessage(ctx, channelID, at, opts)
+}
+
+func (s scheduleSender) options(text string) (slack.PostMessageOptions, error) {
+	text, _, _, err := redactMessage(s.cmd, s.cmdCtx.Config, text, "")
+	if err != nil {
+		return slack.PostMessageOptions{}, err
+	}
+	return slack.PostMessageOptions{
+		Text:        text,
+		UnfurlLinks: true,
+		UnfurlMedia: true,
+		AsUser:      s.cmdCtx.AuthRole == config.RoleUser,
+	}, nil
+}
+
+// daemonSchedules runs due schedules from daemon run. Like the outbox, the database is
+// opened on the first tick after it has been created.
+type daemonSchedules struct {
+	path   string
+	runner *schedule.Runner
+}
+
+func newDaemonSchedules(configPath string) (*daemonSchedules, error) {
+	path, err := schedule.DefaultPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonSchedules{path: path}, nil
+}
+
+// Tick delivers due occurrences and logs the outcome to stderr.
+func (d *daemonSchedules) Tick(cmd *cobra.Command, cmdCtx *CommandContext) {
+	if d.runner == nil {
+		if _, err := os.Stat(d.path); err != nil {
+			return
+		}
+		store, err := schedule.Open(d.path)
+		if err != nil {
+			fmt.Fprintf(output.Stderr(), "failed to open schedules: %v\n", err)
+			return
+		}
+		d.runner = &schedule.Runner{Store: store, Sender: scheduleSender{cmd: cmd, cmdCtx: cmdCtx}}
+	}
+	runs, err := d.runner.Tick(cmdCtx.Ctx, time.Now())
+	if err != nil {
+		fmt.Fprintf(output.Stderr(), "failed to run schedules: %v\n", err)
+	}
+	for _, run := range runs {
+		output.Statusf("schedule %s\n", run)
+	}
+}
+
+// Close closes the schedule database if it was opened.
+func (d *daemonSchedules) Close() error {
+	if d.runner == nil {
+		return nil
+	}
+	return d.runner.Store.Close()
+}
@@ -16,16 +16,23 @@ func TestAllCommandsHaveHelp(t *testing.T) {
 		command *cobra.Command
 	}{
 		{"root", rootCmd},
+		{"alerts", alertsCmd},
 		{"auth", authCmd},
+		{"blocks", blocksCmd},
 		{"cache", cacheCmd},
 		{"channels", channelsCmd},
 		{"daemon", daemonCmd},
 		{"events", eventsCmd},
 		{"messages", messagesCmd},
+		{"notify", notifyCmd},
+		{"outbox", outboxCmd},
 		{"reactions", reactionsCmd},
+		{"report", reportCmd},
 		{"pins", pinsCmd},
 		{"users", usersCmd},
 		{"emoji", emojiCmd},
+		{"dm", dmCmd},
+		{"files", filesCmd},
 	}
 
 	for _, tt := range tests {
@@ -114,6 +121,11 @@ func TestRequiredFlagsEnforced(t *testing.T) {
 		{"channels join", channelsJoinCmd, "channel"},
 		{"channels leave", channelsLeaveCmd, "channel"},
 		{"users info", usersInfoCmd, "user"},
+		{"dm open", dmOpenCmd, "user"},
+		{"files info", filesInfoCmd, "file"},
+		{"files fetch", filesFetchCmd, "file"},
+		{"files share", filesShareCmd, "file"},
+		{"files revoke-public", filesRevokePublicCmd, "file"},
 		{"users presence", usersPresenceCmd, "user"},
 	}
 
@@ -156,16 +168,28 @@ func TestInvalidFlagsRejected(t *testing.T) {
 // TestCommandsRegistered verifies that all expected commands are registered with the root command
 func TestCommandsRegistered(t *testing.T) {
 	expectedCommands := []string{
+		"alerts",
+		"archive",
 		"auth",
+		"blocks",
 		"cache",
 		"channels",
+		"config",
 		"daemon",
 		"events",
 		"messages",
+		"notify",
+		"outbox",
+		"huddles",
 		"reactions",
+		"report",
 		"pins",
 		"users",
 		"emoji",
+		"upgrade",
+		"version",
+		"dm",
+		"files",
 	}
 
 	registeredCommands := make(map[string]bool)
@@ -186,16 +210,26 @@ func TestSubcommandsRegistered(t *testing.T) {
 		parent   *cobra.Command
 		children []string
 	}{
-		{authCmd, []string{"test", "whoami"}},
+		{alertsCmd, []string{"run"}},
+		{archiveCmd, []string{"read"}},
+		{authCmd, []string{"test", "whoami", "login", "logout", "oauth", "tokens", "device", "plan"}},
+		{authTokensCmd, []string{"list", "use"}},
+		{blocksCmd, []string{"validate", "preview"}},
 		{cacheCmd, []string{"populate", "status", "clear"}},
-		{channelsCmd, []string{"list", "join", "leave"}},
+		{channelsCmd, []string{"list", "stats", "stale", "audit-names", "join", "leave"}},
+		{configCmd, []string{"get", "set", "unset"}},
 		{daemonCmd, []string{"run", "status"}},
 		{eventsCmd, []string{"stream", "list", "next", "claim", "ack"}},
-		{messagesCmd, []string{"list", "search", "send", "edit", "delete", "next"}},
+		{messagesCmd, []string{"list", "search", "send", "edit", "delete", "next", "get", "export", "render"}},
+		{huddlesCmd, []string{"list"}},
+		{outboxCmd, []string{"add", "send", "flush", "list"}},
 		{reactionsCmd, []string{"add", "remove", "list"}},
+		{reportCmd, []string{"top-channels"}},
 		{pinsCmd, []string{"add", "remove", "list"}},
 		{usersCmd, []string{"list", "info", "presence"}},
 		{emojiCmd, []string{"list"}},
+		{dmCmd, []string{"open", "close", "list"}},
+		{filesCmd, []string{"info", "fetch", "share", "revoke-public"}},
 	}
 
 	for _, tt := range tests {
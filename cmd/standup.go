@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/standup"
+	"github.com/spf13/cobra"
+)
+
+// standupSweepTimeout bounds the final conversations.replies sweep after collection ends.
+const standupSweepTimeout = 30 * time.Second
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Run asynchronous standups in a thread",
+}
+
+var standupCollectCmd = &cobra.Command{
+	Use:   "collect",
+	Short: "Post a standup prompt and summarize the thread replies",
+	Long: `Post a prompt to a channel, gather the replies in its thread until --wait elapses,
+and print one summary with each participant's answers.
+
+Replies arrive the same way as threads watch (see its --source): over Socket Mode
+when app_token is configured, otherwise by polling conversations.replies every
+--interval. When collection ends the whole thread is read once more, so replies the
+stream missed and edits made in the meantime are in the summary. Replies from the
+active auth identity and from bots are left out.
+
+With --users, collection ends as soon as every named participant has replied, and
+the summary lists who did not. Interrupting the command (Ctrl-C) also ends collection
+early and still prints the summary. Progress goes to stderr (see --quiet).
+
+Output (JSON):
+  {
+    "channel": "#team",
+    "channel_id": "C123ABC",
+    "prompt": "What did you do yesterday?",
+    "ts": "1705309200.000100",
+    "started_at": "2024-01-15T09:00:00Z",
+    "deadline": "2024-01-15T11:00:00Z",
+    "ended_at": "2024-01-15T09:41:07Z",
+    "complete": false,
+    "responded": 1,
+    "expected": 2,
+    "responses": [
+      {
+        "user_id": "U123ABC",
+        "user": "@alice",
+        "first_reply_at": "2024-01-15T09:12:00Z",
+        "text": "Shipped the importer",
+        "replies": [{"ts": "1705309920.000200", "text": "Shipped the importer"}]
+      }
+    ],
+    "missing": ["@bob"]
+  }
+
+Required Scopes:
+  chat:write
+  channels:history (groups:history for private channels)
+  users:read to resolve @names`,
+	Example: `  slk standup collect --channel "#team" --prompt "What did you do yesterday?" --wait 2h
+
+  # Stop early once the whole team has answered
+  slk standup collect -c "#team" --prompt "Standup: yesterday, today, blockers?" \
+    --users @alice,@bob,@carol --wait 4h
+
+  # Feed the answers to a summarizer
+  slk standup collect -c "#team" --prompt "Standup time!" --wait 1h | jq -r '.responses[] | "\(.user): \(.text)"'`,
+	Args: cobra.NoArgs,
+	RunE: runStandupCollect,
+}
+
+func init() {
+	rootCmd.AddCommand(standupCmd)
+	standupCmd.AddCommand(standupCollectCmd)
+
+	standupCollectCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	standupCollectCmd.Flags().String("prompt", "", "Prompt to post (- reads stdin) (required)")
+	standupCollectCmd.Flags().Duration("wait", 2*time.Hour, "How long to collect replies")
+	standupCollectCmd.Flags().String("users", "", "Comma-separated participants as @name or ID; stop once all have replied")
+	standupCollectCmd.Flags().String("source", "auto", "Where replies come from: auto, socket, or poll")
+	standupCollectCmd.Flags().Duration("interval", 30*time.Second, "Polling interval for --source poll")
+	standupCollectCmd.MarkFlagRequired("channel")
+	standupCollectCmd.MarkFlagRequired("prompt")
+	addQuietHoursFlag(standupCollectCmd)
+	addRedactFlag(standupCollectCmd)
+}
+
+func runStandupCollect(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+	prompt, _ := cmd.Flags().GetString("prompt")
+	wait, _ := cmd.Flags().GetDuration("wait")
+	usersFlag, _ := cmd.Flags().GetString("users")
+	source, _ := cmd.Flags().GetString("source")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	switch source {
+	case "auto", "socket", "poll":
+	default:
+		return cerrors.ConfigError("--source must be auto, socket, or poll, got %q", source)
+	}
+	if wait <= 0 {
+		return cerrors.ConfigError("--wait must be positive")
+	}
+	if interval <= 0 {
+		return cerrors.ConfigError("--interval must be positive")
+	}
+	var err error
+	if prompt == "-" {
+		if prompt, err = readRequiredStdin("prompt"); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return cerrors.ConfigError("--prompt must not be empty")
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	// Self is needed to leave the prompter's own thread replies out.
+	if err := cmdCtx.EnsureAuthIdentity(cmdCtx.Ctx); err != nil {
+		return err
+	}
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+	var expected []string
+	for _, input := range splitList(usersFlag) {
+		userID, err := cmdCtx.ResolveUser(input)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(expected, userID) {
+			expected = append(expected, userID)
+		}
+	}
+	appToken := strings.TrimSpace(cmdCtx.Config.AppToken)
+	if source == "auto" {
+		source = "poll"
+		if appToken != "" {
+			source = "socket"
+		}
+	}
+	if source == "socket" && appToken == "" {
+		return cerrors.ConfigError("--source socket needs an app token: set SLACK_APP_TOKEN or add app_token to config")
+	}
+
+	expanders, err := newLinkExpanders(cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+	prompt, _, redactions, err := redactMessage(cmd, cmdCtx.Config, prompt, "")
+	if err != nil {
+		return err
+	}
+	quiet, err := checkQuietHours(cmd, cmdCtx, channelID, false)
+	if err != nil {
+		return err
+	}
+
+	posted, err := cmdCtx.Client.PostMessage(cmdCtx.Ctx, channelID, slack.PostMessageOptions{
+		Text:   prompt,
+		AsUser: cmdCtx.AuthRole == config.RoleUser,
+	})
+	if err != nil {
+		return err
+	}
+	threadTS := posted.Timestamp
+	started := time.Now()
+	result := standup.Result{
+		Channel:   channelInput,
+		ChannelID: channelID,
+		Prompt:    prompt,
+		TS:        threadTS,
+		StartedAt: started,
+		Deadline:  started.Add(wait),
+		Expected:  len(expected),
+	}
+	if name := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID); name != "" && name != channelID {
+		result.Channel = "#" + strings.TrimPrefix(name, "#")
+	}
+	output.Statusf("Posted standup prompt to %s (thread %s); collecting replies until %s",
+		result.Channel, threadTS, result.Deadline.Local().Format("15:04"))
+
+	ctx, cancel := context.WithDeadline(cmdCtx.Ctx, result.Deadline)
+	defer cancel()
+	collector := standup.NewCollector()
+	normalizer := newEventNormalizer(cmdCtx)
+	collect := func(event streamEvent) error {
+		if !event.IsThreadReply || event.IsSelf || event.BotID != "" {
+			return nil
+		}
+		if collector.Add(event.UserID, event.TS, event.displayText()) {
+			output.Statusf("Reply from %s", firstNonEmpty(event.User, event.UserID))
+			if len(expected) > 0 && len(collector.Missing(expected)) == 0 {
+				cancel()
+			}
+		}
+		return nil
+	}
+
+	if source == "poll" {
+		err = watchThreadByPolling(ctx, cmdCtx, normalizer, channelID, threadTS, interval, collect)
+	} else {
+		err = watchThreadBySocket(ctx, cmdCtx, normalizer, channelID, threadTS, collect)
+	}
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	// The sweep runs even after an interrupt so the summary is never lost.
+	sweepCtx, cancelSweep := context.WithTimeout(context.WithoutCancel(cmdCtx.Ctx), standupSweepTimeout)
+	defer cancelSweep()
+	if _, err := pollThreadReplies(sweepCtx, cmdCtx, normalizer, channelID, threadTS, threadTS, collect); err != nil {
+		output.Warnf("final sweep of thread replies: %v", err)
+	}
+	result.EndedAt = time.Now()
+
+	result.Responses = collector.Responses()
+	result.Responded = len(result.Responses)
+	for i := range result.Responses {
+		result.Responses[i].User = "@" + cmdCtx.UserResolver.GetMentionName(sweepCtx, result.Responses[i].UserID)
+	}
+	missing := collector.Missing(expected)
+	for _, userID := range missing {
+		result.Missing = append(result.Missing, "@"+cmdCtx.UserResolver.GetMentionName(sweepCtx, userID))
+	}
+	result.Complete = len(expected) > 0 && len(missing) == 0
+	return output.Print(cmd, withLinks(withRedactions(withPolicy(result, quiet), redactions), expanders))
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// CommandStats is the --stats report written to stderr after a command finishes.
+type CommandStats struct {
+	slack.CallStats
+	RateLimitWaitMS int64 `json:"rate_limit_wait_ms"`
+	BudgetWaitMS    int64 `json:"budget_wait_ms,omitempty"`
+	WallTimeMS      int64 `json:"wall_time_ms"`
+}
+
+func newCommandStats(wall time.Duration) CommandStats {
+	calls := slack.Stats()
+	return CommandStats{
+		CallStats:       calls,
+		RateLimitWaitMS: calls.RateLimitWait.Milliseconds(),
+		BudgetWaitMS:    calls.BudgetWait.Milliseconds(),
+		WallTimeMS:      wall.Milliseconds(),
+	}
+}
+
+// Lines implements output.Printable.
+func (s CommandStats) Lines() []string {
+	line := fmt.Sprintf("Stats: %d API calls (%d retries, %d rate limited", s.Calls, s.Retries, s.RateLimited)
+	if s.RateLimitWait > 0 {
+		line += fmt.Sprintf(", %s waiting", s.RateLimitWait.Round(time.Millisecond))
+	}
+	if s.BudgetWait > 0 {
+		line += fmt.Sprintf(", %s queued for budgets", s.BudgetWait.Round(time.Millisecond))
+	}
+	line += fmt.Sprintf(") in %s", (time.Duration(s.WallTimeMS) * time.Millisecond).String())
+	lines := []string{line}
+	methods := make([]string, 0, len(s.Methods))
+	for method := range s.Methods {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		if s.Methods[methods[i]] != s.Methods[methods[j]] {
+			return s.Methods[methods[i]] > s.Methods[methods[j]]
+		}
+		return methods[i] < methods[j]
+	})
+	for _, method := range methods {
+		lines = append(lines, fmt.Sprintf("  %s: %d", method, s.Methods[method]))
+	}
+	return lines
+}
+
+// writeCommandStats reports API usage for cmd to w when --stats was given. The report is
+// {"stats": {...}} on one line, or a short summary with --human.
+func writeCommandStats(w io.Writer, cmd *cobra.Command, wall time.Duration) {
+	if cmd == nil {
+		return
+	}
+	if enabled, _ := cmd.Flags().GetBool("stats"); !enabled {
+		return
+	}
+	stats := newCommandStats(wall)
+	if human, _ := cmd.Flags().GetBool("human"); human {
+		fmt.Fprintln(w, strings.Join(stats.Lines(), "\n"))
+		return
+	}
+	encoded, err := json.Marshal(map[string]CommandStats{"stats": stats})
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(w, string(encoded))
+}
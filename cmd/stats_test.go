@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestWriteCommandStats(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Bool("stats", false, "")
+	cmd.Flags().Bool("human", false, "")
+
+	var buf bytes.Buffer
+	writeCommandStats(&buf, cmd, time.Second)
+	if buf.Len() != 0 {
+		t.Fatalf("stats written without --stats: %q", buf.String())
+	}
+
+	_ = cmd.Flags().Set("stats", "true")
+	writeCommandStats(&buf, cmd, 1500*time.Millisecond)
+	var report struct {
+		Stats map[string]any `json:"stats"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("stats is not JSON: %v (%q)", err, buf.String())
+	}
+	for _, key := range []string{"api_calls", "retries", "rate_limited", "rate_limit_wait_ms", "wall_time_ms"} {
+		if _, ok := report.Stats[key]; !ok {
+			t.Fatalf("stats missing %s: %s", key, buf.String())
+		}
+	}
+	if report.Stats["wall_time_ms"] != float64(1500) {
+		t.Fatalf("wall_time_ms = %v, want 1500", report.Stats["wall_time_ms"])
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/tracing"
+	"github.com/spf13/cobra"
+)
+
+// startCommandSpan opens the root span for a CLI invocation. The span is renamed once
+// cobra has resolved the subcommand (see annotateCommandSpan).
+func startCommandSpan(ctx context.Context) (context.Context, *tracing.Span) {
+	return tracing.Start(ctx, "slk", tracing.KindInternal)
+}
+
+// annotateCommandSpan records the resolved command path and channel flag on the command span.
+func annotateCommandSpan(cmd *cobra.Command) {
+	span := tracing.SpanFromContext(cmd.Context())
+	if span == nil {
+		return
+	}
+	span.SetName(cmd.CommandPath())
+	span.SetAttribute("slk.command", cmd.CommandPath())
+	if flag := cmd.Flags().Lookup("channel"); flag != nil && flag.Value.String() != "" {
+		span.SetAttribute("slack.channel", flag.Value.String())
+	}
+}
+
+// finishCommandSpan ends the command span with the final exit status and flushes spans.
+func finishCommandSpan(span *tracing.Span, exitCode int, err error, shutdown func(context.Context) error) {
+	span.SetAttribute("slk.exit_code", exitCode)
+	if err != nil {
+		span.SetAttribute("slk.error_code", cerrors.ErrorCode(err))
+		span.RecordError(err)
+	} else {
+		span.SetStatus(tracing.StatusOK, "")
+	}
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = shutdown(ctx)
+}
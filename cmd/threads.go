@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var threadsCmd = &cobra.Command{
+	Use:   "threads",
+	Short: "Thread operations",
+	Long:  "Find active threads in a channel and follow or unfollow them.",
+}
+
+var threadsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List active threads in a channel",
+	Long: `List threads in a channel that were started or replied to within a time range,
+most recently active first.
+
+A thread counts as active when its parent was posted in the range, or its latest
+reply was. Replies can arrive long after the parent, so recent history is scanned
+back up to --max-messages for thread parents. Read a thread with:
+slk messages list --channel <channel> --thread <ts>
+
+Output (JSON):
+  {
+    "channel": "#support",
+    "channel_id": "C123ABC",
+    "threads": [
+      {
+        "channel": "C123ABC",
+        "ts": "1705312365.000100",
+        "user": "@alice",
+        "text": "Checkout is failing for EU customers",
+        "reply_count": 12,
+        "latest_reply": "1705398765.000200",
+        "user_id": "U123ABC"
+      }
+    ],
+    "has_more": false
+  }`,
+	Example: `  # Threads active in the last day
+  slk threads list --channel "#support"
+
+  # Threads active in the last week, human-readable
+  slk threads list --channel "#support" --since 7d --human`,
+	RunE: runThreadsList,
+}
+
+var threadsFollowCmd = &cobra.Command{
+	Use:   "follow",
+	Short: "Follow a thread",
+	Long: `Subscribe to a thread so its replies show up in your Threads view and notifications.
+
+Slack has no public API method for following threads. This uses the method Slack's
+own clients call (subscriptions.thread.add), which only accepts browser-session
+(xoxc) tokens; user and bot tokens are rejected by Slack.
+
+Output (JSON):
+  {"ok": true, "action": "follow", "channel": "#support", "channel_id": "C123ABC", "ts": "1705312365.000100"}`,
+	Example: `  # Follow a thread
+  slk threads follow --channel "#support" --ts "1705312365.000100"`,
+	RunE: func(cmd *cobra.Command, args []string) error { return runThreadsFollow(cmd, "follow") },
+}
+
+var threadsUnfollowCmd = &cobra.Command{
+	Use:   "unfollow",
+	Short: "Unfollow a thread",
+	Long: `Stop following a thread. Like threads follow, this needs a browser-session (xoxc)
+token.
+
+Output (JSON):
+  {"ok": true, "action": "unfollow", "channel": "#support", "channel_id": "C123ABC", "ts": "1705312365.000100"}`,
+	Example: `  # Unfollow a thread
+  slk threads unfollow --channel "#support" --ts "1705312365.000100"`,
+	RunE: func(cmd *cobra.Command, args []string) error { return runThreadsFollow(cmd, "unfollow") },
+}
+
+func init() {
+	rootCmd.AddCommand(threadsCmd)
+	threadsCmd.AddCommand(threadsListCmd)
+	threadsCmd.AddCommand(threadsFollowCmd)
+	threadsCmd.AddCommand(threadsUnfollowCmd)
+
+	threadsListCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	threadsListCmd.Flags().String("since", "24h", "Threads active after this time (ISO or relative like 24h)")
+	threadsListCmd.Flags().String("until", "", "Threads active before this time")
+	threadsListCmd.Flags().IntP("limit", "l", 50, "Maximum threads to return")
+	threadsListCmd.Flags().Int("max-messages", 1000, "Maximum history messages to scan for thread parents")
+	threadsListCmd.Flags().Bool("raw-json", false, "Preserve raw Slack user IDs in JSON output")
+	threadsListCmd.MarkFlagRequired("channel")
+
+	for _, c := range []*cobra.Command{threadsFollowCmd, threadsUnfollowCmd} {
+		c.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+		c.Flags().String("ts", "", "Thread parent timestamp (required)")
+		c.MarkFlagRequired("channel")
+		c.MarkFlagRequired("ts")
+	}
+}
+
+func runThreadsList(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	channelInput, _ := cmd.Flags().GetString("channel")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	limit, _ := cmd.Flags().GetInt("limit")
+	maxMessages, _ := cmd.Flags().GetInt("max-messages")
+	rawJSON, _ := cmd.Flags().GetBool("raw-json")
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+	oldest, latest, err := slack.ParseTimeRange(since, until)
+	if err != nil {
+		return err
+	}
+
+	threads, hasMore, err := cmdCtx.Client.ListActiveThreads(cmdCtx.Ctx, slack.ThreadListParams{
+		Channel:     channelID,
+		Oldest:      oldest,
+		Latest:      latest,
+		Limit:       limit,
+		MaxMessages: maxMessages,
+	})
+	if err != nil {
+		return err
+	}
+
+	channelName := cmdCtx.ChannelResolver.ResolveName(cmdCtx.Ctx, channelID)
+	if channelName == "" || channelName == channelID {
+		channelName = channelInput
+	}
+	if !strings.HasPrefix(channelName, "#") && !strings.HasPrefix(channelName, "@") && !isChannelID(channelName) {
+		channelName = "#" + channelName
+	}
+	result := &slack.ThreadListResult{
+		Channel:   channelName,
+		ChannelID: channelID,
+		Threads:   threads,
+		HasMore:   hasMore,
+	}
+	result.SetUserResolver(cmdCtx.Ctx, cmdCtx.UserResolver)
+	result.SetRawJSON(rawJSON)
+
+	return output.Print(cmd, result)
+}
+
+func runThreadsFollow(cmd *cobra.Command, action string) error {
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	channelInput, _ := cmd.Flags().GetString("channel")
+	timestamp, _ := cmd.Flags().GetString("ts")
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+
+	if action == "follow" {
+		err = cmdCtx.Client.FollowThread(cmdCtx.Ctx, channelID, timestamp)
+	} else {
+		err = cmdCtx.Client.UnfollowThread(cmdCtx.Ctx, channelID, timestamp)
+	}
+	if err != nil {
+		return fmt.Errorf("%s thread: %w", action, err)
+	}
+
+	return output.Print(cmd, &slack.ThreadFollowResult{
+		OK:        true,
+		Action:    action,
+		Channel:   channelInput,
+		ChannelID: channelID,
+		Timestamp: timestamp,
+	})
+}
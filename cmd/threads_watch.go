@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var threadsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream new replies to one thread",
+	Long: `Emit one JSON event per line on stdout for each new reply to a thread, so an
+agent can monitor a conversation it started without consuming the whole channel.
+
+This command is blocking by design. It stops when --timeout elapses (exit code 0)
+or when interrupted. Replies posted before it starts are not emitted; read them
+with: slk messages list --channel <channel> --thread <ts>
+
+Sources:
+  socket  Socket Mode, like events stream; needs app_token (xapp-)
+  poll    conversations.replies every --interval; needs only the API token
+  auto    socket when app_token is configured, otherwise poll (default)
+
+Events have the same shape as events stream message events. Polled events carry
+"kind": "slack.poll" and cannot report edits or deletions.
+
+--follow also follows the thread in Slack (see threads follow), so the signed-in
+user is notified of replies too.`,
+	Example: `  # Watch replies to a thread for ten minutes
+  slk threads watch --channel "#support" --ts "1705312365.000100" --timeout 10m
+
+  # Poll every 30 seconds and skip your own replies
+  slk threads watch --channel "#support" --ts "1705312365.000100" --source poll --interval 30s --exclude-self`,
+	RunE: runThreadsWatch,
+}
+
+func init() {
+	threadsCmd.AddCommand(threadsWatchCmd)
+
+	threadsWatchCmd.Flags().StringP("channel", "c", "", "Channel name or ID (required)")
+	threadsWatchCmd.Flags().String("ts", "", "Thread parent timestamp (required)")
+	threadsWatchCmd.Flags().Duration("timeout", 0, "Stop after this long (default: run until interrupted)")
+	threadsWatchCmd.Flags().String("source", "auto", "Where replies come from: auto, socket, or poll")
+	threadsWatchCmd.Flags().Duration("interval", 15*time.Second, "Polling interval for --source poll")
+	threadsWatchCmd.Flags().Bool("exclude-self", false, "Exclude replies posted by the active auth identity")
+	threadsWatchCmd.Flags().Bool("follow", false, "Also follow the thread in Slack (browser-session tokens only)")
+	threadsWatchCmd.Flags().StringP("file", "f", "", "Also append each reply event to this file")
+	threadsWatchCmd.MarkFlagRequired("channel")
+	threadsWatchCmd.MarkFlagRequired("ts")
+}
+
+func runThreadsWatch(cmd *cobra.Command, args []string) error {
+	channelInput, _ := cmd.Flags().GetString("channel")
+	threadTS, _ := cmd.Flags().GetString("ts")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	source, _ := cmd.Flags().GetString("source")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	excludeSelf, _ := cmd.Flags().GetBool("exclude-self")
+	follow, _ := cmd.Flags().GetBool("follow")
+	human, _ := cmd.Flags().GetBool("human")
+	threadTS = strings.TrimSpace(threadTS)
+
+	switch source {
+	case "auto", "socket", "poll":
+	default:
+		return fmt.Errorf("--source must be auto, socket, or poll, got %q", source)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	if excludeSelf {
+		if err := cmdCtx.EnsureAuthIdentity(cmdCtx.Ctx); err != nil {
+			return err
+		}
+	}
+
+	channelID, err := cmdCtx.ResolveChannel(channelInput)
+	if err != nil {
+		return err
+	}
+	appToken := strings.TrimSpace(cmdCtx.Config.AppToken)
+	if source == "auto" {
+		source = "poll"
+		if appToken != "" {
+			source = "socket"
+		}
+	}
+	if source == "socket" && appToken == "" {
+		return fmt.Errorf("--source socket needs an app token: set SLACK_APP_TOKEN or add app_token to config")
+	}
+	if follow {
+		if err := cmdCtx.Client.FollowThread(cmdCtx.Ctx, channelID, threadTS); err != nil {
+			return fmt.Errorf("follow thread: %w", err)
+		}
+	}
+
+	ctx := cmdCtx.Ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	sink, err := newEventsStreamSink(cmd)
+	if err != nil {
+		return err
+	}
+	normalizer := newEventNormalizer(cmdCtx)
+	emit := func(event streamEvent) error {
+		if !event.IsThreadReply || (excludeSelf && event.IsSelf) {
+			return nil
+		}
+		line, err := formatStreamEventLine(event, human)
+		if err != nil {
+			return err
+		}
+		if err := sink.WriteLine(line); err != nil {
+			return fmt.Errorf("write event: %w", err)
+		}
+		return nil
+	}
+
+	if source == "poll" {
+		err = watchThreadByPolling(ctx, cmdCtx, normalizer, channelID, threadTS, interval, emit)
+	} else {
+		err = watchThreadBySocket(ctx, cmdCtx, normalizer, channelID, threadTS, emit)
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+func watchThreadBySocket(ctx context.Context, cmdCtx *CommandContext, normalizer *eventNormalizer, channelID, threadTS string, emit func(streamEvent) error) error {
+	filter := streamFilter{
+		ChannelID:  channelID,
+		EventTypes: map[string]struct{}{"message": {}},
+		ThreadTS:   threadTS,
+	}
+	return streamSocketEvents(ctx, cmdCtx, normalizer, filter.Match, emit)
+}
+
+// watchThreadByPolling emits replies newer than the latest one present at start.
+func watchThreadByPolling(ctx context.Context, cmdCtx *CommandContext, normalizer *eventNormalizer, channelID, threadTS string, interval time.Duration, emit func(streamEvent) error) error {
+	latest, err := pollThreadReplies(ctx, cmdCtx, normalizer, channelID, threadTS, threadTS, nil)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			next, err := pollThreadReplies(ctx, cmdCtx, normalizer, channelID, threadTS, latest, emit)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				// A failed poll is retried on the next tick rather than ending the watch.
+				output.Warnf("poll thread replies: %v", err)
+				continue
+			}
+			latest = next
+		}
+	}
+}
+
+// pollThreadReplies passes replies after the since timestamp to emit, oldest first, and
+// returns the newest timestamp seen. A nil emit only finds the newest timestamp.
+func pollThreadReplies(ctx context.Context, cmdCtx *CommandContext, normalizer *eventNormalizer, channelID, threadTS, since string, emit func(streamEvent) error) (string, error) {
+	latest := since
+	cursor := ""
+	for {
+		replies, hasMore, next, err := cmdCtx.Client.ListThreadReplies(ctx, slack.ThreadParams{
+			Channel: channelID,
+			Thread:  threadTS,
+			Oldest:  since,
+			Cursor:  cursor,
+			Limit:   200,
+		})
+		if err != nil {
+			return since, err
+		}
+		for _, msg := range replies {
+			if msg.Timestamp == threadTS || slack.CompareTS(msg.Timestamp, latest) <= 0 {
+				continue
+			}
+			if emit != nil {
+				if err := emit(normalizer.normalizeHistoryMessage(channelID, msg)); err != nil {
+					return latest, err
+				}
+			}
+			latest = msg.Timestamp
+		}
+		if !hasMore || next == "" {
+			return latest, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/upgrade"
+	"github.com/spf13/cobra"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Replace slk with the latest release from GitHub",
+	Long: `Download the latest slk release from GitHub and replace the running binary in place.
+
+Before anything is written, the release's checksums.txt is checked against its
+detached Ed25519 signature (checksums.txt.sig) using the release signing key built
+into slk, and the archive for this platform against the SHA-256 checksums.txt lists
+for it. A missing or bad signature or a checksum mismatch aborts the upgrade. Builds
+without a signing key, such as go install builds, cannot upgrade themselves. The new
+binary is written next to the old one and renamed over it, so an interrupted
+upgrade leaves the old binary working. The directory holding slk must be writable.
+
+--channel stable (the default) only considers full releases; --channel prerelease
+also considers release candidates. Development builds and binaries installed by
+Homebrew are not replaced unless --force is given (use brew upgrade slk instead).
+Set GITHUB_TOKEN to avoid GitHub's anonymous API rate limit.
+
+This command does not call Slack.
+
+Output (JSON):
+  {
+    "ok": true,
+    "channel": "stable",
+    "current": "1.3.0",
+    "latest": "1.4.0",
+    "update_available": true,
+    "updated": true,
+    "path": "/usr/local/bin/slk",
+    "asset": "slk_1.4.0_Linux_x86_64.tar.gz",
+    "sha256": "9f2c...",
+    "release_url": "https://github.com/kehao95/slack-agent-cli/releases/tag/v1.4.0"
+  }
+
+With --check, nothing is downloaded and updated is false.`,
+	Example: `  # Is there a newer release?
+  slk upgrade --check
+
+  # Upgrade to the latest stable release
+  slk upgrade
+
+  # Track release candidates
+  slk upgrade --channel prerelease`,
+	Args: cobra.NoArgs,
+	RunE: runUpgrade,
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+
+	upgradeCmd.Flags().String("channel", upgrade.ChannelStable, "Release channel: stable or prerelease")
+	upgradeCmd.Flags().Bool("check", false, "Only report whether a newer release exists")
+	upgradeCmd.Flags().Bool("force", false, "Reinstall even if up to date, from a development build, or over a Homebrew install")
+}
+
+// UpgradeResult reports the outcome of an upgrade.
+type UpgradeResult struct {
+	OK              bool   `json:"ok"`
+	Channel         string `json:"channel"`
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	UpdateAvailable bool   `json:"update_available"`
+	Updated         bool   `json:"updated"`
+	Path            string `json:"path,omitempty"`
+	Asset           string `json:"asset,omitempty"`
+	SHA256          string `json:"sha256,omitempty"`
+	ReleaseURL      string `json:"release_url,omitempty"`
+}
+
+// Lines implements output.Printable.
+func (r *UpgradeResult) Lines() []string {
+	switch {
+	case r.Updated:
+		return []string{
+			fmt.Sprintf("Upgraded slk %s -> %s (%s)", r.Current, r.Latest, r.Path),
+			fmt.Sprintf("Verified %s (sha256 %s)", r.Asset, r.SHA256),
+		}
+	case r.UpdateAvailable:
+		return []string{fmt.Sprintf("slk %s is available (current %s, %s channel): %s", r.Latest, r.Current, r.Channel, r.ReleaseURL)}
+	default:
+		return []string{fmt.Sprintf("slk %s is up to date (%s channel)", r.Current, r.Channel)}
+	}
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	channel, _ := cmd.Flags().GetString("channel")
+	check, _ := cmd.Flags().GetBool("check")
+	force, _ := cmd.Flags().GetBool("force")
+	if channel != upgrade.ChannelStable && channel != upgrade.ChannelPrerelease {
+		return cerrors.ConfigError("invalid --channel %q (use %s or %s)", channel, upgrade.ChannelStable, upgrade.ChannelPrerelease)
+	}
+
+	ctx := cmd.Context()
+	client := upgrade.NewClient()
+	release, err := client.Latest(ctx, channel)
+	if err != nil {
+		return cerrors.WrapWithCode(cerrors.ExitNetwork, err, "check for releases")
+	}
+
+	isRelease := upgrade.IsRelease(buildVersion)
+	result := &UpgradeResult{
+		OK:              true,
+		Channel:         channel,
+		Current:         strings.TrimPrefix(buildVersion, "v"),
+		Latest:          release.Version(),
+		UpdateAvailable: !isRelease || upgrade.CompareVersions(release.Version(), buildVersion) > 0,
+		ReleaseURL:      release.URL,
+	}
+	if check || (!result.UpdateAvailable && !force) {
+		return output.Print(cmd, result)
+	}
+	if !isRelease && !force {
+		return cerrors.NewErrorWithCode(cerrors.ExitGeneral, "slk %s is a development build; pass --force to replace it with %s", buildVersion, release.Tag)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate slk binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if strings.Contains(filepath.ToSlash(exe), "/Cellar/") && !force {
+		return cerrors.NewErrorWithCode(cerrors.ExitGeneral, "%s is managed by Homebrew; run brew upgrade slk (or pass --force)", exe)
+	}
+
+	name := upgrade.CurrentArchiveName(release.Version())
+	archiveAsset, ok := release.Asset(name)
+	if !ok {
+		return cerrors.NotFoundError("release asset", name, fmt.Sprintf("Hint: %s has no build for %s/%s", release.Tag, runtime.GOOS, runtime.GOARCH))
+	}
+	checksumsAsset, ok := release.Asset(upgrade.ChecksumsAsset)
+	if !ok {
+		return cerrors.NotFoundError("release asset", upgrade.ChecksumsAsset, fmt.Sprintf("Hint: %s publishes no checksums; refusing to install an unverified binary", release.Tag))
+	}
+	signatureAsset, ok := release.Asset(upgrade.SignatureAsset)
+	if !ok {
+		return cerrors.NotFoundError("release asset", upgrade.SignatureAsset, fmt.Sprintf("Hint: %s publishes no signature; refusing to install an unverified binary", release.Tag))
+	}
+	checksums, err := client.Download(ctx, checksumsAsset)
+	if err != nil {
+		return cerrors.WrapWithCode(cerrors.ExitNetwork, err, "download %s", upgrade.ChecksumsAsset)
+	}
+	signature, err := client.Download(ctx, signatureAsset)
+	if err != nil {
+		return cerrors.WrapWithCode(cerrors.ExitNetwork, err, "download %s", upgrade.SignatureAsset)
+	}
+	if err := upgrade.VerifySignature(checksums, signature, upgrade.SigningKey); err != nil {
+		if errors.Is(err, upgrade.ErrNoSigningKey) {
+			return cerrors.NewErrorWithCode(cerrors.ExitGeneral, "slk %s was %v, so it cannot verify %s; install it from %s instead", buildVersion, err, release.Tag, release.URL)
+		}
+		return err
+	}
+	archive, err := client.Download(ctx, archiveAsset)
+	if err != nil {
+		return cerrors.WrapWithCode(cerrors.ExitNetwork, err, "download %s", name)
+	}
+	sum, err := upgrade.Verify(checksums, name, archive)
+	if err != nil {
+		return err
+	}
+	binary, err := upgrade.Extract(name, archive, upgrade.BinaryName(runtime.GOOS))
+	if err != nil {
+		return err
+	}
+	if err := upgrade.Replace(exe, binary); err != nil {
+		return fmt.Errorf("replace %s: %w", exe, err)
+	}
+
+	result.Updated = true
+	result.Path = exe
+	result.Asset = name
+	result.SHA256 = sum
+	return output.Print(cmd, result)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/usergroups"
+	"github.com/spf13/cobra"
+)
+
+var usergroupsCmd = &cobra.Command{
+	Use:   "usergroups",
+	Short: "Usergroup operations",
+	Long:  "Inspect Slack usergroups such as @oncall.",
+}
+
+var usergroupsMembersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "List the members of a usergroup",
+	Long: `Expand a usergroup handle into its current members (usergroups.users.list).
+
+Without --resolve only user IDs are returned. --resolve looks up each member's
+profile to add handles, display names, emails, and time zones. Emails are omitted
+unless --include-pii is passed or expose_emails is set in config, and need the
+users:read.email scope.
+
+Output (JSON):
+  {
+    "ok": true,
+    "id": "S0123ONCALL",
+    "handle": "oncall",
+    "name": "On-call",
+    "members": [
+      {
+        "id": "U123ABC",
+        "name": "alice",
+        "real_name": "Alice Smith",
+        "display_name": "alice",
+        "email": "alice@example.com",
+        "tz": "Europe/Berlin"
+      }
+    ]
+  }`,
+	Example: `  # Who is on call right now?
+  slk usergroups members --group @oncall --resolve
+
+  # DM everyone on call
+  slk usergroups members --group @oncall | jq -r '.members[].id' |
+    xargs -I{} slk notify --user {} --text "Incident declared in #inc-42"`,
+	RunE: runUsergroupsMembers,
+}
+
+func init() {
+	rootCmd.AddCommand(usergroupsCmd)
+	usergroupsCmd.AddCommand(usergroupsMembersCmd)
+
+	usergroupsMembersCmd.Flags().String("group", "", "Usergroup handle (@oncall) or ID (required)")
+	usergroupsMembersCmd.Flags().Bool("resolve", false, "Look up each member's name, email, and time zone")
+	addIncludePIIFlag(usergroupsMembersCmd)
+	_ = usergroupsMembersCmd.MarkFlagRequired("group")
+}
+
+func runUsergroupsMembers(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	handle, _ := cmd.Flags().GetString("group")
+	resolve, _ := cmd.Flags().GetBool("resolve")
+
+	group, found, err := cmdCtx.UserGroupResolver.FindByHandle(cmdCtx.Ctx, handle)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return cerrors.NotFoundError("usergroup", handle, "Hint: Run 'slk cache clear' if the usergroup was created recently")
+	}
+
+	result, err := usergroups.Members(cmdCtx.Ctx, cmdCtx.Client, group, resolve)
+	if err != nil {
+		return err
+	}
+	if !includePII(cmd, cmdCtx.Config) {
+		result.StripEmails()
+	}
+	return output.Print(cmd, result)
+}
@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"slices"
 	"time"
 
-	"github.com/kehao95/slack-agent-cli/internal/errors"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
 	"github.com/kehao95/slack-agent-cli/internal/output"
-	"github.com/kehao95/slack-agent-cli/internal/slack"
 	"github.com/kehao95/slack-agent-cli/internal/users"
 	"github.com/spf13/cobra"
 )
@@ -33,17 +31,30 @@ Output (JSON):
         "name": "alice",
         "real_name": "Alice Smith",
         "display_name": "alice",
+        "email": "alice@example.com",
+        "tz": "Europe/Berlin",
         "is_bot": false,
         "is_deleted": false,
-        "profile": {
-          "email": "alice@example.com",
-          "status_text": "In a meeting",
-          "status_emoji": ":calendar:"
-        }
+        "is_admin": true
       }
     ]
   }
 
+Filters:
+  --active-only        Skip deactivated accounts
+  --deleted            Only deactivated accounts
+  --admin-only         Only workspace admins and owners
+  --tz GLOB            Match the user's time zone, e.g. "Europe/*" (* also spans "/")
+  --name-contains STR  Case-insensitive match on handle, real name, or display name
+
+When any filter is set the whole workspace is paged through before filtering, so
+--cursor only sets the starting page and no next_cursor is returned.
+
+--fields id,name,email keeps only the named fields on each user in JSON output.
+
+Emails are omitted unless --include-pii is passed or expose_emails is set in config
+(slk config set expose_emails true); they also need the users:read.email scope.
+
 Note: Set --include-bots to include bot users in results.`,
 	Example: `  # List all users
   slk users list
@@ -52,7 +63,13 @@ Note: Set --include-bots to include bot users in results.`,
   slk users list --limit 50 --cursor "dXNlcl9pZDo..."
 
   # Include bot users
-  slk users list --include-bots`,
+  slk users list --include-bots
+
+  # Active admins in Europe, just IDs and handles
+  slk users list --active-only --admin-only --tz "Europe/*" --fields id,name
+
+  # Include email addresses
+  slk users list --fields id,email --include-pii`,
 	RunE: runUsersList,
 }
 
@@ -69,21 +86,16 @@ Output (JSON):
       "name": "alice",
       "real_name": "Alice Smith",
       "display_name": "alice",
-      "is_bot": false,
-      "is_deleted": false,
-      "profile": {
-        "email": "alice@example.com",
-        "phone": "+1234567890",
-        "title": "Engineer",
-        "status_text": "In a meeting",
-        "status_emoji": ":calendar:",
-        "avatar_hash": "abc123"
-      },
+      "email": "alice@example.com",
+      "title": "Engineer",
       "tz": "America/New_York",
-      "tz_label": "Eastern Standard Time"
+      "is_bot": false,
+      "is_deleted": false
     }
   }
 
+The email is omitted unless --include-pii is passed or expose_emails is set in config.
+
 User Identifier:
   - User ID: U123ABC (direct lookup)
   - Username: @alice (resolved via user list)`,
@@ -115,13 +127,22 @@ func init() {
 	usersListCmd.Flags().Int("limit", 100, "Maximum users per page")
 	usersListCmd.Flags().String("cursor", "", "Continuation cursor for pagination")
 	usersListCmd.Flags().Bool("include-bots", false, "Include bot users in results")
+	usersListCmd.Flags().Bool("active-only", false, "Only include active (non-deactivated) users")
+	usersListCmd.Flags().Bool("deleted", false, "Only include deactivated users")
+	usersListCmd.Flags().Bool("admin-only", false, "Only include workspace admins and owners")
+	usersListCmd.Flags().String("tz", "", "Only include users whose time zone matches this glob (e.g. \"Europe/*\")")
+	usersListCmd.Flags().String("name-contains", "", "Only include users whose handle or name contains this text")
+	usersListCmd.Flags().StringSlice("fields", nil, "Comma-separated user fields to keep in JSON output (e.g. id,name,email)")
+	addIncludePIIFlag(usersListCmd)
+	usersListCmd.MarkFlagsMutuallyExclusive("active-only", "deleted")
 
 	// users info flags
-	usersInfoCmd.Flags().String("user", "", "User ID or @username (required)")
+	usersInfoCmd.Flags().String("user", "", "User ID, @username, or @display name (required)")
+	addIncludePIIFlag(usersInfoCmd)
 	_ = usersInfoCmd.MarkFlagRequired("user")
 
 	// users presence flags
-	usersPresenceCmd.Flags().String("user", "", "User ID or @username (required)")
+	usersPresenceCmd.Flags().String("user", "", "User ID, @username, or @display name (required)")
 	_ = usersPresenceCmd.MarkFlagRequired("user")
 }
 
@@ -137,15 +158,32 @@ func runUsersList(cmd *cobra.Command, args []string) error {
 	limit, _ := cmd.Flags().GetInt("limit")
 	cursor, _ := cmd.Flags().GetString("cursor")
 	includeBots, _ := cmd.Flags().GetBool("include-bots")
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	exposeEmails := includePII(cmd, cmdCtx.Config)
+	if !exposeEmails && slices.Contains(fields, "email") {
+		return cerrors.ConfigError("--fields email needs --include-pii or expose_emails in config")
+	}
+
+	var filter users.Filter
+	filter.ActiveOnly, _ = cmd.Flags().GetBool("active-only")
+	filter.DeletedOnly, _ = cmd.Flags().GetBool("deleted")
+	filter.AdminOnly, _ = cmd.Flags().GetBool("admin-only")
+	filter.TZ, _ = cmd.Flags().GetString("tz")
+	filter.NameContains, _ = cmd.Flags().GetString("name-contains")
 
 	result, err := service.List(cmdCtx.Ctx, users.ListParams{
 		Limit:       limit,
 		Cursor:      cursor,
 		IncludeBots: includeBots,
+		Filter:      filter,
+		Fields:      fields,
 	})
 	if err != nil {
 		return err
 	}
+	if !exposeEmails {
+		result.StripEmails()
+	}
 
 	return output.Print(cmd, result)
 }
@@ -165,7 +203,7 @@ func runUsersInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve user ID from @username or user ID
-	userID, err := resolveUserID(cmd.Context(), cmdCtx.Client, userInput)
+	userID, err := cmdCtx.ResolveUser(userInput)
 	if err != nil {
 		return fmt.Errorf("resolve user: %w", err)
 	}
@@ -174,6 +212,9 @@ func runUsersInfo(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if !includePII(cmd, cmdCtx.Config) {
+		result.StripEmails()
+	}
 
 	return output.Print(cmd, result)
 }
@@ -193,7 +234,7 @@ func runUsersPresence(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve user ID from @username or user ID
-	userID, err := resolveUserID(cmd.Context(), cmdCtx.Client, userInput)
+	userID, err := cmdCtx.ResolveUser(userInput)
 	if err != nil {
 		return fmt.Errorf("resolve user: %w", err)
 	}
@@ -205,25 +246,3 @@ func runUsersPresence(cmd *cobra.Command, args []string) error {
 
 	return output.Print(cmd, result)
 }
-
-// resolveUserID converts @username to user ID, or returns the input if it's already an ID.
-func resolveUserID(ctx context.Context, client *slack.APIClient, input string) (string, error) {
-	// If it starts with @, try to resolve as username
-	if strings.HasPrefix(input, "@") {
-		username := strings.TrimPrefix(input, "@")
-		// We need to list users and find by name
-		allUsers, _, err := client.ListUsers(ctx, "", 1000)
-		if err != nil {
-			return "", fmt.Errorf("list users to resolve name: %w", err)
-		}
-		for _, u := range allUsers {
-			if u.Name == username || u.Profile.DisplayName == username {
-				return u.ID, nil
-			}
-		}
-		return "", errors.UserNotFoundError("@" + username)
-	}
-
-	// Assume it's already a user ID
-	return input, nil
-}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/notify"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/users"
+)
+
+var usersAvailabilityCmd = &cobra.Command{
+	Use:   "availability",
+	Short: "Decide whether people can take work now",
+	Long: `Combine what Slack knows about each user into one availability verdict, for
+agents deciding whom to assign or ping:
+
+  deactivated    The account is deactivated.
+  out_of_office  Their status says they are out: a :palm_tree:, :airplane:, or
+                 :face_with_thermometer: emoji, or text such as "OOO", "vacation",
+                 "PTO", or "on leave". Expired statuses are ignored.
+  dnd            Notifications are snoozed or they are in their DND window.
+  off_hours      It is outside --working-hours in their own time zone.
+  away           Their presence is away.
+  available      None of the above.
+
+available is true only for the available verdict. assignable is false only for
+deactivated and out_of_office: the others will see new work within hours.
+next_available is when DND and working hours next allow reaching them.
+
+Set your team's hours once with:
+  slk config set users.availability.working-hours 08:00-17:00
+
+Output (JSON):
+  {
+    "ok": true,
+    "users": [
+      {
+        "user": "@alice",
+        "user_id": "U123ABC",
+        "verdict": "out_of_office",
+        "available": false,
+        "assignable": false,
+        "reasons": ["status says out of office: :palm_tree: Vacation"],
+        "presence": "away",
+        "status_emoji": ":palm_tree:",
+        "status_text": "Vacation",
+        "status_expires": "2024-01-22T00:00:00-08:00",
+        "timezone": "America/Los_Angeles",
+        "local_time": "2024-01-16T10:12:00-08:00"
+      }
+    ]
+  }
+
+Required Scopes:
+  - users:read
+  - dnd:read`,
+	Example: `  # Who can review this today?
+  slk users availability --users @alice,@bob,@carol
+
+  # Pick the first assignable reviewer
+  slk users availability --users @alice,@bob | jq -r '[.users[] | select(.assignable)][0].user'
+
+  # A team that works 07:00-16:00 including weekends
+  slk users availability --users @oncall-a,@oncall-b --working-hours 07:00-16:00 --weekends`,
+	Args: cobra.NoArgs,
+	RunE: runUsersAvailability,
+}
+
+func init() {
+	usersCmd.AddCommand(usersAvailabilityCmd)
+
+	usersAvailabilityCmd.Flags().String("users", "", "Comma-separated user IDs, @usernames, or @display names (required)")
+	usersAvailabilityCmd.Flags().String("working-hours", "09:00-18:00", "Working hours in each user's time zone, or \"any\"")
+	usersAvailabilityCmd.Flags().Bool("weekends", false, "Treat Saturday and Sunday as working days")
+	usersAvailabilityCmd.MarkFlagRequired("users")
+}
+
+func runUsersAvailability(cmd *cobra.Command, args []string) error {
+	usersFlag, _ := cmd.Flags().GetString("users")
+	workingHours, _ := cmd.Flags().GetString("working-hours")
+	weekends, _ := cmd.Flags().GetBool("weekends")
+
+	inputs := splitList(usersFlag)
+	if len(inputs) == 0 {
+		return cerrors.ConfigError("--users is empty")
+	}
+	hours, err := notify.ParseWorkingHours(workingHours, weekends)
+	if err != nil {
+		return cerrors.ConfigError("--working-hours: %v", err)
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	result := &users.AvailabilityResult{OK: true, Users: []users.UserAvailability{}}
+	now := time.Now()
+	for _, input := range inputs {
+		userID, err := cmdCtx.ResolveUser(input)
+		if err != nil {
+			return fmt.Errorf("resolve user %s: %w", input, err)
+		}
+		info, err := cmdCtx.Client.GetUserInfo(cmdCtx.Ctx, userID)
+		if err != nil {
+			return err
+		}
+		presence, err := cmdCtx.Client.GetUserPresence(cmdCtx.Ctx, userID)
+		if err != nil {
+			return err
+		}
+		dnd, err := cmdCtx.Client.GetDNDInfo(cmdCtx.Ctx, userID)
+		if err != nil {
+			return err
+		}
+		availability := users.Assess(users.Signals{User: info, Presence: presence, DND: availabilityFromDND(dnd)}, hours, now)
+		availability.User = input
+		if info.Name != "" {
+			availability.User = "@" + info.Name
+		}
+		result.Users = append(result.Users, availability)
+	}
+	return output.Print(cmd, result)
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/upgrade"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the slk version and check for upcoming breaking changes",
+	Long: `Show the version, commit, and build date of this binary.
+
+With --check, also compare it against the latest GitHub release and the project's
+deprecation manifest (deprecations.json on the main branch), which lists commands,
+flags, and config keys slated for removal. Each finding is a structured warning so
+automation can act on it before an upgrade breaks a script:
+
+  update_available      a newer release exists on --channel
+  unsupported_version   this version is older than the manifest's minimum_version
+  deprecated            a command, flag, or config key is deprecated; removed_in
+                        names the release that drops it
+
+Deprecations already removed in this version are not reported; commands and flags
+this binary itself marks as deprecated are. Warnings never change the exit code;
+check the warnings array.
+
+Output (JSON):
+  {
+    "ok": true,
+    "version": "1.3.0",
+    "commit": "4f1c2d9",
+    "date": "2024-01-15T10:00:00Z",
+    "go": "go1.25.6",
+    "platform": "linux/amd64",
+    "check": {
+      "channel": "stable",
+      "latest": "1.4.0",
+      "update_available": true,
+      "warnings": [
+        {"code": "update_available", "message": "slk 1.4.0 is available (current 1.3.0)"},
+        {
+          "code": "deprecated",
+          "kind": "flag",
+          "target": "messages list --unread",
+          "deprecated_in": "1.3.0",
+          "removed_in": "2.0.0",
+          "replacement": "messages next",
+          "message": "flag messages list --unread is deprecated since 1.3.0 and will be removed in 2.0.0; use messages next instead"
+        }
+      ]
+    }
+  }`,
+	Example: `  # Print the version
+  slk version
+
+  # Fail a CI job when anything it uses is slated for removal
+  slk version --check | jq -e '[.check.warnings[] | select(.code == "deprecated")] | length == 0'`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().Bool("check", false, "Compare against the latest release and the deprecation manifest")
+	versionCmd.Flags().String("channel", upgrade.ChannelStable, "Release channel for --check: stable or prerelease")
+}
+
+// VersionResult describes the running binary.
+type VersionResult struct {
+	OK       bool          `json:"ok"`
+	Version  string        `json:"version"`
+	Commit   string        `json:"commit"`
+	Date     string        `json:"date"`
+	Go       string        `json:"go"`
+	Platform string        `json:"platform"`
+	Check    *VersionCheck `json:"check,omitempty"`
+}
+
+// VersionCheck is the result of version --check.
+type VersionCheck struct {
+	Channel         string            `json:"channel"`
+	Latest          string            `json:"latest"`
+	UpdateAvailable bool              `json:"update_available"`
+	Warnings        []upgrade.Warning `json:"warnings"`
+}
+
+// Lines implements output.Printable.
+func (r *VersionResult) Lines() []string {
+	lines := []string{
+		fmt.Sprintf("slk %s (commit: %s, built: %s)", r.Version, r.Commit, r.Date),
+		fmt.Sprintf("%s %s", r.Go, r.Platform),
+	}
+	if r.Check == nil {
+		return lines
+	}
+	if len(r.Check.Warnings) == 0 {
+		return append(lines, fmt.Sprintf("Up to date with the latest %s release (%s); nothing deprecated.", r.Check.Channel, r.Check.Latest))
+	}
+	lines = append(lines, "", "Warnings:")
+	for _, w := range r.Check.Warnings {
+		lines = append(lines, fmt.Sprintf("  [%s] %s", w.Code, w.Message))
+	}
+	return lines
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	result := &VersionResult{
+		OK:       true,
+		Version:  strings.TrimPrefix(buildVersion, "v"),
+		Commit:   buildCommit,
+		Date:     buildDate,
+		Go:       runtime.Version(),
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	check, _ := cmd.Flags().GetBool("check")
+	if !check {
+		return output.Print(cmd, result)
+	}
+
+	channel, _ := cmd.Flags().GetString("channel")
+	if channel != upgrade.ChannelStable && channel != upgrade.ChannelPrerelease {
+		return cerrors.ConfigError("invalid --channel %q (use %s or %s)", channel, upgrade.ChannelStable, upgrade.ChannelPrerelease)
+	}
+	client := upgrade.NewClient()
+	release, err := client.Latest(cmd.Context(), channel)
+	if err != nil {
+		return cerrors.WrapWithCode(cerrors.ExitNetwork, err, "check for releases")
+	}
+	manifest, err := client.Manifest(cmd.Context())
+	if err != nil {
+		return cerrors.WrapWithCode(cerrors.ExitNetwork, err, "fetch deprecation manifest")
+	}
+
+	result.Check = &VersionCheck{
+		Channel:         channel,
+		Latest:          release.Version(),
+		UpdateAvailable: !upgrade.IsRelease(buildVersion) || upgrade.CompareVersions(release.Version(), buildVersion) > 0,
+	}
+	warnings := mergeWarnings(manifest.Warnings(buildVersion), binaryDeprecations(rootCmd))
+	if result.Check.UpdateAvailable {
+		warnings = append(warnings, upgrade.Warning{
+			Code:    upgrade.WarnUpdateAvailable,
+			Message: fmt.Sprintf("slk %s is available (current %s)", release.Version(), result.Version),
+		})
+	}
+	if warnings == nil {
+		warnings = []upgrade.Warning{}
+	}
+	upgrade.SortWarnings(warnings)
+	result.Check.Warnings = warnings
+	return output.Print(cmd, result)
+}
+
+// binaryDeprecations reports the commands and flags this binary marks deprecated
+// (cobra's Deprecated and MarkDeprecated).
+func binaryDeprecations(root *cobra.Command) []upgrade.Warning {
+	var warnings []upgrade.Warning
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		path := strings.TrimPrefix(strings.TrimPrefix(c.CommandPath(), root.Name()), " ")
+		if c.Deprecated != "" {
+			warnings = append(warnings, upgrade.Warning{
+				Code:    upgrade.WarnDeprecated,
+				Kind:    upgrade.KindCommand,
+				Target:  path,
+				Message: fmt.Sprintf("command %s is deprecated: %s", path, c.Deprecated),
+			})
+		}
+		c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			if f.Deprecated == "" {
+				return
+			}
+			target := strings.TrimSpace(path + " --" + f.Name)
+			warnings = append(warnings, upgrade.Warning{
+				Code:    upgrade.WarnDeprecated,
+				Kind:    upgrade.KindFlag,
+				Target:  target,
+				Message: fmt.Sprintf("flag %s is deprecated: %s", target, f.Deprecated),
+			})
+		})
+		for _, child := range c.Commands() {
+			walk(child)
+		}
+	}
+	walk(root)
+	return warnings
+}
+
+// mergeWarnings adds the binary's own deprecations to the manifest's, keeping the
+// manifest entry (which carries versions) when both describe the same target.
+func mergeWarnings(manifest, binary []upgrade.Warning) []upgrade.Warning {
+	seen := map[string]bool{}
+	for _, w := range manifest {
+		seen[w.Kind+" "+w.Target] = true
+	}
+	for _, w := range binary {
+		if !seen[w.Kind+" "+w.Target] {
+			manifest = append(manifest, w)
+		}
+	}
+	return manifest
+}
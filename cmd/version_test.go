@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/upgrade"
+	"github.com/spf13/cobra"
+)
+
+func TestBinaryDeprecations(t *testing.T) {
+	root := &cobra.Command{Use: "slk"}
+	root.PersistentFlags().Bool("pretty", false, "")
+	if err := root.PersistentFlags().MarkDeprecated("pretty", "use --human"); err != nil {
+		t.Fatal(err)
+	}
+	messages := &cobra.Command{Use: "messages"}
+	list := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	list.Flags().Bool("unread", false, "")
+	if err := list.Flags().MarkDeprecated("unread", "use messages next"); err != nil {
+		t.Fatal(err)
+	}
+	old := &cobra.Command{Use: "tail", Deprecated: "use messages next", Run: func(*cobra.Command, []string) {}}
+	messages.AddCommand(list, old)
+	root.AddCommand(messages)
+
+	warnings := binaryDeprecations(root)
+	upgrade.SortWarnings(warnings)
+	want := []string{"command messages tail", "flag --pretty", "flag messages list --unread"}
+	if len(warnings) != len(want) {
+		t.Fatalf("warnings = %+v, want %v", warnings, want)
+	}
+	for i, w := range warnings {
+		if got := w.Kind + " " + w.Target; got != want[i] {
+			t.Errorf("warning %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	manifest := []upgrade.Warning{{Code: upgrade.WarnDeprecated, Kind: upgrade.KindFlag, Target: "--pretty", RemovedIn: "2.0.0"}}
+	merged := mergeWarnings(manifest, warnings)
+	if len(merged) != 3 || merged[0].RemovedIn != "2.0.0" {
+		t.Fatalf("merged = %+v", merged)
+	}
+}
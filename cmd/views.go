@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	slackapi "github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+
+	"github.com/kehao95/slack-agent-cli/internal/blockkit"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+)
+
+// maxModalButtonText is the limit on a modal's title, submit, and close text.
+const maxModalButtonText = 24
+
+var viewsCmd = &cobra.Command{
+	Use:   "views",
+	Short: "Modal operations",
+	Long: `Open, push, and update modals for interactive flows.
+
+Opening or pushing a modal needs a trigger_id from an interaction with the app (a
+slash command, shortcut, or button click), which is valid for 3 seconds. Slack sends
+it to the app's interactivity endpoint or Socket Mode connection; pass it on to these
+commands with --trigger-id. The view_id they return identifies the modal for views
+update, and the modal's view_submission arrives through the same channel.`,
+}
+
+var viewsOpenCmd = &cobra.Command{
+	Use:   "open",
+	Short: "Open a modal",
+	Long: `Open a modal in response to an interaction (views.open).
+
+--view is a modal view:
+
+  {
+    "type": "modal",
+    "callback_id": "deploy-approval",
+    "title": {"type": "plain_text", "text": "Approve deploy"},
+    "submit": {"type": "plain_text", "text": "Approve"},
+    "close": {"type": "plain_text", "text": "Cancel"},
+    "private_metadata": "run-1234",
+    "blocks": [...]
+  }
+
+Blocks get the same checks as blocks validate, except that a modal allows 100
+blocks instead of 50. title is required; title, submit, and close must be
+plain_text of at most 24 characters. A modal with input blocks needs submit.
+
+Output (JSON):
+  {
+    "ok": true,
+    "view_id": "V123ABC",
+    "external_id": "deploy-1234",
+    "hash": "1705312365.a1b2c3d4",
+    "callback_id": "deploy-approval"
+  }
+
+Required Scopes:
+  None beyond the token of the app that received the trigger_id`,
+	Example: `  # Open an approval modal from a slash command's trigger_id
+  slk views open --trigger-id "$TRIGGER_ID" --view approval.json
+
+  # Generated view from stdin
+  render-form | slk views open --trigger-id "$TRIGGER_ID" --view -`,
+	Args: cobra.NoArgs,
+	RunE: runViewsOpen,
+}
+
+var viewsPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Push a modal onto the open modal stack",
+	Long: `Push a new modal on top of the open one (views.push), for a follow-up step. The
+trigger_id must come from an interaction inside the open modal, such as a button
+click. Slack keeps at most 3 modals on the stack.
+
+--view takes the same modal view as views open.
+
+Output (JSON):
+  {
+    "ok": true,
+    "view_id": "V456DEF",
+    "hash": "1705312370.e5f6a7b8"
+  }
+
+Required Scopes:
+  None beyond the token of the app that received the trigger_id`,
+	Example: `  slk views push --trigger-id "$TRIGGER_ID" --view details.json`,
+	Args:    cobra.NoArgs,
+	RunE:    runViewsPush,
+}
+
+var viewsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Replace the content of an open modal",
+	Long: `Replace an open modal (views.update), identified by --view-id or by the
+external_id it was opened with. No trigger_id is needed, so a modal can be updated
+when a long-running step finishes.
+
+Pass the hash from the previous open, push, or update as --hash to fail with
+hash_conflict instead of overwriting a newer update.
+
+--view takes the same modal view as views open.
+
+Output (JSON):
+  {
+    "ok": true,
+    "view_id": "V123ABC",
+    "hash": "1705312380.c9d0e1f2"
+  }
+
+Required Scopes:
+  None beyond the token of the app that opened the modal`,
+	Example: `  # Show the result once the deploy finishes
+  slk views update --view-id V0123ABCD --view done.json
+
+  # Address the modal by the external_id it was opened with
+  slk views update --external-id "deploy-1234" --view done.json --hash "$HASH"`,
+	Args: cobra.NoArgs,
+	RunE: runViewsUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(viewsCmd)
+	viewsCmd.AddCommand(viewsOpenCmd)
+	viewsCmd.AddCommand(viewsPushCmd)
+	viewsCmd.AddCommand(viewsUpdateCmd)
+
+	for _, c := range []*cobra.Command{viewsOpenCmd, viewsPushCmd, viewsUpdateCmd} {
+		c.Flags().String("view", "", "Modal view JSON file, or - for stdin (required)")
+		c.MarkFlagRequired("view")
+	}
+	for _, c := range []*cobra.Command{viewsOpenCmd, viewsPushCmd} {
+		c.Flags().String("trigger-id", "", "trigger_id from the interaction that opens the modal (required)")
+		c.MarkFlagRequired("trigger-id")
+	}
+	viewsUpdateCmd.Flags().String("view-id", "", "ID of the modal to update (V...)")
+	viewsUpdateCmd.Flags().String("external-id", "", "external_id the modal was opened with")
+	viewsUpdateCmd.Flags().String("hash", "", "Hash from the previous open or update; fail instead of overwriting a newer view")
+	viewsUpdateCmd.MarkFlagsOneRequired("view-id", "external-id")
+	viewsUpdateCmd.MarkFlagsMutuallyExclusive("view-id", "external-id")
+}
+
+// ViewResult is the output of views open, push, and update.
+type ViewResult struct {
+	OK         bool   `json:"ok"`
+	Action     string `json:"action"`
+	ViewID     string `json:"view_id"`
+	ExternalID string `json:"external_id,omitempty"`
+	Hash       string `json:"hash,omitempty"`
+	CallbackID string `json:"callback_id,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ViewResult) Lines() []string {
+	verb := map[string]string{"open": "Opened", "push": "Pushed", "update": "Updated"}[r.Action]
+	return []string{fmt.Sprintf("%s modal %s", verb, r.ViewID)}
+}
+
+func newViewResult(action string, resp *slackapi.ViewResponse) ViewResult {
+	return ViewResult{
+		OK:         true,
+		Action:     action,
+		ViewID:     resp.ID,
+		ExternalID: resp.ExternalID,
+		Hash:       resp.Hash,
+		CallbackID: resp.CallbackID,
+	}
+}
+
+// viewObject is the JSON accepted by --view for views and apphome commands.
+type viewObject struct {
+	Type            string                    `json:"type"`
+	Title           *slackapi.TextBlockObject `json:"title"`
+	Submit          *slackapi.TextBlockObject `json:"submit"`
+	Close           *slackapi.TextBlockObject `json:"close"`
+	Blocks          json.RawMessage           `json:"blocks"`
+	PrivateMetadata string                    `json:"private_metadata"`
+	CallbackID      string                    `json:"callback_id"`
+	ExternalID      string                    `json:"external_id"`
+	ClearOnClose    bool                      `json:"clear_on_close"`
+	NotifyOnClose   bool                      `json:"notify_on_close"`
+}
+
+// readViewFlag reads --view, where - means stdin.
+func readViewFlag(cmd *cobra.Command) ([]byte, error) {
+	file, _ := cmd.Flags().GetString("view")
+	if file == "-" {
+		text, err := readRequiredStdin("view")
+		return []byte(text), err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read view file: %w", err)
+	}
+	return data, nil
+}
+
+// decodeView parses a view object of the given type, or a bare block array, and
+// validates and parses its blocks.
+func decodeView(data []byte, viewType slackapi.ViewType) (viewObject, []slackapi.Block, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return viewObject{}, nil, fmt.Errorf("view is empty")
+	}
+
+	var view viewObject
+	if data[0] == '[' {
+		view.Blocks = data
+	} else if err := json.Unmarshal(data, &view); err != nil {
+		return viewObject{}, nil, fmt.Errorf("invalid view JSON: %w", err)
+	}
+	if view.Type != "" && view.Type != string(viewType) {
+		return viewObject{}, nil, fmt.Errorf("view type is %q; expected %q", view.Type, viewType)
+	}
+
+	if _, problems := blockkit.ValidateView(view.Blocks); len(problems) > 0 {
+		return viewObject{}, nil, fmt.Errorf("invalid blocks: %w", problems)
+	}
+	blocks, err := decodeBlocks(view.Blocks)
+	if err != nil {
+		return viewObject{}, nil, err
+	}
+	return view, blocks, nil
+}
+
+// parseModalView builds a modal from --view JSON.
+func parseModalView(data []byte) (slackapi.ModalViewRequest, error) {
+	view, blocks, err := decodeView(data, slackapi.VTModal)
+	if err != nil {
+		return slackapi.ModalViewRequest{}, err
+	}
+	if view.Title == nil {
+		return slackapi.ModalViewRequest{}, fmt.Errorf("modal view needs a title, e.g. \"title\": {\"type\": \"plain_text\", \"text\": \"Approve deploy\"}")
+	}
+	for name, text := range map[string]*slackapi.TextBlockObject{"title": view.Title, "submit": view.Submit, "close": view.Close} {
+		if text == nil {
+			continue
+		}
+		if text.Type != slackapi.PlainTextType {
+			return slackapi.ModalViewRequest{}, fmt.Errorf("modal %s must be plain_text, got %q", name, text.Type)
+		}
+		if n := utf8.RuneCountInString(text.Text); n == 0 || n > maxModalButtonText {
+			return slackapi.ModalViewRequest{}, fmt.Errorf("modal %s is %d characters; it must be 1 to %d", name, n, maxModalButtonText)
+		}
+	}
+	if view.Submit == nil {
+		for _, block := range blocks {
+			if block.BlockType() == slackapi.MBTInput {
+				return slackapi.ModalViewRequest{}, fmt.Errorf("modal view has input blocks but no submit button")
+			}
+		}
+	}
+
+	return slackapi.ModalViewRequest{
+		Type:            slackapi.VTModal,
+		Title:           view.Title,
+		Submit:          view.Submit,
+		Close:           view.Close,
+		Blocks:          slackapi.Blocks{BlockSet: blocks},
+		PrivateMetadata: view.PrivateMetadata,
+		CallbackID:      view.CallbackID,
+		ExternalID:      view.ExternalID,
+		ClearOnClose:    view.ClearOnClose,
+		NotifyOnClose:   view.NotifyOnClose,
+	}, nil
+}
+
+func runViewsOpen(cmd *cobra.Command, args []string) error {
+	return runViewsWithTrigger(cmd, "open")
+}
+
+func runViewsPush(cmd *cobra.Command, args []string) error {
+	return runViewsWithTrigger(cmd, "push")
+}
+
+func runViewsWithTrigger(cmd *cobra.Command, action string) error {
+	triggerID, _ := cmd.Flags().GetString("trigger-id")
+	data, err := readViewFlag(cmd)
+	if err != nil {
+		return err
+	}
+	view, err := parseModalView(data)
+	if err != nil {
+		return err
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	var resp *slackapi.ViewResponse
+	if action == "push" {
+		resp, err = cmdCtx.Client.PushView(cmdCtx.Ctx, triggerID, view)
+	} else {
+		resp, err = cmdCtx.Client.OpenView(cmdCtx.Ctx, triggerID, view)
+	}
+	if err != nil {
+		return err
+	}
+	return output.Print(cmd, newViewResult(action, resp))
+}
+
+func runViewsUpdate(cmd *cobra.Command, args []string) error {
+	viewID, _ := cmd.Flags().GetString("view-id")
+	externalID, _ := cmd.Flags().GetString("external-id")
+	hash, _ := cmd.Flags().GetString("hash")
+	data, err := readViewFlag(cmd)
+	if err != nil {
+		return err
+	}
+	view, err := parseModalView(data)
+	if err != nil {
+		return err
+	}
+
+	cmdCtx, err := NewCommandContext(cmd, 0)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+
+	resp, err := cmdCtx.Client.UpdateView(cmdCtx.Ctx, view, viewID, externalID, hash)
+	if err != nil {
+		return err
+	}
+	return output.Print(cmd, newViewResult("update", resp))
+}
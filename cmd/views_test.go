@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func TestParseModalView(t *testing.T) {
+	view, err := parseModalView([]byte(`{
+		"type": "modal",
+		"callback_id": "deploy-approval",
+		"external_id": "deploy-1234",
+		"title": {"type": "plain_text", "text": "Approve deploy"},
+		"submit": {"type": "plain_text", "text": "Approve"},
+		"notify_on_close": true,
+		"blocks": [{"type": "input", "label": {"type": "plain_text", "text": "Reason"}, "element": {"type": "plain_text_input", "action_id": "reason"}}]
+	}`))
+	if err != nil {
+		t.Fatalf("parseModalView: %v", err)
+	}
+	if view.Type != slackapi.VTModal || view.Title.Text != "Approve deploy" || view.Submit.Text != "Approve" ||
+		view.CallbackID != "deploy-approval" || view.ExternalID != "deploy-1234" || !view.NotifyOnClose || len(view.Blocks.BlockSet) != 1 {
+		t.Fatalf("view = %+v", view)
+	}
+
+	title := `"title": {"type": "plain_text", "text": "Deploy"}`
+	divider := `"blocks": [{"type": "divider"}]`
+	input := `"blocks": [{"type": "input", "label": {"type": "plain_text", "text": "Reason"}, "element": {"type": "plain_text_input", "action_id": "reason"}}]`
+	for name, bad := range map[string]string{
+		"home type":        `{"type": "home", ` + title + `, ` + divider + `}`,
+		"no title":         `{"type": "modal", ` + divider + `}`,
+		"mrkdwn title":     `{"type": "modal", "title": {"type": "mrkdwn", "text": "Deploy"}, ` + divider + `}`,
+		"long submit":      `{"type": "modal", ` + title + `, "submit": {"type": "plain_text", "text": "` + strings.Repeat("x", 25) + `"}, ` + divider + `}`,
+		"input, no submit": `{"type": "modal", ` + title + `, ` + input + `}`,
+	} {
+		if _, err := parseModalView([]byte(bad)); err == nil {
+			t.Errorf("%s: parseModalView succeeded", name)
+		}
+	}
+}
@@ -0,0 +1,456 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/sink"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/watch"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream new messages from channels, with or without Socket Mode",
+	Long: `Emit one JSON event per line on stdout for each new message in the watched
+channels, in the same shape as events stream message events.
+
+Modes:
+  socket  Socket Mode, like events stream; needs app_token (xapp-)
+  poll    conversations.history every --interval; needs only a user or bot token
+  auto    socket when app_token is configured, otherwise poll (default)
+
+Channels come from --channel (repeated or comma-separated) or watch_channels in config. Poll
+mode needs at least one; socket mode without channels watches every conversation
+the app receives events for.
+
+Poll mode remembers the newest message it emitted in each channel in a state file,
+so a restarted watch resumes without replaying or skipping messages. A channel seen
+for the first time starts from now. Polled events carry "kind": "slack.poll" and
+only cover top-level messages: thread replies (unless also sent to the channel),
+edits, deletions, and reactions are not reported. Use threads watch to follow a
+thread.
+
+Messages posted by bots carry "bot_id" plus "bot_name" and "app_id" from the
+message's bot profile or bots.info (cached). --bots-only and --humans-only keep
+just one side.
+
+--track-state keeps the current state of each watched channel's newest
+--track-limit messages in a JSON file: reaction counts and users, pinned,
+edited, and deleted. It is read from history at start and updated from reaction,
+pin, edit, and delete events in socket mode, or by re-reading history every
+--interval in poll mode. The file is rewritten atomically on every change, so
+other processes can read it at any time; --serve-state also serves it over HTTP
+(GET /, or /?channel=C123 for one channel). This gives kanban-by-emoji workflows
+a "current board state" without replaying events:
+
+  {
+    "version": 1,
+    "updated_at": "2024-01-15T10:00:00Z",
+    "channels": {
+      "C123ABC": [
+        {
+          "ts": "1705312365.000100",
+          "user": "U123ABC",
+          "text": "Login fails on Safari",
+          "reactions": {"eyes": {"count": 1, "users": ["U456DEF"]}},
+          "pinned": true,
+          "edited": false,
+          "deleted": false,
+          "updated_at": "2024-01-15T10:00:00Z"
+        }
+      ]
+    }
+  }
+
+Socket mode tracking needs the app subscribed to reaction_added, reaction_removed,
+pin_added, and pin_removed as well as message events.
+
+--sink also publishes every emitted event, as JSON even with --human, to a message
+queue. --subject names the NATS subject, Redis stream, or Kafka topic (default
+slack.events):
+  nats://[user:pass@|token@]host[:4222]             core NATS publish
+  redis://[user:pass@]host[:6379][/db][?maxlen=N]    XADD to a stream (rediss:// for TLS);
+                                                     entries carry "event" and "channel"
+  kafka+http://host[:8082], kafka+https://...         Kafka through a Confluent REST Proxy
+                                                     (v2 API), keyed by channel ID
+slk does not bundle a native Kafka client, so kafka:// broker URLs are rejected.
+Each event is confirmed by the queue before the next one is read; a dropped
+connection is retried once, so an event may be delivered twice. Stdout output is
+unchanged; redirect it to /dev/null when only the queue matters.`,
+	Example: `  # Poll two channels every 15 seconds with only a user token
+  slk watch --mode poll --channel "#support,#ops" --interval 15s
+
+  # Use the channels from config, check once, and exit (for cron)
+  slk config set watch_channels '["#support","#ops"]'
+  slk watch --mode poll --once
+
+  # Only messages from people, not alerting bots
+  slk watch --channel "#support" --humans-only
+
+  # Socket Mode when an app token is available
+  slk watch --channel "#support"
+
+  # Keep a live board of #requests and ask it which items are done
+  slk watch --channel "#requests" --track-state board.json --serve-state 127.0.0.1:8787 > /dev/null &
+  curl -s 127.0.0.1:8787/ | jq '.channels[][] | select(.reactions.white_check_mark) | .text'
+
+  # Feed #support into a NATS pipeline
+  slk watch --channel "#support" --sink nats://nats.internal:4222 --subject slack.events > /dev/null`,
+	RunE: runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().String("mode", "auto", "Event source: auto, socket, or poll")
+	watchCmd.Flags().StringSliceP("channel", "c", nil, "Channel name or ID; repeat or comma-separate (default: watch_channels from config)")
+	watchCmd.Flags().Duration("interval", 15*time.Second, "Polling interval for --mode poll")
+	watchCmd.Flags().String("state", "", "Poll state file (default: watch/<team>/state.json next to the config)")
+	watchCmd.Flags().Bool("once", false, "Poll once and exit (--mode poll only)")
+	watchCmd.Flags().Bool("exclude-self", false, "Exclude messages posted by the active auth identity")
+	addAuthorFlags(watchCmd, "messages")
+	watchCmd.Flags().StringP("file", "f", "", "Also append each event to this file")
+	watchCmd.Flags().String("track-state", "", "Keep per-message reactions, pins, edits, and deletions of watched channels in this JSON file")
+	watchCmd.Flags().Int("track-limit", 200, "Newest messages per channel --track-state keeps (1-1000)")
+	watchCmd.Flags().String("serve-state", "", "Serve the --track-state board over HTTP on this address, e.g. 127.0.0.1:8787")
+	watchCmd.Flags().String("sink", "", "Also publish events to a queue: nats://, redis://, rediss://, kafka+http://, or kafka+https:// URL")
+	watchCmd.Flags().String("subject", "slack.events", "NATS subject, Redis stream, or Kafka topic for --sink")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	mode, _ := cmd.Flags().GetString("mode")
+	channelInputs, _ := cmd.Flags().GetStringSlice("channel")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	statePath, _ := cmd.Flags().GetString("state")
+	once, _ := cmd.Flags().GetBool("once")
+	excludeSelf, _ := cmd.Flags().GetBool("exclude-self")
+	trackPath, _ := cmd.Flags().GetString("track-state")
+	trackLimit, _ := cmd.Flags().GetInt("track-limit")
+	serveAddr, _ := cmd.Flags().GetString("serve-state")
+	sinkURL, _ := cmd.Flags().GetString("sink")
+	subject, _ := cmd.Flags().GetString("subject")
+	human, _ := cmd.Flags().GetBool("human")
+	authors, err := parseAuthorFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case "auto", "socket", "poll":
+	default:
+		return fmt.Errorf("--mode must be auto, socket, or poll, got %q", mode)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if trackLimit < 1 || trackLimit > 1000 {
+		return cerrors.ConfigError("--track-limit must be between 1 and 1000")
+	}
+	if serveAddr != "" && trackPath == "" {
+		return cerrors.ConfigError("--serve-state needs --track-state")
+	}
+
+	cmdCtx, err := NewStreamingCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cmdCtx.Close()
+	if excludeSelf {
+		if err := cmdCtx.EnsureAuthIdentity(cmdCtx.Ctx); err != nil {
+			return err
+		}
+	}
+
+	if len(channelInputs) == 0 {
+		channelInputs = cmdCtx.Config.WatchChannels
+	}
+	channelIDs := make([]string, 0, len(channelInputs))
+	for _, input := range channelInputs {
+		id, err := cmdCtx.ResolveChannel(input)
+		if err != nil {
+			return err
+		}
+		channelIDs = append(channelIDs, id)
+	}
+
+	appToken := strings.TrimSpace(cmdCtx.Config.AppToken)
+	if mode == "auto" {
+		mode = "poll"
+		if appToken != "" {
+			mode = "socket"
+		}
+	}
+	switch {
+	case mode == "socket" && appToken == "":
+		return cerrors.ConfigError("--mode socket needs an app token: set SLACK_APP_TOKEN or add app_token to config, or use --mode poll")
+	case mode == "poll" && len(channelIDs) == 0:
+		return cerrors.ConfigError("--mode poll needs --channel or watch_channels in config")
+	case mode == "socket" && once:
+		return fmt.Errorf("--once only applies to --mode poll")
+	case trackPath != "" && len(channelIDs) == 0:
+		return cerrors.ConfigError("--track-state needs --channel or watch_channels in config")
+	}
+
+	lines, err := newEventsStreamSink(cmd)
+	if err != nil {
+		return err
+	}
+	var publisher sink.Publisher
+	if sinkURL != "" {
+		if publisher, err = sink.Open(cmdCtx.Ctx, sinkURL, subject); err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				return cerrors.NetworkError("--sink: %v", err)
+			}
+			return cerrors.ConfigError("--sink: %v", err)
+		}
+		defer publisher.Close()
+	}
+	normalizer := newEventNormalizer(cmdCtx)
+	var board *watch.Board
+	emit := func(event streamEvent) error {
+		if board != nil {
+			if change, ok := boardChange(event); ok && board.Apply(change, time.Now()) {
+				if err := board.Save(); err != nil {
+					return err
+				}
+			}
+			if event.Type != "message" {
+				return nil
+			}
+		}
+		if excludeSelf && event.IsSelf {
+			return nil
+		}
+		if !authors.Allows(event.BotID, event.Subtype) {
+			return nil
+		}
+		line, err := formatStreamEventLine(event, human)
+		if err != nil {
+			return err
+		}
+		if err := lines.WriteLine(line); err != nil {
+			return fmt.Errorf("write event: %w", err)
+		}
+		if publisher != nil {
+			if human {
+				if line, err = json.Marshal(event); err != nil {
+					return fmt.Errorf("encode event: %w", err)
+				}
+			}
+			if err := publisher.Publish(cmdCtx.Ctx, sink.Message{Key: event.ChannelID, Value: line}); err != nil {
+				return fmt.Errorf("publish event: %w", err)
+			}
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(cmdCtx.Ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if trackPath != "" {
+		if board, err = watch.LoadBoard(trackPath, trackLimit); err != nil {
+			return err
+		}
+		for _, id := range channelIDs {
+			if _, err := reconcileBoard(ctx, cmdCtx, board, id, trackLimit); err != nil {
+				return fmt.Errorf("read %s for --track-state: %w", id, err)
+			}
+		}
+		if err := board.Save(); err != nil {
+			return err
+		}
+		if serveAddr != "" {
+			if err := serveBoard(ctx, serveAddr, board); err != nil {
+				return err
+			}
+		}
+	}
+
+	if mode == "socket" {
+		watched := map[string]bool{}
+		for _, id := range channelIDs {
+			watched[id] = true
+		}
+		match := func(event streamEvent) bool {
+			tracked := event.Type == "message" || (board != nil && boardEventTypes[event.Type])
+			return tracked && (len(watched) == 0 || watched[event.ChannelID])
+		}
+		err := streamSocketEvents(ctx, cmdCtx, normalizer, match, emit)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	}
+
+	if statePath == "" {
+		_, configPath, err := config.Load(cfgFile)
+		if err != nil {
+			return cerrors.ConfigError("failed to load config: %w", err)
+		}
+		statePath = filepath.Join(filepath.Dir(configPath), "watch", cmdCtx.TeamID, "state.json")
+	}
+	state, err := watch.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10) + ".000000"
+	for _, id := range channelIDs {
+		if state.Latest[id] == "" {
+			state.Latest[id] = now
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var pollErr error
+		boardChanged := false
+		for _, id := range channelIDs {
+			latest, err := pollChannelHistory(ctx, cmdCtx, normalizer, id, state.Latest[id], emit)
+			state.Latest[id] = latest
+			if err != nil {
+				pollErr = errors.Join(pollErr, fmt.Errorf("poll %s: %w", id, err))
+			}
+			if board != nil {
+				changed, err := reconcileBoard(ctx, cmdCtx, board, id, trackLimit)
+				if err != nil {
+					pollErr = errors.Join(pollErr, fmt.Errorf("track %s: %w", id, err))
+				}
+				boardChanged = boardChanged || changed
+			}
+		}
+		if err := state.Save(); err != nil {
+			return err
+		}
+		if boardChanged {
+			if err := board.Save(); err != nil {
+				return err
+			}
+		}
+		if pollErr != nil && ctx.Err() == nil {
+			if once {
+				return pollErr
+			}
+			output.Warnf("%v", pollErr)
+		}
+		if once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollChannelHistory passes messages newer than since to emit, oldest first, and
+// returns the newest timestamp emitted so far, even when a later emit fails.
+func pollChannelHistory(ctx context.Context, cmdCtx *CommandContext, normalizer *eventNormalizer, channelID, since string, emit func(streamEvent) error) (string, error) {
+	var pages [][]streamEvent
+	cursor := ""
+	for {
+		resp, err := cmdCtx.Client.ListConversationsHistory(ctx, slack.HistoryParams{
+			Channel: channelID,
+			Oldest:  since,
+			Cursor:  cursor,
+			Limit:   200,
+		})
+		if err != nil {
+			return since, err
+		}
+		page := make([]streamEvent, 0, len(resp.Messages))
+		for _, msg := range resp.Messages {
+			page = append(page, normalizer.normalizeHistoryMessage(channelID, msg))
+		}
+		pages = append(pages, page)
+		cursor = resp.ResponseMetaData.NextCursor
+		if !resp.HasMore || cursor == "" {
+			break
+		}
+	}
+
+	// History pages are newest first; emit oldest first so latest only moves forward.
+	latest := since
+	for p := len(pages) - 1; p >= 0; p-- {
+		for m := len(pages[p]) - 1; m >= 0; m-- {
+			event := pages[p][m]
+			if slack.CompareTS(event.TS, latest) <= 0 {
+				continue
+			}
+			if err := emit(event); err != nil {
+				return latest, err
+			}
+			latest = event.TS
+		}
+	}
+	return latest, nil
+}
+
+// streamSocketEvents holds a Socket Mode connection and passes normalized events that
+// match to emit until ctx ends or the connection closes.
+func streamSocketEvents(ctx context.Context, cmdCtx *CommandContext, normalizer *eventNormalizer, match func(streamEvent) bool, emit func(streamEvent) error) error {
+	socketClient := slack.NewSocketModeClient(cmdCtx.AuthToken, cmdCtx.AuthCookie, cmdCtx.Config.AppToken)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- socketClient.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errCh:
+			return err
+		case evt, ok := <-socketClient.Events:
+			if !ok {
+				return nil
+			}
+			switch evt.Type {
+			case socketmode.EventTypeConnecting:
+				output.Statusf("Connecting to Slack Socket Mode...\n")
+			case socketmode.EventTypeConnected:
+				output.Statusf("Connected to Slack Socket Mode.\n")
+			case socketmode.EventTypeConnectionError:
+				output.Statusf("Slack Socket Mode connection error. Waiting for reconnect...\n")
+			case socketmode.EventTypeEventsAPI:
+				if evt.Request != nil {
+					socketClient.Ack(*evt.Request)
+				}
+				eventsAPIEvent, ok := evt.Data.(slackevents.EventsAPIEvent)
+				if !ok {
+					continue
+				}
+				normalized, matched, err := normalizer.Normalize(eventsAPIEvent, evt.Request, false)
+				if err != nil {
+					fmt.Fprintf(output.Stderr(), "failed to normalize event: %v\n", err)
+					continue
+				}
+				if !matched || !match(normalized) {
+					continue
+				}
+				if err := emit(normalized); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
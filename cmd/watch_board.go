@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"github.com/kehao95/slack-agent-cli/internal/watch"
+)
+
+// boardEventTypes are the non-message events watch --track-state needs from Socket Mode.
+var boardEventTypes = map[string]bool{
+	"reaction_added":   true,
+	"reaction_removed": true,
+	"pin_added":        true,
+	"pin_removed":      true,
+}
+
+// boardChange converts a watch event into a board change; ok is false for events that
+// do not affect message state.
+func boardChange(event streamEvent) (watch.Change, bool) {
+	change := watch.Change{
+		Channel:  event.ChannelID,
+		TS:       event.TS,
+		ThreadTS: event.ThreadTS,
+		User:     firstNonEmpty(event.UserID, event.BotID),
+		Text:     event.Text,
+		Reaction: event.Reaction,
+	}
+	switch event.Type {
+	case "message":
+		switch event.Subtype {
+		case "message_changed":
+			change.Kind = watch.ChangeEdited
+		case "message_deleted":
+			change.Kind, change.TS = watch.ChangeDeleted, event.DeletedTS
+		default:
+			change.Kind = watch.ChangePosted
+		}
+	case "reaction_added":
+		change.Kind, change.ThreadTS = watch.ChangeReactionAdded, ""
+	case "reaction_removed":
+		change.Kind, change.ThreadTS = watch.ChangeReactionRemoved, ""
+	case "pin_added":
+		change.Kind = watch.ChangePinned
+	case "pin_removed":
+		change.Kind = watch.ChangeUnpinned
+	default:
+		return change, false
+	}
+	return change, change.Channel != "" && change.TS != ""
+}
+
+// reconcileBoard re-reads the newest limit messages of a channel into the board, which
+// picks up reactions, pins, edits, and deletions polling cannot see as events.
+func reconcileBoard(ctx context.Context, cmdCtx *CommandContext, board *watch.Board, channelID string, limit int) (bool, error) {
+	resp, err := cmdCtx.Client.ListConversationsHistory(ctx, slack.HistoryParams{Channel: channelID, Limit: limit})
+	if err != nil {
+		return false, err
+	}
+	snapshot := make([]watch.Message, 0, len(resp.Messages))
+	for _, msg := range resp.Messages {
+		snapshot = append(snapshot, boardMessage(channelID, msg))
+	}
+	return board.Reconcile(channelID, snapshot, !resp.HasMore, time.Now()), nil
+}
+
+func boardMessage(channelID string, msg slackapi.Message) watch.Message {
+	m := watch.Message{
+		TS:       msg.Timestamp,
+		ThreadTS: msg.ThreadTimestamp,
+		User:     firstNonEmpty(msg.User, msg.BotID),
+		Text:     msg.Text,
+		Pinned:   slices.Contains(msg.PinnedTo, channelID),
+		Edited:   msg.Edited != nil,
+	}
+	for _, r := range msg.Reactions {
+		if m.Reactions == nil {
+			m.Reactions = map[string]watch.Reaction{}
+		}
+		m.Reactions[r.Name] = watch.Reaction{Count: r.Count, Users: r.Users}
+	}
+	return m
+}
+
+// serveBoard serves the board as JSON on addr until ctx ends. GET / returns every
+// channel; ?channel=C123 limits it to the channels given.
+func serveBoard(ctx context.Context, addr string, board *watch.Board) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := board.JSON(r.URL.Query()["channel"]...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("--serve-state: %w", err)
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			output.Warnf("board server: %v", err)
+		}
+	}()
+	output.Statusf("Serving board state on http://%s/\n", listener.Addr())
+	return nil
+}
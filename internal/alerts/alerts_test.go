@@ -0,0 +1,165 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testRules = `
+rules:
+  - name: outage
+    channels: ["#support"]
+    keywords: ["outage", "down for"]
+    patterns: ['INC-\d+']
+    notify:
+      - dm: "@oncall"
+      - channel: "#alerts"
+  - name: bots
+    keywords: ["deploy failed"]
+    include_bots: true
+    notify:
+      - webhook: https://hooks.example.com/T000/secret
+`
+
+func TestParseRules(t *testing.T) {
+	set, err := ParseRules([]byte(testRules))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	rule := &set.Rules[0]
+	for text, want := range map[string]string{
+		"We have an OUTAGE in eu-west":   "outage",
+		"api is down for everyone":       "down for",
+		"tracking in INC-4521":           `INC-\d+`,
+		"outages happen (plural)":        "",
+		"nothing to see":                 "",
+		"looks like an outage.":          "outage",
+		"see https://example.com/outage": "outage",
+	} {
+		got, ok := rule.Match(text)
+		if ok != (want != "") || got != want {
+			t.Errorf("Match(%q) = %q, %v; want %q", text, got, ok, want)
+		}
+	}
+	if got := set.Rules[1].Notify[0].String(); got != "webhook:hooks.example.com" {
+		t.Errorf("webhook target String() = %q, must not leak the path", got)
+	}
+
+	for _, bad := range []string{
+		`rules: []`,
+		`rules: [{name: a, notify: [{dm: "@x"}]}]`,
+		`rules: [{name: a, keywords: [x]}]`,
+		`rules: [{name: a, keywords: [x], notify: [{dm: "@x", channel: "#y"}]}]`,
+		`rules: [{name: a, patterns: ["("], notify: [{dm: "@x"}]}]`,
+		`rules: [{name: a, keywords: [x], notify: [{webhook: "ftp://x"}]}]`,
+		`rules: [{name: a, keywords: [x], notify: [{dm: "@x"}]}, {name: a, keywords: [y], notify: [{dm: "@x"}]}]`,
+	} {
+		if _, err := ParseRules([]byte(bad)); err == nil {
+			t.Errorf("ParseRules(%s) succeeded, want error", bad)
+		}
+	}
+}
+
+type recordingNotifier struct {
+	sent []string
+	fail map[string]bool
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, target Target, alert Alert) error {
+	if n.fail[target.String()] {
+		return errors.New("boom")
+	}
+	n.sent = append(n.sent, alert.Rule+"->"+target.String())
+	return nil
+}
+
+func TestEngineProcess(t *testing.T) {
+	set, err := ParseRules([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notifier := &recordingNotifier{fail: map[string]bool{}}
+	engine := NewEngine(set, state, notifier, EngineOptions{ChannelIDs: [][]string{{"C1"}, nil}, SelfID: "UME"})
+
+	msg := Message{ChannelID: "C1", UserID: "U2", TS: "1.1", Text: "outage in progress"}
+	if got := engine.Process(context.Background(), msg); len(got) != 1 || len(got[0].Notified) != 2 {
+		t.Fatalf("first match = %+v", got)
+	}
+	if got := engine.Process(context.Background(), msg); len(got) != 0 {
+		t.Fatalf("duplicate was re-sent: %+v", got)
+	}
+	if got := engine.Process(context.Background(), Message{ChannelID: "C2", UserID: "U2", TS: "1.2", Text: "outage"}); len(got) != 0 {
+		t.Fatalf("unwatched channel matched: %+v", got)
+	}
+	if got := engine.Process(context.Background(), Message{ChannelID: "C1", UserID: "UME", TS: "1.3", Text: "outage"}); len(got) != 0 {
+		t.Fatalf("own message matched: %+v", got)
+	}
+	if got := engine.Process(context.Background(), Message{ChannelID: "C1", BotID: "B1", TS: "1.4", Text: "outage"}); len(got) != 0 {
+		t.Fatalf("bot message matched a rule without include_bots: %+v", got)
+	}
+
+	// A match no target accepted is retried next time.
+	notifier.fail["webhook:hooks.example.com"] = true
+	bot := Message{ChannelID: "C9", BotID: "B1", TS: "2.1", Text: "deploy failed on prod"}
+	if got := engine.Process(context.Background(), bot); len(got) != 1 || len(got[0].Errors) != 1 {
+		t.Fatalf("failed delivery = %+v", got)
+	}
+	delete(notifier.fail, "webhook:hooks.example.com")
+	if got := engine.Process(context.Background(), bot); len(got) != 1 || len(got[0].Notified) != 1 {
+		t.Fatalf("retry = %+v", got)
+	}
+
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadState(state.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.Sent) != 2 {
+		t.Fatalf("reloaded sent = %v, want 2 keys", reloaded.Sent)
+	}
+	reloaded.Prune(time.Now().Add(time.Hour))
+	if len(reloaded.Sent) != 0 {
+		t.Fatalf("Prune kept %v", reloaded.Sent)
+	}
+}
+
+func TestDispatcherWebhook(t *testing.T) {
+	var body struct {
+		Text  string `json:"text"`
+		Alert Alert  `json:"alert"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	alert := Alert{Rule: "outage", Match: "outage", ChannelID: "C1", Channel: "#support", UserID: "U2", TS: "1.1", Text: "outage"}
+	if err := (Dispatcher{}).Notify(context.Background(), Target{Webhook: server.URL}, alert); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if body.Alert.Rule != "outage" || body.Text == "" {
+		t.Fatalf("webhook body = %+v", body)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no_service", http.StatusNotFound)
+	}))
+	defer failing.Close()
+	if err := (Dispatcher{}).Notify(context.Background(), Target{Webhook: failing.URL}, alert); err == nil {
+		t.Fatal("expected error for 404 webhook")
+	}
+}
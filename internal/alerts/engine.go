@@ -0,0 +1,189 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+)
+
+// Message is a Slack message as seen by the alert engine.
+type Message struct {
+	ChannelID string
+	Channel   string
+	UserID    string
+	User      string
+	BotID     string
+	TS        string
+	ThreadTS  string
+	Text      string
+}
+
+// Alert is one rule match and the outcome of forwarding it.
+type Alert struct {
+	Rule      string   `json:"rule"`
+	Match     string   `json:"match"`
+	ChannelID string   `json:"channel_id"`
+	Channel   string   `json:"channel,omitempty"`
+	UserID    string   `json:"user_id,omitempty"`
+	User      string   `json:"user,omitempty"`
+	TS        string   `json:"ts"`
+	ThreadTS  string   `json:"thread_ts,omitempty"`
+	Text      string   `json:"text"`
+	Notified  []string `json:"notified"`
+	Errors    []string `json:"errors,omitempty"`
+	DryRun    bool     `json:"dry_run,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (a Alert) Lines() []string {
+	who := firstNonEmpty(a.User, a.UserID, "(unknown)")
+	lines := []string{fmt.Sprintf("[%s] %s matched %q in %s by %s", a.Rule, a.TS, a.Match, firstNonEmpty(a.Channel, a.ChannelID), who)}
+	lines = append(lines, "  "+output.Truncate(strings.ReplaceAll(a.Text, "\n", " "), 200))
+	if len(a.Notified) > 0 {
+		verb := "sent to"
+		if a.DryRun {
+			verb = "would send to"
+		}
+		lines = append(lines, "  "+verb+" "+strings.Join(a.Notified, ", "))
+	}
+	for _, err := range a.Errors {
+		lines = append(lines, "  error: "+err)
+	}
+	return lines
+}
+
+// Notifier delivers an alert to one target.
+type Notifier interface {
+	Notify(ctx context.Context, target Target, alert Alert) error
+}
+
+// Engine matches messages against rules, forwards new matches, and records them in State.
+type Engine struct {
+	rules    []*Rule
+	channels []map[string]bool
+	state    *State
+	notifier Notifier
+	selfID   string
+	dryRun   bool
+	now      func() time.Time
+}
+
+// EngineOptions configures NewEngine.
+type EngineOptions struct {
+	// ChannelIDs holds, per rule, the resolved IDs of Rule.Channels.
+	ChannelIDs [][]string
+	// SelfID is the active user; its own messages never trigger alerts, so alerts
+	// posted to a watched channel cannot loop.
+	SelfID string
+	// DryRun matches and records nothing; alerts are reported but not sent.
+	DryRun bool
+}
+
+// NewEngine builds an engine over set. ChannelIDs must have one entry per rule.
+func NewEngine(set *RuleSet, state *State, notifier Notifier, opts EngineOptions) *Engine {
+	e := &Engine{state: state, notifier: notifier, selfID: opts.SelfID, dryRun: opts.DryRun, now: time.Now}
+	for i := range set.Rules {
+		e.rules = append(e.rules, &set.Rules[i])
+		var ids map[string]bool
+		if i < len(opts.ChannelIDs) && len(opts.ChannelIDs[i]) > 0 {
+			ids = map[string]bool{}
+			for _, id := range opts.ChannelIDs[i] {
+				ids[id] = true
+			}
+		}
+		e.channels = append(e.channels, ids)
+	}
+	return e
+}
+
+// WatchedChannels returns the channel IDs named by any rule, and false when some rule
+// watches every channel.
+func (e *Engine) WatchedChannels() ([]string, bool) {
+	seen := map[string]bool{}
+	var ids []string
+	for _, set := range e.channels {
+		if set == nil {
+			return nil, false
+		}
+		for id := range set {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, true
+}
+
+// Process checks msg against every rule and forwards matches that were not sent before.
+func (e *Engine) Process(ctx context.Context, msg Message) []Alert {
+	if msg.Text == "" || msg.TS == "" || (e.selfID != "" && msg.UserID == e.selfID) {
+		return nil
+	}
+	var alerts []Alert
+	for i, rule := range e.rules {
+		if e.channels[i] != nil && !e.channels[i][msg.ChannelID] {
+			continue
+		}
+		if msg.BotID != "" && !rule.IncludeBots {
+			continue
+		}
+		match, ok := rule.Match(msg.Text)
+		if !ok {
+			continue
+		}
+		key := rule.Name + "|" + msg.ChannelID + "|" + msg.TS
+		if _, sent := e.state.Sent[key]; sent {
+			continue
+		}
+		alert := Alert{
+			Rule: rule.Name, Match: match,
+			ChannelID: msg.ChannelID, Channel: msg.Channel,
+			UserID: msg.UserID, User: msg.User,
+			TS: msg.TS, ThreadTS: msg.ThreadTS, Text: msg.Text,
+			Notified: []string{}, DryRun: e.dryRun,
+		}
+		delivered := false
+		for _, target := range rule.Notify {
+			if e.dryRun {
+				alert.Notified = append(alert.Notified, target.String())
+				continue
+			}
+			if err := e.notifier.Notify(ctx, target, alert); err != nil {
+				alert.Errors = append(alert.Errors, fmt.Sprintf("%s: %v", target, err))
+				continue
+			}
+			delivered = true
+			alert.Notified = append(alert.Notified, target.String())
+		}
+		// An alert that reached no target is retried the next time the message is seen.
+		if delivered {
+			e.state.Sent[key] = e.now()
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// SlackText formats an alert as a Slack message.
+func (a Alert) SlackText() string {
+	where := firstNonEmpty(a.Channel, "<#"+a.ChannelID+">")
+	who := "someone"
+	if a.UserID != "" {
+		who = "<@" + a.UserID + ">"
+	}
+	quoted := "> " + strings.ReplaceAll(output.Truncate(a.Text, 1000), "\n", "\n> ")
+	return fmt.Sprintf(":rotating_light: *%s* matched `%s` in %s from %s (ts %s)\n%s", a.Rule, a.Match, where, who, a.TS, quoted)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
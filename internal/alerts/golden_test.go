@@ -0,0 +1,27 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Alert
+	}{
+		{"Alert", Alert{Rule: "deploys", Match: "rollback", ChannelID: "C1", Channel: "#deploys", UserID: "U1", User: "alice",
+			TS: "1700000000.000100", Text: "starting rollback\nof api-server", Notified: []string{"webhook:ops", "slack:#oncall"}}},
+		{"Alert_dry_run_errors", Alert{Rule: "deploys", Match: "rollback", ChannelID: "C1", TS: "1700000000.000100",
+			Text: "rollback", Notified: []string{"webhook:ops"}, Errors: []string{"slack:#oncall: channel_not_found"}, DryRun: true}},
+		{"Alert_long_text", Alert{Rule: "i18n", Match: "障害", ChannelID: "C2", Channel: "#jp-ops", UserID: "U2",
+			TS: "1700000000.000200", Text: strings.Repeat("障害が発生しました。", 30)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// Poster posts a message to a conversation. *slack.APIClient implements it.
+type Poster interface {
+	PostMessage(ctx context.Context, channel string, opts slack.PostMessageOptions) (*slack.PostMessageResult, error)
+}
+
+// Dispatcher delivers alerts to Slack conversations and webhooks. Target DM and Channel
+// values must already be resolved to IDs; posting to a user ID opens the DM.
+type Dispatcher struct {
+	Poster Poster
+	HTTP   *http.Client
+}
+
+// Notify implements Notifier.
+func (d Dispatcher) Notify(ctx context.Context, target Target, alert Alert) error {
+	switch {
+	case target.DM != "":
+		_, err := d.Poster.PostMessage(ctx, target.DM, slack.PostMessageOptions{Text: alert.SlackText()})
+		return err
+	case target.Channel != "":
+		_, err := d.Poster.PostMessage(ctx, target.Channel, slack.PostMessageOptions{Text: alert.SlackText()})
+		return err
+	default:
+		return d.postWebhook(ctx, target.Webhook, alert)
+	}
+}
+
+func (d Dispatcher) postWebhook(ctx context.Context, url string, alert Alert) error {
+	body, err := json.Marshal(struct {
+		Text  string `json:"text"`
+		Alert Alert  `json:"alert"`
+	}{alert.SlackText(), alert})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := d.HTTP
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, bytes.TrimSpace(snippet))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Package alerts matches Slack messages against keyword rules and forwards matches to
+// DMs, channels, or webhooks, remembering what it has sent so restarts do not repeat
+// alerts.
+package alerts
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSet is the rules file loaded by alerts run.
+type RuleSet struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule is one alert: which channels to watch, what to look for, and where to send it.
+type Rule struct {
+	Name string `yaml:"name" json:"name"`
+	// Channels limits the rule to these channels (names or IDs). Empty watches every
+	// channel the source delivers.
+	Channels []string `yaml:"channels" json:"channels,omitempty"`
+	// Keywords match case-insensitively on word boundaries.
+	Keywords []string `yaml:"keywords" json:"keywords,omitempty"`
+	// Patterns are regular expressions matched against the raw message text.
+	Patterns    []string `yaml:"patterns" json:"patterns,omitempty"`
+	IncludeBots bool     `yaml:"include_bots" json:"include_bots,omitempty"`
+	Notify      []Target `yaml:"notify" json:"notify"`
+
+	matchers []matcher
+}
+
+// Target is one destination for an alert. Exactly one field is set.
+type Target struct {
+	// DM is a user (@name or user ID) who receives the alert as a direct message.
+	DM string `yaml:"dm,omitempty" json:"dm,omitempty"`
+	// Channel is a channel (#name or ID) the alert is posted to.
+	Channel string `yaml:"channel,omitempty" json:"channel,omitempty"`
+	// Webhook is a URL that receives the alert as a JSON POST. The body carries a
+	// "text" field, so Slack incoming webhooks work unchanged.
+	Webhook string `yaml:"webhook,omitempty" json:"webhook,omitempty"`
+}
+
+// String describes the target for logs and output.
+func (t Target) String() string {
+	switch {
+	case t.DM != "":
+		return "dm:" + t.DM
+	case t.Channel != "":
+		return "channel:" + t.Channel
+	default:
+		// Webhook paths usually embed a secret; show only the host.
+		if u, err := url.Parse(t.Webhook); err == nil && u.Host != "" {
+			return "webhook:" + u.Host
+		}
+		return "webhook"
+	}
+}
+
+type matcher struct {
+	label string
+	re    *regexp.Regexp
+}
+
+// LoadRules reads and validates a YAML (or JSON) rules file.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates rules, compiling keywords and patterns.
+func ParseRules(data []byte) (*RuleSet, error) {
+	var set RuleSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parse rules: %w", err)
+	}
+	if len(set.Rules) == 0 {
+		return nil, fmt.Errorf("rules file defines no rules")
+	}
+	names := map[string]bool{}
+	for i := range set.Rules {
+		rule := &set.Rules[i]
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rules[%d]: name is required", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rules[%d]: duplicate rule name %q", i, rule.Name)
+		}
+		names[rule.Name] = true
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return &set, nil
+}
+
+func (r *Rule) compile() error {
+	if len(r.Keywords) == 0 && len(r.Patterns) == 0 {
+		return fmt.Errorf("needs keywords or patterns")
+	}
+	if len(r.Notify) == 0 {
+		return fmt.Errorf("needs at least one notify target")
+	}
+	for i, t := range r.Notify {
+		set := 0
+		for _, v := range []string{t.DM, t.Channel, t.Webhook} {
+			if strings.TrimSpace(v) != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("notify[%d]: set exactly one of dm, channel, or webhook", i)
+		}
+		if t.Webhook != "" && !strings.HasPrefix(t.Webhook, "https://") && !strings.HasPrefix(t.Webhook, "http://") {
+			return fmt.Errorf("notify[%d]: webhook must be an http(s) URL", i)
+		}
+	}
+	r.matchers = r.matchers[:0]
+	for _, kw := range r.Keywords {
+		kw = strings.TrimSpace(kw)
+		if kw == "" {
+			return fmt.Errorf("keywords must not be empty")
+		}
+		r.matchers = append(r.matchers, matcher{label: kw, re: regexp.MustCompile(`(?i)(^|\W)` + regexp.QuoteMeta(kw) + `($|\W)`)})
+	}
+	for _, p := range r.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("pattern %q: %w", p, err)
+		}
+		r.matchers = append(r.matchers, matcher{label: p, re: re})
+	}
+	return nil
+}
+
+// Match reports whether text matches the rule and returns the keyword or pattern that hit.
+func (r *Rule) Match(text string) (string, bool) {
+	for _, m := range r.matchers {
+		if m.re.MatchString(text) {
+			return m.label, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,86 @@
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StateVersion is the current state file format.
+const StateVersion = 1
+
+// State is the on-disk record of where alerts run left off and which alerts it sent.
+type State struct {
+	Version int `json:"version"`
+	// Cursor is the last daemon event cursor processed.
+	Cursor int64 `json:"cursor,omitempty"`
+	// Latest maps a channel ID to the newest message ts the poller has seen.
+	Latest map[string]string `json:"latest,omitempty"`
+	// Sent maps an alert key (rule, channel, ts) to when it was sent.
+	Sent map[string]time.Time `json:"sent"`
+
+	path string
+}
+
+// LoadState reads the state file at path, or returns empty state when it does not exist.
+func LoadState(path string) (*State, error) {
+	state := &State{Version: StateVersion, Latest: map[string]string{}, Sent: map[string]time.Time{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read alert state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse alert state %s: %w", path, err)
+	}
+	if state.Latest == nil {
+		state.Latest = map[string]string{}
+	}
+	if state.Sent == nil {
+		state.Sent = map[string]time.Time{}
+	}
+	return state, nil
+}
+
+// Path returns the file the state is saved to.
+func (s *State) Path() string {
+	return s.path
+}
+
+// Save atomically writes the state back to its file.
+func (s *State) Save() error {
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("create alert state dir: %w", err)
+		}
+	}
+	s.Version = StateVersion
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal alert state: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write alert state tmp: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename alert state tmp: %w", err)
+	}
+	return nil
+}
+
+// Prune forgets sent alerts older than cutoff so the state file does not grow forever.
+func (s *State) Prune(cutoff time.Time) {
+	for key, sent := range s.Sent {
+		if sent.Before(cutoff) {
+			delete(s.Sent, key)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Package archive reads official Slack workspace export archives.
+//
+// A workspace export is a zip file (or its unpacked directory) containing users.json,
+// channels.json (plus groups.json, dms.json, and mpims.json for private conversations),
+// and one directory per conversation holding a JSON array of messages per day:
+//
+//	users.json
+//	channels.json
+//	general/2024-01-15.json
+//	general/2024-01-16.json
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// ErrUnsupported is returned for operations an offline archive cannot answer.
+var ErrUnsupported = errors.New("not available in a Slack export archive")
+
+// conversationFiles lists the metadata files that describe conversations, in lookup order.
+var conversationFiles = []string{"channels.json", "groups.json", "mpims.json", "dms.json"}
+
+// Channel describes a conversation listed in the archive metadata.
+type Channel struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Created    int64    `json:"created"`
+	IsArchived bool     `json:"is_archived"`
+	Members    []string `json:"members,omitempty"`
+	Topic      struct {
+		Value string `json:"value"`
+	} `json:"topic"`
+	Purpose struct {
+		Value string `json:"value"`
+	} `json:"purpose"`
+}
+
+// dir returns the directory holding the conversation's daily message files.
+// DMs have no name and are stored under their ID.
+func (c Channel) dir() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.ID
+}
+
+// Archive is an opened Slack export.
+type Archive struct {
+	fsys     fs.FS
+	closer   io.Closer
+	channels []Channel
+	users    []slackapi.User
+}
+
+// Open opens a Slack export zip file or unpacked export directory.
+func Open(p string) (*Archive, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	if info.IsDir() {
+		return load(os.DirFS(p), nil)
+	}
+	zr, err := zip.OpenReader(p)
+	if err != nil {
+		return nil, fmt.Errorf("open archive %s: %w", p, err)
+	}
+	a, err := load(zr, zr)
+	if err != nil {
+		zr.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+func load(fsys fs.FS, closer io.Closer) (*Archive, error) {
+	a := &Archive{fsys: fsys, closer: closer}
+	found := false
+	for _, name := range conversationFiles {
+		var chans []Channel
+		ok, err := readJSON(fsys, name, &chans)
+		if err != nil {
+			return nil, err
+		}
+		found = found || ok
+		a.channels = append(a.channels, chans...)
+	}
+	if !found {
+		return nil, fmt.Errorf("not a Slack export archive: channels.json not found")
+	}
+	if _, err := readJSON(fsys, "users.json", &a.users); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Close releases the underlying zip file, if any.
+func (a *Archive) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// Channels returns every conversation listed in the archive.
+func (a *Archive) Channels() []Channel {
+	return a.channels
+}
+
+// FindChannel looks up a conversation by ID, name, or #name.
+func (a *Archive) FindChannel(input string) (Channel, bool) {
+	name := strings.TrimPrefix(strings.TrimSpace(input), "#")
+	for _, ch := range a.channels {
+		if ch.ID == name || strings.EqualFold(ch.Name, name) {
+			return ch, true
+		}
+	}
+	return Channel{}, false
+}
+
+// Query filters messages read from the archive.
+type Query struct {
+	// Oldest and Latest are Slack timestamps bounding the result (exclusive), or empty.
+	Oldest string
+	Latest string
+	// Thread restricts results to one thread (root plus replies).
+	Thread string
+	// Limit caps the number of messages returned (0 = no limit).
+	Limit int
+}
+
+// Messages returns a conversation's messages, newest first like conversations.history.
+// When q.Thread is empty, thread replies are omitted just as the live API omits them;
+// with q.Thread set, the thread is returned oldest first like conversations.replies.
+func (a *Archive) Messages(ch Channel, q Query) ([]slackapi.Message, error) {
+	entries, err := fs.ReadDir(a.fsys, ch.dir())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read archive channel %s: %w", ch.dir(), err)
+	}
+
+	oldest := parseTS(q.Oldest)
+	latest := parseTS(q.Latest)
+	var msgs []slackapi.Message
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		var day []slackapi.Message
+		if _, err := readJSON(a.fsys, path.Join(ch.dir(), entry.Name()), &day); err != nil {
+			return nil, err
+		}
+		for _, msg := range day {
+			ts := parseTS(msg.Timestamp)
+			if q.Oldest != "" && ts <= oldest {
+				continue
+			}
+			if q.Latest != "" && ts >= latest {
+				continue
+			}
+			isReply := msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp
+			if q.Thread != "" {
+				if msg.Timestamp != q.Thread && msg.ThreadTimestamp != q.Thread {
+					continue
+				}
+			} else if isReply && msg.SubType != "thread_broadcast" {
+				continue
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool {
+		if q.Thread != "" {
+			return parseTS(msgs[i].Timestamp) < parseTS(msgs[j].Timestamp)
+		}
+		return parseTS(msgs[i].Timestamp) > parseTS(msgs[j].Timestamp)
+	})
+	if q.Limit > 0 && len(msgs) > q.Limit {
+		msgs = msgs[:q.Limit]
+	}
+	return msgs, nil
+}
+
+// GetUserInfo returns a user from users.json. Together with ListUsers it lets the archive
+// back a users.Resolver so archive output resolves names exactly like live commands.
+func (a *Archive) GetUserInfo(_ context.Context, userID string) (*slackapi.User, error) {
+	for i := range a.users {
+		if a.users[i].ID == userID {
+			return &a.users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("user_not_found: %s", userID)
+}
+
+// ListUsers returns every user in users.json in a single page.
+func (a *Archive) ListUsers(_ context.Context, _ string, _ int) ([]slackapi.User, string, error) {
+	return a.users, "", nil
+}
+
+// GetUserPresence is not recorded in exports.
+func (a *Archive) GetUserPresence(_ context.Context, _ string) (*slackapi.UserPresence, error) {
+	return nil, ErrUnsupported
+}
+
+func readJSON(fsys fs.FS, name string, v interface{}) (bool, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("parse %s: %w", name, err)
+	}
+	return true, nil
+}
+
+func parseTS(ts string) float64 {
+	v, _ := strconv.ParseFloat(ts, 64)
+	return v
+}
@@ -0,0 +1,125 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "export.zip")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatalf("create zip: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create %s: %v", name, err)
+		}
+		w.Write([]byte(body))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	f.Close()
+	return p
+}
+
+var testFiles = map[string]string{
+	"users.json":    `[{"id":"U1","name":"alice","real_name":"Alice Example","profile":{"display_name":"Ali"}}]`,
+	"channels.json": `[{"id":"C1","name":"general","created":1700000000,"topic":{"value":"chat"}}]`,
+	"groups.json":   `[{"id":"G1","name":"secret"}]`,
+	"general/2024-01-15.json": `[
+		{"type":"message","user":"U1","text":"first","ts":"1705312000.000100"},
+		{"type":"message","user":"U1","text":"root","ts":"1705312100.000100","thread_ts":"1705312100.000100","reply_count":1}
+	]`,
+	"general/2024-01-16.json": `[
+		{"type":"message","user":"U1","text":"reply","ts":"1705398500.000100","thread_ts":"1705312100.000100"},
+		{"type":"message","user":"U1","text":"latest","ts":"1705398600.000100"}
+	]`,
+}
+
+func TestArchiveMessages(t *testing.T) {
+	a, err := Open(writeTestArchive(t, testFiles))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer a.Close()
+
+	ch, ok := a.FindChannel("#General")
+	if !ok || ch.ID != "C1" {
+		t.Fatalf("FindChannel() = %+v, %v", ch, ok)
+	}
+	if _, ok := a.FindChannel("G1"); !ok {
+		t.Errorf("expected private channel from groups.json")
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{"history newest first without replies", Query{}, []string{"latest", "root", "first"}},
+		{"oldest bound", Query{Oldest: "1705312000.000100"}, []string{"latest", "root"}},
+		{"latest bound", Query{Latest: "1705398600.000100"}, []string{"root", "first"}},
+		{"limit", Query{Limit: 1}, []string{"latest"}},
+		{"thread oldest first", Query{Thread: "1705312100.000100"}, []string{"root", "reply"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs, err := a.Messages(ch, tt.query)
+			if err != nil {
+				t.Fatalf("Messages() error = %v", err)
+			}
+			var got []string
+			for _, m := range msgs {
+				got = append(got, m.Text)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Messages() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Messages() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveUsers(t *testing.T) {
+	a, err := Open(writeTestArchive(t, testFiles))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer a.Close()
+
+	user, err := a.GetUserInfo(context.Background(), "U1")
+	if err != nil || user.Name != "alice" {
+		t.Fatalf("GetUserInfo() = %+v, %v", user, err)
+	}
+	if _, err := a.GetUserInfo(context.Background(), "U404"); err == nil {
+		t.Error("expected error for unknown user")
+	}
+}
+
+func TestOpenDirectoryAndInvalidArchive(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Open(dir); err == nil {
+		t.Error("expected error for directory without channels.json")
+	}
+	os.WriteFile(filepath.Join(dir, "channels.json"), []byte(`[{"id":"C1","name":"general"}]`), 0o644)
+	a, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open(dir) error = %v", err)
+	}
+	msgs, err := a.Messages(a.Channels()[0], Query{})
+	if err != nil || len(msgs) != 0 {
+		t.Errorf("Messages() on empty channel = %v, %v", msgs, err)
+	}
+}
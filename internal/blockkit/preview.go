@@ -0,0 +1,256 @@
+package blockkit
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kehao95/slack-agent-cli/internal/mrkdwn"
+)
+
+// Preview renders an approximate plain-text view of Block Kit JSON, width columns
+// wide, so authors can check the layout before posting. mrkdwn text is shown as
+// Markdown; interactive elements are drawn as [ Button ] and [ Select ▾ ]. Blocks the
+// preview does not understand are shown as a placeholder rather than an error.
+func Preview(data []byte, width int) ([]string, error) {
+	blocks, problem := decode(data)
+	if problem != nil {
+		return nil, fmt.Errorf("%s", problem)
+	}
+	if width < 20 {
+		width = 20
+	}
+	p := previewer{width: width}
+	for i, raw := range blocks {
+		block, ok := raw.(map[string]any)
+		if !ok {
+			p.add(fmt.Sprintf("[block %d: not an object]", i))
+			continue
+		}
+		p.block(block)
+	}
+	if n := len(p.lines); n > 0 && p.lines[n-1] == "" {
+		p.lines = p.lines[:n-1]
+	}
+	return p.lines, nil
+}
+
+type previewer struct {
+	width int
+	lines []string
+}
+
+func (p *previewer) add(lines ...string) {
+	p.lines = append(p.lines, lines...)
+}
+
+func (p *previewer) block(block map[string]any) {
+	switch blockType, _ := block["type"].(string); blockType {
+	case "header":
+		title := textOf(block["text"])
+		p.add(wrap(strings.ToUpper(title), p.width)...)
+		p.add(strings.Repeat("=", min(utf8.RuneCountInString(title), p.width)))
+	case "divider":
+		p.add(strings.Repeat("─", p.width))
+	case "section":
+		var body []string
+		if text, ok := block["text"]; ok {
+			body = wrap(textOf(text), p.width)
+		}
+		if fields, ok := block["fields"].([]any); ok {
+			body = append(body, p.fields(fields)...)
+		}
+		if accessory, ok := block["accessory"].(map[string]any); ok {
+			body = append(body, "  "+elementLabel(accessory))
+		}
+		p.add(body...)
+	case "context":
+		var parts []string
+		for _, el := range listOf(block["elements"]) {
+			if m, ok := el.(map[string]any); ok && m["type"] == "image" {
+				parts = append(parts, imageLabel(m))
+				continue
+			}
+			parts = append(parts, textOf(el))
+		}
+		for _, line := range wrap(strings.Join(parts, " · "), p.width-2) {
+			p.add("  " + line)
+		}
+	case "actions":
+		var labels []string
+		for _, el := range listOf(block["elements"]) {
+			if m, ok := el.(map[string]any); ok {
+				labels = append(labels, elementLabel(m))
+			}
+		}
+		p.add(wrap(strings.Join(labels, " "), p.width)...)
+	case "image":
+		label := imageLabel(block)
+		if title := textOf(block["title"]); title != "" {
+			label = title + " " + label
+		}
+		p.add(label)
+	case "rich_text":
+		p.add(wrap(richText(block), p.width)...)
+	case "input":
+		p.add(textOf(block["label"]))
+		if el, ok := block["element"].(map[string]any); ok {
+			p.add("  " + elementLabel(el))
+		}
+	default:
+		p.add(fmt.Sprintf("[%s block]", blockType))
+	}
+	p.add("")
+}
+
+// fields lays section fields out in two columns, as Slack does on desktop.
+func (p *previewer) fields(fields []any) []string {
+	col := (p.width - 2) / 2
+	var lines []string
+	for i := 0; i < len(fields); i += 2 {
+		left := wrap(textOf(fields[i]), col)
+		var right []string
+		if i+1 < len(fields) {
+			right = wrap(textOf(fields[i+1]), col)
+		}
+		for j := 0; j < max(len(left), len(right)); j++ {
+			var l, r string
+			if j < len(left) {
+				l = left[j]
+			}
+			if j < len(right) {
+				r = right[j]
+			}
+			lines = append(lines, strings.TrimRight(l+strings.Repeat(" ", col-utf8.RuneCountInString(l)+2)+r, " "))
+		}
+	}
+	return lines
+}
+
+// textOf returns the display text of a text object.
+func textOf(raw any) string {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return ""
+	}
+	text, _ := obj["text"].(string)
+	if obj["type"] == "mrkdwn" {
+		return mrkdwn.ToMarkdown(text)
+	}
+	return text
+}
+
+func listOf(raw any) []any {
+	list, _ := raw.([]any)
+	return list
+}
+
+func elementLabel(el map[string]any) string {
+	label := textOf(el["text"])
+	if label == "" {
+		label = textOf(el["placeholder"])
+	}
+	switch elType, _ := el["type"].(string); {
+	case elType == "button":
+		if el["style"] == "danger" {
+			return "[! " + label + " ]"
+		}
+		if el["style"] == "primary" {
+			return "[* " + label + " ]"
+		}
+		return "[ " + label + " ]"
+	case elType == "image":
+		return imageLabel(el)
+	case strings.Contains(elType, "select"):
+		if label == "" {
+			label = "Select"
+		}
+		return "[ " + label + " ▾ ]"
+	case elType == "overflow":
+		return "[ ⋯ ]"
+	case strings.Contains(elType, "picker"):
+		if label == "" {
+			label = strings.ReplaceAll(elType, "_", " ")
+		}
+		return "[ " + label + " ]"
+	default:
+		return "[" + elType + "]"
+	}
+}
+
+func imageLabel(obj map[string]any) string {
+	alt, _ := obj["alt_text"].(string)
+	return "[image: " + alt + "]"
+}
+
+// richText flattens a rich_text block's sections into plain text.
+func richText(block map[string]any) string {
+	var b strings.Builder
+	var walk func(elements []any)
+	walk = func(elements []any) {
+		for _, raw := range elements {
+			el, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch el["type"] {
+			case "text":
+				text, _ := el["text"].(string)
+				b.WriteString(text)
+			case "link":
+				text, _ := el["text"].(string)
+				if text == "" {
+					text, _ = el["url"].(string)
+				}
+				b.WriteString(text)
+			case "user":
+				id, _ := el["user_id"].(string)
+				b.WriteString("@" + id)
+			case "channel":
+				id, _ := el["channel_id"].(string)
+				b.WriteString("#" + id)
+			case "emoji":
+				name, _ := el["name"].(string)
+				b.WriteString(":" + name + ":")
+			case "rich_text_list":
+				for _, item := range listOf(el["elements"]) {
+					b.WriteString("• ")
+					if m, ok := item.(map[string]any); ok {
+						walk(listOf(m["elements"]))
+					}
+					b.WriteString("\n")
+				}
+			case "rich_text_preformatted", "rich_text_quote":
+				walk(listOf(el["elements"]))
+				b.WriteString("\n")
+			default:
+				walk(listOf(el["elements"]))
+			}
+		}
+	}
+	walk(listOf(block["elements"]))
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// wrap breaks text into lines of at most width runes, keeping existing line breaks.
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		line := words[0]
+		for _, word := range words[1:] {
+			if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
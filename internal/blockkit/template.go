@@ -0,0 +1,48 @@
+package blockkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// ParseVars parses key=value pairs as given to --var.
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// Render executes a Block Kit template with vars available as {{.key}}. Referencing a
+// variable that was not supplied is an error. The json function quotes a value as a
+// JSON string, so text containing quotes or newlines stays valid:
+//
+//	{"type": "section", "text": {"type": "mrkdwn", "text": {{json .summary}}}}
+func Render(name, text string, vars map[string]string) ([]byte, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"json": jsonString}).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse blocks template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return nil, fmt.Errorf("render blocks template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func jsonString(v any) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
@@ -0,0 +1,384 @@
+// Package blockkit checks Slack Block Kit JSON against the limits chat.postMessage
+// enforces, so callers get precise errors instead of a bare invalid_blocks.
+//
+// Validation works on the raw JSON rather than slack-go types so every problem can be
+// reported with its path (blocks[2].fields[0].text). It covers block counts, required
+// fields, text object types, and documented length limits for message surfaces.
+package blockkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits documented for message surfaces.
+const (
+	MaxBlocks          = 50
+	MaxViewBlocks      = 100
+	maxBlockID         = 255
+	maxSectionText     = 3000
+	maxSectionFields   = 10
+	maxFieldText       = 2000
+	maxHeaderText      = 150
+	maxContextElements = 10
+	maxActionElements  = 25
+	maxImageURL        = 3000
+	maxAltText         = 2000
+	maxButtonText      = 75
+	maxActionID        = 255
+	maxButtonValue     = 2000
+	maxURL             = 3000
+)
+
+// Problem is one validation failure.
+type Problem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return p.Path + ": " + p.Message
+}
+
+// Problems is a list of validation failures. It implements error.
+type Problems []Problem
+
+func (p Problems) Error() string {
+	parts := make([]string, len(p))
+	for i, problem := range p {
+		parts[i] = problem.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Validate parses data as a Block Kit array, or an object with a "blocks" array (the
+// shape Block Kit Builder exports), and returns the number of blocks and any problems.
+// Malformed JSON is reported as a single problem with its line and column.
+func Validate(data []byte) (int, Problems) {
+	return validate(data, MaxBlocks, "a message")
+}
+
+// ValidateView is Validate for modal and Home tab views, which allow up to
+// MaxViewBlocks blocks.
+func ValidateView(data []byte) (int, Problems) {
+	return validate(data, MaxViewBlocks, "a view")
+}
+
+func validate(data []byte, maxBlocks int, surface string) (int, Problems) {
+	blocks, problem := decode(data)
+	if problem != nil {
+		return 0, Problems{*problem}
+	}
+	v := &validator{blockIDs: map[string]string{}}
+	if len(blocks) == 0 {
+		v.add("blocks", "must contain at least one block")
+	}
+	if len(blocks) > maxBlocks {
+		v.add("blocks", "has %d blocks; %s allows at most %d", len(blocks), surface, maxBlocks)
+	}
+	for i, block := range blocks {
+		v.block(fmt.Sprintf("blocks[%d]", i), block)
+	}
+	return len(blocks), v.problems
+}
+
+// decode unmarshals a block array, or an object with a "blocks" array, into generic values.
+func decode(data []byte) ([]any, *Problem) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, &Problem{Path: "blocks", Message: "input is empty"}
+	}
+	if trimmed[0] == '{' {
+		var payload struct {
+			Blocks json.RawMessage `json:"blocks"`
+		}
+		if err := json.Unmarshal(trimmed, &payload); err != nil {
+			problem := syntaxProblem(data, err)
+			return nil, &problem
+		}
+		if payload.Blocks == nil {
+			return nil, &Problem{Path: "blocks", Message: `object input needs a "blocks" array`}
+		}
+		trimmed = payload.Blocks
+	}
+
+	var blocks []any
+	if err := json.Unmarshal(trimmed, &blocks); err != nil {
+		problem := syntaxProblem(data, err)
+		return nil, &problem
+	}
+	return blocks, nil
+}
+
+// syntaxProblem converts a JSON decoding error into a problem with a line and column.
+func syntaxProblem(data []byte, err error) Problem {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, col := position(data, syntaxErr.Offset)
+		return Problem{Path: "blocks", Message: fmt.Sprintf("invalid JSON at line %d, column %d: %v", line, col, err)}
+	case errors.As(err, &typeErr):
+		return Problem{Path: "blocks", Message: fmt.Sprintf("expected a JSON array of blocks, got %s", typeErr.Value)}
+	default:
+		return Problem{Path: "blocks", Message: "invalid JSON: " + err.Error()}
+	}
+}
+
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+type validator struct {
+	problems Problems
+	blockIDs map[string]string
+}
+
+func (v *validator) add(path, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) block(path string, raw any) {
+	block, ok := raw.(map[string]any)
+	if !ok {
+		v.add(path, "must be an object")
+		return
+	}
+	blockType, _ := block["type"].(string)
+	if blockType == "" {
+		v.add(path+".type", "is required")
+		return
+	}
+	if id, ok := block["block_id"]; ok {
+		if s, ok := v.str(path+".block_id", id); ok {
+			v.maxLen(path+".block_id", s, maxBlockID)
+			if first, dup := v.blockIDs[s]; dup {
+				v.add(path+".block_id", "%q is already used by %s", s, first)
+			} else {
+				v.blockIDs[s] = path
+			}
+		}
+	}
+
+	switch blockType {
+	case "section":
+		text, hasText := block["text"]
+		fields, hasFields := block["fields"]
+		if !hasText && !hasFields {
+			v.add(path, "section needs text or fields")
+		}
+		if hasText {
+			v.textObject(path+".text", text, "", maxSectionText)
+		}
+		if hasFields {
+			list, ok := fields.([]any)
+			switch {
+			case !ok:
+				v.add(path+".fields", "must be an array")
+			case len(list) == 0:
+				v.add(path+".fields", "must not be empty")
+			case len(list) > maxSectionFields:
+				v.add(path+".fields", "has %d items; at most %d allowed", len(list), maxSectionFields)
+			}
+			for i, field := range list {
+				v.textObject(fmt.Sprintf("%s.fields[%d]", path, i), field, "", maxFieldText)
+			}
+		}
+		if accessory, ok := block["accessory"]; ok {
+			v.element(path+".accessory", accessory)
+		}
+	case "header":
+		text, ok := block["text"]
+		if !ok {
+			v.add(path+".text", "is required")
+			break
+		}
+		v.textObject(path+".text", text, "plain_text", maxHeaderText)
+	case "divider":
+	case "context":
+		list := v.elements(path, block, maxContextElements)
+		for i, el := range list {
+			elPath := fmt.Sprintf("%s.elements[%d]", path, i)
+			if m, ok := el.(map[string]any); ok && m["type"] == "image" {
+				v.image(elPath, m)
+				continue
+			}
+			v.textObject(elPath, el, "", maxSectionText)
+		}
+	case "actions":
+		for i, el := range v.elements(path, block, maxActionElements) {
+			v.element(fmt.Sprintf("%s.elements[%d]", path, i), el)
+		}
+	case "image":
+		v.image(path, block)
+		if title, ok := block["title"]; ok {
+			v.textObject(path+".title", title, "plain_text", maxAltText)
+		}
+	case "rich_text":
+		v.elements(path, block, 0)
+	case "input":
+		if label, ok := block["label"]; ok {
+			v.textObject(path+".label", label, "plain_text", 2000)
+		} else {
+			v.add(path+".label", "is required")
+		}
+		if el, ok := block["element"]; ok {
+			v.element(path+".element", el)
+		} else {
+			v.add(path+".element", "is required")
+		}
+	case "file", "video":
+		// Validated by Slack; these need app-specific IDs and URLs.
+	default:
+		v.add(path+".type", "unknown block type %q", blockType)
+	}
+}
+
+// elements checks a block's elements array and returns it. max of 0 means unlimited.
+func (v *validator) elements(path string, block map[string]any, max int) []any {
+	raw, ok := block["elements"]
+	if !ok {
+		v.add(path+".elements", "is required")
+		return nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		v.add(path+".elements", "must be an array")
+		return nil
+	}
+	if len(list) == 0 {
+		v.add(path+".elements", "must not be empty")
+	}
+	if max > 0 && len(list) > max {
+		v.add(path+".elements", "has %d items; at most %d allowed", len(list), max)
+	}
+	return list
+}
+
+// textObject checks a {"type": ..., "text": ...} object. want restricts the type when set.
+func (v *validator) textObject(path string, raw any, want string, max int) {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		v.add(path, "must be a text object like {\"type\": \"mrkdwn\", \"text\": \"...\"}")
+		return
+	}
+	textType, _ := obj["type"].(string)
+	switch {
+	case textType == "":
+		v.add(path+".type", "is required (plain_text or mrkdwn)")
+	case want != "" && textType != want:
+		v.add(path+".type", "must be %s, got %q", want, textType)
+	case textType != "plain_text" && textType != "mrkdwn":
+		v.add(path+".type", "must be plain_text or mrkdwn, got %q", textType)
+	}
+	if _, ok := obj["emoji"]; ok && textType == "mrkdwn" {
+		v.add(path+".emoji", "is only valid on plain_text")
+	}
+	text, ok := obj["text"]
+	if !ok {
+		v.add(path+".text", "is required")
+		return
+	}
+	if s, ok := v.str(path+".text", text); ok {
+		if strings.TrimSpace(s) == "" {
+			v.add(path+".text", "must not be empty")
+		}
+		v.maxLen(path+".text", s, max)
+	}
+}
+
+func (v *validator) image(path string, obj map[string]any) {
+	_, hasURL := obj["image_url"]
+	_, hasFile := obj["slack_file"]
+	if !hasURL && !hasFile {
+		v.add(path+".image_url", "is required (or slack_file)")
+	}
+	if hasURL {
+		if s, ok := v.str(path+".image_url", obj["image_url"]); ok {
+			v.maxLen(path+".image_url", s, maxImageURL)
+		}
+	}
+	alt, ok := obj["alt_text"]
+	if !ok {
+		v.add(path+".alt_text", "is required")
+		return
+	}
+	if s, ok := v.str(path+".alt_text", alt); ok {
+		v.maxLen(path+".alt_text", s, maxAltText)
+	}
+}
+
+// element checks an interactive element (button, select, ...) or an image accessory.
+func (v *validator) element(path string, raw any) {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		v.add(path, "must be an object")
+		return
+	}
+	elType, _ := obj["type"].(string)
+	if elType == "" {
+		v.add(path+".type", "is required")
+		return
+	}
+	if id, ok := obj["action_id"]; ok {
+		if s, ok := v.str(path+".action_id", id); ok {
+			v.maxLen(path+".action_id", s, maxActionID)
+		}
+	}
+	switch elType {
+	case "image":
+		v.image(path, obj)
+	case "button":
+		if text, ok := obj["text"]; ok {
+			v.textObject(path+".text", text, "plain_text", maxButtonText)
+		} else {
+			v.add(path+".text", "is required")
+		}
+		if value, ok := obj["value"]; ok {
+			if s, ok := v.str(path+".value", value); ok {
+				v.maxLen(path+".value", s, maxButtonValue)
+			}
+		}
+		if url, ok := obj["url"]; ok {
+			if s, ok := v.str(path+".url", url); ok {
+				v.maxLen(path+".url", s, maxURL)
+			}
+		}
+		if style, ok := obj["style"]; ok && style != "primary" && style != "danger" {
+			v.add(path+".style", "must be primary or danger, got %v", style)
+		}
+	case "static_select", "multi_static_select":
+		_, hasOptions := obj["options"]
+		_, hasGroups := obj["option_groups"]
+		if !hasOptions && !hasGroups {
+			v.add(path+".options", "is required (or option_groups)")
+		}
+	}
+	if placeholder, ok := obj["placeholder"]; ok {
+		v.textObject(path+".placeholder", placeholder, "plain_text", 150)
+	}
+}
+
+func (v *validator) str(path string, raw any) (string, bool) {
+	s, ok := raw.(string)
+	if !ok {
+		v.add(path, "must be a string")
+	}
+	return s, ok
+}
+
+func (v *validator) maxLen(path, s string, max int) {
+	if n := utf8.RuneCountInString(s); n > max {
+		v.add(path, "is %d characters; the limit is %d", n, max)
+	}
+}
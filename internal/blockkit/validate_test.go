@@ -0,0 +1,157 @@
+package blockkit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	long := strings.Repeat("a", 3001)
+	tests := []struct {
+		name   string
+		input  string
+		count  int
+		paths  []string
+		substr string
+	}{
+		{
+			name:  "valid message",
+			input: `[{"type":"header","text":{"type":"plain_text","text":"Deploy"}},{"type":"divider"},{"type":"section","text":{"type":"mrkdwn","text":"*done*"},"accessory":{"type":"button","text":{"type":"plain_text","text":"Open"},"url":"https://example.com"}}]`,
+			count: 3,
+		},
+		{
+			name:  "builder export object",
+			input: `{"blocks":[{"type":"section","fields":[{"type":"mrkdwn","text":"a"},{"type":"plain_text","text":"b"}]}]}`,
+			count: 1,
+		},
+		{
+			name:  "header must be plain_text",
+			input: `[{"type":"header","text":{"type":"mrkdwn","text":"Deploy"}}]`,
+			count: 1,
+			paths: []string{"blocks[0].text.type"},
+		},
+		{
+			name:   "section text too long",
+			input:  `[{"type":"section","text":{"type":"mrkdwn","text":"` + long + `"}}]`,
+			count:  1,
+			paths:  []string{"blocks[0].text.text"},
+			substr: "3001 characters",
+		},
+		{
+			name:  "missing required fields",
+			input: `[{"type":"section"},{"type":"image","image_url":"https://example.com/a.png"},{"text":"x"}]`,
+			count: 3,
+			paths: []string{"blocks[0]", "blocks[1].alt_text", "blocks[2].type"},
+		},
+		{
+			name:  "duplicate block_id and unknown type",
+			input: `[{"type":"divider","block_id":"a"},{"type":"divider","block_id":"a"},{"type":"carousel"}]`,
+			count: 3,
+			paths: []string{"blocks[1].block_id", "blocks[2].type"},
+		},
+		{
+			name:   "syntax error has position",
+			input:  "[\n  {\"type\": \"divider\"},\n  {\"type\" \"section\"}\n]",
+			paths:  []string{"blocks"},
+			substr: "line 3",
+		},
+		{
+			name:   "not an array",
+			input:  `"hello"`,
+			paths:  []string{"blocks"},
+			substr: "expected a JSON array",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, problems := Validate([]byte(tt.input))
+			if count != tt.count {
+				t.Errorf("count = %d, want %d", count, tt.count)
+			}
+			var paths []string
+			for _, p := range problems {
+				paths = append(paths, p.Path)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.paths) {
+				t.Errorf("problem paths = %v, want %v (%v)", paths, tt.paths, problems)
+			}
+			if tt.substr != "" && !strings.Contains(problems.Error(), tt.substr) {
+				t.Errorf("problems %q do not mention %q", problems.Error(), tt.substr)
+			}
+		})
+	}
+}
+
+func TestValidateBlockLimit(t *testing.T) {
+	blocks := strings.TrimSuffix(strings.Repeat(`{"type":"divider"},`, MaxBlocks+1), ",")
+	count, problems := Validate([]byte("[" + blocks + "]"))
+	if count != MaxBlocks+1 || len(problems) != 1 || !strings.Contains(problems[0].Message, "at most 50") {
+		t.Fatalf("Validate = %d, %v; want one block-count problem", count, problems)
+	}
+	if _, problems := ValidateView([]byte("[" + blocks + "]")); len(problems) != 0 {
+		t.Fatalf("ValidateView(%d blocks) = %v; want no problems", MaxBlocks+1, problems)
+	}
+}
+
+func TestRender(t *testing.T) {
+	vars, err := ParseVars([]string{"title=Deploy", `summary=Shipped "v2"` + "\nall green"})
+	if err != nil {
+		t.Fatalf("ParseVars: %v", err)
+	}
+	out, err := Render("t", `[{"type":"header","text":{"type":"plain_text","text":"{{.title}}"}},{"type":"section","text":{"type":"mrkdwn","text":{{json .summary}}}}]`, vars)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if count, problems := Validate(out); count != 2 || len(problems) > 0 {
+		t.Fatalf("rendered blocks invalid: %d %v\n%s", count, problems, out)
+	}
+	if !strings.Contains(string(out), `"Shipped \"v2\"\nall green"`) {
+		t.Errorf("summary not JSON-quoted: %s", out)
+	}
+
+	if _, err := Render("t", `{{.missing}}`, vars); err == nil {
+		t.Error("expected error for a missing variable")
+	}
+	if _, err := ParseVars([]string{"novalue"}); err == nil {
+		t.Error("expected error for a --var without =")
+	}
+}
+
+func TestPreview(t *testing.T) {
+	input := `{"blocks":[
+		{"type":"header","text":{"type":"plain_text","text":"Deploy"}},
+		{"type":"section","text":{"type":"mrkdwn","text":"*Done* see <https://example.com|notes>"},"accessory":{"type":"button","text":{"type":"plain_text","text":"Open"}}},
+		{"type":"section","fields":[{"type":"mrkdwn","text":"Env"},{"type":"mrkdwn","text":"prod"}]},
+		{"type":"divider"},
+		{"type":"actions","elements":[{"type":"button","style":"primary","text":{"type":"plain_text","text":"Approve"}},{"type":"static_select","placeholder":{"type":"plain_text","text":"Pick"}}]},
+		{"type":"context","elements":[{"type":"mrkdwn","text":"by bot"},{"type":"image","image_url":"https://example.com/a.png","alt_text":"logo"}]}
+	]}`
+	lines, err := Preview([]byte(input), 40)
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	want := []string{
+		"DEPLOY",
+		"======",
+		"",
+		"**Done** see",
+		"[notes](https://example.com)",
+		"  [ Open ]",
+		"",
+		"Env                  prod",
+		"",
+		strings.Repeat("─", 40),
+		"",
+		"[* Approve ] [ Pick ▾ ]",
+		"",
+		"  by bot · [image: logo]",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("preview:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := Preview([]byte("{"), 40); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+}
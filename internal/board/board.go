@@ -0,0 +1,159 @@
+// Package board groups channel messages into kanban columns by their reactions, so a
+// channel where people mark requests with :eyes: or :white_check_mark: reads as a
+// workflow board.
+package board
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// UntaggedColumn holds messages with none of the columns' reactions.
+const UntaggedColumn = "untagged"
+
+// Column maps a reaction to a workflow stage.
+type Column struct {
+	Name string `json:"name"`
+	// Emoji is the reaction name without colons, e.g. "eyes".
+	Emoji string `json:"emoji"`
+}
+
+// ParseColumns parses ":eyes:=triage,:hammer_and_wrench:=in-progress". Columns are
+// in workflow order; a message is placed in the last column whose reaction it has.
+// A column without "=name" is named after its emoji.
+func ParseColumns(spec string) ([]Column, error) {
+	var columns []Column
+	seen := map[string]bool{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		emoji, name, _ := strings.Cut(item, "=")
+		emoji = NormalizeReaction(emoji)
+		name = strings.TrimSpace(name)
+		if emoji == "" {
+			return nil, fmt.Errorf("column %q has no emoji", item)
+		}
+		if name == "" {
+			name = emoji
+		}
+		if name == UntaggedColumn {
+			return nil, fmt.Errorf("column name %q is reserved", UntaggedColumn)
+		}
+		if seen[emoji] {
+			return nil, fmt.Errorf("emoji :%s: is used by more than one column", emoji)
+		}
+		seen[emoji] = true
+		columns = append(columns, Column{Name: name, Emoji: emoji})
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns in %q; want \":eyes:=triage,:white_check_mark:=done\"", spec)
+	}
+	return columns, nil
+}
+
+// NormalizeReaction strips colons and skin tones, so ":+1::skin-tone-3:" and "+1"
+// are the same reaction.
+func NormalizeReaction(name string) string {
+	name = strings.TrimSpace(name)
+	name, _, _ = strings.Cut(strings.Trim(name, ":"), "::")
+	return name
+}
+
+// Card is one message on the board.
+type Card struct {
+	TS         string         `json:"ts"`
+	User       string         `json:"user,omitempty"`
+	UserID     string         `json:"user_id,omitempty"`
+	Text       string         `json:"text"`
+	Reactions  map[string]int `json:"reactions,omitempty"`
+	ReplyCount int            `json:"reply_count,omitempty"`
+}
+
+// Lane is a column with its cards, oldest first.
+type Lane struct {
+	Column
+	Count int    `json:"count"`
+	Cards []Card `json:"cards"`
+}
+
+// Result is the output of board show.
+type Result struct {
+	Channel   string `json:"channel"`
+	ChannelID string `json:"channel_id"`
+	// Source is "history" or the watch --track-state file the cards came from.
+	Source  string `json:"source"`
+	Scanned int    `json:"scanned"`
+	Lanes   []Lane `json:"columns"`
+}
+
+// Build places cards in the last column whose reaction they carry. With untagged,
+// cards matching no column are kept in a leading "untagged" lane; otherwise they are
+// dropped.
+func Build(columns []Column, cards []Card, untagged bool) []Lane {
+	lanes := make([]Lane, 0, len(columns)+1)
+	if untagged {
+		lanes = append(lanes, Lane{Column: Column{Name: UntaggedColumn}, Cards: []Card{}})
+	}
+	offset := len(lanes)
+	for _, c := range columns {
+		lanes = append(lanes, Lane{Column: c, Cards: []Card{}})
+	}
+	sorted := append([]Card(nil), cards...)
+	sort.SliceStable(sorted, func(i, j int) bool { return slack.CompareTS(sorted[i].TS, sorted[j].TS) < 0 })
+	for _, card := range sorted {
+		lane := -1
+		for i, c := range columns {
+			if card.Reactions[c.Emoji] > 0 {
+				lane = offset + i
+			}
+		}
+		if lane < 0 {
+			if !untagged {
+				continue
+			}
+			lane = 0
+		}
+		lanes[lane].Cards = append(lanes[lane].Cards, card)
+		lanes[lane].Count++
+	}
+	return lanes
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r Result) Lines() []string {
+	var counts []string
+	for _, lane := range r.Lanes {
+		counts = append(counts, fmt.Sprintf("%d %s", lane.Count, lane.Name))
+	}
+	lines := []string{fmt.Sprintf("%s: %s (%d messages scanned)", r.Channel, strings.Join(counts, ", "), r.Scanned)}
+
+	width := len("COLUMN")
+	for _, lane := range r.Lanes {
+		width = max(width, len(lane.Name))
+	}
+	header := fmt.Sprintf("%-*s  %-17s  %-16s  %s", width, "COLUMN", "TS", "AUTHOR", "MESSAGE")
+	rows := []string{}
+	for _, lane := range r.Lanes {
+		for _, card := range lane.Cards {
+			text, _, _ := strings.Cut(card.Text, "\n")
+			if card.ReplyCount > 0 {
+				text += fmt.Sprintf(" (%d replies)", card.ReplyCount)
+			}
+			author := card.User
+			if author == "" {
+				author = card.UserID
+			}
+			rows = append(rows, fmt.Sprintf("%-*s  %-17s  %-16s  %s", width, lane.Name, card.TS, output.Truncate(author, 16), output.Truncate(text, 60)))
+		}
+	}
+	if len(rows) == 0 {
+		return append(lines, "No messages on the board")
+	}
+	return append(append(lines, header), rows...)
+}
@@ -0,0 +1,72 @@
+package board
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseColumns(t *testing.T) {
+	got, err := ParseColumns(":eyes:=triage, :hammer_and_wrench:=in-progress,white_check_mark")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Column{{Name: "triage", Emoji: "eyes"}, {Name: "in-progress", Emoji: "hammer_and_wrench"}, {Name: "white_check_mark", Emoji: "white_check_mark"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseColumns = %+v, want %+v", got, want)
+	}
+
+	for spec, wantErr := range map[string]string{
+		"":                           "no columns",
+		"=triage":                    "no emoji",
+		":eyes:=a,:eyes:=b":          "more than one column",
+		":eyes:=untagged":            "reserved",
+		":eyes::skin-tone-2:=a,eyes": "more than one column",
+	} {
+		if _, err := ParseColumns(spec); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("ParseColumns(%q) err = %v, want %q", spec, err, wantErr)
+		}
+	}
+}
+
+func TestNormalizeReaction(t *testing.T) {
+	for in, want := range map[string]string{":+1::skin-tone-3:": "+1", "eyes": "eyes", " :tada: ": "tada"} {
+		if got := NormalizeReaction(in); got != want {
+			t.Errorf("NormalizeReaction(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBuildPlacesCardsInFurthestColumn(t *testing.T) {
+	columns := []Column{{Name: "triage", Emoji: "eyes"}, {Name: "done", Emoji: "white_check_mark"}}
+	cards := []Card{
+		{TS: "3.0", Reactions: map[string]int{"eyes": 1, "white_check_mark": 1}},
+		{TS: "1.0", Reactions: map[string]int{"eyes": 2}},
+		{TS: "2.0", Reactions: map[string]int{"tada": 1}},
+		{TS: "0.5", Reactions: map[string]int{"eyes": 1}},
+	}
+	lanes := Build(columns, cards, true)
+	names := func(l Lane) []string {
+		var out []string
+		for _, c := range l.Cards {
+			out = append(out, c.TS)
+		}
+		return out
+	}
+	if len(lanes) != 3 || lanes[0].Name != UntaggedColumn {
+		t.Fatalf("lanes = %+v", lanes)
+	}
+	if got := names(lanes[0]); !reflect.DeepEqual(got, []string{"2.0"}) {
+		t.Errorf("untagged = %v", got)
+	}
+	if got := names(lanes[1]); !reflect.DeepEqual(got, []string{"0.5", "1.0"}) || lanes[1].Count != 2 {
+		t.Errorf("triage = %v", got)
+	}
+	if got := names(lanes[2]); !reflect.DeepEqual(got, []string{"3.0"}) {
+		t.Errorf("done = %v", got)
+	}
+
+	if lanes := Build(columns, cards, false); len(lanes) != 2 || lanes[0].Name != "triage" {
+		t.Errorf("without untagged: %+v", lanes)
+	}
+}
@@ -0,0 +1,30 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	columns := []Column{{Name: "triage", Emoji: "eyes"}, {Name: "in-progress", Emoji: "hammer_and_wrench"}, {Name: "done", Emoji: "white_check_mark"}}
+	cards := []Card{
+		{TS: "1705312365.000100", User: "@alice", UserID: "U1", Text: "Login fails on Safari\nSteps: open /login", Reactions: map[string]int{"eyes": 1}},
+		{TS: "1705312400.000200", User: "@bob", UserID: "U2", Text: "Export times out for large channels", Reactions: map[string]int{"eyes": 1, "hammer_and_wrench": 1}, ReplyCount: 3},
+		{TS: "1705312500.000300", UserID: "B1", Text: "Nightly build failed", Reactions: map[string]int{"white_check_mark": 2}},
+		{TS: "1705312600.000400", User: "@carol", UserID: "U3", Text: "Can someone add me to #deploys?"},
+	}
+	tests := []struct {
+		name string
+		p    output.Printable
+	}{
+		{"Result", Result{Channel: "#requests", ChannelID: "C123ABC", Source: "history", Scanned: 4, Lanes: Build(columns, cards, true)}},
+		{"Result_empty", Result{Channel: "#requests", ChannelID: "C123ABC", Source: "board.json", Lanes: Build(columns, nil, false)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
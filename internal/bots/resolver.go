@@ -0,0 +1,128 @@
+// Package bots provides cached bot profile lookups.
+package bots
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/cache"
+)
+
+// BotClient defines the Slack operations needed for bot lookups.
+type BotClient interface {
+	GetBotInfo(ctx context.Context, botID string) (*slackapi.Bot, error)
+}
+
+// CachedBot holds the subset of bot info we persist.
+type CachedBot struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	AppID   string `json:"app_id,omitempty"`
+	UserID  string `json:"user_id,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// Resolver resolves bot IDs (B...) to profiles using a disk cache. Slack has no
+// method to list bots, so each unknown ID costs one bots.info call and is then
+// added to the cache; IDs that fail to resolve are not retried within a session.
+type Resolver struct {
+	client BotClient
+	cache  *cache.Store
+
+	mu     sync.Mutex
+	bots   map[string]CachedBot
+	failed map[string]struct{}
+}
+
+// NewResolver creates a Resolver with no cache (API-only).
+func NewResolver(client BotClient) *Resolver {
+	return &Resolver{client: client}
+}
+
+// NewCachedResolver creates a Resolver backed by the given cache store.
+func NewCachedResolver(client BotClient, store *cache.Store) *Resolver {
+	return &Resolver{client: client, cache: store}
+}
+
+// RefreshCache clears the bot cache.
+func (r *Resolver) RefreshCache(ctx context.Context) error {
+	r.mu.Lock()
+	r.bots = nil
+	r.failed = nil
+	r.mu.Unlock()
+	if r.cache != nil {
+		if err := r.cache.Expire(cache.CacheKeyBots); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lookup returns the profile for a bot ID, fetching and caching it on first use.
+func (r *Resolver) Lookup(ctx context.Context, botID string) (CachedBot, bool) {
+	botID = strings.TrimSpace(botID)
+	if botID == "" {
+		return CachedBot{}, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.bots == nil {
+		r.bots = r.loadBots()
+	}
+	if bot, ok := r.bots[botID]; ok {
+		return bot, true
+	}
+	if _, failed := r.failed[botID]; failed || r.client == nil {
+		return CachedBot{}, false
+	}
+
+	info, err := r.client.GetBotInfo(ctx, botID)
+	if err != nil || info == nil || info.ID == "" {
+		if r.failed == nil {
+			r.failed = map[string]struct{}{}
+		}
+		r.failed[botID] = struct{}{}
+		return CachedBot{}, false
+	}
+
+	bot := toCachedBot(info)
+	r.bots[botID] = bot
+	if r.cache != nil {
+		_ = r.cache.Save(cache.CacheKeyBots, r.bots)
+	}
+	return bot, true
+}
+
+// GetName returns the bot's display name, or the ID when it cannot be resolved.
+func (r *Resolver) GetName(ctx context.Context, botID string) string {
+	if bot, ok := r.Lookup(ctx, botID); ok && bot.Name != "" {
+		return bot.Name
+	}
+	return botID
+}
+
+// loadBots returns the cached bot map from disk, or an empty map.
+func (r *Resolver) loadBots() map[string]CachedBot {
+	if r.cache != nil {
+		var cached map[string]CachedBot
+		if found, err := r.cache.Load(cache.CacheKeyBots, &cached); err == nil && found && cached != nil {
+			return cached
+		}
+	}
+	return map[string]CachedBot{}
+}
+
+func toCachedBot(b *slackapi.Bot) CachedBot {
+	return CachedBot{
+		ID:      b.ID,
+		Name:    b.Name,
+		AppID:   b.AppID,
+		UserID:  b.UserID,
+		Deleted: b.Deleted,
+	}
+}
@@ -0,0 +1,57 @@
+package bots
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/cache"
+)
+
+type mockBotClient struct {
+	bots    map[string]*slackapi.Bot
+	lookups int
+}
+
+func (m *mockBotClient) GetBotInfo(ctx context.Context, botID string) (*slackapi.Bot, error) {
+	m.lookups++
+	b, ok := m.bots[botID]
+	if !ok {
+		return nil, errors.New("bot_not_found")
+	}
+	return b, nil
+}
+
+func TestLookupCachesProfiles(t *testing.T) {
+	client := &mockBotClient{bots: map[string]*slackapi.Bot{
+		"B1": {ID: "B1", Name: "deploybot", AppID: "A1"},
+	}}
+	store := cache.New(t.TempDir(), time.Hour)
+	resolver := NewCachedResolver(client, store)
+	ctx := context.Background()
+
+	bot, ok := resolver.Lookup(ctx, "B1")
+	if !ok || bot.Name != "deploybot" || bot.AppID != "A1" {
+		t.Fatalf("Lookup(B1) = %+v, %v", bot, ok)
+	}
+	if _, ok := resolver.Lookup(ctx, "B1"); !ok || client.lookups != 1 {
+		t.Fatalf("second lookup hit the API: lookups = %d", client.lookups)
+	}
+
+	if got := resolver.GetName(ctx, "B9"); got != "B9" {
+		t.Fatalf("GetName(unknown) = %q, want the ID", got)
+	}
+	resolver.GetName(ctx, "B9")
+	if client.lookups != 2 {
+		t.Fatalf("failed lookup was retried: lookups = %d", client.lookups)
+	}
+
+	// A fresh resolver over the same store reads the profile from disk.
+	fresh := NewCachedResolver(&mockBotClient{}, store)
+	if got := fresh.GetName(ctx, "B1"); got != "deploybot" {
+		t.Fatalf("cached GetName(B1) = %q", got)
+	}
+}
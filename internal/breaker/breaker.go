@@ -0,0 +1,231 @@
+// Package breaker keeps per-method circuit breakers for Slack API calls, so a batch job
+// fails fast on an endpoint that keeps failing instead of grinding through it.
+package breaker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StateVersion is the current state file format.
+const StateVersion = 1
+
+// Breaker states reported by Statuses.
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half_open"
+)
+
+// ErrOpen is returned, wrapped in an *OpenError, for calls refused by an open breaker.
+var ErrOpen = errors.New("circuit breaker open")
+
+// OpenError describes a call refused by an open breaker.
+type OpenError struct {
+	Method    string
+	Failures  int
+	LastError string
+	RetryAt   time.Time
+}
+
+func (e *OpenError) Error() string {
+	msg := fmt.Sprintf("%s: %v after %d consecutive failures", e.Method, ErrOpen, e.Failures)
+	if e.LastError != "" {
+		msg += fmt.Sprintf(" (last: %s)", e.LastError)
+	}
+	return msg + fmt.Sprintf("; next attempt allowed at %s", e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *OpenError) Unwrap() error {
+	return ErrOpen
+}
+
+// Method is the recorded failure streak of one API method.
+type Method struct {
+	Failures  int        `json:"failures"`
+	LastError string     `json:"last_error,omitempty"`
+	OpenedAt  *time.Time `json:"opened_at,omitempty"`
+	RetryAt   *time.Time `json:"retry_at,omitempty"`
+}
+
+// Status is a method's breaker as reported by health api.
+type Status struct {
+	Method    string     `json:"method"`
+	State     string     `json:"state"`
+	Failures  int        `json:"failures"`
+	LastError string     `json:"last_error,omitempty"`
+	OpenedAt  *time.Time `json:"opened_at,omitempty"`
+	RetryAt   *time.Time `json:"retry_at,omitempty"`
+}
+
+// Breaker opens a method after Threshold consecutive failures and refuses calls to it
+// until Cooldown has passed. The next call after that is a trial: success closes the
+// breaker, failure opens it again. State is kept in a file so separate invocations of
+// the CLI share it; concurrent processes may overwrite each other's updates.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	path      string
+	now       func() time.Time
+
+	mu      sync.Mutex
+	methods map[string]*Method
+}
+
+type stateFile struct {
+	Version int                `json:"version"`
+	Methods map[string]*Method `json:"methods"`
+}
+
+// Load reads the breaker state at path, or starts empty when it does not exist.
+func Load(path string, threshold int, cooldown time.Duration) (*Breaker, error) {
+	b := &Breaker{threshold: threshold, cooldown: cooldown, path: path, now: time.Now, methods: map[string]*Method{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read breaker state: %w", err)
+	}
+	var state stateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse breaker state %s: %w", path, err)
+	}
+	for method, m := range state.Methods {
+		if m != nil {
+			b.methods[method] = m
+		}
+	}
+	return b, nil
+}
+
+// Path returns the file the state is saved to.
+func (b *Breaker) Path() string {
+	return b.path
+}
+
+// Threshold returns the number of consecutive failures that opens a breaker.
+func (b *Breaker) Threshold() int {
+	return b.threshold
+}
+
+// Cooldown returns how long an open breaker refuses calls.
+func (b *Breaker) Cooldown() time.Duration {
+	return b.cooldown
+}
+
+// Allow returns an *OpenError when method's breaker is open and its cooldown has not
+// passed. A threshold of 0 disables the breaker.
+func (b *Breaker) Allow(method string) error {
+	if b.threshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m := b.methods[method]
+	if m == nil || m.RetryAt == nil || !b.now().Before(*m.RetryAt) {
+		return nil
+	}
+	return &OpenError{Method: method, Failures: m.Failures, LastError: m.LastError, RetryAt: *m.RetryAt}
+}
+
+// Success clears method's failure streak.
+func (b *Breaker) Success(method string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.methods[method]; !ok {
+		return nil
+	}
+	delete(b.methods, method)
+	return b.save()
+}
+
+// Failure records a failed call to method, opening its breaker once the streak reaches
+// the threshold.
+func (b *Breaker) Failure(method, reason string) error {
+	if b.threshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m := b.methods[method]
+	if m == nil {
+		m = &Method{}
+		b.methods[method] = m
+	}
+	m.Failures++
+	m.LastError = reason
+	if m.Failures >= b.threshold {
+		now := b.now().UTC()
+		retryAt := now.Add(b.cooldown)
+		m.OpenedAt, m.RetryAt = &now, &retryAt
+	}
+	return b.save()
+}
+
+// Reset closes method's breaker, or every breaker when method is empty.
+func (b *Breaker) Reset(method string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if method == "" {
+		b.methods = map[string]*Method{}
+	} else {
+		delete(b.methods, method)
+	}
+	return b.save()
+}
+
+// Statuses returns every method with a recorded failure, open breakers first.
+func (b *Breaker) Statuses() []Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	statuses := make([]Status, 0, len(b.methods))
+	for method, m := range b.methods {
+		state := StateClosed
+		if m.RetryAt != nil {
+			state = StateOpen
+			if !now.Before(*m.RetryAt) {
+				state = StateHalfOpen
+			}
+		}
+		statuses = append(statuses, Status{Method: method, State: state, Failures: m.Failures, LastError: m.LastError, OpenedAt: m.OpenedAt, RetryAt: m.RetryAt})
+	}
+	rank := map[string]int{StateOpen: 0, StateHalfOpen: 1, StateClosed: 2}
+	sort.Slice(statuses, func(i, j int) bool {
+		if rank[statuses[i].State] != rank[statuses[j].State] {
+			return rank[statuses[i].State] < rank[statuses[j].State]
+		}
+		return statuses[i].Method < statuses[j].Method
+	})
+	return statuses
+}
+
+// save atomically writes the state file. The caller holds b.mu.
+func (b *Breaker) save() error {
+	if dir := filepath.Dir(b.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("create breaker state dir: %w", err)
+		}
+	}
+	data, err := json.MarshalIndent(stateFile{Version: StateVersion, Methods: b.methods}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal breaker state: %w", err)
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write breaker state tmp: %w", err)
+	}
+	if err := os.Rename(tmp, b.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename breaker state tmp: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package breaker
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensAndRecovers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breakers.json")
+	b, err := Load(path, 3, time.Minute)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := b.Failure("chat.postMessage", "502 Bad Gateway"); err != nil {
+			t.Fatalf("Failure() error = %v", err)
+		}
+	}
+	if err := b.Allow("chat.postMessage"); err != nil {
+		t.Fatalf("breaker opened below threshold: %v", err)
+	}
+	if err := b.Failure("chat.postMessage", "502 Bad Gateway"); err != nil {
+		t.Fatalf("Failure() error = %v", err)
+	}
+
+	// A new process sees the open breaker.
+	reloaded, err := Load(path, 3, time.Minute)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	reloaded.now = b.now
+	err = reloaded.Allow("chat.postMessage")
+	var openErr *OpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrOpen) || openErr.Failures != 3 {
+		t.Fatalf("Allow() = %v, want open breaker after 3 failures", err)
+	}
+	if err := reloaded.Allow("conversations.history"); err != nil {
+		t.Fatalf("other methods must stay closed: %v", err)
+	}
+	if got := reloaded.Statuses(); len(got) != 1 || got[0].State != StateOpen {
+		t.Fatalf("Statuses() = %+v", got)
+	}
+
+	now = now.Add(time.Minute)
+	if err := reloaded.Allow("chat.postMessage"); err != nil {
+		t.Fatalf("trial call refused after cooldown: %v", err)
+	}
+	if got := reloaded.Statuses(); got[0].State != StateHalfOpen {
+		t.Fatalf("state after cooldown = %s, want half_open", got[0].State)
+	}
+	if err := reloaded.Success("chat.postMessage"); err != nil {
+		t.Fatalf("Success() error = %v", err)
+	}
+	if got := reloaded.Statuses(); len(got) != 0 {
+		t.Fatalf("Statuses() after success = %+v", got)
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	b, err := Load(filepath.Join(t.TempDir(), "breakers.json"), 0, time.Minute)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		_ = b.Failure("chat.postMessage", "timeout")
+	}
+	if err := b.Allow("chat.postMessage"); err != nil {
+		t.Fatalf("disabled breaker refused a call: %v", err)
+	}
+}
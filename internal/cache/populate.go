@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kehao95/slack-agent-cli/internal/tracing"
 	slackapi "github.com/slack-go/slack"
 )
 
@@ -89,6 +90,7 @@ func (s *Store) PopulateChannels(ctx context.Context, fetcher ChannelFetcher, cf
 
 	pages := 0
 	scanned := 0
+	attempt := 0
 	for {
 		select {
 		case <-ctx.Done():
@@ -104,7 +106,7 @@ func (s *Store) PopulateChannels(ctx context.Context, fetcher ChannelFetcher, cf
 		default:
 		}
 
-		page, nextCursor, pageScanned, err := fetcher.ListChannels(ctx, cursor, cfg.PageSize)
+		page, nextCursor, pageScanned, err := fetcher.ListChannels(tracing.WithRetryAttempt(ctx, attempt), cursor, cfg.PageSize)
 		if err != nil {
 			// Check for rate limit error and retry
 			if rlErr, ok := err.(*slackapi.RateLimitedError); ok {
@@ -120,6 +122,7 @@ func (s *Store) PopulateChannels(ctx context.Context, fetcher ChannelFetcher, cf
 						Scanned:    scanned,
 					}, ctx.Err()
 				case <-time.After(rlErr.RetryAfter):
+					attempt++
 					continue // Retry the same page
 				}
 			}
@@ -137,6 +140,7 @@ func (s *Store) PopulateChannels(ctx context.Context, fetcher ChannelFetcher, cf
 						Scanned:    scanned,
 					}, ctx.Err()
 				case <-time.After(30 * time.Second):
+					attempt++
 					continue // Retry the same page
 				}
 			}
@@ -151,6 +155,7 @@ func (s *Store) PopulateChannels(ctx context.Context, fetcher ChannelFetcher, cf
 			}, fmt.Errorf("fetch channels: %w", err)
 		}
 
+		attempt = 0
 		channels = append(channels, page...)
 		pages++
 		scanned += pageScanned
@@ -216,6 +221,12 @@ const CacheKeyUsers = "users"
 // CacheKeyUserGroups is the cache key for usergroups.
 const CacheKeyUserGroups = "usergroups"
 
+// CacheKeyBots is the cache key for bot profiles.
+const CacheKeyBots = "bots"
+
+// CacheKeyEmoji is the cache key for custom emoji.
+const CacheKeyEmoji = "emoji"
+
 // PopulateUsers incrementally populates the user cache.
 func (s *Store) PopulateUsers(ctx context.Context, fetcher UserFetcher, cfg PopulateConfig) (PopulateResult, error) {
 	if cfg.PageSize == 0 {
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
 )
 
 // DefaultTTL is the default cache entry lifetime (7 days).
@@ -170,11 +172,11 @@ func (s *Store) now() time.Time {
 }
 
 func defaultBasePath() (string, error) {
-	home, err := os.UserHomeDir()
+	dir, err := config.DefaultDir()
 	if err != nil {
-		return "", fmt.Errorf("determine home directory: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, ".config", "slack-cli", "cache"), nil
+	return filepath.Join(dir, "cache"), nil
 }
 
 // PartialState represents the current state of a partial cache.
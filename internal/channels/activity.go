@@ -0,0 +1,160 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// unreadScanLimit caps how many unread messages are counted per channel.
+const unreadScanLimit = 100
+
+// previewChars is the length of a latest-message preview, in grapheme clusters.
+const previewChars = 120
+
+// ActivityClient defines the Slack operations needed to describe channel activity.
+type ActivityClient interface {
+	GetConversationInfo(ctx context.Context, channelID string) (*slackapi.Channel, error)
+	ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error)
+}
+
+// Activity describes a channel's latest message and, for members, what is unread.
+type Activity struct {
+	LastTS       string     `json:"last_ts,omitempty"`
+	LastActivity *time.Time `json:"last_activity,omitempty"`
+	LastUser     string     `json:"last_user,omitempty"`
+	Preview      string     `json:"preview,omitempty"`
+	// LastRead and UnreadCount are only known for conversations the token's user
+	// belongs to; bot tokens have no read position. UnreadCount stops at 100, with
+	// UnreadMore set when there are more.
+	LastRead    string `json:"last_read,omitempty"`
+	UnreadCount *int   `json:"unread_count,omitempty"`
+	UnreadMore  bool   `json:"unread_more,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// FetchActivity looks up the activity of every channel, at most concurrency at a
+// time, keyed by channel ID. It also refreshes each channel's IsMember flag (and a
+// DM's IsOpen flag) from conversations.info. A channel that cannot be read gets an Activity with Error set.
+func FetchActivity(ctx context.Context, client ActivityClient, chans []slackapi.Channel, concurrency int) map[string]Activity {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	activity := make([]Activity, len(chans))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range chans {
+		wg.Add(1)
+		go func(ch *slackapi.Channel, out *Activity) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				out.Error = ctx.Err().Error()
+				return
+			}
+			defer func() { <-sem }()
+			*out = channelActivity(ctx, client, ch)
+		}(&chans[i], &activity[i])
+	}
+	wg.Wait()
+
+	byID := make(map[string]Activity, len(chans))
+	for i, ch := range chans {
+		byID[ch.ID] = activity[i]
+	}
+	return byID
+}
+
+func channelActivity(ctx context.Context, client ActivityClient, ch *slackapi.Channel) Activity {
+	var a Activity
+	info, err := client.GetConversationInfo(ctx, ch.ID)
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	ch.IsMember = info.IsMember || ch.IsIM || ch.IsMpIM
+	if ch.IsIM || ch.IsMpIM {
+		ch.IsOpen = info.IsOpen
+	}
+	a.LastRead = info.LastRead
+
+	var latest *slackapi.Message
+	if a.LastRead != "" {
+		unread, err := client.ListConversationsHistory(ctx, slack.HistoryParams{Channel: ch.ID, Oldest: a.LastRead, Limit: unreadScanLimit})
+		if err != nil {
+			a.Error = err.Error()
+			return a
+		}
+		count := len(unread.Messages)
+		a.UnreadCount = &count
+		a.UnreadMore = unread.HasMore
+		if count > 0 {
+			latest = &unread.Messages[0]
+		}
+	}
+	if latest == nil {
+		history, err := client.ListConversationsHistory(ctx, slack.HistoryParams{Channel: ch.ID, Limit: 1})
+		if err != nil {
+			a.Error = err.Error()
+			return a
+		}
+		if len(history.Messages) > 0 {
+			latest = &history.Messages[0]
+		}
+	}
+	if latest != nil {
+		a.LastTS = latest.Timestamp
+		if at, ok := tsTime(latest.Timestamp); ok {
+			a.LastActivity = &at
+		}
+		a.LastUser = latest.User
+		if a.LastUser == "" {
+			a.LastUser = latest.Username
+		}
+		a.Preview = preview(latest.Text)
+	}
+	return a
+}
+
+// Summary is the human-readable suffix of a channel line, starting with " - ".
+func (a Activity) Summary() string {
+	if a.Error != "" {
+		return " - activity unavailable: " + a.Error
+	}
+	var parts []string
+	if a.UnreadCount != nil {
+		unread := fmt.Sprintf("%d unread", *a.UnreadCount)
+		if a.UnreadMore {
+			unread = fmt.Sprintf("%d+ unread", *a.UnreadCount)
+		}
+		parts = append(parts, unread)
+	}
+	if a.LastActivity != nil {
+		last := "last " + a.LastActivity.Local().Format("2006-01-02 15:04")
+		if a.Preview != "" {
+			last += ": " + a.Preview
+		}
+		parts = append(parts, last)
+	} else {
+		parts = append(parts, "no messages")
+	}
+	return " - " + strings.Join(parts, ", ")
+}
+
+// preview collapses whitespace and shortens text to previewChars.
+func preview(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if output.GraphemeCount(text) <= previewChars {
+		return text
+	}
+	cut, _ := output.CutGraphemes(text, previewChars-1)
+	return cut + "…"
+}
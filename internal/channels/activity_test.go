@@ -0,0 +1,91 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+type fakeActivityClient struct {
+	info    map[string]*slackapi.Channel
+	history map[string][]slackapi.Message // newest first
+}
+
+func (f fakeActivityClient) GetConversationInfo(ctx context.Context, channelID string) (*slackapi.Channel, error) {
+	if ch, ok := f.info[channelID]; ok {
+		return ch, nil
+	}
+	return nil, errors.New("channel_not_found")
+}
+
+func (f fakeActivityClient) ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error) {
+	var msgs []slackapi.Message
+	for _, m := range f.history[params.Channel] {
+		if params.Oldest != "" && m.Timestamp <= params.Oldest {
+			break
+		}
+		msgs = append(msgs, m)
+	}
+	resp := &slackapi.GetConversationHistoryResponse{}
+	if len(msgs) > params.Limit {
+		msgs, resp.HasMore = msgs[:params.Limit], true
+	}
+	resp.Messages = msgs
+	return resp, nil
+}
+
+func channel(id string) slackapi.Channel {
+	return slackapi.Channel{GroupConversation: slackapi.GroupConversation{Name: strings.ToLower(id), Conversation: slackapi.Conversation{ID: id}}}
+}
+
+func message(ts, user, text string) slackapi.Message {
+	return slackapi.Message{Msg: slackapi.Msg{Timestamp: ts, User: user, Text: text}}
+}
+
+func TestFetchActivity(t *testing.T) {
+	member := &slackapi.Channel{}
+	member.IsMember = true
+	member.LastRead = "1700000002.000000"
+	client := fakeActivityClient{
+		info: map[string]*slackapi.Channel{"C1": member, "C2": {}},
+		history: map[string][]slackapi.Message{
+			"C1": {message("1700000004.000000", "U1", "deploy\n  finished"), message("1700000003.000000", "U2", "starting"), message("1700000002.000000", "U1", "read")},
+			"C2": {message("1700000001.000000", "U3", "hello")},
+		},
+	}
+	chans := []slackapi.Channel{channel("C1"), channel("C2"), channel("C3")}
+
+	activity := FetchActivity(context.Background(), client, chans, 2)
+
+	c1 := activity["C1"]
+	if c1.LastTS != "1700000004.000000" || c1.LastUser != "U1" || c1.Preview != "deploy finished" || c1.UnreadCount == nil || *c1.UnreadCount != 2 {
+		t.Fatalf("C1 activity = %+v", c1)
+	}
+	if !chans[0].IsMember || chans[1].IsMember {
+		t.Fatalf("membership not refreshed: C1 %v, C2 %v", chans[0].IsMember, chans[1].IsMember)
+	}
+	c2 := activity["C2"]
+	if c2.LastTS != "1700000001.000000" || c2.UnreadCount != nil || c2.LastActivity == nil {
+		t.Fatalf("C2 activity = %+v", c2)
+	}
+	if activity["C3"].Error == "" {
+		t.Fatalf("C3 activity = %+v, want an error", activity["C3"])
+	}
+
+	data, err := json.Marshal(ListResult{Channels: chans, Activity: activity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"activity":{"last_ts":"1700000004.000000"`) || !strings.Contains(string(data), `"unread_count":2`) {
+		t.Fatalf("json = %s", data)
+	}
+	if data, _ := json.Marshal(ListResult{Channels: chans}); strings.Contains(string(data), "activity") {
+		t.Fatalf("json without --with-activity = %s", data)
+	}
+}
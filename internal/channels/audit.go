@@ -0,0 +1,229 @@
+package channels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// defaultTempPrefixes mark short-lived channels when the config declares none.
+var defaultTempPrefixes = []string{"tmp-", "temp-", "test-"}
+
+// copySuffixes are name suffixes that usually mean a channel was recreated or forked
+// rather than being a distinct topic (proj-x vs proj-x-old, proj-x-2).
+var copySuffixes = map[string]bool{"old": true, "new": true, "copy": true, "tmp": true, "temp": true, "archive": true, "archived": true, "backup": true}
+
+// NamePair is two channels whose names look like duplicates.
+type NamePair struct {
+	ChannelID      string `json:"channel_id"`
+	Channel        string `json:"channel"`
+	OtherChannelID string `json:"other_channel_id"`
+	OtherChannel   string `json:"other_channel"`
+	Reason         string `json:"reason"`
+}
+
+// AuditedChannel is a channel flagged by the name audit.
+type AuditedChannel struct {
+	ChannelID string `json:"channel_id"`
+	Channel   string `json:"channel"`
+	Members   int    `json:"members"`
+	AgeDays   int    `json:"age_days"`
+	Reason    string `json:"reason"`
+}
+
+// NameAudit is the cleanup report produced by AuditNames.
+type NameAudit struct {
+	Checked        int              `json:"checked"`
+	NearDuplicates []NamePair       `json:"near_duplicates"`
+	TempChannels   []AuditedChannel `json:"temp_channels"`
+	Violations     []AuditedChannel `json:"violations"`
+}
+
+// NameAuditOptions configures AuditNames.
+type NameAuditOptions struct {
+	// MaxDistance is the largest edit distance treated as a near-duplicate (default 2).
+	// Names shorter than 5 characters only match when their normalized forms are equal.
+	MaxDistance int
+	// TempBefore reports temp channels created before it as orphaned.
+	TempBefore   time.Time
+	TempPrefixes []string
+	// Pattern and Prefixes are the naming convention; nil and empty skip the check.
+	Pattern  *regexp.Regexp
+	Prefixes []string
+	Now      time.Time
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (a NameAudit) Lines() []string {
+	lines := []string{fmt.Sprintf("Checked %d channels", a.Checked)}
+	section := func(title string, n int) {
+		lines = append(lines, "", fmt.Sprintf("%s (%d)", title, n))
+	}
+	section("Near-duplicate names", len(a.NearDuplicates))
+	for _, p := range a.NearDuplicates {
+		lines = append(lines, fmt.Sprintf("  %s ~ %s  (%s)", p.Channel, p.OtherChannel, p.Reason))
+	}
+	section("Orphaned temp channels", len(a.TempChannels))
+	for _, ch := range a.TempChannels {
+		lines = append(lines, fmt.Sprintf("  %-32s %4dd old, %d members", ch.Channel, ch.AgeDays, ch.Members))
+	}
+	section("Naming convention violations", len(a.Violations))
+	for _, ch := range a.Violations {
+		lines = append(lines, fmt.Sprintf("  %-32s %s", ch.Channel, ch.Reason))
+	}
+	return lines
+}
+
+// AuditNames scans channel names for near-duplicates, orphaned temp channels, and
+// naming convention violations. Archived channels are ignored.
+func AuditNames(chans []slackapi.Channel, opts NameAuditOptions) NameAudit {
+	if opts.MaxDistance <= 0 {
+		opts.MaxDistance = 2
+	}
+	if len(opts.TempPrefixes) == 0 {
+		opts.TempPrefixes = defaultTempPrefixes
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	audit := NameAudit{NearDuplicates: []NamePair{}, TempChannels: []AuditedChannel{}, Violations: []AuditedChannel{}}
+
+	var active []slackapi.Channel
+	for _, ch := range chans {
+		if !ch.IsArchived && ch.Name != "" {
+			active = append(active, ch)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Name < active[j].Name })
+	audit.Checked = len(active)
+
+	for i, a := range active {
+		for _, b := range active[i+1:] {
+			if reason := duplicateReason(a.Name, b.Name, opts.MaxDistance); reason != "" {
+				audit.NearDuplicates = append(audit.NearDuplicates, NamePair{
+					ChannelID: a.ID, Channel: "#" + a.Name,
+					OtherChannelID: b.ID, OtherChannel: "#" + b.Name,
+					Reason: reason,
+				})
+			}
+		}
+
+		entry := AuditedChannel{ChannelID: a.ID, Channel: "#" + a.Name, Members: a.NumMembers}
+		if created := a.Created.Time(); a.Created > 0 {
+			entry.AgeDays = int(opts.Now.Sub(created).Hours() / 24)
+		}
+		if prefix := matchingPrefix(a.Name, opts.TempPrefixes); prefix != "" {
+			if a.Created > 0 && a.Created.Time().Before(opts.TempBefore) {
+				entry.Reason = fmt.Sprintf("temp prefix %q", prefix)
+				audit.TempChannels = append(audit.TempChannels, entry)
+			}
+			continue
+		}
+		if opts.Pattern != nil && !opts.Pattern.MatchString(a.Name) {
+			entry.Reason = fmt.Sprintf("does not match %s", opts.Pattern)
+			audit.Violations = append(audit.Violations, entry)
+		} else if len(opts.Prefixes) > 0 && matchingPrefix(a.Name, opts.Prefixes) == "" {
+			entry.Reason = "no allowed prefix (" + strings.Join(opts.Prefixes, ", ") + ")"
+			audit.Violations = append(audit.Violations, entry)
+		}
+	}
+	return audit
+}
+
+// duplicateReason explains why two names look like duplicates, or returns "".
+func duplicateReason(a, b string, maxDistance int) string {
+	na, nb := normalizeName(a), normalizeName(b)
+	if na == nb {
+		return "same name ignoring separators"
+	}
+	if reason := copyOf(a, b); reason != "" {
+		return reason
+	}
+	if reason := copyOf(b, a); reason != "" {
+		return reason
+	}
+	// Numbered siblings such as incident-101 and incident-102 are distinct channels.
+	if len(na) < 5 || len(nb) < 5 || stripDigits(na) == stripDigits(nb) {
+		return ""
+	}
+	if d := levenshtein(na, nb, maxDistance); d <= maxDistance {
+		return fmt.Sprintf("edit distance %d", d)
+	}
+	return ""
+}
+
+// copyOf reports whether long is short plus a separator and a copy-like suffix.
+func copyOf(short, long string) string {
+	rest, ok := strings.CutPrefix(long, short)
+	if !ok || len(rest) < 2 || (rest[0] != '-' && rest[0] != '_') {
+		return ""
+	}
+	suffix := rest[1:]
+	if copySuffixes[suffix] || strings.IndexFunc(suffix, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+		return fmt.Sprintf("common prefix with suffix %q", suffix)
+	}
+	return ""
+}
+
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+func stripDigits(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return -1
+		}
+		return r
+	}, name)
+}
+
+func matchingPrefix(name string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// levenshtein returns the edit distance between a and b, or max+1 once it is known to
+// exceed max.
+func levenshtein(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,85 @@
+package channels
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func TestAuditNames(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	channel := func(id, name string, ageDays int) slackapi.Channel {
+		var ch slackapi.Channel
+		ch.ID = id
+		ch.Name = name
+		ch.Created = slackapi.JSONTime(now.AddDate(0, 0, -ageDays).Unix())
+		return ch
+	}
+	chans := []slackapi.Channel{
+		channel("C1", "team-devops", 400),
+		channel("C2", "team-dev-ops", 10),
+		channel("C3", "proj-alpha", 300),
+		channel("C4", "proj-alpha-old", 200),
+		channel("C5", "team-platfrom", 50),
+		channel("C6", "team-platform", 50),
+		channel("C7", "incident-101", 90),
+		channel("C8", "incident-102", 80),
+		channel("C9", "tmp-debug", 60),
+		channel("C10", "tmp-fresh", 2),
+		channel("C11", "Random_Stuff", 700),
+	}
+
+	audit := AuditNames(chans, NameAuditOptions{
+		MaxDistance: 2,
+		TempBefore:  now.AddDate(0, 0, -30),
+		Pattern:     regexp.MustCompile(`^[a-z0-9-]+$`),
+		Prefixes:    []string{"team-", "proj-", "incident-"},
+		Now:         now,
+	})
+	if audit.Checked != len(chans) {
+		t.Fatalf("checked = %d", audit.Checked)
+	}
+
+	pairs := map[string]string{}
+	for _, p := range audit.NearDuplicates {
+		pairs[p.Channel+" "+p.OtherChannel] = p.Reason
+	}
+	for _, want := range []string{"#team-dev-ops #team-devops", "#proj-alpha #proj-alpha-old", "#team-platform #team-platfrom"} {
+		if _, ok := pairs[want]; !ok {
+			t.Errorf("missing near-duplicate %s; got %v", want, pairs)
+		}
+	}
+	if _, ok := pairs["#incident-101 #incident-102"]; ok {
+		t.Errorf("numbered siblings reported as duplicates")
+	}
+	if len(pairs) != 3 {
+		t.Errorf("near-duplicates = %v, want 3", pairs)
+	}
+
+	if len(audit.TempChannels) != 1 || audit.TempChannels[0].ChannelID != "C9" || audit.TempChannels[0].AgeDays != 60 {
+		t.Errorf("temp channels = %+v, want C9 only", audit.TempChannels)
+	}
+	if len(audit.Violations) != 1 || audit.Violations[0].ChannelID != "C11" {
+		t.Errorf("violations = %+v, want C11 only", audit.Violations)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{"kitten", "sitting", 5, 3},
+		{"platform", "platfrom", 2, 2},
+		{"same", "same", 2, 0},
+		{"short", "muchlongername", 2, 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b, tt.max); got != tt.want {
+			t.Errorf("levenshtein(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package channels
+
+import (
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	last := time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+	unread := 100
+	general := channel("C1")
+	general.Name = "general"
+	general.IsMember = true
+	secret := channel("C2")
+	secret.Name = "secret"
+	secret.IsPrivate = true
+
+	tests := []struct {
+		name string
+		p    interface{ Lines() []string }
+	}{
+		{"ListResult", ListResult{Channels: []slackapi.Channel{general, secret}, NextCursor: "Y2hhbm5lbDpDMg=="}},
+		{"ListResult_activity", ListResult{Channels: []slackapi.Channel{general, secret, channel("C3")}, Activity: map[string]Activity{
+			"C1": {LastActivity: &last, Preview: "デプロイ完了 🚀", UnreadCount: &unread, UnreadMore: true},
+			"C2": {Error: "not_in_channel"},
+			"C3": {},
+		}}},
+		{"ListResult_empty", ListResult{}},
+		{"StaleResult", StaleResult{Cutoff: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), Checked: 40, Archived: 1,
+			Stale: []StaleChannel{
+				{ChannelID: "C4", Channel: "#proj-apollo", LastActivity: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), InactiveDays: 320, Archived: true},
+				{ChannelID: "C5", Channel: "#tmp-hack", LastActivity: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), InactiveDays: 228, Error: "cant_archive_general"},
+				{ChannelID: "C6", Channel: "#quiet", LastActivity: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), InactiveDays: 136},
+			},
+			Failed: []StaleChannel{{ChannelID: "C7", Channel: "#locked", Error: "not_in_channel"}},
+		}},
+		{"NameAudit", NameAudit{Checked: 30,
+			NearDuplicates: []NamePair{{Channel: "#deploys", OtherChannel: "#deploy", Reason: "edit distance 1"}},
+			TempChannels:   []AuditedChannel{{Channel: "#tmp-incident-42", AgeDays: 95, Members: 3}},
+			Violations:     []AuditedChannel{{Channel: "#random-stuff", Reason: "missing prefix (team-, proj-)"}},
+		}},
+		{"NameAudit_clean", NameAudit{Checked: 3}},
+		{"ProbeResult", ProbeResult{Channel: "C123ABC", ChannelName: "#support", TokenType: slack.TokenBot, Operations: []ProbeOperation{
+			{Operation: OpRead, Method: "conversations.info", Allowed: true, Checked: CheckedAPI},
+			{Operation: OpHistory, Method: "conversations.history", Checked: CheckedAPI, Reason: "not_in_channel"},
+			{Operation: OpPost, Method: "chat.postMessage", Allowed: true, Checked: CheckedInferred, Reason: "not a member; chat:write.public allows posting to public channels"},
+			{Operation: OpReact, Method: "reactions.add", Checked: CheckedInferred, Scope: "reactions:write", Reason: "token lacks reactions:write"},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// Probe operations, in the order they are reported.
+const (
+	OpRead    = "read"
+	OpHistory = "history"
+	OpPost    = "post"
+	OpReact   = "react"
+)
+
+// How a probe answer was reached: by calling the method, or from scopes, membership,
+// and local policy for methods that would change the channel.
+const (
+	CheckedAPI      = "api"
+	CheckedInferred = "inferred"
+)
+
+// ProbeClient defines the Slack operations the permission probe calls. Both only read.
+type ProbeClient interface {
+	GetConversationInfo(ctx context.Context, channelID string) (*slackapi.Channel, error)
+	ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error)
+}
+
+// ProbeInput describes the token being probed.
+type ProbeInput struct {
+	ChannelID string
+	TokenType slack.TokenType
+	// Scopes are the token's granted scopes; empty when unknown, as for xoxc tokens,
+	// in which case scopes are not checked.
+	Scopes []string
+	// CheckWrite returns the error local policy (read-only mode, channel lists, user
+	// impersonation) would refuse a method with, or nil.
+	CheckWrite func(method string) error
+}
+
+// ProbeOperation is the answer for one operation.
+type ProbeOperation struct {
+	Operation string `json:"operation"`
+	Method    string `json:"method"`
+	Allowed   bool   `json:"allowed"`
+	Checked   string `json:"checked"`
+	// Scope is the missing scope, when that is why the operation is refused.
+	Scope  string `json:"scope,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ProbeResult is the output of channels history-permissions probe.
+type ProbeResult struct {
+	Channel     string           `json:"channel"`
+	ChannelName string           `json:"channel_name,omitempty"`
+	TokenType   slack.TokenType  `json:"token_type"`
+	IsMember    *bool            `json:"is_member,omitempty"`
+	IsPrivate   bool             `json:"is_private"`
+	IsArchived  bool             `json:"is_archived"`
+	Operations  []ProbeOperation `json:"operations"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ProbeResult) Lines() []string {
+	name := r.Channel
+	if r.ChannelName != "" {
+		name = fmt.Sprintf("%s (%s)", r.ChannelName, r.Channel)
+	}
+	lines := []string{fmt.Sprintf("Permissions in %s for %s token:", name, r.TokenType)}
+	for _, op := range r.Operations {
+		mark := "✓"
+		if !op.Allowed {
+			mark = "✗"
+		}
+		line := fmt.Sprintf("  %s %-8s %-22s", mark, op.Operation, op.Method)
+		if op.Checked == CheckedInferred {
+			line += " (inferred)"
+		}
+		if op.Reason != "" {
+			line += " " + op.Reason
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	return lines
+}
+
+// Probe reports which of read, history, post, and react the token can perform in a
+// channel. read and history call conversations.info and conversations.history (one
+// message); post and react are never attempted, and are answered from the token's
+// scopes, its membership, whether the channel is archived, and local policy.
+func Probe(ctx context.Context, client ProbeClient, in ProbeInput) ProbeResult {
+	result := ProbeResult{Channel: in.ChannelID, TokenType: in.TokenType}
+
+	ch, infoErr := client.GetConversationInfo(ctx, in.ChannelID)
+	read := ProbeOperation{Operation: OpRead, Method: "conversations.info", Checked: CheckedAPI, Allowed: infoErr == nil}
+	if infoErr != nil {
+		read.Reason = infoErr.Error()
+		read.Scope = missingScope(in.Scopes, conversationScope(in.ChannelID, nil, "read"))
+	} else {
+		result.IsPrivate = ch.IsPrivate
+		result.IsArchived = ch.IsArchived
+		if ch.Name != "" {
+			result.ChannelName = "#" + ch.Name
+		}
+		if !ch.IsIM {
+			member := ch.IsMember
+			result.IsMember = &member
+		}
+	}
+
+	history := ProbeOperation{Operation: OpHistory, Method: "conversations.history", Checked: CheckedAPI}
+	if _, err := client.ListConversationsHistory(ctx, slack.HistoryParams{Channel: in.ChannelID, Limit: 1}); err != nil {
+		history.Reason = err.Error()
+		history.Scope = missingScope(in.Scopes, conversationScope(in.ChannelID, ch, "history"))
+	} else {
+		history.Allowed = true
+	}
+
+	result.Operations = []ProbeOperation{
+		read,
+		history,
+		inferWrite(in, ch, OpPost, "chat.postMessage", "chat:write"),
+		inferWrite(in, ch, OpReact, "reactions.add", "reactions:write"),
+	}
+	return result
+}
+
+// inferWrite answers a write operation without calling it. ch is nil when the
+// channel could not be read.
+func inferWrite(in ProbeInput, ch *slackapi.Channel, operation, method, scope string) ProbeOperation {
+	op := ProbeOperation{Operation: operation, Method: method, Checked: CheckedInferred}
+	if in.CheckWrite != nil {
+		if err := in.CheckWrite(method); err != nil {
+			op.Reason = err.Error()
+			return op
+		}
+	}
+	if op.Scope = missingScope(in.Scopes, scope); op.Scope != "" {
+		op.Reason = "token lacks " + scope
+		return op
+	}
+	switch {
+	case ch == nil:
+		op.Reason = "channel info is unavailable, so membership is unknown"
+	case ch.IsArchived:
+		op.Reason = "channel is archived"
+	case ch.IsIM || ch.IsMember:
+		op.Allowed = true
+	case operation == OpPost && in.TokenType == slack.TokenBot && !ch.IsPrivate && slices.Contains(in.Scopes, "chat:write.public"):
+		op.Allowed = true
+		op.Reason = "not a member; chat:write.public allows posting to public channels"
+	case ch.IsPrivate:
+		op.Reason = "not a member of this private channel"
+	default:
+		op.Reason = "not a member (Slack refuses with not_in_channel); join with slk channels join"
+	}
+	return op
+}
+
+// missingScope returns scope when the known scopes lack it.
+func missingScope(scopes []string, scope string) string {
+	if len(scopes) == 0 || scope == "" || slices.Contains(scopes, scope) {
+		return ""
+	}
+	return scope
+}
+
+// conversationScope picks the read or history scope for the conversation type, from
+// ch when it could be read. Without it only DM IDs tell the type apart: C and G IDs can
+// be public or private channels.
+func conversationScope(channelID string, ch *slackapi.Channel, suffix string) string {
+	switch {
+	case ch == nil && strings.HasPrefix(channelID, "D"):
+		return "im:" + suffix
+	case ch == nil:
+		return ""
+	case ch.IsIM:
+		return "im:" + suffix
+	case ch.IsMpIM:
+		return "mpim:" + suffix
+	case ch.IsPrivate:
+		return "groups:" + suffix
+	}
+	return "channels:" + suffix
+}
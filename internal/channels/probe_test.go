@@ -0,0 +1,79 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// fakeProbeClient fails conversations.history for channels in historyErr.
+type fakeProbeClient struct {
+	fakeActivityClient
+	historyErr map[string]error
+}
+
+func (f fakeProbeClient) ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error) {
+	if err := f.historyErr[params.Channel]; err != nil {
+		return nil, err
+	}
+	return f.fakeActivityClient.ListConversationsHistory(ctx, params)
+}
+
+func TestProbe(t *testing.T) {
+	member := channel("C1")
+	member.IsMember = true
+	public := channel("C2")
+	private := channel("C3")
+	private.IsPrivate = true
+	archived := channel("C4")
+	archived.IsMember = true
+	archived.IsArchived = true
+	client := fakeProbeClient{
+		fakeActivityClient: fakeActivityClient{info: map[string]*slackapi.Channel{"C1": &member, "C2": &public, "C3": &private, "C4": &archived}},
+		historyErr: map[string]error{
+			"C2": errors.New("not_in_channel"),
+			"C3": errors.New("missing_scope"),
+			"C9": errors.New("channel_not_found"),
+		},
+	}
+	userScopes := []string{"channels:read", "channels:history", "chat:write", "reactions:write"}
+	botScopes := []string{"channels:read", "channels:history", "chat:write", "chat:write.public"}
+
+	tests := []struct {
+		name  string
+		in    ProbeInput
+		want  [4]bool // read, history, post, react
+		scope [4]string
+	}{
+		{"member", ProbeInput{ChannelID: "C1", TokenType: slack.TokenUser, Scopes: userScopes}, [4]bool{true, true, true, true}, [4]string{}},
+		{"user not a member", ProbeInput{ChannelID: "C2", TokenType: slack.TokenUser, Scopes: userScopes}, [4]bool{true, false, false, false}, [4]string{}},
+		{"bot with chat:write.public", ProbeInput{ChannelID: "C2", TokenType: slack.TokenBot, Scopes: botScopes}, [4]bool{true, false, true, false}, [4]string{3: "reactions:write"}},
+		{"private without groups:history", ProbeInput{ChannelID: "C3", TokenType: slack.TokenBot, Scopes: botScopes}, [4]bool{true, false, false, false}, [4]string{1: "groups:history", 3: "reactions:write"}},
+		{"archived", ProbeInput{ChannelID: "C4", TokenType: slack.TokenUser, Scopes: userScopes}, [4]bool{true, true, false, false}, [4]string{}},
+		{"unreadable", ProbeInput{ChannelID: "C9", TokenType: slack.TokenUser, Scopes: userScopes}, [4]bool{}, [4]string{}},
+		{"unknown scopes", ProbeInput{ChannelID: "C1", TokenType: slack.TokenClient}, [4]bool{true, true, true, true}, [4]string{}},
+		{"local policy", ProbeInput{ChannelID: "C1", TokenType: slack.TokenUser, Scopes: userScopes, CheckWrite: func(method string) error {
+			return errors.New(method + " is disabled")
+		}}, [4]bool{true, true, false, false}, [4]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Probe(context.Background(), client, tt.in)
+			if len(result.Operations) != 4 {
+				t.Fatalf("got %d operations, want 4", len(result.Operations))
+			}
+			for i, op := range result.Operations {
+				if op.Allowed != tt.want[i] || op.Scope != tt.scope[i] {
+					t.Errorf("%s: allowed=%v scope=%q (%s), want allowed=%v scope=%q", op.Operation, op.Allowed, op.Scope, op.Reason, tt.want[i], tt.scope[i])
+				}
+				if !op.Allowed && op.Reason == "" {
+					t.Errorf("%s: refused without a reason", op.Operation)
+				}
+			}
+		})
+	}
+}
@@ -14,12 +14,19 @@ import (
 	"github.com/kehao95/slack-agent-cli/internal/slack"
 )
 
-var conversationIDPattern = regexp.MustCompile(`^[CDG][A-Z0-9]+$`)
+// conversationIDPattern matches public (C), private (G), and DM (D) conversation IDs.
+// Real IDs have at least eight characters after the prefix, so short upper-case
+// names are still looked up by name.
+var conversationIDPattern = regexp.MustCompile(`^[CDG][A-Z0-9]{8,}$`)
 
 // Resolver resolves channel names to IDs using disk-cached lookups.
 type Resolver struct {
 	client slack.ChannelClient
 	cache  *cache.Store
+	// includePrivate adds private channels to name lookups (groups:read).
+	includePrivate bool
+	// suggestions is how many close channel names a not-found error lists.
+	suggestions int
 }
 
 // NewResolver creates a Resolver with no cache (API-only).
@@ -32,6 +39,27 @@ func NewCachedResolver(client slack.ChannelClient, store *cache.Store) *Resolver
 	return &Resolver{client: client, cache: store}
 }
 
+// SetIncludePrivate makes name lookups that reach the API list private channels
+// as well as public ones. Only enable it when the token has groups:read, or
+// conversations.list fails with missing_scope.
+func (r *Resolver) SetIncludePrivate(include bool) {
+	r.includePrivate = include
+}
+
+// SetSuggestions makes ResolveID list up to n known channel names close to a name
+// it cannot find, matched by prefix and then by edit distance. Zero disables it.
+func (r *Resolver) SetSuggestions(n int) {
+	r.suggestions = n
+}
+
+// listTypes returns the conversation types fetched for name lookups.
+func (r *Resolver) listTypes() []string {
+	if r.includePrivate {
+		return []string{"public_channel", "private_channel"}
+	}
+	return []string{"public_channel"}
+}
+
 // RefreshCache forces a cache refresh for channels by clearing existing cache.
 // Use "slack-cli cache populate channels" to repopulate.
 func (r *Resolver) RefreshCache(ctx context.Context) error {
@@ -92,6 +120,12 @@ func (r *Resolver) ResolveID(ctx context.Context, input string) (string, error)
 		}
 	}
 
+	if r.suggestions > 0 {
+		// The lookup above cached every page it fetched.
+		if known, _, err := r.loadChannels(ctx); err == nil {
+			return "", errors.ChannelNotFoundWithSuggestions(trimmed, closestNames(normalized, known, r.suggestions))
+		}
+	}
 	return "", errors.ChannelNotFoundError(trimmed)
 }
 
@@ -129,6 +163,40 @@ func (r *Resolver) ResolveName(ctx context.Context, channelID string) string {
 	return channelID // Fallback to ID if not found
 }
 
+// CachedChannels returns the channels in the disk cache without calling the API, and
+// whether the cached list is complete. It is empty until cache populate or a name
+// lookup has run.
+func (r *Resolver) CachedChannels(ctx context.Context) ([]slackapi.Channel, bool, error) {
+	channels, cursor, err := r.loadChannels(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return channels, cursor == "" && len(channels) > 0, nil
+}
+
+// GetConversationInfo returns conversation metadata for a channel ID, preferring the
+// disk cache and falling back to a single conversations.info call whose result is cached.
+func (r *Resolver) GetConversationInfo(ctx context.Context, channelID string) (*slackapi.Channel, error) {
+	channels, cursor, err := r.loadChannels(ctx)
+	if err == nil {
+		for i := range channels {
+			if channels[i].ID == channelID {
+				return &channels[i], nil
+			}
+		}
+	}
+	if r.client == nil {
+		return nil, fmt.Errorf("conversation %s not cached", channelID)
+	}
+
+	info, err := r.client.GetConversationInfo(ctx, channelID)
+	if err != nil || info == nil {
+		return info, err
+	}
+	r.cacheConversationInfo(channels, cursor, *info)
+	return info, nil
+}
+
 func (r *Resolver) lookupNameByID(ctx context.Context, channelID string, channels []slackapi.Channel, cursor string) string {
 	info, err := r.client.GetConversationInfo(ctx, channelID)
 	if err != nil || info == nil {
@@ -177,7 +245,7 @@ func (r *Resolver) fetchNameForID(ctx context.Context, channelID string, existin
 			Limit:           200,
 			Cursor:          currentCursor,
 			IncludeArchived: false,
-			Types:           []string{"public_channel"},
+			Types:           r.listTypes(),
 		})
 		if err != nil {
 			// Save progress before returning
@@ -288,12 +356,12 @@ func (r *Resolver) fetchUntilFound(ctx context.Context, name string, existing []
 
 	for {
 		// Fetch next page
-		// Note: Only fetch public_channel to avoid scope issues (private_channel requires groups:read)
+		// private_channel is only requested with groups:read (see SetIncludePrivate).
 		page, nextCursor, err := r.client.ListChannels(ctx, slack.ListChannelsParams{
 			Limit:           200,
 			Cursor:          currentCursor,
 			IncludeArchived: false,
-			Types:           []string{"public_channel"},
+			Types:           r.listTypes(),
 		})
 		if err != nil {
 			// Save progress before returning error
@@ -18,6 +18,7 @@ type resolverMockClient struct {
 	index            int
 	error            error
 	conversationInfo map[string]*slackapi.Channel
+	types            []string
 }
 
 func (m *resolverMockClient) ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error) {
@@ -29,6 +30,7 @@ func (m *resolverMockClient) ListThreadReplies(ctx context.Context, params slack
 }
 
 func (m *resolverMockClient) ListChannels(ctx context.Context, params slack.ListChannelsParams) ([]slackapi.Channel, string, error) {
+	m.types = params.Types
 	if m.error != nil {
 		return nil, "", m.error
 	}
@@ -68,7 +70,7 @@ func (m *resolverMockClient) LeaveChannel(ctx context.Context, channelID string)
 
 func TestResolverResolveID_DirectID(t *testing.T) {
 	// Direct conversation IDs should work without cache or client
-	tests := []string{"C123ABC", "D123ABC", "G123ABC"}
+	tests := []string{"C0123ABCD", "D0123ABCD", "G0123ABCD", "C01234567890"}
 	resolver := NewResolver(nil)
 	for _, input := range tests {
 		id, err := resolver.ResolveID(context.Background(), input)
@@ -89,13 +91,18 @@ func TestResolverResolveID_PermalinkID(t *testing.T) {
 	}{
 		{
 			name:     "public channel permalink",
-			link:     "https://example.slack.com/archives/C123ABC/p1705312365000100",
-			expected: "C123ABC",
+			link:     "https://example.slack.com/archives/C0123ABCD/p1705312365000100",
+			expected: "C0123ABCD",
 		},
 		{
 			name:     "dm permalink",
-			link:     "https://example.slack.com/archives/D123ABC/p1705312365000100?thread_ts=1705312365.000100",
-			expected: "D123ABC",
+			link:     "https://example.slack.com/archives/D0123ABCD/p1705312365000100?thread_ts=1705312365.000100",
+			expected: "D0123ABCD",
+		},
+		{
+			name:     "private channel permalink",
+			link:     "https://example.slack.com/archives/g0123abcd/p1705312365000100",
+			expected: "G0123ABCD",
 		},
 	}
 
@@ -113,6 +120,54 @@ func TestResolverResolveID_PermalinkID(t *testing.T) {
 	}
 }
 
+func TestResolverResolveID_ShortUpperCaseIsName(t *testing.T) {
+	// Too short to be an ID, so it is looked up by name.
+	client := &resolverMockClient{
+		responses: [][]slackapi.Channel{
+			{{GroupConversation: slackapi.GroupConversation{Name: "dev", Conversation: slackapi.Conversation{ID: "C0123ABCD"}}}},
+		},
+	}
+	id, err := NewResolver(client).ResolveID(context.Background(), "DEV")
+	if err != nil {
+		t.Fatalf("ResolveID returned error: %v", err)
+	}
+	if id != "C0123ABCD" {
+		t.Fatalf("expected C0123ABCD, got %s", id)
+	}
+}
+
+func TestResolverResolveID_PrivateChannels(t *testing.T) {
+	newClient := func() *resolverMockClient {
+		return &resolverMockClient{
+			responses: [][]slackapi.Channel{
+				{{GroupConversation: slackapi.GroupConversation{Name: "secret", Conversation: slackapi.Conversation{ID: "G0123ABCD"}}}},
+			},
+		}
+	}
+
+	client := newClient()
+	if _, err := NewResolver(client).ResolveID(context.Background(), "#secret"); err != nil {
+		t.Fatalf("ResolveID returned error: %v", err)
+	}
+	if strings.Join(client.types, ",") != "public_channel" {
+		t.Fatalf("types without groups:read = %v", client.types)
+	}
+
+	client = newClient()
+	resolver := NewResolver(client)
+	resolver.SetIncludePrivate(true)
+	id, err := resolver.ResolveID(context.Background(), "#secret")
+	if err != nil {
+		t.Fatalf("ResolveID returned error: %v", err)
+	}
+	if id != "G0123ABCD" {
+		t.Fatalf("expected G0123ABCD, got %s", id)
+	}
+	if strings.Join(client.types, ",") != "public_channel,private_channel" {
+		t.Fatalf("types with groups:read = %v", client.types)
+	}
+}
+
 func TestResolverResolveID_NoCacheFetchesOnDemand(t *testing.T) {
 	// Without cache, resolver fetches from API on demand
 	client := &resolverMockClient{
@@ -416,3 +471,31 @@ func TestResolverResolveNameWithConversationInfo(t *testing.T) {
 		t.Fatalf("did not expect full channel cache to be written from single conversation lookup")
 	}
 }
+
+func TestResolverGetConversationInfoUsesCache(t *testing.T) {
+	store := cache.New(t.TempDir(), cache.DefaultTTL)
+	channels := []slackapi.Channel{
+		{GroupConversation: slackapi.GroupConversation{Name: "secret", Conversation: slackapi.Conversation{ID: "G1", IsPrivate: true}}},
+	}
+	if err := store.Save(cache.CacheKeyChannels, channels); err != nil {
+		t.Fatalf("failed to pre-populate cache: %v", err)
+	}
+	client := &resolverMockClient{conversationInfo: map[string]*slackapi.Channel{
+		"G2": {GroupConversation: slackapi.GroupConversation{Name: "group-dm", Conversation: slackapi.Conversation{ID: "G2", IsMpIM: true}}},
+	}}
+	resolver := NewCachedResolver(client, store)
+
+	info, err := resolver.GetConversationInfo(context.Background(), "G1")
+	if err != nil || info == nil || !info.IsPrivate {
+		t.Fatalf("expected cached private conversation, got %+v, %v", info, err)
+	}
+
+	info, err = resolver.GetConversationInfo(context.Background(), "G2")
+	if err != nil || info == nil || !info.IsMpIM {
+		t.Fatalf("expected fetched mpim conversation, got %+v, %v", info, err)
+	}
+	client.conversationInfo = nil
+	if info, err := resolver.GetConversationInfo(context.Background(), "G2"); err != nil || info == nil || info.Name != "group-dm" {
+		t.Fatalf("expected fetched conversation to be cached, got %+v, %v", info, err)
+	}
+}
@@ -2,6 +2,7 @@ package channels
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -31,6 +32,35 @@ type ListParams struct {
 type ListResult struct {
 	Channels   []slackapi.Channel `json:"channels"`
 	NextCursor string             `json:"next_cursor"`
+	// Activity, keyed by channel ID, is added to each channel as "activity" when set.
+	Activity map[string]Activity `json:"-"`
+}
+
+// MarshalJSON adds each channel's activity, when fetched, to its Slack object.
+func (r ListResult) MarshalJSON() ([]byte, error) {
+	type plain ListResult
+	if r.Activity == nil {
+		return json.Marshal(plain(r))
+	}
+	out := struct {
+		Channels   []map[string]interface{} `json:"channels"`
+		NextCursor string                   `json:"next_cursor"`
+	}{Channels: make([]map[string]interface{}, len(r.Channels)), NextCursor: r.NextCursor}
+	for i, ch := range r.Channels {
+		encoded, err := json.Marshal(ch)
+		if err != nil {
+			return nil, err
+		}
+		var enriched map[string]interface{}
+		if err := json.Unmarshal(encoded, &enriched); err != nil {
+			return nil, err
+		}
+		if a, ok := r.Activity[ch.ID]; ok {
+			enriched["activity"] = a
+		}
+		out.Channels[i] = enriched
+	}
+	return json.Marshal(out)
 }
 
 func (s *Service) List(ctx context.Context, params ListParams) (ListResult, error) {
@@ -68,7 +98,14 @@ func (r ListResult) Lines() []string {
 		if ch.IsPrivate {
 			privacy = "private"
 		}
-		lines = append(lines, fmt.Sprintf("%s (%s) - %s", ch.Name, ch.ID, privacy))
+		line := fmt.Sprintf("%s (%s) - %s", ch.Name, ch.ID, privacy)
+		if ch.IsMember {
+			line += ", member"
+		}
+		if a, ok := r.Activity[ch.ID]; ok {
+			line += a.Summary()
+		}
+		lines = append(lines, line)
 	}
 	if r.NextCursor != "" {
 		lines = append(lines, fmt.Sprintf("Next cursor: %s", r.NextCursor))
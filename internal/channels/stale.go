@@ -0,0 +1,141 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// StaleChannel is a channel with no messages since the inactivity cutoff.
+type StaleChannel struct {
+	ChannelID string `json:"channel_id"`
+	Channel   string `json:"channel"`
+	IsPrivate bool   `json:"is_private,omitempty"`
+	// LastActivity is the latest message time, or the creation time for channels that
+	// have never had a message.
+	LastActivity time.Time `json:"last_activity"`
+	LastTS       string    `json:"last_ts,omitempty"`
+	InactiveDays int       `json:"inactive_days"`
+	Archived     bool      `json:"archived,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// StaleResult is the output of channels stale.
+type StaleResult struct {
+	Cutoff   time.Time      `json:"cutoff"`
+	Checked  int            `json:"checked"`
+	Stale    []StaleChannel `json:"stale"`
+	Failed   []StaleChannel `json:"failed,omitempty"`
+	Archived int            `json:"archived,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r StaleResult) Lines() []string {
+	lines := []string{fmt.Sprintf("%d of %d channels inactive since %s", len(r.Stale), r.Checked, r.Cutoff.Format(time.DateOnly))}
+	for _, ch := range r.Stale {
+		line := fmt.Sprintf("  %-32s %5dd  last %s", ch.Channel, ch.InactiveDays, ch.LastActivity.Format(time.DateOnly))
+		if ch.Archived {
+			line += "  archived"
+		} else if ch.Error != "" {
+			line += "  archive failed: " + ch.Error
+		}
+		lines = append(lines, line)
+	}
+	for _, ch := range r.Failed {
+		lines = append(lines, fmt.Sprintf("  %-32s skipped: %s", ch.Channel, ch.Error))
+	}
+	if r.Archived > 0 {
+		lines = append(lines, fmt.Sprintf("Archived %d channels", r.Archived))
+	}
+	return lines
+}
+
+// Ellipsize implements output.Ellipsizer; the rows are a table.
+func (r StaleResult) Ellipsize() bool {
+	return true
+}
+
+// LatestFunc returns the ts of a channel's newest message, or "" when it has none.
+type LatestFunc func(ctx context.Context, channelID string) (string, error)
+
+// FindStale checks channels concurrently and returns those whose latest message (or
+// creation time, when empty) is before cutoff, oldest first, plus the channels that
+// could not be checked. Archived channels are ignored.
+func FindStale(ctx context.Context, chans []slackapi.Channel, latest LatestFunc, cutoff, now time.Time, concurrency int) (StaleResult, error) {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	result := StaleResult{Cutoff: cutoff, Stale: []StaleChannel{}}
+	var active []slackapi.Channel
+	for _, ch := range chans {
+		if !ch.IsArchived {
+			active = append(active, ch)
+		}
+	}
+	result.Checked = len(active)
+
+	checked := make([]StaleChannel, len(active))
+	stale := make([]bool, len(active))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ch := range active {
+		wg.Add(1)
+		go func(i int, ch slackapi.Channel) {
+			defer wg.Done()
+			entry := StaleChannel{ChannelID: ch.ID, Channel: "#" + ch.Name, IsPrivate: ch.IsPrivate}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				entry.Error = ctx.Err().Error()
+				checked[i] = entry
+				return
+			}
+			defer func() { <-sem }()
+
+			ts, err := latest(ctx, ch.ID)
+			if err != nil {
+				entry.Error = err.Error()
+				checked[i] = entry
+				return
+			}
+			entry.LastTS = ts
+			entry.LastActivity = ch.Created.Time()
+			if at, ok := tsTime(ts); ok {
+				entry.LastActivity = at
+			}
+			entry.InactiveDays = int(now.Sub(entry.LastActivity).Hours() / 24)
+			checked[i] = entry
+			stale[i] = entry.LastActivity.Before(cutoff)
+		}(i, ch)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	for i, entry := range checked {
+		switch {
+		case entry.Error != "":
+			result.Failed = append(result.Failed, entry)
+		case stale[i]:
+			result.Stale = append(result.Stale, entry)
+		}
+	}
+	sort.SliceStable(result.Stale, func(i, j int) bool {
+		return result.Stale[i].LastActivity.Before(result.Stale[j].LastActivity)
+	})
+	return result, nil
+}
+
+func tsTime(ts string) (time.Time, bool) {
+	sec, err := strconv.ParseFloat(ts, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(sec), 0), true
+}
@@ -0,0 +1,58 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func TestFindStale(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cutoff := now.AddDate(0, 0, -90)
+	channel := func(id, name string, created time.Time, archived bool) slackapi.Channel {
+		var ch slackapi.Channel
+		ch.ID = id
+		ch.Name = name
+		ch.Created = slackapi.JSONTime(created.Unix())
+		ch.IsArchived = archived
+		return ch
+	}
+	chans := []slackapi.Channel{
+		channel("C1", "active", now.AddDate(-1, 0, 0), false),
+		channel("C2", "quiet", now.AddDate(-1, 0, 0), false),
+		channel("C3", "empty-old", now.AddDate(0, 0, -200), false),
+		channel("C4", "empty-new", now.AddDate(0, 0, -5), false),
+		channel("C5", "forbidden", now.AddDate(-1, 0, 0), false),
+		channel("C6", "archived", now.AddDate(-1, 0, 0), true),
+	}
+	latest := map[string]string{
+		"C1": "1699990000.000100",
+		"C2": "1690000000.000100",
+	}
+	fetch := func(ctx context.Context, id string) (string, error) {
+		if id == "C5" {
+			return "", errors.New("not_in_channel")
+		}
+		return latest[id], nil
+	}
+
+	result, err := FindStale(context.Background(), chans, fetch, cutoff, now, 2)
+	if err != nil {
+		t.Fatalf("FindStale: %v", err)
+	}
+	if result.Checked != 5 {
+		t.Fatalf("checked = %d, want 5", result.Checked)
+	}
+	if len(result.Stale) != 2 || result.Stale[0].ChannelID != "C3" || result.Stale[1].ChannelID != "C2" {
+		t.Fatalf("stale = %+v, want C3 then C2", result.Stale)
+	}
+	if result.Stale[1].InactiveDays != 115 {
+		t.Fatalf("C2 inactive days = %d, want 115", result.Stale[1].InactiveDays)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ChannelID != "C5" {
+		t.Fatalf("failed = %+v, want C5", result.Failed)
+	}
+}
@@ -0,0 +1,57 @@
+package channels
+
+import (
+	"sort"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// closestNames returns up to n channel names that the mistyped input most likely
+// meant: names starting with it first (shortest first), then names within a few
+// edits of it (closest first). Archived channels are skipped.
+func closestNames(input string, chans []slackapi.Channel, n int) []string {
+	query := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(input), "#"))
+	if query == "" || n <= 0 {
+		return nil
+	}
+	maxDistance := max(2, len([]rune(query))/3)
+
+	type candidate struct {
+		name  string
+		tier  int
+		score int
+	}
+	seen := map[string]bool{}
+	var candidates []candidate
+	for _, ch := range chans {
+		name := strings.ToLower(ch.Name)
+		if name == "" || ch.IsArchived || seen[name] {
+			continue
+		}
+		seen[name] = true
+		if strings.HasPrefix(name, query) {
+			candidates = append(candidates, candidate{name: ch.Name, tier: 0, score: len(name) - len(query)})
+			continue
+		}
+		if d := levenshtein(query, name, maxDistance); d <= maxDistance {
+			candidates = append(candidates, candidate{name: ch.Name, tier: 1, score: d})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.tier != b.tier {
+			return a.tier < b.tier
+		}
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		return a.name < b.name
+	})
+
+	names := make([]string, 0, min(n, len(candidates)))
+	for _, c := range candidates[:min(n, len(candidates))] {
+		names = append(names, c.name)
+	}
+	return names
+}
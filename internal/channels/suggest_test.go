@@ -0,0 +1,67 @@
+package channels
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/cache"
+)
+
+func namedChannels(names ...string) []slackapi.Channel {
+	chans := make([]slackapi.Channel, len(names))
+	for i, name := range names {
+		chans[i].ID = "C" + strings.ToUpper(name)
+		chans[i].Name = name
+	}
+	return chans
+}
+
+func TestClosestNames(t *testing.T) {
+	chans := namedChannels("deploys", "deploys-staging", "deploy-bot", "dev", "general", "random")
+	archived := namedChannels("deploys-old")
+	archived[0].IsArchived = true
+	chans = append(chans, archived...)
+
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"deploy", []string{"deploys", "deploy-bot", "deploys-staging"}},
+		{"#Deplyos", []string{"deploys"}},
+		{"genral", []string{"general"}},
+		{"randon", []string{"random"}},
+		{"incidents", []string{}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got := closestNames(tt.input, chans, 3)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("closestNames(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestResolverResolveID_Suggestions(t *testing.T) {
+	client := &resolverMockClient{responses: [][]slackapi.Channel{namedChannels("deploys", "general")}}
+	resolver := NewResolver(client)
+	_, err := resolver.ResolveID(context.Background(), "#deplys")
+	if err == nil || strings.Contains(err.Error(), "Did you mean") {
+		t.Fatalf("without suggestions: error = %v", err)
+	}
+
+	store := cache.New(t.TempDir(), cache.DefaultTTL)
+	client = &resolverMockClient{responses: [][]slackapi.Channel{namedChannels("deploys", "general")}}
+	resolver = NewCachedResolver(client, store)
+	resolver.SetSuggestions(3)
+	_, err = resolver.ResolveID(context.Background(), "#deplys")
+	if err == nil || !strings.Contains(err.Error(), "Did you mean: #deploys?") {
+		t.Fatalf("with suggestions: error = %v", err)
+	}
+}
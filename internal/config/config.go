@@ -7,14 +7,19 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/ratelimit"
 )
 
 const (
-	defaultConfigRelativePath = ".config/slack-cli/config.json"
-	currentVersion            = 1
-	RoleUser                  = "user"
-	RoleBot                   = "bot"
+	appDirName     = "slack-cli"
+	configFileName = "config.json"
+	currentVersion = 1
+	RoleUser       = "user"
+	RoleBot        = "bot"
 )
 
 // Config represents the configuration stored on disk.
@@ -23,14 +28,61 @@ const (
 //   - Bot token (xoxb-): SLACK_BOT_TOKEN env var
 //   - Client token (xoxc-): SLACK_CLIENT_TOKEN + SLACK_CLIENT_COOKIE env vars
 type Config struct {
-	Version   int            `json:"version"`
-	Role      string         `json:"role,omitempty"`
-	UserToken string         `json:"user_token"`
-	BotToken  string         `json:"bot_token,omitempty"`
-	AppToken  string         `json:"app_token,omitempty"`
-	Cookie    string         `json:"cookie,omitempty"`
-	Defaults  Defaults       `json:"defaults"`
-	Channels  map[string]ACL `json:"channels"`
+	Version   int    `json:"version"`
+	Role      string `json:"role,omitempty"`
+	UserToken string `json:"user_token"`
+	BotToken  string `json:"bot_token,omitempty"`
+	AppToken  string `json:"app_token,omitempty"`
+	Cookie    string `json:"cookie,omitempty"`
+	// CookieExpires is when the browser session behind Cookie ends (RFC 3339), as
+	// shown in the browser's cookie storage. Slack does not report it over the API.
+	CookieExpires string         `json:"cookie_expires,omitempty"`
+	Defaults      Defaults       `json:"defaults"`
+	Channels      map[string]ACL `json:"channels"`
+	// ChannelNaming declares the naming conventions checked by channels audit-names.
+	ChannelNaming *ChannelNaming `json:"channel_naming,omitempty"`
+	// QuietHours limits when mutating commands may run.
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+	// ReadOnly blocks every Slack call that changes the workspace.
+	ReadOnly bool `json:"read_only,omitempty"`
+	// AllowUserImpersonation lets commands change the workspace with a user token,
+	// acting as the person who owns it. Without it only reads work under role=user.
+	AllowUserImpersonation bool `json:"allow_user_impersonation,omitempty"`
+	// AllowedChannels, when set, are the only channels (IDs or #names) the CLI reads
+	// from or writes to. DeniedChannels are never touched.
+	AllowedChannels []string `json:"allowed_channels,omitempty"`
+	DeniedChannels  []string `json:"denied_channels,omitempty"`
+	// WatchChannels are the channels watch follows when --channel is not given.
+	WatchChannels []string `json:"watch_channels,omitempty"`
+	// Redaction masks secrets in outgoing messages.
+	Redaction *Redaction `json:"redaction,omitempty"`
+	// Scrub configures --scrub pii on fetched messages.
+	Scrub *Scrub `json:"scrub,omitempty"`
+	// DedupeWindow, such as "5m", makes messages send refuse text identical to a message
+	// it posted to the same channel within the window.
+	DedupeWindow string `json:"dedupe_window,omitempty"`
+	// DedupeAction is "reject" (default) or "warn", which posts anyway with a warning.
+	DedupeAction string `json:"dedupe_action,omitempty"`
+	// ExposeEmails lets users and usergroups commands print email addresses without
+	// --include-pii. Off by default so directory PII stays out of agent logs.
+	ExposeEmails bool `json:"expose_emails,omitempty"`
+	// ExcludeDeactivatedUsers makes @name lookups refuse deactivated accounts.
+	ExcludeDeactivatedUsers bool `json:"exclude_deactivated_users,omitempty"`
+	// Retries tunes how the shared Slack transport retries failed API calls.
+	Retries *Retries `json:"retries,omitempty"`
+	// CircuitBreaker tunes the per-method breakers that stop calls to failing endpoints.
+	CircuitBreaker *CircuitBreaker `json:"circuit_breaker,omitempty"`
+	// Budgets caps Slack API calls per method group across every invocation of the CLI,
+	// such as {"search": "10/min", "history": "40/min"}. Calls over budget wait for a
+	// free slot, or fail with --no-wait.
+	Budgets map[string]string `json:"budgets,omitempty"`
+	// OCRCommand is the program files fetch --ocr runs on an image, as an argument
+	// list such as ["tesseract", "{file}", "stdout"]; {file} is replaced by the image
+	// path and the text is read from its stdout. Tesseract is used when unset.
+	OCRCommand []string `json:"ocr_command,omitempty"`
+	// Expanders turn ticket references in message text, such as ABC-123 or #4567, into
+	// links in human output and transcripts.
+	Expanders []Expander `json:"expanders,omitempty"`
 }
 
 // Defaults groups general default options.
@@ -38,8 +90,19 @@ type Defaults struct {
 	OutputFormat   string `json:"output_format"`
 	IncludeBots    bool   `json:"include_bots"`
 	TextChunkLimit int    `json:"text_chunk_limit"`
+	// Flags holds per-command flag defaults keyed by command path and flag name,
+	// e.g. "messages.list.limit": 100 or "channels.list.types": ["public_channel"].
+	// The special key "output" ("json" or "human") applies to every command.
+	Flags map[string]interface{} `json:"flags,omitempty"`
 }
 
+// CookieExpiryWindow is how long before CookieExpires commands start warning that the
+// xoxc- session needs renewing.
+const CookieExpiryWindow = 7 * 24 * time.Hour
+
+// OutputFlagKey is the Defaults.Flags key selecting json or human output for every command.
+const OutputFlagKey = "output"
+
 // ACL describes per-channel rules.
 type ACL struct {
 	Name           string   `json:"name"`
@@ -47,8 +110,248 @@ type ACL struct {
 	AllowedUsers   []string `json:"allowed_users"`
 }
 
+// ChannelNaming describes workspace channel naming conventions. A name passes when it
+// matches Pattern (if set) and starts with one of Prefixes (if any are listed).
+type ChannelNaming struct {
+	Pattern  string   `json:"pattern,omitempty"`
+	Prefixes []string `json:"prefixes,omitempty"`
+	// TempPrefixes mark short-lived channels; the default is tmp-, temp-, and test-.
+	TempPrefixes []string `json:"temp_prefixes,omitempty"`
+}
+
+// Redaction configures the secrets filter on outgoing messages. The built-in patterns
+// (api_key, aws_access_key, aws_secret_key, jwt, email) apply unless listed in Skip.
+type Redaction struct {
+	// Disabled turns the filter off.
+	Disabled bool `json:"disabled,omitempty"`
+	// Skip lists built-in patterns to leave unmasked.
+	Skip []string `json:"skip,omitempty"`
+	// Patterns adds named regular expressions to mask. When one has a capture group,
+	// only the first group is masked.
+	Patterns map[string]string `json:"patterns,omitempty"`
+}
+
+// Scrub configures --scrub pii, which masks emails and phone numbers in fetched
+// messages before they are printed or exported.
+type Scrub struct {
+	// Patterns adds named regular expressions to mask. When one has a capture group,
+	// only the first group is masked.
+	Patterns map[string]string `json:"patterns,omitempty"`
+}
+
+// Expander links text matching Pattern to URL. URL refers to the match as $0 and to
+// capture groups as $1 or ${name}, as in regexp.Expand.
+type Expander struct {
+	Name    string `json:"name,omitempty"`
+	Pattern string `json:"pattern"`
+	URL     string `json:"url"`
+}
+
+// Retries configures automatic retries of Slack API calls. Unset fields keep their
+// defaults: 2 retries, 1s base backoff doubling up to 30s, on rate_limit and network.
+type Retries struct {
+	// Max is the number of retries after the first attempt; 0 disables retrying.
+	Max *int `json:"max,omitempty"`
+	// BaseBackoff is the wait before the first retry, such as "500ms". Each later
+	// retry waits twice as long as the one before.
+	BaseBackoff string `json:"base_backoff,omitempty"`
+	// MaxBackoff caps a single wait. A rate limit whose Retry-After exceeds it is
+	// returned to the caller instead of retried.
+	MaxBackoff string `json:"max_backoff,omitempty"`
+	// RetryOn lists the failure classes to retry: rate_limit (HTTP 429), network
+	// (calls that could not connect), and server (HTTP 5xx).
+	RetryOn []string `json:"retry_on,omitempty"`
+}
+
+// Retry failure classes.
+const (
+	RetryOnRateLimit = "rate_limit"
+	RetryOnNetwork   = "network"
+	RetryOnServer    = "server"
+)
+
+// RetrySettings is the parsed form of Retries with defaults applied.
+type RetrySettings struct {
+	Max         int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	RetryOn     []string
+}
+
+// RetrySettings returns the retry policy for Slack API calls.
+func (c *Config) RetrySettings() (RetrySettings, error) {
+	settings := RetrySettings{
+		Max:         2,
+		BaseBackoff: time.Second,
+		MaxBackoff:  30 * time.Second,
+		RetryOn:     []string{RetryOnRateLimit, RetryOnNetwork},
+	}
+	r := c.Retries
+	if r == nil {
+		return settings, nil
+	}
+	if r.Max != nil {
+		if *r.Max < 0 || *r.Max > 20 {
+			return RetrySettings{}, fmt.Errorf("retries.max must be between 0 and 20, got %d", *r.Max)
+		}
+		settings.Max = *r.Max
+	}
+	for _, d := range []struct {
+		key   string
+		raw   string
+		value *time.Duration
+	}{
+		{"retries.base_backoff", r.BaseBackoff, &settings.BaseBackoff},
+		{"retries.max_backoff", r.MaxBackoff, &settings.MaxBackoff},
+	} {
+		if d.raw == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.raw)
+		if err != nil || parsed <= 0 {
+			return RetrySettings{}, fmt.Errorf("%s must be a positive duration such as 500ms, got %q", d.key, d.raw)
+		}
+		*d.value = parsed
+	}
+	if settings.MaxBackoff < settings.BaseBackoff {
+		return RetrySettings{}, fmt.Errorf("retries.max_backoff (%s) must not be shorter than retries.base_backoff (%s)", settings.MaxBackoff, settings.BaseBackoff)
+	}
+	if r.RetryOn != nil {
+		settings.RetryOn = nil
+		for _, class := range r.RetryOn {
+			switch class {
+			case RetryOnRateLimit, RetryOnNetwork, RetryOnServer:
+				settings.RetryOn = append(settings.RetryOn, class)
+			default:
+				return RetrySettings{}, fmt.Errorf("retries.retry_on entries must be %s, %s, or %s, got %q", RetryOnRateLimit, RetryOnNetwork, RetryOnServer, class)
+			}
+		}
+	}
+	return settings, nil
+}
+
+// CircuitBreaker configures the per-method circuit breakers on Slack API calls. Unset
+// fields keep their defaults: open after 5 consecutive failures, for 1m.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures that opens a method's breaker;
+	// 0 disables the breakers.
+	Threshold *int `json:"threshold,omitempty"`
+	// Cooldown, such as "1m", is how long an open breaker refuses calls before letting
+	// a trial call through.
+	Cooldown string `json:"cooldown,omitempty"`
+}
+
+// BreakerSettings returns the circuit breaker threshold and cooldown.
+func (c *Config) BreakerSettings() (int, time.Duration, error) {
+	threshold, cooldown := 5, time.Minute
+	b := c.CircuitBreaker
+	if b == nil {
+		return threshold, cooldown, nil
+	}
+	if b.Threshold != nil {
+		if *b.Threshold < 0 {
+			return 0, 0, fmt.Errorf("circuit_breaker.threshold must not be negative, got %d", *b.Threshold)
+		}
+		threshold = *b.Threshold
+	}
+	if b.Cooldown != "" {
+		parsed, err := time.ParseDuration(b.Cooldown)
+		if err != nil || parsed <= 0 {
+			return 0, 0, fmt.Errorf("circuit_breaker.cooldown must be a positive duration such as 1m, got %q", b.Cooldown)
+		}
+		cooldown = parsed
+	}
+	return threshold, cooldown, nil
+}
+
+// BudgetSettings returns the parsed budgets keyed by group.
+func (c *Config) BudgetSettings() (map[string]ratelimit.Budget, error) {
+	if len(c.Budgets) == 0 {
+		return nil, nil
+	}
+	settings := make(map[string]ratelimit.Budget, len(c.Budgets))
+	for group, raw := range c.Budgets {
+		if _, ok := ratelimit.Groups[group]; !ok {
+			return nil, fmt.Errorf("budgets.%s: unknown group (valid: %s)", group, strings.Join(ratelimit.GroupNames(), ", "))
+		}
+		budget, err := ratelimit.ParseBudget(raw)
+		if err != nil {
+			return nil, fmt.Errorf("budgets.%s: %w", group, err)
+		}
+		settings[group] = budget
+	}
+	return settings, nil
+}
+
+// Dedupe actions.
+const (
+	DedupeActionReject = "reject"
+	DedupeActionWarn   = "warn"
+)
+
+// MaxDedupeWindow is the longest dedupe_window; sends are only remembered this long.
+const MaxDedupeWindow = 7 * 24 * time.Hour
+
+// DedupeSettings returns the parsed dedupe window and action. A zero window disables
+// deduplication.
+func (c *Config) DedupeSettings() (time.Duration, string, error) {
+	action := c.DedupeAction
+	if action == "" {
+		action = DedupeActionReject
+	}
+	if action != DedupeActionReject && action != DedupeActionWarn {
+		return 0, "", fmt.Errorf("dedupe_action must be %q or %q, got %q", DedupeActionReject, DedupeActionWarn, c.DedupeAction)
+	}
+	if c.DedupeWindow == "" {
+		return 0, action, nil
+	}
+	window, err := time.ParseDuration(c.DedupeWindow)
+	if err != nil || window <= 0 || window > MaxDedupeWindow {
+		return 0, "", fmt.Errorf("dedupe_window must be a duration between 1s and %s, got %q", MaxDedupeWindow, c.DedupeWindow)
+	}
+	return window, action, nil
+}
+
+// Quiet hours actions.
+const (
+	QuietActionReject = "reject"
+	QuietActionQueue  = "queue"
+)
+
+// QuietHours restricts mutating commands to a daily window. Channels overrides the
+// global window for specific channels, keyed by channel ID or #name; an override with
+// an empty Allowed lifts the restriction for that channel.
+type QuietHours struct {
+	QuietWindow
+	Channels map[string]QuietWindow `json:"channels,omitempty"`
+}
+
+// QuietWindow is a daily window when mutating commands may run.
+type QuietWindow struct {
+	// Allowed is the window, such as "09:00-18:00".
+	Allowed string `json:"allowed,omitempty"`
+	// Weekends allows the window on Saturday and Sunday too.
+	Weekends bool `json:"weekends,omitempty"`
+	// Timezone is an IANA zone name; the default is the local zone.
+	Timezone string `json:"timezone,omitempty"`
+	// Action is what happens outside the window: "reject" (default) or "queue", which
+	// schedules messages for the next opening. Commands that cannot be queued are rejected.
+	Action string `json:"action,omitempty"`
+}
+
 // Load reads configuration from disk, applying defaults and env overrides.
 func Load(path string) (*Config, string, error) {
+	cfg, actualPath, err := LoadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	applyEnvOverrides(cfg)
+	return cfg, actualPath, nil
+}
+
+// LoadFile reads configuration from disk without env overrides, for commands that
+// edit and save the file and must not persist values that only came from the environment.
+func LoadFile(path string) (*Config, string, error) {
 	actualPath, err := resolvePath(path)
 	if err != nil {
 		return nil, "", fmt.Errorf("resolve config path: %w", err)
@@ -66,11 +369,39 @@ func Load(path string) (*Config, string, error) {
 	} else if !errors.Is(err, fs.ErrNotExist) {
 		return nil, "", fmt.Errorf("stat config: %w", err)
 	}
-
-	applyEnvOverrides(cfg)
 	return cfg, actualPath, nil
 }
 
+// FlagDefaults returns the configured flag defaults for a command path such as
+// "messages.list", keyed by flag name.
+func (c *Config) FlagDefaults(commandPath string) map[string]interface{} {
+	defaults := map[string]interface{}{}
+	if c == nil || commandPath == "" {
+		return defaults
+	}
+	prefix := commandPath + "."
+	for key, value := range c.Defaults.Flags {
+		name := strings.TrimPrefix(key, prefix)
+		if name == key || name == "" || strings.Contains(name, ".") {
+			continue
+		}
+		defaults[name] = value
+	}
+	return defaults
+}
+
+// SetFlagDefault stores a flag default, or removes it when value is nil.
+func (c *Config) SetFlagDefault(key string, value interface{}) {
+	if value == nil {
+		delete(c.Defaults.Flags, key)
+		return
+	}
+	if c.Defaults.Flags == nil {
+		c.Defaults.Flags = map[string]interface{}{}
+	}
+	c.Defaults.Flags[key] = value
+}
+
 // Save writes the configuration to disk, ensuring directories exist.
 func Save(path string, cfg *Config) (string, error) {
 	if cfg == nil {
@@ -91,6 +422,11 @@ func Save(path string, cfg *Config) (string, error) {
 	if err := os.WriteFile(actualPath, data, 0o600); err != nil {
 		return "", fmt.Errorf("write config: %w", err)
 	}
+	// WriteFile keeps the mode of an existing file; tokens and cookies must not be
+	// readable by other users even if the file was created by hand.
+	if err := os.Chmod(actualPath, 0o600); err != nil {
+		return "", fmt.Errorf("restrict config permissions: %w", err)
+	}
 	return actualPath, nil
 }
 
@@ -116,6 +452,18 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// CookieExpiry returns the parsed CookieExpires, if one is recorded.
+func (c *Config) CookieExpiry() (time.Time, bool) {
+	if c == nil || strings.TrimSpace(c.CookieExpires) == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(c.CookieExpires))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 // ActiveAuth returns the Slack API token/cookie selected by the configured role.
 func (c *Config) ActiveAuth() (token string, cookie string, role string, err error) {
 	if c == nil {
@@ -151,28 +499,89 @@ func (c *Config) ActiveAuth() (token string, cookie string, role string, err err
 
 func resolvePath(path string) (string, error) {
 	if path == "" {
-		path = filepath.Join("~", defaultConfigRelativePath)
+		path = strings.TrimSpace(os.Getenv("SLACK_CLI_CONFIG"))
+	}
+	if path == "" || path == "~" {
+		dir, err := DefaultDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, configFileName), nil
 	}
-	if strings.HasPrefix(path, "~/") || path == "~" {
+	if strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
 		if err != nil {
 			return "", fmt.Errorf("determine home directory: %w", err)
 		}
-		if path == "~" {
-			return filepath.Join(home, defaultConfigRelativePath), nil
-		}
 		return filepath.Join(home, path[2:]), nil
 	}
 	return path, nil
 }
 
+var portable bool
+
+// SetPortable makes DefaultDir a slack-cli directory next to the executable, for
+// installs that must not write to the user's home (set by the global --portable flag).
+func SetPortable(enabled bool) {
+	portable = enabled
+}
+
+// DefaultDir returns the directory holding the config file, cache, and other local
+// state. In order of preference: next to the executable in portable mode,
+// $XDG_CONFIG_HOME/slack-cli, %APPDATA%\slack-cli on Windows, then ~/.config/slack-cli.
+// A config already in ~/.config/slack-cli keeps being used until one exists in the
+// preferred location.
+func DefaultDir() (string, error) {
+	if portable {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", fmt.Errorf("locate executable for portable mode: %w", err)
+		}
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		return filepath.Join(filepath.Dir(exe), appDirName), nil
+	}
+
+	home, homeErr := os.UserHomeDir()
+	legacy := ""
+	if homeErr == nil {
+		legacy = filepath.Join(home, ".config", appDirName)
+	}
+	base := ""
+	if xdg := strings.TrimSpace(os.Getenv("XDG_CONFIG_HOME")); filepath.IsAbs(xdg) {
+		base = xdg
+	} else if runtime.GOOS == "windows" {
+		base = strings.TrimSpace(os.Getenv("APPDATA"))
+	}
+	if base == "" {
+		if homeErr != nil {
+			return "", fmt.Errorf("determine home directory: %w", homeErr)
+		}
+		return legacy, nil
+	}
+	dir := filepath.Join(base, appDirName)
+	if legacy != "" && dir != legacy && !fileExists(filepath.Join(dir, configFileName)) && fileExists(filepath.Join(legacy, configFileName)) {
+		return legacy, nil
+	}
+	return dir, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 func applyEnvOverrides(cfg *Config) {
 	// Client token (xoxc-) - extracted from browser/desktop (lowest priority)
 	if val := os.Getenv("SLACK_CLIENT_TOKEN"); val != "" {
 		cfg.UserToken = val
 	}
-	// Cookie for client tokens
+	// Cookie for client tokens. The stored expiry describes the stored cookie only.
 	if val := os.Getenv("SLACK_CLIENT_COOKIE"); val != "" {
+		if val != cfg.Cookie {
+			cfg.CookieExpires = ""
+		}
 		cfg.Cookie = val
 	}
 	// OAuth user token (xoxp-) - highest priority, preferred auth method
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadDefaultWhenMissing(t *testing.T) {
@@ -98,6 +101,36 @@ func TestApplyEnvOverridesClientToken(t *testing.T) {
 	}
 }
 
+func TestApplyEnvOverridesCookieClearsStoredExpiry(t *testing.T) {
+	t.Setenv("SLACK_CLIENT_COOKIE", "xoxd-other")
+
+	cfg := DefaultConfig()
+	cfg.Cookie = "xoxd-stored"
+	cfg.CookieExpires = "2026-12-31T00:00:00Z"
+	applyEnvOverrides(cfg)
+
+	if _, ok := cfg.CookieExpiry(); ok {
+		t.Fatalf("expiry of the stored cookie should not apply to SLACK_CLIENT_COOKIE, got %q", cfg.CookieExpires)
+	}
+}
+
+func TestSaveRestrictsPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Save(path, DefaultConfig()); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Fatalf("config mode = %o, want 600", mode)
+	}
+}
+
 func TestApplyEnvOverridesUserTokenTakesPrecedence(t *testing.T) {
 	// When both are set, SLACK_USER_TOKEN should win
 	t.Setenv("SLACK_USER_TOKEN", "xoxp-user")
@@ -166,3 +199,142 @@ func TestActiveAuth(t *testing.T) {
 		t.Fatalf("expected bot auth, got token=%q cookie=%q role=%q", token, cookie, role)
 	}
 }
+
+func TestFlagDefaults(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SetFlagDefault("messages.list.limit", 100)
+	cfg.SetFlagDefault("messages.list.thread.limit", 5)
+	cfg.SetFlagDefault("messages.search.limit", 20)
+	cfg.SetFlagDefault(OutputFlagKey, "json")
+
+	defaults := cfg.FlagDefaults("messages.list")
+	if len(defaults) != 1 || defaults["limit"] != 100 {
+		t.Fatalf("unexpected defaults for messages.list: %v", defaults)
+	}
+
+	cfg.SetFlagDefault("messages.list.limit", nil)
+	if len(cfg.FlagDefaults("messages.list")) != 0 {
+		t.Fatal("expected unset default to be removed")
+	}
+}
+
+func TestLoadFileSkipsEnvOverrides(t *testing.T) {
+	t.Setenv("SLACK_USER_TOKEN", "xoxp-env")
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := DefaultConfig()
+	cfg.UserToken = "xoxp-file"
+	if _, err := Save(path, cfg); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	fileCfg, _, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if fileCfg.UserToken != "xoxp-file" {
+		t.Fatalf("expected file token, got %q", fileCfg.UserToken)
+	}
+	envCfg, _, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if envCfg.UserToken != "xoxp-env" {
+		t.Fatalf("expected env token, got %q", envCfg.UserToken)
+	}
+}
+
+func TestDefaultPathHonorsConfigEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.json")
+	t.Setenv("SLACK_CLI_CONFIG", path)
+
+	got, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath returned error: %v", err)
+	}
+	if got != path {
+		t.Fatalf("expected %s, got %s", path, got)
+	}
+}
+
+func TestRetrySettings(t *testing.T) {
+	cfg := DefaultConfig()
+	got, err := cfg.RetrySettings()
+	if err != nil {
+		t.Fatalf("RetrySettings() error = %v", err)
+	}
+	if got.Max != 2 || got.BaseBackoff != time.Second || got.MaxBackoff != 30*time.Second || len(got.RetryOn) != 2 {
+		t.Fatalf("default retry settings = %+v", got)
+	}
+
+	zero := 0
+	cfg.Retries = &Retries{Max: &zero, BaseBackoff: "500ms", RetryOn: []string{}}
+	got, err = cfg.RetrySettings()
+	if err != nil {
+		t.Fatalf("RetrySettings() error = %v", err)
+	}
+	if got.Max != 0 || got.BaseBackoff != 500*time.Millisecond || got.MaxBackoff != 30*time.Second || len(got.RetryOn) != 0 {
+		t.Fatalf("configured retry settings = %+v", got)
+	}
+}
+
+func TestBudgetSettings(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := cfg.Set("budgets.search", "10/min"); err != nil {
+		t.Fatalf("Set(budgets.search) error = %v", err)
+	}
+	got, err := cfg.BudgetSettings()
+	if err != nil {
+		t.Fatalf("BudgetSettings() error = %v", err)
+	}
+	if b := got["search"]; b.Calls != 10 || b.Per != time.Minute || len(got) != 1 {
+		t.Fatalf("budget settings = %+v", got)
+	}
+	if err := cfg.Set("budgets.chat", "10/min"); err == nil || !strings.Contains(err.Error(), "unknown group") {
+		t.Errorf("Set(budgets.chat) error = %v", err)
+	}
+	if err := cfg.Set("budgets.history", "fast"); err == nil {
+		t.Error("Set(budgets.history, fast) succeeded")
+	}
+}
+
+func TestDefaultDir(t *testing.T) {
+	home := t.TempDir()
+	xdg := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	dir, err := DefaultDir()
+	if err != nil {
+		t.Fatalf("DefaultDir: %v", err)
+	}
+	if want := filepath.Join(xdg, "slack-cli"); dir != want {
+		t.Fatalf("DefaultDir = %q, want %q", dir, want)
+	}
+
+	// An existing config in the legacy location keeps being used.
+	legacy := filepath.Join(home, ".config", "slack-cli")
+	if err := os.MkdirAll(legacy, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "config.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if dir, _ := DefaultDir(); dir != legacy {
+		t.Fatalf("DefaultDir with legacy config = %q, want %q", dir, legacy)
+	}
+
+	SetPortable(true)
+	defer SetPortable(false)
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if dir, _ := DefaultDir(); dir != filepath.Join(filepath.Dir(exe), "slack-cli") {
+		t.Fatalf("portable DefaultDir = %q, want next to %q", dir, exe)
+	}
+}
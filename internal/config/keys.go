@@ -0,0 +1,316 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// secretKeys are top-level keys whose values are masked by Masked.
+var secretKeys = map[string]bool{"user_token": true, "bot_token": true, "app_token": true, "cookie": true}
+
+// Get returns the value at a dotted key path such as "defaults.include_bots" or
+// "channels.C123.require_mention". An empty path returns the whole config.
+func (c *Config) Get(path string) (interface{}, error) {
+	tree, err := c.tree()
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return tree, nil
+	}
+	var node interface{} = tree
+	for _, part := range strings.Split(path, ".") {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", path)
+		}
+		if node, ok = m[part]; !ok {
+			return nil, fmt.Errorf("unknown key %q", path)
+		}
+	}
+	return node, nil
+}
+
+// Set assigns a value at a dotted key path. The raw value is used as JSON when it
+// parses as such and fits the field (numbers, booleans, lists), and as a plain string
+// otherwise. Unknown keys and invalid values are rejected without modifying c.
+func (c *Config) Set(path, raw string) error {
+	var candidates []interface{}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(raw), &decoded); err == nil {
+		candidates = append(candidates, decoded)
+	}
+	candidates = append(candidates, raw)
+
+	var lastErr error
+	for _, value := range candidates {
+		next, err := c.withValue(path, value, false)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err := next.ValidateSettings(); err != nil {
+			return err
+		}
+		*c = *next
+		return nil
+	}
+	return lastErr
+}
+
+// Unset removes the value at a dotted key path, restoring the field's default.
+func (c *Config) Unset(path string) error {
+	next, err := c.withValue(path, nil, true)
+	if err != nil {
+		return err
+	}
+	*c = *next
+	return nil
+}
+
+func (c *Config) withValue(path string, value interface{}, remove bool) (*Config, error) {
+	parts := strings.Split(path, ".")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid key %q", path)
+		}
+	}
+	tree, err := c.tree()
+	if err != nil {
+		return nil, err
+	}
+
+	node := tree
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := node[part].(map[string]interface{})
+		if !ok {
+			if remove {
+				return nil, fmt.Errorf("unknown key %q", path)
+			}
+			child = map[string]interface{}{}
+			node[part] = child
+		}
+		node = child
+	}
+	last := parts[len(parts)-1]
+	if remove {
+		if _, ok := node[last]; !ok {
+			return nil, fmt.Errorf("key %q is not set", path)
+		}
+		delete(node, last)
+	} else {
+		node[last] = value
+	}
+
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	// Decoding over DefaultConfig restores the default for a removed field.
+	next := DefaultConfig()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(next); err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", path, err)
+	}
+	return next, nil
+}
+
+func (c *Config) tree() (map[string]interface{}, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	tree := map[string]interface{}{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// ValidateSettings checks individual values (role, output format, token and cookie formats)
+// without requiring that credentials are present.
+func (c *Config) ValidateSettings() error {
+	if c == nil {
+		return errors.New("config is nil")
+	}
+	if role := strings.ToLower(strings.TrimSpace(c.Role)); role != "" && role != RoleUser && role != RoleBot {
+		return fmt.Errorf("role must be %q or %q, got %q", RoleUser, RoleBot, c.Role)
+	}
+	if f := c.Defaults.OutputFormat; f != "" && f != "json" && f != "human" {
+		return fmt.Errorf("defaults.output_format must be json or human, got %q", f)
+	}
+	if c.Defaults.TextChunkLimit < 0 {
+		return fmt.Errorf("defaults.text_chunk_limit must not be negative")
+	}
+	if c.ChannelNaming != nil && c.ChannelNaming.Pattern != "" {
+		if _, err := regexp.Compile(c.ChannelNaming.Pattern); err != nil {
+			return fmt.Errorf("channel_naming.pattern is not a valid regular expression: %v", err)
+		}
+	}
+	if _, _, err := c.DedupeSettings(); err != nil {
+		return err
+	}
+	if _, err := c.RetrySettings(); err != nil {
+		return err
+	}
+	if _, err := c.BudgetSettings(); err != nil {
+		return err
+	}
+	if _, _, err := c.BreakerSettings(); err != nil {
+		return err
+	}
+	if len(c.OCRCommand) > 0 && strings.TrimSpace(c.OCRCommand[0]) == "" {
+		return fmt.Errorf("ocr_command must start with a program name")
+	}
+	for key, list := range map[string][]string{"allowed_channels": c.AllowedChannels, "denied_channels": c.DeniedChannels, "watch_channels": c.WatchChannels} {
+		for _, entry := range list {
+			if strings.TrimSpace(entry) == "" {
+				return fmt.Errorf("%s must not contain empty entries", key)
+			}
+		}
+	}
+	if r := c.Redaction; r != nil {
+		if err := validatePatterns("redaction.patterns", r.Patterns); err != nil {
+			return err
+		}
+	}
+	if s := c.Scrub; s != nil {
+		if err := validatePatterns("scrub.patterns", s.Patterns); err != nil {
+			return err
+		}
+	}
+	if q := c.QuietHours; q != nil {
+		if err := q.QuietWindow.validate("quiet_hours"); err != nil {
+			return err
+		}
+		for key, window := range q.Channels {
+			if err := window.validate("quiet_hours.channels." + key); err != nil {
+				return err
+			}
+		}
+	}
+	if c.Cookie != "" && !strings.HasPrefix(c.Cookie, "xoxd-") {
+		return fmt.Errorf("cookie must be the value of the d cookie, starting with xoxd-")
+	}
+	if c.CookieExpires != "" {
+		if _, err := time.Parse(time.RFC3339, c.CookieExpires); err != nil {
+			return fmt.Errorf("cookie_expires must be an RFC 3339 time such as 2026-01-02T15:04:05Z")
+		}
+	}
+	prefixes := []struct {
+		key, value string
+		allowed    []string
+	}{
+		{"user_token", c.UserToken, []string{"xoxp-", "xoxc-"}},
+		{"bot_token", c.BotToken, []string{"xoxb-"}},
+		{"app_token", c.AppToken, []string{"xapp-"}},
+	}
+	for _, p := range prefixes {
+		if p.value == "" {
+			continue
+		}
+		ok := false
+		for _, prefix := range p.allowed {
+			ok = ok || strings.HasPrefix(p.value, prefix)
+		}
+		if !ok {
+			return fmt.Errorf("%s must start with %s", p.key, strings.Join(p.allowed, " or "))
+		}
+	}
+	return nil
+}
+
+func (w QuietWindow) validate(key string) error {
+	if w.Allowed != "" {
+		start, end, ok := strings.Cut(w.Allowed, "-")
+		startAt, errStart := time.Parse("15:04", strings.TrimSpace(start))
+		endAt, errEnd := time.Parse("15:04", strings.TrimSpace(end))
+		if !ok || errStart != nil || errEnd != nil || !endAt.After(startAt) {
+			return fmt.Errorf("%s.allowed must look like 09:00-18:00, got %q", key, w.Allowed)
+		}
+	}
+	if w.Timezone != "" {
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return fmt.Errorf("%s.timezone: %v", key, err)
+		}
+	}
+	if w.Action != "" && w.Action != QuietActionReject && w.Action != QuietActionQueue {
+		return fmt.Errorf("%s.action must be %q or %q, got %q", key, QuietActionReject, QuietActionQueue, w.Action)
+	}
+	return nil
+}
+
+// Masked returns a copy of a Get result with token and cookie values shortened so
+// they can be printed. path is the key the value was read from.
+func Masked(path string, value interface{}) interface{} {
+	if secretKeys[path] {
+		if s, ok := value.(string); ok {
+			return MaskSecret(s)
+		}
+		return value
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok || path != "" {
+		return value
+	}
+	masked := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		masked[k] = Masked(k, v)
+	}
+	return masked
+}
+
+// MaskSecret shortens a token or cookie to its prefix and last four characters.
+func MaskSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	prefix := ""
+	if i := strings.Index(s, "-"); i >= 0 && i < 6 {
+		prefix = s[:i+1]
+	}
+	if len(s) <= len(prefix)+8 {
+		return prefix + "****"
+	}
+	return prefix + "****" + s[len(s)-4:]
+}
+
+// Flatten turns a Get result into sorted "key = value" lines.
+func Flatten(path string, value interface{}) []string {
+	var lines []string
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+			for k, child := range m {
+				key := k
+				if prefix != "" {
+					key = prefix + "." + k
+				}
+				walk(key, child)
+			}
+			return
+		}
+		encoded, _ := json.Marshal(v)
+		lines = append(lines, fmt.Sprintf("%s = %s", prefix, encoded))
+	}
+	walk(path, value)
+	sort.Strings(lines)
+	return lines
+}
+
+// validatePatterns checks that every named regular expression compiles.
+func validatePatterns(key string, patterns map[string]string) error {
+	for name, expr := range patterns {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("%s.%s is not a valid regular expression: %v", key, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Package dedupe remembers the Slack event IDs a stream has handled, so events Slack
+// delivers again (after a reconnect or a missed acknowledgement) reach downstream
+// handlers once. The window is saved to disk, so a restarted stream still recognizes
+// events it handled before it stopped.
+package dedupe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Version is the current window file format.
+const Version = 1
+
+// Window is the set of event IDs handled within the last Duration. It is safe for
+// concurrent use.
+type Window struct {
+	mu       sync.Mutex
+	path     string
+	duration time.Duration
+	seen     map[string]time.Time
+}
+
+type windowFile struct {
+	Version int                  `json:"version"`
+	Seen    map[string]time.Time `json:"seen"`
+}
+
+// DefaultPath returns the window path inside a team's cache directory.
+func DefaultPath(cacheDir string) string {
+	return filepath.Join(cacheDir, "events-seen.json")
+}
+
+// Open reads the window file at path, or starts an empty window when it does not
+// exist. IDs older than duration at now are dropped.
+func Open(path string, duration time.Duration, now time.Time) (*Window, error) {
+	w := &Window{path: path, duration: duration, seen: map[string]time.Time{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return w, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read event dedupe window: %w", err)
+	}
+	var file windowFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse event dedupe window %s: %w", path, err)
+	}
+	for id, at := range file.Seen {
+		w.seen[id] = at
+	}
+	w.prune(now)
+	return w, nil
+}
+
+// Seen reports whether eventID was handled within the window, and records it at now
+// when it was not. Events without an ID are never duplicates.
+func (w *Window) Seen(eventID string, now time.Time) bool {
+	if eventID == "" {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if at, ok := w.seen[eventID]; ok && now.Sub(at) < w.duration {
+		return true
+	}
+	w.seen[eventID] = now
+	return false
+}
+
+// Len returns the number of IDs in the window.
+func (w *Window) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.seen)
+}
+
+// Save drops IDs older than the window at now and atomically writes the rest back to
+// the file.
+func (w *Window) Save(now time.Time) error {
+	w.mu.Lock()
+	w.prune(now)
+	data, err := json.Marshal(windowFile{Version: Version, Seen: w.seen})
+	w.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("marshal event dedupe window: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(w.path), 0o700); err != nil {
+		return fmt.Errorf("create event dedupe window dir: %w", err)
+	}
+	tmp := w.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write event dedupe window tmp: %w", err)
+	}
+	if err := os.Rename(tmp, w.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename event dedupe window tmp: %w", err)
+	}
+	return nil
+}
+
+func (w *Window) prune(now time.Time) {
+	for id, at := range w.seen {
+		if now.Sub(at) >= w.duration {
+			delete(w.seen, id)
+		}
+	}
+}
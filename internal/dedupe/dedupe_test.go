@@ -0,0 +1,44 @@
+package dedupe
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "team", "events-seen.json")
+	w, err := Open(path, 10*time.Minute, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Seen("Ev1", now) {
+		t.Error("first delivery reported as seen")
+	}
+	if !w.Seen("Ev1", now.Add(time.Minute)) {
+		t.Error("redelivery within the window not reported as seen")
+	}
+	if w.Seen("", now) || w.Seen("", now) {
+		t.Error("event without an ID reported as seen")
+	}
+	w.Seen("Ev2", now.Add(5*time.Minute))
+	if err := w.Save(now.Add(5 * time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A restarted stream still knows Ev2; Ev1 has aged out.
+	reopened, err := Open(path, 10*time.Minute, now.Add(12*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 1 {
+		t.Errorf("Len = %d, want 1", reopened.Len())
+	}
+	if !reopened.Seen("Ev2", now.Add(12*time.Minute)) {
+		t.Error("Ev2 not remembered across restart")
+	}
+	if reopened.Seen("Ev1", now.Add(12*time.Minute)) {
+		t.Error("expired ID reported as seen")
+	}
+}
@@ -0,0 +1,183 @@
+// Package dms lists direct message conversations with their latest activity.
+package dms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/channels"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+const pageSize = 200
+
+// ListClient defines the Slack operations needed to list DMs.
+type ListClient interface {
+	channels.ActivityClient
+	ListChannels(ctx context.Context, params slack.ListChannelsParams) ([]slackapi.Channel, string, error)
+}
+
+// ListParams describes input for List.
+type ListParams struct {
+	// IncludeClosed keeps DMs that are not open in the sidebar.
+	IncludeClosed bool
+	// IncludeGroups adds multi-person DMs (mpim:read).
+	IncludeGroups bool
+	// Concurrency bounds the conversations.info and history calls in flight.
+	Concurrency int
+}
+
+// DM is a direct message conversation.
+type DM struct {
+	ChannelID string `json:"channel_id"`
+	// UserID and User identify the other person; both are empty for group DMs.
+	UserID   string            `json:"user_id,omitempty"`
+	User     string            `json:"user,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	IsGroup  bool              `json:"is_group,omitempty"`
+	IsOpen   bool              `json:"is_open"`
+	Activity channels.Activity `json:"activity"`
+}
+
+// ListResult holds DMs, most recently active first.
+type ListResult struct {
+	OK  bool `json:"ok"`
+	DMs []DM `json:"dms"`
+}
+
+// List returns the calling user's DMs with each one's latest message. Slack lists
+// every DM the user has ever had, so DMs whose conversations.info reports them
+// closed are dropped unless IncludeClosed is set; a DM whose info could not be read
+// is kept, with the error in its activity.
+func List(ctx context.Context, client ListClient, params ListParams) (ListResult, error) {
+	types := []string{"im"}
+	if params.IncludeGroups {
+		types = append(types, "mpim")
+	}
+	var convs []slackapi.Channel
+	cursor := ""
+	for {
+		page, next, err := client.ListChannels(ctx, slack.ListChannelsParams{Limit: pageSize, Cursor: cursor, Types: types})
+		if err != nil {
+			return ListResult{}, fmt.Errorf("list dms: %w", err)
+		}
+		convs = append(convs, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	activity := channels.FetchActivity(ctx, client, convs, params.Concurrency)
+	result := ListResult{OK: true, DMs: []DM{}}
+	for _, ch := range convs {
+		a := activity[ch.ID]
+		if !ch.IsOpen && a.Error == "" && !params.IncludeClosed {
+			continue
+		}
+		dm := DM{
+			ChannelID: ch.ID,
+			UserID:    ch.User,
+			IsGroup:   ch.IsMpIM,
+			IsOpen:    ch.IsOpen,
+			Activity:  a,
+		}
+		if ch.IsMpIM {
+			dm.UserID = ""
+			dm.Name = ch.Name
+		}
+		result.DMs = append(result.DMs, dm)
+	}
+	sort.SliceStable(result.DMs, func(i, j int) bool {
+		return result.DMs[i].Activity.LastTS > result.DMs[j].Activity.LastTS
+	})
+	return result, nil
+}
+
+// Lines implements output.Printable.
+func (r ListResult) Lines() []string {
+	if len(r.DMs) == 0 {
+		return []string{"No open DMs"}
+	}
+	lines := make([]string, 0, len(r.DMs))
+	for _, dm := range r.DMs {
+		who := dm.Name
+		if !dm.IsGroup {
+			who = dm.User
+			if who == "" {
+				who = dm.UserID
+			}
+		}
+		line := fmt.Sprintf("%s (%s)", who, dm.ChannelID)
+		if !dm.IsOpen {
+			line += ", closed"
+		}
+		lines = append(lines, line+dm.Activity.Summary())
+	}
+	return lines
+}
+
+// OpenResult is the result of opening a DM.
+type OpenResult struct {
+	OK          bool   `json:"ok"`
+	User        string `json:"user"`
+	UserID      string `json:"user_id"`
+	ChannelID   string `json:"channel_id"`
+	AlreadyOpen bool   `json:"already_open"`
+}
+
+// Lines implements output.Printable.
+func (r OpenResult) Lines() []string {
+	if r.AlreadyOpen {
+		return []string{fmt.Sprintf("DM with %s is already open: %s", r.User, r.ChannelID)}
+	}
+	return []string{fmt.Sprintf("✓ Opened DM with %s: %s", r.User, r.ChannelID)}
+}
+
+// CloseResult is the result of closing a DM.
+type CloseResult struct {
+	OK            bool   `json:"ok"`
+	User          string `json:"user,omitempty"`
+	UserID        string `json:"user_id,omitempty"`
+	ChannelID     string `json:"channel_id"`
+	AlreadyClosed bool   `json:"already_closed"`
+}
+
+// Lines implements output.Printable.
+func (r CloseResult) Lines() []string {
+	target := r.ChannelID
+	if r.User != "" {
+		target = fmt.Sprintf("%s (%s)", r.User, r.ChannelID)
+	}
+	if r.AlreadyClosed {
+		return []string{fmt.Sprintf("DM %s was already closed", target)}
+	}
+	return []string{fmt.Sprintf("✓ Closed DM %s", target)}
+}
+
+// CreateResult is the result of opening a group DM and posting its first message.
+type CreateResult struct {
+	OK          bool     `json:"ok"`
+	Users       []string `json:"users"`
+	UserIDs     []string `json:"user_ids"`
+	ChannelID   string   `json:"channel_id"`
+	AlreadyOpen bool     `json:"already_open"`
+	TS          string   `json:"ts"`
+	Text        string   `json:"text"`
+}
+
+// Lines implements output.Printable.
+func (r CreateResult) Lines() []string {
+	verb := "Opened"
+	if r.AlreadyOpen {
+		verb = "Reused"
+	}
+	return []string{
+		fmt.Sprintf("✓ %s DM with %s: %s", verb, strings.Join(r.Users, ", "), r.ChannelID),
+		"✓ Posted message " + r.TS,
+	}
+}
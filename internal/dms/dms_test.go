@@ -0,0 +1,101 @@
+package dms
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+type fakeClient struct {
+	pages   [][]slackapi.Channel
+	info    map[string]*slackapi.Channel
+	latest  map[string]string
+	gotType []string
+}
+
+func (f *fakeClient) ListChannels(ctx context.Context, params slack.ListChannelsParams) ([]slackapi.Channel, string, error) {
+	f.gotType = params.Types
+	page := 0
+	if params.Cursor != "" {
+		page = 1
+	}
+	next := ""
+	if page+1 < len(f.pages) {
+		next = "page2"
+	}
+	return f.pages[page], next, nil
+}
+
+func (f *fakeClient) GetConversationInfo(ctx context.Context, channelID string) (*slackapi.Channel, error) {
+	if info, ok := f.info[channelID]; ok {
+		return info, nil
+	}
+	return nil, errors.New("channel_not_found")
+}
+
+func (f *fakeClient) ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error) {
+	resp := &slackapi.GetConversationHistoryResponse{}
+	if ts, ok := f.latest[params.Channel]; ok {
+		resp.Messages = []slackapi.Message{{Msg: slackapi.Msg{Timestamp: ts, Text: "hi"}}}
+	}
+	return resp, nil
+}
+
+func im(id, user string) slackapi.Channel {
+	var ch slackapi.Channel
+	ch.ID, ch.User, ch.IsIM = id, user, true
+	return ch
+}
+
+func openInfo(open bool) *slackapi.Channel {
+	var ch slackapi.Channel
+	ch.IsOpen = open
+	return &ch
+}
+
+func TestList(t *testing.T) {
+	client := &fakeClient{
+		pages: [][]slackapi.Channel{{im("D1", "U1"), im("D2", "U2")}, {im("D3", "U3"), im("D4", "U4")}},
+		info:  map[string]*slackapi.Channel{"D1": openInfo(true), "D2": openInfo(false), "D3": openInfo(true)},
+		latest: map[string]string{
+			"D1": "1700000001.000000",
+			"D2": "1700000003.000000",
+			"D3": "1700000002.000000",
+		},
+	}
+
+	result, err := List(context.Background(), client, ListParams{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(client.gotType, []string{"im"}) {
+		t.Fatalf("types = %v, want [im]", client.gotType)
+	}
+	var got []string
+	for _, dm := range result.DMs {
+		got = append(got, dm.ChannelID)
+	}
+	// D2 is closed; D4's info failed, so it is kept with the error and sorts last.
+	if want := []string{"D3", "D1", "D4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("DMs = %v, want %v", got, want)
+	}
+	if result.DMs[0].UserID != "U3" || result.DMs[0].Activity.Preview != "hi" || result.DMs[2].Activity.Error == "" {
+		t.Fatalf("DMs = %+v", result.DMs)
+	}
+
+	result, err = List(context.Background(), client, ListParams{IncludeClosed: true, IncludeGroups: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DMs) != 4 || result.DMs[0].ChannelID != "D2" || result.DMs[0].IsOpen {
+		t.Fatalf("with closed DMs = %+v", result.DMs)
+	}
+	if !reflect.DeepEqual(client.gotType, []string{"im", "mpim"}) {
+		t.Fatalf("types = %v, want [im mpim]", client.gotType)
+	}
+}
@@ -0,0 +1,41 @@
+package dms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/channels"
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	last := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	unread := 3
+	tests := []struct {
+		name string
+		p    output.Printable
+	}{
+		{"ListResult", ListResult{OK: true, DMs: []DM{
+			{ChannelID: "D1", UserID: "U1", User: "alice", IsOpen: true,
+				Activity: channels.Activity{LastActivity: &last, Preview: "see you at standup", UnreadCount: &unread}},
+			{ChannelID: "D2", UserID: "U2", IsOpen: false},
+			{ChannelID: "G1", Name: "mpdm-alice--bob--carol-1", IsGroup: true, IsOpen: true,
+				Activity: channels.Activity{Error: "missing_scope"}},
+		}}},
+		{"ListResult_empty", ListResult{OK: true, DMs: []DM{}}},
+		{"OpenResult", OpenResult{OK: true, User: "alice", UserID: "U1", ChannelID: "D1"}},
+		{"OpenResult_already_open", OpenResult{OK: true, User: "alice", UserID: "U1", ChannelID: "D1", AlreadyOpen: true}},
+		{"CreateResult", CreateResult{OK: true, Users: []string{"@alice", "@bob", "@carol"}, UserIDs: []string{"U1", "U2", "U3"},
+			ChannelID: "C0MPIM", TS: "1705312365.000100", Text: "Kicking off incident bridge"}},
+		{"CreateResult_already_open", CreateResult{OK: true, Users: []string{"@alice", "@bob"}, UserIDs: []string{"U1", "U2"},
+			ChannelID: "C0MPIM", AlreadyOpen: true, TS: "1705312400.000200", Text: "Still on it"}},
+		{"CloseResult", CloseResult{OK: true, User: "alice", UserID: "U1", ChannelID: "D1"}},
+		{"CloseResult_already_closed", CloseResult{OK: true, ChannelID: "D1", AlreadyClosed: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package emoji
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/cache"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"thumbsup":                      "+1",
+		":thumbsup:":                    "+1",
+		" :+1: ":                        "+1",
+		"👍":                             "+1",
+		"👍🏽":                            "+1::skin-tone-4",
+		":+1::skin-tone-2:":             "+1::skin-tone-2",
+		"❤️":                            "heart",
+		"❤":                             "heart",
+		"✅":                             "white_check_mark",
+		"Party_Parrot":                  "party_parrot",
+		":了解:":                          "了解",
+		"white_check_mark":              "white_check_mark",
+		"wave::skin-tone-6":             "wave::skin-tone-6",
+		":simple_smile:":                "simple_smile",
+		"rolling_on_the_floor_laughing": "rolling_on_the_floor_laughing",
+	}
+	for input, want := range tests {
+		got, err := Normalize(input)
+		if err != nil || got != want {
+			t.Errorf("Normalize(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, bad := range []string{"", "::", "🫨", "wave::skin-tone-9", "two words", "a:b"} {
+		if got, err := Normalize(bad); err == nil {
+			t.Errorf("Normalize(%q) = %q, want error", bad, got)
+		}
+	}
+}
+
+type fakeEmojiClient struct {
+	emoji map[string]string
+	calls int
+}
+
+func (f *fakeEmojiClient) ListEmoji(ctx context.Context) (*slack.EmojiListResult, error) {
+	f.calls++
+	return &slack.EmojiListResult{OK: true, Emoji: f.emoji, Count: len(f.emoji)}, nil
+}
+
+func TestResolverFollowsCustomAliases(t *testing.T) {
+	client := &fakeEmojiClient{emoji: map[string]string{
+		"party_parrot": "https://emoji.slack-edge.com/T1/party_parrot/abc.gif",
+		"parrot":       "alias:party_parrot",
+		"lgtm":         "alias:white_check_mark",
+		"shipit":       "alias:lgtm",
+	}}
+	r := NewCachedResolver(client, cache.New(t.TempDir(), 0))
+	ctx := context.Background()
+
+	for input, want := range map[string]string{
+		":parrot:":            "party_parrot",
+		"shipit":              "white_check_mark",
+		"party_parrot":        "party_parrot",
+		":lgtm::skin-tone-2:": "white_check_mark::skin-tone-2",
+		"unknown_custom":      "unknown_custom",
+	} {
+		got, err := r.Resolve(ctx, input)
+		if err != nil || got != want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("emoji.list called %d times, want 1 (cached afterwards)", client.calls)
+	}
+
+	if _, err := r.Resolve(ctx, "👍"); err != nil || client.calls != 1 {
+		t.Errorf("built-in emoji triggered a lookup (calls = %d, err = %v)", client.calls, err)
+	}
+}
@@ -0,0 +1,278 @@
+package emoji
+
+// unicodeNames maps Unicode emoji (without the U+FE0F variation selector or a skin
+// tone modifier) to their Slack shortcodes. It covers the emoji commonly used as
+// reactions; others can still be passed by name.
+var unicodeNames = map[string]string{
+	"😀": "grinning",
+	"😃": "smiley",
+	"😄": "smile",
+	"😁": "grin",
+	"😆": "laughing",
+	"😅": "sweat_smile",
+	"🤣": "rolling_on_the_floor_laughing",
+	"😂": "joy",
+	"🙂": "slightly_smiling_face",
+	"🙃": "upside_down_face",
+	"🫠": "melting_face",
+	"😉": "wink",
+	"😊": "blush",
+	"😇": "innocent",
+	"🥰": "smiling_face_with_3_hearts",
+	"😍": "heart_eyes",
+	"🤩": "star-struck",
+	"😘": "kissing_heart",
+	"🥲": "smiling_face_with_tear",
+	"😋": "yum",
+	"😛": "stuck_out_tongue",
+	"😜": "stuck_out_tongue_winking_eye",
+	"🤪": "zany_face",
+	"🤗": "hugging_face",
+	"🤭": "face_with_hand_over_mouth",
+	"🫡": "saluting_face",
+	"🤫": "shushing_face",
+	"🤔": "thinking_face",
+	"🤐": "zipper_mouth_face",
+	"🤨": "face_with_raised_eyebrow",
+	"😐": "neutral_face",
+	"😑": "expressionless",
+	"😶": "no_mouth",
+	"😏": "smirk",
+	"😒": "unamused",
+	"🙄": "face_with_rolling_eyes",
+	"😬": "grimacing",
+	"😌": "relieved",
+	"😔": "pensive",
+	"😪": "sleepy",
+	"😴": "sleeping",
+	"😷": "mask",
+	"🤒": "face_with_thermometer",
+	"🤕": "face_with_head_bandage",
+	"🤢": "nauseated_face",
+	"🤯": "exploding_head",
+	"🥳": "partying_face",
+	"😎": "sunglasses",
+	"🤓": "nerd_face",
+	"😕": "confused",
+	"😟": "worried",
+	"🙁": "slightly_frowning_face",
+	"😮": "open_mouth",
+	"😯": "hushed",
+	"😲": "astonished",
+	"😳": "flushed",
+	"🥺": "pleading_face",
+	"😨": "fearful",
+	"😰": "cold_sweat",
+	"😥": "disappointed_relieved",
+	"😢": "cry",
+	"😭": "sob",
+	"😱": "scream",
+	"😖": "confounded",
+	"😣": "persevere",
+	"😞": "disappointed",
+	"😓": "sweat",
+	"😩": "weary",
+	"😫": "tired_face",
+	"🥱": "yawning_face",
+	"😤": "triumph",
+	"😡": "rage",
+	"😠": "angry",
+	"🤬": "face_with_symbols_on_mouth",
+	"😈": "smiling_imp",
+	"💀": "skull",
+	"💩": "hankey",
+	"🤡": "clown_face",
+	"👻": "ghost",
+	"👽": "alien",
+	"🤖": "robot_face",
+	"🙈": "see_no_evil",
+	"🙉": "hear_no_evil",
+	"🙊": "speak_no_evil",
+	"💯": "100",
+	"💢": "anger",
+	"💥": "boom",
+	"💫": "dizzy",
+	"💦": "sweat_drops",
+	"💨": "dash",
+	"💬": "speech_balloon",
+	"💭": "thought_balloon",
+	"💤": "zzz",
+	"👋": "wave",
+	"🤚": "raised_back_of_hand",
+	"✋": "hand",
+	"🖖": "spock-hand",
+	"👌": "ok_hand",
+	"🤌": "pinched_fingers",
+	"✌": "v",
+	"🤞": "crossed_fingers",
+	"🤟": "i_love_you_hand_sign",
+	"🤘": "the_horns",
+	"🤙": "call_me_hand",
+	"👈": "point_left",
+	"👉": "point_right",
+	"👆": "point_up_2",
+	"👇": "point_down",
+	"☝": "point_up",
+	"👍": "+1",
+	"👎": "-1",
+	"✊": "fist",
+	"👊": "facepunch",
+	"👏": "clap",
+	"🙌": "raised_hands",
+	"🫶": "heart_hands",
+	"👐": "open_hands",
+	"🤝": "handshake",
+	"🙏": "pray",
+	"✍": "writing_hand",
+	"💪": "muscle",
+	"🧠": "brain",
+	"👀": "eyes",
+	"👁": "eye",
+	"👂": "ear",
+	"🙋": "raising_hand",
+	"🙅": "no_good",
+	"🙆": "ok_woman",
+	"🤦": "face_palm",
+	"🤷": "shrug",
+	"🏃": "runner",
+	"❤": "heart",
+	"🧡": "orange_heart",
+	"💛": "yellow_heart",
+	"💚": "green_heart",
+	"💙": "blue_heart",
+	"💜": "purple_heart",
+	"🖤": "black_heart",
+	"🤍": "white_heart",
+	"💔": "broken_heart",
+	"💕": "two_hearts",
+	"💖": "sparkling_heart",
+	"🔥": "fire",
+	"✨": "sparkles",
+	"⭐": "star",
+	"🌟": "star2",
+	"⚡": "zap",
+	"🌈": "rainbow",
+	"☀": "sunny",
+	"🌧": "rain_cloud",
+	"❄": "snowflake",
+	"🌊": "ocean",
+	"🍀": "four_leaf_clover",
+	"🌱": "seedling",
+	"🐶": "dog",
+	"🐱": "cat",
+	"🐢": "turtle",
+	"🐐": "goat",
+	"🦄": "unicorn_face",
+	"🐛": "bug",
+	"🪲": "beetle",
+	"🦆": "duck",
+	"🐙": "octopus",
+	"☕": "coffee",
+	"🍵": "tea",
+	"🍺": "beer",
+	"🍻": "beers",
+	"🍾": "champagne",
+	"🥂": "clinking_glasses",
+	"🍕": "pizza",
+	"🌮": "taco",
+	"🍩": "doughnut",
+	"🍪": "cookie",
+	"🍿": "popcorn",
+	"🎂": "birthday",
+	"🎉": "tada",
+	"🎊": "confetti_ball",
+	"🎈": "balloon",
+	"🎁": "gift",
+	"🏆": "trophy",
+	"🥇": "first_place_medal",
+	"🎯": "dart",
+	"🎶": "notes",
+	"🚀": "rocket",
+	"🚢": "ship",
+	"⛵": "boat",
+	"🚨": "rotating_light",
+	"🚧": "construction",
+	"🛑": "octagonal_sign",
+	"🏁": "checkered_flag",
+	"🚩": "triangular_flag_on_post",
+	"⏰": "alarm_clock",
+	"⏳": "hourglass_flowing_sand",
+	"⌛": "hourglass",
+	"📅": "date",
+	"🗓": "spiral_calendar_pad",
+	"📈": "chart_with_upwards_trend",
+	"📉": "chart_with_downwards_trend",
+	"📊": "bar_chart",
+	"📌": "pushpin",
+	"📎": "paperclip",
+	"📝": "memo",
+	"📣": "mega",
+	"📢": "loudspeaker",
+	"🔔": "bell",
+	"🔕": "no_bell",
+	"🔒": "lock",
+	"🔓": "unlock",
+	"🔑": "key",
+	"💡": "bulb",
+	"🔍": "mag",
+	"🔗": "link",
+	"📦": "package",
+	"🧪": "test_tube",
+	"🧵": "thread",
+	"🔧": "wrench",
+	"🔨": "hammer",
+	"🛠": "hammer_and_wrench",
+	"⚙": "gear",
+	"🩹": "adhesive_bandage",
+	"🗑": "wastebasket",
+	"♻": "recycle",
+	"💰": "moneybag",
+	"👑": "crown",
+	"✅": "white_check_mark",
+	"✔": "heavy_check_mark",
+	"☑": "ballot_box_with_check",
+	"❌": "x",
+	"❎": "negative_squared_cross_mark",
+	"⭕": "o",
+	"❗": "exclamation",
+	"❓": "question",
+	"‼": "bangbang",
+	"⁉": "interrobang",
+	"⚠": "warning",
+	"🚫": "no_entry_sign",
+	"⛔": "no_entry",
+	"➕": "heavy_plus_sign",
+	"➖": "heavy_minus_sign",
+	"➡": "arrow_right",
+	"⬅": "arrow_left",
+	"⬆": "arrow_up",
+	"⬇": "arrow_down",
+	"🔁": "repeat",
+	"🔄": "arrows_counterclockwise",
+	"🆗": "ok",
+	"🆕": "new",
+	"🆙": "up",
+	"🆘": "sos",
+	"🔴": "red_circle",
+	"🟠": "large_orange_circle",
+	"🟡": "large_yellow_circle",
+	"🟢": "large_green_circle",
+	"🔵": "large_blue_circle",
+	"🟣": "large_purple_circle",
+	"⚫": "black_circle",
+	"⚪": "white_circle",
+}
+
+// standardAliases maps alternative names Slack shows for built-in emoji to the
+// canonical name.
+var standardAliases = map[string]string{
+	"thumbsup":               "+1",
+	"thumbsdown":             "-1",
+	"satisfied":              "laughing",
+	"poop":                   "hankey",
+	"shit":                   "hankey",
+	"punch":                  "facepunch",
+	"raised_hand":            "hand",
+	"sailboat":               "boat",
+	"heavy_exclamation_mark": "exclamation",
+}
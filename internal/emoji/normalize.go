@@ -0,0 +1,104 @@
+// Package emoji turns user-supplied emoji (":thumbsup:", "👍", custom aliases) into
+// the names Slack's reactions API accepts.
+package emoji
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// skinTones maps the Unicode skin tone modifiers to Slack's ::skin-tone-N suffixes.
+var skinTones = map[rune]string{
+	0x1F3FB: "skin-tone-2",
+	0x1F3FC: "skin-tone-3",
+	0x1F3FD: "skin-tone-4",
+	0x1F3FE: "skin-tone-5",
+	0x1F3FF: "skin-tone-6",
+}
+
+var skinTonePattern = regexp.MustCompile(`^skin-tone-[2-6]$`)
+
+// Normalize converts an emoji given as a name, a :shortcode:, or a Unicode emoji to
+// the Slack name used by reactions.add: colons are stripped, names are lowercased,
+// Unicode emoji are mapped to their Slack shortcode, and skin tones become a
+// ::skin-tone-N suffix. Names may use any letters, so custom emoji such as :了解:
+// are kept as they are. Unicode emoji missing from the built-in table are rejected
+// with a hint to pass the name instead.
+func Normalize(input string) (string, error) {
+	s := strings.TrimSpace(strings.ReplaceAll(input, "\uFE0F", ""))
+	if len(s) > 1 && strings.HasPrefix(s, ":") && strings.HasSuffix(s, ":") {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		return "", fmt.Errorf("empty emoji name")
+	}
+
+	if name, ok := fromUnicode(s); ok {
+		return name, nil
+	}
+
+	base, tone, hasTone := strings.Cut(s, "::")
+	base = strings.ToLower(base)
+	for _, r := range base {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_+-'", r) {
+			if isEmojiRune(r) {
+				return "", fmt.Errorf("unknown emoji %q; pass its Slack name instead (e.g. --emoji thumbsup)", input)
+			}
+			return "", fmt.Errorf("invalid emoji name %q", input)
+		}
+	}
+	if alias, ok := standardAliases[base]; ok {
+		base = alias
+	}
+	if !hasTone {
+		return base, nil
+	}
+	if !skinTonePattern.MatchString(tone) {
+		return "", fmt.Errorf("invalid skin tone in emoji %q (use skin-tone-2 to skin-tone-6)", input)
+	}
+	return base + "::" + tone, nil
+}
+
+// fromUnicode looks up a Unicode emoji, with an optional trailing skin tone modifier.
+func fromUnicode(s string) (string, bool) {
+	runes := []rune(s)
+	tone := ""
+	if t, ok := skinTones[runes[len(runes)-1]]; ok && len(runes) > 1 {
+		tone = t
+		runes = runes[:len(runes)-1]
+	}
+	name, ok := unicodeNames[string(runes)]
+	if !ok {
+		return "", false
+	}
+	if tone != "" {
+		name += "::" + tone
+	}
+	return name, true
+}
+
+// isEmojiRune reports whether r is a pictograph or symbol rather than part of a word.
+func isEmojiRune(r rune) bool {
+	return unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r) || r == '\u200D' || (r >= 0x1F000 && r <= 0x1FAFF)
+}
+
+// IsStandard reports whether name (without a skin tone) is a built-in Slack emoji
+// this package knows, so callers can skip looking it up among custom emoji.
+func IsStandard(name string) bool {
+	base, _, _ := strings.Cut(name, "::")
+	return standardNames[base]
+}
+
+var standardNames = func() map[string]bool {
+	names := make(map[string]bool, len(unicodeNames)+len(standardAliases))
+	for _, name := range unicodeNames {
+		names[name] = true
+	}
+	for alias, name := range standardAliases {
+		names[alias] = true
+		names[name] = true
+	}
+	return names
+}()
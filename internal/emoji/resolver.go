@@ -0,0 +1,92 @@
+package emoji
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/cache"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// EmojiClient defines the Slack operation needed to look up custom emoji.
+type EmojiClient interface {
+	ListEmoji(ctx context.Context) (*slack.EmojiListResult, error)
+}
+
+// missRefresh is how long a cached emoji list is trusted for names it lacks.
+const missRefresh = 10 * time.Minute
+
+// maxAliasDepth bounds alias chains so a cycle cannot loop forever.
+const maxAliasDepth = 5
+
+// Resolver normalizes emoji input and follows custom emoji aliases using a disk cache.
+type Resolver struct {
+	client EmojiClient
+	cache  *cache.Store
+}
+
+// NewResolver creates a Resolver with no cache (API-only).
+func NewResolver(client EmojiClient) *Resolver {
+	return &Resolver{client: client}
+}
+
+// NewCachedResolver creates a Resolver backed by the given cache store.
+func NewCachedResolver(client EmojiClient, store *cache.Store) *Resolver {
+	return &Resolver{client: client, cache: store}
+}
+
+// Resolve returns the Slack name for an emoji given as a name, :shortcode:, or
+// Unicode emoji. Custom emoji defined as an alias of another emoji resolve to
+// their target. Built-in emoji never trigger a lookup, and names that cannot be
+// checked (for example without the emoji:read scope) are returned as normalized.
+func (r *Resolver) Resolve(ctx context.Context, input string) (string, error) {
+	name, err := Normalize(input)
+	if err != nil {
+		return "", err
+	}
+	if IsStandard(name) || r == nil || r.client == nil {
+		return name, nil
+	}
+	base, tone, hasTone := strings.Cut(name, "::")
+	custom, ok := r.lookup(ctx, base)
+	if !ok {
+		return name, nil
+	}
+	for i := 0; i < maxAliasDepth; i++ {
+		target, isAlias := strings.CutPrefix(custom[base], "alias:")
+		if !isAlias {
+			break
+		}
+		base = target
+	}
+	if hasTone {
+		return base + "::" + tone, nil
+	}
+	return base, nil
+}
+
+// lookup returns the custom emoji map if it contains name. A cached map that lacks
+// name is refetched when older than missRefresh, in case the emoji is new; built-in
+// emoji missing from the package's table would otherwise cost a call every time.
+func (r *Resolver) lookup(ctx context.Context, name string) (map[string]string, bool) {
+	if r.cache != nil {
+		var cached map[string]string
+		if found, err := r.cache.Load(cache.CacheKeyEmoji, &cached); err == nil && found {
+			_, ok := cached[name]
+			status, _ := r.cache.GetStatus(cache.CacheKeyEmoji)
+			if ok || time.Since(status.FetchedAt) < missRefresh {
+				return cached, ok
+			}
+		}
+	}
+	result, err := r.client.ListEmoji(ctx)
+	if err != nil || result == nil {
+		return nil, false
+	}
+	if r.cache != nil {
+		_ = r.cache.Save(cache.CacheKeyEmoji, result.Emoji)
+	}
+	_, ok := result.Emoji[name]
+	return result.Emoji, ok
+}
@@ -0,0 +1,167 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Stable error codes. Each error slk returns carries one, so callers can branch on
+// the code instead of parsing the message. A code keeps its meaning once released.
+const (
+	CodeGeneral           = "SLK_E_GENERAL"
+	CodeConfig            = "SLK_E_CONFIG"
+	CodeAuth              = "SLK_E_AUTH"
+	CodeRateLimited       = "SLK_E_RATE_LIMITED"
+	CodeNetwork           = "SLK_E_NETWORK"
+	CodePermission        = "SLK_E_PERMISSION"
+	CodeMissingScope      = "SLK_E_MISSING_SCOPE"
+	CodeNotFound          = "SLK_E_NOT_FOUND"
+	CodeChannelNotFound   = "SLK_E_CHANNEL_NOT_FOUND"
+	CodeUserNotFound      = "SLK_E_USER_NOT_FOUND"
+	CodeMessageNotFound   = "SLK_E_MESSAGE_NOT_FOUND"
+	CodeNotInChannel      = "SLK_E_NOT_IN_CHANNEL"
+	CodeAmbiguousName     = "SLK_E_AMBIGUOUS_NAME"
+	CodePolicy            = "SLK_E_POLICY"
+	CodeQuietHours        = "SLK_E_QUIET_HOURS"
+	CodeDuplicateMessage  = "SLK_E_DUPLICATE_MESSAGE"
+	CodeReadOnly          = "SLK_E_READ_ONLY"
+	CodeUserImpersonation = "SLK_E_USER_IMPERSONATION"
+	CodeChannelBlocked    = "SLK_E_CHANNEL_BLOCKED"
+	CodeBreakerOpen       = "SLK_E_BREAKER_OPEN"
+	CodeBudgetExhausted   = "SLK_E_BUDGET_EXHAUSTED"
+	CodeTimeout           = "SLK_E_TIMEOUT"
+)
+
+// CatalogEntry documents one stable error code.
+type CatalogEntry struct {
+	Code        string `json:"code"`
+	ExitCode    int    `json:"exit_code"`
+	Description string `json:"description"`
+	Remediation string `json:"remediation"`
+}
+
+var catalog = []CatalogEntry{
+	{CodeGeneral, ExitGeneral, "Unclassified failure", "Read the message; rerun with --stats or OTEL tracing to see the failing API call"},
+	{CodeConfig, ExitConfig, "Missing or invalid configuration", "Check slk config get and slk config env; run slk auth login to store a token"},
+	{CodeAuth, ExitAuth, "Token is invalid, expired, or revoked", "Run slk auth test, then reauthorize with slk auth login, oauth, or device"},
+	{CodeRateLimited, ExitRateLimit, "Slack rate limit exceeded after retries", "Wait and retry, lower --limit, or raise retries.max and retries.max_backoff in config"},
+	{CodeNetwork, ExitNetwork, "Slack or a relay could not be reached", "Check connectivity and HTTPS_PROXY, then retry"},
+	{CodePermission, ExitPermission, "Slack refused the call for this user or bot", "Check that the identity may act in the channel (slk auth test shows who you are)"},
+	{CodeMissingScope, ExitPermission, "Token lacks an OAuth scope the call needs", "Run the printed slk auth oauth --scopes command, or slk auth plan to compute scopes"},
+	{CodeNotFound, ExitNotFound, "A named resource does not exist", "Check the identifier; the message includes a hint for the resource type"},
+	{CodeChannelNotFound, ExitNotFound, "Channel name or ID did not resolve", "Pass a channel ID, add --suggest, or run slk cache populate channels --all"},
+	{CodeUserNotFound, ExitNotFound, "User name or ID did not resolve", "Pass a user ID or run slk cache populate users --all"},
+	{CodeMessageNotFound, ExitNotFound, "Message timestamp does not exist in the channel", "Check the ts and channel, or use slk messages get --client-msg-id"},
+	{CodeNotInChannel, ExitNotFound, "Identity is not a member of the channel", "Run slk channels join, or invite the bot to the channel"},
+	{CodeAmbiguousName, ExitNotFound, "A name matched several users", "Pass one of the listed IDs or @handles"},
+	{CodePolicy, ExitPolicy, "Refused by a configured policy", "Read the message for the policy and its override flag"},
+	{CodeQuietHours, ExitPolicy, "Blocked by quiet hours", "Wait until the printed time or pass --override-quiet-hours"},
+	{CodeDuplicateMessage, ExitPolicy, "Identical message sent within dedupe_window", "Pass --allow-duplicate, or change the message"},
+	{CodeReadOnly, ExitPolicy, "Write refused in read-only mode", "Drop --read-only and unset read_only in config"},
+	{CodeUserImpersonation, ExitPolicy, "Write as a person refused without allow_user_impersonation", "Use a bot token (SLACK_CLI_ROLE=bot) or set allow_user_impersonation: true in config"},
+	{CodeChannelBlocked, ExitPolicy, "Channel excluded by allowed_channels or denied_channels", "Update the channel lists in config"},
+	{CodeBreakerOpen, ExitBreaker, "Refused locally by an open circuit breaker", "Check slk health api and retry after the cooldown"},
+	{CodeBudgetExhausted, ExitRateLimit, "Refused under --no-wait because a budgets entry in config is used up", "Retry after the printed time, drop --no-wait to queue, or raise the budget"},
+	{CodeTimeout, ExitTimeout, "A wait or authorization timed out", "Raise --timeout or rerun the command"},
+}
+
+// Catalog returns every stable error code with its exit code and remediation.
+func Catalog() []CatalogEntry {
+	return append([]CatalogEntry(nil), catalog...)
+}
+
+// LookupCode returns the catalog entry for code.
+func LookupCode(code string) (CatalogEntry, bool) {
+	for _, entry := range catalog {
+		if entry.Code == code {
+			return entry, true
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+// exitCodes maps each exit code to the code reported when nothing more specific is known.
+var exitCodes = map[int]string{
+	ExitGeneral:    CodeGeneral,
+	ExitConfig:     CodeConfig,
+	ExitAuth:       CodeAuth,
+	ExitRateLimit:  CodeRateLimited,
+	ExitNetwork:    CodeNetwork,
+	ExitPermission: CodePermission,
+	ExitNotFound:   CodeNotFound,
+	ExitPolicy:     CodePolicy,
+	ExitBreaker:    CodeBreakerOpen,
+	ExitTimeout:    CodeTimeout,
+}
+
+// slackErrorCodes maps Slack API error strings to stable codes, checked in order.
+var slackErrorCodes = []struct{ slack, code string }{
+	{"missing_scope", CodeMissingScope},
+	{"channel_not_found", CodeChannelNotFound},
+	{"user_not_found", CodeUserNotFound},
+	{"message_not_found", CodeMessageNotFound},
+	{"thread_not_found", CodeMessageNotFound},
+	{"not_in_channel", CodeNotInChannel},
+}
+
+// WithCode sets the stable code of err, which must come from this package's
+// constructors; other errors are wrapped with the code's exit code.
+func WithCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var errWithCode *ErrorWithExitCode
+	if errors.As(err, &errWithCode) {
+		return &ErrorWithExitCode{Err: errWithCode.Err, ExitCode: errWithCode.ExitCode, Code: code}
+	}
+	entry, _ := LookupCode(code)
+	return &ErrorWithExitCode{Err: err, ExitCode: entry.ExitCode, Code: code}
+}
+
+// Classify returns err with an exit code and stable code. Errors from this package
+// keep theirs; anything else is classified from the Slack API error it carries.
+func Classify(err error) *ErrorWithExitCode {
+	if err == nil {
+		return nil
+	}
+	return &ErrorWithExitCode{Err: err, ExitCode: classifyExitCode(err), Code: ErrorCode(err)}
+}
+
+// classifyExitCode returns the exit code of an error from this package, or classifies
+// a raw Slack API or config error.
+func classifyExitCode(err error) int {
+	var errWithCode *ErrorWithExitCode
+	if errors.As(err, &errWithCode) {
+		return errWithCode.ExitCode
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "load config") ||
+		strings.Contains(msg, "invalid config") ||
+		strings.Contains(msg, "config file") {
+		return ExitConfig
+	}
+	return ClassifySlackError(err)
+}
+
+// ErrorCode returns the stable code for err, or "" for nil. Slack API errors map to the
+// most specific code whose exit code matches err's.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	var errWithCode *ErrorWithExitCode
+	if errors.As(err, &errWithCode) && errWithCode.Code != "" {
+		return errWithCode.Code
+	}
+	exitCode := classifyExitCode(err)
+	msg := err.Error()
+	for _, m := range slackErrorCodes {
+		if entry, _ := LookupCode(m.code); entry.ExitCode == exitCode && strings.Contains(msg, m.slack) {
+			return m.code
+		}
+	}
+	if code, ok := exitCodes[exitCode]; ok {
+		return code
+	}
+	return CodeGeneral
+}
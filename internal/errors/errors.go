@@ -20,13 +20,17 @@ const (
 	ExitNetwork    = 5 // Network error
 	ExitPermission = 6 // Permission denied (missing scopes)
 	ExitNotFound   = 7 // Resource not found (channel, user, message)
+	ExitPolicy     = 8 // Blocked by a configured policy (quiet hours)
+	ExitBreaker    = 9 // Refused locally by an open circuit breaker
 	ExitTimeout    = 124
 )
 
-// ErrorWithExitCode wraps an error with a specific exit code.
+// ErrorWithExitCode wraps an error with a specific exit code and, when known, a stable
+// error code from the catalog (see ErrorCode).
 type ErrorWithExitCode struct {
 	Err      error
 	ExitCode int
+	Code     string
 }
 
 func (e *ErrorWithExitCode) Error() string {
@@ -115,57 +119,45 @@ func HandleCommandError(cmd *cobra.Command, err error) error {
 	if err == nil {
 		return nil
 	}
-
-	// Check if error already has an exit code
-	var errWithCode *ErrorWithExitCode
-	if errors.As(err, &errWithCode) {
-		cmd.SilenceUsage = true
-		return errWithCode
-	}
-
-	// Classify Slack API errors
-	exitCode := ClassifySlackError(err)
-
-	// Special handling for config errors
-	if strings.Contains(err.Error(), "load config") ||
-		strings.Contains(err.Error(), "invalid config") ||
-		strings.Contains(err.Error(), "config file") {
-		exitCode = ExitConfig
-	}
-
-	// Wrap with appropriate exit code
 	cmd.SilenceUsage = true
-	return &ErrorWithExitCode{
-		Err:      err,
-		ExitCode: exitCode,
-	}
+	return Classify(err)
 }
 
 // Execute runs a cobra command and exits with the appropriate code.
 // This should be used in main.go to ensure proper exit codes.
 func Execute(rootCmd *cobra.Command) {
-	err := rootCmd.Execute()
-	if err != nil {
-		var errWithCode *ErrorWithExitCode
-		if errors.As(err, &errWithCode) {
-			os.Exit(errWithCode.ExitCode)
-		}
-		os.Exit(ExitGeneral)
+	os.Exit(ExitCode(rootCmd.Execute()))
+}
+
+// ExitCode returns the process exit code for an error returned by a command.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+	var errWithCode *ErrorWithExitCode
+	if errors.As(err, &errWithCode) {
+		return errWithCode.ExitCode
 	}
-	os.Exit(ExitSuccess)
+	return ExitGeneral
 }
 
 // Scope error helpers
 
 // MissingScopeError creates a user-friendly error for missing OAuth scopes.
 func MissingScopeError(operation string, requiredScopes ...string) error {
+	return ScopeGapError(operation, requiredScopes, nil)
+}
+
+// ScopeGapError is MissingScopeError for a token whose granted scopes are known. The
+// suggested reauthorization command requests them together with the missing ones.
+func ScopeGapError(operation string, requiredScopes, grantedScopes []string) error {
 	scopeList := strings.Join(requiredScopes, ", ")
-	return NewErrorWithCode(
-		ExitPermission,
-		"missing OAuth scope for %s\nRequired scope(s): %s\n\nTo fix:\n  1. Add the scope(s) to your Slack app manifest\n  2. Reinstall the app to your workspace\n  3. Update your config with the new token",
+	return &ErrorWithExitCode{Code: CodeMissingScope, ExitCode: ExitPermission, Err: fmt.Errorf(
+		"missing OAuth scope for %s\nRequired scope(s): %s\n\nTo fix:\n  1. Add the scope(s) to your Slack app manifest\n  2. Reinstall the app to your workspace\n  3. Update your config with the new token, e.g. for a user token:\n     %s",
 		operation,
 		scopeList,
-	)
+		scopeFixCommand(requiredScopes, grantedScopes),
+	)}
 }
 
 // NotFoundError creates a user-friendly error for missing resources.
@@ -174,7 +166,16 @@ func NotFoundError(resourceType, identifier string, hint string) error {
 	if hint != "" {
 		msg += "\n" + hint
 	}
-	return NewErrorWithCode(ExitNotFound, "%s", msg)
+	code := CodeNotFound
+	switch resourceType {
+	case "channel":
+		code = CodeChannelNotFound
+	case "user":
+		code = CodeUserNotFound
+	case "message":
+		code = CodeMessageNotFound
+	}
+	return &ErrorWithExitCode{Err: errors.New(msg), ExitCode: ExitNotFound, Code: code}
 }
 
 // ChannelNotFoundError creates a specific error for missing channels with helpful hints.
@@ -183,12 +184,73 @@ func ChannelNotFoundError(channel string) error {
 	return NotFoundError("channel", channel, hint)
 }
 
+// ChannelNotFoundWithSuggestions is ChannelNotFoundError listing the channel names
+// the input most likely meant.
+func ChannelNotFoundWithSuggestions(channel string, suggestions []string) error {
+	if len(suggestions) == 0 {
+		return ChannelNotFoundError(channel)
+	}
+	names := make([]string, len(suggestions))
+	for i, name := range suggestions {
+		names[i] = "#" + name
+	}
+	hint := "Did you mean: " + strings.Join(names, ", ") + "?\n" +
+		"Hint: Run 'slk cache populate channels --all' to refresh the channel cache"
+	return NotFoundError("channel", channel, hint)
+}
+
 // UserNotFoundError creates a specific error for missing users with helpful hints.
 func UserNotFoundError(user string) error {
 	hint := "Hint: Run 'slk cache populate users --all' to refresh the user cache"
 	return NotFoundError("user", user, hint)
 }
 
+// Candidate is one of the resources an ambiguous name matched.
+type Candidate struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	RealName string `json:"real_name,omitempty"`
+	// Deactivated marks a user whose account is deactivated.
+	Deactivated bool `json:"deactivated,omitempty"`
+}
+
+// AmbiguousNameError reports a name that matches more than one resource. Callers
+// can recover the candidates with errors.As.
+type AmbiguousNameError struct {
+	Resource   string
+	Input      string
+	Candidates []Candidate
+}
+
+func (e *AmbiguousNameError) Error() string {
+	lines := []string{fmt.Sprintf("%s name is ambiguous: %s matches %d %ss", e.Resource, e.Input, len(e.Candidates), e.Resource)}
+	for _, c := range e.Candidates {
+		line := "  " + c.ID
+		if c.RealName != "" {
+			line += " " + c.RealName
+		}
+		if c.Name != "" {
+			line += " (@" + c.Name + ")"
+		}
+		if c.Deactivated {
+			line += " [deactivated]"
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, fmt.Sprintf("Hint: Pass the %s ID or @handle instead", e.Resource))
+	return strings.Join(lines, "\n")
+}
+
+// AmbiguousUserError creates a not-found error for a user name that matches several
+// users, listing each one's ID, real name, and handle.
+func AmbiguousUserError(user string, candidates []Candidate) error {
+	return &ErrorWithExitCode{
+		Err:      &AmbiguousNameError{Resource: "user", Input: user, Candidates: candidates},
+		ExitCode: ExitNotFound,
+		Code:     CodeAmbiguousName,
+	}
+}
+
 // ConfigError creates a configuration-related error.
 func ConfigError(msg string, args ...interface{}) error {
 	return NewErrorWithCode(ExitConfig, msg, args...)
@@ -213,6 +275,11 @@ func RateLimitError(retryAfter string) error {
 	)
 }
 
+// PolicyError creates an error for commands refused by a configured policy.
+func PolicyError(msg string, args ...interface{}) error {
+	return NewErrorWithCode(ExitPolicy, msg, args...)
+}
+
 // TimeoutError creates an error for commands that intentionally waited and timed out.
 func TimeoutError(msg string, args ...interface{}) error {
 	return NewErrorWithCode(ExitTimeout, msg, args...)
@@ -171,6 +171,99 @@ func TestMissingScopeError(t *testing.T) {
 	}
 }
 
+func TestScopeGapErrorSuggestsReauthorization(t *testing.T) {
+	err := ScopeGapError("pin messages", []string{"pins:write"}, []string{"channels:read", "pins:read"})
+
+	if code := ExitCode(err); code != ExitPermission {
+		t.Errorf("ExitCode = %d, want %d", code, ExitPermission)
+	}
+	want := "slk auth oauth --scopes channels:read,pins:read,pins:write --save"
+	if !contains(err.Error(), want) {
+		t.Errorf("error %q does not suggest %q", err.Error(), want)
+	}
+}
+
+func TestMethodScopeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		needed   string
+		provided string
+		want     []string
+	}{
+		{
+			name:     "table narrowed by needed",
+			method:   "conversations.history",
+			needed:   "groups:history",
+			provided: "channels:history",
+			want:     []string{"conversations.history", "Required scope(s): groups:history\n", "--scopes channels:history,groups:history --save"},
+		},
+		{
+			name:   "table without needed",
+			method: "reactions.add",
+			want:   []string{"Required scope(s): reactions:write\n", "--scopes <current scopes>,reactions:write --save"},
+		},
+		{
+			name:     "unknown method falls back to needed",
+			method:   "admin.users.list",
+			needed:   "admin.users:read",
+			provided: "users:read",
+			want:     []string{"Required scope(s): admin.users:read\n", "--scopes users:read,admin.users:read --save"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MethodScopeError(tt.method, tt.needed, tt.provided)
+			if code := ExitCode(err); code != ExitPermission {
+				t.Errorf("ExitCode = %d, want %d", code, ExitPermission)
+			}
+			if !containsAll(err.Error(), tt.want...) {
+				t.Errorf("error %q missing one of %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodScopesTable(t *testing.T) {
+	if got := MethodScopes("chat.postMessage"); len(got) != 1 || got[0] != "chat:write" {
+		t.Errorf("MethodScopes(chat.postMessage) = %v, want [chat:write]", got)
+	}
+	if got := MethodScopes("no.such.method"); got != nil {
+		t.Errorf("MethodScopes(no.such.method) = %v, want nil", got)
+	}
+}
+
+func TestChannelNotFoundWithSuggestions(t *testing.T) {
+	err := ChannelNotFoundWithSuggestions("#deplyos", []string{"deploys", "deploys-staging"})
+	if ExitCode(err) != ExitNotFound {
+		t.Errorf("ExitCode = %d, want %d", ExitCode(err), ExitNotFound)
+	}
+	if !containsAll(err.Error(), "#deplyos", "Did you mean: #deploys, #deploys-staging?", "cache populate channels") {
+		t.Errorf("Error message missing suggestions: %q", err.Error())
+	}
+	if got, want := ChannelNotFoundWithSuggestions("#x", nil).Error(), ChannelNotFoundError("#x").Error(); got != want {
+		t.Errorf("without suggestions = %q, want %q", got, want)
+	}
+}
+
+func TestAmbiguousUserError(t *testing.T) {
+	err := AmbiguousUserError("@Al", []Candidate{
+		{ID: "U1", Name: "alice", RealName: "Alice Smith"},
+		{ID: "U2", Name: "albert", RealName: "Albert Jones"},
+	})
+	if ExitCode(err) != ExitNotFound {
+		t.Errorf("ExitCode = %d, want %d", ExitCode(err), ExitNotFound)
+	}
+	var ambiguous *AmbiguousNameError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Fatalf("errors.As(AmbiguousNameError) = %+v", ambiguous)
+	}
+	if !containsAll(err.Error(), "@Al matches 2 users", "U1 Alice Smith (@alice)", "U2 Albert Jones (@albert)") {
+		t.Errorf("Error message missing candidates: %q", err.Error())
+	}
+}
+
 func TestChannelNotFoundError(t *testing.T) {
 	err := ChannelNotFoundError("#general")
 
@@ -359,3 +452,84 @@ func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && (s[:len(substr)] == substr || contains(s[1:], substr))))
 }
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitSuccess},
+		{"plain error", errors.New("boom"), ExitGeneral},
+		{"coded error", AuthError("bad token"), ExitAuth},
+		{"wrapped coded error", fmt.Errorf("outer: %w", NotFoundError("channel", "#x", "")), ExitNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"channel helper", ChannelNotFoundError("#deploys"), CodeChannelNotFound},
+		{"generic not found", NotFoundError("usergroup", "oncall", ""), CodeNotFound},
+		{"ambiguous user", AmbiguousUserError("alex", nil), CodeAmbiguousName},
+		{"missing scope helper", MissingScopeError("pin", "pins:write"), CodeMissingScope},
+		{"explicit code", WithCode(CodeQuietHours, PolicyError("blocked")), CodeQuietHours},
+		{"raw slack error", errors.New("channel_not_found"), CodeChannelNotFound},
+		{"wrapped slack error", fmt.Errorf("send: %w", errors.New("not_in_channel")), CodeNotInChannel},
+		{"raw auth error", errors.New("invalid_auth"), CodeAuth},
+		{"exit code default", NewErrorWithCode(ExitNetwork, "relay down"), CodeNetwork},
+		{"unclassified", errors.New("boom"), CodeGeneral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCode(tt.err); got != tt.want {
+				t.Errorf("ErrorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyKeepsMessageAndSetsExitCode(t *testing.T) {
+	err := Classify(fmt.Errorf("list messages: %w", errors.New("channel_not_found")))
+	if err.ExitCode != ExitNotFound || err.Code != CodeChannelNotFound {
+		t.Errorf("Classify() = exit %d code %q, want %d %q", err.ExitCode, err.Code, ExitNotFound, CodeChannelNotFound)
+	}
+	if err.Error() != "list messages: channel_not_found" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestCatalogIsConsistent(t *testing.T) {
+	seen := map[string]bool{}
+	for _, entry := range Catalog() {
+		if seen[entry.Code] {
+			t.Errorf("duplicate code %s", entry.Code)
+		}
+		seen[entry.Code] = true
+		if entry.Description == "" || entry.Remediation == "" {
+			t.Errorf("%s lacks a description or remediation", entry.Code)
+		}
+	}
+	for exitCode, code := range exitCodes {
+		if entry, ok := LookupCode(code); !ok || entry.ExitCode != exitCode {
+			t.Errorf("default code %s for exit %d is not catalogued with that exit code", code, exitCode)
+		}
+	}
+	for _, m := range slackErrorCodes {
+		if !seen[m.code] {
+			t.Errorf("Slack error %s maps to uncatalogued code %s", m.slack, m.code)
+		}
+	}
+}
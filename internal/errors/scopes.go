@@ -0,0 +1,85 @@
+package errors
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+//go:embed method_scopes.json
+var methodScopesJSON []byte
+
+// methodScopes maps a Slack Web API method to the OAuth scopes that authorize it. Methods
+// that work across conversation types list one scope per type.
+var methodScopes = sync.OnceValue(func() map[string][]string {
+	table := map[string][]string{}
+	if err := json.Unmarshal(methodScopesJSON, &table); err != nil {
+		panic("errors: invalid method_scopes.json: " + err.Error())
+	}
+	return table
+})
+
+// MethodScopes returns the OAuth scopes that authorize a Slack Web API method such as
+// conversations.history, or nil when the method is not in the embedded table.
+func MethodScopes(method string) []string {
+	return append([]string(nil), methodScopes()[method]...)
+}
+
+// MethodScopeError creates a MissingScopeError for a missing_scope answer from a Slack API
+// method. needed and provided are the comma-separated fields Slack sends with that error.
+// The scopes come from the method table, narrowed to Slack's needed list when the two
+// overlap; provided is kept in the suggested fix so reauthorizing does not drop scopes.
+func MethodScopeError(method, needed, provided string) error {
+	required := MethodScopes(method)
+	if narrowed := intersectScopes(required, splitScopes(needed)); len(narrowed) > 0 {
+		required = narrowed
+	} else if len(required) == 0 {
+		required = splitScopes(needed)
+	}
+	return ScopeGapError(method, required, splitScopes(provided))
+}
+
+// scopeFixCommand returns the slk command that requests granted plus the missing scopes.
+// Without granted scopes it leaves a placeholder for them.
+func scopeFixCommand(required, granted []string) string {
+	scopes := append([]string(nil), granted...)
+	if len(scopes) == 0 {
+		scopes = append(scopes, "<current scopes>")
+	}
+	seen := map[string]bool{}
+	for _, scope := range granted {
+		seen[scope] = true
+	}
+	for _, scope := range required {
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return "slk auth oauth --scopes " + strings.Join(scopes, ",") + " --save"
+}
+
+func splitScopes(list string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(list, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func intersectScopes(a, b []string) []string {
+	in := map[string]bool{}
+	for _, scope := range b {
+		in[scope] = true
+	}
+	var out []string
+	for _, scope := range a {
+		if in[scope] {
+			out = append(out, scope)
+		}
+	}
+	return out
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kehao95/slack-agent-cli/internal/config"
 	_ "modernc.org/sqlite"
 )
 
@@ -106,11 +107,10 @@ func DefaultPath(configPath, teamID string) (string, error) {
 		return "", errors.New("team id is required")
 	}
 	if configPath == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return "", fmt.Errorf("determine home directory: %w", err)
+		var err error
+		if configPath, err = config.DefaultPath(); err != nil {
+			return "", err
 		}
-		configPath = filepath.Join(home, ".config", "slack-cli", "config.json")
 	}
 	return filepath.Join(filepath.Dir(configPath), "events", teamID, "events.db"), nil
 }
@@ -0,0 +1,256 @@
+// Package idempotency records which keyed sends have already reached Slack, so a
+// retried command returns the original result instead of posting again. It also keeps
+// a short history of posted content for the dedupe_window check.
+//
+// A key is reserved before the Slack call and completed with its result afterwards.
+// A reservation that never completes (the process died mid-send) is reported as
+// unconfirmed rather than retried, because the message may already be posted.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	slackapi "github.com/slack-go/slack"
+	_ "modernc.org/sqlite"
+)
+
+// TTL is how long completed keys and posted content are remembered.
+const TTL = 7 * 24 * time.Hour
+
+// Reservation states returned by Reserve.
+const (
+	// StateReserved means the caller owns the key and should send.
+	StateReserved = "reserved"
+	// StateCompleted means the key was already sent; Entry.Result holds the original result.
+	StateCompleted = "completed"
+	// StateInFlight means another send with the key has not finished, or died before
+	// recording its result.
+	StateInFlight = "in_flight"
+	// StateConflict means the key was used for a different message.
+	StateConflict = "conflict"
+)
+
+// Entry is one recorded key.
+type Entry struct {
+	Key         string
+	Fingerprint string
+	ReservedAt  time.Time
+	CompletedAt time.Time
+	Result      json.RawMessage
+}
+
+// Ledger wraps the idempotency SQLite database.
+type Ledger struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the ledger path inside a team's cache directory.
+func DefaultPath(cacheDir string) string {
+	return filepath.Join(cacheDir, "idempotency.db")
+}
+
+// Open opens or creates a ledger and forgets keys older than TTL.
+func Open(path string) (*Ledger, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("idempotency ledger path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create idempotency ledger dir: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	ledger := &Ledger{db: db}
+	if err := ledger.init(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	cutoff := formatTime(time.Now().Add(-TTL))
+	for _, stmt := range []string{`DELETE FROM sends WHERE reserved_at < ?`, `DELETE FROM posts WHERE posted_at < ?`} {
+		if _, err := db.Exec(stmt, cutoff); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("prune idempotency ledger: %w", err)
+		}
+	}
+	return ledger, nil
+}
+
+// Close closes the database.
+func (l *Ledger) Close() error {
+	if l == nil || l.db == nil {
+		return nil
+	}
+	return l.db.Close()
+}
+
+func (l *Ledger) init() error {
+	stmts := []string{
+		`PRAGMA busy_timeout=5000`,
+		`PRAGMA journal_mode=WAL`,
+		`CREATE TABLE IF NOT EXISTS sends (
+			key TEXT PRIMARY KEY,
+			fingerprint TEXT NOT NULL,
+			reserved_at TEXT NOT NULL,
+			completed_at TEXT NOT NULL DEFAULT '',
+			result TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE TABLE IF NOT EXISTS posts (
+			channel_id TEXT NOT NULL,
+			fingerprint TEXT NOT NULL,
+			ts TEXT NOT NULL,
+			posted_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_posts_content ON posts(channel_id, fingerprint, posted_at)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := l.db.Exec(stmt); err != nil {
+			return fmt.Errorf("init idempotency ledger: %w", err)
+		}
+	}
+	return nil
+}
+
+// Reserve claims key for a send of the message identified by fingerprint. Only the
+// StateReserved caller may send; the existing entry is returned for the other states.
+func (l *Ledger) Reserve(ctx context.Context, key, fingerprint string) (string, Entry, error) {
+	if strings.TrimSpace(key) == "" {
+		return "", Entry{}, errors.New("idempotency key is required")
+	}
+	now := time.Now()
+	res, err := l.db.ExecContext(ctx,
+		`INSERT INTO sends (key, fingerprint, reserved_at) VALUES (?, ?, ?) ON CONFLICT(key) DO NOTHING`,
+		key, fingerprint, formatTime(now))
+	if err != nil {
+		return "", Entry{}, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 1 {
+		return StateReserved, Entry{Key: key, Fingerprint: fingerprint, ReservedAt: now}, nil
+	}
+
+	entry, err := l.get(ctx, key)
+	if err != nil {
+		return "", Entry{}, err
+	}
+	switch {
+	case entry.Fingerprint != fingerprint:
+		return StateConflict, entry, nil
+	case entry.CompletedAt.IsZero():
+		return StateInFlight, entry, nil
+	default:
+		return StateCompleted, entry, nil
+	}
+}
+
+// Complete records the result of a successful send.
+func (l *Ledger) Complete(ctx context.Context, key string, result any) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encode idempotent result: %w", err)
+	}
+	if _, err := l.db.ExecContext(ctx, `UPDATE sends SET completed_at = ?, result = ? WHERE key = ?`,
+		formatTime(time.Now()), string(data), key); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release forgets a reservation whose send failed before reaching Slack, so the key
+// can be retried.
+func (l *Ledger) Release(ctx context.Context, key string) error {
+	if _, err := l.db.ExecContext(ctx, `DELETE FROM sends WHERE key = ? AND completed_at = ''`, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// RecordPost remembers that content identified by fingerprint was posted to channelID.
+func (l *Ledger) RecordPost(ctx context.Context, channelID, fingerprint, ts string, at time.Time) error {
+	if _, err := l.db.ExecContext(ctx, `INSERT INTO posts (channel_id, fingerprint, ts, posted_at) VALUES (?, ?, ?, ?)`,
+		channelID, fingerprint, ts, formatTime(at)); err != nil {
+		return fmt.Errorf("record post: %w", err)
+	}
+	return nil
+}
+
+// LastPost returns the most recent post of the same content to channelID since the
+// given time.
+func (l *Ledger) LastPost(ctx context.Context, channelID, fingerprint string, since time.Time) (ts string, at time.Time, ok bool, err error) {
+	var postedAt string
+	err = l.db.QueryRowContext(ctx,
+		`SELECT ts, posted_at FROM posts WHERE channel_id = ? AND fingerprint = ? AND posted_at >= ? ORDER BY posted_at DESC LIMIT 1`,
+		channelID, fingerprint, formatTime(since)).Scan(&ts, &postedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", time.Time{}, false, nil
+	}
+	if err != nil {
+		return "", time.Time{}, false, fmt.Errorf("read recent posts: %w", err)
+	}
+	at, _ = time.Parse(time.RFC3339Nano, postedAt)
+	return ts, at, true, nil
+}
+
+func (l *Ledger) get(ctx context.Context, key string) (Entry, error) {
+	var entry Entry
+	var reservedAt, completedAt, result string
+	err := l.db.QueryRowContext(ctx, `SELECT key, fingerprint, reserved_at, completed_at, result FROM sends WHERE key = ?`, key).
+		Scan(&entry.Key, &entry.Fingerprint, &reservedAt, &completedAt, &result)
+	if err != nil {
+		return Entry{}, fmt.Errorf("read idempotency key: %w", err)
+	}
+	entry.ReservedAt, _ = time.Parse(time.RFC3339Nano, reservedAt)
+	entry.CompletedAt, _ = time.Parse(time.RFC3339Nano, completedAt)
+	if result != "" {
+		entry.Result = json.RawMessage(result)
+	}
+	return entry, nil
+}
+
+// Fingerprint identifies a message by its destination and content, so a key reused
+// for a different message is detected.
+func Fingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%d:%s\n", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// formatTime stores times as fixed-width UTC so they compare correctly as text.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000000Z")
+}
+
+// NotSent reports whether a failed send certainly did not post the message: Slack
+// answered with an error, read-only mode, the user impersonation guard, or the
+// channel lists blocked it, or no connection was made. Timeouts and dropped
+// connections are ambiguous and report false.
+func NotSent(err error) bool {
+	var slackErr slackapi.SlackErrorResponse
+	var rateLimited *slackapi.RateLimitedError
+	if errors.As(err, &slackErr) || errors.As(err, &rateLimited) ||
+		errors.Is(err, slack.ErrReadOnly) || errors.Is(err, slack.ErrUserImpersonation) ||
+		errors.Is(err, slack.ErrChannelBlocked) {
+		return true
+	}
+	text := err.Error()
+	for _, marker := range []string{"no such host", "connection refused", "network is unreachable"} {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func TestLedgerReserve(t *testing.T) {
+	ctx := context.Background()
+	ledger, err := Open(DefaultPath(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ledger.Close()
+
+	fp := Fingerprint("C1", "", "hello")
+	if state, _, err := ledger.Reserve(ctx, "k1", fp); err != nil || state != StateReserved {
+		t.Fatalf("first Reserve = %q, %v", state, err)
+	}
+	if state, _, _ := ledger.Reserve(ctx, "k1", fp); state != StateInFlight {
+		t.Fatalf("Reserve before Complete = %q, want %q", state, StateInFlight)
+	}
+	if state, _, _ := ledger.Reserve(ctx, "k1", Fingerprint("C1", "", "other")); state != StateConflict {
+		t.Fatalf("Reserve with another message = %q, want %q", state, StateConflict)
+	}
+
+	if err := ledger.Complete(ctx, "k1", map[string]string{"ts": "1.000100"}); err != nil {
+		t.Fatal(err)
+	}
+	state, entry, err := ledger.Reserve(ctx, "k1", fp)
+	if err != nil || state != StateCompleted || string(entry.Result) != `{"ts":"1.000100"}` {
+		t.Fatalf("Reserve after Complete = %q, %s, %v", state, entry.Result, err)
+	}
+	// Completed keys are never released.
+	if err := ledger.Release(ctx, "k1"); err != nil {
+		t.Fatal(err)
+	}
+	if state, _, _ := ledger.Reserve(ctx, "k1", fp); state != StateCompleted {
+		t.Fatalf("Release removed a completed key: %q", state)
+	}
+
+	if _, _, err := ledger.Reserve(ctx, "k2", fp); err != nil {
+		t.Fatal(err)
+	}
+	if err := ledger.Release(ctx, "k2"); err != nil {
+		t.Fatal(err)
+	}
+	if state, _, _ := ledger.Reserve(ctx, "k2", fp); state != StateReserved {
+		t.Fatalf("Reserve after Release = %q, want %q", state, StateReserved)
+	}
+}
+
+func TestLedgerPersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nested", "idempotency.db")
+	ledger, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger.Reserve(ctx, "k", "fp")
+	ledger.Complete(ctx, "k", "done")
+	ledger.Close()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if state, _, _ := reopened.Reserve(ctx, "k", "fp"); state != StateCompleted {
+		t.Fatalf("state after reopen = %q", state)
+	}
+}
+
+func TestFingerprintSeparatesParts(t *testing.T) {
+	if Fingerprint("ab", "c") == Fingerprint("a", "bc") {
+		t.Fatal("fingerprints of different parts collide")
+	}
+}
+
+func TestNotSent(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{slackapi.SlackErrorResponse{Err: "channel_not_found"}, true},
+		{&slackapi.RateLimitedError{}, true},
+		{errors.New(`Post "https://slack.com/api/chat.postMessage": dial tcp: lookup slack.com: no such host`), true},
+		{errors.New("context deadline exceeded"), false},
+		{errors.New("unexpected EOF"), false},
+	}
+	for _, tt := range tests {
+		if got := NotSent(tt.err); got != tt.want {
+			t.Errorf("NotSent(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestLedgerLastPost(t *testing.T) {
+	ctx := context.Background()
+	ledger, err := Open(DefaultPath(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ledger.Close()
+
+	now := time.Now()
+	fp := Fingerprint("C1", "", "hello")
+	if _, _, ok, err := ledger.LastPost(ctx, "C1", fp, now.Add(-time.Hour)); err != nil || ok {
+		t.Fatalf("LastPost before any post = %v, %v", ok, err)
+	}
+	for _, post := range []struct {
+		ts string
+		at time.Time
+	}{{"1.000100", now.Add(-10 * time.Minute)}, {"2.000200", now.Add(-2 * time.Minute)}} {
+		if err := ledger.RecordPost(ctx, "C1", fp, post.ts, post.at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ts, at, ok, err := ledger.LastPost(ctx, "C1", fp, now.Add(-5*time.Minute))
+	if err != nil || !ok || ts != "2.000200" || !at.Equal(now.Add(-2*time.Minute).UTC()) {
+		t.Fatalf("LastPost = %q, %v, %v, %v", ts, at, ok, err)
+	}
+	if _, _, ok, _ := ledger.LastPost(ctx, "C1", fp, now.Add(-time.Minute)); ok {
+		t.Fatal("LastPost found a post older than since")
+	}
+	if _, _, ok, _ := ledger.LastPost(ctx, "C2", fp, now.Add(-time.Hour)); ok {
+		t.Fatal("LastPost matched another channel")
+	}
+}
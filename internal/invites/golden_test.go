@@ -0,0 +1,26 @@
+package invites
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	list := []Invite{
+		{Email: "ada@example.com", Name: "Ada Lovelace", Channels: []string{"#onboarding", "#eng"}, ChannelIDs: []string{"C1", "C2"}, Method: "admin.users.invite", Sent: true},
+		{Email: "bob@example.com", Channels: []string{"#onboarding"}, ChannelIDs: []string{"C1"}, Method: "admin.users.invite", Error: "admin.users.invite: already_in_team"},
+	}
+	tests := []struct {
+		name string
+		p    interface{ Lines() []string }
+	}{
+		{"SendResult", SendResult{Sent: 1, Failed: 1, Invites: list}},
+		{"SendResult_dry_run", SendResult{DryRun: true, Invites: list}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
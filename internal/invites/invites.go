@@ -0,0 +1,169 @@
+// Package invites reads invite lists and reports workspace invites for
+// slk admin invites send.
+package invites
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"slices"
+	"strings"
+)
+
+// Invitee is one person to invite. Channels are as given (#name or ID) and add to the
+// channels every invitee gets.
+type Invitee struct {
+	Email    string
+	Name     string
+	Channels []string
+}
+
+// ParseEmail accepts a bare address or "Name <address>" and returns the address and
+// name.
+func ParseEmail(s string) (string, string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(s))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid email %q", strings.TrimSpace(s))
+	}
+	return addr.Address, addr.Name, nil
+}
+
+// ReadCSV reads invitees from CSV with a header row. The email column is required;
+// name (or real_name) and channels are optional, and other columns are ignored. A
+// row's channels are separated by semicolons, commas, or spaces.
+func ReadCSV(r io.Reader) ([]Invitee, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if name == "real_name" {
+			name = "name"
+		}
+		if _, dup := columns[name]; !dup {
+			columns[name] = i
+		}
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, fmt.Errorf("csv header has no email column (got %s)", strings.Join(header, ","))
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var invitees []Invitee
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return invitees, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+		raw := field(record, "email")
+		if raw == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		email, name, err := ParseEmail(raw)
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: %w", line, err)
+		}
+		if n := field(record, "name"); n != "" {
+			name = n
+		}
+		invitees = append(invitees, Invitee{
+			Email:    email,
+			Name:     name,
+			Channels: strings.FieldsFunc(field(record, "channels"), func(r rune) bool { return r == ';' || r == ',' || r == ' ' }),
+		})
+	}
+}
+
+// Dedupe merges invitees with the same email, case-insensitively, keeping the first
+// name and every channel.
+func Dedupe(invitees []Invitee) []Invitee {
+	index := map[string]int{}
+	var out []Invitee
+	for _, inv := range invitees {
+		key := strings.ToLower(inv.Email)
+		i, seen := index[key]
+		if !seen {
+			index[key] = len(out)
+			inv.Channels = appendNew(nil, inv.Channels...)
+			out = append(out, inv)
+			continue
+		}
+		if out[i].Name == "" {
+			out[i].Name = inv.Name
+		}
+		out[i].Channels = appendNew(out[i].Channels, inv.Channels...)
+	}
+	return out
+}
+
+func appendNew(list []string, items ...string) []string {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Invite is the outcome for one invitee.
+type Invite struct {
+	Email      string   `json:"email"`
+	Name       string   `json:"name,omitempty"`
+	Channels   []string `json:"channels"`
+	ChannelIDs []string `json:"channel_ids"`
+	// Method is the API method that sent, or last tried to send, the invite.
+	Method string `json:"method,omitempty"`
+	Sent   bool   `json:"sent"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SendResult is the output of admin invites send.
+type SendResult struct {
+	DryRun  bool     `json:"dry_run,omitempty"`
+	Sent    int      `json:"sent"`
+	Failed  int      `json:"failed"`
+	Invites []Invite `json:"invites"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r SendResult) Lines() []string {
+	var lines []string
+	for _, inv := range r.Invites {
+		target := inv.Email
+		if inv.Name != "" {
+			target = fmt.Sprintf("%s <%s>", inv.Name, inv.Email)
+		}
+		target += " → " + strings.Join(inv.Channels, ", ")
+		switch {
+		case r.DryRun:
+			lines = append(lines, "Would invite "+target)
+		case inv.Sent:
+			lines = append(lines, fmt.Sprintf("✓ %s (%s)", target, inv.Method))
+		default:
+			lines = append(lines, fmt.Sprintf("✗ %s: %s", target, inv.Error))
+		}
+	}
+	if r.DryRun {
+		return append(lines, fmt.Sprintf("Dry run: %d to invite, nothing sent", len(r.Invites)))
+	}
+	return append(lines, fmt.Sprintf("Invites: %d sent, %d failed", r.Sent, r.Failed))
+}
@@ -0,0 +1,47 @@
+package invites
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	in := "\ufeffEmail,Real_Name,Channels,Team\n" +
+		"ada@example.com,Ada Lovelace,#eng;#design,ignored\n" +
+		",,,\n" +
+		"Grace Hopper <grace@example.com>,,,\n"
+	got, err := ReadCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Invitee{
+		{Email: "ada@example.com", Name: "Ada Lovelace", Channels: []string{"#eng", "#design"}},
+		{Email: "grace@example.com", Name: "Grace Hopper", Channels: []string{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCSV() = %#v, want %#v", got, want)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("name\nAda\n")); err == nil || !strings.Contains(err.Error(), "email column") {
+		t.Errorf("missing email column: err = %v", err)
+	}
+	if _, err := ReadCSV(strings.NewReader("email\nada@example.com\nnot-an-email\n")); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("invalid email: err = %v, want the line number", err)
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	got := Dedupe([]Invitee{
+		{Email: "ada@example.com", Channels: []string{"#onboarding"}},
+		{Email: "ADA@example.com", Name: "Ada", Channels: []string{"#onboarding", "#eng"}},
+		{Email: "grace@example.com", Channels: []string{"#onboarding"}},
+	})
+	want := []Invitee{
+		{Email: "ada@example.com", Name: "Ada", Channels: []string{"#onboarding", "#eng"}},
+		{Email: "grace@example.com", Channels: []string{"#onboarding"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Dedupe() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+// Package links expands ticket references in message text, such as ABC-123 or #4567,
+// into links to the tracker they belong to, using the expanders configured under
+// "expanders" in config.
+package links
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+)
+
+// verbatim matches text expanders leave alone: URLs, Slack link markup such as
+// <https://...|label> or <@U123>, labels already followed by their URL as in
+// "ABC-1 (https://...)", Markdown links, and code spans.
+var verbatim = regexp.MustCompile("<[^<>\\s][^<>]*>|[^\\s()]+ \\(https?://[^\\s)]+\\)|\\bhttps?://[^\\s<>]+|\\[[^\\]\\n]*\\]\\([^)\\s]*\\)|```[\\s\\S]*?```|`[^`\\n]*`")
+
+type expander struct {
+	name string
+	re   *regexp.Regexp
+	url  string
+}
+
+// Set is a list of compiled expanders. A nil Set expands nothing.
+type Set struct {
+	expanders []expander
+}
+
+// New compiles expanders. It returns nil when there are none.
+func New(expanders []config.Expander) (*Set, error) {
+	if len(expanders) == 0 {
+		return nil, nil
+	}
+	s := &Set{}
+	for i, e := range expanders {
+		name := e.Name
+		if name == "" {
+			name = fmt.Sprintf("expanders[%d]", i)
+		}
+		if strings.TrimSpace(e.Pattern) == "" || strings.TrimSpace(e.URL) == "" {
+			return nil, fmt.Errorf("%s: pattern and url are required", name)
+		}
+		re, err := regexp.Compile(e.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		s.expanders = append(s.expanders, expander{name: name, re: re, url: e.URL})
+	}
+	return s, nil
+}
+
+// Match is one expanded reference in a text.
+type Match struct {
+	Start int
+	End   int
+	Text  string
+	URL   string
+}
+
+// Find returns the references in text, in order and without overlaps. When two
+// expanders match at the same place the one listed first wins. Empty matches and
+// matches touching a URL, Slack link markup, or code are skipped, as are matches
+// ending in an ellipsis, which may be a longer reference cut short for display.
+func (s *Set) Find(text string) []Match {
+	if s == nil || text == "" {
+		return nil
+	}
+	skip := verbatim.FindAllStringIndex(text, -1)
+	var found []Match
+	for _, e := range s.expanders {
+		for _, idx := range e.re.FindAllStringSubmatchIndex(text, -1) {
+			if idx[0] == idx[1] || overlaps(skip, idx[0], idx[1]) || cut(text[idx[1]:]) {
+				continue
+			}
+			url := string(e.re.ExpandString(nil, e.url, text, idx))
+			found = append(found, Match{Start: idx[0], End: idx[1], Text: text[idx[0]:idx[1]], URL: url})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Start < found[j].Start })
+	matches := found[:0]
+	end := 0
+	for _, m := range found {
+		if m.Start < end {
+			continue
+		}
+		matches = append(matches, m)
+		end = m.End
+	}
+	return matches
+}
+
+// Plain adds each reference's URL after it in parentheses, the way transcripts show
+// Slack links, so terminals can open it.
+func (s *Set) Plain(text string) string {
+	return s.replace(text, func(m Match) string { return fmt.Sprintf("%s (%s)", m.Text, m.URL) }, nil)
+}
+
+// Markdown turns each reference into a Markdown link.
+func (s *Set) Markdown(text string) string {
+	return s.replace(text, func(m Match) string { return fmt.Sprintf("[%s](%s)", m.Text, m.URL) }, nil)
+}
+
+// HTML escapes text and turns each reference into an anchor.
+func (s *Set) HTML(text string) template.HTML {
+	out := s.replace(text, func(m Match) string {
+		return fmt.Sprintf(`<a href="%s">%s</a>`, template.HTMLEscapeString(m.URL), template.HTMLEscapeString(m.Text))
+	}, template.HTMLEscapeString)
+	return template.HTML(out)
+}
+
+// replace rewrites the references in text with link, passing the text between them
+// through other when it is set.
+func (s *Set) replace(text string, link func(Match) string, other func(string) string) string {
+	if other == nil {
+		other = func(s string) string { return s }
+	}
+	matches := s.Find(text)
+	if len(matches) == 0 {
+		return other(text)
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(other(text[last:m.Start]))
+		b.WriteString(link(m))
+		last = m.End
+	}
+	b.WriteString(other(text[last:]))
+	return b.String()
+}
+
+func cut(rest string) bool {
+	return strings.HasPrefix(rest, "...") || strings.HasPrefix(rest, "…")
+}
+
+func overlaps(spans [][]int, start, end int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package links
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+)
+
+func testSet(t *testing.T) *Set {
+	t.Helper()
+	s, err := New([]config.Expander{
+		{Name: "jira", Pattern: `\b([A-Z][A-Z0-9]+)-(\d+)\b`, URL: "https://acme.atlassian.net/browse/$1-$2"},
+		{Name: "github", Pattern: `\B#(?P<num>\d+)\b`, URL: "https://github.com/acme/app/issues/${num}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestPlain(t *testing.T) {
+	s := testSet(t)
+	tests := []struct {
+		in, want string
+	}{
+		{"Fixed in ABC-123", "Fixed in ABC-123 (https://acme.atlassian.net/browse/ABC-123)"},
+		{"see #4567 and OPS-9.", "see #4567 (https://github.com/acme/app/issues/4567) and OPS-9 (https://acme.atlassian.net/browse/OPS-9)."},
+		{"issue#12 and #general", "issue#12 and #general"},
+		{"<https://acme.atlassian.net/browse/ABC-1|ABC-1> https://x.test/ABC-2", "<https://acme.atlassian.net/browse/ABC-1|ABC-1> https://x.test/ABC-2"},
+		{"run `make ABC-1` then ABC-2", "run `make ABC-1` then ABC-2 (https://acme.atlassian.net/browse/ABC-2)"},
+		{"no refs", "no refs"},
+		{"Deploy ABC-12...", "Deploy ABC-12..."},
+		{"ABC-1 (https://acme.atlassian.net/browse/ABC-1)", "ABC-1 (https://acme.atlassian.net/browse/ABC-1)"},
+	}
+	for _, tt := range tests {
+		if got := s.Plain(tt.in); got != tt.want {
+			t.Errorf("Plain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownAndHTML(t *testing.T) {
+	s := testSet(t)
+	if got, want := s.Markdown("ABC-1 & #2"), "[ABC-1](https://acme.atlassian.net/browse/ABC-1) & [#2](https://github.com/acme/app/issues/2)"; got != want {
+		t.Errorf("Markdown = %q, want %q", got, want)
+	}
+	if got, want := string(s.HTML("<b> ABC-1")), `&lt;b&gt; <a href="https://acme.atlassian.net/browse/ABC-1">ABC-1</a>`; got != want {
+		t.Errorf("HTML = %q, want %q", got, want)
+	}
+	var none *Set
+	if got := none.Plain("ABC-1"); got != "ABC-1" {
+		t.Errorf("nil Plain = %q", got)
+	}
+	if got := string(none.HTML("a < b")); got != "a &lt; b" {
+		t.Errorf("nil HTML = %q", got)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if s, err := New(nil); s != nil || err != nil {
+		t.Errorf("New(nil) = %v, %v", s, err)
+	}
+	for _, e := range []config.Expander{
+		{Name: "bad", Pattern: `(`, URL: "https://x/$0"},
+		{Pattern: `ABC-\d+`},
+	} {
+		if _, err := New([]config.Expander{e}); err == nil {
+			t.Errorf("New(%+v) succeeded", e)
+		}
+	}
+}
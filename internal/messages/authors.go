@@ -0,0 +1,64 @@
+package messages
+
+import (
+	"fmt"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// AuthorFilter selects messages by whether a bot or a person posted them.
+type AuthorFilter int
+
+const (
+	// AllAuthors keeps every message.
+	AllAuthors AuthorFilter = iota
+	// BotsOnly keeps messages posted by bots and integrations.
+	BotsOnly
+	// HumansOnly keeps messages posted by people.
+	HumansOnly
+)
+
+// ParseAuthorFilter builds a filter from --bots-only and --humans-only.
+func ParseAuthorFilter(botsOnly, humansOnly bool) (AuthorFilter, error) {
+	switch {
+	case botsOnly && humansOnly:
+		return AllAuthors, fmt.Errorf("--bots-only and --humans-only cannot be combined")
+	case botsOnly:
+		return BotsOnly, nil
+	case humansOnly:
+		return HumansOnly, nil
+	}
+	return AllAuthors, nil
+}
+
+// IsBotMessage reports whether a message with the given bot ID and subtype was
+// posted by a bot. Apps posting as themselves carry a bot_id; legacy integrations
+// and webhooks use the bot_message subtype.
+func IsBotMessage(botID, subtype string) bool {
+	return botID != "" || subtype == "bot_message"
+}
+
+// Allows reports whether a message with the given bot ID and subtype passes the filter.
+func (f AuthorFilter) Allows(botID, subtype string) bool {
+	switch f {
+	case BotsOnly:
+		return IsBotMessage(botID, subtype)
+	case HumansOnly:
+		return !IsBotMessage(botID, subtype)
+	}
+	return true
+}
+
+// Apply removes messages whose author the filter rejects.
+func (f AuthorFilter) Apply(msgs []slackapi.Message) []slackapi.Message {
+	if f == AllAuthors {
+		return msgs
+	}
+	kept := msgs[:0]
+	for _, msg := range msgs {
+		if f.Allows(msg.BotID, msg.SubType) {
+			kept = append(kept, msg)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,40 @@
+package messages
+
+import (
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func TestAuthorFilter(t *testing.T) {
+	msgs := func() []slackapi.Message {
+		return []slackapi.Message{
+			{Msg: slackapi.Msg{Timestamp: "1", User: "U1"}},
+			{Msg: slackapi.Msg{Timestamp: "2", User: "U2", BotID: "B1"}},
+			{Msg: slackapi.Msg{Timestamp: "3", SubType: "bot_message", Username: "webhook"}},
+		}
+	}
+
+	f, err := ParseAuthorFilter(true, false)
+	if err != nil {
+		t.Fatalf("ParseAuthorFilter: %v", err)
+	}
+	if kept := f.Apply(msgs()); len(kept) != 2 || kept[0].Timestamp != "2" || kept[1].Timestamp != "3" {
+		t.Fatalf("bots-only kept %+v", kept)
+	}
+
+	f, err = ParseAuthorFilter(false, true)
+	if err != nil {
+		t.Fatalf("ParseAuthorFilter: %v", err)
+	}
+	if kept := f.Apply(msgs()); len(kept) != 1 || kept[0].Timestamp != "1" {
+		t.Fatalf("humans-only kept %+v", kept)
+	}
+
+	if kept := AllAuthors.Apply(msgs()); len(kept) != 3 {
+		t.Fatalf("no filter kept %d messages", len(kept))
+	}
+	if _, err := ParseAuthorFilter(true, true); err == nil {
+		t.Fatal("expected error for --bots-only with --humans-only")
+	}
+}
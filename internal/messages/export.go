@@ -0,0 +1,526 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/ratelimit"
+	"github.com/kehao95/slack-agent-cli/internal/segment"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// CheckpointVersion is the current checkpoint file format version.
+const CheckpointVersion = 1
+
+// Checkpoint records the progress of a messages export so an interrupted run can resume
+// from the last saved page instead of starting over. It mirrors the partial cache state:
+// the next cursor to fetch plus enough bookkeeping to validate the output file.
+type Checkpoint struct {
+	Version       int    `json:"version"`
+	Channel       string `json:"channel"`
+	Output        string `json:"output"`
+	ThreadsOutput string `json:"threads_output,omitempty"`
+	Oldest        string `json:"oldest,omitempty"`
+	Latest        string `json:"latest,omitempty"`
+	Cursor        string `json:"cursor"`
+	LastTS        string `json:"last_ts,omitempty"`
+	Exported      int    `json:"exported"`
+	ThreadReplies int    `json:"thread_replies,omitempty"`
+	Pages         int    `json:"pages"`
+	OutputBytes   int64  `json:"output_bytes"`
+	ThreadsBytes  int64  `json:"threads_bytes,omitempty"`
+	// Scrub is the --scrub mode the export started with, so a resume keeps it.
+	Scrub string `json:"scrub,omitempty"`
+	// OutputSegments and ThreadsSegments are set for compressed or rotated output: the
+	// options the export started with and the segments as of this checkpoint. The
+	// *Bytes fields then count uncompressed bytes and are not file offsets.
+	OutputSegments  *segment.Manifest `json:"output_segments,omitempty"`
+	ThreadsSegments *segment.Manifest `json:"threads_segments,omitempty"`
+	Complete        bool              `json:"complete"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// LoadCheckpoint reads a checkpoint file written by SaveCheckpoint.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("checkpoint not found: %s", path)
+		}
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+	if cp.Version != CheckpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version %d", cp.Version)
+	}
+	if cp.Channel == "" || cp.Output == "" {
+		return nil, fmt.Errorf("checkpoint %s is missing channel or output", path)
+	}
+	return &cp, nil
+}
+
+// SaveCheckpoint atomically writes cp to path.
+func SaveCheckpoint(path string, cp *Checkpoint) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("create checkpoint dir: %w", err)
+		}
+	}
+	cp.Version = CheckpointVersion
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write checkpoint tmp: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename checkpoint tmp: %w", err)
+	}
+	return nil
+}
+
+// ExportConfig controls export pagination and checkpoint behavior.
+type ExportConfig struct {
+	// PageSize is the number of messages requested per API call (default 200).
+	PageSize int
+	// PageDelay is the delay between pages to stay under rate limits (default 1s).
+	// It is ignored when Limiter is set.
+	PageDelay time.Duration
+	// Limiter paces every API call when several exports share one request budget.
+	Limiter *ratelimit.Limiter
+	// Threads receives thread replies (one JSON line per reply, root excluded);
+	// nil skips fetching threads.
+	Threads io.Writer
+	// CheckpointEvery is the number of pages between checkpoint writes (default 1).
+	CheckpointEvery int
+	// SaveCheckpoint persists progress; nil disables checkpointing.
+	SaveCheckpoint func(*Checkpoint) error
+	// Output receives progress messages (can be nil for silent operation).
+	Output io.Writer
+	// Scrub rewrites each exported JSON document, e.g. to mask personal data; nil
+	// writes messages unchanged.
+	Scrub func([]byte) ([]byte, error)
+}
+
+// ExportResult summarizes an export run.
+type ExportResult struct {
+	Channel     string `json:"channel"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	Output      string `json:"output"`
+	Checkpoint  string `json:"checkpoint,omitempty"`
+	Exported    int    `json:"exported"`
+	Pages       int    `json:"pages"`
+	Resumed     bool   `json:"resumed"`
+	Complete    bool   `json:"complete"`
+	NextCursor  string `json:"next_cursor,omitempty"`
+	OldestTS    string `json:"oldest,omitempty"`
+	LatestTS    string `json:"latest,omitempty"`
+	LastTS      string `json:"last_ts,omitempty"`
+	OutputBytes int64  `json:"output_bytes"`
+	// Manifest lists the segments of compressed or rotated output.
+	Manifest string `json:"manifest,omitempty"`
+	Segments int    `json:"segments,omitempty"`
+}
+
+// Lines returns human-readable lines for ExportResult.
+func (r ExportResult) Lines() []string {
+	status := "complete"
+	if !r.Complete {
+		status = "incomplete"
+	}
+	lines := []string{
+		fmt.Sprintf("Exported %d messages from %s to %s (%s)", r.Exported, r.Channel, r.Output, status),
+		fmt.Sprintf("Pages: %d", r.Pages),
+	}
+	if r.Manifest != "" {
+		lines = append(lines, fmt.Sprintf("Segments: %d (manifest %s)", r.Segments, r.Manifest))
+	}
+	if r.Resumed {
+		lines = append(lines, "Resumed from checkpoint")
+	}
+	if !r.Complete && r.Checkpoint != "" {
+		lines = append(lines, fmt.Sprintf("Resume with: slk messages export --resume %s", r.Checkpoint))
+	}
+	return lines
+}
+
+// Exporter streams channel history to NDJSON, one message per line.
+type Exporter struct {
+	fetcher Fetcher
+}
+
+// NewExporter constructs an Exporter.
+func NewExporter(fetcher Fetcher) *Exporter {
+	return &Exporter{fetcher: fetcher}
+}
+
+// Export fetches every page of history described by cp and appends each message as a JSON
+// line to w. cp is updated in place after each page; progress is persisted every
+// cfg.CheckpointEvery pages and whenever the run stops early. Messages are written in the
+// order Slack returns them (newest first).
+func (e *Exporter) Export(ctx context.Context, cp *Checkpoint, w io.Writer, cfg ExportConfig) error {
+	if cp == nil || cp.Channel == "" {
+		return fmt.Errorf("channel is required")
+	}
+	if cp.Complete {
+		return nil
+	}
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = 200
+	}
+	if cfg.PageDelay == 0 {
+		cfg.PageDelay = time.Second
+	}
+	if cfg.CheckpointEvery <= 0 {
+		cfg.CheckpointEvery = 1
+	}
+
+	save := func() error {
+		if cfg.SaveCheckpoint == nil {
+			return nil
+		}
+		if out, ok := w.(*segment.Writer); ok {
+			cp.OutputSegments = out.State()
+		}
+		if out, ok := cfg.Threads.(*segment.Writer); ok {
+			cp.ThreadsSegments = out.State()
+		}
+		cp.UpdatedAt = time.Now().UTC()
+		return cfg.SaveCheckpoint(cp)
+	}
+
+	// Progress is only durable once it is checkpointed; remember the last persisted
+	// state so an error mid-run never records pages that were not fully written.
+	saved := *cp
+	sinceSave := 0
+	for {
+		select {
+		case <-ctx.Done():
+			*cp = saved
+			_ = save()
+			return ctx.Err()
+		default:
+		}
+
+		var (
+			msgs       []slackapi.Message
+			nextCursor string
+		)
+		err := e.call(ctx, cfg, func() error {
+			var err error
+			msgs, nextCursor, _, err = e.fetcher.ListMessages(ctx, slack.HistoryParams{
+				Channel: cp.Channel,
+				Cursor:  cp.Cursor,
+				Limit:   cfg.PageSize,
+				Oldest:  cp.Oldest,
+				Latest:  cp.Latest,
+			})
+			return err
+		})
+		if err != nil {
+			*cp = saved
+			_ = save()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("export %s: %w", cp.Channel, err)
+		}
+
+		written, err := writeMessages(w, msgs, cfg.Scrub)
+		if err != nil {
+			*cp = saved
+			_ = save()
+			return fmt.Errorf("write export: %w", err)
+		}
+		if cfg.Threads != nil {
+			replies, threadBytes, err := e.exportThreads(ctx, cp.Channel, msgs, cfg)
+			if err != nil {
+				*cp = saved
+				_ = save()
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("export %s threads: %w", cp.Channel, err)
+			}
+			cp.ThreadReplies += replies
+			cp.ThreadsBytes += threadBytes
+		}
+		cp.OutputBytes += written
+		cp.Exported += len(msgs)
+		cp.Pages++
+		if len(msgs) > 0 {
+			cp.LastTS = msgs[len(msgs)-1].Timestamp
+		}
+		cp.Cursor = nextCursor
+		cp.Complete = nextCursor == ""
+		logf(cfg.Output, "Page %d: %d messages (%d total)\n", cp.Pages, len(msgs), cp.Exported)
+
+		sinceSave++
+		if cp.Complete || sinceSave >= cfg.CheckpointEvery {
+			for _, out := range []io.Writer{w, cfg.Threads} {
+				if seg, ok := out.(*segment.Writer); ok && cp.Complete {
+					if err := seg.Finish(); err != nil {
+						return fmt.Errorf("finish export: %w", err)
+					}
+				} else if syncer, ok := out.(interface{ Sync() error }); ok {
+					if err := syncer.Sync(); err != nil {
+						return fmt.Errorf("sync export: %w", err)
+					}
+				}
+			}
+			if err := save(); err != nil {
+				return err
+			}
+			saved = *cp
+			sinceSave = 0
+		}
+		if cp.Complete {
+			return nil
+		}
+
+		if cfg.Limiter != nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			*cp = saved
+			_ = save()
+			return ctx.Err()
+		case <-time.After(cfg.PageDelay):
+		}
+	}
+}
+
+// exportThreads writes the replies of every thread root in msgs and returns the number of
+// replies and bytes written.
+func (e *Exporter) exportThreads(ctx context.Context, channel string, msgs []slackapi.Message, cfg ExportConfig) (int, int64, error) {
+	var (
+		count   int
+		written int64
+	)
+	for _, root := range msgs {
+		if root.ReplyCount == 0 || (root.ThreadTimestamp != "" && root.ThreadTimestamp != root.Timestamp) {
+			continue
+		}
+		cursor := ""
+		for {
+			var (
+				replies []slackapi.Message
+				next    string
+			)
+			err := e.call(ctx, cfg, func() error {
+				var err error
+				replies, next, _, err = e.fetcher.ListThread(ctx, slack.ThreadParams{
+					Channel: channel,
+					Thread:  root.Timestamp,
+					Cursor:  cursor,
+					Limit:   cfg.PageSize,
+				})
+				return err
+			})
+			if err != nil {
+				return count, written, err
+			}
+			filtered := replies[:0]
+			for _, reply := range replies {
+				if reply.Timestamp != root.Timestamp {
+					filtered = append(filtered, reply)
+				}
+			}
+			n, err := writeMessages(cfg.Threads, filtered, cfg.Scrub)
+			written += n
+			if err != nil {
+				return count, written, err
+			}
+			count += len(filtered)
+			if next == "" {
+				break
+			}
+			cursor = next
+			if cfg.Limiter == nil {
+				select {
+				case <-ctx.Done():
+					return count, written, ctx.Err()
+				case <-time.After(cfg.PageDelay):
+				}
+			}
+		}
+	}
+	return count, written, nil
+}
+
+// call runs one API request, pacing it through the shared limiter and retrying the same
+// request after rate-limit responses.
+func (e *Exporter) call(ctx context.Context, cfg ExportConfig, fn func() error) error {
+	for {
+		if cfg.Limiter != nil {
+			if err := cfg.Limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		wait, ok := rateLimitWait(err)
+		if !ok {
+			return err
+		}
+		logf(cfg.Output, "Rate limited, waiting %v...\n", wait)
+		if cfg.Limiter != nil {
+			cfg.Limiter.Backoff(wait)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// PrepareOutput opens the export file for a run described by cp. Resumed runs truncate the
+// file back to the last checkpointed size so a page written after the final checkpoint is
+// not duplicated. When cp.OutputSegments is set the output is written as compressed or
+// rotated segments instead.
+func PrepareOutput(cp *Checkpoint, resume bool) (io.WriteCloser, error) {
+	if cp.OutputSegments != nil {
+		return openSegments(cp.OutputSegments, resume)
+	}
+	return OpenExportFile(cp.Output, cp.OutputBytes, resume)
+}
+
+// PrepareThreadsOutput opens the thread replies file for cp, truncating it like PrepareOutput.
+func PrepareThreadsOutput(cp *Checkpoint, resume bool) (io.WriteCloser, error) {
+	if cp.ThreadsSegments != nil {
+		return openSegments(cp.ThreadsSegments, resume)
+	}
+	return OpenExportFile(cp.ThreadsOutput, cp.ThreadsBytes, resume)
+}
+
+// SegmentOutputs makes cp write compressed or rotated segments with opts. It does
+// nothing when opts asks for neither. Call it once cp's channel and scrub mode are set.
+func SegmentOutputs(cp *Checkpoint, opts segment.Options) {
+	if !opts.Enabled() {
+		return
+	}
+	cp.OutputSegments = segment.New(cp.Output, opts)
+	cp.OutputSegments.Channel, cp.OutputSegments.Kind, cp.OutputSegments.Scrub = cp.Channel, SegmentMessages, cp.Scrub
+	if cp.ThreadsOutput != "" {
+		cp.ThreadsSegments = segment.New(cp.ThreadsOutput, opts)
+		cp.ThreadsSegments.Channel, cp.ThreadsSegments.Kind, cp.ThreadsSegments.Scrub = cp.Channel, SegmentThreadReplies, cp.Scrub
+	}
+}
+
+// Kinds of exported segments.
+const (
+	SegmentMessages      = "messages"
+	SegmentThreadReplies = "thread_replies"
+)
+
+func openSegments(m *segment.Manifest, resume bool) (io.WriteCloser, error) {
+	w, err := segment.Open(m, resume)
+	if err != nil {
+		return nil, fmt.Errorf("open export output: %w", err)
+	}
+	return w, nil
+}
+
+// OpenExportFile opens path for writing. Fresh runs truncate the file; resumed runs keep the
+// first offset bytes and continue writing after them.
+func OpenExportFile(path string, offset int64, resume bool) (*os.File, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create output dir: %w", err)
+		}
+	}
+	if !resume {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open export output: %w", err)
+		}
+		return f, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open export output: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("stat export output: %w", err)
+	}
+	if info.Size() < offset {
+		f.Close()
+		return nil, fmt.Errorf("export output %s is shorter than checkpoint (%d < %d bytes)", path, info.Size(), offset)
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("truncate export output: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("seek export output: %w", err)
+	}
+	return f, nil
+}
+
+// DefaultCheckpointPath returns the checkpoint path used for an export output file.
+func DefaultCheckpointPath(output string) string {
+	return strings.TrimSuffix(output, filepath.Ext(output)) + ".checkpoint.json"
+}
+
+func writeMessages(w io.Writer, msgs []slackapi.Message, scrub func([]byte) ([]byte, error)) (int64, error) {
+	var written int64
+	for _, msg := range msgs {
+		line, err := json.Marshal(msg)
+		if err != nil {
+			return written, err
+		}
+		if scrub != nil {
+			if line, err = scrub(line); err != nil {
+				return written, err
+			}
+		}
+		n, err := w.Write(append(line, '\n'))
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func rateLimitWait(err error) (time.Duration, bool) {
+	var rlErr *slackapi.RateLimitedError
+	if errors.As(err, &rlErr) {
+		return rlErr.RetryAfter, true
+	}
+	if strings.Contains(err.Error(), "rate limit") {
+		return 30 * time.Second, true
+	}
+	return 0, false
+}
+
+func logf(w io.Writer, format string, args ...interface{}) {
+	if w != nil {
+		fmt.Fprintf(w, format, args...)
+	}
+}
@@ -0,0 +1,396 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/segment"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// ManifestVersion is the current export manifest format version.
+const ManifestVersion = 1
+
+// ManifestFile is the file name of the manifest written next to multi-channel exports.
+const ManifestFile = "manifest.json"
+
+// ChannelPlaceholder is replaced by the channel name in export output patterns.
+const ChannelPlaceholder = "{channel}"
+
+// MetadataClient provides the channel metadata and pins recorded alongside an export.
+type MetadataClient interface {
+	GetConversationInfo(ctx context.Context, channelID string) (*slackapi.Channel, error)
+	ListPins(ctx context.Context, channel string) (*slack.PinListResult, error)
+}
+
+// ExportManifest describes every channel written by a multi-channel export.
+type ExportManifest struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Oldest     string            `json:"oldest,omitempty"`
+	Latest     string            `json:"latest,omitempty"`
+	Complete   bool              `json:"complete"`
+	Channels   []ManifestChannel `json:"channels"`
+	path       string            `json:"-"`
+}
+
+// ManifestChannel records channel metadata and the files produced for one channel.
+type ManifestChannel struct {
+	ID           string `json:"id"`
+	Name         string `json:"name,omitempty"`
+	IsPrivate    bool   `json:"is_private"`
+	IsArchived   bool   `json:"is_archived"`
+	Topic        string `json:"topic,omitempty"`
+	Purpose      string `json:"purpose,omitempty"`
+	Created      int64  `json:"created,omitempty"`
+	NumMembers   int    `json:"num_members,omitempty"`
+	MessagesFile string `json:"messages_file"`
+	ThreadsFile  string `json:"threads_file,omitempty"`
+	PinsFile     string `json:"pins_file,omitempty"`
+	// MessagesManifest and ThreadsManifest list the segments of compressed or rotated
+	// output, which is not written to MessagesFile and ThreadsFile themselves.
+	MessagesManifest string `json:"messages_manifest,omitempty"`
+	ThreadsManifest  string `json:"threads_manifest,omitempty"`
+	MessageCount     int    `json:"message_count"`
+	ThreadReplyCount int    `json:"thread_reply_count,omitempty"`
+	PinCount         int    `json:"pin_count,omitempty"`
+	Resumed          bool   `json:"resumed,omitempty"`
+	Complete         bool   `json:"complete"`
+	Checkpoint       string `json:"checkpoint,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// Path returns the location the manifest was written to.
+func (m *ExportManifest) Path() string {
+	return m.path
+}
+
+// Lines returns human-readable lines for ExportManifest.
+func (m *ExportManifest) Lines() []string {
+	status := "complete"
+	if !m.Complete {
+		status = "incomplete"
+	}
+	lines := []string{
+		fmt.Sprintf("Exported %d channels (%s)", len(m.Channels), status),
+	}
+	if m.path != "" {
+		lines = append(lines, fmt.Sprintf("Manifest: %s", m.path))
+	}
+	for _, ch := range m.Channels {
+		name := ch.Name
+		if name == "" {
+			name = ch.ID
+		}
+		line := fmt.Sprintf("  #%s: %d messages", name, ch.MessageCount)
+		if ch.ThreadsFile != "" {
+			line += fmt.Sprintf(", %d thread replies", ch.ThreadReplyCount)
+		}
+		if ch.PinsFile != "" {
+			line += fmt.Sprintf(", %d pins", ch.PinCount)
+		}
+		if ch.MessagesManifest != "" {
+			line += " -> " + ch.MessagesManifest
+		} else {
+			line += " -> " + ch.MessagesFile
+		}
+		if ch.Error != "" {
+			line += " (error: " + ch.Error + ")"
+		} else if !ch.Complete {
+			line += " (incomplete)"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// BatchExportConfig controls a multi-channel export.
+type BatchExportConfig struct {
+	// OutputPattern is a directory, or a file pattern containing {channel}
+	// (e.g. ./export/{channel}.ndjson).
+	OutputPattern string
+	// Oldest and Latest bound the exported history (Slack timestamps).
+	Oldest string
+	Latest string
+	// Concurrency is the number of channels exported at once (default 4).
+	Concurrency int
+	// Threads also exports thread replies to <channel>.threads.ndjson.
+	Threads bool
+	// Pins also exports pinned items to <channel>.pins.json.
+	Pins bool
+	// Segments compresses or rotates the messages and thread replies files.
+	Segments segment.Options
+	// ScrubMode is the --scrub mode Export.Scrub applies, recorded in checkpoints and
+	// segment manifests.
+	ScrubMode string
+	// Export is the per-channel export configuration; its Limiter is shared by all workers.
+	Export ExportConfig
+}
+
+// BatchExporter exports several channels concurrently into one directory.
+type BatchExporter struct {
+	exporter *Exporter
+	meta     MetadataClient
+}
+
+// NewBatchExporter constructs a BatchExporter.
+func NewBatchExporter(fetcher Fetcher, meta MetadataClient) *BatchExporter {
+	return &BatchExporter{exporter: NewExporter(fetcher), meta: meta}
+}
+
+// Run exports channelIDs and writes a manifest next to the output files. Channels with an
+// existing checkpoint in the output directory are resumed. The manifest is written even
+// when some channels fail; the returned error reports the first failure.
+func (b *BatchExporter) Run(ctx context.Context, channelIDs []string, cfg BatchExportConfig) (*ExportManifest, error) {
+	if len(channelIDs) == 0 {
+		return nil, fmt.Errorf("at least one channel is required")
+	}
+	pattern := ExportOutputPattern(cfg.OutputPattern)
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+
+	results := make([]ManifestChannel, len(channelIDs))
+	errs := make([]error, len(channelIDs))
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, channelID := range channelIDs {
+		wg.Add(1)
+		go func(i int, channelID string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = ManifestChannel{ID: channelID, Error: ctx.Err().Error()}
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			results[i], errs[i] = b.exportChannel(ctx, channelID, pattern, cfg)
+		}(i, channelID)
+	}
+	wg.Wait()
+
+	manifest := &ExportManifest{
+		Version:    ManifestVersion,
+		ExportedAt: time.Now().UTC(),
+		Oldest:     cfg.Oldest,
+		Latest:     cfg.Latest,
+		Complete:   true,
+		Channels:   results,
+		path:       filepath.Join(filepath.Dir(pattern), ManifestFile),
+	}
+	manifestDir := filepath.Dir(manifest.path)
+	for i := range results {
+		results[i].relativeTo(manifestDir)
+	}
+	var firstErr error
+	for i, err := range errs {
+		if !results[i].Complete {
+			manifest.Complete = false
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := writeJSONFile(manifest.path, manifest); err != nil {
+		return manifest, fmt.Errorf("write manifest: %w", err)
+	}
+	return manifest, firstErr
+}
+
+func (b *BatchExporter) exportChannel(ctx context.Context, channelID, pattern string, cfg BatchExportConfig) (ManifestChannel, error) {
+	entry := ManifestChannel{ID: channelID}
+	var info *slackapi.Channel
+	err := b.exporter.call(ctx, cfg.Export, func() error {
+		var err error
+		info, err = b.meta.GetConversationInfo(ctx, channelID)
+		return err
+	})
+	if err != nil {
+		entry.Error = err.Error()
+		return entry, fmt.Errorf("channel info %s: %w", channelID, err)
+	}
+	applyChannelInfo(&entry, info)
+
+	entry.MessagesFile = strings.ReplaceAll(pattern, ChannelPlaceholder, exportFileName(entry))
+	base := strings.TrimSuffix(entry.MessagesFile, filepath.Ext(entry.MessagesFile))
+	checkpointPath := base + ".checkpoint.json"
+
+	cp, resume := resumableCheckpoint(checkpointPath, channelID)
+	if !resume {
+		cp = &Checkpoint{Channel: channelID, Output: entry.MessagesFile, Oldest: cfg.Oldest, Latest: cfg.Latest, Scrub: cfg.ScrubMode}
+		if cfg.Threads {
+			cp.ThreadsOutput = base + ".threads.ndjson"
+		}
+		SegmentOutputs(cp, cfg.Segments)
+	}
+	entry.Resumed = resume
+	entry.ThreadsFile = cp.ThreadsOutput
+	if cp.OutputSegments != nil {
+		entry.MessagesManifest = segment.ManifestPath(cp.Output)
+	}
+	if cp.ThreadsSegments != nil {
+		entry.ThreadsManifest = segment.ManifestPath(cp.ThreadsOutput)
+	}
+
+	out, err := PrepareOutput(cp, resume)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry, err
+	}
+	defer out.Close()
+
+	exportCfg := cfg.Export
+	exportCfg.Threads = nil
+	if cp.ThreadsOutput != "" {
+		threads, err := PrepareThreadsOutput(cp, resume)
+		if err != nil {
+			entry.Error = err.Error()
+			return entry, err
+		}
+		defer threads.Close()
+		exportCfg.Threads = threads
+	}
+	exportCfg.SaveCheckpoint = func(cp *Checkpoint) error {
+		return SaveCheckpoint(checkpointPath, cp)
+	}
+
+	exportErr := b.exporter.Export(ctx, cp, out, exportCfg)
+	entry.MessageCount = cp.Exported
+	entry.ThreadReplyCount = cp.ThreadReplies
+	entry.Complete = cp.Complete
+	if exportErr != nil {
+		entry.Checkpoint = checkpointPath
+		entry.Error = exportErr.Error()
+		return entry, exportErr
+	}
+
+	if cfg.Pins {
+		var pins *slack.PinListResult
+		err := b.exporter.call(ctx, cfg.Export, func() error {
+			var err error
+			pins, err = b.meta.ListPins(ctx, channelID)
+			return err
+		})
+		if err != nil {
+			entry.Complete = false
+			entry.Checkpoint = checkpointPath
+			entry.Error = fmt.Sprintf("pins: %v", err)
+			return entry, fmt.Errorf("pins %s: %w", channelID, err)
+		}
+		entry.PinsFile = base + ".pins.json"
+		entry.PinCount = len(pins.Items)
+		var items any = pins.Items
+		if cfg.Export.Scrub != nil {
+			data, err := json.Marshal(pins.Items)
+			if err == nil {
+				data, err = cfg.Export.Scrub(data)
+			}
+			if err != nil {
+				entry.Complete = false
+				entry.Error = err.Error()
+				return entry, err
+			}
+			items = json.RawMessage(data)
+		}
+		if err := writeJSONFile(entry.PinsFile, items); err != nil {
+			entry.Complete = false
+			entry.Error = err.Error()
+			return entry, err
+		}
+	}
+
+	if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+		return entry, fmt.Errorf("remove checkpoint: %w", err)
+	}
+	return entry, nil
+}
+
+// relativeTo rewrites file references relative to dir so the export directory can be moved.
+func (c *ManifestChannel) relativeTo(dir string) {
+	for _, path := range []*string{&c.MessagesFile, &c.ThreadsFile, &c.PinsFile, &c.MessagesManifest, &c.ThreadsManifest, &c.Checkpoint} {
+		if *path == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, *path); err == nil {
+			*path = rel
+		}
+	}
+}
+
+// ExportOutputPattern normalizes an --out-dir value into a file pattern containing {channel}.
+func ExportOutputPattern(outDir string) string {
+	if strings.Contains(outDir, ChannelPlaceholder) {
+		return outDir
+	}
+	if outDir == "" {
+		outDir = "."
+	}
+	return filepath.Join(outDir, ChannelPlaceholder+".ndjson")
+}
+
+func resumableCheckpoint(path, channelID string) (*Checkpoint, bool) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, false
+	}
+	cp, err := LoadCheckpoint(path)
+	if err != nil || cp.Channel != channelID {
+		return nil, false
+	}
+	return cp, true
+}
+
+func applyChannelInfo(entry *ManifestChannel, info *slackapi.Channel) {
+	if info == nil {
+		return
+	}
+	entry.Name = info.Name
+	entry.IsPrivate = info.IsPrivate
+	entry.IsArchived = info.IsArchived
+	entry.Topic = info.Topic.Value
+	entry.Purpose = info.Purpose.Value
+	entry.Created = int64(info.Created)
+	entry.NumMembers = info.NumMembers
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportFileName returns a filesystem-safe file stem for a channel.
+func exportFileName(entry ManifestChannel) string {
+	name := unsafeFileChars.ReplaceAllString(entry.Name, "_")
+	if strings.Trim(name, "._") == "" {
+		return entry.ID
+	}
+	return name
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create output dir: %w", err)
+		}
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
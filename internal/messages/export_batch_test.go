@@ -0,0 +1,149 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/ratelimit"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+type mockMetadata struct {
+	names map[string]string
+	pins  map[string][]slack.PinnedItem
+}
+
+func (m mockMetadata) GetConversationInfo(_ context.Context, channelID string) (*slackapi.Channel, error) {
+	name, ok := m.names[channelID]
+	if !ok {
+		return nil, errors.New("channel_not_found")
+	}
+	ch := &slackapi.Channel{}
+	ch.ID = channelID
+	ch.Name = name
+	ch.NumMembers = 3
+	return ch, nil
+}
+
+func (m mockMetadata) ListPins(_ context.Context, channel string) (*slack.PinListResult, error) {
+	return &slack.PinListResult{OK: true, Channel: channel, Items: m.pins[channel]}, nil
+}
+
+func TestBatchExporterWritesPerChannelFilesAndManifest(t *testing.T) {
+	dir := t.TempDir()
+	var mu sync.Mutex
+	threadCalls := 0
+	fetcher := mockFetcher{
+		listMessages: func(_ context.Context, params slack.HistoryParams) ([]slackapi.Message, string, bool, error) {
+			root := msg("10")
+			root.ReplyCount = 1
+			root.ThreadTimestamp = "10"
+			if params.Channel == "C2" {
+				return []slackapi.Message{msg("20")}, "", false, nil
+			}
+			return []slackapi.Message{root, msg("9")}, "", false, nil
+		},
+		listThread: func(_ context.Context, params slack.ThreadParams) ([]slackapi.Message, string, bool, error) {
+			mu.Lock()
+			threadCalls++
+			mu.Unlock()
+			reply := msg("11")
+			reply.ThreadTimestamp = params.Thread
+			return []slackapi.Message{msg(params.Thread), reply}, "", false, nil
+		},
+	}
+	meta := mockMetadata{
+		names: map[string]string{"C1": "general", "C2": "ops/alerts"},
+		pins:  map[string][]slack.PinnedItem{"C1": {{Type: "message", Created: 1}}},
+	}
+
+	manifest, err := NewBatchExporter(fetcher, meta).Run(context.Background(), []string{"C1", "C2"}, BatchExportConfig{
+		OutputPattern: dir,
+		Concurrency:   2,
+		Threads:       true,
+		Pins:          true,
+		Export:        ExportConfig{Limiter: ratelimit.New(time.Millisecond)},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !manifest.Complete || len(manifest.Channels) != 2 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	if threadCalls != 1 {
+		t.Errorf("thread fetches = %d, want 1", threadCalls)
+	}
+
+	general := manifest.Channels[0]
+	if general.Name != "general" || general.MessagesFile != "general.ndjson" || general.MessageCount != 2 {
+		t.Errorf("unexpected general entry: %+v", general)
+	}
+	if general.ThreadReplyCount != 1 || general.PinCount != 1 || general.NumMembers != 3 {
+		t.Errorf("unexpected general counts: %+v", general)
+	}
+	if got := manifest.Channels[1].MessagesFile; got != "ops_alerts.ndjson" {
+		t.Errorf("sanitized file name = %q", got)
+	}
+
+	if got := readExportTimestamps(t, filepath.Join(dir, "general.threads.ndjson")); len(got) != 1 || got[0] != "11" {
+		t.Errorf("thread replies = %v, want [11]", got)
+	}
+	for _, name := range []string{"general.ndjson", "general.pins.json", "ops_alerts.ndjson", ManifestFile} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "general.checkpoint.json")); !os.IsNotExist(err) {
+		t.Errorf("checkpoint should be removed after completion")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var onDisk ExportManifest
+	if err := json.Unmarshal(data, &onDisk); err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	if onDisk.Version != ManifestVersion || len(onDisk.Channels) != 2 {
+		t.Errorf("unexpected manifest on disk: %+v", onDisk)
+	}
+}
+
+func TestBatchExporterRecordsChannelErrors(t *testing.T) {
+	dir := t.TempDir()
+	fetcher := mockFetcher{
+		listMessages: func(context.Context, slack.HistoryParams) ([]slackapi.Message, string, bool, error) {
+			return []slackapi.Message{msg("1")}, "", false, nil
+		},
+	}
+	meta := mockMetadata{names: map[string]string{"C1": "general"}}
+
+	manifest, err := NewBatchExporter(fetcher, meta).Run(context.Background(), []string{"C1", "CMISSING"}, BatchExportConfig{
+		OutputPattern: filepath.Join(dir, "{channel}.ndjson"),
+		Export:        ExportConfig{Limiter: ratelimit.New(0)},
+	})
+	if err == nil {
+		t.Fatal("expected error for missing channel")
+	}
+	if manifest.Complete || manifest.Channels[1].Error == "" || !manifest.Channels[0].Complete {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+}
+
+func TestExportOutputPattern(t *testing.T) {
+	if got := ExportOutputPattern("out"); got != filepath.Join("out", "{channel}.ndjson") {
+		t.Errorf("ExportOutputPattern(dir) = %q", got)
+	}
+	if got := ExportOutputPattern("out/{channel}.jsonl"); got != "out/{channel}.jsonl" {
+		t.Errorf("ExportOutputPattern(pattern) = %q", got)
+	}
+}
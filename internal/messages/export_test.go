@@ -0,0 +1,295 @@
+package messages
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/segment"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// pagedFetcher serves fixed pages keyed by cursor and optionally fails on one cursor.
+func pagedFetcher(pages map[string][]slackapi.Message, next map[string]string, failOn string) mockFetcher {
+	return mockFetcher{
+		listMessages: func(ctx context.Context, params slack.HistoryParams) ([]slackapi.Message, string, bool, error) {
+			if params.Cursor == failOn {
+				return nil, "", false, errors.New("network down")
+			}
+			return pages[params.Cursor], next[params.Cursor], next[params.Cursor] != "", nil
+		},
+		listThread: func(ctx context.Context, params slack.ThreadParams) ([]slackapi.Message, string, bool, error) {
+			return nil, "", false, errors.New("unexpected thread call")
+		},
+	}
+}
+
+func msg(ts string) slackapi.Message {
+	return slackapi.Message{Msg: slackapi.Msg{Timestamp: ts, Text: "m" + ts}}
+}
+
+func readExportTimestamps(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer f.Close()
+	var out []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var m slackapi.Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		out = append(out, m.Timestamp)
+	}
+	return out
+}
+
+func TestExportResumesFromCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "general.ndjson")
+	cpPath := DefaultCheckpointPath(outPath)
+
+	pages := map[string][]slackapi.Message{
+		"":   {msg("5"), msg("4")},
+		"c1": {msg("3"), msg("2")},
+		"c2": {msg("1")},
+	}
+	next := map[string]string{"": "c1", "c1": "c2"}
+	cfg := ExportConfig{
+		PageDelay:      time.Millisecond,
+		SaveCheckpoint: func(cp *Checkpoint) error { return SaveCheckpoint(cpPath, cp) },
+	}
+
+	// First run fails on the third page.
+	cp := &Checkpoint{Channel: "C1", Output: outPath}
+	f, err := PrepareOutput(cp, false)
+	if err != nil {
+		t.Fatalf("PrepareOutput() error = %v", err)
+	}
+	err = NewExporter(pagedFetcher(pages, next, "c2")).Export(context.Background(), cp, f, cfg)
+	f.Close()
+	if err == nil {
+		t.Fatal("expected export error")
+	}
+
+	saved, err := LoadCheckpoint(cpPath)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error = %v", err)
+	}
+	if saved.Cursor != "c2" || saved.Exported != 4 || saved.Pages != 2 || saved.LastTS != "2" || saved.Complete {
+		t.Fatalf("unexpected checkpoint: %+v", saved)
+	}
+
+	// Simulate a partial write after the last checkpoint; resume must discard it.
+	appendFile(t, outPath, `{"ts":"garbage"}`+"\n")
+
+	f, err = PrepareOutput(saved, true)
+	if err != nil {
+		t.Fatalf("PrepareOutput(resume) error = %v", err)
+	}
+	err = NewExporter(pagedFetcher(pages, next, "none")).Export(context.Background(), saved, f, cfg)
+	f.Close()
+	if err != nil {
+		t.Fatalf("resumed Export() error = %v", err)
+	}
+	if !saved.Complete || saved.Exported != 5 || saved.Pages != 3 {
+		t.Fatalf("unexpected final checkpoint: %+v", saved)
+	}
+
+	got := readExportTimestamps(t, outPath)
+	want := []string{"5", "4", "3", "2", "1"}
+	if len(got) != len(want) {
+		t.Fatalf("exported %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("exported %v, want %v", got, want)
+		}
+	}
+}
+
+func TestExportSegmentedResumesFromCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "general.ndjson")
+	cpPath := DefaultCheckpointPath(outPath)
+
+	pages := map[string][]slackapi.Message{
+		"":   {msg("5"), msg("4")},
+		"c1": {msg("3"), msg("2")},
+		"c2": {msg("1")},
+	}
+	next := map[string]string{"": "c1", "c1": "c2"}
+	cfg := ExportConfig{
+		PageDelay:      time.Millisecond,
+		SaveCheckpoint: func(cp *Checkpoint) error { return SaveCheckpoint(cpPath, cp) },
+	}
+
+	cp := &Checkpoint{Channel: "C1", Output: outPath}
+	SegmentOutputs(cp, segment.Options{Compress: segment.Gzip, MaxBytes: 100})
+	out, err := PrepareOutput(cp, false)
+	if err != nil {
+		t.Fatalf("PrepareOutput() error = %v", err)
+	}
+	err = NewExporter(pagedFetcher(pages, next, "c2")).Export(context.Background(), cp, out, cfg)
+	out.Close()
+	if err == nil {
+		t.Fatal("expected export error")
+	}
+
+	saved, err := LoadCheckpoint(cpPath)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error = %v", err)
+	}
+	if saved.OutputSegments == nil || saved.OutputSegments.Lines() != 4 {
+		t.Fatalf("checkpoint segments = %+v, want 4 lines", saved.OutputSegments)
+	}
+
+	out, err = PrepareOutput(saved, true)
+	if err != nil {
+		t.Fatalf("PrepareOutput(resume) error = %v", err)
+	}
+	err = NewExporter(pagedFetcher(pages, next, "none")).Export(context.Background(), saved, out, cfg)
+	out.Close()
+	if err != nil {
+		t.Fatalf("resumed Export() error = %v", err)
+	}
+
+	m, err := segment.LoadManifest(segment.ManifestPath(outPath))
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if !m.Complete || m.Lines() != 5 || len(m.Segments) < 2 {
+		t.Fatalf("manifest complete=%v lines=%d segments=%d", m.Complete, m.Lines(), len(m.Segments))
+	}
+	var got []string
+	for _, s := range m.Segments {
+		f, err := os.Open(filepath.Join(dir, s.File))
+		if err != nil {
+			t.Fatalf("open segment: %v", err)
+		}
+		r, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("gzip %s: %v", s.File, err)
+		}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			var m slackapi.Message
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+			}
+			got = append(got, m.Timestamp)
+		}
+		f.Close()
+	}
+	if strings.Join(got, ",") != "5,4,3,2,1" {
+		t.Fatalf("exported %v, want 5,4,3,2,1", got)
+	}
+}
+
+func TestExportCheckpointEvery(t *testing.T) {
+	var saves []Checkpoint
+	pages := map[string][]slackapi.Message{"": {msg("3")}, "c1": {msg("2")}, "c2": {msg("1")}}
+	next := map[string]string{"": "c1", "c1": "c2"}
+	cfg := ExportConfig{
+		PageDelay:       time.Millisecond,
+		CheckpointEvery: 2,
+		SaveCheckpoint: func(cp *Checkpoint) error {
+			saves = append(saves, *cp)
+			return nil
+		},
+	}
+	cp := &Checkpoint{Channel: "C1", Output: "unused"}
+	if err := NewExporter(pagedFetcher(pages, next, "none")).Export(context.Background(), cp, discard{}, cfg); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	// One save after two pages, one on completion.
+	if len(saves) != 2 || saves[0].Pages != 2 || !saves[1].Complete {
+		t.Fatalf("unexpected saves: %+v", saves)
+	}
+}
+
+func TestExportScrubsLines(t *testing.T) {
+	var out bytes.Buffer
+	cfg := ExportConfig{
+		PageDelay: time.Millisecond,
+		Scrub: func(line []byte) ([]byte, error) {
+			return bytes.ReplaceAll(line, []byte("secret"), []byte("[REDACTED]")), nil
+		},
+	}
+	pages := map[string][]slackapi.Message{"": {{Msg: slackapi.Msg{Timestamp: "1", Text: "the secret"}}}}
+	cp := &Checkpoint{Channel: "C1", Output: "unused"}
+	if err := NewExporter(pagedFetcher(pages, nil, "none")).Export(context.Background(), cp, &out, cfg); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !strings.Contains(out.String(), `"text":"the [REDACTED]"`) {
+		t.Fatalf("export not scrubbed: %s", out.String())
+	}
+}
+
+func TestExportCancelRestoresLastCheckpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var last Checkpoint
+	fetcher := mockFetcher{
+		listMessages: func(context.Context, slack.HistoryParams) ([]slackapi.Message, string, bool, error) {
+			cancel()
+			return []slackapi.Message{msg("1")}, "c1", true, nil
+		},
+	}
+	cfg := ExportConfig{
+		PageDelay:       time.Hour,
+		CheckpointEvery: 5,
+		SaveCheckpoint: func(cp *Checkpoint) error {
+			last = *cp
+			return nil
+		},
+	}
+	cp := &Checkpoint{Channel: "C1", Output: "unused"}
+	err := NewExporter(fetcher).Export(ctx, cp, discard{}, cfg)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Export() error = %v, want context.Canceled", err)
+	}
+	if last.Pages != 0 || last.Cursor != "" {
+		t.Fatalf("checkpoint recorded unsaved progress: %+v", last)
+	}
+}
+
+func TestLoadCheckpointErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadCheckpoint(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing checkpoint")
+	}
+	bad := filepath.Join(dir, "bad.json")
+	os.WriteFile(bad, []byte(`{"version":99,"channel":"C1","output":"x"}`), 0o600)
+	if _, err := LoadCheckpoint(bad); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
@@ -0,0 +1,522 @@
+package messages
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/segment"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// Verification statuses of one exported file.
+const (
+	VerifyOK        = "ok"
+	VerifyOpen      = "open"
+	VerifySkipped   = "skipped"
+	VerifyMissing   = "missing"
+	VerifySize      = "size_mismatch"
+	VerifyChecksum  = "checksum_mismatch"
+	VerifyCorrupt   = "corrupt"
+	VerifyLineCount = "line_count_mismatch"
+	VerifyOrder     = "out_of_order"
+)
+
+// VerifyFile is the verification result of one segment or export file.
+type VerifyFile struct {
+	File          string `json:"file"`
+	Channel       string `json:"channel,omitempty"`
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Lines         int64  `json:"lines"`
+	ExpectedLines int64  `json:"expected_lines"`
+	Problem       string `json:"problem,omitempty"`
+	// Repaired is set when the file's range was fetched from Slack again and rewritten.
+	Repaired  bool `json:"repaired,omitempty"`
+	Refetched int  `json:"refetched,omitempty"`
+}
+
+// Failed reports whether the file failed verification and was not repaired.
+func (f VerifyFile) Failed() bool {
+	switch f.Status {
+	case VerifyOK, VerifyOpen, VerifySkipped:
+		return false
+	}
+	return !f.Repaired
+}
+
+// VerifyResult is the output of export verify.
+type VerifyResult struct {
+	OK       bool         `json:"ok"`
+	Manifest string       `json:"manifest"`
+	Checked  int          `json:"checked"`
+	Skipped  int          `json:"skipped,omitempty"`
+	Failed   int          `json:"failed"`
+	Repaired int          `json:"repaired,omitempty"`
+	Files    []VerifyFile `json:"files"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *VerifyResult) Lines() []string {
+	status := "OK"
+	if !r.OK {
+		status = "FAILED"
+	}
+	line := fmt.Sprintf("Verified %s: %s (%d checked", r.Manifest, status, r.Checked)
+	if r.Skipped > 0 {
+		line += fmt.Sprintf(", %d skipped", r.Skipped)
+	}
+	line += fmt.Sprintf(", %d failed", r.Failed)
+	if r.Repaired > 0 {
+		line += fmt.Sprintf(", %d repaired", r.Repaired)
+	}
+	lines := []string{line + ")"}
+	for _, f := range r.Files {
+		if f.Status == VerifyOK || f.Status == VerifySkipped {
+			continue
+		}
+		l := fmt.Sprintf("  %s: %s", f.File, strings.ReplaceAll(f.Status, "_", " "))
+		if f.Problem != "" {
+			l += " (" + f.Problem + ")"
+		}
+		if f.Repaired {
+			l += fmt.Sprintf(", repaired with %d messages", f.Refetched)
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// VerifyConfig controls export verification.
+type VerifyConfig struct {
+	// Fetcher fetches damaged message ranges again; nil only reports them.
+	Fetcher Fetcher
+	// Scrub returns the scrubber for a recorded --scrub mode, so refetched messages are
+	// masked like the rest of the export.
+	Scrub func(mode string) (func([]byte) ([]byte, error), error)
+	// Resume skips segments an interrupted earlier run already verified, as recorded in
+	// the verify state file next to the manifest.
+	Resume bool
+	// Export paces refetch requests.
+	Export ExportConfig
+}
+
+// verifyState records segments verified so far, so an interrupted verification of a
+// large archive can continue.
+type verifyState struct {
+	Version  int                     `json:"version"`
+	Verified map[string]verifiedFile `json:"verified"`
+}
+
+type verifiedFile struct {
+	SHA256     string    `json:"sha256"`
+	Bytes      int64     `json:"bytes"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// VerifyStatePath returns the verify state path for a manifest.
+func VerifyStatePath(manifestPath string) string {
+	return strings.TrimSuffix(manifestPath, filepath.Ext(manifestPath)) + ".verify.json"
+}
+
+// VerifyExport checks an export against its manifest: a segment manifest written by
+// --compress or --rotate-*, or the manifest.json of an --out-dir export. Segments are
+// checked against their recorded size and SHA-256 checksum; every file must decompress,
+// hold one JSON document per line, and match its recorded line count, and messages
+// must be in the newest-first order exports write. With cfg.Fetcher set, damaged
+// message segments are fetched again and rewritten.
+func VerifyExport(ctx context.Context, manifestPath string, cfg VerifyConfig) (*VerifyResult, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var probe struct {
+		Segments json.RawMessage `json:"segments"`
+		Channels json.RawMessage `json:"channels"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", manifestPath, err)
+	}
+
+	v := &verifier{cfg: cfg, statePath: VerifyStatePath(manifestPath), state: &verifyState{Version: 1, Verified: map[string]verifiedFile{}}}
+	if cfg.Resume {
+		if err := v.loadState(); err != nil {
+			return nil, err
+		}
+	}
+	result := &VerifyResult{Manifest: manifestPath}
+	switch {
+	case probe.Segments != nil:
+		m, err := segment.LoadManifest(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		m.Output = filepath.Join(filepath.Dir(manifestPath), filepath.Base(m.Output))
+		files, err := v.segments(ctx, m, -1)
+		result.Files = files
+		if err != nil {
+			return result, err
+		}
+	case probe.Channels != nil:
+		var manifest ExportManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("parse manifest %s: %w", manifestPath, err)
+		}
+		dir := filepath.Dir(manifestPath)
+		for _, ch := range manifest.Channels {
+			if ch.Error != "" && ch.MessageCount == 0 {
+				continue
+			}
+			files, err := v.channel(ctx, dir, ch)
+			result.Files = append(result.Files, files...)
+			if err != nil {
+				return result, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s is not an export manifest", manifestPath)
+	}
+
+	for _, f := range result.Files {
+		switch {
+		case f.Status == VerifySkipped:
+			result.Skipped++
+		case f.Failed():
+			result.Failed++
+			result.Checked++
+		default:
+			result.Checked++
+		}
+		if f.Repaired {
+			result.Repaired++
+		}
+	}
+	result.OK = result.Failed == 0
+	if err := os.Remove(v.statePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return result, fmt.Errorf("remove verify state: %w", err)
+	}
+	return result, nil
+}
+
+type verifier struct {
+	cfg       VerifyConfig
+	statePath string
+	state     *verifyState
+}
+
+// channel verifies the messages and thread replies of one channel of an --out-dir
+// export. Counts are only compared for channels that finished exporting.
+func (v *verifier) channel(ctx context.Context, dir string, ch ManifestChannel) ([]VerifyFile, error) {
+	var files []VerifyFile
+	for _, part := range []struct {
+		kind, file, manifest string
+		count                int
+	}{
+		{SegmentMessages, ch.MessagesFile, ch.MessagesManifest, ch.MessageCount},
+		{SegmentThreadReplies, ch.ThreadsFile, ch.ThreadsManifest, ch.ThreadReplyCount},
+	} {
+		expected := int64(-1)
+		if ch.Complete {
+			expected = int64(part.count)
+		}
+		switch {
+		case part.manifest != "":
+			m, err := segment.LoadManifest(filepath.Join(dir, part.manifest))
+			if err != nil {
+				files = append(files, VerifyFile{File: part.manifest, Channel: ch.ID, Kind: part.kind, Status: VerifyMissing, Problem: err.Error()})
+				continue
+			}
+			m.Output = filepath.Join(dir, filepath.Dir(part.manifest), filepath.Base(m.Output))
+			got, err := v.segments(ctx, m, expected)
+			files = append(files, got...)
+			if err != nil {
+				return files, err
+			}
+		case part.file != "":
+			files = append(files, v.plainFile(filepath.Join(dir, part.file), ch.ID, part.kind, expected))
+		}
+	}
+	return files, nil
+}
+
+// segments verifies and, when configured, repairs the segments of m. A non-negative
+// expected is the total line count the export recorded elsewhere.
+func (v *verifier) segments(ctx context.Context, m *segment.Manifest, expected int64) ([]VerifyFile, error) {
+	dir := filepath.Dir(m.Output)
+	kind := m.Kind
+	if kind == "" {
+		kind = SegmentMessages
+	}
+	var files []VerifyFile
+	prevTS := ""
+	for i, s := range m.Segments {
+		if err := ctx.Err(); err != nil {
+			return files, err
+		}
+		path := filepath.Join(dir, s.File)
+		f := VerifyFile{File: path, Channel: m.Channel, Kind: kind, ExpectedLines: s.Lines}
+		if done, ok := v.state.Verified[path]; ok && s.SHA256 != "" && done.SHA256 == s.SHA256 && done.Bytes == s.Bytes && sizeIs(path, s.Bytes) {
+			f.Status, f.Lines = VerifySkipped, s.Lines
+			files = append(files, f)
+			prevTS = s.LastTS
+			continue
+		}
+		last := v.checkSegment(m, s, path, &f, prevTS)
+		if f.Failed() && kind == SegmentMessages && v.cfg.Fetcher != nil {
+			if err := v.repair(ctx, m, i, &f); err != nil {
+				if ctx.Err() != nil {
+					return files, ctx.Err()
+				}
+				f.Problem += "; repair failed: " + err.Error()
+			}
+			last = m.Segments[i].LastTS
+		}
+		if last != "" {
+			prevTS = last
+		}
+		if f.Status == VerifyOK && s.SHA256 != "" {
+			if err := v.markVerified(path, s); err != nil {
+				return files, err
+			}
+		}
+		files = append(files, f)
+	}
+	if expected >= 0 && m.Lines() != expected {
+		files = append(files, VerifyFile{
+			File: segment.ManifestPath(m.Output), Channel: m.Channel, Kind: kind, Status: VerifyLineCount,
+			Lines: m.Lines(), ExpectedLines: expected, Problem: fmt.Sprintf("segments hold %d lines, the export manifest says %d", m.Lines(), expected),
+		})
+	}
+	return files, nil
+}
+
+// checkSegment verifies one segment into f and returns the ts of its last message.
+func (v *verifier) checkSegment(m *segment.Manifest, s segment.Segment, path string, f *VerifyFile, prevTS string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		f.Status, f.Problem = VerifyMissing, err.Error()
+		return ""
+	}
+	if s.SHA256 == "" {
+		// Still being written: only the synced part is known.
+		if int64(len(data)) < s.Bytes {
+			f.Status, f.Problem = VerifySize, fmt.Sprintf("%d bytes on disk, manifest says at least %d", len(data), s.Bytes)
+			return ""
+		}
+		data = data[:s.Bytes]
+	} else {
+		if int64(len(data)) != s.Bytes {
+			f.Status, f.Problem = VerifySize, fmt.Sprintf("%d bytes on disk, manifest says %d", len(data), s.Bytes)
+			return ""
+		}
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != s.SHA256 {
+			f.Status, f.Problem = VerifyChecksum, "SHA-256 differs from the manifest"
+			return ""
+		}
+	}
+	r, err := segment.NewReader(bytes.NewReader(data), m.Compression)
+	if err != nil {
+		f.Status, f.Problem = VerifyCorrupt, err.Error()
+		return ""
+	}
+	check := checkLines(r, f.Kind == SegmentMessages, prevTS)
+	if err := r.Close(); err != nil && check.err == nil {
+		check.err = err
+	}
+	f.Lines = check.lines
+	switch {
+	case check.err != nil:
+		f.Status, f.Problem = VerifyCorrupt, check.err.Error()
+	case check.lines != s.Lines:
+		f.Status, f.Problem = VerifyLineCount, fmt.Sprintf("%d lines, manifest says %d", check.lines, s.Lines)
+	case check.order != "":
+		f.Status, f.Problem = VerifyOrder, check.order
+	case s.SHA256 == "":
+		f.Status = VerifyOpen
+	default:
+		f.Status = VerifyOK
+	}
+	return check.last
+}
+
+// plainFile verifies an uncompressed export file, which has no checksum to compare.
+func (v *verifier) plainFile(path, channel, kind string, expected int64) VerifyFile {
+	f := VerifyFile{File: path, Channel: channel, Kind: kind, ExpectedLines: expected}
+	file, err := os.Open(path)
+	if err != nil {
+		f.Status, f.Problem = VerifyMissing, err.Error()
+		return f
+	}
+	defer file.Close()
+	check := checkLines(file, kind == SegmentMessages, "")
+	f.Lines = check.lines
+	if expected < 0 {
+		f.ExpectedLines = check.lines
+	}
+	switch {
+	case check.err != nil:
+		f.Status, f.Problem = VerifyCorrupt, check.err.Error()
+	case expected >= 0 && check.lines != expected:
+		f.Status, f.Problem = VerifyLineCount, fmt.Sprintf("%d lines, manifest says %d", check.lines, expected)
+	case check.order != "":
+		f.Status, f.Problem = VerifyOrder, check.order
+	default:
+		f.Status = VerifyOK
+	}
+	return f
+}
+
+type lineCheck struct {
+	lines int64
+	last  string
+	order string
+	err   error
+}
+
+// checkLines counts the JSON lines in r. With ordered, each message's ts must be older
+// than the one before it, starting from prevTS.
+func checkLines(r io.Reader, ordered bool, prevTS string) lineCheck {
+	var c lineCheck
+	c.last = prevTS
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		c.lines++
+		var doc struct {
+			TS string `json:"ts"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			c.err = fmt.Errorf("line %d: %w", c.lines, err)
+			return c
+		}
+		if ordered && doc.TS != "" {
+			if c.last != "" && slack.CompareTS(doc.TS, c.last) >= 0 && c.order == "" {
+				c.order = fmt.Sprintf("line %d: ts %s is not older than %s", c.lines, doc.TS, c.last)
+			}
+			c.last = doc.TS
+		}
+	}
+	c.err = scanner.Err()
+	return c
+}
+
+// repair fetches the messages between the segment's first and last ts again and
+// rewrites the segment with them.
+func (v *verifier) repair(ctx context.Context, m *segment.Manifest, index int, f *VerifyFile) error {
+	s := m.Segments[index]
+	if m.Channel == "" || s.FirstTS == "" || s.LastTS == "" {
+		return errors.New("the manifest does not record the segment's channel and ts range")
+	}
+	if s.SHA256 == "" {
+		return errors.New("the segment is still open; resume the export instead")
+	}
+	var scrub func([]byte) ([]byte, error)
+	if m.Scrub != "" {
+		if v.cfg.Scrub == nil {
+			return fmt.Errorf("cannot apply --scrub %s", m.Scrub)
+		}
+		var err error
+		if scrub, err = v.cfg.Scrub(m.Scrub); err != nil {
+			return err
+		}
+	}
+	cfg := v.cfg.Export
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = 200
+	}
+	exporter := NewExporter(v.cfg.Fetcher)
+	var buf bytes.Buffer
+	cursor := ""
+	count := 0
+	for {
+		var (
+			msgs []slackapi.Message
+			next string
+		)
+		err := exporter.call(ctx, cfg, func() error {
+			var err error
+			msgs, next, _, err = v.cfg.Fetcher.ListMessages(ctx, slack.HistoryParams{
+				Channel:   m.Channel,
+				Cursor:    cursor,
+				Limit:     cfg.PageSize,
+				Oldest:    s.LastTS,
+				Latest:    s.FirstTS,
+				Inclusive: true,
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := writeMessages(&buf, msgs, scrub); err != nil {
+			return err
+		}
+		count += len(msgs)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if err := segment.Replace(m, index, buf.Bytes()); err != nil {
+		return err
+	}
+	f.Repaired, f.Refetched = true, count
+	if count != int(s.Lines) {
+		f.Problem = strings.TrimPrefix(f.Problem+fmt.Sprintf("; Slack now has %d messages in the range, the export had %d", count, s.Lines), "; ")
+	}
+	return v.markVerified(filepath.Join(filepath.Dir(m.Output), s.File), m.Segments[index])
+}
+
+func (v *verifier) loadState() error {
+	data, err := os.ReadFile(v.statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read verify state: %w", err)
+	}
+	if err := json.Unmarshal(data, v.state); err != nil {
+		return fmt.Errorf("parse verify state %s: %w", v.statePath, err)
+	}
+	if v.state.Verified == nil {
+		v.state.Verified = map[string]verifiedFile{}
+	}
+	return nil
+}
+
+// markVerified records a verified segment and saves the state, so an interrupted run
+// can resume after it.
+func (v *verifier) markVerified(path string, s segment.Segment) error {
+	v.state.Verified[path] = verifiedFile{SHA256: s.SHA256, Bytes: s.Bytes, VerifiedAt: time.Now().UTC()}
+	data, err := json.Marshal(v.state)
+	if err != nil {
+		return fmt.Errorf("marshal verify state: %w", err)
+	}
+	tmp := v.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write verify state: %w", err)
+	}
+	if err := os.Rename(tmp, v.statePath); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename verify state: %w", err)
+	}
+	return nil
+}
+
+func sizeIs(path string, size int64) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Size() == size
+}
@@ -0,0 +1,155 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/segment"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// historyFetcher serves msgs (newest first) filtered by the requested ts range, one page
+// per call.
+func historyFetcher(msgs []slackapi.Message) mockFetcher {
+	return mockFetcher{
+		listMessages: func(ctx context.Context, params slack.HistoryParams) ([]slackapi.Message, string, bool, error) {
+			var page []slackapi.Message
+			for _, m := range msgs {
+				if params.Oldest != "" && slack.CompareTS(m.Timestamp, params.Oldest) < 0 {
+					continue
+				}
+				if params.Latest != "" && slack.CompareTS(m.Timestamp, params.Latest) > 0 {
+					continue
+				}
+				page = append(page, m)
+			}
+			return page, "", false, nil
+		},
+	}
+}
+
+// segmentedExport exports msgs as gzip segments of at most 150 bytes and returns the
+// manifest path.
+func segmentedExport(t *testing.T, msgs []slackapi.Message) string {
+	t.Helper()
+	outPath := filepath.Join(t.TempDir(), "general.ndjson")
+	cp := &Checkpoint{Channel: "C1", Output: outPath}
+	SegmentOutputs(cp, segment.Options{Compress: segment.Gzip, MaxBytes: 150})
+	out, err := PrepareOutput(cp, false)
+	if err != nil {
+		t.Fatalf("PrepareOutput() error = %v", err)
+	}
+	err = NewExporter(historyFetcher(msgs)).Export(context.Background(), cp, out, ExportConfig{PageDelay: time.Millisecond})
+	out.Close()
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	return segment.ManifestPath(outPath)
+}
+
+func TestVerifyExportRepairsDamagedSegment(t *testing.T) {
+	msgs := []slackapi.Message{msg("6"), msg("5"), msg("4"), msg("3"), msg("2"), msg("1")}
+	manifestPath := segmentedExport(t, msgs)
+
+	result, err := VerifyExport(context.Background(), manifestPath, VerifyConfig{})
+	if err != nil {
+		t.Fatalf("VerifyExport() error = %v", err)
+	}
+	if !result.OK || result.Checked < 2 || result.Failed != 0 {
+		t.Fatalf("fresh export: %+v", result)
+	}
+
+	m, _ := segment.LoadManifest(manifestPath)
+	damaged := filepath.Join(filepath.Dir(manifestPath), m.Segments[1].File)
+	data, _ := os.ReadFile(damaged)
+	data[len(data)/2] ^= 0xff
+	if err := os.WriteFile(damaged, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = VerifyExport(context.Background(), manifestPath, VerifyConfig{})
+	if err != nil {
+		t.Fatalf("VerifyExport() error = %v", err)
+	}
+	if result.OK || result.Failed != 1 || result.Files[1].Status != VerifyChecksum {
+		t.Fatalf("damaged export: %+v", result)
+	}
+
+	result, err = VerifyExport(context.Background(), manifestPath, VerifyConfig{Fetcher: historyFetcher(msgs), Export: ExportConfig{PageDelay: time.Millisecond}})
+	if err != nil {
+		t.Fatalf("VerifyExport(repair) error = %v", err)
+	}
+	if !result.OK || result.Repaired != 1 || !result.Files[1].Repaired || result.Files[1].Refetched != int(m.Segments[1].Lines) {
+		t.Fatalf("repair: %+v", result)
+	}
+	result, err = VerifyExport(context.Background(), manifestPath, VerifyConfig{})
+	if err != nil || !result.OK || result.Repaired != 0 {
+		t.Fatalf("after repair: %+v, %v", result, err)
+	}
+}
+
+func TestVerifyExportResumesAfterVerifiedSegments(t *testing.T) {
+	manifestPath := segmentedExport(t, []slackapi.Message{msg("4"), msg("3"), msg("2"), msg("1")})
+	m, _ := segment.LoadManifest(manifestPath)
+	first := filepath.Join(filepath.Dir(manifestPath), m.Segments[0].File)
+	state, _ := json.Marshal(verifyState{Version: 1, Verified: map[string]verifiedFile{
+		first: {SHA256: m.Segments[0].SHA256, Bytes: m.Segments[0].Bytes},
+	}})
+	if err := os.WriteFile(VerifyStatePath(manifestPath), state, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := VerifyExport(context.Background(), manifestPath, VerifyConfig{Resume: true})
+	if err != nil {
+		t.Fatalf("VerifyExport() error = %v", err)
+	}
+	if !result.OK || result.Skipped != 1 || result.Files[0].Status != VerifySkipped {
+		t.Fatalf("resume: %+v", result)
+	}
+	if _, err := os.Stat(VerifyStatePath(manifestPath)); !os.IsNotExist(err) {
+		t.Errorf("verify state left behind after a finished run: %v", err)
+	}
+}
+
+func TestVerifyExportChecksPlainFilesAgainstManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, lines ...string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("general.ndjson", `{"ts":"3.0"}`, `{"ts":"2.0"}`)
+	write("ops.ndjson", `{"ts":"1.0"}`, `{"ts":"2.0"}`)
+	write("random.ndjson", `{"ts":"1.0"}`, `{"ts"`)
+	manifest := ExportManifest{Version: 1, Complete: true, Channels: []ManifestChannel{
+		{ID: "C1", MessagesFile: "general.ndjson", MessageCount: 3, Complete: true},
+		{ID: "C2", MessagesFile: "ops.ndjson", MessageCount: 2, Complete: true},
+		{ID: "C3", MessagesFile: "random.ndjson", MessageCount: 2, Complete: true},
+	}}
+	data, _ := json.Marshal(manifest)
+	manifestPath := filepath.Join(dir, ManifestFile)
+	if err := os.WriteFile(manifestPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := VerifyExport(context.Background(), manifestPath, VerifyConfig{})
+	if err != nil {
+		t.Fatalf("VerifyExport() error = %v", err)
+	}
+	want := []string{VerifyLineCount, VerifyOrder, VerifyCorrupt}
+	if result.OK || result.Failed != 3 || len(result.Files) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+	for i, status := range want {
+		if result.Files[i].Status != status {
+			t.Errorf("%s status = %s, want %s", result.Files[i].File, result.Files[i].Status, status)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// DefaultMaxFileBytes is the default size limit for inlined file attachments.
+const DefaultMaxFileBytes = 64 * 1024
+
+// FileDownloader fetches private Slack file URLs with the caller's credentials.
+type FileDownloader interface {
+	DownloadFile(ctx context.Context, url string, maxBytes int64) ([]byte, error)
+}
+
+// FetchFilesOptions controls which attachments FetchFiles downloads and where content goes.
+type FetchFilesOptions struct {
+	// MaxBytes skips files larger than this many bytes (default DefaultMaxFileBytes).
+	MaxBytes int64
+	// Dir saves content under this directory and reports the path instead of inlining it.
+	Dir string
+}
+
+// FileContent is the fetched content of one attachment, keyed by file ID.
+type FileContent struct {
+	Content string
+	Path    string
+	Skipped string
+}
+
+// textFileTypes are Slack filetype values treated as text regardless of mimetype.
+var textFileTypes = map[string]bool{
+	"text": true, "csv": true, "tsv": true, "log": true, "json": true, "yaml": true, "xml": true,
+	"markdown": true, "post": true, "diff": true, "patch": true, "sql": true, "shell": true,
+	"python": true, "go": true, "javascript": true, "typescript": true, "ruby": true, "java": true,
+	"c": true, "cpp": true, "rust": true, "toml": true, "ini": true, "html": true, "css": true,
+}
+
+// IsTextFile reports whether a Slack file looks like a text document worth inlining.
+func IsTextFile(file slackapi.File) bool {
+	if textFileTypes[strings.ToLower(file.Filetype)] {
+		return true
+	}
+	mime := strings.ToLower(file.Mimetype)
+	switch {
+	case strings.HasPrefix(mime, "text/"):
+		return true
+	case mime == "application/json", mime == "application/xml", mime == "application/x-yaml",
+		mime == "application/yaml", mime == "application/csv", mime == "application/x-ndjson":
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(file.Name)) {
+	case ".txt", ".log", ".csv", ".tsv", ".json", ".ndjson", ".yaml", ".yml", ".md", ".xml":
+		return true
+	}
+	return false
+}
+
+// FetchFiles downloads small text attachments on every message so JSON output includes
+// their content (or a saved path). Files that are external, not hosted by Slack, not text,
+// exceed the size limit, or fail to download are recorded with a content_skipped reason
+// instead of failing the listing.
+func (r *Result) FetchFiles(ctx context.Context, downloader FileDownloader, opts FetchFilesOptions) error {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxFileBytes
+	}
+	if opts.Dir != "" {
+		if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+			return fmt.Errorf("create files dir: %w", err)
+		}
+	}
+	if r.fileContents == nil {
+		r.fileContents = map[string]FileContent{}
+	}
+	for _, msg := range r.Messages {
+		for _, file := range msg.Files {
+			if file.ID == "" {
+				continue
+			}
+			if _, done := r.fileContents[file.ID]; done {
+				continue
+			}
+			r.fileContents[file.ID] = fetchFile(ctx, downloader, file, opts)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fetchFile(ctx context.Context, downloader FileDownloader, file slackapi.File, opts FetchFilesOptions) FileContent {
+	if file.IsExternal {
+		return FileContent{Skipped: "external: hosted outside Slack"}
+	}
+	if !IsTextFile(file) {
+		return FileContent{Skipped: "not_text"}
+	}
+	if int64(file.Size) > opts.MaxBytes {
+		return FileContent{Skipped: fmt.Sprintf("too_large: %d bytes exceeds limit of %d", file.Size, opts.MaxBytes)}
+	}
+	url := file.URLPrivateDownload
+	if url == "" {
+		url = file.URLPrivate
+	}
+	// The download carries the user's token, so only Slack's own file hosts get it.
+	if err := slack.CheckFileURL(url); err != nil {
+		return FileContent{Skipped: "not_slack_hosted: " + err.Error()}
+	}
+	data, err := downloader.DownloadFile(ctx, url, opts.MaxBytes)
+	if err != nil {
+		if errors.Is(err, slack.ErrFileTooLarge) {
+			return FileContent{Skipped: fmt.Sprintf("too_large: exceeds limit of %d bytes", opts.MaxBytes)}
+		}
+		return FileContent{Skipped: "error: " + err.Error()}
+	}
+	if !utf8.Valid(data) {
+		return FileContent{Skipped: "not_text"}
+	}
+	if opts.Dir == "" {
+		return FileContent{Content: string(data)}
+	}
+	path := filepath.Join(opts.Dir, file.ID+"-"+unsafeFileChars.ReplaceAllString(file.Name, "_"))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return FileContent{Skipped: "error: " + err.Error()}
+	}
+	return FileContent{Path: path}
+}
+
+// applyFileContents merges fetched content into a message's encoded "files" entries.
+func (r Result) applyFileContents(enriched map[string]interface{}) {
+	files, ok := enriched["files"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, item := range files {
+		file, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := file["id"].(string)
+		content, ok := r.fileContents[id]
+		if !ok {
+			continue
+		}
+		switch {
+		case content.Path != "":
+			file["content_path"] = content.Path
+		case content.Skipped != "":
+			file["content_skipped"] = content.Skipped
+		default:
+			file["content"] = content.Content
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+type fakeDownloader struct {
+	files map[string]string
+	calls []string
+}
+
+func (f *fakeDownloader) DownloadFile(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	f.calls = append(f.calls, url)
+	content, ok := f.files[url]
+	if !ok {
+		return nil, errors.New("404")
+	}
+	return []byte(content), nil
+}
+
+func fileMessages() []slackapi.Message {
+	return []slackapi.Message{{Msg: slackapi.Msg{Timestamp: "1", Files: []slackapi.File{
+		{ID: "F1", Name: "app.log", Filetype: "text", Size: 12, URLPrivate: "https://files.slack.com/files-pri/T1-app.log"},
+		{ID: "F2", Name: "shot.png", Filetype: "png", Mimetype: "image/png", Size: 10, URLPrivate: "https://files.slack.com/files-pri/T1-shot.png"},
+		{ID: "F3", Name: "big.csv", Filetype: "csv", Size: 1 << 20, URLPrivate: "https://files.slack.com/files-pri/T1-big.csv"},
+		{ID: "F4", Name: "gone.txt", Mimetype: "text/plain", Size: 5, URLPrivate: "https://files.slack.com/files-pri/T1-gone.txt"},
+	}}}}
+}
+
+func TestFetchFilesInlinesSmallTextFiles(t *testing.T) {
+	downloader := &fakeDownloader{files: map[string]string{"https://files.slack.com/files-pri/T1-app.log": "ERROR boom\n"}}
+	result := Result{Channel: "C1", Messages: fileMessages()}
+	if err := result.FetchFiles(context.Background(), downloader, FetchFilesOptions{MaxBytes: 64 * 1024}); err != nil {
+		t.Fatalf("FetchFiles: %v", err)
+	}
+	if len(downloader.calls) != 2 {
+		t.Fatalf("downloaded %v, want only app.log and gone.txt", downloader.calls)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var out struct {
+		Messages []struct {
+			Files []map[string]interface{} `json:"files"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	files := out.Messages[0].Files
+	if files[0]["content"] != "ERROR boom\n" {
+		t.Errorf("app.log content = %v", files[0]["content"])
+	}
+	if files[1]["content_skipped"] != "not_text" {
+		t.Errorf("png content_skipped = %v", files[1]["content_skipped"])
+	}
+	if skipped, _ := files[2]["content_skipped"].(string); !strings.HasPrefix(skipped, "too_large") {
+		t.Errorf("big.csv content_skipped = %v", files[2]["content_skipped"])
+	}
+	if skipped, _ := files[3]["content_skipped"].(string); !strings.HasPrefix(skipped, "error:") {
+		t.Errorf("gone.txt content_skipped = %v", files[3]["content_skipped"])
+	}
+}
+
+func TestFetchFilesSkipsFilesOutsideSlack(t *testing.T) {
+	downloader := &fakeDownloader{files: map[string]string{
+		"https://attacker.example.com/notes.txt": "token please",
+		"https://docs.example.com/plan.txt":      "external",
+	}}
+	result := Result{Channel: "C1", Messages: []slackapi.Message{{Msg: slackapi.Msg{Timestamp: "1", Files: []slackapi.File{
+		{ID: "F1", Name: "notes.txt", Filetype: "text", Size: 12, URLPrivate: "https://attacker.example.com/notes.txt"},
+		{ID: "F2", Name: "plan.txt", Filetype: "text", Size: 8, IsExternal: true, URLPrivate: "https://docs.example.com/plan.txt"},
+	}}}}}
+	if err := result.FetchFiles(context.Background(), downloader, FetchFilesOptions{}); err != nil {
+		t.Fatalf("FetchFiles: %v", err)
+	}
+	if len(downloader.calls) != 0 {
+		t.Fatalf("downloaded %v, want no requests outside Slack", downloader.calls)
+	}
+	if skipped := result.fileContents["F1"].Skipped; !strings.HasPrefix(skipped, "not_slack_hosted") {
+		t.Errorf("off-Slack url_private content_skipped = %q", skipped)
+	}
+	if skipped := result.fileContents["F2"].Skipped; !strings.HasPrefix(skipped, "external") {
+		t.Errorf("external file content_skipped = %q", skipped)
+	}
+}
+
+func TestFetchFilesToDir(t *testing.T) {
+	dir := t.TempDir()
+	downloader := &fakeDownloader{files: map[string]string{"https://files.slack.com/files-pri/T1-app.log": "line\n"}}
+	result := Result{Channel: "C1", Messages: fileMessages()[:1]}
+	result.Messages[0].Files = result.Messages[0].Files[:1]
+	if err := result.FetchFiles(context.Background(), downloader, FetchFilesOptions{Dir: dir}); err != nil {
+		t.Fatalf("FetchFiles: %v", err)
+	}
+	want := filepath.Join(dir, "F1-app.log")
+	if got := result.fileContents["F1"].Path; got != want {
+		t.Fatalf("content path = %q, want %q", got, want)
+	}
+	if data, err := os.ReadFile(want); err != nil || string(data) != "line\n" {
+		t.Fatalf("saved file = %q, %v", data, err)
+	}
+}
+
+func TestIsTextFile(t *testing.T) {
+	tests := []struct {
+		file slackapi.File
+		want bool
+	}{
+		{slackapi.File{Filetype: "csv"}, true},
+		{slackapi.File{Mimetype: "application/json"}, true},
+		{slackapi.File{Name: "trace.LOG", Filetype: "binary"}, true},
+		{slackapi.File{Filetype: "pdf", Mimetype: "application/pdf"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsTextFile(tt.file); got != tt.want {
+			t.Errorf("IsTextFile(%+v) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// How Get found a message.
+const (
+	MatchTS          = "ts"
+	MatchInclusive   = "inclusive"
+	MatchClientMsgID = "client_msg_id"
+)
+
+// DefaultScanLimit is how many messages a client_msg_id lookup reads before giving up.
+const DefaultScanLimit = 1000
+
+const scanPageSize = 200
+
+// GetClient defines the Slack operations needed to fetch a single message.
+type GetClient interface {
+	GetMessage(ctx context.Context, channel, timestamp string) (*slackapi.Message, error)
+	ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error)
+	GetPermalink(ctx context.Context, channel, timestamp string) (string, error)
+}
+
+// GetParams describes input for Get. Exactly one of TS and ClientMsgID is set.
+type GetParams struct {
+	Channel string
+	TS      string
+	// Inclusive returns the newest message at or before TS when no message has
+	// exactly that timestamp.
+	Inclusive   bool
+	ClientMsgID string
+	// Since and ScanLimit bound a ClientMsgID lookup, which has to read history
+	// newest first because Slack cannot search by client_msg_id.
+	Since     string
+	ScanLimit int
+}
+
+// GetResult is a single message with its permalink.
+type GetResult struct {
+	Result
+	Permalink string
+	MatchedBy string
+	Scanned   int
+}
+
+// Message returns the fetched message.
+func (r GetResult) Message() slackapi.Message {
+	return r.Messages[0]
+}
+
+// Get fetches one message by timestamp or client_msg_id. It returns an error
+// wrapping slack.ErrNotFound when no message matches.
+func Get(ctx context.Context, client GetClient, params GetParams) (GetResult, error) {
+	if params.Channel == "" {
+		return GetResult{}, slack.ErrChannelRequired
+	}
+	var (
+		msg       *slackapi.Message
+		matchedBy string
+		scanned   int
+		err       error
+	)
+	if params.ClientMsgID != "" {
+		matchedBy = MatchClientMsgID
+		msg, scanned, err = findByClientMsgID(ctx, client, params)
+	} else {
+		matchedBy = MatchTS
+		msg, err = client.GetMessage(ctx, params.Channel, params.TS)
+		if errors.Is(err, slack.ErrNotFound) && params.Inclusive {
+			matchedBy = MatchInclusive
+			msg, err = latestAtOrBefore(ctx, client, params.Channel, params.TS)
+		}
+	}
+	if err != nil {
+		return GetResult{}, err
+	}
+
+	permalink, err := client.GetPermalink(ctx, params.Channel, msg.Timestamp)
+	if err != nil {
+		return GetResult{}, err
+	}
+	return GetResult{
+		Result:    Result{Channel: params.Channel, Messages: []slackapi.Message{*msg}},
+		Permalink: permalink,
+		MatchedBy: matchedBy,
+		Scanned:   scanned,
+	}, nil
+}
+
+func latestAtOrBefore(ctx context.Context, client GetClient, channel, ts string) (*slackapi.Message, error) {
+	history, err := client.ListConversationsHistory(ctx, slack.HistoryParams{Channel: channel, Latest: ts, Inclusive: true, Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("get message: %w", err)
+	}
+	if len(history.Messages) == 0 {
+		return nil, fmt.Errorf("no message at or before %s: %w", ts, slack.ErrNotFound)
+	}
+	return &history.Messages[0], nil
+}
+
+func findByClientMsgID(ctx context.Context, client GetClient, params GetParams) (*slackapi.Message, int, error) {
+	oldest, _, err := slack.ParseTimeRange(params.Since, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	limit := params.ScanLimit
+	if limit <= 0 {
+		limit = DefaultScanLimit
+	}
+	scanned := 0
+	cursor := ""
+	for scanned < limit {
+		history, err := client.ListConversationsHistory(ctx, slack.HistoryParams{
+			Channel: params.Channel,
+			Cursor:  cursor,
+			Oldest:  oldest,
+			Limit:   min(scanPageSize, limit-scanned),
+		})
+		if err != nil {
+			return nil, scanned, fmt.Errorf("scan history: %w", err)
+		}
+		for i := range history.Messages {
+			scanned++
+			if history.Messages[i].ClientMsgID == params.ClientMsgID {
+				return &history.Messages[i], scanned, nil
+			}
+		}
+		cursor = history.ResponseMetaData.NextCursor
+		if !history.HasMore || cursor == "" || len(history.Messages) == 0 {
+			break
+		}
+	}
+	return nil, scanned, fmt.Errorf("client_msg_id %s in the last %d messages: %w", params.ClientMsgID, scanned, slack.ErrNotFound)
+}
+
+// MarshalJSON returns the message the way messages list renders it, with the
+// permalink and lookup details alongside.
+func (r GetResult) MarshalJSON() ([]byte, error) {
+	encoded, err := json.Marshal(r.Result)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Channel     string            `json:"channel"`
+		ChannelID   string            `json:"channel_id"`
+		ChannelName string            `json:"channel_name"`
+		Messages    []json.RawMessage `json:"messages"`
+	}
+	if err := json.Unmarshal(encoded, &list); err != nil {
+		return nil, err
+	}
+	if len(list.Messages) != 1 {
+		return nil, fmt.Errorf("expected one message, got %d", len(list.Messages))
+	}
+	return json.Marshal(struct {
+		OK          bool            `json:"ok"`
+		Channel     string          `json:"channel"`
+		ChannelID   string          `json:"channel_id,omitempty"`
+		ChannelName string          `json:"channel_name,omitempty"`
+		TS          string          `json:"ts"`
+		Permalink   string          `json:"permalink"`
+		MatchedBy   string          `json:"matched_by"`
+		Scanned     int             `json:"scanned,omitempty"`
+		Message     json.RawMessage `json:"message"`
+	}{
+		OK:          true,
+		Channel:     list.Channel,
+		ChannelID:   list.ChannelID,
+		ChannelName: list.ChannelName,
+		TS:          r.Message().Timestamp,
+		Permalink:   r.Permalink,
+		MatchedBy:   r.MatchedBy,
+		Scanned:     r.Scanned,
+		Message:     list.Messages[0],
+	})
+}
+
+// Lines returns the message as messages list shows it, followed by its permalink.
+func (r GetResult) Lines() []string {
+	lines := r.Result.Lines()
+	// Drop the "#channel - 1 messages" title and its underline.
+	if len(lines) > 2 {
+		lines = lines[2:]
+	}
+	if r.MatchedBy == MatchInclusive {
+		lines = append(lines, "(closest message at or before the requested ts)")
+	}
+	return append(lines, r.Permalink)
+}
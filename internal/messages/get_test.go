@@ -0,0 +1,117 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// fakeGetClient serves a channel history of messages, newest first.
+type fakeGetClient struct {
+	history []slackapi.Message
+	pages   int
+}
+
+func (f *fakeGetClient) GetMessage(ctx context.Context, channel, ts string) (*slackapi.Message, error) {
+	for i := range f.history {
+		if f.history[i].Timestamp == ts {
+			return &f.history[i], nil
+		}
+	}
+	return nil, fmt.Errorf("get message %s: %w", ts, slack.ErrNotFound)
+}
+
+func (f *fakeGetClient) ListConversationsHistory(ctx context.Context, params slack.HistoryParams) (*slackapi.GetConversationHistoryResponse, error) {
+	f.pages++
+	start := 0
+	fmt.Sscan(params.Cursor, &start)
+	var msgs []slackapi.Message
+	for _, m := range f.history[start:] {
+		if params.Latest != "" && m.Timestamp > params.Latest {
+			start++
+			continue
+		}
+		if len(msgs) == params.Limit {
+			break
+		}
+		msgs = append(msgs, m)
+	}
+	resp := &slackapi.GetConversationHistoryResponse{Messages: msgs}
+	if next := start + len(msgs); next < len(f.history) {
+		resp.HasMore = true
+		resp.ResponseMetaData.NextCursor = fmt.Sprint(next)
+	}
+	return resp, nil
+}
+
+func (f *fakeGetClient) GetPermalink(ctx context.Context, channel, ts string) (string, error) {
+	return "https://example.slack.com/archives/" + channel + "/p" + ts, nil
+}
+
+func testHistory(n int) []slackapi.Message {
+	msgs := make([]slackapi.Message, n)
+	for i := range msgs {
+		ts := fmt.Sprintf("17000%05d.000100", n-i)
+		msgs[i] = slackapi.Message{Msg: slackapi.Msg{Timestamp: ts, Text: "msg " + ts, User: "U1", ClientMsgID: fmt.Sprintf("id-%d", n-i)}}
+	}
+	return msgs
+}
+
+func TestGetByTS(t *testing.T) {
+	client := &fakeGetClient{history: testHistory(5)}
+	ctx := context.Background()
+
+	result, err := Get(ctx, client, GetParams{Channel: "C1", TS: "1700000003.000100"})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if result.Message().Text != "msg 1700000003.000100" || result.MatchedBy != MatchTS {
+		t.Fatalf("result = %+v", result)
+	}
+
+	if _, err := Get(ctx, client, GetParams{Channel: "C1", TS: "1700000003.500000"}); !errors.Is(err, slack.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	result, err = Get(ctx, client, GetParams{Channel: "C1", TS: "1700000003.500000", Inclusive: true})
+	if err != nil || result.Message().Timestamp != "1700000003.000100" || result.MatchedBy != MatchInclusive {
+		t.Fatalf("inclusive result = %+v, %v", result, err)
+	}
+}
+
+func TestGetByClientMsgID(t *testing.T) {
+	client := &fakeGetClient{history: testHistory(450)}
+	ctx := context.Background()
+
+	result, err := Get(ctx, client, GetParams{Channel: "C1", ClientMsgID: "id-20"})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if result.Message().ClientMsgID != "id-20" || result.Scanned != 431 || client.pages != 3 {
+		t.Fatalf("scanned = %d over %d pages, message = %+v", result.Scanned, client.pages, result.Message())
+	}
+
+	var decoded map[string]any
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	message, _ := decoded["message"].(map[string]any)
+	if decoded["matched_by"] != MatchClientMsgID || decoded["permalink"] == "" || message["client_msg_id"] != "id-20" {
+		t.Fatalf("json = %s", data)
+	}
+
+	client.pages = 0
+	_, err = Get(ctx, client, GetParams{Channel: "C1", ClientMsgID: "id-20", ScanLimit: 100})
+	if !errors.Is(err, slack.ErrNotFound) || client.pages != 1 {
+		t.Fatalf("limited scan err = %v after %d pages", err, client.pages)
+	}
+}
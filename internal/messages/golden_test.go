@@ -0,0 +1,89 @@
+package messages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	resolver := mockUserResolver{users: map[string]string{"U1": "alice", "U2": "bob"}}
+	history := Result{
+		Channel:     "C1",
+		ChannelName: "general",
+		Messages: []slackapi.Message{
+			{Msg: slackapi.Msg{Timestamp: "1700000000.000100", User: "U1", Text: "deploy done, ping <@U2>", ReplyCount: 2, ThreadTimestamp: "1700000000.000100"}},
+			{Msg: slackapi.Msg{Timestamp: "1700000060.000200", User: "U2", Text: "héllo wörld 👋"}},
+			{Msg: slackapi.Msg{Timestamp: "1700000120.000300", User: "U1", SubType: slack.HuddleSubtype}},
+			{Msg: slackapi.Msg{Timestamp: "1700000180.000400", Username: "deploybot", Text: "*build* passed"}},
+		},
+		HasMore:    true,
+		NextCursor: "dXNlcjpVMDYx",
+	}
+	history.SetUserResolver(context.Background(), resolver)
+
+	thread := Result{
+		Channel:  "C1",
+		ThreadTS: "1700000000.000100",
+		Messages: []slackapi.Message{
+			{Msg: slackapi.Msg{Timestamp: "1700000000.000100", User: "U1", Text: "deploy done", ReplyCount: 1, ThreadTimestamp: "1700000000.000100"}},
+			{Msg: slackapi.Msg{Timestamp: "1700000030.000100", User: "U2", Text: "*thanks*"}},
+		},
+	}
+	thread.SetUserResolver(context.Background(), resolver)
+	thread.SetRenderMarkdown(true)
+
+	single := Result{Channel: "C1", ChannelName: "general", Messages: history.Messages[1:2]}
+	single.SetUserResolver(context.Background(), resolver)
+
+	median := 90.0
+	exportedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		p    output.Printable
+	}{
+		{"Result", history},
+		{"Result_thread", thread},
+		{"Result_empty", Result{Channel: "C1"}},
+		{"GetResult", GetResult{Result: single, Permalink: "https://example.slack.com/archives/C1/p1700000060000200", MatchedBy: MatchTS}},
+		{"GetResult_inclusive", GetResult{Result: single, Permalink: "https://example.slack.com/archives/C1/p1700000060000200", MatchedBy: MatchInclusive, Scanned: 40}},
+		{"Stats", Stats{Channel: "C1", ChannelName: "#general", Since: "2024-03-01T00:00:00Z", Until: "2024-03-03T00:00:00Z", Timezone: "UTC",
+			Messages: 75, Replies: 12, Threads: 4, ThreadRatio: 0.0533, MedianResponseSeconds: &median, ResponseSamples: 3,
+			PerDay:       []DayCount{{Date: "2024-03-01", Messages: 70}, {Date: "2024-03-02", Messages: 5}},
+			TopPosters:   []PosterCount{{UserID: "U1", User: "alice", Messages: 50}, {UserID: "U2", Messages: 25}},
+			BusiestHours: []HourCount{{Hour: 9, Messages: 40}, {Hour: 14, Messages: 20}}}},
+		{"Stats_truncated", Stats{Channel: "C1", Since: "2024-03-01T00:00:00Z", Until: "2024-03-02T00:00:00Z", Timezone: "Asia/Tokyo",
+			PerDay: []DayCount{{Date: "2024-03-01", Messages: 0}}, Truncated: true}},
+		{"ExportResult", ExportResult{Channel: "#general", ChannelID: "C1", Output: "general.ndjson", Exported: 1200, Pages: 6, Complete: true}},
+		{"ExportResult_incomplete", ExportResult{Channel: "#general", ChannelID: "C1", Output: "general.ndjson", Checkpoint: "general.checkpoint.json",
+			Exported: 400, Pages: 2, Resumed: true}},
+		{"ExportResult_segmented", ExportResult{Channel: "#general", ChannelID: "C1", Output: "general.ndjson", Exported: 1200, Pages: 6, Complete: true,
+			Manifest: "general.manifest.json", Segments: 3}},
+		{"VerifyResult", &VerifyResult{OK: false, Manifest: "archive/general.manifest.json", Checked: 3, Skipped: 1, Failed: 1, Repaired: 1, Files: []VerifyFile{
+			{File: "archive/general.000001.ndjson.gz", Channel: "C1", Kind: SegmentMessages, Status: VerifySkipped, Lines: 900, ExpectedLines: 900},
+			{File: "archive/general.000002.ndjson.gz", Channel: "C1", Kind: SegmentMessages, Status: VerifyChecksum, Lines: 0, ExpectedLines: 850,
+				Problem: "SHA-256 differs from the manifest", Repaired: true, Refetched: 850},
+			{File: "archive/general.000003.ndjson.gz", Channel: "C1", Kind: SegmentMessages, Status: VerifyOK, Lines: 700, ExpectedLines: 700},
+			{File: "archive/general.000004.ndjson.gz", Channel: "C1", Kind: SegmentMessages, Status: VerifyMissing, ExpectedLines: 120,
+				Problem: "open archive/general.000004.ndjson.gz: no such file or directory"},
+		}}},
+		{"ExportManifest", &ExportManifest{Version: 1, ExportedAt: exportedAt, Complete: false, path: "export/manifest.json", Channels: []ManifestChannel{
+			{ID: "C1", Name: "general", MessagesFile: "export/general.ndjson", ThreadsFile: "export/general.threads.ndjson", ThreadReplyCount: 30,
+				PinsFile: "export/general.pins.json", PinCount: 2, MessageCount: 1200, Complete: true},
+			{ID: "C2", MessagesFile: "export/C2.ndjson", MessageCount: 10},
+			{ID: "C3", Name: "secret", MessagesFile: "export/secret.ndjson", Error: "not_in_channel"},
+			{ID: "C4", Name: "ops", MessagesFile: "export/ops.ndjson", MessagesManifest: "export/ops.manifest.json", MessageCount: 500, Complete: true},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
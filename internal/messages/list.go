@@ -11,6 +11,8 @@ import (
 
 	slackapi "github.com/slack-go/slack"
 
+	"github.com/kehao95/slack-agent-cli/internal/bots"
+	"github.com/kehao95/slack-agent-cli/internal/mrkdwn"
 	"github.com/kehao95/slack-agent-cli/internal/slack"
 )
 
@@ -31,6 +33,11 @@ type UserGroupResolver interface {
 	GetHandle(ctx context.Context, groupID string) string
 }
 
+// BotResolver resolves bot IDs to bot profiles.
+type BotResolver interface {
+	Lookup(ctx context.Context, botID string) (bots.CachedBot, bool)
+}
+
 // Service coordinates message list operations.
 type Service struct {
 	fetcher Fetcher
@@ -53,16 +60,20 @@ type Params struct {
 
 // Result represents list output.
 type Result struct {
-	Channel           string             `json:"channel"`
-	ChannelName       string             `json:"channel_name,omitempty"`
-	ThreadTS          string             `json:"thread_ts,omitempty"`
-	Messages          []slackapi.Message `json:"messages"`
-	HasMore           bool               `json:"has_more"`
-	NextCursor        string             `json:"next_cursor"`
-	userResolver      UserResolver       `json:"-"`
-	userGroupResolver UserGroupResolver  `json:"-"`
-	ctx               context.Context    `json:"-"`
-	rawJSON           bool               `json:"-"`
+	Channel           string                 `json:"channel"`
+	ChannelName       string                 `json:"channel_name,omitempty"`
+	ThreadTS          string                 `json:"thread_ts,omitempty"`
+	Messages          []slackapi.Message     `json:"messages"`
+	HasMore           bool                   `json:"has_more"`
+	NextCursor        string                 `json:"next_cursor"`
+	userResolver      UserResolver           `json:"-"`
+	userGroupResolver UserGroupResolver      `json:"-"`
+	botResolver       BotResolver            `json:"-"`
+	ctx               context.Context        `json:"-"`
+	rawJSON           bool                   `json:"-"`
+	renderMarkdown    bool                   `json:"-"`
+	extractEntities   bool                   `json:"-"`
+	fileContents      map[string]FileContent `json:"-"`
 }
 
 // SetUserResolver sets the user resolver for human-readable output.
@@ -77,11 +88,27 @@ func (r *Result) SetUserGroupResolver(ctx context.Context, resolver UserGroupRes
 	r.userGroupResolver = resolver
 }
 
+// SetBotResolver sets the resolver that names messages posted by bots.
+func (r *Result) SetBotResolver(ctx context.Context, resolver BotResolver) {
+	r.ctx = ctx
+	r.botResolver = resolver
+}
+
 // SetRawJSON controls whether JSON output should preserve raw Slack IDs.
 func (r *Result) SetRawJSON(raw bool) {
 	r.rawJSON = raw
 }
 
+// SetRenderMarkdown converts message text from Slack mrkdwn to Markdown in all output.
+func (r *Result) SetRenderMarkdown(render bool) {
+	r.renderMarkdown = render
+}
+
+// SetExtractEntities adds an "entities" object (URLs, mentions, emoji) to each message in JSON output.
+func (r *Result) SetExtractEntities(extract bool) {
+	r.extractEntities = extract
+}
+
 // MarshalJSON enriches the JSON output with resolved usernames for each message.
 func (r Result) MarshalJSON() ([]byte, error) {
 	type output struct {
@@ -127,6 +154,17 @@ func (r Result) MarshalJSON() ([]byte, error) {
 		if username := r.resolvedUsername(msg); username != "" {
 			enriched["username"] = username
 		}
+		if bot, ok := r.botProfile(msg); ok {
+			if bot.Name != "" {
+				enriched["bot_name"] = bot.Name
+				if _, ok := enriched["username"]; !ok {
+					enriched["username"] = bot.Name
+				}
+			}
+			if bot.AppID != "" {
+				enriched["app_id"] = bot.AppID
+			}
+		}
 
 		if !r.rawJSON {
 			if userID := msg.Msg.User; userID != "" {
@@ -141,6 +179,22 @@ func (r Result) MarshalJSON() ([]byte, error) {
 			r.enrichNestedUserReferences(enriched)
 		}
 
+		if r.renderMarkdown {
+			text := msg.Text
+			if !r.rawJSON {
+				text = r.resolveUserMentions(text)
+			}
+			enriched["text"] = mrkdwn.ToMarkdown(text)
+		}
+
+		if r.extractEntities {
+			enriched["entities"] = r.entities(msg.Text)
+		}
+
+		if len(r.fileContents) > 0 {
+			r.applyFileContents(enriched)
+		}
+
 		outputValue.Messages[i] = enriched
 	}
 
@@ -160,6 +214,7 @@ func (s *Service) List(ctx context.Context, params Params) (Result, error) {
 		msgs, cursor, more, err := s.fetcher.ListThread(ctx, slack.ThreadParams{
 			Channel: params.Channel,
 			Limit:   params.Limit,
+			Cursor:  params.Cursor,
 			Latest:  latest,
 			Oldest:  oldest,
 			Thread:  params.Thread,
@@ -202,6 +257,12 @@ func (r Result) Lines() []string {
 	for _, msg := range r.Messages {
 		// Resolve user mentions in the message text
 		text := r.resolveUserMentions(msg.Msg.Text)
+		if r.renderMarkdown {
+			text = mrkdwn.ToMarkdown(text)
+		}
+		if msg.SubType == slack.HuddleSubtype && strings.TrimSpace(text) == "" {
+			text = "[huddle] started a huddle"
+		}
 		msgLine := fmt.Sprintf("[%s] @%s: %s", formatTimestamp(msg.Msg.Timestamp), r.displayUser(msg), text)
 
 		// Add thread indicator if message has replies (and we're not already in a thread view)
@@ -226,6 +287,9 @@ func (r Result) displayUser(msg slackapi.Message) string {
 
 	userID := msg.Msg.User
 	if userID == "" {
+		if bot, ok := r.botProfile(msg); ok && bot.Name != "" {
+			return bot.Name
+		}
 		return "unknown"
 	}
 
@@ -240,6 +304,21 @@ func (r Result) displayUser(msg slackapi.Message) string {
 	return userID
 }
 
+// botProfile returns the profile of the bot that posted msg. The bot_profile Slack
+// embeds in history is used when present; otherwise the bot resolver is asked.
+func (r Result) botProfile(msg slackapi.Message) (bots.CachedBot, bool) {
+	if msg.BotID == "" {
+		return bots.CachedBot{}, false
+	}
+	if p := msg.BotProfile; p != nil && p.Name != "" {
+		return bots.CachedBot{ID: msg.BotID, Name: p.Name, AppID: p.AppID, Deleted: p.Deleted}, true
+	}
+	if r.botResolver == nil || r.ctx == nil {
+		return bots.CachedBot{}, false
+	}
+	return r.botResolver.Lookup(r.ctx, msg.BotID)
+}
+
 func (r Result) resolvedChannelRef() string {
 	name := strings.TrimSpace(r.ChannelName)
 	if name == "" || name == r.Channel {
@@ -365,6 +444,30 @@ func (r Result) resolveUserList(raw interface{}) ([]interface{}, bool) {
 	return resolved, changed
 }
 
+// entities extracts structured references from text, filling in user and usergroup names
+// from the resolvers unless raw JSON was requested.
+func (r Result) entities(text string) mrkdwn.Entities {
+	e := mrkdwn.Extract(text)
+	if r.rawJSON || r.ctx == nil {
+		return e
+	}
+	if r.userResolver != nil {
+		for i, user := range e.Users {
+			if name := r.userResolver.GetMentionName(r.ctx, user.ID); name != "" && name != user.ID {
+				e.Users[i].Name = name
+			}
+		}
+	}
+	if r.userGroupResolver != nil {
+		for i, group := range e.UserGroups {
+			if handle := r.userGroupResolver.GetHandle(r.ctx, group.ID); handle != "" && handle != group.ID {
+				e.UserGroups[i].Name = strings.TrimPrefix(handle, "@")
+			}
+		}
+	}
+	return e
+}
+
 func isLikelyUserID(value string) bool {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" || strings.HasPrefix(trimmed, "@") {
@@ -9,6 +9,7 @@ import (
 
 	slackapi "github.com/slack-go/slack"
 
+	"github.com/kehao95/slack-agent-cli/internal/bots"
 	"github.com/kehao95/slack-agent-cli/internal/slack"
 )
 
@@ -276,3 +277,105 @@ func TestResultMarshalJSON_RawJSON(t *testing.T) {
 		t.Errorf("expected no username without resolver, got %v", msg1["username"])
 	}
 }
+
+func TestResultRenderMarkdown(t *testing.T) {
+	result := Result{
+		Channel:  "C123",
+		Messages: []slackapi.Message{{Msg: slackapi.Msg{Timestamp: "1", User: "U1", Text: "*hi* <@U2>, see <https://example.com|docs>"}}},
+	}
+	result.SetUserResolver(context.Background(), mockUserResolver{users: map[string]string{"U1": "alice", "U2": "bob"}})
+	result.SetRenderMarkdown(true)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := "**hi** @bob, see [docs](https://example.com)"
+	if !strings.Contains(string(data), want) {
+		t.Fatalf("JSON text not rendered as Markdown: %s", data)
+	}
+	if lines := result.Lines(); !strings.Contains(lines[2], want) {
+		t.Fatalf("human line not rendered as Markdown: %q", lines[2])
+	}
+}
+
+func TestResultExtractEntities(t *testing.T) {
+	result := Result{
+		Channel:  "C123",
+		Messages: []slackapi.Message{{Msg: slackapi.Msg{Timestamp: "1", User: "U1", Text: "ping <@U2> re <https://example.com> :eyes:"}}},
+	}
+	result.SetUserResolver(context.Background(), mockUserResolver{users: map[string]string{"U2": "bob"}})
+	result.SetExtractEntities(true)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	for _, want := range []string{`"users":[{"id":"U2","name":"bob"}]`, `"urls":[{"url":"https://example.com"}]`, `"emoji":["eyes"]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON missing %s: %s", want, data)
+		}
+	}
+}
+
+func TestResultLinesShowsHuddles(t *testing.T) {
+	result := Result{
+		Channel:  "C123",
+		Messages: []slackapi.Message{{Msg: slackapi.Msg{Timestamp: "1", User: "U1", SubType: "huddle_thread"}}},
+	}
+	if lines := result.Lines(); !strings.Contains(lines[2], "[huddle]") {
+		t.Fatalf("huddle message not labeled: %q", lines[2])
+	}
+}
+
+type mockBotResolver struct {
+	bots    map[string]bots.CachedBot
+	lookups []string
+}
+
+func (m *mockBotResolver) Lookup(ctx context.Context, botID string) (bots.CachedBot, bool) {
+	m.lookups = append(m.lookups, botID)
+	b, ok := m.bots[botID]
+	return b, ok
+}
+
+func TestResultNamesBotMessages(t *testing.T) {
+	resolver := &mockBotResolver{bots: map[string]bots.CachedBot{"B1": {ID: "B1", Name: "deploybot", AppID: "A1"}}}
+	result := Result{
+		Channel: "C123",
+		Messages: []slackapi.Message{
+			{Msg: slackapi.Msg{Timestamp: "1", BotID: "B1", Text: "deployed"}},
+			{Msg: slackapi.Msg{Timestamp: "2", BotID: "B2", Text: "paged", BotProfile: &slackapi.BotProfile{Name: "pagerbot", AppID: "A2"}}},
+		},
+	}
+	result.SetBotResolver(context.Background(), resolver)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var output struct {
+		Messages []map[string]interface{} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	first, second := output.Messages[0], output.Messages[1]
+	if first["bot_name"] != "deploybot" || first["app_id"] != "A1" || first["username"] != "deploybot" {
+		t.Fatalf("resolved bot message = %v", first)
+	}
+	if second["bot_name"] != "pagerbot" || second["app_id"] != "A2" {
+		t.Fatalf("bot_profile message = %v", second)
+	}
+
+	lines := result.Lines()
+	if !strings.Contains(lines[2], "@deploybot: deployed") || !strings.Contains(lines[3], "@pagerbot: paged") {
+		t.Fatalf("Lines() = %q", lines)
+	}
+	// The embedded bot_profile saves a bots.info lookup.
+	for _, id := range resolver.lookups {
+		if id != "B1" {
+			t.Fatalf("looked up %s despite its bot_profile", id)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package messages
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// statsSubtypes are the message subtypes counted as activity. Joins, topic changes,
+// and other channel housekeeping are ignored.
+var statsSubtypes = map[string]bool{"": true, "bot_message": true, "thread_broadcast": true, "file_share": true, "me_message": true}
+
+// DayCount is the number of top-level messages posted on one local calendar day.
+type DayCount struct {
+	Date     string `json:"date"`
+	Messages int    `json:"messages"`
+}
+
+// PosterCount is the number of top-level messages one user or bot posted.
+type PosterCount struct {
+	UserID   string `json:"user_id"`
+	User     string `json:"user,omitempty"`
+	Messages int    `json:"messages"`
+}
+
+// HourCount is the number of top-level messages posted in one local hour of the day.
+type HourCount struct {
+	Hour     int `json:"hour"`
+	Messages int `json:"messages"`
+}
+
+// Stats summarizes channel activity over a time range.
+type Stats struct {
+	Channel     string `json:"channel"`
+	ChannelName string `json:"channel_name,omitempty"`
+	Since       string `json:"since"`
+	Until       string `json:"until"`
+	Timezone    string `json:"timezone"`
+	Messages    int    `json:"messages"`
+	Replies     int    `json:"replies"`
+	Threads     int    `json:"threads"`
+	// ThreadRatio is the share of top-level messages that started a thread.
+	ThreadRatio float64 `json:"thread_ratio"`
+	// MedianResponseSeconds is the median time from a thread root to the first reply by
+	// someone else, over ResponseSamples threads. Nil when no thread was answered.
+	MedianResponseSeconds *float64      `json:"median_response_seconds"`
+	ResponseSamples       int           `json:"response_samples"`
+	PerDay                []DayCount    `json:"per_day"`
+	TopPosters            []PosterCount `json:"top_posters"`
+	BusiestHours          []HourCount   `json:"busiest_hours"`
+	Truncated             bool          `json:"truncated,omitempty"`
+}
+
+// StatsInput holds what ComputeStats needs. FirstReplies maps a thread root ts to the
+// first reply in that thread by someone other than the root's author.
+type StatsInput struct {
+	Channel      string
+	From, To     time.Time
+	Location     *time.Location
+	Messages     []slackapi.Message
+	FirstReplies map[string]slackapi.Message
+	TopN         int
+	UserName     func(userID string) string
+}
+
+// ComputeStats aggregates top-level channel messages into activity metrics.
+func ComputeStats(in StatsInput) Stats {
+	loc := in.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	topN := in.TopN
+	if topN <= 0 {
+		topN = 10
+	}
+	stats := Stats{
+		Channel:  in.Channel,
+		Since:    in.From.In(loc).Format(time.RFC3339),
+		Until:    in.To.In(loc).Format(time.RFC3339),
+		Timezone: loc.String(),
+	}
+
+	perDay := map[string]int{}
+	posters := map[string]int{}
+	var hours [24]int
+	var responses []float64
+	for _, msg := range in.Messages {
+		if !statsSubtypes[msg.SubType] {
+			continue
+		}
+		// Replies broadcast to the channel belong to their thread, not the channel.
+		if msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp {
+			continue
+		}
+		posted, ok := SlackTime(msg.Timestamp)
+		if !ok {
+			continue
+		}
+		local := posted.In(loc)
+		stats.Messages++
+		perDay[local.Format(time.DateOnly)]++
+		hours[local.Hour()]++
+		posters[firstNonEmptyString(msg.User, msg.BotID, msg.Username)]++
+		if msg.ReplyCount > 0 {
+			stats.Threads++
+			stats.Replies += msg.ReplyCount
+		}
+		if reply, ok := in.FirstReplies[msg.Timestamp]; ok {
+			if replied, ok := SlackTime(reply.Timestamp); ok && !replied.Before(posted) {
+				responses = append(responses, replied.Sub(posted).Seconds())
+			}
+		}
+	}
+	if stats.Messages > 0 {
+		stats.ThreadRatio = float64(stats.Threads) / float64(stats.Messages)
+	}
+	if len(responses) > 0 {
+		median := medianOf(responses)
+		stats.MedianResponseSeconds = &median
+		stats.ResponseSamples = len(responses)
+	}
+
+	stats.PerDay = []DayCount{}
+	first := time.Date(in.From.In(loc).Year(), in.From.In(loc).Month(), in.From.In(loc).Day(), 0, 0, 0, 0, loc)
+	for day := first; !day.After(in.To.In(loc)); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		stats.PerDay = append(stats.PerDay, DayCount{Date: date, Messages: perDay[date]})
+	}
+
+	stats.TopPosters = []PosterCount{}
+	for id, count := range posters {
+		poster := PosterCount{UserID: id, Messages: count}
+		if in.UserName != nil && id != "" {
+			poster.User = in.UserName(id)
+		}
+		stats.TopPosters = append(stats.TopPosters, poster)
+	}
+	sort.Slice(stats.TopPosters, func(i, j int) bool {
+		a, b := stats.TopPosters[i], stats.TopPosters[j]
+		if a.Messages != b.Messages {
+			return a.Messages > b.Messages
+		}
+		return a.UserID < b.UserID
+	})
+	if len(stats.TopPosters) > topN {
+		stats.TopPosters = stats.TopPosters[:topN]
+	}
+
+	stats.BusiestHours = []HourCount{}
+	for hour, count := range hours {
+		if count > 0 {
+			stats.BusiestHours = append(stats.BusiestHours, HourCount{Hour: hour, Messages: count})
+		}
+	}
+	sort.SliceStable(stats.BusiestHours, func(i, j int) bool {
+		return stats.BusiestHours[i].Messages > stats.BusiestHours[j].Messages
+	})
+	if len(stats.BusiestHours) > 5 {
+		stats.BusiestHours = stats.BusiestHours[:5]
+	}
+	return stats
+}
+
+// FirstResponse returns the first reply in a thread posted by someone other than the
+// root's author. replies is conversations.replies output, root first.
+func FirstResponse(replies []slackapi.Message) (slackapi.Message, bool) {
+	if len(replies) == 0 {
+		return slackapi.Message{}, false
+	}
+	root := replies[0]
+	author := firstNonEmptyString(root.User, root.BotID)
+	for _, reply := range replies[1:] {
+		if reply.Timestamp == root.Timestamp {
+			continue
+		}
+		if who := firstNonEmptyString(reply.User, reply.BotID); who != "" && who != author {
+			return reply, true
+		}
+	}
+	return slackapi.Message{}, false
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (s Stats) Lines() []string {
+	name := firstNonEmptyString(s.ChannelName, s.Channel)
+	lines := []string{
+		fmt.Sprintf("%s activity %s to %s (%s)", name, s.Since[:10], s.Until[:10], s.Timezone),
+		fmt.Sprintf("Messages: %d  Threads: %d (%.0f%%)  Replies: %d", s.Messages, s.Threads, s.ThreadRatio*100, s.Replies),
+	}
+	if s.MedianResponseSeconds != nil {
+		lines = append(lines, fmt.Sprintf("Median first response: %s (%d threads)", (time.Duration(*s.MedianResponseSeconds)*time.Second).String(), s.ResponseSamples))
+	}
+	if len(s.TopPosters) > 0 {
+		lines = append(lines, "Top posters:")
+		for _, p := range s.TopPosters {
+			lines = append(lines, fmt.Sprintf("  %-24s %d", firstNonEmptyString(p.User, p.UserID, "(unknown)"), p.Messages))
+		}
+	}
+	if len(s.BusiestHours) > 0 {
+		parts := make([]string, len(s.BusiestHours))
+		for i, h := range s.BusiestHours {
+			parts[i] = fmt.Sprintf("%02d:00 (%d)", h.Hour, h.Messages)
+		}
+		lines = append(lines, "Busiest hours: "+strings.Join(parts, ", "))
+	}
+	lines = append(lines, "Per day:")
+	for _, d := range s.PerDay {
+		lines = append(lines, fmt.Sprintf("  %s %4d %s", d.Date, d.Messages, strings.Repeat("▇", min(d.Messages, 60))))
+	}
+	if s.Truncated {
+		lines = append(lines, "Note: history was truncated at --limit; counts cover the most recent messages only")
+	}
+	return lines
+}
+
+// SlackTime parses a Slack message timestamp such as "1705312365.000100".
+func SlackTime(ts string) (time.Time, bool) {
+	sec, err := strconv.ParseFloat(ts, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}, false
+	}
+	whole := int64(sec)
+	return time.Unix(whole, int64((sec-float64(whole))*1e9)), true
+}
+
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package messages
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func statsMessage(ts, user, subtype string, replies int) slackapi.Message {
+	var msg slackapi.Message
+	msg.Timestamp = ts
+	msg.User = user
+	msg.SubType = subtype
+	msg.ReplyCount = replies
+	if replies > 0 {
+		msg.ThreadTimestamp = ts
+	}
+	return msg
+}
+
+func TestComputeStats(t *testing.T) {
+	loc := time.UTC
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, loc)
+	to := time.Date(2024, 1, 3, 12, 0, 0, 0, loc)
+	day1 := time.Date(2024, 1, 1, 14, 0, 0, 0, loc).Unix()
+	day3 := time.Date(2024, 1, 3, 9, 0, 0, 0, loc).Unix()
+	ts := func(sec int64) string { return fmt.Sprintf("%d.000100", sec) }
+
+	msgs := []slackapi.Message{
+		statsMessage(ts(day1), "U1", "", 2),
+		statsMessage(ts(day1+60), "U1", "", 0),
+		statsMessage(ts(day1+120), "U2", "channel_join", 0),
+		statsMessage(ts(day3), "U2", "", 1),
+	}
+	reply := statsMessage(ts(day1+300), "U2", "", 0)
+	reply2 := statsMessage(ts(day3+900), "U1", "", 0)
+
+	stats := ComputeStats(StatsInput{
+		Channel:      "C1",
+		From:         from,
+		To:           to,
+		Location:     loc,
+		Messages:     msgs,
+		FirstReplies: map[string]slackapi.Message{ts(day1): reply, ts(day3): reply2},
+		UserName:     func(id string) string { return "name-" + id },
+	})
+
+	if stats.Messages != 3 || stats.Threads != 2 || stats.Replies != 3 {
+		t.Fatalf("counts = %d messages, %d threads, %d replies; want 3, 2, 3", stats.Messages, stats.Threads, stats.Replies)
+	}
+	if got := len(stats.PerDay); got != 3 || stats.PerDay[0].Messages != 2 || stats.PerDay[1].Messages != 0 || stats.PerDay[2].Messages != 1 {
+		t.Fatalf("per_day = %+v", stats.PerDay)
+	}
+	if stats.TopPosters[0].UserID != "U1" || stats.TopPosters[0].Messages != 2 || stats.TopPosters[0].User != "name-U1" {
+		t.Fatalf("top_posters = %+v", stats.TopPosters)
+	}
+	if stats.BusiestHours[0].Hour != 14 || stats.BusiestHours[0].Messages != 2 {
+		t.Fatalf("busiest_hours = %+v", stats.BusiestHours)
+	}
+	if stats.MedianResponseSeconds == nil || *stats.MedianResponseSeconds != 600 || stats.ResponseSamples != 2 {
+		t.Fatalf("median response = %v over %d", stats.MedianResponseSeconds, stats.ResponseSamples)
+	}
+}
+
+func TestFirstResponse(t *testing.T) {
+	replies := []slackapi.Message{
+		statsMessage("100.000000", "U1", "", 2),
+		statsMessage("101.000000", "U1", "", 0),
+		statsMessage("102.000000", "U2", "", 0),
+	}
+	got, ok := FirstResponse(replies)
+	if !ok || got.Timestamp != "102.000000" {
+		t.Fatalf("FirstResponse = %q, %v; want the reply by U2", got.Timestamp, ok)
+	}
+	if _, ok := FirstResponse(replies[:2]); ok {
+		t.Fatal("a thread with only the author's replies has no response")
+	}
+}
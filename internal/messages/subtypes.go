@@ -0,0 +1,83 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// NoSubtype matches ordinary user messages, which carry no subtype.
+const NoSubtype = "none"
+
+// SubtypeFilter selects messages by Slack subtype (bot_message, channel_join, ...).
+// An empty filter allows everything.
+type SubtypeFilter struct {
+	Include map[string]struct{}
+	Exclude map[string]struct{}
+}
+
+// ParseSubtypeFilter parses comma-separated include and exclude lists. Use "none" for
+// ordinary messages without a subtype, e.g. --subtypes none,thread_broadcast.
+func ParseSubtypeFilter(include, exclude string) (SubtypeFilter, error) {
+	var f SubtypeFilter
+	var err error
+	if f.Include, err = parseSubtypes("--subtypes", include); err != nil {
+		return SubtypeFilter{}, err
+	}
+	if f.Exclude, err = parseSubtypes("--exclude-subtypes", exclude); err != nil {
+		return SubtypeFilter{}, err
+	}
+	return f, nil
+}
+
+func parseSubtypes(flag, raw string) (map[string]struct{}, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	result := make(map[string]struct{})
+	for _, part := range strings.Split(raw, ",") {
+		value := strings.ToLower(strings.TrimSpace(part))
+		if value == "" {
+			return nil, fmt.Errorf("invalid %s %q: values must be non-empty comma-separated subtypes", flag, raw)
+		}
+		result[value] = struct{}{}
+	}
+	return result, nil
+}
+
+// IsZero reports whether the filter allows every subtype.
+func (f SubtypeFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Allows reports whether a message with the given subtype passes the filter.
+func (f SubtypeFilter) Allows(subtype string) bool {
+	key := strings.ToLower(subtype)
+	if key == "" {
+		key = NoSubtype
+	}
+	if _, excluded := f.Exclude[key]; excluded {
+		return false
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	_, included := f.Include[key]
+	return included
+}
+
+// Apply removes messages whose subtype the filter rejects.
+func (f SubtypeFilter) Apply(msgs []slackapi.Message) []slackapi.Message {
+	if f.IsZero() {
+		return msgs
+	}
+	kept := msgs[:0]
+	for _, msg := range msgs {
+		if f.Allows(msg.SubType) {
+			kept = append(kept, msg)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,39 @@
+package messages
+
+import (
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func TestSubtypeFilter(t *testing.T) {
+	f, err := ParseSubtypeFilter("none, channel_join,BOT_MESSAGE", "")
+	if err != nil {
+		t.Fatalf("ParseSubtypeFilter: %v", err)
+	}
+	for subtype, want := range map[string]bool{"": true, "channel_join": true, "bot_message": true, "me_message": false} {
+		if got := f.Allows(subtype); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", subtype, got, want)
+		}
+	}
+
+	f, err = ParseSubtypeFilter("", "bot_message")
+	if err != nil {
+		t.Fatalf("ParseSubtypeFilter: %v", err)
+	}
+	msgs := f.Apply([]slackapi.Message{
+		{Msg: slackapi.Msg{Timestamp: "1"}},
+		{Msg: slackapi.Msg{Timestamp: "2", SubType: "bot_message"}},
+		{Msg: slackapi.Msg{Timestamp: "3", SubType: "huddle_thread"}},
+	})
+	if len(msgs) != 2 || msgs[0].Timestamp != "1" || msgs[1].Timestamp != "3" {
+		t.Fatalf("Apply kept %+v", msgs)
+	}
+
+	if _, err := ParseSubtypeFilter("bot_message,,", ""); err == nil {
+		t.Fatal("expected error for empty subtype")
+	}
+	if !(SubtypeFilter{}).Allows("anything") {
+		t.Fatal("zero filter should allow everything")
+	}
+}
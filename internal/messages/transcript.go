@@ -0,0 +1,265 @@
+package messages
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/links"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// Transcript formats supported by Render.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// TranscriptEntry is one rendered message with resolved names.
+type TranscriptEntry struct {
+	TS        string
+	Time      string
+	User      string
+	Text      string
+	Reactions []TranscriptReaction
+	Files     []TranscriptFile
+	Replies   []TranscriptEntry
+}
+
+// TranscriptReaction summarizes one emoji reaction.
+type TranscriptReaction struct {
+	Name  string
+	Count int
+	Users []string
+}
+
+// TranscriptFile links to a file shared in a message.
+type TranscriptFile struct {
+	Name string
+	URL  string
+}
+
+// Transcript is a readable, chronological view of channel history.
+type Transcript struct {
+	Title       string
+	Channel     string
+	GeneratedAt string
+	Entries     []TranscriptEntry
+	// Links expands ticket references in message text into links when set.
+	Links *links.Set
+}
+
+// Transcript builds a chronological transcript from the result's messages, resolving user
+// names and mentions with the configured resolvers. replies maps a thread root ts to its
+// replies (the root itself is skipped if present).
+func (r Result) Transcript(replies map[string][]slackapi.Message) Transcript {
+	channel := r.resolvedChannelRef()
+	if r.ChannelName != "" && !strings.HasPrefix(channel, "#") && !strings.HasPrefix(channel, "@") {
+		channel = "#" + r.ChannelName
+	}
+	t := Transcript{
+		Title:       "Transcript of " + channel,
+		Channel:     channel,
+		GeneratedAt: time.Now().Format("2006-01-02 15:04 MST"),
+	}
+
+	msgs := append([]slackapi.Message(nil), r.Messages...)
+	sortChronological(msgs)
+	for _, msg := range msgs {
+		entry := r.transcriptEntry(msg)
+		thread := append([]slackapi.Message(nil), replies[msg.Timestamp]...)
+		sortChronological(thread)
+		for _, reply := range thread {
+			if reply.Timestamp == msg.Timestamp {
+				continue
+			}
+			entry.Replies = append(entry.Replies, r.transcriptEntry(reply))
+		}
+		t.Entries = append(t.Entries, entry)
+	}
+	return t
+}
+
+func (r Result) transcriptEntry(msg slackapi.Message) TranscriptEntry {
+	entry := TranscriptEntry{
+		TS:   msg.Timestamp,
+		Time: transcriptTime(msg.Timestamp),
+		User: r.displayUser(msg),
+		Text: slackLinksToPlain(r.resolveUserMentions(msg.Text)),
+	}
+	if msg.SubType == slack.HuddleSubtype && strings.TrimSpace(entry.Text) == "" {
+		entry.Text = "(started a huddle)"
+	}
+	for _, reaction := range msg.Reactions {
+		users := make([]string, 0, len(reaction.Users))
+		for _, userID := range reaction.Users {
+			users = append(users, r.displayUser(slackapi.Message{Msg: slackapi.Msg{User: userID}}))
+		}
+		entry.Reactions = append(entry.Reactions, TranscriptReaction{Name: reaction.Name, Count: reaction.Count, Users: users})
+	}
+	for _, file := range msg.Files {
+		url := file.Permalink
+		if url == "" {
+			url = file.URLPrivate
+		}
+		name := file.Title
+		if name == "" {
+			name = file.Name
+		}
+		entry.Files = append(entry.Files, TranscriptFile{Name: name, URL: url})
+	}
+	return entry
+}
+
+// Render writes the transcript in the given format (markdown or html).
+func (t Transcript) Render(w io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case FormatMarkdown, "md":
+		_, err := io.WriteString(w, t.markdown())
+		return err
+	case FormatHTML:
+		tmpl, err := transcriptHTML.Clone()
+		if err != nil {
+			return err
+		}
+		return tmpl.Funcs(template.FuncMap{"text": t.Links.HTML}).Execute(w, t)
+	default:
+		return fmt.Errorf("unsupported format %q (use markdown or html)", format)
+	}
+}
+
+// MessageCount returns the number of messages in the transcript, including replies.
+func (t Transcript) MessageCount() int {
+	n := 0
+	for _, entry := range t.Entries {
+		n += 1 + len(entry.Replies)
+	}
+	return n
+}
+
+func (t Transcript) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.Title)
+	fmt.Fprintf(&b, "_Generated %s · %d messages_\n\n", t.GeneratedAt, t.MessageCount())
+	for _, entry := range t.Entries {
+		writeMarkdownEntry(&b, entry, "", t.Links)
+		for _, reply := range entry.Replies {
+			writeMarkdownEntry(&b, reply, "> ", t.Links)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func writeMarkdownEntry(b *strings.Builder, entry TranscriptEntry, prefix string, expanders *links.Set) {
+	fmt.Fprintf(b, "%s**%s** · %s\n", prefix, entry.User, entry.Time)
+	if prefix != "" {
+		b.WriteString(prefix + "\n")
+	} else {
+		b.WriteString("\n")
+	}
+	for _, line := range strings.Split(entry.Text, "\n") {
+		b.WriteString(prefix + expanders.Markdown(line) + "\n")
+	}
+	for _, file := range entry.Files {
+		fmt.Fprintf(b, "%s📎 [%s](%s)\n", prefix, file.Name, file.URL)
+	}
+	if len(entry.Reactions) > 0 {
+		parts := make([]string, 0, len(entry.Reactions))
+		for _, reaction := range entry.Reactions {
+			parts = append(parts, fmt.Sprintf(":%s: %d", reaction.Name, reaction.Count))
+		}
+		fmt.Fprintf(b, "%s%s\n", prefix, strings.Join(parts, " "))
+	}
+	if prefix != "" {
+		b.WriteString(prefix + "\n")
+	} else {
+		b.WriteString("\n")
+	}
+}
+
+// transcriptHTML is cloned for each Render, which binds "text" to the transcript's
+// link expanders.
+var transcriptHTML = template.Must(template.New("transcript").Funcs(template.FuncMap{"text": (*links.Set)(nil).HTML}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 48rem; margin: 2rem auto; color: #1d1c1d; }
+.msg { margin: 0 0 1rem; }
+.meta { color: #616061; font-size: 0.85rem; }
+.user { font-weight: 600; color: #1d1c1d; }
+.text { white-space: pre-wrap; margin: 0.25rem 0; }
+.replies { border-left: 3px solid #ddd; margin-left: 1rem; padding-left: 1rem; }
+.reactions span { background: #f1f1f1; border-radius: 1rem; padding: 0 0.5rem; margin-right: 0.25rem; font-size: 0.85rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Generated {{.GeneratedAt}} · {{.MessageCount}} messages</p>
+{{range .Entries}}{{template "entry" .}}{{end}}
+</body>
+</html>
+{{define "entry"}}<div class="msg" id="m{{.TS}}">
+<div class="meta"><span class="user">{{.User}}</span> · {{.Time}}</div>
+<div class="text">{{text .Text}}</div>
+{{range .Files}}<div class="file">📎 <a href="{{.URL}}">{{.Name}}</a></div>
+{{end}}{{if .Reactions}}<div class="reactions">{{range .Reactions}}<span title="{{range $i, $u := .Users}}{{if $i}}, {{end}}{{$u}}{{end}}">:{{.Name}}: {{.Count}}</span>{{end}}</div>
+{{end}}{{if .Replies}}<div class="replies">
+{{range .Replies}}{{template "entry" .}}{{end}}</div>
+{{end}}</div>
+{{end}}`))
+
+var slackLinkPattern = regexp.MustCompile(`<([^<>|]+)(?:\|([^<>]*))?>`)
+
+// slackLinksToPlain rewrites Slack link markup (<url|label>, <#C123|general>) into plain text.
+func slackLinksToPlain(text string) string {
+	text = slackLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := slackLinkPattern.FindStringSubmatch(match)
+		target, label := parts[1], parts[2]
+		switch {
+		case strings.HasPrefix(target, "#"):
+			if label != "" {
+				return "#" + label
+			}
+			return target
+		case strings.HasPrefix(target, "!"):
+			if label != "" {
+				return label
+			}
+			return "@" + strings.TrimPrefix(target, "!")
+		case strings.HasPrefix(target, "@"):
+			return match
+		case label != "" && label != target:
+			return fmt.Sprintf("%s (%s)", label, target)
+		default:
+			return target
+		}
+	})
+	replacer := strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+	return replacer.Replace(text)
+}
+
+func sortChronological(msgs []slackapi.Message) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		a, _ := strconv.ParseFloat(msgs[i].Timestamp, 64)
+		b, _ := strconv.ParseFloat(msgs[j].Timestamp, 64)
+		return a < b
+	})
+}
+
+func transcriptTime(ts string) string {
+	secs, err := strconv.ParseFloat(ts, 64)
+	if err != nil {
+		return ts
+	}
+	return time.Unix(int64(secs), 0).Format("2006-01-02 15:04")
+}
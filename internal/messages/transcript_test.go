@@ -0,0 +1,124 @@
+package messages
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/links"
+)
+
+func transcriptFixture() (Result, map[string][]slackapi.Message) {
+	result := Result{
+		Channel:     "C123",
+		ChannelName: "general",
+		Messages: []slackapi.Message{
+			{Msg: slackapi.Msg{Timestamp: "1700000200.000000", User: "U2", Text: "see <https://example.com|the docs> in <#C999|random>", Files: []slackapi.File{{Title: "plan.pdf", Permalink: "https://files/plan.pdf"}}}},
+			{Msg: slackapi.Msg{Timestamp: "1700000100.000000", User: "U1", Text: "hi <@U2> &amp; &lt;b&gt;", ReplyCount: 1, Reactions: []slackapi.ItemReaction{{Name: "wave", Count: 1, Users: []string{"U2"}}}}},
+		},
+	}
+	result.SetUserResolver(context.Background(), mockUserResolver{users: map[string]string{"U1": "alice", "U2": "bob"}})
+	replies := map[string][]slackapi.Message{
+		"1700000100.000000": {
+			{Msg: slackapi.Msg{Timestamp: "1700000100.000000", User: "U1", Text: "hi"}},
+			{Msg: slackapi.Msg{Timestamp: "1700000150.000000", User: "U2", Text: "hello back"}},
+		},
+	}
+	return result, replies
+}
+
+func TestTranscriptChronologicalWithReplies(t *testing.T) {
+	result, replies := transcriptFixture()
+	transcript := result.Transcript(replies)
+
+	if transcript.Channel != "#general" {
+		t.Fatalf("channel = %q, want #general", transcript.Channel)
+	}
+	if len(transcript.Entries) != 2 || transcript.Entries[0].User != "alice" || transcript.Entries[1].User != "bob" {
+		t.Fatalf("entries not chronological: %+v", transcript.Entries)
+	}
+	first := transcript.Entries[0]
+	if len(first.Replies) != 1 || first.Replies[0].Text != "hello back" {
+		t.Fatalf("replies = %+v, want one reply without the root", first.Replies)
+	}
+	if len(first.Reactions) != 1 || first.Reactions[0].Users[0] != "bob" {
+		t.Fatalf("reactions = %+v", first.Reactions)
+	}
+	if got := transcript.MessageCount(); got != 3 {
+		t.Fatalf("MessageCount = %d, want 3", got)
+	}
+}
+
+func TestTranscriptRenderMarkdown(t *testing.T) {
+	result, replies := transcriptFixture()
+	var b strings.Builder
+	if err := result.Transcript(replies).Render(&b, FormatMarkdown); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# Transcript of #general",
+		"**alice**",
+		"> hello back",
+		"the docs (https://example.com) in #random",
+		"[plan.pdf](https://files/plan.pdf)",
+		":wave: 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTranscriptRenderHTMLEscapes(t *testing.T) {
+	result, replies := transcriptFixture()
+	var b strings.Builder
+	if err := result.Transcript(replies).Render(&b, FormatHTML); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := b.String()
+	if !strings.Contains(out, `<a href="https://files/plan.pdf">plan.pdf</a>`) {
+		t.Errorf("html missing file link:\n%s", out)
+	}
+	if !strings.Contains(out, "&lt;b&gt;") || strings.Contains(out, "<b>") {
+		t.Errorf("html did not escape message text:\n%s", out)
+	}
+	if !strings.Contains(out, `class="replies"`) {
+		t.Errorf("html missing replies block:\n%s", out)
+	}
+}
+
+func TestTranscriptRenderExpandsLinks(t *testing.T) {
+	expanders, err := links.New([]config.Expander{{Pattern: `\b[A-Z]+-\d+\b`, URL: "https://jira.test/browse/$0"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := Result{Channel: "C123", ChannelName: "general", Messages: []slackapi.Message{
+		{Msg: slackapi.Msg{Timestamp: "1700000100.000000", User: "U1", Text: "ABC-12 is fixed, see <https://jira.test/browse/ABC-11|ABC-11>"}},
+	}}
+	transcript := result.Transcript(nil)
+	transcript.Links = expanders
+
+	var md, html strings.Builder
+	if err := transcript.Render(&md, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(md.String(), "[ABC-12](https://jira.test/browse/ABC-12) is fixed, see ABC-11 (https://jira.test/browse/ABC-11)") {
+		t.Errorf("markdown:\n%s", md.String())
+	}
+	if err := transcript.Render(&html, FormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html.String(), `<a href="https://jira.test/browse/ABC-12">ABC-12</a> is fixed`) {
+		t.Errorf("html:\n%s", html.String())
+	}
+}
+
+func TestTranscriptRenderUnknownFormat(t *testing.T) {
+	if err := (Transcript{}).Render(&strings.Builder{}, "pdf"); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
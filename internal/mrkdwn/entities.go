@@ -0,0 +1,119 @@
+package mrkdwn
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Entities are the structured references found in a message's text.
+// Slices are never nil so JSON output always has the same shape.
+type Entities struct {
+	URLs       []Link    `json:"urls"`
+	Users      []Mention `json:"users"`
+	Channels   []Mention `json:"channels"`
+	UserGroups []Mention `json:"usergroups"`
+	Broadcasts []string  `json:"broadcasts"`
+	Emoji      []string  `json:"emoji"`
+}
+
+// Link is a URL with its optional display label.
+type Link struct {
+	URL   string `json:"url"`
+	Label string `json:"label,omitempty"`
+}
+
+// Mention is a user, channel, or usergroup reference.
+// Name is the label Slack embedded in the text, or a resolved name filled in by the caller.
+type Mention struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+var emojiPattern = regexp.MustCompile(`:([a-z0-9_+'-]+(?:::skin-tone-[2-6])?):`)
+
+// Extract parses Slack message text for URLs, user/channel/usergroup mentions,
+// broadcasts (@here, @channel, @everyone), and :emoji: codes. Each entity is listed
+// once, in order of first appearance. Emoji inside code are ignored.
+func Extract(text string) Entities {
+	e := Entities{
+		URLs:       []Link{},
+		Users:      []Mention{},
+		Channels:   []Mention{},
+		UserGroups: []Mention{},
+		Broadcasts: []string{},
+		Emoji:      []string{},
+	}
+	seen := map[string]bool{}
+	once := func(key string) bool {
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+
+	for _, m := range slackLinkPattern.FindAllStringSubmatch(text, -1) {
+		target, label := m[1], unescape(m[2])
+		switch {
+		case strings.HasPrefix(target, "@"):
+			if id := target[1:]; once("u:" + id) {
+				e.Users = append(e.Users, Mention{ID: id, Name: strings.TrimPrefix(label, "@")})
+			}
+		case strings.HasPrefix(target, "#"):
+			if id := target[1:]; once("c:" + id) {
+				e.Channels = append(e.Channels, Mention{ID: id, Name: label})
+			}
+		case strings.HasPrefix(target, "!subteam^"):
+			if id := strings.TrimPrefix(target, "!subteam^"); once("g:" + id) {
+				e.UserGroups = append(e.UserGroups, Mention{ID: id, Name: strings.TrimPrefix(label, "@")})
+			}
+		case target == "!here" || target == "!channel" || target == "!everyone":
+			if name := target[1:]; once("b:" + name) {
+				e.Broadcasts = append(e.Broadcasts, name)
+			}
+		case strings.HasPrefix(target, "!"):
+			// Dates and other special commands carry no entity.
+		default:
+			url := unescape(target)
+			if once("l:" + url) {
+				if label == url {
+					label = ""
+				}
+				e.URLs = append(e.URLs, Link{URL: url, Label: label})
+			}
+		}
+	}
+
+	plain := stripCode(text)
+	for _, loc := range emojiPattern.FindAllStringSubmatchIndex(plain, -1) {
+		// Skip times like 10:30:45 where the "emoji" follows a letter or digit.
+		if loc[0] > 0 && isWordByte(plain[loc[0]-1]) {
+			continue
+		}
+		if name := plain[loc[2]:loc[3]]; once("e:" + name) {
+			e.Emoji = append(e.Emoji, name)
+		}
+	}
+	return e
+}
+
+// stripCode removes code blocks and inline code so their contents are not parsed as emoji.
+func stripCode(text string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(text, "```") {
+		if i%2 == 1 {
+			continue
+		}
+		for j, span := range splitCodeSpans(part) {
+			if j%2 == 0 {
+				b.WriteString(span)
+				b.WriteString(" ")
+			}
+		}
+	}
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,36 @@
+package mrkdwn
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	text := "hey <@U123> and <@U456|bob>, see <https://example.com/a?b=1&amp;c=2|the docs> " +
+		"and <https://example.com> in <#C789|general> <!here> <!subteam^S1|@oncall> " +
+		":tada: :+1::skin-tone-2: at 10:30:45 `:not_emoji:` again <@U123> :tada:"
+	got := Extract(text)
+	want := Entities{
+		URLs:       []Link{{URL: "https://example.com/a?b=1&c=2", Label: "the docs"}, {URL: "https://example.com"}},
+		Users:      []Mention{{ID: "U123"}, {ID: "U456", Name: "bob"}},
+		Channels:   []Mention{{ID: "C789", Name: "general"}},
+		UserGroups: []Mention{{ID: "S1", Name: "oncall"}},
+		Broadcasts: []string{"here"},
+		Emoji:      []string{"tada", "+1::skin-tone-2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Extract =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestExtractEmptyHasStableShape(t *testing.T) {
+	data, err := json.Marshal(Extract("plain text"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"urls":[],"users":[],"channels":[],"usergroups":[],"broadcasts":[],"emoji":[]}`
+	if string(data) != want {
+		t.Fatalf("got %s, want %s", data, want)
+	}
+}
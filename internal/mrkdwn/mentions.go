@@ -0,0 +1,84 @@
+package mrkdwn
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MentionResolver looks up a name written in plain text. kind is '@' for users and '#'
+// for channels; name has the sigil removed. It returns the Slack ID and whether the name
+// is known.
+type MentionResolver func(kind byte, name string) (string, bool)
+
+// plainMentionPattern matches @name and #name that start a word, so e-mail addresses and
+// URL fragments are left alone.
+var plainMentionPattern = regexp.MustCompile(`(^|[^\w<|@#&/:.-])([@#])(\w[\w.'-]*)`)
+
+// LinkMentions rewrites plain @user and #channel references into Slack mention syntax
+// (<@U123>, <#C123>) so they notify people and link channels. @here, @channel, and
+// @everyone become broadcasts. Names the resolver does not know are left as written and
+// returned, sigil included, in order of first appearance. Existing <...> links and code
+// are not touched.
+func LinkMentions(text string, resolve MentionResolver) (string, []string) {
+	var unresolved []string
+	seen := map[string]bool{}
+	link := func(segment string) string {
+		var ph placeholders
+		segment = slackLinkPattern.ReplaceAllStringFunc(segment, ph.add)
+		segment = plainMentionPattern.ReplaceAllStringFunc(segment, func(m string) string {
+			sub := plainMentionPattern.FindStringSubmatch(m)
+			prefix, sigil, name := sub[1], sub[2], sub[3]
+			// Trailing punctuation ends a sentence rather than the name.
+			trimmed := strings.TrimRight(name, ".'-")
+			suffix := name[len(trimmed):]
+			if sigil == "@" {
+				switch strings.ToLower(trimmed) {
+				case "here", "channel", "everyone":
+					return prefix + "<!" + strings.ToLower(trimmed) + ">" + suffix
+				}
+			}
+			if id, ok := resolve(sigil[0], trimmed); ok {
+				return prefix + "<" + sigil + id + ">" + suffix
+			}
+			if key := sigil + trimmed; !seen[key] {
+				seen[key] = true
+				unresolved = append(unresolved, key)
+			}
+			return m
+		})
+		return ph.restore(segment)
+	}
+
+	lines := strings.Split(text, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.Count(line, "```")%2 == 1 {
+			inFence = !inFence
+			continue
+		}
+		if inFence || strings.Contains(line, "```") {
+			continue
+		}
+		spans := splitCodeSpans(line)
+		for j := range spans {
+			if j%2 == 0 {
+				spans[j] = link(spans[j])
+			}
+		}
+		lines[i] = joinCodeSpans(spans)
+	}
+	return strings.Join(lines, "\n"), unresolved
+}
+
+// joinCodeSpans reverses splitCodeSpans.
+func joinCodeSpans(spans []string) string {
+	var b strings.Builder
+	for j, span := range spans {
+		if j%2 == 1 {
+			b.WriteString("`" + span + "`")
+			continue
+		}
+		b.WriteString(span)
+	}
+	return b.String()
+}
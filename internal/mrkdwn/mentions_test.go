@@ -0,0 +1,43 @@
+package mrkdwn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinkMentions(t *testing.T) {
+	known := map[string]string{"@alice": "U111", "@bob.smith": "U222", "#general": "C333"}
+	resolve := func(kind byte, name string) (string, bool) {
+		id, ok := known[string(kind)+name]
+		return id, ok
+	}
+
+	tests := []struct {
+		name       string
+		in         string
+		want       string
+		unresolved []string
+	}{
+		{"user and channel", "@alice see #general", "<@U111> see <#C333>", nil},
+		{"trailing punctuation", "thanks @bob.smith.", "thanks <@U222>.", nil},
+		{"broadcast", "@here deploy done", "<!here> deploy done", nil},
+		{"unknown kept", "@carol and #random, @carol", "@carol and #random, @carol", []string{"@carol", "#random"}},
+		{"email untouched", "mail alice@example.com", "mail alice@example.com", nil},
+		{"url fragment untouched", "see https://example.com/#general", "see https://example.com/#general", nil},
+		{"existing link untouched", "<@U999|alice> and @alice", "<@U999|alice> and <@U111>", nil},
+		{"inline code untouched", "run `@alice` then ping @alice", "run `@alice` then ping <@U111>", nil},
+		{"code block untouched", "```\n@alice\n```\n@alice", "```\n@alice\n```\n<@U111>", nil},
+		{"parenthesised", "(cc @alice)", "(cc <@U111>)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unresolved := LinkMentions(tt.in, resolve)
+			if got != tt.want {
+				t.Errorf("LinkMentions(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if !reflect.DeepEqual(unresolved, tt.unresolved) {
+				t.Errorf("unresolved = %v, want %v", unresolved, tt.unresolved)
+			}
+		})
+	}
+}
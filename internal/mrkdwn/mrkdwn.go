@@ -0,0 +1,295 @@
+// Package mrkdwn converts between GitHub-flavored Markdown and Slack mrkdwn.
+//
+// The conversions cover the constructs agents and people commonly write: emphasis,
+// strikethrough, links, inline code, fenced code blocks, headings, bullet lists, and
+// blockquotes. Code is never reformatted. Anything without a Slack equivalent (tables,
+// nested emphasis edge cases) passes through unchanged.
+package mrkdwn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdImagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdLinkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdAutolinkPattern = regexp.MustCompile(`<((?:https?|mailto):[^<>\s]+)>`)
+	// mdSlackMarkupPattern matches Slack markup written directly in Markdown: <@U123>,
+	// <#C123|general>, <!here>, <!subteam^S1>, and <https://example.com|label>.
+	mdSlackMarkupPattern = regexp.MustCompile(`<(?:[@#!][\w^][^<>]*|[a-zA-Z][\w+.-]*:[^<>|\s]+\|[^<>]*)>`)
+	mdEscapePattern      = regexp.MustCompile("\\\\([\\\\`*_{}\\[\\]()#+\\-.!~|>])")
+	mdBoldPattern        = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdItalicPattern      = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*]*?\S)?)\*`)
+	mdStrikePattern      = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdHeadingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	mdBulletPattern      = regexp.MustCompile(`^(\s*)[-*+]\s+(\[[ xX]\]\s+)?`)
+	mdQuotePattern       = regexp.MustCompile(`^(\s*>\s?)+`)
+
+	slackLinkPattern   = regexp.MustCompile(`<([^<>|]+)(?:\|([^<>]*))?>`)
+	slackBoldPattern   = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*\n]*?\S)?)\*`)
+	slackStrikePattern = regexp.MustCompile(`(^|[^\w~])~(\S(?:[^~\n]*?\S)?)~`)
+	slackBulletPattern = regexp.MustCompile(`^(\s*)[•◦▪]\s+`)
+	slackQuotePattern  = regexp.MustCompile(`^&gt;\s?`)
+)
+
+// placeholders hold already-converted fragments so later inline rewrites cannot touch them.
+type placeholders []string
+
+func (p *placeholders) add(s string) string {
+	*p = append(*p, s)
+	return fmt.Sprintf("\x00%d\x00", len(*p)-1)
+}
+
+var placeholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+
+func (p placeholders) restore(s string) string {
+	return placeholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+		var i int
+		fmt.Sscanf(m[1:len(m)-1], "%d", &i)
+		if i < len(p) {
+			return p[i]
+		}
+		return m
+	})
+}
+
+// FromMarkdown converts GitHub-flavored Markdown into Slack mrkdwn suitable for chat.postMessage.
+//
+//	**bold** / __bold__  ->  *bold*
+//	*italic*             ->  _italic_
+//	~~strike~~           ->  ~strike~
+//	[text](url)          ->  <url|text>
+//	# Heading            ->  *Heading*
+//	- item / * item      ->  • item
+//	```go                ->  ```
+//
+// &, <, and > are escaped as Slack requires, except in Slack markup already written in
+// the text (<@U123>, <#C123|general>, <!here>, <url|label>), which passes through.
+func FromMarkdown(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			// Slack shows a language tag literally, so the fence is always bare.
+			out = append(out, "```")
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, escape(line))
+			continue
+		}
+		out = append(out, convertMarkdownLine(line))
+	}
+	return strings.Join(out, "\n")
+}
+
+func convertMarkdownLine(line string) string {
+	prefix := ""
+	if m := mdQuotePattern.FindString(line); m != "" {
+		prefix = "> "
+		line = line[len(m):]
+	}
+	if m := mdHeadingPattern.FindStringSubmatch(line); m != nil {
+		return prefix + "*" + convertMarkdownInline(m[2]) + "*"
+	}
+	if m := mdBulletPattern.FindStringSubmatch(line); m != nil && !isRule(line) {
+		bullet := m[1] + "• "
+		if task := strings.TrimSpace(m[2]); task != "" {
+			if strings.EqualFold(task, "[x]") {
+				bullet += "☑ "
+			} else {
+				bullet += "☐ "
+			}
+		}
+		return prefix + bullet + convertMarkdownInline(line[len(m[0]):])
+	}
+	return prefix + convertMarkdownInline(line)
+}
+
+func isRule(line string) bool {
+	compact := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	return len(compact) >= 3 && strings.Trim(compact, "-*_") == "" && strings.Count(compact, compact[:1]) == len(compact)
+}
+
+// convertMarkdownInline rewrites inline Markdown outside code spans.
+func convertMarkdownInline(text string) string {
+	var b strings.Builder
+	for i, part := range splitCodeSpans(text) {
+		if i%2 == 1 {
+			b.WriteString("`" + escape(part) + "`")
+			continue
+		}
+		b.WriteString(convertMarkdownText(part))
+	}
+	return b.String()
+}
+
+func convertMarkdownText(text string) string {
+	var p placeholders
+	text = mdEscapePattern.ReplaceAllStringFunc(text, func(m string) string {
+		return p.add(escape(m[1:]))
+	})
+	text = mdSlackMarkupPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return p.add(m)
+	})
+	text = mdImagePattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := mdImagePattern.FindStringSubmatch(m)
+		return p.add(slackLink(parts[2], parts[1]))
+	})
+	text = mdLinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := mdLinkPattern.FindStringSubmatch(m)
+		return p.add(slackLink(parts[2], parts[1]))
+	})
+	text = mdAutolinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return p.add(m)
+	})
+
+	text = escape(text)
+	text = mdBoldPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := mdBoldPattern.FindStringSubmatch(m)
+		inner := parts[1]
+		if inner == "" {
+			inner = parts[2]
+		}
+		return "\x01" + inner + "\x01"
+	})
+	text = mdItalicPattern.ReplaceAllString(text, "${1}_${2}_")
+	text = strings.ReplaceAll(text, "\x01", "*")
+	text = mdStrikePattern.ReplaceAllString(text, "~${1}~")
+	return p.restore(text)
+}
+
+func slackLink(url, label string) string {
+	label = strings.TrimSpace(label)
+	if label == "" || label == url {
+		return "<" + url + ">"
+	}
+	label = strings.NewReplacer("|", "¦", "*", "", "_", "", "`", "").Replace(label)
+	return "<" + url + "|" + escape(label) + ">"
+}
+
+// escape applies Slack's required HTML entity escaping.
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func unescape(s string) string {
+	return strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(s)
+}
+
+// splitCodeSpans splits text on single-backtick code spans. Odd indexes are code.
+// An unmatched backtick is treated as literal text.
+func splitCodeSpans(text string) []string {
+	var parts []string
+	for {
+		start := strings.Index(text, "`")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start+1:], "`")
+		if end < 0 {
+			break
+		}
+		end += start + 1
+		parts = append(parts, text[:start], text[start+1:end])
+		text = text[end+1:]
+	}
+	return append(parts, text)
+}
+
+// ToMarkdown converts Slack mrkdwn (as returned in message text) into GitHub-flavored Markdown.
+//
+//	*bold*          ->  **bold**
+//	~strike~        ->  ~~strike~~
+//	<url|text>      ->  [text](url)
+//	<#C123|general> ->  #general
+//	<@U123>         ->  @U123
+//	<!here>         ->  @here
+//	• item          ->  - item
+//
+// _italic_, inline code, code blocks, and blockquotes are already valid Markdown.
+// Resolve user mentions before converting to get names instead of IDs.
+func ToMarkdown(text string) string {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	for _, line := range lines {
+		if strings.Count(line, "```")%2 == 1 {
+			inFence = !inFence
+			out = append(out, unescape(line))
+			continue
+		}
+		if inFence || strings.HasPrefix(strings.TrimSpace(line), "```") {
+			out = append(out, unescape(line))
+			continue
+		}
+		out = append(out, convertMrkdwnLine(line))
+	}
+	return strings.Join(out, "\n")
+}
+
+func convertMrkdwnLine(line string) string {
+	prefix := ""
+	if m := slackQuotePattern.FindString(line); m != "" {
+		prefix = "> "
+		line = line[len(m):]
+	}
+	if m := slackBulletPattern.FindStringSubmatch(line); m != nil {
+		prefix += m[1] + "- "
+		line = line[len(m[0]):]
+	}
+	var b strings.Builder
+	for i, part := range splitCodeSpans(line) {
+		if i%2 == 1 {
+			b.WriteString("`" + unescape(part) + "`")
+			continue
+		}
+		b.WriteString(convertMrkdwnText(part))
+	}
+	return prefix + b.String()
+}
+
+func convertMrkdwnText(text string) string {
+	var p placeholders
+	text = slackLinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := slackLinkPattern.FindStringSubmatch(m)
+		return p.add(markdownLink(parts[1], unescape(parts[2])))
+	})
+	text = slackBoldPattern.ReplaceAllString(text, "$1**$2**")
+	text = slackStrikePattern.ReplaceAllString(text, "$1~~$2~~")
+	return p.restore(unescape(text))
+}
+
+func markdownLink(target, label string) string {
+	switch {
+	case strings.HasPrefix(target, "@"):
+		if label != "" {
+			return "@" + strings.TrimPrefix(label, "@")
+		}
+		return target
+	case strings.HasPrefix(target, "#"):
+		if label != "" {
+			return "#" + label
+		}
+		return target
+	case strings.HasPrefix(target, "!subteam^"):
+		if label != "" {
+			return "@" + strings.TrimPrefix(label, "@")
+		}
+		return "@" + strings.TrimPrefix(target, "!subteam^")
+	case strings.HasPrefix(target, "!"):
+		if label != "" {
+			return label
+		}
+		return "@" + strings.TrimPrefix(target, "!")
+	case label == "" || label == target:
+		return unescape(target)
+	default:
+		return "[" + label + "](" + unescape(target) + ")"
+	}
+}
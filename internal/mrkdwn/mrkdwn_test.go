@@ -0,0 +1,83 @@
+package mrkdwn
+
+import "testing"
+
+func TestFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bold", "this is **important**", "this is *important*"},
+		{"underscore bold", "__also bold__", "*also bold*"},
+		{"italic", "an *emphasized* word", "an _emphasized_ word"},
+		{"bold and italic", "**bold** and *italic*", "*bold* and _italic_"},
+		{"strike", "~~gone~~", "~gone~"},
+		{"link", "see [the docs](https://example.com/a?b=1&c=2)", "see <https://example.com/a?b=1&c=2|the docs>"},
+		{"autolink", "<https://example.com>", "<https://example.com>"},
+		{"image", "![diagram](https://example.com/d.png)", "<https://example.com/d.png|diagram>"},
+		{"escaping", "a < b && c > d", "a &lt; b &amp;&amp; c &gt; d"},
+		{"user mention", "hi <@U123ABC> see **this**", "hi <@U123ABC> see *this*"},
+		{"channel link", "in <#C123|gen> & <#C456>", "in <#C123|gen> &amp; <#C456>"},
+		{"broadcast", "<!here> deploy < 5m", "<!here> deploy &lt; 5m"},
+		{"special mention", "<!subteam^S123|@oncall> and <!channel>", "<!subteam^S123|@oncall> and <!channel>"},
+		{"slack link", "see <https://example.com/a?b=1&c=2|the **docs**>", "see <https://example.com/a?b=1&c=2|the **docs**>"},
+		{"not markup", "if a<b|c>d", "if a&lt;b|c&gt;d"},
+		{"inline code untouched", "run `**not bold** <x>`", "run `**not bold** &lt;x&gt;`"},
+		{"heading", "## Release notes ##", "*Release notes*"},
+		{"bullets", "- one\n* two\n  + nested", "• one\n• two\n  • nested"},
+		{"task list", "- [x] done\n- [ ] todo", "• ☑ done\n• ☐ todo"},
+		{"blockquote", "> quoted **text**", "> quoted *text*"},
+		{"rule is not a bullet", "---", "---"},
+		{"backslash escape", `2 \* 3 = 6`, "2 * 3 = 6"},
+		{"multiplication", "2 * 3 * 4", "2 * 3 * 4"},
+		{"fenced code", "```go\nif a < b && **x** {}\n```", "```\nif a &lt; b &amp;&amp; **x** {}\n```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMarkdown(tt.in); got != tt.want {
+				t.Errorf("FromMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bold", "this is *important*", "this is **important**"},
+		{"adjacent bold", "*a* *b*", "**a** **b**"},
+		{"italic unchanged", "_quiet_", "_quiet_"},
+		{"strike", "~gone~", "~~gone~~"},
+		{"link", "see <https://example.com|the docs>", "see [the docs](https://example.com)"},
+		{"bare link", "<https://example.com>", "https://example.com"},
+		{"channel", "in <#C123|general>", "in #general"},
+		{"user", "hi <@U123>", "hi @U123"},
+		{"broadcast", "<!here> heads up", "@here heads up"},
+		{"usergroup", "<!subteam^S1|@oncall> ping", "@oncall ping"},
+		{"entities", "a &lt; b &amp;&amp; c &gt; d", "a < b && c > d"},
+		{"quote", "&gt; quoted *text*", "> quoted **text**"},
+		{"bullet", "• one\n  ◦ two", "- one\n  - two"},
+		{"inline code untouched", "run `*x* &lt;y&gt;`", "run `*x* <y>`"},
+		{"code block untouched", "```\n*x* = 1\n```", "```\n*x* = 1\n```"},
+		{"single-line code block", "```*x*```", "```*x*```"},
+		{"multiplication", "2*3*4", "2*3*4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToMarkdown(tt.in); got != tt.want {
+				t.Errorf("ToMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	md := "**Deploy** finished: see [logs](https://ci.example.com/1) and ~~old~~ `cmd`"
+	if got := ToMarkdown(FromMarkdown(md)); got != md {
+		t.Fatalf("round trip = %q, want %q", got, md)
+	}
+}
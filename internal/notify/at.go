@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Zones an At can be read in besides IANA names.
+const (
+	// ZoneRecipient is the recipient's own time zone from users.info.
+	ZoneRecipient = "recipient-local"
+	// ZoneLocal is the sender's time zone.
+	ZoneLocal = "local"
+)
+
+// At is a delivery time as written by a person: an absolute RFC 3339 time, or a clock
+// time with an optional date and time zone, such as "09:00 recipient-local" or
+// "2024-01-16 09:00 Europe/Berlin".
+type At struct {
+	abs   time.Time
+	date  time.Time // midnight UTC of the date; zero for the next occurrence
+	clock time.Duration
+	// Zone is ZoneRecipient, ZoneLocal, or an IANA name; empty for absolute times.
+	Zone string
+}
+
+// ParseAt parses "HH:MM [zone]", "YYYY-MM-DD HH:MM [zone]", or an RFC 3339 time.
+// The zone defaults to local.
+func ParseAt(value string) (At, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return At{abs: t}, nil
+	}
+	fields := strings.Fields(value)
+	at := At{Zone: ZoneLocal}
+	if len(fields) > 0 {
+		if d, err := time.Parse("2006-01-02", fields[0]); err == nil {
+			at.date = d
+			fields = fields[1:]
+		}
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return At{}, fmt.Errorf(`want "HH:MM [zone]", "YYYY-MM-DD HH:MM [zone]", or RFC 3339, got %q`, value)
+	}
+	clock, err := parseClock(fields[0])
+	if err != nil || clock >= 24*time.Hour {
+		return At{}, fmt.Errorf("invalid time %q, want HH:MM", fields[0])
+	}
+	at.clock = clock
+	if len(fields) == 2 {
+		at.Zone = fields[1]
+		switch strings.ToLower(at.Zone) {
+		case ZoneRecipient, ZoneLocal:
+			at.Zone = strings.ToLower(at.Zone)
+		default:
+			if _, err := time.LoadLocation(at.Zone); err != nil {
+				return At{}, fmt.Errorf("unknown time zone %q: use %s, %s, or an IANA name such as Europe/Berlin", at.Zone, ZoneRecipient, ZoneLocal)
+			}
+		}
+	}
+	return at, nil
+}
+
+// Resolve returns the moment a is next due after now, in the zone it was written in.
+// recipient is the recipient's zone, needed only for ZoneRecipient. With weekdays, a
+// clock time that would land on a Saturday or Sunday moves to Monday. A date in the
+// past is an error.
+func (a At) Resolve(now time.Time, recipient *time.Location, weekdays bool) (time.Time, error) {
+	if !a.abs.IsZero() {
+		if !a.abs.After(now) {
+			return time.Time{}, fmt.Errorf("%s is in the past", a.abs.Format(time.RFC3339))
+		}
+		return a.abs, nil
+	}
+	var loc *time.Location
+	switch a.Zone {
+	case ZoneRecipient:
+		if recipient == nil {
+			return time.Time{}, fmt.Errorf("%s needs a recipient", ZoneRecipient)
+		}
+		loc = recipient
+	case ZoneLocal, "":
+		loc = now.Location()
+	default:
+		var err error
+		if loc, err = time.LoadLocation(a.Zone); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	day := now.In(loc)
+	if !a.date.IsZero() {
+		day = a.date
+	}
+	t := a.on(day, loc)
+	if !a.date.IsZero() {
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("%s is in the past", t.Format("2006-01-02 15:04 MST"))
+		}
+		return t, nil
+	}
+	for !t.After(now) || (weekdays && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday)) {
+		day = day.AddDate(0, 0, 1)
+		t = a.on(day, loc)
+	}
+	return t, nil
+}
+
+// on returns a's clock time on day's date in loc. Building it from wall-clock fields
+// keeps 09:00 at 09:00 on days a DST change shifts midnight.
+func (a At) on(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(a.clock/time.Hour), int(a.clock%time.Hour/time.Minute), 0, 0, loc)
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAtResolve(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	// Friday Jan 19 2024, 20:00 UTC: already Saturday 05:00 in Tokyo.
+	now := time.Date(2024, time.January, 19, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		weekdays bool
+		want     time.Time
+	}{
+		{name: "recipient later today", value: "09:00 recipient-local", want: time.Date(2024, 1, 20, 9, 0, 0, 0, tokyo)},
+		{name: "recipient weekdays", value: "09:00 recipient-local", weekdays: true, want: time.Date(2024, 1, 22, 9, 0, 0, 0, tokyo)},
+		{name: "recipient already past", value: "04:30 recipient-local", want: time.Date(2024, 1, 21, 4, 30, 0, 0, tokyo)},
+		{name: "local default", value: "21:15", want: time.Date(2024, 1, 19, 21, 15, 0, 0, time.UTC)},
+		{name: "local tomorrow", value: "8:00 LOCAL", want: time.Date(2024, 1, 20, 8, 0, 0, 0, time.UTC)},
+		{name: "iana zone", value: "09:00 Europe/Berlin", want: time.Date(2024, 1, 20, 9, 0, 0, 0, berlin)},
+		{name: "date", value: "2024-01-25 09:00 recipient-local", want: time.Date(2024, 1, 25, 9, 0, 0, 0, tokyo)},
+		{name: "date on weekend", value: "2024-01-27 09:00 recipient-local", weekdays: true, want: time.Date(2024, 1, 27, 9, 0, 0, 0, tokyo)},
+		{name: "rfc3339", value: "2024-01-22T09:00:00+09:00", want: time.Date(2024, 1, 22, 9, 0, 0, 0, tokyo)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, err := ParseAt(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := at.Resolve(now, tokyo, tt.weekdays)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Resolve(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAtErrors(t *testing.T) {
+	for _, value := range []string{"", "9am", "25:00", "09:00 Mars/Olympus", "09:00 local extra", "2024-01-25"} {
+		if _, err := ParseAt(value); err == nil {
+			t.Errorf("ParseAt(%q) succeeded, want error", value)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	now := time.Date(2024, time.January, 19, 20, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2024-01-19 19:00 UTC", "2024-01-19T19:00:00Z"} {
+		at, err := ParseAt(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := at.Resolve(now, time.UTC, false); err == nil {
+			t.Errorf("Resolve(%q) succeeded for a past time", value)
+		}
+	}
+	at, err := ParseAt("09:00 recipient-local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := at.Resolve(now, nil, false); err == nil {
+		t.Error("recipient-local without a recipient succeeded")
+	}
+}
@@ -0,0 +1,148 @@
+// Package notify decides when a direct message should reach someone, honoring their
+// Do Not Disturb snooze, scheduled DND window, and working hours.
+package notify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Actions a Decision can take.
+const (
+	ActionSend     = "sent"
+	ActionSchedule = "scheduled"
+	ActionFallback = "fallback"
+)
+
+// Availability is what Slack reports about a user's DND state.
+type Availability struct {
+	// SnoozeUntil is when a manual snooze ends; zero when not snoozed.
+	SnoozeUntil time.Time
+	// DNDStart and DNDEnd bound the next scheduled Do Not Disturb window.
+	DNDStart, DNDEnd time.Time
+}
+
+// WorkingHours is a daily window in the recipient's time zone. The zero value means
+// any time is fine.
+type WorkingHours struct {
+	Start, End time.Duration // offsets from local midnight
+	Weekends   bool
+	set        bool
+}
+
+// ParseWorkingHours parses "09:00-18:00". "" and "any" disable the check.
+func ParseWorkingHours(value string, weekends bool) (WorkingHours, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "any" {
+		return WorkingHours{}, nil
+	}
+	startRaw, endRaw, ok := strings.Cut(value, "-")
+	if !ok {
+		return WorkingHours{}, fmt.Errorf("working hours must look like 09:00-18:00, got %q", value)
+	}
+	start, err := parseClock(startRaw)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	end, err := parseClock(endRaw)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	if end <= start {
+		return WorkingHours{}, fmt.Errorf("working hours must end after they start, got %q", value)
+	}
+	return WorkingHours{Start: start, End: end, Weekends: weekends, set: true}, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(value), ":")
+	h, errH := strconv.Atoi(hh)
+	m, errM := strconv.Atoi(mm)
+	if !ok || errH != nil || errM != nil || h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", value)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// Next returns the earliest time at or after t that falls inside working hours.
+func (w WorkingHours) Next(t time.Time) time.Time {
+	if !w.set {
+		return t
+	}
+	for i := 0; i < 8; i++ {
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		weekend := midnight.Weekday() == time.Saturday || midnight.Weekday() == time.Sunday
+		if !weekend || w.Weekends {
+			start, end := midnight.Add(w.Start), midnight.Add(w.End)
+			if t.Before(start) {
+				return start
+			}
+			if t.Before(end) {
+				return t
+			}
+		}
+		t = midnight.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// Decision is when and where a notification should be delivered.
+type Decision struct {
+	Action string
+	// At is the delivery time for ActionSchedule.
+	At     time.Time
+	Reason string
+}
+
+// Decide picks how to deliver a DM at now to someone in loc. A message that cannot be
+// delivered within maxDelay goes to the fallback channel when hasFallback is set;
+// otherwise it is scheduled. maxDelay of 0 means no limit.
+func Decide(now time.Time, loc *time.Location, avail Availability, hours WorkingHours, maxDelay time.Duration, hasFallback bool) Decision {
+	if loc == nil {
+		loc = time.UTC
+	}
+	at := now.In(loc)
+	var reasons []string
+	// Constraints can push each other (a snooze ending after hours), so settle them together.
+	for i := 0; i < 4; i++ {
+		moved := false
+		if at.Before(avail.SnoozeUntil) {
+			at = avail.SnoozeUntil.In(loc)
+			reasons = appendOnce(reasons, "snoozed")
+			moved = true
+		}
+		if !avail.DNDStart.IsZero() && !at.Before(avail.DNDStart) && at.Before(avail.DNDEnd) {
+			at = avail.DNDEnd.In(loc)
+			reasons = appendOnce(reasons, "in Do Not Disturb")
+			moved = true
+		}
+		if next := hours.Next(at); !next.Equal(at) {
+			at = next
+			reasons = appendOnce(reasons, "outside working hours")
+			moved = true
+		}
+		if !moved {
+			break
+		}
+	}
+
+	if len(reasons) == 0 {
+		return Decision{Action: ActionSend, Reason: "available"}
+	}
+	reason := strings.Join(reasons, ", ")
+	if hasFallback && maxDelay > 0 && at.Sub(now) > maxDelay {
+		return Decision{Action: ActionFallback, At: at, Reason: fmt.Sprintf("%s until %s", reason, at.Format(time.RFC3339))}
+	}
+	return Decision{Action: ActionSchedule, At: at, Reason: reason}
+}
+
+func appendOnce(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
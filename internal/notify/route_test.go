@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecide(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	hours, err := ParseWorkingHours("09:00-18:00", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, loc) // Jan 15 2024 is a Monday
+	}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		avail       Availability
+		hasFallback bool
+		wantAction  string
+		wantAt      time.Time
+		wantReason  string
+	}{
+		{name: "available", now: at(15, 10, 0), wantAction: ActionSend},
+		{name: "before hours", now: at(15, 7, 30), wantAction: ActionSchedule, wantAt: at(15, 9, 0), wantReason: "outside working hours"},
+		{name: "friday evening", now: at(19, 19, 0), wantAction: ActionSchedule, wantAt: at(22, 9, 0), wantReason: "outside working hours"},
+		{name: "snoozed", now: at(15, 10, 0), avail: Availability{SnoozeUntil: at(15, 11, 0)}, wantAction: ActionSchedule, wantAt: at(15, 11, 0), wantReason: "snoozed"},
+		{
+			name:       "snooze ends after hours",
+			now:        at(15, 17, 0),
+			avail:      Availability{SnoozeUntil: at(15, 20, 0)},
+			wantAction: ActionSchedule, wantAt: at(16, 9, 0), wantReason: "snoozed, outside working hours",
+		},
+		{
+			name:       "in dnd window",
+			now:        at(15, 12, 0),
+			avail:      Availability{DNDStart: at(15, 11, 0), DNDEnd: at(15, 13, 0)},
+			wantAction: ActionSchedule, wantAt: at(15, 13, 0), wantReason: "in Do Not Disturb",
+		},
+		{name: "future dnd window", now: at(15, 10, 0), avail: Availability{DNDStart: at(15, 22, 0), DNDEnd: at(16, 8, 0)}, wantAction: ActionSend},
+		{name: "fallback", now: at(19, 19, 0), hasFallback: true, wantAction: ActionFallback, wantAt: at(22, 9, 0)},
+		{name: "short wait skips fallback", now: at(15, 8, 30), hasFallback: true, wantAction: ActionSchedule, wantAt: at(15, 9, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Decide(tt.now, loc, tt.avail, hours, 4*time.Hour, tt.hasFallback)
+			if got.Action != tt.wantAction {
+				t.Fatalf("action = %s (%s), want %s", got.Action, got.Reason, tt.wantAction)
+			}
+			if !tt.wantAt.IsZero() && !got.At.Equal(tt.wantAt) {
+				t.Fatalf("at = %s, want %s", got.At, tt.wantAt)
+			}
+			if tt.wantReason != "" && got.Reason != tt.wantReason {
+				t.Fatalf("reason = %q, want %q", got.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestParseWorkingHours(t *testing.T) {
+	if h, err := ParseWorkingHours("any", false); err != nil || h.set {
+		t.Fatalf("any = %+v, %v", h, err)
+	}
+	for _, bad := range []string{"9-5", "18:00-09:00", "09:00", "25:00-26:00", "09:60-10:00"} {
+		if _, err := ParseWorkingHours(bad, false); err == nil {
+			t.Errorf("ParseWorkingHours(%q) succeeded", bad)
+		}
+	}
+}
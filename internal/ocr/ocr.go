@@ -0,0 +1,64 @@
+// Package ocr extracts text from images by running an external OCR program, so the
+// program can be swapped through config without changing the CLI.
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// FilePlaceholder in a command is replaced by the image path. A command without it
+// gets the path as its last argument.
+const FilePlaceholder = "{file}"
+
+// DefaultCommand runs Tesseract and prints the text to stdout. It is used when
+// ocr_command is not configured.
+var DefaultCommand = []string{"tesseract", FilePlaceholder, "stdout"}
+
+// Args returns the command line that extracts text from path.
+func Args(command []string, path string) []string {
+	if len(command) == 0 {
+		command = DefaultCommand
+	}
+	args := make([]string, 0, len(command)+1)
+	substituted := false
+	for _, arg := range command {
+		if strings.Contains(arg, FilePlaceholder) {
+			arg = strings.ReplaceAll(arg, FilePlaceholder, path)
+			substituted = true
+		}
+		args = append(args, arg)
+	}
+	if !substituted {
+		args = append(args, path)
+	}
+	return args
+}
+
+// Run extracts text from the image at path with command (DefaultCommand when empty)
+// and returns its standard output with surrounding whitespace trimmed. A failing
+// command's standard error is included in the returned error.
+func Run(ctx context.Context, command []string, path string) (string, error) {
+	args := Args(command, path)
+	if _, err := exec.LookPath(args[0]); err != nil {
+		if len(command) == 0 {
+			return "", fmt.Errorf("ocr: %s not found; install Tesseract or set ocr_command in config", args[0])
+		}
+		return "", fmt.Errorf("ocr: %w", err)
+	}
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, args[0], args[1:]...)
+	c.Stdout, c.Stderr = &stdout, &stderr
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if msg := strings.TrimSpace(stderr.String()); msg != "" && errors.As(err, &exitErr) {
+			return "", fmt.Errorf("ocr: %s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("ocr: %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
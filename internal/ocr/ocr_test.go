@@ -0,0 +1,49 @@
+package ocr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	tests := []struct {
+		command []string
+		want    []string
+	}{
+		{nil, []string{"tesseract", "/tmp/a.png", "stdout"}},
+		{[]string{"ocrmypdf", "--image={file}", "-"}, []string{"ocrmypdf", "--image=/tmp/a.png", "-"}},
+		{[]string{"my-ocr", "--lang", "eng"}, []string{"my-ocr", "--lang", "eng", "/tmp/a.png"}},
+	}
+	for _, tt := range tests {
+		if got := Args(tt.command, "/tmp/a.png"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Args(%v) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.txt")
+	if err := os.WriteFile(path, []byte("  HELLO FROM A SCREENSHOT\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	text, err := Run(context.Background(), []string{"cat"}, path)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if text != "HELLO FROM A SCREENSHOT" {
+		t.Fatalf("text = %q", text)
+	}
+
+	_, err = Run(context.Background(), []string{"sh", "-c", "echo bad image >&2; exit 3", "sh"}, path)
+	if err == nil || !strings.Contains(err.Error(), "bad image") {
+		t.Fatalf("err = %v, want the command's stderr", err)
+	}
+
+	if _, err := Run(context.Background(), []string{"no-such-ocr-tool"}, path); err == nil {
+		t.Fatal("expected an error for a missing command")
+	}
+}
@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	slackapi "github.com/slack-go/slack"
+)
+
+// permanentErrors are chat.postMessage errors that retrying the same payload cannot fix.
+var permanentErrors = []string{"invalid_blocks", "msg_too_long", "no_text", "is_archived", "invalid_arguments", "too_many_attachments"}
+
+// Outcome is how a failed delivery should be handled.
+type Outcome struct {
+	// Permanent means the message will never be accepted as queued.
+	Permanent bool
+	// Offline means Slack could not be reached or is throttling; later messages in the
+	// same flush would fail the same way.
+	Offline bool
+	// RetryAfter is Slack's requested wait for a rate-limited call, if any.
+	RetryAfter time.Duration
+}
+
+// Classify decides whether a failed delivery is worth retrying. Errors are retried
+// unless Slack rejected the channel, the permissions, or the payload itself.
+func Classify(err error) Outcome {
+	var rateLimited *slackapi.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return Outcome{Offline: true, RetryAfter: rateLimited.RetryAfter}
+	}
+	// Read-only mode blocks every delivery until it is turned off.
+	if errors.Is(err, slack.ErrReadOnly) {
+		return Outcome{Offline: true}
+	}
+	// So does a user token without allow_user_impersonation.
+	if errors.Is(err, slack.ErrUserImpersonation) {
+		return Outcome{Offline: true}
+	}
+	if errors.Is(err, slack.ErrChannelBlocked) {
+		return Outcome{Permanent: true}
+	}
+	// Transport failures are recognized by their text even when wrapped in a coded
+	// error such as a failed auth.test.
+	code := cerrors.ClassifySlackError(err)
+	if code == cerrors.ExitRateLimit || code == cerrors.ExitNetwork {
+		return Outcome{Offline: true}
+	}
+	if coded := cerrors.ExitCode(err); coded != cerrors.ExitGeneral {
+		code = coded
+	}
+	if code == cerrors.ExitPermission || code == cerrors.ExitNotFound {
+		return Outcome{Permanent: true}
+	}
+	text := err.Error()
+	for _, code := range permanentErrors {
+		if strings.Contains(text, code) {
+			return Outcome{Permanent: true}
+		}
+	}
+	return Outcome{}
+}
+
+// NextAttempt returns when a message that has failed attempts times should be retried.
+func (o Outcome) NextAttempt(now time.Time, attempts int) time.Time {
+	if o.RetryAfter > 0 {
+		return now.Add(o.RetryAfter)
+	}
+	return now.Add(Backoff(attempts))
+}
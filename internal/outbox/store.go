@@ -0,0 +1,363 @@
+// Package outbox provides a local SQLite-backed queue of messages waiting to be posted.
+//
+// Messages are written before any delivery attempt and only removed from the pending set
+// once Slack has accepted them, so a message survives crashes, network loss, and rate
+// limits. A worker that dies between posting and MarkSent leaves its lease to expire and
+// the message is posted again: delivery is at least once.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	_ "modernc.org/sqlite"
+)
+
+// Message statuses.
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusFailed  = "failed"
+)
+
+// Message is one queued Slack message.
+type Message struct {
+	ID        int64     `json:"id"`
+	Key       string    `json:"key,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	// Channel is the target as given (name, ID, or @user); it is resolved at delivery.
+	Channel   string `json:"channel"`
+	ChannelID string `json:"channel_id,omitempty"`
+	Text      string `json:"text,omitempty"`
+	ThreadTS  string `json:"thread_ts,omitempty"`
+	Blocks    string `json:"blocks,omitempty"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+	// NextAttempt is when a pending message is next due.
+	NextAttempt *time.Time `json:"next_attempt_at,omitempty"`
+	SentAt      *time.Time `json:"sent_at,omitempty"`
+	TS          string     `json:"ts,omitempty"`
+}
+
+// Store wraps an outbox SQLite database.
+type Store struct {
+	db   *sql.DB
+	path string
+}
+
+// DefaultPath returns the default outbox path adjacent to the slk config file. The outbox
+// is not keyed by workspace so messages can be queued before auth.test is reachable.
+func DefaultPath(configPath string) (string, error) {
+	if configPath == "" {
+		var err error
+		if configPath, err = config.DefaultPath(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(filepath.Dir(configPath), "outbox", "outbox.db"), nil
+}
+
+// Open opens or creates an outbox.
+func Open(path string) (*Store, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("outbox path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create outbox dir: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	store := &Store{db: db, path: path}
+	if err := store.init(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Path returns the backing SQLite path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *Store) init() error {
+	stmts := []string{
+		`PRAGMA busy_timeout=5000`,
+		`PRAGMA journal_mode=WAL`,
+		`CREATE TABLE IF NOT EXISTS messages (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			key TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			channel TEXT NOT NULL,
+			channel_id TEXT NOT NULL DEFAULT '',
+			text TEXT NOT NULL DEFAULT '',
+			thread_ts TEXT NOT NULL DEFAULT '',
+			blocks TEXT NOT NULL DEFAULT '',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			next_attempt_at TEXT NOT NULL,
+			lease_until TEXT NOT NULL DEFAULT '',
+			sent_at TEXT NOT NULL DEFAULT '',
+			ts TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_key ON messages(key) WHERE key != ''`,
+		`CREATE INDEX IF NOT EXISTS idx_messages_due ON messages(status, next_attempt_at, id)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("init outbox: %w", err)
+		}
+	}
+	return nil
+}
+
+// Add queues a message and returns its ID. When msg.Key matches a message already in the
+// outbox, nothing is added and the existing ID is returned with duplicate set.
+func (s *Store) Add(ctx context.Context, msg Message) (id int64, duplicate bool, err error) {
+	if strings.TrimSpace(msg.Channel) == "" {
+		return 0, false, errors.New("channel is required")
+	}
+	if msg.Text == "" && msg.Blocks == "" {
+		return 0, false, errors.New("text or blocks is required")
+	}
+	if msg.Key != "" {
+		err := s.db.QueryRowContext(ctx, `SELECT id FROM messages WHERE key = ?`, msg.Key).Scan(&id)
+		if err == nil {
+			return id, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, false, fmt.Errorf("check outbox key: %w", err)
+		}
+	}
+	now := formatTime(time.Now())
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO messages (key, status, created_at, channel, text, thread_ts, blocks, next_attempt_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		msg.Key, StatusPending, now, msg.Channel, msg.Text, msg.ThreadTS, msg.Blocks, now,
+	)
+	if err != nil {
+		return 0, false, fmt.Errorf("queue message: %w", err)
+	}
+	if id, err = res.LastInsertId(); err != nil {
+		return 0, false, fmt.Errorf("read queued id: %w", err)
+	}
+	return id, false, nil
+}
+
+// Claim leases the oldest pending message due by now. Claimed messages are skipped by
+// other workers until the lease expires. id of 0 claims any due message; otherwise only
+// that message is claimed, even if it is not yet due.
+func (s *Store) Claim(ctx context.Context, id int64, now time.Time, lease time.Duration) (Message, bool, error) {
+	if lease <= 0 {
+		return Message{}, false, fmt.Errorf("lease must be greater than zero")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Message{}, false, fmt.Errorf("begin claim transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	nowText := formatTime(now)
+	query := `SELECT ` + messageColumns + ` FROM messages WHERE status = ? AND lease_until <= ?`
+	args := []interface{}{StatusPending, nowText}
+	if id > 0 {
+		query += ` AND id = ?`
+		args = append(args, id)
+	} else {
+		query += ` AND next_attempt_at <= ?`
+		args = append(args, nowText)
+	}
+	msg, err := scanMessage(tx.QueryRowContext(ctx, query+` ORDER BY next_attempt_at, id LIMIT 1`, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Message{}, false, nil
+		}
+		return Message{}, false, fmt.Errorf("claim select: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE messages SET lease_until = ?, attempts = attempts + 1 WHERE id = ?`,
+		formatTime(now.Add(lease)), msg.ID); err != nil {
+		return Message{}, false, fmt.Errorf("claim update: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Message{}, false, fmt.Errorf("commit claim: %w", err)
+	}
+	msg.Attempts++
+	return msg, true, nil
+}
+
+// MarkSent records a successful delivery.
+func (s *Store) MarkSent(ctx context.Context, id int64, channelID, ts string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE messages SET status = ?, channel_id = ?, ts = ?, sent_at = ?, last_error = '', lease_until = '' WHERE id = ?`,
+		StatusSent, channelID, ts, formatTime(time.Now()), id)
+	if err != nil {
+		return fmt.Errorf("mark message sent: %w", err)
+	}
+	return nil
+}
+
+// Retry releases a claimed message to be tried again at next.
+func (s *Store) Retry(ctx context.Context, id int64, cause string, next time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE messages SET last_error = ?, next_attempt_at = ?, lease_until = '' WHERE id = ?`,
+		cause, formatTime(next), id)
+	if err != nil {
+		return fmt.Errorf("reschedule message: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed gives up on a message that Slack will never accept as queued.
+func (s *Store) MarkFailed(ctx context.Context, id int64, cause string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE messages SET status = ?, last_error = ?, lease_until = '' WHERE id = ?`,
+		StatusFailed, cause, id)
+	if err != nil {
+		return fmt.Errorf("mark message failed: %w", err)
+	}
+	return nil
+}
+
+// Requeue returns failed messages to the pending set. id of 0 requeues all of them.
+func (s *Store) Requeue(ctx context.Context, id int64) (int64, error) {
+	query := `UPDATE messages SET status = ?, next_attempt_at = ? WHERE status = ?`
+	args := []interface{}{StatusPending, formatTime(time.Now()), StatusFailed}
+	if id > 0 {
+		query += ` AND id = ?`
+		args = append(args, id)
+	}
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("requeue messages: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// Get returns one message by ID.
+func (s *Store) Get(ctx context.Context, id int64) (Message, bool, error) {
+	msg, err := scanMessage(s.db.QueryRowContext(ctx, `SELECT `+messageColumns+` FROM messages WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Message{}, false, nil
+	}
+	if err != nil {
+		return Message{}, false, fmt.Errorf("get outbox message: %w", err)
+	}
+	return msg, true, nil
+}
+
+// List returns messages with the given status, or all messages when status is "",
+// oldest first. limit of 0 means no limit.
+func (s *Store) List(ctx context.Context, status string, limit int) ([]Message, error) {
+	query := `SELECT ` + messageColumns + ` FROM messages`
+	var args []interface{}
+	if status != "" {
+		query += ` WHERE status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY id`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox: %w", err)
+	}
+	defer rows.Close()
+	messages := []Message{}
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// Counts returns the number of messages in each status.
+func (s *Store) Counts(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM messages GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("count outbox: %w", err)
+	}
+	defer rows.Close()
+	counts := map[string]int{StatusPending: 0, StatusSent: 0, StatusFailed: 0}
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("scan outbox count: %w", err)
+		}
+		counts[status] = n
+	}
+	return counts, rows.Err()
+}
+
+// PruneSent deletes sent messages older than cutoff.
+func (s *Store) PruneSent(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM messages WHERE status = ? AND sent_at < ?`, StatusSent, formatTime(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("prune outbox: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// Backoff returns the delay before retry attempt n+1 after n failed attempts: 30s
+// doubling up to an hour.
+func Backoff(attempts int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, time.Hour)
+}
+
+const messageColumns = `id, key, status, created_at, channel, channel_id, text, thread_ts, blocks, attempts, last_error, next_attempt_at, sent_at, ts`
+
+func scanMessage(scanner interface {
+	Scan(dest ...interface{}) error
+}) (Message, error) {
+	var msg Message
+	var createdAt, nextAttempt, sentAt string
+	if err := scanner.Scan(&msg.ID, &msg.Key, &msg.Status, &createdAt, &msg.Channel, &msg.ChannelID, &msg.Text,
+		&msg.ThreadTS, &msg.Blocks, &msg.Attempts, &msg.LastError, &nextAttempt, &sentAt, &msg.TS); err != nil {
+		return Message{}, err
+	}
+	msg.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	if t, err := time.Parse(time.RFC3339Nano, nextAttempt); err == nil && msg.Status == StatusPending {
+		msg.NextAttempt = &t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, sentAt); err == nil {
+		msg.SentAt = &t
+	}
+	return msg, nil
+}
+
+// formatTime stores times as fixed-width UTC so they compare correctly as text.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000000Z")
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	slackapi "github.com/slack-go/slack"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "outbox.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStoreDeliveryLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+
+	first, dup, err := store.Add(ctx, Message{Channel: "#alerts", Text: "one", Key: "k1"})
+	if err != nil || dup {
+		t.Fatalf("Add = %d, %v, %v", first, dup, err)
+	}
+	again, dup, err := store.Add(ctx, Message{Channel: "#alerts", Text: "one", Key: "k1"})
+	if err != nil || !dup || again != first {
+		t.Fatalf("duplicate Add = %d, %v, %v; want %d, true", again, dup, err, first)
+	}
+	second, _, err := store.Add(ctx, Message{Channel: "#ops", Text: "two"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	msg, ok, err := store.Claim(ctx, 0, now, time.Minute)
+	if err != nil || !ok || msg.ID != first || msg.Attempts != 1 {
+		t.Fatalf("Claim = %+v, %v, %v", msg, ok, err)
+	}
+	// The leased message is skipped until its lease expires.
+	next, ok, err := store.Claim(ctx, 0, now, time.Minute)
+	if err != nil || !ok || next.ID != second {
+		t.Fatalf("second Claim = %+v, %v, %v", next, ok, err)
+	}
+	if _, ok, _ := store.Claim(ctx, 0, now, time.Minute); ok {
+		t.Fatal("expected nothing left to claim")
+	}
+	if expired, ok, _ := store.Claim(ctx, 0, now.Add(2*time.Minute), time.Minute); !ok || expired.ID != first || expired.Attempts != 2 {
+		t.Fatalf("expired lease not reclaimed: %+v, %v", expired, ok)
+	}
+
+	if err := store.MarkSent(ctx, first, "C1", "1.000100"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Retry(ctx, second, "dial tcp: offline", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := store.Claim(ctx, 0, now.Add(5*time.Minute), time.Minute); ok {
+		t.Fatal("message claimed before its next attempt")
+	}
+	if forced, ok, _ := store.Claim(ctx, second, now.Add(5*time.Minute), time.Minute); !ok || forced.ID != second {
+		t.Fatalf("Claim by id = %+v, %v", forced, ok)
+	}
+	if err := store.MarkFailed(ctx, second, "channel_not_found"); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := store.Counts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[StatusSent] != 1 || counts[StatusFailed] != 1 || counts[StatusPending] != 0 {
+		t.Fatalf("counts = %v", counts)
+	}
+	sent, _, _ := store.Get(ctx, first)
+	if sent.TS != "1.000100" || sent.ChannelID != "C1" || sent.SentAt == nil || sent.NextAttempt != nil {
+		t.Fatalf("sent message = %+v", sent)
+	}
+
+	if n, err := store.Requeue(ctx, 0); err != nil || n != 1 {
+		t.Fatalf("Requeue = %d, %v", n, err)
+	}
+	pending, err := store.List(ctx, StatusPending, 0)
+	if err != nil || len(pending) != 1 || pending[0].ID != second || pending[0].LastError != "channel_not_found" {
+		t.Fatalf("pending = %+v, %v", pending, err)
+	}
+}
+
+func TestAddRequiresContent(t *testing.T) {
+	store := openTestStore(t)
+	if _, _, err := store.Add(context.Background(), Message{Channel: "#alerts"}); err == nil {
+		t.Fatal("expected error for a message without text or blocks")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 4: 4 * time.Minute, 20: time.Hour} {
+		if got := Backoff(attempts); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Outcome
+	}{
+		{"rate limited", &slackapi.RateLimitedError{RetryAfter: 20 * time.Second}, Outcome{Offline: true, RetryAfter: 20 * time.Second}},
+		{"offline auth.test", cerrors.AuthError("auth test failed: %w", errors.New("dial tcp: lookup slack.com: no such host")), Outcome{Offline: true}},
+		{"unknown channel", cerrors.ChannelNotFoundError("#nope"), Outcome{Permanent: true}},
+		{"bad payload", errors.New("post message: invalid_blocks"), Outcome{Permanent: true}},
+		{"slack hiccup", errors.New("post message: internal_error"), Outcome{}},
+		{"revoked token", errors.New("post message: token_revoked"), Outcome{}},
+		{"denied channel", cerrors.WrapWithCode(cerrors.ExitPolicy, slack.ErrChannelBlocked, "C1 is in denied_channels"), Outcome{Permanent: true}},
+		{"read-only mode", cerrors.WrapWithCode(cerrors.ExitPolicy, slack.ErrReadOnly, "chat.postMessage is disabled"), Outcome{Offline: true}},
+		{"user impersonation", cerrors.WrapWithCode(cerrors.ExitPolicy, slack.ErrUserImpersonation, "chat.postMessage would act as a person"), Outcome{Offline: true}},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.want {
+			t.Errorf("%s: Classify = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
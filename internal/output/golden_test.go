@@ -0,0 +1,23 @@
+package output_test
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		p    output.Printable
+	}{
+		{"ListFormatter", output.ListFormatter{Title: "Channels", LinesData: []string{"#general", "#random"}}},
+		{"ListFormatter_untitled", output.ListFormatter{LinesData: []string{"one", "two"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
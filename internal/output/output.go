@@ -1,10 +1,13 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/kehao95/slack-agent-cli/internal/tokens"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
 	"github.com/spf13/cobra"
 )
 
@@ -14,38 +17,150 @@ type Printable interface {
 }
 
 // Print writes output in the desired format based on --human flag.
-// Default is JSON (machine-first). Use --human for human-readable output.
+// Default is JSON (machine-first). Use --human for human-readable output, which is
+// fitted to the terminal's width and colored (see Terminal) when stdout is a terminal.
+// --max-output-tokens trims the output to a token budget, and --estimate-tokens
+// reports its approximate token count on stderr. Pending warnings (see package warnings)
+// are added to a JSON object as a top-level "warnings" array, or written to stderr.
 func Print(cmd *cobra.Command, data interface{}) error {
 	humanFlag, _ := cmd.Flags().GetBool("human")
+	estimate, _ := cmd.Flags().GetBool("estimate-tokens")
+	budget, _ := cmd.Flags().GetInt("max-output-tokens")
+	if estimate || budget > 0 {
+		return printBudgeted(data, humanFlag, estimate, budget)
+	}
 	if humanFlag {
 		return printHuman(data)
 	}
 	return printJSON(data)
 }
 
+// TokenEstimate is the --estimate-tokens report written to stderr.
+type TokenEstimate struct {
+	Bytes     int                `json:"bytes"`
+	Tokens    map[string]int     `json:"tokens"`
+	Truncated *tokens.Truncation `json:"truncated,omitempty"`
+}
+
+func printBudgeted(data interface{}, human, estimate bool, budget int) error {
+	var (
+		text string
+		cut  *tokens.Truncation
+	)
+	if human {
+		lines := humanLines(data)
+		if budget > 0 {
+			lines, cut = tokens.FitLines(lines, budget)
+		}
+		text = strings.Join(TerminalFor(stdout).Render(data, lines), "\n")
+		fmt.Fprintln(stdout, text)
+		writeWarnings(warnings.Take())
+	} else {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("marshal json: %w", err)
+		}
+		pending := warnings.Take()
+		if budget > 0 {
+			if encoded, cut, err = tokens.FitJSON(encoded, budget); err != nil {
+				return err
+			}
+		}
+		if cut != nil {
+			pending = append(pending, warnings.Warning{
+				Code:    warnings.OutputTruncated,
+				Message: fmt.Sprintf("output was trimmed to --max-output-tokens %d; see the truncated field", budget),
+			})
+		}
+		text = string(withWarnings(encoded, pending))
+		fmt.Fprintln(stdout, text)
+	}
+
+	if !estimate {
+		return nil
+	}
+	report := TokenEstimate{Bytes: len(text), Tokens: tokens.Estimate(text), Truncated: cut}
+	if human {
+		var parts []string
+		for _, family := range tokens.Families {
+			parts = append(parts, fmt.Sprintf("~%d %s", report.Tokens[family.Name], family.Name))
+		}
+		fmt.Fprintf(stderr, "Estimated tokens: %s (%d bytes)\n", strings.Join(parts, ", "), report.Bytes)
+		return nil
+	}
+	encoded, err := json.Marshal(map[string]TokenEstimate{"token_estimate": report})
+	if err != nil {
+		return fmt.Errorf("marshal token estimate: %w", err)
+	}
+	fmt.Fprintln(stderr, string(encoded))
+	return nil
+}
+
 func printJSON(data interface{}) error {
 	// Default to minified JSON for machine efficiency (pipe-friendly)
 	encoded, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("marshal json: %w", err)
 	}
-	fmt.Println(string(encoded))
+	fmt.Fprintln(stdout, string(withWarnings(encoded, warnings.Take())))
 	return nil
 }
 
 func printHuman(data interface{}) error {
+	for _, line := range TerminalFor(stdout).Render(data, humanLines(data)) {
+		fmt.Fprintln(stdout, line)
+	}
+	writeWarnings(warnings.Take())
+	return nil
+}
+
+// withWarnings appends a "warnings" array to an encoded JSON object, keeping its field
+// order. Output that is not an object, or already has a warnings field, is returned
+// unchanged and the warnings go to stderr.
+func withWarnings(encoded []byte, pending []warnings.Warning) []byte {
+	if len(pending) == 0 {
+		return encoded
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(encoded, &fields) != nil || fields == nil || fields["warnings"] != nil {
+		writeWarnings(pending)
+		return encoded
+	}
+	list, err := json.Marshal(pending)
+	if err != nil {
+		writeWarnings(pending)
+		return encoded
+	}
+	trimmed := bytes.TrimRight(encoded, " \n")
+	out := append([]byte(nil), trimmed[:len(trimmed)-1]...)
+	if len(fields) > 0 {
+		out = append(out, ',')
+	}
+	out = append(out, `"warnings":`...)
+	out = append(out, list...)
+	return append(out, '}')
+}
+
+// FlushWarnings writes warnings no output picked up, such as those raised by a command
+// that then failed, to stderr.
+func FlushWarnings() {
+	writeWarnings(warnings.Take())
+}
+
+func writeWarnings(pending []warnings.Warning) {
+	for _, w := range pending {
+		Warnf("%s", w.Message)
+	}
+}
+
+func humanLines(data interface{}) []string {
 	switch v := data.(type) {
 	case Printable:
-		for _, line := range v.Lines() {
-			fmt.Println(line)
-		}
-		return nil
+		return v.Lines()
 	case fmt.Stringer:
-		fmt.Println(v.String())
-		return nil
+		return []string{v.String()}
 	default:
-		fmt.Printf("%v\n", v)
-		return nil
+		return []string{fmt.Sprintf("%v", v)}
 	}
 }
 
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
+)
+
+func TestWithWarnings(t *testing.T) {
+	pending := []warnings.Warning{{Code: warnings.CachePartial, Message: "channel cache is partial"}}
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"object keeps field order", `{"b":1,"a":2}`, `{"b":1,"a":2,"warnings":[{"code":"cache_partial","message":"channel cache is partial"}]}`},
+		{"empty object", `{}`, `{"warnings":[{"code":"cache_partial","message":"channel cache is partial"}]}`},
+		{"existing warnings field", `{"warnings":["x"]}`, `{"warnings":["x"]}`},
+		{"array", `[1,2]`, `[1,2]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(withWarnings([]byte(tt.in), pending)); got != tt.want {
+				t.Errorf("withWarnings() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if got := string(withWarnings([]byte(`{"a":1}`), nil)); got != `{"a":1}` {
+		t.Errorf("withWarnings() without warnings = %s", got)
+	}
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"fmt"
+	"io"
+)
+
+// quiet is set by --quiet; see SetQuiet.
+var quiet bool
+
+// SetQuiet turns status messages and warnings on stderr off (or back on). Errors,
+// prompts a command cannot work without, and output requested explicitly (--stats,
+// --estimate-tokens) are still written.
+func SetQuiet(q bool) {
+	quiet = q
+}
+
+// Quiet reports whether --quiet is in effect.
+func Quiet() bool {
+	return quiet
+}
+
+// Statusf writes a progress or status message to stderr unless --quiet is set.
+func Statusf(format string, args ...interface{}) {
+	if quiet {
+		return
+	}
+	fmt.Fprintf(stderr, format, args...)
+}
+
+// Warnf writes a "Warning: ..." line to stderr unless --quiet is set.
+func Warnf(format string, args ...interface{}) {
+	if quiet {
+		return
+	}
+	fmt.Fprintln(stderr, TerminalFor(stderr).colorize("Warning: "+fmt.Sprintf(format, args...)))
+}
+
+// StatusWriter returns where progress output goes: Stderr, or io.Discard under
+// --quiet.
+func StatusWriter() io.Writer {
+	if quiet {
+		return io.Discard
+	}
+	return stderr
+}
@@ -0,0 +1,21 @@
+package output
+
+import (
+	"io"
+	"testing"
+)
+
+func TestStatusWriterQuiet(t *testing.T) {
+	t.Cleanup(func() { SetQuiet(false) })
+
+	if w := StatusWriter(); w != Stderr() {
+		t.Fatalf("StatusWriter() = %v, want Stderr()", w)
+	}
+	SetQuiet(true)
+	if !Quiet() {
+		t.Fatal("Quiet() = false after SetQuiet(true)")
+	}
+	if w := StatusWriter(); w != io.Discard {
+		t.Fatalf("StatusWriter() under --quiet = %v, want io.Discard", w)
+	}
+}
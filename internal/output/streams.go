@@ -0,0 +1,53 @@
+package output
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// The process's output streams. Everything slk prints goes through them: command
+// results (Print, streamed events, rendered transcripts) to Stdout, and errors,
+// status, and warnings to Stderr. This is the only file that names os.Stdout and
+// os.Stderr; TestStdioContract in the module root enforces that.
+var (
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// Stdout returns the writer for command output: data a caller may parse.
+func Stdout() io.Writer {
+	return stdout
+}
+
+// Stderr returns the writer for everything that is not data: errors, prompts, status,
+// and warnings. Prefer Statusf and Warnf, which honor --quiet.
+func Stderr() io.Writer {
+	return stderr
+}
+
+// SetStreams redirects Stdout and Stderr, for tests, and returns a func restoring the
+// previous writers.
+func SetStreams(out, errOut io.Writer) (restore func()) {
+	prevOut, prevErr := stdout, stderr
+	stdout, stderr = out, errOut
+	return func() {
+		stdout, stderr = prevOut, prevErr
+	}
+}
+
+// IsTerminal reports whether w is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// TerminalFor is DetectTerminal for a stream. Writers that are not files, such as
+// buffers in tests, are never wrapped and are only colored with --color always.
+func TerminalFor(w io.Writer) Terminal {
+	if f, ok := w.(*os.File); ok {
+		return DetectTerminal(f)
+	}
+	return Terminal{Color: colorMode == ColorAlways}
+}
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
+	"github.com/spf13/cobra"
+)
+
+func TestStreamsSeparateDataFromStatus(t *testing.T) {
+	var out, errOut bytes.Buffer
+	t.Cleanup(SetStreams(&out, &errOut))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("human", false, "")
+	cmd.Flags().Set("human", "true")
+
+	Statusf("Fetching channels...\n")
+	warnings.Add(warnings.CachePartial, "channel cache is partial")
+	if err := Print(cmd, ListFormatter{LinesData: []string{"#general"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := out.String(), "#general\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if got, want := errOut.String(), "Fetching channels...\nWarning: channel cache is partial\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
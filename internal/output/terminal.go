@@ -0,0 +1,135 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Color modes accepted by --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ANSI escapes for the status markers in human output.
+const (
+	ansiReset  = "\033[0m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+)
+
+// Terminal describes where human output goes: how wide it may be and whether it may
+// use color. Width 0 means no limit, as for pipes and files.
+type Terminal struct {
+	Width int
+	Color bool
+}
+
+// colorMode is the --color mode for this run, set by SetColorMode.
+var colorMode = ColorAuto
+
+// SetColorMode sets the color mode DetectTerminal applies; see ColorMode.
+func SetColorMode(mode string) {
+	colorMode = mode
+}
+
+// DetectTerminal inspects f and the environment. Output is only wrapped when f is a
+// terminal, whose detected width $COLUMNS overrides. In auto mode it is only colored
+// when f is a terminal and neither NO_COLOR nor TERM=dumb is set.
+func DetectTerminal(f *os.File) Terminal {
+	isTTY := term.IsTerminal(int(f.Fd()))
+	var t Terminal
+	if isTTY {
+		if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+			t.Width = cols
+		} else if cols, _, err := term.GetSize(int(f.Fd())); err == nil {
+			t.Width = cols
+		}
+	}
+	switch colorMode {
+	case ColorAlways:
+		t.Color = true
+	case ColorNever:
+		t.Color = false
+	default:
+		t.Color = isTTY && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+	}
+	return t
+}
+
+// ColorMode returns the --color mode selected by cmd's flags; --no-color wins over
+// --color.
+func ColorMode(cmd *cobra.Command) (string, error) {
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor {
+		return ColorNever, nil
+	}
+	mode, _ := cmd.Flags().GetString("color")
+	switch mode {
+	case "", ColorAuto:
+		return ColorAuto, nil
+	case ColorAlways, ColorNever:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid --color %q (want auto, always, or never)", mode)
+}
+
+// Ellipsizer is implemented by Printables whose lines are table rows. In a narrow
+// terminal their rows are cut with "..." instead of wrapped, so columns stay aligned.
+type Ellipsizer interface {
+	Printable
+	Ellipsize() bool
+}
+
+// Render fits lines to the terminal: long lines are wrapped, or ellipsized for an
+// Ellipsizer, and status markers are colored when color is on.
+func (t Terminal) Render(data interface{}, lines []string) []string {
+	ellipsize := false
+	if e, ok := data.(Ellipsizer); ok {
+		ellipsize = e.Ellipsize()
+	}
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		var fitted []string
+		switch {
+		case t.Width <= 0:
+			fitted = []string{line}
+		case ellipsize:
+			fitted = []string{Ellipsize(line, t.Width)}
+		default:
+			fitted = Wrap(line, t.Width)
+		}
+		for _, l := range fitted {
+			out = append(out, t.colorize(l))
+		}
+	}
+	return out
+}
+
+// colorize colors a line's leading status marker: ✓ green, ✗ red, and ⚠ or
+// "Warning:" yellow.
+func (t Terminal) colorize(line string) string {
+	if !t.Color {
+		return line
+	}
+	body := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(body)]
+	for _, m := range []struct{ marker, color string }{
+		{"✓", ansiGreen},
+		{"✗", ansiRed},
+		{"⚠\ufe0f", ansiYellow},
+		{"⚠", ansiYellow},
+		{"Warning:", ansiYellow},
+	} {
+		if strings.HasPrefix(body, m.marker) {
+			return indent + m.color + m.marker + ansiReset + body[len(m.marker):]
+		}
+	}
+	return line
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+type tableRows []string
+
+func (r tableRows) Lines() []string { return r }
+func (r tableRows) Ellipsize() bool { return true }
+
+func TestTerminalRender(t *testing.T) {
+	lines := []string{"✓ Sent message to #general with a long trailing note", "  ✗ failed"}
+	tests := []struct {
+		name string
+		term Terminal
+		data interface{}
+		want []string
+	}{
+		{"pipe", Terminal{}, ListFormatter{LinesData: lines}, lines},
+		{"wrap", Terminal{Width: 24}, ListFormatter{LinesData: lines},
+			[]string{"✓ Sent message to", "  #general with a long", "  trailing note", "  ✗ failed"}},
+		{"ellipsize", Terminal{Width: 24}, tableRows(lines), []string{"✓ Sent message to #ge...", "  ✗ failed"}},
+		{"color", Terminal{Color: true}, ListFormatter{LinesData: lines},
+			[]string{ansiGreen + "✓" + ansiReset + " Sent message to #general with a long trailing note", "  " + ansiRed + "✗" + ansiReset + " failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.term.Render(tt.data, lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectTerminalPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	t.Setenv("COLUMNS", "40")
+	t.Setenv("NO_COLOR", "")
+
+	defer SetColorMode(ColorAuto)
+	for _, tt := range []struct {
+		mode string
+		want Terminal
+	}{
+		{ColorAuto, Terminal{}},
+		{ColorAlways, Terminal{Color: true}},
+		{ColorNever, Terminal{}},
+	} {
+		SetColorMode(tt.mode)
+		if got := DetectTerminal(w); got != tt.want {
+			t.Errorf("DetectTerminal(pipe) with --color=%s = %+v, want %+v", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestColorMode(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{nil, ColorAuto, false},
+		{[]string{"--color=always"}, ColorAlways, false},
+		{[]string{"--color", "never"}, ColorNever, false},
+		{[]string{"--color=always", "--no-color"}, ColorNever, false},
+		{[]string{"--color=sometimes"}, "", true},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{Use: "slk"}
+		cmd.Flags().String("color", ColorAuto, "")
+		cmd.Flags().Bool("no-color", false, "")
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ColorMode(cmd)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ColorMode(%v) = %q, %v; want %q, error %v", tt.args, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Package testkit snapshots Printable output against golden files, so a change to any
+// Lines() implementation shows up as a reviewable diff.
+//
+// Golden files live in the calling package's testdata directory as <name>.golden.
+// After an intended output change, regenerate them and review the diff:
+//
+//	go test ./internal/slack -update
+//	SLK_UPDATE_GOLDEN=1 go test ./...
+//
+// Importing testkit pins time.Local to UTC so snapshots do not depend on the machine's
+// time zone.
+package testkit
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+)
+
+// UpdateEnv regenerates golden files in every package when set, where -update only
+// reaches packages given on the command line.
+const UpdateEnv = "SLK_UPDATE_GOLDEN"
+
+var update = flag.Bool("update", false, "rewrite golden files under testdata/ with the current output")
+
+func init() {
+	time.Local = time.UTC
+}
+
+// Updating reports whether golden files are being regenerated.
+func Updating() bool {
+	return *update || os.Getenv(UpdateEnv) != ""
+}
+
+// Lines snapshots p.Lines() in testdata/<name>.golden, one line per line.
+func Lines(t testing.TB, name string, p output.Printable) {
+	t.Helper()
+	Golden(t, name, []byte(strings.Join(p.Lines(), "\n")+"\n"))
+}
+
+// Golden compares got with testdata/<name>.golden, or rewrites the file when updating.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if Updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create testdata: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file: %v (run go test with -update to create it)", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s differs from the golden file (run go test with -update if the change is intended):\n%s", path, diff(string(want), string(got)))
+	}
+}
+
+// diff describes the first differing line of want and got.
+func diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g || i >= len(wantLines) || i >= len(gotLines) {
+			return fmt.Sprintf("line %d:\n  want: %q\n  got:  %q", i+1, w, g)
+		}
+	}
+	return "files differ only in trailing bytes"
+}
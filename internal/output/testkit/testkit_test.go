@@ -0,0 +1,14 @@
+package testkit
+
+import "testing"
+
+func TestDiffReportsFirstDifferingLine(t *testing.T) {
+	got := diff("a\nb\nc\n", "a\nx\nc\n")
+	want := "line 2:\n  want: \"b\"\n  got:  \"x\""
+	if got != want {
+		t.Errorf("diff() = %q, want %q", got, want)
+	}
+	if got := diff("a\n", "a\nb\n"); got != "line 2:\n  want: \"\"\n  got:  \"b\"" {
+		t.Errorf("diff() for an added line = %q", got)
+	}
+}
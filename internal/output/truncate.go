@@ -0,0 +1,119 @@
+package output
+
+import "unicode"
+
+// Truncate shortens s to at most n characters for human output, ending it with "..."
+// when cut. A character is a grapheme cluster, so accented letters, emoji with skin
+// tones or ZWJ sequences, and flags are never split.
+func Truncate(s string, n int) string {
+	if n <= 3 {
+		cut, _ := CutGraphemes(s, n)
+		return cut
+	}
+	if GraphemeCount(s) <= n {
+		return s
+	}
+	cut, _ := CutGraphemes(s, n-3)
+	return cut + "..."
+}
+
+// CutGraphemes returns the first n grapheme clusters of s and whether anything was
+// cut. Use it where the caller picks its own ellipsis.
+func CutGraphemes(s string, n int) (string, bool) {
+	if n <= 0 {
+		return "", s != ""
+	}
+	var g graphemes
+	count := 0
+	for i, r := range s {
+		if g.breakBefore(r) {
+			count++
+			if count > n {
+				return s[:i], true
+			}
+		}
+	}
+	return s, false
+}
+
+// GraphemeCount returns the number of grapheme clusters in s.
+func GraphemeCount(s string) int {
+	var g graphemes
+	count := 0
+	for _, r := range s {
+		if g.breakBefore(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// graphemes finds cluster boundaries with the subset of UAX #29 that shows up in
+// Slack text: combining marks, variation selectors, emoji modifiers and tags, ZWJ
+// sequences, regional indicator pairs, and CRLF. Hangul syllables are handled as
+// precomposed runes, which is how Slack sends them.
+type graphemes struct {
+	started bool
+	prev    rune
+	// regional counts the regional indicators at the end of the current cluster.
+	regional int
+}
+
+// breakBefore consumes r and reports whether a new cluster starts at it.
+func (g *graphemes) breakBefore(r rune) bool {
+	if !g.started {
+		g.started = true
+		g.prev = r
+		g.regional = boolInt(isRegional(r))
+		return true
+	}
+	extend := false
+	switch {
+	case g.prev == '\r' && r == '\n':
+		extend = true
+	case g.prev == '\r' || g.prev == '\n' || r == '\r' || r == '\n':
+		extend = false
+	case isExtend(r) || r == zwj:
+		extend = true
+	case g.prev == zwj:
+		extend = true
+	case isRegional(r) && g.regional == 1:
+		extend = true
+	}
+	if extend {
+		if isRegional(r) {
+			g.regional++
+		}
+	} else {
+		g.regional = boolInt(isRegional(r))
+	}
+	g.prev = r
+	return !extend
+}
+
+const zwj = '\u200d' // zero width joiner
+
+func isExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0100 && r <= 0xE01EF: // variation selectors
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // emoji skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // tag characters (subdivision flags)
+		return true
+	}
+	return false
+}
+
+func isRegional(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
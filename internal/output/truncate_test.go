@@ -0,0 +1,51 @@
+package output
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		n    int
+		want string
+	}{
+		{"short", "hello", 10, "hello"},
+		{"exact", "hello", 5, "hello"},
+		{"ascii", "hello world", 8, "hello..."},
+		{"multi-byte", "デプロイが完了しました", 6, "デプロ..."},
+		{"combining mark", "cafe\u0301 au lait", 7, "cafe\u0301..."},
+		{"skin tone", "hi 👋🏽👋🏽👋🏽👋🏽", 6, "hi ..."},
+		{"zwj family", "👨‍👩‍👧‍👦👨‍👩‍👧‍👦👨‍👩‍👧‍👦👨‍👩‍👧‍👦👨‍👩‍👧‍👦", 4, "👨‍👩‍👧‍👦..."},
+		{"flags", "🇯🇵🇺🇸🇫🇷🇩🇪🇬🇧", 4, "🇯🇵..."},
+		{"keycap", "1️⃣2️⃣3️⃣4️⃣5️⃣", 4, "1️⃣..."},
+		{"tiny limit", "👋🏽👋🏽👋🏽", 2, "👋🏽👋🏽"},
+		{"zero", "abc", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.in, tt.n); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"e\u0301", 1},
+		{"👨‍👩‍👧‍👦", 1},
+		{"🇯🇵🇺🇸🇫", 3},
+		{"a\r\nb", 3},
+		{"🏴󠁧󠁢󠁳󠁣󠁴󠁿", 1},
+	}
+	for _, tt := range tests {
+		if got := GraphemeCount(tt.in); got != tt.want {
+			t.Errorf("GraphemeCount(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package output
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Width returns the number of terminal columns s occupies. East Asian wide and
+// fullwidth characters and emoji take two columns; combining marks and other
+// zero-width runes extend the character before them.
+func Width(s string) int {
+	width := 0
+	forEachGrapheme(s, func(cluster string) bool {
+		width += clusterWidth(cluster)
+		return true
+	})
+	return width
+}
+
+// Ellipsize cuts s to at most cols columns, ending it with "..." when cut.
+func Ellipsize(s string, cols int) string {
+	if cols <= 0 || Width(s) <= cols {
+		return s
+	}
+	if cols <= 3 {
+		return cutColumns(s, cols)
+	}
+	return cutColumns(s, cols-3) + "..."
+}
+
+// Wrap breaks s into lines of at most cols columns, preferring to break at spaces.
+// Continuation lines keep the leading indentation of s plus two spaces, so wrapped
+// list entries stay visually grouped. Words wider than a line are split between
+// characters. cols <= 0 returns s unchanged.
+func Wrap(s string, cols int) []string {
+	if cols <= 0 || Width(s) <= cols {
+		return []string{s}
+	}
+	body := strings.TrimLeft(s, " ")
+	indent := s[:len(s)-len(body)]
+	hanging := indent + "  "
+	if Width(hanging) >= cols/2 {
+		indent, hanging = "", ""
+	}
+
+	var lines []string
+	line, width, empty := indent, Width(indent), true
+	for _, word := range strings.Fields(body) {
+		w := Width(word)
+		if !empty && width+1+w > cols {
+			lines = append(lines, line)
+			line, width, empty = hanging, Width(hanging), true
+		}
+		if !empty {
+			line += " "
+			width++
+		}
+		// Split words that do not fit on a line of their own.
+		for width+w > cols {
+			head := cutColumns(word, cols-width)
+			if head == "" {
+				head, _ = CutGraphemes(word, 1)
+			}
+			lines = append(lines, line+head)
+			word = word[len(head):]
+			w = Width(word)
+			line, width = hanging, Width(hanging)
+		}
+		if word == "" {
+			empty = true
+			continue
+		}
+		line += word
+		width += w
+		empty = false
+	}
+	return append(lines, line)
+}
+
+// cutColumns returns the longest prefix of s that fits in cols columns without
+// splitting a character.
+func cutColumns(s string, cols int) string {
+	end, width := 0, 0
+	forEachGrapheme(s, func(cluster string) bool {
+		w := clusterWidth(cluster)
+		if width+w > cols {
+			return false
+		}
+		width += w
+		end += len(cluster)
+		return true
+	})
+	return s[:end]
+}
+
+// forEachGrapheme calls fn with each grapheme cluster of s until fn returns false.
+func forEachGrapheme(s string, fn func(cluster string) bool) {
+	var g graphemes
+	start := -1
+	for i, r := range s {
+		if g.breakBefore(r) {
+			if start >= 0 && !fn(s[start:i]) {
+				return
+			}
+			start = i
+		}
+	}
+	if start >= 0 {
+		fn(s[start:])
+	}
+}
+
+func clusterWidth(cluster string) int {
+	first := []rune(cluster)[0]
+	if strings.ContainsRune(cluster, '\ufe0f') || strings.ContainsRune(cluster, zwj) {
+		// Emoji presentation and ZWJ sequences render as one wide glyph.
+		return 2
+	}
+	return runeWidth(first)
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r == 0, unicode.IsControl(r), isExtend(r), r == zwj:
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports East Asian wide and fullwidth runes and emoji shown as pictographs.
+func isWide(r rune) bool {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, // Hangul Jamo initials
+		r >= 0x2E80 && r <= 0x303E, // CJK radicals, punctuation
+		r >= 0x3041 && r <= 0x33FF, // kana, CJK symbols
+		r >= 0x3400 && r <= 0x4DBF, // CJK extension A
+		r >= 0x4E00 && r <= 0x9FFF, // CJK unified ideographs
+		r >= 0xA000 && r <= 0xA4CF, // Yi
+		r >= 0xAC00 && r <= 0xD7A3, // Hangul syllables
+		r >= 0xF900 && r <= 0xFAFF, // CJK compatibility ideographs
+		r >= 0xFE30 && r <= 0xFE4F, // CJK compatibility forms
+		r >= 0xFF00 && r <= 0xFF60, // fullwidth forms
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x1F1E6 && r <= 0x1F1FF, // regional indicators
+		r >= 0x1F300 && r <= 0x1F64F, // pictographs, emoticons
+		r >= 0x1F680 && r <= 0x1F6FF, // transport and map symbols
+		r >= 0x1F900 && r <= 0x1F9FF, // supplemental symbols and pictographs
+		r >= 0x1FA70 && r <= 0x1FAFF,
+		r >= 0x20000 && r <= 0x3FFFD: // CJK extensions B and later
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"café", 4},
+		{"デプロイ", 8},
+		{"ｄｅｐｌｏｙ", 12},
+		{"ok 🚀", 5},
+		{"👍🏽", 2},
+		{"👨‍👩‍👧", 2},
+		{"🇯🇵", 2},
+		{"⚠️ done", 7},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.in); got != tt.want {
+			t.Errorf("Width(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEllipsize(t *testing.T) {
+	tests := []struct {
+		in   string
+		cols int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"hello world", 8, "hello..."},
+		{"デプロイ完了", 8, "デプ..."},
+		{"デプロイ完了", 9, "デプロ..."},
+		{"abc", 0, "abc"},
+		{"abcdef", 2, "ab"},
+	}
+	for _, tt := range tests {
+		if got := Ellipsize(tt.in, tt.cols); got != tt.want {
+			t.Errorf("Ellipsize(%q, %d) = %q, want %q", tt.in, tt.cols, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		cols int
+		want []string
+	}{
+		{"fits", "one two", 20, []string{"one two"}},
+		{"no limit", "one two three", 0, []string{"one two three"}},
+		{"words", "the quick brown fox jumps", 10, []string{"the quick", "  brown", "  fox", "  jumps"}},
+		{"keeps indent", "  - the quick brown fox", 12, []string{"  - the", "    quick", "    brown", "    fox"}},
+		{"long word", "see https://example.com/abcdefgh", 12, []string{"see", "  https://ex", "  ample.com/", "  abcdefgh"}},
+		{"wide runes", "デプロイ完了 しました", 10, []string{"デプロイ完", "  了", "  しました"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Wrap(tt.in, tt.cols)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.in, tt.cols, got, tt.want)
+			}
+			if tt.cols > 0 {
+				for _, line := range got {
+					if Width(line) > tt.cols {
+						t.Errorf("line %q is %d columns, over %d", line, Width(line), tt.cols)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package pins
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *SyncResult
+	}{
+		{"SyncResult", &SyncResult{OK: true, Channel: "#runbooks", ChannelID: "C1",
+			Added: []string{"1700000000.000100"}, Removed: []string{"1690000000.000100", "1690000001.000100"}, Unchanged: []string{"1680000000.000100"}}},
+		{"SyncResult_dry_run", &SyncResult{OK: true, Channel: "#runbooks", ChannelID: "C1", DryRun: true, Added: []string{"1700000000.000100"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
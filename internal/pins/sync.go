@@ -0,0 +1,166 @@
+// Package pins converges a channel's pinned messages to a declared list, so automation
+// can keep runbook links pinned without tracking what it pinned before.
+package pins
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+	"gopkg.in/yaml.v3"
+)
+
+var timestampPattern = regexp.MustCompile(`^\d+\.\d+$`)
+
+// Spec is the pins file read by pins sync.
+type Spec struct {
+	Pins []Entry `yaml:"pins" json:"pins"`
+}
+
+// Entry is one message that should stay pinned. Exactly one of Permalink and TS is
+// set; a bare string in the file is read as a permalink if it is a URL and as a
+// timestamp otherwise.
+type Entry struct {
+	Permalink string `yaml:"permalink,omitempty" json:"permalink,omitempty"`
+	TS        string `yaml:"ts,omitempty" json:"ts,omitempty"`
+	// Note is free text for the reader of the file; it is not sent to Slack.
+	Note string `yaml:"note,omitempty" json:"note,omitempty"`
+}
+
+// UnmarshalYAML accepts either a mapping or a bare permalink or timestamp.
+func (e *Entry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		value := strings.TrimSpace(node.Value)
+		if strings.Contains(value, "://") {
+			e.Permalink = value
+		} else {
+			e.TS = value
+		}
+		return nil
+	}
+	type plain Entry
+	return node.Decode((*plain)(e))
+}
+
+// LoadSpec reads and validates a YAML (or JSON) pins file.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read pins file: %w", err)
+	}
+	return ParseSpec(data)
+}
+
+// ParseSpec parses and validates a pins file. An empty list is allowed and unpins
+// every message in the channel.
+func ParseSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse pins file: %w", err)
+	}
+	for i, entry := range spec.Pins {
+		switch {
+		case entry.Permalink != "" && entry.TS != "":
+			return nil, fmt.Errorf("pins[%d]: set either permalink or ts, not both", i)
+		case entry.Permalink != "":
+			if _, _, err := slack.ParsePermalink(entry.Permalink); err != nil {
+				return nil, fmt.Errorf("pins[%d]: %w", i, err)
+			}
+		case entry.TS != "":
+			if !timestampPattern.MatchString(entry.TS) {
+				return nil, fmt.Errorf("pins[%d]: invalid ts %q", i, entry.TS)
+			}
+		default:
+			return nil, fmt.Errorf("pins[%d]: permalink or ts is required", i)
+		}
+	}
+	return &spec, nil
+}
+
+// Timestamps returns the message timestamps the spec pins in channelID, in file
+// order without duplicates. A permalink to another channel is an error.
+func (s *Spec) Timestamps(channelID string) ([]string, error) {
+	seen := map[string]bool{}
+	var out []string
+	for i, entry := range s.Pins {
+		ts := entry.TS
+		if entry.Permalink != "" {
+			linkChannel, linkTS, err := slack.ParsePermalink(entry.Permalink)
+			if err != nil {
+				return nil, fmt.Errorf("pins[%d]: %w", i, err)
+			}
+			if linkChannel != channelID {
+				return nil, fmt.Errorf("pins[%d]: permalink points to %s, not %s", i, linkChannel, channelID)
+			}
+			ts = linkTS
+		}
+		if !seen[ts] {
+			seen[ts] = true
+			out = append(out, ts)
+		}
+	}
+	return out, nil
+}
+
+// Plan is the set of changes that converges the pinned messages.
+type Plan struct {
+	Add       []string
+	Remove    []string
+	Unchanged []string
+}
+
+// Diff compares the desired timestamps with those currently pinned. Additions keep
+// the desired order; removals are sorted oldest first.
+func Diff(desired, current []string) Plan {
+	pinned := map[string]bool{}
+	for _, ts := range current {
+		pinned[ts] = true
+	}
+	wanted := map[string]bool{}
+	var plan Plan
+	for _, ts := range desired {
+		wanted[ts] = true
+		if pinned[ts] {
+			plan.Unchanged = append(plan.Unchanged, ts)
+		} else {
+			plan.Add = append(plan.Add, ts)
+		}
+	}
+	for ts := range pinned {
+		if !wanted[ts] {
+			plan.Remove = append(plan.Remove, ts)
+		}
+	}
+	sort.Strings(plan.Remove)
+	return plan
+}
+
+// SyncResult reports what pins sync changed, or would change with --dry-run.
+type SyncResult struct {
+	OK        bool     `json:"ok"`
+	Channel   string   `json:"channel"`
+	ChannelID string   `json:"channel_id"`
+	DryRun    bool     `json:"dry_run,omitempty"`
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Unchanged []string `json:"unchanged"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *SyncResult) Lines() []string {
+	verb := "Synced"
+	if r.DryRun {
+		verb = "Would sync"
+	}
+	lines := []string{fmt.Sprintf("%s pins in %s: %d added, %d removed, %d unchanged", verb, r.Channel, len(r.Added), len(r.Removed), len(r.Unchanged))}
+	for _, ts := range r.Added {
+		lines = append(lines, "  + "+ts)
+	}
+	for _, ts := range r.Removed {
+		lines = append(lines, "  - "+ts)
+	}
+	return lines
+}
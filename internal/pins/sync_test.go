@@ -0,0 +1,65 @@
+package pins
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec([]byte(`
+pins:
+  - permalink: https://acme.slack.com/archives/C0123/p1705312365000100
+    note: Incident runbook
+  - ts: "1705312400.000200"
+  - https://acme.slack.com/archives/C0123/p1705312500000300
+  - "1705312365.000100"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Pins) != 4 || spec.Pins[0].Note != "Incident runbook" || spec.Pins[2].Permalink == "" || spec.Pins[3].TS == "" {
+		t.Fatalf("unexpected spec: %+v", spec.Pins)
+	}
+
+	got, err := spec.Timestamps("C0123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"1705312365.000100", "1705312400.000200", "1705312500.000300"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Timestamps = %v, want %v", got, want)
+	}
+	if _, err := spec.Timestamps("C9999"); err == nil || !strings.Contains(err.Error(), "points to C0123") {
+		t.Fatalf("expected channel mismatch error, got %v", err)
+	}
+}
+
+func TestParseSpecRejectsBadEntries(t *testing.T) {
+	for _, data := range []string{
+		"pins:\n  - note: nothing\n",
+		"pins:\n  - ts: \"1705312365.000100\"\n    permalink: https://acme.slack.com/archives/C1/p1705312365000100\n",
+		"pins:\n  - ts: yesterday\n",
+		"pins:\n  - https://acme.slack.com/archives/C1\n",
+	} {
+		if _, err := ParseSpec([]byte(data)); err == nil {
+			t.Errorf("ParseSpec(%q) succeeded, want error", data)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	plan := Diff(
+		[]string{"3.0", "1.0", "4.0"},
+		[]string{"5.0", "1.0", "2.0"},
+	)
+	if !reflect.DeepEqual(plan.Add, []string{"3.0", "4.0"}) {
+		t.Errorf("Add = %v", plan.Add)
+	}
+	if !reflect.DeepEqual(plan.Remove, []string{"2.0", "5.0"}) {
+		t.Errorf("Remove = %v", plan.Remove)
+	}
+	if !reflect.DeepEqual(plan.Unchanged, []string{"1.0"}) {
+		t.Errorf("Unchanged = %v", plan.Unchanged)
+	}
+}
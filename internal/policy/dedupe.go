@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+)
+
+// DedupePolicy is the Decision.Policy value for the dedupe window.
+const DedupePolicy = "dedupe"
+
+// ActionWarn lets a command run while reporting that it broke a policy.
+const ActionWarn = "warn"
+
+// DedupeRequest describes a send to check against the dedupe window.
+type DedupeRequest struct {
+	Window time.Duration
+	// Action is config.DedupeActionReject or config.DedupeActionWarn.
+	Action string
+	// LastTS and LastAt identify the most recent identical post, if any.
+	LastTS string
+	LastAt time.Time
+	// Override is set by --allow-duplicate.
+	Override bool
+	Now      time.Time
+}
+
+// Dedupe checks a send against the most recent identical post. A zero window or no
+// earlier post within it always allows the send.
+func Dedupe(req DedupeRequest) Decision {
+	decision := Decision{Policy: DedupePolicy, Action: ActionAllow, Reason: "no identical message in the window"}
+	if req.Window <= 0 {
+		decision.Reason = "no dedupe window configured"
+		return decision
+	}
+	decision.Window = req.Window.String()
+	if req.LastAt.IsZero() || req.Now.Sub(req.LastAt) >= req.Window {
+		return decision
+	}
+	decision.DuplicateOf = req.LastTS
+	age := req.Now.Sub(req.LastAt).Round(time.Second)
+	next := req.LastAt.Add(req.Window)
+	decision.NextAllowed = &next
+	switch {
+	case req.Override:
+		decision.Action = ActionOverride
+		decision.Reason = fmt.Sprintf("identical message sent %s ago; overridden with --allow-duplicate", age)
+	case req.Action == config.DedupeActionWarn:
+		decision.Action = ActionWarn
+		decision.Reason = fmt.Sprintf("identical message sent %s ago", age)
+	default:
+		decision.Action = ActionReject
+		decision.Reason = fmt.Sprintf("identical message sent %s ago", age)
+	}
+	return decision
+}
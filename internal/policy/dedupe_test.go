@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+)
+
+func TestDedupe(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		req        DedupeRequest
+		wantAction string
+	}{
+		{"no window", DedupeRequest{LastAt: now.Add(-time.Minute), LastTS: "1.0"}, ActionAllow},
+		{"no earlier post", DedupeRequest{Window: 5 * time.Minute}, ActionAllow},
+		{"outside window", DedupeRequest{Window: 5 * time.Minute, LastAt: now.Add(-6 * time.Minute), LastTS: "1.0"}, ActionAllow},
+		{"inside window", DedupeRequest{Window: 5 * time.Minute, LastAt: now.Add(-time.Minute), LastTS: "1.0"}, ActionReject},
+		{"warn", DedupeRequest{Window: 5 * time.Minute, Action: config.DedupeActionWarn, LastAt: now.Add(-time.Minute), LastTS: "1.0"}, ActionWarn},
+		{"override", DedupeRequest{Window: 5 * time.Minute, Override: true, LastAt: now.Add(-time.Minute), LastTS: "1.0"}, ActionOverride},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Now = now
+			got := Dedupe(tt.req)
+			if got.Action != tt.wantAction {
+				t.Fatalf("Action = %q, want %q (%s)", got.Action, tt.wantAction, got.Reason)
+			}
+			if tt.wantAction == ActionAllow {
+				if got.DuplicateOf != "" || got.NextAllowed != nil {
+					t.Fatalf("allowed decision = %+v", got)
+				}
+				return
+			}
+			if got.DuplicateOf != "1.0" || !got.NextAllowed.Equal(now.Add(4*time.Minute)) {
+				t.Fatalf("DuplicateOf = %q, NextAllowed = %v", got.DuplicateOf, got.NextAllowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	next := time.Date(2026, 3, 2, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		name string
+		p    Decision
+	}{
+		{"Decision_allow", Decision{Policy: QuietHoursPolicy, Action: ActionAllow, Reason: "no quiet hours configured"}},
+		{"Decision_queue", Decision{Policy: QuietHoursPolicy, Action: ActionQueue, Reason: "outside 09:00-18:00 Mon-Fri", NextAllowed: &next}},
+		{"Decision_duplicate", Decision{Policy: "dedupe", Action: ActionReject, Reason: "identical message within 10m", DuplicateOf: "1700000000.000100"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
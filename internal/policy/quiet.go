@@ -0,0 +1,137 @@
+// Package policy evaluates the configured rules that limit what the CLI may do on the
+// user's behalf. Commands ask for a Decision before they change anything in Slack.
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	"github.com/kehao95/slack-agent-cli/internal/notify"
+)
+
+// QuietHoursPolicy is the Decision.Policy value for quiet hours.
+const QuietHoursPolicy = "quiet_hours"
+
+// Actions a Decision can take.
+const (
+	ActionAllow    = "allow"
+	ActionReject   = "reject"
+	ActionQueue    = "queue"
+	ActionOverride = "override"
+)
+
+// Decision is the outcome of a policy check.
+type Decision struct {
+	Policy   string `json:"policy"`
+	Action   string `json:"action"`
+	Reason   string `json:"reason"`
+	Channel  string `json:"channel,omitempty"`
+	Window   string `json:"window,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	// DuplicateOf is the ts of the earlier identical message, for the dedupe policy.
+	DuplicateOf string `json:"duplicate_of,omitempty"`
+	// NextAllowed is when the window next opens, for rejected and queued commands.
+	NextAllowed *time.Time `json:"next_allowed,omitempty"`
+}
+
+// Blocked reports whether the command must not run now.
+func (d Decision) Blocked() bool {
+	return d.Action == ActionReject || d.Action == ActionQueue
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (d Decision) Lines() []string {
+	line := fmt.Sprintf("Policy %s: %s (%s)", d.Policy, d.Action, d.Reason)
+	if d.DuplicateOf != "" {
+		line += "; duplicate of " + d.DuplicateOf
+	}
+	if d.NextAllowed != nil {
+		line += "; next allowed " + d.NextAllowed.Format(time.RFC3339)
+	}
+	return []string{line}
+}
+
+// QuietHoursRequest describes a mutating command to check.
+type QuietHoursRequest struct {
+	// Keys are the channel ID and #name the command targets; the first key with a
+	// channel override wins. Empty for commands without a channel.
+	Keys []string
+	// CanQueue is set when the command can be deferred as a scheduled message.
+	CanQueue bool
+	// Override is set by --override-quiet-hours.
+	Override bool
+	Now      time.Time
+}
+
+// QuietHours checks a command against the configured quiet hours. A nil config, or a
+// window with no Allowed range, always allows the command.
+func QuietHours(cfg *config.QuietHours, req QuietHoursRequest) (Decision, error) {
+	decision := Decision{Policy: QuietHoursPolicy, Action: ActionAllow, Reason: "no quiet hours configured"}
+	if cfg == nil {
+		return decision, nil
+	}
+	window, key := effectiveWindow(cfg, req.Keys)
+	decision.Channel = key
+	if window.Allowed == "" {
+		if key != "" {
+			decision.Reason = "no quiet hours for this channel"
+		}
+		return decision, nil
+	}
+
+	hours, err := notify.ParseWorkingHours(window.Allowed, window.Weekends)
+	if err != nil {
+		return decision, fmt.Errorf("quiet_hours: %w", err)
+	}
+	loc := time.Local
+	if window.Timezone != "" {
+		if loc, err = time.LoadLocation(window.Timezone); err != nil {
+			return decision, fmt.Errorf("quiet_hours.timezone: %w", err)
+		}
+	}
+	decision.Window = window.Allowed
+	decision.Timezone = loc.String()
+
+	now := req.Now.In(loc)
+	next := hours.Next(now)
+	if next.Equal(now) {
+		decision.Reason = "inside allowed hours"
+		return decision, nil
+	}
+	decision.NextAllowed = &next
+	switch {
+	case req.Override:
+		decision.Action = ActionOverride
+		decision.Reason = "outside allowed hours; overridden with --override-quiet-hours"
+	case window.Action == config.QuietActionQueue && req.CanQueue:
+		decision.Action = ActionQueue
+		decision.Reason = "outside allowed hours; queued for the next window"
+	default:
+		decision.Action = ActionReject
+		decision.Reason = "outside allowed hours"
+	}
+	return decision, nil
+}
+
+// effectiveWindow merges the first matching channel override onto the global window.
+// It returns the key that matched, or "" for the global window.
+func effectiveWindow(cfg *config.QuietHours, keys []string) (config.QuietWindow, string) {
+	window := cfg.QuietWindow
+	for _, key := range keys {
+		override, ok := cfg.Channels[key]
+		if !ok {
+			continue
+		}
+		window.Allowed = override.Allowed
+		window.Weekends = override.Weekends
+		if override.Timezone != "" {
+			window.Timezone = override.Timezone
+		}
+		if override.Action != "" {
+			window.Action = override.Action
+		}
+		return window, key
+	}
+	return window, ""
+}
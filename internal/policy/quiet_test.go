@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+)
+
+func TestQuietHours(t *testing.T) {
+	cfg := &config.QuietHours{
+		QuietWindow: config.QuietWindow{Allowed: "09:00-18:00", Timezone: "UTC"},
+		Channels: map[string]config.QuietWindow{
+			"#alerts":  {},
+			"C0QUEUED": {Allowed: "10:00-12:00", Action: config.QuietActionQueue},
+		},
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.January, day, hour, 0, 0, 0, time.UTC) // Jan 15 2024 is a Monday
+	}
+
+	tests := []struct {
+		name       string
+		req        QuietHoursRequest
+		wantAction string
+		wantNext   time.Time
+		wantKey    string
+	}{
+		{name: "inside window", req: QuietHoursRequest{Now: at(15, 10)}, wantAction: ActionAllow},
+		{name: "evening", req: QuietHoursRequest{Now: at(15, 20)}, wantAction: ActionReject, wantNext: at(16, 9)},
+		{name: "weekend", req: QuietHoursRequest{Now: at(20, 10)}, wantAction: ActionReject, wantNext: at(22, 9)},
+		{name: "override", req: QuietHoursRequest{Now: at(15, 20), Override: true}, wantAction: ActionOverride, wantNext: at(16, 9)},
+		{name: "queue needs a queueable command", req: QuietHoursRequest{Now: at(15, 20), CanQueue: true}, wantAction: ActionReject, wantNext: at(16, 9)},
+		{name: "channel lifts restriction", req: QuietHoursRequest{Now: at(15, 20), Keys: []string{"C0ALERTS", "#alerts"}}, wantAction: ActionAllow, wantKey: "#alerts"},
+		{
+			name:       "channel window queues",
+			req:        QuietHoursRequest{Now: at(15, 13), Keys: []string{"C0QUEUED"}, CanQueue: true},
+			wantAction: ActionQueue, wantNext: at(16, 10), wantKey: "C0QUEUED",
+		},
+		{
+			name:       "channel queue falls back to reject",
+			req:        QuietHoursRequest{Now: at(15, 13), Keys: []string{"C0QUEUED"}},
+			wantAction: ActionReject, wantNext: at(16, 10), wantKey: "C0QUEUED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QuietHours(cfg, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Action != tt.wantAction || got.Channel != tt.wantKey {
+				t.Fatalf("got action %q channel %q, want %q %q (%s)", got.Action, got.Channel, tt.wantAction, tt.wantKey, got.Reason)
+			}
+			if tt.wantNext.IsZero() != (got.NextAllowed == nil) {
+				t.Fatalf("NextAllowed = %v, want %v", got.NextAllowed, tt.wantNext)
+			}
+			if got.NextAllowed != nil && !got.NextAllowed.Equal(tt.wantNext) {
+				t.Fatalf("NextAllowed = %v, want %v", got.NextAllowed, tt.wantNext)
+			}
+			if got.Blocked() != (tt.wantAction == ActionReject || tt.wantAction == ActionQueue) {
+				t.Fatalf("Blocked() = %v for %s", got.Blocked(), got.Action)
+			}
+		})
+	}
+}
+
+func TestQuietHoursUnconfigured(t *testing.T) {
+	got, err := QuietHours(nil, QuietHoursRequest{Now: time.Now()})
+	if err != nil || got.Action != ActionAllow {
+		t.Fatalf("got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,233 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BudgetStateVersion is the current budget state file format.
+const BudgetStateVersion = 1
+
+// Groups maps each budget group to the Slack API methods it covers.
+var Groups = map[string][]string{
+	"search":  {"search.messages", "search.files", "search.all"},
+	"history": {"conversations.history", "conversations.replies"},
+}
+
+// GroupNames returns the budget group names in a stable order.
+func GroupNames() []string {
+	names := make([]string, 0, len(Groups))
+	for name := range Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GroupOf returns the budget group of an API method, or "" when no group covers it.
+func GroupOf(method string) string {
+	for name, methods := range Groups {
+		for _, m := range methods {
+			if m == method {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// Budget allows Calls API calls in any window of length Per.
+type Budget struct {
+	Calls int
+	Per   time.Duration
+}
+
+// budgetUnits are the window names accepted after the slash in ParseBudget.
+var budgetUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "second": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hour": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour,
+}
+
+// ParseBudget parses a budget such as "10/min", "500/hour", or "40/5m".
+func ParseBudget(s string) (Budget, error) {
+	calls, per, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Budget{}, fmt.Errorf("budget %q must look like 10/min", s)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(calls))
+	if err != nil || n <= 0 {
+		return Budget{}, fmt.Errorf("budget %q must start with a positive number of calls", s)
+	}
+	per = strings.ToLower(strings.TrimSpace(per))
+	window, ok := budgetUnits[per]
+	if !ok {
+		if window, err = time.ParseDuration(per); err != nil || window <= 0 {
+			return Budget{}, fmt.Errorf("budget %q must end in s, min, hour, day, or a duration such as 5m", s)
+		}
+	}
+	return Budget{Calls: n, Per: window}, nil
+}
+
+func (b Budget) String() string {
+	for _, unit := range []string{"s", "min", "hour", "day"} {
+		if budgetUnits[unit] == b.Per {
+			return fmt.Sprintf("%d/%s", b.Calls, unit)
+		}
+	}
+	return fmt.Sprintf("%d/%s", b.Calls, b.Per)
+}
+
+// ErrBudgetExhausted is returned, wrapped in an *ExhaustedError, for calls refused
+// without waiting because their group's budget is used up.
+var ErrBudgetExhausted = errors.New("API budget exhausted")
+
+// ExhaustedError describes a call refused by an exhausted budget.
+type ExhaustedError struct {
+	Group   string
+	Budget  Budget
+	RetryAt time.Time
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("%s: %v (%s); next call allowed at %s", e.Group, ErrBudgetExhausted, e.Budget, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *ExhaustedError) Unwrap() error {
+	return ErrBudgetExhausted
+}
+
+// Budgets enforces a Budget per group over a sliding window. Calls are recorded in a
+// file so separate invocations of the CLI share one budget; the file is re-read before
+// every call, but concurrent processes may still overwrite each other's updates.
+type Budgets struct {
+	path    string
+	budgets map[string]Budget
+	noWait  bool
+	now     func() time.Time
+	// OnWait, when set, is called before a call waits d for a slot in group.
+	OnWait func(group string, d time.Duration)
+
+	mu sync.Mutex
+}
+
+type budgetState struct {
+	Version int                    `json:"version"`
+	Groups  map[string][]time.Time `json:"groups"`
+}
+
+// NewBudgets enforces budgets, keyed by group, with call times kept in the file at
+// path. With noWait, a call over budget fails with an *ExhaustedError instead of
+// waiting for a slot.
+func NewBudgets(path string, budgets map[string]Budget, noWait bool) *Budgets {
+	return &Budgets{path: path, budgets: budgets, noWait: noWait, now: time.Now}
+}
+
+// Path returns the file call times are saved to.
+func (b *Budgets) Path() string {
+	return b.path
+}
+
+// Wait blocks until method's group has budget for one more call and records it. Methods
+// outside every budgeted group return at once.
+func (b *Budgets) Wait(ctx context.Context, method string) error {
+	group := GroupOf(method)
+	budget, ok := b.budgets[group]
+	if !ok {
+		return nil
+	}
+	for {
+		delay, err := b.reserve(group, budget)
+		if err != nil || delay <= 0 {
+			return err
+		}
+		if b.noWait {
+			return &ExhaustedError{Group: group, Budget: budget, RetryAt: b.now().Add(delay)}
+		}
+		if b.OnWait != nil {
+			b.OnWait(group, delay)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve records a call in group and returns 0 when the budget allows it, or how long
+// until the oldest call in the window expires.
+func (b *Budgets) reserve(group string, budget Budget) (time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, err := b.load()
+	if err != nil {
+		return 0, err
+	}
+	now := b.now()
+	var calls []time.Time
+	for _, t := range state.Groups[group] {
+		if now.Sub(t) < budget.Per {
+			calls = append(calls, t)
+		}
+	}
+	if len(calls) >= budget.Calls {
+		return calls[len(calls)-budget.Calls].Add(budget.Per).Sub(now), nil
+	}
+	state.Groups[group] = append(calls, now.UTC())
+	return 0, b.save(state)
+}
+
+func (b *Budgets) load() (budgetState, error) {
+	state := budgetState{Version: BudgetStateVersion, Groups: map[string][]time.Time{}}
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("read budget state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("parse budget state %s: %w", b.path, err)
+	}
+	if state.Groups == nil {
+		state.Groups = map[string][]time.Time{}
+	}
+	return state, nil
+}
+
+// save atomically writes the state file. The caller holds b.mu.
+func (b *Budgets) save(state budgetState) error {
+	if dir := filepath.Dir(b.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("create budget state dir: %w", err)
+		}
+	}
+	state.Version = BudgetStateVersion
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal budget state: %w", err)
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", b.path, os.Getpid())
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write budget state tmp: %w", err)
+	}
+	if err := os.Rename(tmp, b.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename budget state tmp: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseBudget(t *testing.T) {
+	for raw, want := range map[string]Budget{
+		"10/min":    {10, time.Minute},
+		"40/minute": {40, time.Minute},
+		"500/hour":  {500, time.Hour},
+		"3/5m":      {3, 5 * time.Minute},
+	} {
+		got, err := ParseBudget(raw)
+		if err != nil || got != want {
+			t.Errorf("ParseBudget(%q) = %+v, %v, want %+v", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"10", "0/min", "ten/min", "10/fortnight"} {
+		if _, err := ParseBudget(raw); err == nil {
+			t.Errorf("ParseBudget(%q) succeeded", raw)
+		}
+	}
+	if got := (Budget{10, time.Minute}).String(); got != "10/min" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestBudgetsShareStateAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budgets.json")
+	base := time.Unix(1000, 0)
+	now := base
+	budget := map[string]Budget{"search": {2, time.Minute}}
+	open := func() *Budgets {
+		b := NewBudgets(path, budget, true)
+		b.now = func() time.Time { return now }
+		return b
+	}
+
+	ctx := context.Background()
+	if err := open().Wait(ctx, "search.messages"); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	now = base.Add(10 * time.Second)
+	if err := open().Wait(ctx, "search.files"); err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+	// Methods outside every group are not counted.
+	if err := open().Wait(ctx, "chat.postMessage"); err != nil {
+		t.Fatalf("unbudgeted call: %v", err)
+	}
+
+	err := open().Wait(ctx, "search.messages")
+	var exhausted *ExhaustedError
+	if !errors.As(err, &exhausted) || !exhausted.RetryAt.Equal(base.Add(time.Minute)) {
+		t.Fatalf("third call error = %v, want exhausted until the first call expires", err)
+	}
+
+	now = base.Add(time.Minute)
+	if err := open().Wait(ctx, "search.messages"); err != nil {
+		t.Fatalf("call after the window: %v", err)
+	}
+}
+
+func TestBudgetsQueueUntilSlotFrees(t *testing.T) {
+	b := NewBudgets(filepath.Join(t.TempDir(), "budgets.json"), map[string]Budget{"history": {1, 50 * time.Millisecond}}, false)
+	var waits int
+	b.OnWait = func(group string, d time.Duration) {
+		if group != "history" || d <= 0 {
+			t.Errorf("OnWait(%q, %v)", group, d)
+		}
+		waits++
+	}
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := b.Wait(context.Background(), "conversations.history"); err != nil {
+			t.Fatalf("Wait #%d: %v", i, err)
+		}
+	}
+	if waits == 0 || time.Since(start) < 40*time.Millisecond {
+		t.Fatalf("second call did not queue (waits=%d, elapsed=%v)", waits, time.Since(start))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Wait(ctx, "conversations.replies"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait with canceled context = %v", err)
+	}
+}
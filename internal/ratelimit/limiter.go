@@ -0,0 +1,74 @@
+// Package ratelimit provides a small request pacer that lets concurrent workers share one
+// Slack API request budget.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter spaces requests evenly at a fixed interval. It is safe for concurrent use;
+// every caller reserves the next free slot, so N workers together never exceed the rate.
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	now      func() time.Time
+}
+
+// New creates a limiter that allows one request per interval. An interval <= 0 disables pacing.
+func New(interval time.Duration) *Limiter {
+	return &Limiter{interval: interval, now: time.Now}
+}
+
+// PerMinute creates a limiter allowing n requests per minute. n <= 0 disables pacing.
+func PerMinute(n int) *Limiter {
+	if n <= 0 {
+		return New(0)
+	}
+	return New(time.Minute / time.Duration(n))
+}
+
+// Interval returns the spacing between requests.
+func (l *Limiter) Interval() time.Duration {
+	return l.interval
+}
+
+// Wait blocks until the caller's reserved slot arrives or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Backoff pushes the next available slot at least d into the future, e.g. after Slack
+// answered with a Retry-After header. All workers sharing the limiter pause together.
+func (l *Limiter) Backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
+
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	return slot.Sub(now)
+}
@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReserveSpacesSlots(t *testing.T) {
+	base := time.Unix(1000, 0)
+	l := New(100 * time.Millisecond)
+	l.now = func() time.Time { return base }
+
+	want := []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i, w := range want {
+		if got := l.reserve(); got != w {
+			t.Errorf("reserve() #%d = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestBackoffDelaysAllCallers(t *testing.T) {
+	base := time.Unix(1000, 0)
+	l := New(time.Second)
+	l.now = func() time.Time { return base }
+
+	l.Backoff(30 * time.Second)
+	if got := l.reserve(); got != 30*time.Second {
+		t.Errorf("reserve() after backoff = %v, want 30s", got)
+	}
+	// A shorter backoff never moves the slot earlier.
+	l.Backoff(time.Second)
+	if got := l.reserve(); got != 31*time.Second {
+		t.Errorf("reserve() = %v, want 31s", got)
+	}
+}
+
+func TestPerMinute(t *testing.T) {
+	if got := PerMinute(60).Interval(); got != time.Second {
+		t.Errorf("PerMinute(60) interval = %v, want 1s", got)
+	}
+	if got := PerMinute(0).Interval(); got != 0 {
+		t.Errorf("PerMinute(0) interval = %v, want 0", got)
+	}
+}
+
+func TestWaitHonorsContext(t *testing.T) {
+	l := New(time.Hour)
+	l.reserve()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected context error")
+	}
+}
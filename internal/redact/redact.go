@@ -0,0 +1,196 @@
+// Package redact masks secrets in outgoing message text and Block Kit JSON, so an
+// agent cannot post credentials it picked up from logs or files. It also scrubs
+// personal data from fetched messages before they are handed to other tools.
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pattern is a named expression to mask. When the expression has a capture group,
+// only the first group is masked, so a key name such as aws_secret_access_key= stays
+// readable.
+type pattern struct {
+	Name string
+	re   *regexp.Regexp
+}
+
+var emailPattern = regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)
+
+// builtins are the patterns masked unless skipped in config.
+var builtins = []pattern{
+	{"api_key", regexp.MustCompile(`\b(?:xox[abposre]-[0-9A-Za-z-]{10,}|xapp-[0-9A-Za-z-]{10,}|sk-[A-Za-z0-9_-]{20,}|gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,}|glpat-[A-Za-z0-9_-]{20,}|AIza[0-9A-Za-z_-]{35})`)},
+	{"aws_access_key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"aws_secret_key", regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}`)},
+	{"email", emailPattern},
+}
+
+// piiPatterns are the patterns masked by NewPII. Phone numbers need separators or a
+// leading +, so timestamps and IDs are left alone.
+var piiPatterns = []pattern{
+	{"email", emailPattern},
+	{"phone", regexp.MustCompile(`\+\d{1,3}(?:[\s.-]?\(?\d{2,4}\)?){2,4}\b|(?:\(\d{3}\)|\b\d{3})[\s.-]\d{3}[\s.-]\d{4}\b`)},
+}
+
+// Builtins returns the names of the built-in patterns.
+func Builtins() []string {
+	names := make([]string, 0, len(builtins))
+	for _, p := range builtins {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// Finding counts the matches of one pattern.
+type Finding struct {
+	Pattern string `json:"pattern"`
+	Count   int    `json:"count"`
+}
+
+// Redactor masks every configured pattern.
+type Redactor struct {
+	patterns []pattern
+}
+
+// New builds a Redactor from the built-in patterns, minus those named in skip, plus
+// the extra named expressions.
+func New(skip []string, extra map[string]string) (*Redactor, error) {
+	skipped := map[string]bool{}
+	for _, name := range skip {
+		skipped[name] = true
+	}
+	r := &Redactor{}
+	for _, p := range builtins {
+		if skipped[p.Name] {
+			delete(skipped, p.Name)
+			continue
+		}
+		r.patterns = append(r.patterns, p)
+	}
+	if len(skipped) > 0 {
+		unknown := make([]string, 0, len(skipped))
+		for name := range skipped {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown built-in redaction pattern %s (choose from %s)", strings.Join(unknown, ", "), strings.Join(Builtins(), ", "))
+	}
+	return r.withExtra(extra)
+}
+
+// NewPII builds a Redactor for personal data (emails and phone numbers) plus the extra
+// named expressions.
+func NewPII(extra map[string]string) (*Redactor, error) {
+	r := &Redactor{patterns: append([]pattern(nil), piiPatterns...)}
+	return r.withExtra(extra)
+}
+
+func (r *Redactor) withExtra(extra map[string]string) (*Redactor, error) {
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		re, err := regexp.Compile(extra[name])
+		if err != nil {
+			return nil, fmt.Errorf("pattern %s: %w", name, err)
+		}
+		r.patterns = append(r.patterns, pattern{Name: name, re: re})
+	}
+	return r, nil
+}
+
+// Text masks secrets in text and reports what was masked.
+func (r *Redactor) Text(text string) (string, []Finding) {
+	counts := map[string]int{}
+	text = r.text(text, counts)
+	return text, findings(counts)
+}
+
+// JSON masks secrets in every string value of a JSON document, such as Block Kit
+// blocks. Object keys are left alone.
+func (r *Redactor) JSON(data string) (string, []Finding, error) {
+	var doc any
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		return "", nil, fmt.Errorf("redact blocks: %w", err)
+	}
+	counts := map[string]int{}
+	doc = r.walk(doc, counts)
+	if len(counts) == 0 {
+		return data, nil, nil
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return "", nil, fmt.Errorf("redact blocks: %w", err)
+	}
+	return string(out), findings(counts), nil
+}
+
+func (r *Redactor) walk(node any, counts map[string]int) any {
+	switch v := node.(type) {
+	case string:
+		return r.text(v, counts)
+	case []any:
+		for i := range v {
+			v[i] = r.walk(v[i], counts)
+		}
+	case map[string]any:
+		for key, value := range v {
+			v[key] = r.walk(value, counts)
+		}
+	}
+	return node
+}
+
+func (r *Redactor) text(text string, counts map[string]int) string {
+	for _, p := range r.patterns {
+		matches := p.re.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, m := range matches {
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			b.WriteString(text[last:start])
+			b.WriteString("[REDACTED:" + p.Name + "]")
+			last = end
+		}
+		b.WriteString(text[last:])
+		counts[p.Name] += len(matches)
+		text = b.String()
+	}
+	return text
+}
+
+func findings(counts map[string]int) []Finding {
+	if len(counts) == 0 {
+		return nil
+	}
+	out := make([]Finding, 0, len(counts))
+	for name, n := range counts {
+		out = append(out, Finding{Pattern: name, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Pattern < out[j].Pattern })
+	return out
+}
+
+// Merge combines findings from several fields.
+func Merge(lists ...[]Finding) []Finding {
+	counts := map[string]int{}
+	for _, list := range lists {
+		for _, f := range list {
+			counts[f.Pattern] += f.Count
+		}
+	}
+	return findings(counts)
+}
@@ -0,0 +1,25 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		p    TopChannelsResult
+	}{
+		{"TopChannelsResult", TopChannelsResult{Since: "24h", Scanned: 12, Channels: []ChannelActivity{
+			{ChannelID: "C1", Channel: "#incidents", Messages: 200, Sampled: true, Posters: 14, Unread: 40, UnreadMentions: 3, UnreadBroadcasts: 1},
+			{ChannelID: "C2", Channel: "#デプロイ", Messages: 8, Posters: 2, Unread: 8},
+		}}},
+		{"TopChannelsResult_empty", TopChannelsResult{Since: "7d", Scanned: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Package report builds workspace-level summaries from channel history.
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// ChannelActivity is one channel's activity over the report window.
+type ChannelActivity struct {
+	ChannelID string `json:"channel_id"`
+	Channel   string `json:"channel"`
+	IsPrivate bool   `json:"is_private,omitempty"`
+	Messages  int    `json:"messages"`
+	// Sampled is set when the channel had more messages than were read.
+	Sampled bool `json:"sampled,omitempty"`
+	Posters int  `json:"posters"`
+	// Unread counts messages after the channel's last_read marker. Without a marker
+	// (bot tokens), every message in the window counts as unread.
+	Unread           int    `json:"unread"`
+	UnreadMentions   int    `json:"unread_mentions"`
+	UnreadBroadcasts int    `json:"unread_broadcasts"`
+	LatestTS         string `json:"latest_ts,omitempty"`
+}
+
+// TopChannelsResult is the output of report top-channels.
+type TopChannelsResult struct {
+	Since    string            `json:"since"`
+	Scanned  int               `json:"scanned"`
+	Channels []ChannelActivity `json:"channels"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r TopChannelsResult) Lines() []string {
+	if len(r.Channels) == 0 {
+		return []string{fmt.Sprintf("No activity in %d channels since %s", r.Scanned, r.Since)}
+	}
+	lines := []string{
+		fmt.Sprintf("Top channels since %s (%d scanned)", r.Since, r.Scanned),
+		fmt.Sprintf("%-28s %8s %8s %8s %8s", "CHANNEL", "MENTIONS", "UNREAD", "MESSAGES", "POSTERS"),
+	}
+	for _, c := range r.Channels {
+		messages := strconv.Itoa(c.Messages)
+		if c.Sampled {
+			messages += "+"
+		}
+		mentions := strconv.Itoa(c.UnreadMentions)
+		if c.UnreadBroadcasts > 0 {
+			mentions += fmt.Sprintf(" (+%d)", c.UnreadBroadcasts)
+		}
+		lines = append(lines, fmt.Sprintf("%-28s %8s %8d %8s %8d", c.Channel, mentions, c.Unread, messages, c.Posters))
+	}
+	return lines
+}
+
+// Ellipsize implements output.Ellipsizer; the rows are a table.
+func (r TopChannelsResult) Ellipsize() bool {
+	return true
+}
+
+// Activity summarizes a channel's messages in the window. selfID is the active user;
+// lastRead is the channel's last_read timestamp, or "" when unknown.
+func Activity(msgs []slackapi.Message, selfID, lastRead string) ChannelActivity {
+	var a ChannelActivity
+	posters := map[string]bool{}
+	for _, msg := range msgs {
+		if msg.SubType != "" && msg.SubType != "bot_message" && msg.SubType != "thread_broadcast" && msg.SubType != "file_share" {
+			continue
+		}
+		a.Messages++
+		if who := firstNonEmpty(msg.User, msg.BotID); who != "" {
+			posters[who] = true
+		}
+		if a.LatestTS == "" || tsAfter(msg.Timestamp, a.LatestTS) {
+			a.LatestTS = msg.Timestamp
+		}
+		if lastRead != "" && !tsAfter(msg.Timestamp, lastRead) {
+			continue
+		}
+		if selfID != "" && msg.User == selfID {
+			continue
+		}
+		a.Unread++
+		switch {
+		case selfID != "" && (strings.Contains(msg.Text, "<@"+selfID+">") || strings.Contains(msg.Text, "<@"+selfID+"|")):
+			a.UnreadMentions++
+		case strings.Contains(msg.Text, "<!here") || strings.Contains(msg.Text, "<!channel") || strings.Contains(msg.Text, "<!everyone"):
+			a.UnreadBroadcasts++
+		}
+	}
+	a.Posters = len(posters)
+	return a
+}
+
+// Rank orders channels by unread mentions, then unread broadcasts, then message count,
+// drops channels with no activity, and keeps at most limit entries (0 keeps all).
+func Rank(channels []ChannelActivity, limit int) []ChannelActivity {
+	ranked := make([]ChannelActivity, 0, len(channels))
+	for _, c := range channels {
+		if c.Messages > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.UnreadMentions != b.UnreadMentions {
+			return a.UnreadMentions > b.UnreadMentions
+		}
+		if a.UnreadBroadcasts != b.UnreadBroadcasts {
+			return a.UnreadBroadcasts > b.UnreadBroadcasts
+		}
+		if a.Messages != b.Messages {
+			return a.Messages > b.Messages
+		}
+		return a.Channel < b.Channel
+	})
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// tsAfter reports whether Slack timestamp a is later than b.
+func tsAfter(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return a > b
+	}
+	return fa > fb
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+func msg(ts, user, text string) slackapi.Message {
+	return slackapi.Message{Msg: slackapi.Msg{Timestamp: ts, User: user, Text: text}}
+}
+
+func TestActivity(t *testing.T) {
+	msgs := []slackapi.Message{
+		msg("1700000500.000100", "U2", "ping <@U1> please look"),
+		msg("1700000400.000100", "U3", "<!here> deploy starting"),
+		msg("1700000300.000100", "U1", "my own message <@U1>"),
+		msg("1700000200.000100", "U2", "old mention <@U1|alice>"),
+		{Msg: slackapi.Msg{Timestamp: "1700000100.000100", User: "U4", SubType: "channel_join"}},
+	}
+
+	got := Activity(msgs, "U1", "1700000250.000000")
+	if got.Messages != 4 || got.Posters != 3 {
+		t.Fatalf("messages/posters = %d/%d, want 4/3", got.Messages, got.Posters)
+	}
+	if got.Unread != 2 || got.UnreadMentions != 1 || got.UnreadBroadcasts != 1 {
+		t.Fatalf("unread = %d mentions = %d broadcasts = %d, want 2/1/1", got.Unread, got.UnreadMentions, got.UnreadBroadcasts)
+	}
+	if got.LatestTS != "1700000500.000100" {
+		t.Fatalf("latest = %q", got.LatestTS)
+	}
+
+	// Without a read marker every message by someone else is unread.
+	got = Activity(msgs, "U1", "")
+	if got.Unread != 3 || got.UnreadMentions != 2 {
+		t.Fatalf("no marker: unread = %d mentions = %d, want 3/2", got.Unread, got.UnreadMentions)
+	}
+}
+
+func TestRank(t *testing.T) {
+	channels := []ChannelActivity{
+		{Channel: "#quiet", Messages: 0},
+		{Channel: "#busy", Messages: 90},
+		{Channel: "#pinged", Messages: 3, UnreadMentions: 1},
+		{Channel: "#alerts", Messages: 5, UnreadBroadcasts: 2},
+		{Channel: "#also-busy", Messages: 90},
+	}
+	got := Rank(channels, 0)
+	want := []string{"#pinged", "#alerts", "#also-busy", "#busy"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d channels, want %d", len(got), len(want))
+	}
+	for i, name := range want {
+		if got[i].Channel != name {
+			t.Fatalf("rank %d = %s, want %s", i, got[i].Channel, name)
+		}
+	}
+	if got := Rank(channels, 2); len(got) != 2 {
+		t.Fatalf("limit 2 returned %d channels", len(got))
+	}
+}
@@ -0,0 +1,29 @@
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	prev := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		p    *NextResult
+	}{
+		{"NextResult", &NextResult{OK: true, Usergroup: "@support-rotation", UsergroupID: "S0123ROTA",
+			User: "@carol", UserID: "U3", Previous: "@alice", PreviousID: "U1", PreviousAt: &prev,
+			Position: 3, Members: 4, Skipped: []Skip{{User: "@bob", UserID: "U2", Reason: "out_of_office"}},
+			Recorded: true, AssignedGroup: "@support-primary",
+			Posted: &Posted{Channel: "#support", ChannelID: "C123ABC", TS: "1705309200.000100"}}},
+		{"NextResult_preview", &NextResult{OK: true, Usergroup: "@support-rotation", UsergroupID: "S0123ROTA",
+			UserID: "U1", Position: 1, Members: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
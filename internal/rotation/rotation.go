@@ -0,0 +1,211 @@
+// Package rotation keeps round-robin state for usergroup rotations, so slk rotation
+// next picks the member after the last one assigned without any external tooling.
+//
+// Members are taken in user ID order and the next pick is the first member after the
+// last assignee. That keeps the order stable when people join or leave the group: a
+// newcomer slots in by ID, and a departed last assignee still marks the position.
+package rotation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Version is the current state file format.
+const Version = 1
+
+// HistoryLimit is how many past assignments are kept per rotation.
+const HistoryLimit = 20
+
+// Assignment is one recorded pick.
+type Assignment struct {
+	UserID string    `json:"user_id"`
+	At     time.Time `json:"at"`
+}
+
+// Group is the state of one usergroup's rotation.
+type Group struct {
+	Handle string `json:"handle"`
+	// History holds the most recent assignments, newest last.
+	History []Assignment `json:"history"`
+}
+
+// Last returns the most recent assignment, if any.
+func (g *Group) Last() (Assignment, bool) {
+	if g == nil || len(g.History) == 0 {
+		return Assignment{}, false
+	}
+	return g.History[len(g.History)-1], true
+}
+
+// State is the rotation state of every usergroup, keyed by usergroup ID.
+type State struct {
+	path   string
+	Groups map[string]*Group
+}
+
+type stateFile struct {
+	Version int               `json:"version"`
+	Groups  map[string]*Group `json:"groups"`
+}
+
+// DefaultPath returns the state path inside a team's cache directory.
+func DefaultPath(cacheDir string) string {
+	return filepath.Join(cacheDir, "rotation.json")
+}
+
+// Load reads the state file at path, or returns empty state when it does not exist.
+func Load(path string) (*State, error) {
+	s := &State{path: path, Groups: map[string]*Group{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read rotation state: %w", err)
+	}
+	var file stateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse rotation state %s: %w", path, err)
+	}
+	for id, g := range file.Groups {
+		if g != nil {
+			s.Groups[id] = g
+		}
+	}
+	return s, nil
+}
+
+// Group returns the rotation for a usergroup ID, or nil when none was recorded.
+func (s *State) Group(groupID string) *Group {
+	return s.Groups[groupID]
+}
+
+// Record appends an assignment to a usergroup's rotation.
+func (s *State) Record(groupID, handle, userID string, now time.Time) {
+	g := s.Groups[groupID]
+	if g == nil {
+		g = &Group{}
+		s.Groups[groupID] = g
+	}
+	g.Handle = handle
+	g.History = append(g.History, Assignment{UserID: userID, At: now})
+	if len(g.History) > HistoryLimit {
+		g.History = slices.Clone(g.History[len(g.History)-HistoryLimit:])
+	}
+}
+
+// Save atomically writes the state back to its file.
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(stateFile{Version: Version, Groups: s.Groups}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal rotation state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create rotation state dir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write rotation state tmp: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename rotation state tmp: %w", err)
+	}
+	return nil
+}
+
+// Order returns members in the order they are up next: sorted by ID, starting with
+// the first member after last and wrapping around, so last itself (when still a
+// member) comes at the end. With no last assignee the order starts at the lowest ID.
+func Order(members []string, last string) []string {
+	sorted := slices.Clone(members)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	if last == "" {
+		return sorted
+	}
+	start, _ := slices.BinarySearchFunc(sorted, last, func(id, target string) int {
+		if id <= target {
+			return -1
+		}
+		return 1
+	})
+	return append(sorted[start:], sorted[:start]...)
+}
+
+// Skip is a member passed over for this pick.
+type Skip struct {
+	User   string `json:"user,omitempty"`
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// Posted is the assignment announcement.
+type Posted struct {
+	Channel   string `json:"channel"`
+	ChannelID string `json:"channel_id"`
+	TS        string `json:"ts"`
+}
+
+// NextResult is the output of rotation next.
+type NextResult struct {
+	OK          bool   `json:"ok"`
+	Usergroup   string `json:"usergroup"`
+	UsergroupID string `json:"usergroup_id"`
+	User        string `json:"user,omitempty"`
+	UserID      string `json:"user_id"`
+	// Previous is the last recorded assignee.
+	Previous   string     `json:"previous,omitempty"`
+	PreviousID string     `json:"previous_id,omitempty"`
+	PreviousAt *time.Time `json:"previous_at,omitempty"`
+	// Position is the pick's 1-based place in the ID-ordered member list.
+	Position int    `json:"position"`
+	Members  int    `json:"members"`
+	Skipped  []Skip `json:"skipped,omitempty"`
+	Recorded bool   `json:"recorded"`
+	// AssignedGroup is the usergroup whose members were replaced with the pick.
+	AssignedGroup string  `json:"assigned_group,omitempty"`
+	Posted        *Posted `json:"posted,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *NextResult) Lines() []string {
+	lines := []string{fmt.Sprintf("Next for %s: %s (%d of %d)", r.Usergroup, name(r.User, r.UserID), r.Position, r.Members)}
+	if r.PreviousID != "" {
+		line := "Previous: " + name(r.Previous, r.PreviousID)
+		if r.PreviousAt != nil {
+			line += " at " + r.PreviousAt.Format(time.RFC3339)
+		}
+		lines = append(lines, line)
+	}
+	for _, s := range r.Skipped {
+		lines = append(lines, fmt.Sprintf("Skipped %s: %s", name(s.User, s.UserID), strings.ReplaceAll(s.Reason, "_", " ")))
+	}
+	if r.Recorded {
+		lines = append(lines, "Recorded as the latest assignment")
+	} else {
+		lines = append(lines, "Not recorded; run with --record to advance the rotation")
+	}
+	if r.AssignedGroup != "" {
+		lines = append(lines, fmt.Sprintf("Set %s to %s", r.AssignedGroup, name(r.User, r.UserID)))
+	}
+	if r.Posted != nil {
+		lines = append(lines, fmt.Sprintf("Posted to %s (ts %s)", r.Posted.Channel, r.Posted.TS))
+	}
+	return lines
+}
+
+func name(user, id string) string {
+	if user == "" || user == id {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", user, id)
+}
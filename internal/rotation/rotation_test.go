@@ -0,0 +1,65 @@
+package rotation
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOrder(t *testing.T) {
+	members := []string{"U3", "U1", "U4", "U2", "U1"}
+	tests := []struct {
+		last string
+		want []string
+	}{
+		{"", []string{"U1", "U2", "U3", "U4"}},
+		{"U1", []string{"U2", "U3", "U4", "U1"}},
+		{"U4", []string{"U1", "U2", "U3", "U4"}},
+		// The last assignee left the group: continue with the next ID after theirs.
+		{"U25", []string{"U3", "U4", "U1", "U2"}},
+		{"U9", []string{"U1", "U2", "U3", "U4"}},
+	}
+	for _, tt := range tests {
+		if got := Order(members, tt.last); !slices.Equal(got, tt.want) {
+			t.Errorf("Order(last=%q) = %v, want %v", tt.last, got, tt.want)
+		}
+	}
+	if got := Order(nil, "U1"); len(got) != 0 {
+		t.Errorf("Order(nil) = %v", got)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team", "rotation.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Group("S1") != nil {
+		t.Fatal("empty state has a group")
+	}
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < HistoryLimit+5; i++ {
+		s.Record("S1", "support", "U"+string(rune('A'+i)), start.Add(time.Duration(i)*time.Hour))
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := loaded.Group("S1")
+	if g == nil || g.Handle != "support" || len(g.History) != HistoryLimit {
+		t.Fatalf("group = %+v", g)
+	}
+	last, ok := g.Last()
+	if !ok || last.UserID != "U"+string(rune('A'+HistoryLimit+4)) || !last.At.Equal(start.Add(time.Duration(HistoryLimit+4)*time.Hour)) {
+		t.Errorf("last = %+v", last)
+	}
+	if _, ok := (*Group)(nil).Last(); ok {
+		t.Error("nil group has a last assignment")
+	}
+}
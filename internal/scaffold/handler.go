@@ -0,0 +1,99 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Invocation is one slash command or interaction routed to a handler.
+type Invocation struct {
+	// Type is "slash_command", or the interaction type (block_actions, view_submission, ...).
+	Type        string
+	Command     string
+	Text        string
+	UserID      string
+	ChannelID   string
+	TriggerID   string
+	ResponseURL string
+	// Payload is the JSON Slack sent, passed to the handler on stdin.
+	Payload []byte
+}
+
+// Env returns the SLK_* variables describing the invocation, added to the handler's
+// environment so simple scripts need not parse the payload.
+func (inv Invocation) Env() []string {
+	return []string{
+		"SLK_EVENT_TYPE=" + inv.Type,
+		"SLK_COMMAND=" + inv.Command,
+		"SLK_TEXT=" + inv.Text,
+		"SLK_USER_ID=" + inv.UserID,
+		"SLK_CHANNEL_ID=" + inv.ChannelID,
+		"SLK_TRIGGER_ID=" + inv.TriggerID,
+		"SLK_RESPONSE_URL=" + inv.ResponseURL,
+	}
+}
+
+// Run runs handler with the payload on stdin and returns its standard output with
+// surrounding whitespace trimmed. The handler's standard error goes to stderr.
+func Run(ctx context.Context, handler string, inv Invocation, stderr io.Writer) (string, error) {
+	var stdout bytes.Buffer
+	c := exec.CommandContext(ctx, handler)
+	c.Stdin = bytes.NewReader(inv.Payload)
+	c.Stdout, c.Stderr = &stdout, stderr
+	c.Env = append(os.Environ(), inv.Env()...)
+	if err := c.Run(); err != nil {
+		return strings.TrimSpace(stdout.String()), fmt.Errorf("%s: %w", handler, err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// ExitCode returns the handler's exit code for err from Run, or -1 when it did not exit.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// ResponseBody turns handler output into a response_url message. A JSON object is sent
+// as is, so handlers can reply with blocks or "response_type": "in_channel"; any other
+// text becomes an ephemeral message only the invoking user sees.
+func ResponseBody(output string) []byte {
+	trimmed := strings.TrimSpace(output)
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		return []byte(trimmed)
+	}
+	body, _ := json.Marshal(map[string]string{"response_type": "ephemeral", "text": trimmed})
+	return body
+}
+
+// Respond posts body to a slash command's or interaction's response_url.
+func Respond(ctx context.Context, client *http.Client, responseURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("respond: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("respond: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("respond: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
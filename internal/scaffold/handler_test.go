@@ -0,0 +1,66 @@
+package scaffold
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunPassesPayloadAndEnv(t *testing.T) {
+	handler := filepath.Join(t.TempDir(), "handler.sh")
+	script := "#!/bin/sh\nread payload\necho \"$SLK_COMMAND $SLK_TEXT $payload\"\n[ \"$SLK_USER_ID\" = U1 ] || exit 3\n"
+	if err := os.WriteFile(handler, []byte(script), 0o755); err != nil {
+		t.Fatalf("write handler: %v", err)
+	}
+
+	inv := Invocation{Type: "slash_command", Command: "/triage", Text: "INC-42", UserID: "U1", Payload: []byte(`{"ok":1}` + "\n")}
+	out, err := Run(context.Background(), handler, inv, io.Discard)
+	if err != nil || out != `/triage INC-42 {"ok":1}` {
+		t.Fatalf("Run = %q, %v", out, err)
+	}
+
+	inv.UserID = "U2"
+	_, err = Run(context.Background(), handler, inv, io.Discard)
+	if code := ExitCode(err); code != 3 {
+		t.Fatalf("ExitCode = %d (err %v), want 3", code, err)
+	}
+}
+
+func TestResponseBody(t *testing.T) {
+	if got := string(ResponseBody("Filed INC-42")); got != `{"response_type":"ephemeral","text":"Filed INC-42"}` {
+		t.Fatalf("text body = %s", got)
+	}
+	blocks := `{"response_type":"in_channel","text":"done"}`
+	if got := string(ResponseBody("\n" + blocks + "\n")); got != blocks {
+		t.Fatalf("JSON body = %s", got)
+	}
+	if got := string(ResponseBody("{not json")); got != `{"response_type":"ephemeral","text":"{not json"}` {
+		t.Fatalf("invalid JSON body = %s", got)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		if r.URL.Path == "/expired" {
+			http.Error(w, "expired_url", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	if err := Respond(context.Background(), server.Client(), server.URL+"/hook", ResponseBody("hi")); err != nil {
+		t.Fatalf("Respond: %v", err)
+	}
+	if got != `{"response_type":"ephemeral","text":"hi"}` {
+		t.Fatalf("posted %s", got)
+	}
+	if err := Respond(context.Background(), server.Client(), server.URL+"/expired", ResponseBody("hi")); err == nil {
+		t.Fatal("expected error for expired response URL")
+	}
+}
@@ -0,0 +1,99 @@
+// Package scaffold generates Slack app configuration for wrapping existing scripts
+// as Slack commands, and runs those scripts when the commands arrive.
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// slashNamePattern matches the names Slack accepts for slash commands.
+var slashNamePattern = regexp.MustCompile(`^/[a-z0-9_-]{1,31}$`)
+
+// RequiredBotScopes are the bot scopes a slash command app needs.
+var RequiredBotScopes = []string{"commands"}
+
+// SlashCommand describes a slash command to add to an app.
+type SlashCommand struct {
+	Name        string `json:"command" yaml:"command"`
+	Description string `json:"description" yaml:"description"`
+	UsageHint   string `json:"usage_hint,omitempty" yaml:"usage_hint,omitempty"`
+}
+
+// Validate checks the command name and fills in a default description.
+func (c *SlashCommand) Validate() error {
+	c.Name = strings.ToLower(strings.TrimSpace(c.Name))
+	if !strings.HasPrefix(c.Name, "/") {
+		c.Name = "/" + c.Name
+	}
+	if !slashNamePattern.MatchString(c.Name) {
+		return fmt.Errorf("invalid slash command %q: use a slash followed by up to 31 lowercase letters, digits, - or _", c.Name)
+	}
+	if strings.TrimSpace(c.Description) == "" {
+		c.Description = "Run " + c.Name
+	}
+	return nil
+}
+
+// Manifest is the app manifest snippet that declares slash commands over Socket Mode.
+type Manifest struct {
+	Features    ManifestFeatures `json:"features" yaml:"features"`
+	OAuthConfig ManifestOAuth    `json:"oauth_config" yaml:"oauth_config"`
+	Settings    ManifestSettings `json:"settings" yaml:"settings"`
+}
+
+// ManifestFeatures lists the app's slash commands.
+type ManifestFeatures struct {
+	SlashCommands []ManifestSlashCommand `json:"slash_commands" yaml:"slash_commands"`
+}
+
+// ManifestSlashCommand is one features.slash_commands entry.
+type ManifestSlashCommand struct {
+	SlashCommand `yaml:",inline"`
+	ShouldEscape bool `json:"should_escape" yaml:"should_escape"`
+}
+
+// ManifestOAuth lists the bot scopes.
+type ManifestOAuth struct {
+	Scopes ManifestScopes `json:"scopes" yaml:"scopes"`
+}
+
+// ManifestScopes holds bot scopes.
+type ManifestScopes struct {
+	Bot []string `json:"bot" yaml:"bot"`
+}
+
+// ManifestSettings enables Socket Mode and interactivity, so no public URL is needed.
+type ManifestSettings struct {
+	Interactivity     ManifestInteractivity `json:"interactivity" yaml:"interactivity"`
+	SocketModeEnabled bool                  `json:"socket_mode_enabled" yaml:"socket_mode_enabled"`
+}
+
+// ManifestInteractivity turns on interactive payloads (buttons, modals).
+type ManifestInteractivity struct {
+	IsEnabled bool `json:"is_enabled" yaml:"is_enabled"`
+}
+
+// NewManifest returns the manifest snippet for a validated command.
+func NewManifest(cmd SlashCommand) Manifest {
+	return Manifest{
+		Features:    ManifestFeatures{SlashCommands: []ManifestSlashCommand{{SlashCommand: cmd}}},
+		OAuthConfig: ManifestOAuth{Scopes: ManifestScopes{Bot: RequiredBotScopes}},
+		Settings: ManifestSettings{
+			Interactivity:     ManifestInteractivity{IsEnabled: true},
+			SocketModeEnabled: true,
+		},
+	}
+}
+
+// YAML renders the manifest snippet for pasting into the app settings.
+func (m Manifest) YAML() (string, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("encode manifest: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,39 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlashCommandValidate(t *testing.T) {
+	cmd := SlashCommand{Name: "Triage"}
+	if err := cmd.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if cmd.Name != "/triage" || cmd.Description != "Run /triage" {
+		t.Fatalf("normalized command = %+v", cmd)
+	}
+	for _, bad := range []string{"/", "/two words", "/" + strings.Repeat("x", 32), "/tri@ge"} {
+		c := SlashCommand{Name: bad}
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestManifestYAML(t *testing.T) {
+	got, err := NewManifest(SlashCommand{Name: "/triage", Description: "Triage an incident", UsageHint: "[incident id]"}).YAML()
+	if err != nil {
+		t.Fatalf("YAML: %v", err)
+	}
+	for _, want := range []string{
+		"slash_commands:\n        - command: /triage\n          description: Triage an incident\n          usage_hint: '[incident id]'\n          should_escape: false",
+		"bot:\n            - commands",
+		"socket_mode_enabled: true",
+		"interactivity:\n        is_enabled: true",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("manifest missing %q:\n%s", want, got)
+		}
+	}
+}
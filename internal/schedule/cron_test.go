@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata unavailable:", err)
+	}
+	// Friday 2024-01-12 16:20 in Berlin.
+	from := time.Date(2024, 1, 12, 16, 20, 30, 0, berlin)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 9 * * MON", time.Date(2024, 1, 15, 9, 0, 0, 0, berlin)},
+		{"*/15 * * * *", time.Date(2024, 1, 12, 16, 30, 0, 0, berlin)},
+		{"30 17 * * mon-fri", time.Date(2024, 1, 12, 17, 30, 0, 0, berlin)},
+		{"0 9-17/4 * * *", time.Date(2024, 1, 12, 17, 0, 0, 0, berlin)},
+		{"0 0 1,15 * *", time.Date(2024, 1, 15, 0, 0, 0, 0, berlin)},
+		{"0 0 29 FEB *", time.Date(2024, 2, 29, 0, 0, 0, 0, berlin)},
+		{"0 12 * * 7", time.Date(2024, 1, 14, 12, 0, 0, 0, berlin)},
+		// Day of month and day of week both restricted: either matches.
+		{"0 8 20 * MON", time.Date(2024, 1, 15, 8, 0, 0, 0, berlin)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, berlin)},
+		// Spring forward: 02:30 does not exist on 2024-03-31 and is skipped.
+		{"30 2 31 3 *", time.Date(2025, 3, 31, 2, 30, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if got := c.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%v) = %v, want %v", tt.expr, from, got, tt.want)
+		}
+	}
+
+	never, _ := ParseCron("0 0 31 2 *")
+	if got := never.Next(from); !got.IsZero() {
+		t.Errorf("Feb 31 should never match, got %v", got)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for expr, want := range map[string]string{
+		"0 9 * *":        "want 5 fields",
+		"60 * * * *":     "minute",
+		"0 9 * * FUNDAY": "day of week",
+		"0 17-9 * * *":   "backwards",
+		"*/0 * * * *":    "invalid step",
+	} {
+		if _, err := ParseCron(expr); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseCron(%q) err = %v, want it to mention %q", expr, err, want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	monday := func(day int) time.Time { return time.Date(2024, 1, day, 9, 0, 0, 0, time.UTC) }
+	lastRun := monday(15)
+	standup := Schedule{ID: 3, Cron: "0 9 * * MON", Channel: "#team", Template: "/home/me/standup.tmpl", Delivery: DeliveryPost,
+		CreatedAt: time.Date(2024, 1, 12, 16, 20, 0, 0, time.UTC), NextRun: monday(15)}
+	ran := standup
+	ran.NextRun, ran.Runs, ran.LastRun, ran.LastTS = monday(22), 1, &lastRun, "1705309200.000100"
+	pager := Schedule{ID: 4, Cron: "30 17 * * MON-FRI", Channel: "#ops", Text: "Reminder: hand over the pager", Delivery: DeliverySlack,
+		NextRun: time.Date(2024, 1, 15, 17, 30, 0, 0, time.UTC), ScheduledChannelID: "C0OPS", ScheduledMessageID: "Q1298393284",
+		LastError: "channel_not_found"}
+	tests := []struct {
+		name string
+		p    output.Printable
+	}{
+		{"AddResult", AddResult{OK: true, Schedule: standup, Upcoming: []time.Time{monday(15), monday(22), monday(29)}}},
+		{"ListResult", ListResult{Path: "/home/me/.config/slack-cli/schedule/schedules.db", Schedules: []Schedule{ran, pager}}},
+		{"ListResult_empty", ListResult{Path: "/home/me/.config/slack-cli/schedule/schedules.db", Schedules: []Schedule{}}},
+		{"RemoveResult", RemoveResult{OK: true, ID: 3, Channel: "#team"}},
+		{"RemoveResult_canceled", RemoveResult{OK: true, ID: 4, Channel: "#ops", CanceledScheduledMessageID: "Q1298393284"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
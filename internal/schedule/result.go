@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeLayout is how occurrences are shown to people: weekday first, since most
+// schedules are weekly.
+const timeLayout = "Mon 2006-01-02 15:04 MST"
+
+// AddResult is the output of schedule add.
+type AddResult struct {
+	OK       bool        `json:"ok"`
+	Schedule Schedule    `json:"schedule"`
+	Upcoming []time.Time `json:"upcoming"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r AddResult) Lines() []string {
+	lines := []string{fmt.Sprintf("✓ Schedule #%d: %q → %s (%s)", r.Schedule.ID, r.Schedule.Cron, r.Schedule.Channel, r.Schedule.Delivery)}
+	for _, t := range r.Upcoming {
+		lines = append(lines, "  next "+t.Format(timeLayout))
+	}
+	return lines
+}
+
+// ListResult is the output of schedule list.
+type ListResult struct {
+	Path      string     `json:"path"`
+	Schedules []Schedule `json:"schedules"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r ListResult) Lines() []string {
+	if len(r.Schedules) == 0 {
+		return []string{"No schedules"}
+	}
+	var lines []string
+	for _, s := range r.Schedules {
+		body := s.Template
+		if body == "" {
+			body = fmt.Sprintf("%q", s.Text)
+		}
+		runs := fmt.Sprintf("%d runs", s.Runs)
+		if s.Runs == 1 {
+			runs = "1 run"
+		}
+		line := fmt.Sprintf("#%-3d %-18s → %s, next %s (%s, %s): %s", s.ID, s.Cron, s.Channel, s.NextRun.Format(timeLayout), s.Delivery, runs, body)
+		if s.HandedOff() {
+			line += " [scheduled in Slack]"
+		}
+		lines = append(lines, line)
+		if s.LastError != "" {
+			lines = append(lines, "     last error: "+s.LastError)
+		}
+	}
+	return lines
+}
+
+// RemoveResult is the output of schedule remove.
+type RemoveResult struct {
+	OK      bool   `json:"ok"`
+	ID      int64  `json:"id"`
+	Channel string `json:"channel"`
+	// CanceledScheduledMessageID is the hand-off deleted from Slack with the schedule.
+	CanceledScheduledMessageID string `json:"canceled_scheduled_message_id,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r RemoveResult) Lines() []string {
+	lines := []string{fmt.Sprintf("✓ Removed schedule #%d for %s", r.ID, r.Channel)}
+	if r.CanceledScheduledMessageID != "" {
+		lines = append(lines, "✓ Canceled scheduled message "+r.CanceledScheduledMessageID)
+	}
+	return lines
+}
@@ -0,0 +1,228 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// MissedGrace is how late an occurrence may still be posted, for a daemon that was
+	// restarted around it. Older occurrences are skipped rather than posted late.
+	MissedGrace = 15 * time.Minute
+	// HandOffMinLead keeps hand-offs far enough ahead that chat.scheduleMessage does not
+	// reject them as being in the past; closer occurrences are posted directly.
+	HandOffMinLead = 2 * time.Minute
+	// HandOffMaxLead is how far ahead Slack accepts scheduled messages.
+	HandOffMaxLead = 120 * 24 * time.Hour
+)
+
+// Run actions.
+const (
+	ActionPosted    = "posted"
+	ActionScheduled = "scheduled"
+	// ActionDelivered is an occurrence Slack posted from an earlier hand-off.
+	ActionDelivered = "delivered"
+	ActionMissed    = "missed"
+	ActionFailed    = "failed"
+)
+
+// TemplateData is what a message template is executed with.
+type TemplateData struct {
+	// Time is the occurrence, in the schedule's time zone.
+	Time    time.Time
+	Date    string
+	Weekday string
+	Channel string
+	// Run counts occurrences, starting at 1.
+	Run int
+}
+
+// Render returns the message for the occurrence at at: the template file executed
+// with TemplateData, or the schedule's text.
+func Render(sched Schedule, at time.Time) (string, error) {
+	if sched.Template == "" {
+		return sched.Text, nil
+	}
+	data, err := os.ReadFile(sched.Template)
+	if err != nil {
+		return "", fmt.Errorf("read template: %w", err)
+	}
+	return RenderTemplate(sched.Template, string(data), TemplateData{
+		Time:    at,
+		Date:    at.Format("2006-01-02"),
+		Weekday: at.Weekday().String(),
+		Channel: sched.Channel,
+		Run:     sched.Runs + 1,
+	})
+}
+
+// RenderTemplate executes a message template. Unknown fields are errors, as is a
+// template that renders to nothing.
+func RenderTemplate(name, text string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+	out := strings.TrimRight(buf.String(), "\n")
+	if strings.TrimSpace(out) == "" {
+		return "", fmt.Errorf("template %s renders an empty message", name)
+	}
+	return out, nil
+}
+
+// Sender delivers schedule messages to Slack.
+type Sender interface {
+	ResolveChannel(channel string) (string, error)
+	Post(ctx context.Context, channelID, text string) (ts string, err error)
+	// Schedule calls chat.scheduleMessage; the ID may be empty when Slack does not
+	// report it.
+	Schedule(ctx context.Context, channelID, text string, at time.Time) (scheduledID string, err error)
+}
+
+// Run is the outcome of one occurrence handled by a Runner.
+type Run struct {
+	ID                 int64     `json:"id"`
+	Channel            string    `json:"channel"`
+	At                 time.Time `json:"at"`
+	Action             string    `json:"action"`
+	TS                 string    `json:"ts,omitempty"`
+	ScheduledMessageID string    `json:"scheduled_message_id,omitempty"`
+	Error              string    `json:"error,omitempty"`
+}
+
+// String describes the run for daemon logs.
+func (r Run) String() string {
+	line := fmt.Sprintf("#%d %s to %s for %s", r.ID, r.Action, r.Channel, r.At.Format("2006-01-02 15:04 MST"))
+	if r.TS != "" {
+		line += " (ts " + r.TS + ")"
+	}
+	if r.Error != "" {
+		line += ": " + r.Error
+	}
+	return line
+}
+
+// Runner evaluates schedules and delivers the occurrences that are due.
+type Runner struct {
+	Store  *Store
+	Sender Sender
+	// refused remembers occurrences Slack would not schedule, so each is offered to
+	// chat.scheduleMessage once per process and then posted directly.
+	refused map[int64]time.Time
+}
+
+// Tick handles every schedule once at now: it posts due occurrences, records ones
+// Slack posted from a hand-off, and hands upcoming occurrences of slack-delivery
+// schedules to chat.scheduleMessage. Problems with one schedule are reported in its
+// Run and do not stop the others.
+func (r *Runner) Tick(ctx context.Context, now time.Time) ([]Run, error) {
+	schedules, err := r.Store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var runs []Run
+	for _, sched := range schedules {
+		run, ok, err := r.tick(ctx, sched, now)
+		if err != nil {
+			return runs, err
+		}
+		if ok {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+func (r *Runner) tick(ctx context.Context, sched Schedule, now time.Time) (Run, bool, error) {
+	run := Run{ID: sched.ID, Channel: sched.Channel, At: sched.NextRun}
+	if sched.NextRun.After(now) {
+		if sched.Delivery != DeliverySlack || sched.HandedOff() || !r.canHandOff(sched, now) {
+			return run, false, nil
+		}
+		return r.handOff(ctx, sched, run)
+	}
+
+	following, err := sched.NextAfter(now)
+	if err != nil {
+		run.Action, run.Error = ActionFailed, err.Error()
+		return run, true, r.Store.RecordRun(ctx, sched.ID, sched.NextRun, "", run.Error)
+	}
+	// Claiming first means an occurrence lost to a crash mid-post is skipped, never
+	// posted twice.
+	won, err := r.Store.Claim(ctx, sched, following)
+	if err != nil || !won {
+		return run, false, err
+	}
+	switch {
+	case sched.HandedOff():
+		run.Action, run.ScheduledMessageID = ActionDelivered, sched.ScheduledMessageID
+		return run, true, r.Store.RecordRun(ctx, sched.ID, sched.NextRun, "", "")
+	case now.Sub(sched.NextRun) > MissedGrace:
+		run.Action = ActionMissed
+		run.Error = fmt.Sprintf("skipped: due %s ago while the daemon was not running", now.Sub(sched.NextRun).Round(time.Minute))
+		return run, true, r.Store.RecordRun(ctx, sched.ID, sched.NextRun, "", run.Error)
+	}
+
+	run.Action = ActionFailed
+	text, err := Render(sched, sched.NextRun)
+	if err == nil {
+		var channelID string
+		if channelID, err = r.Sender.ResolveChannel(sched.Channel); err == nil {
+			run.TS, err = r.Sender.Post(ctx, channelID, text)
+		}
+	}
+	if err != nil {
+		run.Error = err.Error()
+	} else {
+		run.Action = ActionPosted
+	}
+	return run, true, r.Store.RecordRun(ctx, sched.ID, sched.NextRun, run.TS, run.Error)
+}
+
+func (r *Runner) canHandOff(sched Schedule, now time.Time) bool {
+	if at, ok := r.refused[sched.ID]; ok && at.Equal(sched.NextRun) {
+		return false
+	}
+	lead := sched.NextRun.Sub(now)
+	return lead >= HandOffMinLead && lead <= HandOffMaxLead
+}
+
+func (r *Runner) handOff(ctx context.Context, sched Schedule, run Run) (Run, bool, error) {
+	refuse := func(err error) (Run, bool, error) {
+		if r.refused == nil {
+			r.refused = map[int64]time.Time{}
+		}
+		r.refused[sched.ID] = sched.NextRun
+		run.Action = ActionFailed
+		run.Error = err.Error() + "; it will be posted directly when due"
+		return run, true, nil
+	}
+	text, err := Render(sched, sched.NextRun)
+	if err != nil {
+		return refuse(err)
+	}
+	channelID, err := r.Sender.ResolveChannel(sched.Channel)
+	if err != nil {
+		return refuse(err)
+	}
+	won, err := r.Store.ClaimHandOff(ctx, sched, channelID)
+	if err != nil || !won {
+		return run, false, err
+	}
+	run.ScheduledMessageID, err = r.Sender.Schedule(ctx, channelID, text, sched.NextRun)
+	if err != nil {
+		run, ok, _ := refuse(err)
+		return run, ok, r.Store.ReleaseHandOff(ctx, sched.ID, run.Error)
+	}
+	run.Action = ActionScheduled
+	return run, true, r.Store.CompleteHandOff(ctx, sched.ID, run.ScheduledMessageID)
+}
@@ -0,0 +1,153 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "schedules.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+type fakeSender struct {
+	posts       []string
+	scheduled   []time.Time
+	scheduleErr error
+}
+
+func (f *fakeSender) ResolveChannel(channel string) (string, error) {
+	return "C123ABC", nil
+}
+
+func (f *fakeSender) Post(ctx context.Context, channelID, text string) (string, error) {
+	f.posts = append(f.posts, text)
+	return "1705305600.000100", nil
+}
+
+func (f *fakeSender) Schedule(ctx context.Context, channelID, text string, at time.Time) (string, error) {
+	if f.scheduleErr != nil {
+		return "", f.scheduleErr
+	}
+	f.scheduled = append(f.scheduled, at)
+	return "Q1298393284", nil
+}
+
+func TestRunnerPostsDueOccurrencesOnce(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+	tmpl := filepath.Join(t.TempDir(), "standup.tmpl")
+	if err := os.WriteFile(tmpl, []byte("Standup #{{.Run}} for {{.Weekday}} {{.Date}} in {{.Channel}}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	added := time.Date(2024, 1, 12, 16, 20, 0, 0, time.UTC)
+	sched, err := store.Add(ctx, Schedule{Cron: "0 9 * * MON", Timezone: "UTC", Channel: "#team", Template: tmpl}, added)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &fakeSender{}
+	runner := &Runner{Store: store, Sender: sender}
+	if runs, err := runner.Tick(ctx, added.Add(time.Hour)); err != nil || len(runs) != 0 {
+		t.Fatalf("Tick before due = %+v, %v", runs, err)
+	}
+
+	due := sched.NextRun.Add(30 * time.Second)
+	runs, err := runner.Tick(ctx, due)
+	if err != nil || len(runs) != 1 || runs[0].Action != ActionPosted {
+		t.Fatalf("Tick when due = %+v, %v", runs, err)
+	}
+	if want := "Standup #1 for Monday 2024-01-15 in #team"; len(sender.posts) != 1 || sender.posts[0] != want {
+		t.Errorf("posts = %q, want [%q]", sender.posts, want)
+	}
+	// A second daemon, or the next tick, must not post the occurrence again.
+	other := &Runner{Store: store, Sender: sender}
+	if runs, _ := other.Tick(ctx, due); len(runs) != 0 || len(sender.posts) != 1 {
+		t.Errorf("occurrence posted twice: runs %+v, posts %q", runs, sender.posts)
+	}
+
+	got, _, _ := store.Get(ctx, sched.ID)
+	if got.Runs != 1 || got.LastTS != "1705305600.000100" || !got.NextRun.Equal(time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("after run: runs %d, last_ts %q, next_run %v", got.Runs, got.LastTS, got.NextRun)
+	}
+
+	// Long overdue occurrences are skipped, not posted late.
+	runs, _ = runner.Tick(ctx, got.NextRun.Add(2*time.Hour))
+	if len(runs) != 1 || runs[0].Action != ActionMissed || len(sender.posts) != 1 {
+		t.Errorf("overdue tick = %+v, posts %q", runs, sender.posts)
+	}
+}
+
+func TestRunnerHandsOffToSlack(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+	added := time.Date(2024, 1, 12, 16, 20, 0, 0, time.UTC)
+	sched, err := store.Add(ctx, Schedule{Cron: "0 9 * * MON", Timezone: "UTC", Channel: "#team", Text: "Standup!", Delivery: DeliverySlack}, added)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := &fakeSender{}
+	runner := &Runner{Store: store, Sender: sender}
+
+	runs, err := runner.Tick(ctx, added)
+	if err != nil || len(runs) != 1 || runs[0].Action != ActionScheduled || runs[0].ScheduledMessageID != "Q1298393284" {
+		t.Fatalf("hand-off tick = %+v, %v", runs, err)
+	}
+	if runs, _ := runner.Tick(ctx, added.Add(time.Minute)); len(runs) != 0 || len(sender.scheduled) != 1 {
+		t.Errorf("occurrence handed off twice: runs %+v, scheduled %v", runs, sender.scheduled)
+	}
+
+	// Once Slack has posted it, the occurrence is recorded and the next one handed off.
+	runs, _ = runner.Tick(ctx, sched.NextRun.Add(time.Minute))
+	if len(runs) != 1 || runs[0].Action != ActionDelivered || len(sender.posts) != 0 {
+		t.Fatalf("delivered tick = %+v, posts %q", runs, sender.posts)
+	}
+	runs, _ = runner.Tick(ctx, sched.NextRun.Add(2*time.Minute))
+	if len(runs) != 1 || runs[0].Action != ActionScheduled || !sender.scheduled[1].Equal(time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("next hand-off = %+v, scheduled %v", runs, sender.scheduled)
+	}
+}
+
+func TestRunnerPostsWhenSlackRefusesHandOff(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+	added := time.Date(2024, 1, 12, 16, 20, 0, 0, time.UTC)
+	sched, err := store.Add(ctx, Schedule{Cron: "0 9 * * MON", Timezone: "UTC", Channel: "#team", Text: "Standup!", Delivery: DeliverySlack}, added)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := &fakeSender{scheduleErr: errors.New("schedule message: restricted_action")}
+	runner := &Runner{Store: store, Sender: sender}
+
+	runs, _ := runner.Tick(ctx, added)
+	if len(runs) != 1 || runs[0].Action != ActionFailed {
+		t.Fatalf("refused hand-off = %+v", runs)
+	}
+	// The refusal is not retried every tick.
+	if runs, _ := runner.Tick(ctx, added.Add(time.Minute)); len(runs) != 0 {
+		t.Errorf("hand-off retried: %+v", runs)
+	}
+	runs, _ = runner.Tick(ctx, sched.NextRun)
+	if len(runs) != 1 || runs[0].Action != ActionPosted || len(sender.posts) != 1 {
+		t.Errorf("due tick after refusal = %+v, posts %q", runs, sender.posts)
+	}
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	data := TemplateData{Date: "2024-01-15"}
+	if _, err := RenderTemplate("t", "{{.Nope}}", data); err == nil {
+		t.Error("unknown field should fail")
+	}
+	if _, err := RenderTemplate("t", "{{if false}}x{{end}}\n", data); err == nil {
+		t.Error("empty output should fail")
+	}
+}
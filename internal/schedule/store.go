@@ -0,0 +1,330 @@
+// Package schedule keeps recurring messages for slk schedule: cron expressions
+// evaluated locally by daemon run, and a SQLite store next to the config file.
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/config"
+	_ "modernc.org/sqlite"
+)
+
+// How a schedule's messages reach Slack.
+const (
+	// DeliveryPost has the daemon post each message when it is due.
+	DeliveryPost = "post"
+	// DeliverySlack hands each occurrence to chat.scheduleMessage ahead of time, so
+	// Slack posts it even if the daemon is down when it is due.
+	DeliverySlack = "slack"
+)
+
+// Schedule is one recurring message.
+type Schedule struct {
+	ID   int64  `json:"id"`
+	Cron string `json:"cron"`
+	// Timezone is the IANA zone the cron expression is evaluated in; empty means the
+	// local zone of the process evaluating it.
+	Timezone string `json:"timezone,omitempty"`
+	// Channel is the target as given (name, ID, or @user); it is resolved at delivery.
+	Channel string `json:"channel"`
+	// Template is the absolute path of a text/template file, read at each delivery so
+	// edits apply to the next message. Text is used instead when it is empty.
+	Template  string    `json:"template,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	Delivery  string    `json:"delivery"`
+	CreatedAt time.Time `json:"created_at"`
+	// NextRun is the next occurrence that has not been posted.
+	NextRun time.Time `json:"next_run"`
+	// ScheduledChannelID is set while NextRun has been handed to chat.scheduleMessage,
+	// with the ID Slack gave it when it could be read back.
+	ScheduledChannelID string     `json:"scheduled_channel_id,omitempty"`
+	ScheduledMessageID string     `json:"scheduled_message_id,omitempty"`
+	Runs               int        `json:"runs"`
+	LastRun            *time.Time `json:"last_run,omitempty"`
+	LastTS             string     `json:"last_ts,omitempty"`
+	LastError          string     `json:"last_error,omitempty"`
+}
+
+// HandedOff reports whether Slack, rather than the daemon, will post NextRun.
+func (s Schedule) HandedOff() bool {
+	return s.ScheduledChannelID != ""
+}
+
+// Location returns the schedule's time zone.
+func (s Schedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// NextAfter returns the schedule's first occurrence after t.
+func (s Schedule) NextAfter(t time.Time) (time.Time, error) {
+	c, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := s.Location()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timezone %q: %w", s.Timezone, err)
+	}
+	next := c.Next(t.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron %q never matches", s.Cron)
+	}
+	return next, nil
+}
+
+// Store wraps a schedule SQLite database.
+type Store struct {
+	db   *sql.DB
+	path string
+}
+
+// DefaultPath returns the default schedule database path adjacent to the slk config
+// file.
+func DefaultPath(configPath string) (string, error) {
+	if configPath == "" {
+		var err error
+		if configPath, err = config.DefaultPath(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(filepath.Dir(configPath), "schedule", "schedules.db"), nil
+}
+
+// Open opens or creates a schedule database.
+func Open(path string) (*Store, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("schedule path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create schedule dir: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	store := &Store{db: db, path: path}
+	if err := store.init(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Path returns the backing SQLite path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *Store) init() error {
+	stmts := []string{
+		`PRAGMA busy_timeout=5000`,
+		`PRAGMA journal_mode=WAL`,
+		`CREATE TABLE IF NOT EXISTS schedules (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cron TEXT NOT NULL,
+			timezone TEXT NOT NULL DEFAULT '',
+			channel TEXT NOT NULL,
+			template TEXT NOT NULL DEFAULT '',
+			text TEXT NOT NULL DEFAULT '',
+			delivery TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			next_run TEXT NOT NULL,
+			scheduled_channel_id TEXT NOT NULL DEFAULT '',
+			scheduled_message_id TEXT NOT NULL DEFAULT '',
+			runs INTEGER NOT NULL DEFAULT 0,
+			last_run TEXT NOT NULL DEFAULT '',
+			last_ts TEXT NOT NULL DEFAULT '',
+			last_error TEXT NOT NULL DEFAULT ''
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("init schedules: %w", err)
+		}
+	}
+	return nil
+}
+
+// Add stores a schedule with its first occurrence after now and returns it with its ID.
+func (s *Store) Add(ctx context.Context, sched Schedule, now time.Time) (Schedule, error) {
+	if strings.TrimSpace(sched.Channel) == "" {
+		return Schedule{}, errors.New("channel is required")
+	}
+	if sched.Template == "" && sched.Text == "" {
+		return Schedule{}, errors.New("template or text is required")
+	}
+	if sched.Delivery == "" {
+		sched.Delivery = DeliveryPost
+	}
+	next, err := sched.NextAfter(now)
+	if err != nil {
+		return Schedule{}, err
+	}
+	sched.CreatedAt = now
+	sched.NextRun = next
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO schedules (cron, timezone, channel, template, text, delivery, created_at, next_run)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		sched.Cron, sched.Timezone, sched.Channel, sched.Template, sched.Text, sched.Delivery, formatTime(now), formatTime(next))
+	if err != nil {
+		return Schedule{}, fmt.Errorf("add schedule: %w", err)
+	}
+	if sched.ID, err = res.LastInsertId(); err != nil {
+		return Schedule{}, fmt.Errorf("read schedule id: %w", err)
+	}
+	return sched, nil
+}
+
+// Get returns one schedule by ID.
+func (s *Store) Get(ctx context.Context, id int64) (Schedule, bool, error) {
+	sched, err := scanSchedule(s.db.QueryRowContext(ctx, `SELECT `+scheduleColumns+` FROM schedules WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Schedule{}, false, nil
+	}
+	if err != nil {
+		return Schedule{}, false, fmt.Errorf("get schedule: %w", err)
+	}
+	return sched, true, nil
+}
+
+// List returns every schedule in ID order.
+func (s *Store) List(ctx context.Context) ([]Schedule, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+scheduleColumns+` FROM schedules ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("list schedules: %w", err)
+	}
+	defer rows.Close()
+	schedules := []Schedule{}
+	for rows.Next() {
+		sched, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan schedule: %w", err)
+		}
+		schedules = append(schedules, sched)
+	}
+	return schedules, rows.Err()
+}
+
+// Remove deletes a schedule and reports whether it existed.
+func (s *Store) Remove(ctx context.Context, id int64) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM schedules WHERE id = ?`, id)
+	if err != nil {
+		return false, fmt.Errorf("remove schedule: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// Claim advances a schedule whose occurrence at sched.NextRun is being delivered to its
+// following occurrence, and reports whether this caller won it. Two daemons, or a
+// daemon and a removal, racing for the same occurrence cannot both win, so each
+// occurrence is posted at most once.
+func (s *Store) Claim(ctx context.Context, sched Schedule, next time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE schedules SET next_run = ?, scheduled_message_id = '', scheduled_channel_id = ''
+		 WHERE id = ? AND next_run = ? AND scheduled_channel_id = ?`,
+		formatTime(next), sched.ID, formatTime(sched.NextRun), sched.ScheduledChannelID)
+	if err != nil {
+		return false, fmt.Errorf("claim schedule: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// ClaimHandOff reserves the occurrence at sched.NextRun for chat.scheduleMessage in
+// channelID, and reports whether this caller won it. The reservation is made before
+// Slack is called so two daemons cannot both schedule the same occurrence.
+func (s *Store) ClaimHandOff(ctx context.Context, sched Schedule, channelID string) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE schedules SET scheduled_channel_id = ?, scheduled_message_id = '' WHERE id = ? AND next_run = ? AND scheduled_channel_id = ''`,
+		channelID, sched.ID, formatTime(sched.NextRun))
+	if err != nil {
+		return false, fmt.Errorf("claim schedule hand-off: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// CompleteHandOff records the scheduled_message_id of a claimed hand-off; it may be
+// empty when Slack did not report it.
+func (s *Store) CompleteHandOff(ctx context.Context, id int64, scheduledID string) error {
+	if _, err := s.db.ExecContext(ctx, `UPDATE schedules SET scheduled_message_id = ?, last_error = '' WHERE id = ?`, scheduledID, id); err != nil {
+		return fmt.Errorf("record scheduled message: %w", err)
+	}
+	return nil
+}
+
+// ReleaseHandOff gives back a claimed hand-off that Slack refused, so the daemon posts
+// the occurrence itself when it is due.
+func (s *Store) ReleaseHandOff(ctx context.Context, id int64, cause string) error {
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE schedules SET scheduled_channel_id = '', scheduled_message_id = '', last_error = ? WHERE id = ?`, cause, id); err != nil {
+		return fmt.Errorf("release schedule hand-off: %w", err)
+	}
+	return nil
+}
+
+// RecordRun records the outcome of the occurrence at ranAt. An empty ts with a cause
+// records a failure; a skipped occurrence records only the cause.
+func (s *Store) RecordRun(ctx context.Context, id int64, ranAt time.Time, ts, cause string) error {
+	runs := 0
+	if cause == "" {
+		runs = 1
+	}
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE schedules SET runs = runs + ?, last_run = ?, last_ts = CASE WHEN ? = '' THEN last_ts ELSE ? END, last_error = ? WHERE id = ?`,
+		runs, formatTime(ranAt), ts, ts, cause, id)
+	if err != nil {
+		return fmt.Errorf("record schedule run: %w", err)
+	}
+	return nil
+}
+
+const scheduleColumns = `id, cron, timezone, channel, template, text, delivery, created_at, next_run, scheduled_channel_id, scheduled_message_id, runs, last_run, last_ts, last_error`
+
+func scanSchedule(scanner interface {
+	Scan(dest ...interface{}) error
+}) (Schedule, error) {
+	var sched Schedule
+	var createdAt, nextRun, lastRun string
+	if err := scanner.Scan(&sched.ID, &sched.Cron, &sched.Timezone, &sched.Channel, &sched.Template, &sched.Text,
+		&sched.Delivery, &createdAt, &nextRun, &sched.ScheduledChannelID, &sched.ScheduledMessageID, &sched.Runs,
+		&lastRun, &sched.LastTS, &sched.LastError); err != nil {
+		return Schedule{}, err
+	}
+	sched.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	sched.NextRun, _ = time.Parse(time.RFC3339Nano, nextRun)
+	if t, err := time.Parse(time.RFC3339Nano, lastRun); err == nil {
+		sched.LastRun = &t
+	}
+	if loc, err := sched.Location(); err == nil {
+		sched.CreatedAt = sched.CreatedAt.In(loc)
+		sched.NextRun = sched.NextRun.In(loc)
+	}
+	return sched, nil
+}
+
+// formatTime stores times as fixed-width UTC so they compare correctly as text.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000000Z")
+}
@@ -0,0 +1,245 @@
+package segment
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func line(i int) string {
+	return fmt.Sprintf(`{"ts":"%d.000100","text":"message %d"}`+"\n", i, i)
+}
+
+func writeLines(t *testing.T, w *Writer, from, to int) string {
+	t.Helper()
+	var all strings.Builder
+	for i := from; i < to; i++ {
+		if _, err := w.Write([]byte(line(i))); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		all.WriteString(line(i))
+	}
+	return all.String()
+}
+
+// readSegments checks every closed segment against its manifest entry and returns the
+// decompressed contents of all segments.
+func readSegments(t *testing.T, m *Manifest) string {
+	t.Helper()
+	dir := filepath.Dir(m.Output)
+	var all strings.Builder
+	for _, s := range m.Segments {
+		data, err := os.ReadFile(filepath.Join(dir, s.File))
+		if err != nil {
+			t.Fatalf("read segment: %v", err)
+		}
+		if int64(len(data)) != s.Bytes {
+			t.Errorf("%s is %d bytes, manifest says %d", s.File, len(data), s.Bytes)
+		}
+		if sum := sha256.Sum256(data); s.SHA256 != hex.EncodeToString(sum[:]) {
+			t.Errorf("%s checksum = %s, want %x", s.File, s.SHA256, sum)
+		}
+		var text []byte
+		switch m.Compression {
+		case Gzip:
+			r, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("gzip %s: %v", s.File, err)
+			}
+			if text, err = io.ReadAll(r); err != nil {
+				t.Fatalf("gunzip %s: %v", s.File, err)
+			}
+		case Zstd:
+			c := exec.Command("zstd", "-d", "-c", "-q")
+			c.Stdin = bytes.NewReader(data)
+			if text, err = c.Output(); err != nil {
+				t.Fatalf("unzstd %s: %v", s.File, err)
+			}
+		default:
+			text = data
+		}
+		if got := int64(bytes.Count(text, []byte("\n"))); got != s.Lines {
+			t.Errorf("%s has %d lines, manifest says %d", s.File, got, s.Lines)
+		}
+		if int64(len(text)) != s.UncompressedBytes {
+			t.Errorf("%s has %d uncompressed bytes, manifest says %d", s.File, len(text), s.UncompressedBytes)
+		}
+		all.Write(text)
+	}
+	return all.String()
+}
+
+func TestWriterRotatesBySize(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "general.ndjson")
+	w, err := Open(New(out, Options{Compress: Gzip, MaxBytes: 200}), false)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	want := writeLines(t, w, 0, 10)
+	if err := w.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	want += writeLines(t, w, 10, 20)
+	if err := w.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	m, err := LoadManifest(ManifestPath(out))
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if !m.Complete || len(m.Segments) < 3 || m.Lines() != 20 {
+		t.Fatalf("manifest complete=%v segments=%d lines=%d, want complete with 20 lines over several segments", m.Complete, len(m.Segments), m.Lines())
+	}
+	if m.Segments[0].File != "general.000001.ndjson.gz" {
+		t.Errorf("first segment = %s", m.Segments[0].File)
+	}
+	for _, s := range m.Segments {
+		if s.UncompressedBytes > 200 {
+			t.Errorf("%s holds %d bytes, over the 200 byte limit", s.File, s.UncompressedBytes)
+		}
+	}
+	if got := readSegments(t, m); got != want {
+		t.Errorf("segments hold %q, want %q", got, want)
+	}
+}
+
+func TestWriterRotatesByAge(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "general.ndjson")
+	w, err := Open(New(out, Options{MaxAge: time.Hour}), false)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	// The first segment was opened with the real clock; start the test's clock there.
+	w.manifest.Segments[0].StartedAt = now
+
+	want := writeLines(t, w, 0, 3)
+	now = now.Add(59 * time.Minute)
+	want += writeLines(t, w, 3, 4)
+	now = now.Add(time.Minute)
+	want += writeLines(t, w, 4, 5)
+	if err := w.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	m, _ := LoadManifest(ManifestPath(out))
+	if len(m.Segments) != 2 || m.Segments[0].Lines != 4 || m.Segments[1].File != "general.000002.ndjson" {
+		t.Fatalf("segments = %+v, want 4 lines then a new segment after an hour", m.Segments)
+	}
+	if got := readSegments(t, m); got != want {
+		t.Errorf("segments hold %q, want %q", got, want)
+	}
+}
+
+func TestWriterResumesFromSyncedState(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "general.ndjson")
+	w, err := Open(New(out, Options{Compress: Gzip, MaxBytes: 200}), false)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	want := writeLines(t, w, 0, 6)
+	if err := w.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	state := w.State()
+	// Written after the checkpoint, including a rotation, then interrupted.
+	writeLines(t, w, 6, 20)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	w, err = Open(state, true)
+	if err != nil {
+		t.Fatalf("Open(resume): %v", err)
+	}
+	want += writeLines(t, w, 6, 12)
+	if err := w.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	m, _ := LoadManifest(ManifestPath(out))
+	if got := readSegments(t, m); got != want {
+		t.Errorf("segments hold %q, want %q", got, want)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "general.0*"))
+	if len(files) != len(m.Segments) {
+		t.Errorf("found segment files %v, manifest lists %d", files, len(m.Segments))
+	}
+}
+
+func TestWriterCompressOnly(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "general.ndjson")
+	// Segments from an earlier rotated export to the same output are removed.
+	if err := os.WriteFile(filepath.Join(dir, "general.000001.ndjson.gz"), []byte("stale"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := Open(New(out, Options{Compress: Gzip}), false)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	want := writeLines(t, w, 0, 50)
+	if err := w.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	m, _ := LoadManifest(ManifestPath(out))
+	if len(m.Segments) != 1 || m.Segments[0].File != "general.ndjson.gz" {
+		t.Fatalf("segments = %+v, want only general.ndjson.gz", m.Segments)
+	}
+	if m.Segments[0].Bytes >= m.Segments[0].UncompressedBytes {
+		t.Errorf("compressed %d bytes to %d", m.Segments[0].UncompressedBytes, m.Segments[0].Bytes)
+	}
+	if got := readSegments(t, m); got != want {
+		t.Errorf("segment holds %q, want %q", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "general.000001.ndjson.gz")); !os.IsNotExist(err) {
+		t.Errorf("stale segment was not removed: %v", err)
+	}
+}
+
+func TestWriterZstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd not installed")
+	}
+	out := filepath.Join(t.TempDir(), "general.ndjson")
+	w, err := Open(New(out, Options{Compress: Zstd, MaxBytes: 300}), false)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	want := writeLines(t, w, 0, 5)
+	if err := w.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	want += writeLines(t, w, 5, 15)
+	if err := w.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	m, _ := LoadManifest(ManifestPath(out))
+	if !strings.HasSuffix(m.Segments[0].File, ".ndjson.zst") {
+		t.Errorf("first segment = %s", m.Segments[0].File)
+	}
+	if got := readSegments(t, m); got != want {
+		t.Errorf("segments hold %q, want %q", got, want)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	if err := (Options{Compress: "brotli"}).Validate(); err == nil || !strings.Contains(err.Error(), "unknown compression") {
+		t.Errorf("Validate(brotli) = %v", err)
+	}
+	if err := (Options{MaxBytes: -1}).Validate(); err == nil {
+		t.Error("Validate accepted a negative size")
+	}
+}
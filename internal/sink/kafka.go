@@ -0,0 +1,135 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const kafkaRESTContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaRESTPublisher produces records through a Confluent Kafka REST Proxy (API v2).
+// Each message becomes one JSON record keyed by Message.Key.
+type kafkaRESTPublisher struct {
+	endpoint string
+	topic    string
+	user     string
+	pass     string
+	client   *http.Client
+}
+
+type kafkaRESTRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaRESTOffsets struct {
+	Offsets []struct {
+		Partition *int   `json:"partition"`
+		Offset    *int64 `json:"offset"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+type kafkaRESTError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func openKafkaREST(ctx context.Context, u *url.URL, topic string) (*kafkaRESTPublisher, error) {
+	base := url.URL{Scheme: strings.TrimPrefix(u.Scheme, "kafka+"), Host: u.Host, Path: strings.TrimRight(u.Path, "/")}
+	p := &kafkaRESTPublisher{
+		endpoint: base.String() + "/topics/" + url.PathEscape(topic),
+		topic:    topic,
+		client:   &http.Client{Timeout: Timeout},
+	}
+	if u.User != nil {
+		p.user = u.User.Username()
+		p.pass, _ = u.User.Password()
+	}
+	// Check the topic exists up front so a typo fails at start, not on the first event.
+	req, err := p.request(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("connect to Kafka REST Proxy %s: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("Kafka topic %s not found at %s", topic, u.Host)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, p.statusError(resp)
+	}
+	return p, nil
+}
+
+// Publish produces msg as one record and checks the proxy's per-record result.
+func (p *kafkaRESTPublisher) Publish(ctx context.Context, msg Message) error {
+	if !json.Valid(msg.Value) {
+		return fmt.Errorf("Kafka REST Proxy records must be JSON")
+	}
+	body, err := json.Marshal(map[string][]kafkaRESTRecord{"records": {{Key: msg.Key, Value: msg.Value}}})
+	if err != nil {
+		return err
+	}
+	req, err := p.request(ctx, http.MethodPost, body)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("produce to Kafka topic %s: %w", p.topic, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return p.statusError(resp)
+	}
+	var result kafkaRESTOffsets
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("parse Kafka REST Proxy response: %w", err)
+	}
+	for _, o := range result.Offsets {
+		if o.ErrorCode != nil || o.Error != "" {
+			return fmt.Errorf("produce to Kafka topic %s: %s", p.topic, o.Error)
+		}
+	}
+	return nil
+}
+
+func (p *kafkaRESTPublisher) request(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if body != nil {
+		req.Header.Set("Content-Type", kafkaRESTContentType)
+	}
+	if p.user != "" {
+		req.SetBasicAuth(p.user, p.pass)
+	}
+	return req, nil
+}
+
+func (p *kafkaRESTPublisher) statusError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var e kafkaRESTError
+	if json.Unmarshal(data, &e) == nil && e.Message != "" {
+		return fmt.Errorf("Kafka REST Proxy: %s (error_code %d)", e.Message, e.ErrorCode)
+	}
+	return fmt.Errorf("Kafka REST Proxy: %s", resp.Status)
+}
+
+// Close does nothing; requests do not hold a connection open.
+func (p *kafkaRESTPublisher) Close() error {
+	return nil
+}
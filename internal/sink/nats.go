@@ -0,0 +1,172 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// natsPublisher publishes with core NATS. Each PUB is followed by a PING, and the
+// publish succeeds once the PONG arrives, so permission errors and dropped
+// connections surface on the event that hit them rather than silently later.
+type natsPublisher struct {
+	host    string
+	subject string
+	user    string
+	pass    string
+	token   string
+	conn    net.Conn
+	r       *bufio.Reader
+}
+
+func openNATS(ctx context.Context, u *url.URL, subject string) (*natsPublisher, error) {
+	if strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("NATS subject %q must not contain whitespace", subject)
+	}
+	p := &natsPublisher{host: u.Host, subject: subject}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			p.user, p.pass = u.User.Username(), pass
+		} else {
+			p.token = u.User.Username()
+		}
+	}
+	if err := p.connect(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *natsPublisher) connect(ctx context.Context) error {
+	c, err := dial(ctx, p.host, "4222")
+	if err != nil {
+		return fmt.Errorf("connect to NATS %s: %w", p.host, err)
+	}
+	_ = c.SetDeadline(deadline(ctx))
+	r := bufio.NewReader(c)
+	line, err := readLine(r)
+	if err != nil {
+		c.Close()
+		return fmt.Errorf("read NATS INFO: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		c.Close()
+		return fmt.Errorf("NATS %s: expected INFO, got %q", p.host, line)
+	}
+	var info struct {
+		TLSRequired bool `json:"tls_required"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		c.Close()
+		return fmt.Errorf("parse NATS INFO: %w", err)
+	}
+	if info.TLSRequired {
+		serverName, _, err := net.SplitHostPort(p.host)
+		if err != nil {
+			serverName = p.host
+		}
+		tc := tls.Client(c, &tls.Config{ServerName: serverName})
+		if err := tc.HandshakeContext(ctx); err != nil {
+			c.Close()
+			return fmt.Errorf("NATS TLS handshake: %w", err)
+		}
+		c, r = tc, bufio.NewReader(tc)
+	}
+
+	options, err := json.Marshal(map[string]any{
+		"verbose":    false,
+		"pedantic":   false,
+		"name":       "slk",
+		"lang":       "go",
+		"protocol":   1,
+		"user":       p.user,
+		"pass":       p.pass,
+		"auth_token": p.token,
+	})
+	if err != nil {
+		c.Close()
+		return err
+	}
+	p.conn, p.r = c, r
+	if _, err := fmt.Fprintf(c, "CONNECT %s\r\nPING\r\n", options); err != nil {
+		p.disconnect()
+		return fmt.Errorf("send NATS CONNECT: %w", err)
+	}
+	if err := p.awaitPong(); err != nil {
+		p.disconnect()
+		return fmt.Errorf("NATS %s: %w", p.host, err)
+	}
+	return nil
+}
+
+func (p *natsPublisher) connected() bool { return p.conn != nil }
+
+func (p *natsPublisher) disconnect() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn, p.r = nil, nil
+	}
+}
+
+// Publish sends msg.Value to the subject and waits for the server to confirm it
+// processed the message.
+func (p *natsPublisher) Publish(ctx context.Context, msg Message) error {
+	return withReconnect(ctx, p, func() error {
+		_ = p.conn.SetDeadline(deadline(ctx))
+		frame := make([]byte, 0, len(msg.Value)+len(p.subject)+32)
+		frame = fmt.Appendf(frame, "PUB %s %d\r\n", p.subject, len(msg.Value))
+		frame = append(frame, msg.Value...)
+		frame = append(frame, "\r\nPING\r\n"...)
+		if _, err := p.conn.Write(frame); err != nil {
+			return fmt.Errorf("publish to NATS: %w", err)
+		}
+		if err := p.awaitPong(); err != nil {
+			return fmt.Errorf("publish to NATS subject %s: %w", p.subject, err)
+		}
+		return nil
+	})
+}
+
+// awaitPong reads server messages until the PONG for our PING, answering the
+// server's own PINGs on the way.
+func (p *natsPublisher) awaitPong() error {
+	for {
+		line, err := readLine(p.r)
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+		case strings.HasPrefix(line, "-ERR"):
+			return &serverError{msg: strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'")}
+		default:
+			return fmt.Errorf("unexpected NATS message %q", line)
+		}
+	}
+}
+
+// Close closes the connection.
+func (p *natsPublisher) Close() error {
+	p.disconnect()
+	return nil
+}
+
+// readLine reads one CRLF-terminated protocol line without its terminator.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
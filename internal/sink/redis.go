@@ -0,0 +1,176 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// redisPublisher appends each message to a Redis stream with XADD. Entries have an
+// "event" field holding the message and, when the message has a key, a "channel"
+// field holding it.
+type redisPublisher struct {
+	host   string
+	tls    bool
+	stream string
+	user   string
+	pass   string
+	db     int
+	maxLen int
+	conn   net.Conn
+	r      *bufio.Reader
+}
+
+func openRedis(ctx context.Context, u *url.URL, stream string) (*redisPublisher, error) {
+	p := &redisPublisher{host: u.Host, tls: u.Scheme == "rediss", stream: stream}
+	if u.User != nil {
+		p.user = u.User.Username()
+		if pass, ok := u.User.Password(); ok {
+			p.pass = pass
+		} else {
+			// redis://secret@host is a password with the default user.
+			p.user, p.pass = "", p.user
+		}
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		n, err := strconv.Atoi(db)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("redis database %q must be a non-negative number", db)
+		}
+		p.db = n
+	}
+	if v := u.Query().Get("maxlen"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("redis maxlen %q must be a positive number", v)
+		}
+		p.maxLen = n
+	}
+	if err := p.connect(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *redisPublisher) connect(ctx context.Context) error {
+	c, err := dial(ctx, p.host, "6379")
+	if err != nil {
+		return fmt.Errorf("connect to Redis %s: %w", p.host, err)
+	}
+	if p.tls {
+		serverName, _, err := net.SplitHostPort(p.host)
+		if err != nil {
+			serverName = p.host
+		}
+		tc := tls.Client(c, &tls.Config{ServerName: serverName})
+		if err := tc.HandshakeContext(ctx); err != nil {
+			c.Close()
+			return fmt.Errorf("Redis TLS handshake: %w", err)
+		}
+		c = tc
+	}
+	p.conn, p.r = c, bufio.NewReader(c)
+	_ = c.SetDeadline(deadline(ctx))
+	if p.pass != "" {
+		args := []string{"AUTH", p.pass}
+		if p.user != "" {
+			args = []string{"AUTH", p.user, p.pass}
+		}
+		if _, err := p.do(args...); err != nil {
+			p.disconnect()
+			return fmt.Errorf("Redis AUTH: %w", err)
+		}
+	}
+	if p.db != 0 {
+		if _, err := p.do("SELECT", strconv.Itoa(p.db)); err != nil {
+			p.disconnect()
+			return fmt.Errorf("Redis SELECT %d: %w", p.db, err)
+		}
+	}
+	return nil
+}
+
+func (p *redisPublisher) connected() bool { return p.conn != nil }
+
+func (p *redisPublisher) disconnect() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn, p.r = nil, nil
+	}
+}
+
+// Publish appends msg to the stream, trimming it to about maxlen entries when set.
+func (p *redisPublisher) Publish(ctx context.Context, msg Message) error {
+	args := []string{"XADD", p.stream}
+	if p.maxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.Itoa(p.maxLen))
+	}
+	args = append(args, "*", "event", string(msg.Value))
+	if msg.Key != "" {
+		args = append(args, "channel", msg.Key)
+	}
+	return withReconnect(ctx, p, func() error {
+		_ = p.conn.SetDeadline(deadline(ctx))
+		if _, err := p.do(args...); err != nil {
+			return fmt.Errorf("XADD to Redis stream %s: %w", p.stream, err)
+		}
+		return nil
+	})
+}
+
+// do sends one command and reads its reply.
+func (p *redisPublisher) do(args ...string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(p.conn, b.String()); err != nil {
+		return "", err
+	}
+	return readReply(p.r)
+}
+
+// readReply reads a simple, integer, error, or bulk string reply.
+func readReply(r *bufio.Reader) (string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return "", err
+	}
+	if line == "" {
+		return "", fmt.Errorf("empty Redis reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", &serverError{msg: line[1:]}
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("bad Redis bulk length %q", line)
+		}
+		if n < 0 {
+			return "", nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:n]), nil
+	default:
+		return "", fmt.Errorf("unexpected Redis reply %q", line)
+	}
+}
+
+// Close closes the connection.
+func (p *redisPublisher) Close() error {
+	p.disconnect()
+	return nil
+}
@@ -0,0 +1,122 @@
+// Package sink publishes watched Slack events to message queues, so slk watch can
+// feed an existing streaming pipeline without a bridge process in between.
+//
+// Sinks are picked by URL scheme:
+//
+//	nats://[user:pass@|token@]host[:4222]             core NATS PUB to a subject
+//	redis://[user:pass@]host[:6379][/db][?maxlen=N]    XADD to a Redis stream (rediss:// for TLS)
+//	kafka+http://host[:8082], kafka+https://...         Kafka through a Confluent REST Proxy (v2 API)
+//
+// The NATS and Redis clients speak the wire protocol directly and only cover what
+// publishing needs. Kafka's binary protocol is out of scope, so Kafka topics are
+// reached through the REST Proxy.
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Timeout bounds connecting and each publish when the context has no deadline.
+const Timeout = 10 * time.Second
+
+// Message is one event to publish.
+type Message struct {
+	// Key groups related messages: the Redis stream entry's channel field and the
+	// Kafka record key, so one channel's events stay in order on one partition.
+	// NATS ignores it.
+	Key   string
+	Value []byte
+}
+
+// Publisher sends messages to one subject, stream, or topic.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Open connects to the sink at rawURL, publishing to subject: the NATS subject,
+// Redis stream key, or Kafka topic.
+func Open(ctx context.Context, rawURL, subject string) (Publisher, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("parse sink URL: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("sink URL %q has no host", rawURL)
+	}
+	if strings.TrimSpace(subject) == "" {
+		return nil, errors.New("sink subject must not be empty")
+	}
+	switch u.Scheme {
+	case "nats":
+		return openNATS(ctx, u, subject)
+	case "redis", "rediss":
+		return openRedis(ctx, u, subject)
+	case "kafka+http", "kafka+https":
+		return openKafkaREST(ctx, u, subject)
+	case "kafka":
+		return nil, errors.New("kafka:// brokers are not supported directly; point --sink at a Kafka REST Proxy as kafka+http://host:8082")
+	default:
+		return nil, fmt.Errorf("unsupported sink scheme %q (want nats, redis, rediss, kafka+http, or kafka+https)", u.Scheme)
+	}
+}
+
+// serverError is an error the sink reported, as opposed to a broken connection.
+// Reconnecting does not help with these, so they are not retried.
+type serverError struct {
+	msg string
+}
+
+func (e *serverError) Error() string { return e.msg }
+
+// conn is a reconnecting TCP connection shared by the NATS and Redis publishers.
+type conn interface {
+	connect(ctx context.Context) error
+	disconnect()
+	connected() bool
+}
+
+// withReconnect runs publish on an open connection and, when it fails with a
+// connection error, once more on a fresh one, so a connection the server dropped
+// while watch sat idle does not end the stream. A retried message may be delivered
+// twice.
+func withReconnect(ctx context.Context, c conn, publish func() error) error {
+	if !c.connected() {
+		if err := c.connect(ctx); err != nil {
+			return err
+		}
+	}
+	err := publish()
+	var serr *serverError
+	if err == nil || errors.As(err, &serr) || ctx.Err() != nil {
+		return err
+	}
+	c.disconnect()
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+	return publish()
+}
+
+// dial opens a TCP connection to host, adding port when host has none.
+func dial(ctx context.Context, host, port string) (net.Conn, error) {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, port)
+	}
+	d := net.Dialer{Timeout: Timeout}
+	return d.DialContext(ctx, "tcp", host)
+}
+
+// deadline is the context's deadline, or Timeout from now.
+func deadline(ctx context.Context) time.Time {
+	if d, ok := ctx.Deadline(); ok {
+		return d
+	}
+	return time.Now().Add(Timeout)
+}
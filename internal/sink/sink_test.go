@@ -0,0 +1,285 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// listen starts a TCP server that hands each connection to serve, and returns its
+// address.
+func listen(t *testing.T, serve func(net.Conn)) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				serve(c)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// fakeNATS answers CONNECT and PING, records published payloads, and rejects
+// subjects starting with "denied.".
+func fakeNATS(published chan<- string) func(net.Conn) {
+	return func(c net.Conn) {
+		r := bufio.NewReader(c)
+		fmt.Fprint(c, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+		for {
+			line, err := readLine(r)
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				published <- line
+			case line == "PING":
+				fmt.Fprint(c, "PONG\r\n")
+			case strings.HasPrefix(line, "PUB "):
+				fields := strings.Fields(line)
+				n, _ := strconv.Atoi(fields[2])
+				payload := make([]byte, n+2)
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return
+				}
+				if strings.HasPrefix(fields[1], "denied.") {
+					fmt.Fprintf(c, "-ERR 'Permissions Violation for Publish to \"%s\"'\r\n", fields[1])
+					continue
+				}
+				published <- fields[1] + " " + string(payload[:n])
+			}
+		}
+	}
+}
+
+func TestNATSPublish(t *testing.T) {
+	published := make(chan string, 10)
+	addr := listen(t, fakeNATS(published))
+
+	p, err := Open(context.Background(), "nats://alice:secret@"+addr, "slack.events")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+	connect := <-published
+	if !strings.Contains(connect, `"user":"alice"`) || !strings.Contains(connect, `"pass":"secret"`) {
+		t.Errorf("CONNECT = %s, want credentials from the URL", connect)
+	}
+
+	for _, value := range []string{`{"ts":"1"}`, `{"ts":"2"}`} {
+		if err := p.Publish(context.Background(), Message{Key: "C1", Value: []byte(value)}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		if got, want := <-published, "slack.events "+value; got != want {
+			t.Errorf("published %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNATSPublishReportsServerErrors(t *testing.T) {
+	published := make(chan string, 10)
+	addr := listen(t, fakeNATS(published))
+
+	p, err := Open(context.Background(), "nats://"+addr, "denied.events")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+	err = p.Publish(context.Background(), Message{Value: []byte(`{}`)})
+	if err == nil || !strings.Contains(err.Error(), "Permissions Violation") {
+		t.Fatalf("Publish error = %v, want the server's -ERR", err)
+	}
+}
+
+func TestNATSPublishReconnects(t *testing.T) {
+	published := make(chan string, 10)
+	var conns atomic.Int32
+	addr := listen(t, func(c net.Conn) {
+		if conns.Add(1) == 1 {
+			// Accept the connection, then drop it before the first publish.
+			r := bufio.NewReader(c)
+			fmt.Fprint(c, "INFO {}\r\n")
+			readLine(r)
+			readLine(r)
+			fmt.Fprint(c, "PONG\r\n")
+			return
+		}
+		fakeNATS(published)(c)
+	})
+
+	p, err := Open(context.Background(), "nats://"+addr, "slack.events")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+	if err := p.Publish(context.Background(), Message{Value: []byte(`{"ts":"1"}`)}); err != nil {
+		t.Fatalf("Publish after a dropped connection: %v", err)
+	}
+	<-published // CONNECT on the new connection
+	if got := <-published; got != `slack.events {"ts":"1"}` {
+		t.Errorf("published %q", got)
+	}
+}
+
+// fakeRedis answers AUTH, SELECT, and XADD, recording each command.
+func fakeRedis(commands chan<- []string) func(net.Conn) {
+	return func(c net.Conn) {
+		r := bufio.NewReader(c)
+		for {
+			line, err := readLine(r)
+			if err != nil || !strings.HasPrefix(line, "*") {
+				return
+			}
+			n, _ := strconv.Atoi(line[1:])
+			args := make([]string, n)
+			for i := range args {
+				header, _ := readLine(r)
+				size, _ := strconv.Atoi(header[1:])
+				buf := make([]byte, size+2)
+				if _, err := io.ReadFull(r, buf); err != nil {
+					return
+				}
+				args[i] = string(buf[:size])
+			}
+			commands <- args
+			switch args[0] {
+			case "AUTH":
+				if args[len(args)-1] != "secret" {
+					fmt.Fprint(c, "-WRONGPASS invalid username-password pair\r\n")
+					continue
+				}
+				fmt.Fprint(c, "+OK\r\n")
+			case "SELECT":
+				fmt.Fprint(c, "+OK\r\n")
+			case "XADD":
+				fmt.Fprint(c, "$15\r\n1705312365000-0\r\n")
+			default:
+				fmt.Fprintf(c, "-ERR unknown command '%s'\r\n", args[0])
+			}
+		}
+	}
+}
+
+func TestRedisPublish(t *testing.T) {
+	commands := make(chan []string, 10)
+	addr := listen(t, fakeRedis(commands))
+
+	p, err := Open(context.Background(), "redis://:secret@"+addr+"/2?maxlen=1000", "slack:events")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+	if got := strings.Join(<-commands, " "); got != "AUTH secret" {
+		t.Errorf("first command = %q, want AUTH with only the password", got)
+	}
+	if got := strings.Join(<-commands, " "); got != "SELECT 2" {
+		t.Errorf("second command = %q, want SELECT 2", got)
+	}
+
+	if err := p.Publish(context.Background(), Message{Key: "C123", Value: []byte(`{"ts":"1"}`)}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	want := `XADD slack:events MAXLEN ~ 1000 * event {"ts":"1"} channel C123`
+	if got := strings.Join(<-commands, " "); got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+}
+
+func TestRedisOpenRejectsWrongPassword(t *testing.T) {
+	commands := make(chan []string, 10)
+	addr := listen(t, fakeRedis(commands))
+
+	_, err := Open(context.Background(), "redis://default:wrong@"+addr, "slack:events")
+	if err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Fatalf("Open error = %v, want WRONGPASS", err)
+	}
+	if got := strings.Join(<-commands, " "); got != "AUTH default wrong" {
+		t.Errorf("command = %q, want AUTH with user and password", got)
+	}
+}
+
+func TestKafkaRESTPublish(t *testing.T) {
+	var produced []map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/proxy/topics/slack.events" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error_code":40401,"message":"Topic not found."}`)
+			return
+		}
+		if user, pass, _ := r.BasicAuth(); user != "svc" || pass != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"name":"slack.events"}`)
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != kafkaRESTContentType {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		var body struct {
+			Records []map[string]json.RawMessage `json:"records"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		produced = append(produced, body.Records...)
+		fmt.Fprint(w, `{"offsets":[{"partition":0,"offset":7,"error_code":null,"error":null}]}`)
+	}))
+	defer server.Close()
+	base := "kafka+" + strings.Replace(server.URL, "://", "://svc:pw@", 1) + "/proxy"
+
+	p, err := Open(context.Background(), base, "slack.events")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+	if err := p.Publish(context.Background(), Message{Key: "C123", Value: []byte(`{"ts":"1"}`)}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(produced) != 1 || string(produced[0]["key"]) != `"C123"` || string(produced[0]["value"]) != `{"ts":"1"}` {
+		t.Errorf("produced = %s", produced)
+	}
+
+	if _, err := Open(context.Background(), base, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Open missing topic error = %v, want not found", err)
+	}
+}
+
+func TestOpenRejectsUnsupportedURLs(t *testing.T) {
+	for _, tc := range []struct {
+		url, subject, want string
+	}{
+		{"kafka://broker:9092", "events", "REST Proxy"},
+		{"amqp://broker", "events", "unsupported sink scheme"},
+		{"nats://", "events", "no host"},
+		{"nats://127.0.0.1:1", "", "subject must not be empty"},
+		{"nats://127.0.0.1:1", "slack events", "whitespace"},
+		{"redis://127.0.0.1:1/x", "events", "non-negative number"},
+	} {
+		_, err := Open(context.Background(), tc.url, tc.subject)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Open(%q, %q) error = %v, want %q", tc.url, tc.subject, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// Invite methods, in the order InviteUser tries them.
+const (
+	MethodAdminInvite  = "admin.users.invite"
+	MethodLegacyInvite = "users.admin.invite"
+)
+
+// adminInviteUnavailable lists the admin.users.invite errors that mean the token or
+// workspace cannot use the Enterprise Grid method at all, so the legacy method is
+// worth trying. Any other error (invalid_email, already_in_team, ...) is final.
+var adminInviteUnavailable = map[string]bool{
+	"not_an_enterprise":      true,
+	"not_allowed_token_type": true,
+	"missing_scope":          true,
+	"feature_not_enabled":    true,
+	"unknown_method":         true,
+	"team_not_found":         true,
+}
+
+// InviteParams wraps arguments for InviteUser.
+type InviteParams struct {
+	Email string
+	// ChannelIDs are the channels the invitee joins. admin.users.invite needs at least
+	// one.
+	ChannelIDs []string
+	// TeamID is the workspace to invite to; admin.users.invite requires it.
+	TeamID   string
+	RealName string
+	// Guest invites a single-channel guest (ultra restricted) instead of a member.
+	Guest         bool
+	CustomMessage string
+	Resend        bool
+}
+
+// InviteUser invites an email address to the workspace. It calls admin.users.invite,
+// which needs an Enterprise Grid org admin token with admin.users:write, and falls
+// back to the legacy users.admin.invite when the workspace or token cannot use it.
+// It returns the method that sent the invite.
+func (c *APIClient) InviteUser(ctx context.Context, params InviteParams) (string, error) {
+	if err := c.checkWritable(MethodAdminInvite); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(params.Email) == "" {
+		return "", ErrEmailRequired
+	}
+	for _, channel := range params.ChannelIDs {
+		if err := c.checkChannel(channel); err != nil {
+			return "", err
+		}
+	}
+
+	adminErr := c.call(ctx, MethodAdminInvite, adminInviteForm(params), nil)
+	if adminErr == nil {
+		return MethodAdminInvite, nil
+	}
+	var slackErr slackapi.SlackErrorResponse
+	if !errors.As(adminErr, &slackErr) || !adminInviteUnavailable[slackErr.Err] {
+		return MethodAdminInvite, adminErr
+	}
+	if err := c.call(ctx, MethodLegacyInvite, legacyInviteForm(params), nil); err != nil {
+		return MethodLegacyInvite, fmt.Errorf("%w (%s: %s)", err, MethodAdminInvite, slackErr.Err)
+	}
+	return MethodLegacyInvite, nil
+}
+
+func adminInviteForm(params InviteParams) url.Values {
+	form := url.Values{
+		"email":       {params.Email},
+		"channel_ids": {strings.Join(params.ChannelIDs, ",")},
+		"team_id":     {params.TeamID},
+	}
+	if params.RealName != "" {
+		form.Set("real_name", params.RealName)
+	}
+	if params.Guest {
+		form.Set("is_ultra_restricted", "true")
+	}
+	if params.CustomMessage != "" {
+		form.Set("custom_message", params.CustomMessage)
+	}
+	if params.Resend {
+		form.Set("resend", "true")
+	}
+	return form
+}
+
+func legacyInviteForm(params InviteParams) url.Values {
+	form := url.Values{"email": {params.Email}}
+	if len(params.ChannelIDs) > 0 {
+		form.Set("channels", strings.Join(params.ChannelIDs, ","))
+	}
+	if first, last, ok := strings.Cut(strings.TrimSpace(params.RealName), " "); ok {
+		form.Set("first_name", first)
+		form.Set("last_name", strings.TrimSpace(last))
+	} else if first != "" {
+		form.Set("first_name", first)
+	}
+	if params.Guest {
+		form.Set("ultra_restricted", "1")
+	}
+	if params.Resend {
+		form.Set("resend", "true")
+	}
+	return form
+}
@@ -83,9 +83,15 @@ func isMissingScopeError(err error) bool {
 
 // JoinChannel joins a channel by ID.
 func (c *APIClient) JoinChannel(ctx context.Context, channelID string) (*ChannelJoinResult, error) {
+	if err := c.checkWritable("conversations.join"); err != nil {
+		return nil, err
+	}
 	if channelID == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(channelID); err != nil {
+		return nil, err
+	}
 
 	channel, _, _, err := c.sdk.JoinConversationContext(ctx, channelID)
 	if err != nil {
@@ -106,9 +112,15 @@ func (c *APIClient) JoinChannel(ctx context.Context, channelID string) (*Channel
 
 // LeaveChannel leaves a channel by ID.
 func (c *APIClient) LeaveChannel(ctx context.Context, channelID string) (*ChannelLeaveResult, error) {
+	if err := c.checkWritable("conversations.leave"); err != nil {
+		return nil, err
+	}
 	if channelID == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(channelID); err != nil {
+		return nil, err
+	}
 
 	_, err := c.sdk.LeaveConversationContext(ctx, channelID)
 	if err != nil {
@@ -121,3 +133,78 @@ func (c *APIClient) LeaveChannel(ctx context.Context, channelID string) (*Channe
 		ChannelID: channelID,
 	}, nil
 }
+
+// ArchiveChannel archives a channel by ID.
+func (c *APIClient) ArchiveChannel(ctx context.Context, channelID string) error {
+	if err := c.checkWritable("conversations.archive"); err != nil {
+		return err
+	}
+	if channelID == "" {
+		return ErrChannelRequired
+	}
+	if err := c.checkChannel(channelID); err != nil {
+		return err
+	}
+	if err := c.sdk.ArchiveConversationContext(ctx, channelID); err != nil {
+		return fmt.Errorf("archive channel: %w", err)
+	}
+	return nil
+}
+
+// OpenDM opens (or returns the existing) direct message conversation with a user and
+// returns its ID.
+func (c *APIClient) OpenDM(ctx context.Context, userID string) (string, error) {
+	channelID, _, err := c.OpenDMConversation(ctx, userID)
+	return channelID, err
+}
+
+// OpenDMConversation is OpenDM that also reports whether the DM was already open.
+func (c *APIClient) OpenDMConversation(ctx context.Context, userID string) (string, bool, error) {
+	if err := c.checkWritable("conversations.open"); err != nil {
+		return "", false, err
+	}
+	if userID == "" {
+		return "", false, fmt.Errorf("open dm: user is required")
+	}
+	channel, _, alreadyOpen, err := c.sdk.OpenConversationContext(ctx, &slackapi.OpenConversationParameters{Users: []string{userID}})
+	if err != nil {
+		return "", false, fmt.Errorf("open dm: %w", err)
+	}
+	return channel.ID, alreadyOpen, nil
+}
+
+// OpenGroupDM opens the multi-person DM between the caller and userIDs
+// (conversations.open), creating it the first time, and reports whether it was
+// already open. With a single user it opens the one-to-one DM.
+func (c *APIClient) OpenGroupDM(ctx context.Context, userIDs []string) (string, bool, error) {
+	if err := c.checkWritable("conversations.open"); err != nil {
+		return "", false, err
+	}
+	if len(userIDs) == 0 {
+		return "", false, fmt.Errorf("open group dm: %w", ErrUserRequired)
+	}
+	channel, _, alreadyOpen, err := c.sdk.OpenConversationContext(ctx, &slackapi.OpenConversationParameters{Users: userIDs})
+	if err != nil {
+		return "", false, fmt.Errorf("open group dm: %w", err)
+	}
+	return channel.ID, alreadyOpen, nil
+}
+
+// CloseDM closes a direct message conversation, removing it from the sidebar. It
+// reports whether the DM was already closed.
+func (c *APIClient) CloseDM(ctx context.Context, channelID string) (bool, error) {
+	if err := c.checkWritable("conversations.close"); err != nil {
+		return false, err
+	}
+	if channelID == "" {
+		return false, ErrChannelRequired
+	}
+	if err := c.checkChannel(channelID); err != nil {
+		return false, err
+	}
+	_, alreadyClosed, err := c.sdk.CloseConversationContext(ctx, channelID)
+	if err != nil {
+		return false, fmt.Errorf("close dm: %w", err)
+	}
+	return alreadyClosed, nil
+}
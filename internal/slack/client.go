@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
 	slackapi "github.com/slack-go/slack"
 	"github.com/slack-go/slack/socketmode"
 )
@@ -11,12 +12,23 @@ import (
 // APIClient implements Client by wrapping slack-go's Client.
 type APIClient struct {
 	sdk *slackapi.Client
+	// token, http, and apiURL let call reach Web API methods slack-go does not wrap.
+	token    string
+	http     *http.Client
+	apiURL   string
+	readOnly bool
+	// humanWrites blocks changes made with a user token (see SetHumanWritesBlocked).
+	humanWrites bool
+	allowed     map[string]bool
+	denied      map[string]bool
 }
 
 // New creates a new APIClient using the provided user token.
 // For xoxc- tokens (client tokens), use NewWithCookie instead.
 func New(userToken string, options ...slackapi.Option) *APIClient {
-	return &APIClient{sdk: slackapi.New(userToken, options...)}
+	httpClient := defaultHTTPClient()
+	options = append([]slackapi.Option{slackapi.OptionHTTPClient(httpClient)}, options...)
+	return &APIClient{sdk: slackapi.New(userToken, options...), token: userToken, http: httpClient, apiURL: slackapi.APIURL}
 }
 
 // NewWithCookie creates a new APIClient for xoxc- tokens that require a cookie.
@@ -25,10 +37,10 @@ func NewWithCookie(token, cookie string) *APIClient {
 	httpClient := &http.Client{
 		Transport: &cookieTransport{
 			cookie: cookie,
-			base:   http.DefaultTransport,
+			base:   defaultTransport(),
 		},
 	}
-	return &APIClient{sdk: slackapi.New(token, slackapi.OptionHTTPClient(httpClient))}
+	return &APIClient{sdk: slackapi.New(token, slackapi.OptionHTTPClient(httpClient)), token: token, http: httpClient, apiURL: slackapi.APIURL}
 }
 
 // NewAuto automatically creates the appropriate client based on token type.
@@ -40,6 +52,71 @@ func NewAuto(token, cookie string) *APIClient {
 	return New(token)
 }
 
+// SetReadOnly makes every method that changes the workspace fail with a policy error
+// before calling Slack.
+func (c *APIClient) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
+// SetHumanWritesBlocked makes every method that changes the workspace fail with a
+// policy error before calling Slack, because the token acts as a person rather than
+// a bot and the config has not opted in to that.
+func (c *APIClient) SetHumanWritesBlocked(blocked bool) {
+	c.humanWrites = blocked
+}
+
+// checkWritable rejects the Slack API method in read-only mode, or when the token
+// would change the workspace as a person without allow_user_impersonation.
+func (c *APIClient) checkWritable(method string) error {
+	if c.readOnly {
+		return cerrors.WithCode(cerrors.CodeReadOnly, cerrors.WrapWithCode(cerrors.ExitPolicy, ErrReadOnly, "%s is disabled", method))
+	}
+	if c.humanWrites {
+		return cerrors.WithCode(cerrors.CodeUserImpersonation, cerrors.WrapWithCode(cerrors.ExitPolicy, ErrUserImpersonation,
+			"%s would act as the person who owns this user token; use a bot token (SLACK_CLI_ROLE=bot), or set allow_user_impersonation to true in config if posting as that person is intended", method))
+	}
+	return nil
+}
+
+// CheckWrite returns the error the client would refuse method in channelID with, from
+// read-only mode, the user impersonation guard, or the channel lists, without calling
+// Slack.
+func (c *APIClient) CheckWrite(method, channelID string) error {
+	if err := c.checkWritable(method); err != nil {
+		return err
+	}
+	return c.checkChannel(channelID)
+}
+
+// SetChannelLists limits the channels the client reads from and writes to. Entries are
+// conversation IDs. An empty allowed list permits every channel that is not denied.
+func (c *APIClient) SetChannelLists(allowed, denied []string) {
+	c.allowed = channelSet(allowed)
+	c.denied = channelSet(denied)
+}
+
+// checkChannel rejects a channel outside the allowed list or on the denied list.
+func (c *APIClient) checkChannel(channelID string) error {
+	if c.denied[channelID] {
+		return cerrors.WithCode(cerrors.CodeChannelBlocked, cerrors.WrapWithCode(cerrors.ExitPolicy, ErrChannelBlocked, "%s is in denied_channels", channelID))
+	}
+	if len(c.allowed) > 0 && !c.allowed[channelID] {
+		return cerrors.WithCode(cerrors.CodeChannelBlocked, cerrors.WrapWithCode(cerrors.ExitPolicy, ErrChannelBlocked, "%s is not in allowed_channels", channelID))
+	}
+	return nil
+}
+
+func channelSet(ids []string) map[string]bool {
+	if len(ids) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
 // NewSocketModeClient creates a socketmode client using the existing user token model plus an
 // app-level token for Socket Mode connection management.
 func NewSocketModeClient(token, cookie, appToken string) *socketmode.Client {
@@ -48,7 +125,7 @@ func NewSocketModeClient(token, cookie, appToken string) *socketmode.Client {
 		httpClient := &http.Client{
 			Transport: &cookieTransport{
 				cookie: cookie,
-				base:   http.DefaultTransport,
+				base:   defaultTransport(),
 			},
 		}
 		api = slackapi.New(
@@ -57,7 +134,11 @@ func NewSocketModeClient(token, cookie, appToken string) *socketmode.Client {
 			slackapi.OptionAppLevelToken(appToken),
 		)
 	} else {
-		api = slackapi.New(token, slackapi.OptionAppLevelToken(appToken))
+		api = slackapi.New(
+			token,
+			slackapi.OptionHTTPClient(defaultHTTPClient()),
+			slackapi.OptionAppLevelToken(appToken),
+		)
 	}
 	return socketmode.New(api)
 }
@@ -20,6 +20,9 @@ var (
 	// ErrUserRequired indicates a user ID is required but was empty.
 	ErrUserRequired = errors.New("user is required")
 
+	// ErrFileRequired indicates a file ID is required but was empty.
+	ErrFileRequired = errors.New("file is required")
+
 	// ErrQueryRequired indicates a search query is required but was empty.
 	ErrQueryRequired = errors.New("search query is required")
 
@@ -34,4 +37,17 @@ var (
 
 	// ErrPermissionDenied indicates insufficient permissions.
 	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrReadOnly indicates a mutating call was made in read-only mode.
+	ErrReadOnly = errors.New("read-only mode")
+
+	// ErrUserImpersonation indicates a mutating call was made with a user token
+	// without allow_user_impersonation.
+	ErrUserImpersonation = errors.New("user impersonation not allowed")
+
+	// ErrChannelBlocked indicates a call to a channel excluded by the channel lists.
+	ErrChannelBlocked = errors.New("channel blocked by config")
+
+	// ErrEmailRequired indicates an invite without an email address.
+	ErrEmailRequired = errors.New("email is required")
 )
@@ -20,6 +20,9 @@ func TestSentinelErrors_AreDistinct(t *testing.T) {
 		ErrRateLimited,
 		ErrUnauthorized,
 		ErrPermissionDenied,
+		ErrReadOnly,
+		ErrUserImpersonation,
+		ErrChannelBlocked,
 	}
 
 	for i, e1 := range errs {
@@ -54,6 +57,9 @@ func TestSentinelErrors_HaveDescriptiveMessages(t *testing.T) {
 		{ErrRateLimited, "rate"},
 		{ErrUnauthorized, "unauthorized"},
 		{ErrPermissionDenied, "permission"},
+		{ErrReadOnly, "read-only"},
+		{ErrUserImpersonation, "impersonation"},
+		{ErrChannelBlocked, "channel"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,275 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// ErrFileTooLarge indicates a download exceeded the caller's size limit.
+var ErrFileTooLarge = errors.New("file exceeds size limit")
+
+// ErrNotSlackFileURL indicates a download URL outside Slack's file hosts. The token is
+// sent with every download, so such URLs are never fetched.
+var ErrNotSlackFileURL = errors.New("not a Slack file URL")
+
+// fileHosts are the hosts Slack serves url_private, url_private_download, and
+// thumbnails from.
+var fileHosts = map[string]bool{
+	"files.slack.com":        true,
+	"files-edu.slack.com":    true,
+	"files-origin.slack.com": true,
+	"files.slack-gov.com":    true,
+	"slack-files.com":        true,
+}
+
+// CheckFileURL returns ErrNotSlackFileURL unless rawURL is https on a Slack file host.
+// External and remote files carry third-party URLs that must not receive the token.
+func CheckFileURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || !fileHosts[strings.ToLower(u.Hostname())] {
+		host := rawURL
+		if err == nil && u.Host != "" {
+			host = u.Scheme + "://" + u.Host
+		}
+		return fmt.Errorf("%w: %s", ErrNotSlackFileURL, host)
+	}
+	return nil
+}
+
+// DownloadFile fetches a file's url_private (or url_private_download) with the client's
+// credentials. At most maxBytes are read; larger files return ErrFileTooLarge. URLs
+// outside Slack's file hosts return ErrNotSlackFileURL without a request.
+func (c *APIClient) DownloadFile(ctx context.Context, fileURL string, maxBytes int64) ([]byte, error) {
+	if fileURL == "" {
+		return nil, fmt.Errorf("download file: no private URL")
+	}
+	if err := CheckFileURL(fileURL); err != nil {
+		return nil, fmt.Errorf("download file: %w", err)
+	}
+	buf := &limitedBuffer{max: maxBytes}
+	if err := c.sdk.GetFileContext(ctx, fileURL, buf); err != nil {
+		if errors.Is(err, ErrFileTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("download file: %w", err)
+	}
+	data := buf.buf.Bytes()
+	// Without files:read Slack answers url_private with its HTML sign-in page instead of a 403.
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<!DOCTYPE html")) && bytes.Contains(data, []byte("slack")) {
+		return nil, fmt.Errorf("download file: got a Slack sign-in page (token may lack files:read)")
+	}
+	return data, nil
+}
+
+// limitedBuffer collects a download and refuses writes past max bytes. The buffer is not
+// embedded so io.Copy cannot bypass Write through bytes.Buffer.ReadFrom.
+type limitedBuffer struct {
+	buf bytes.Buffer
+	max int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.max > 0 && int64(b.buf.Len()+len(p)) > b.max {
+		return 0, ErrFileTooLarge
+	}
+	return b.buf.Write(p)
+}
+
+// UploadFileOptions describes a file to share, with an optional message, in a
+// channel or thread.
+type UploadFileOptions struct {
+	Channel  string
+	ThreadTS string
+	Filename string
+	Title    string
+	Content  []byte
+	// Text (mrkdwn) or Blocks (Block Kit JSON) become the message the file is
+	// shared in.
+	Text   string
+	Blocks string
+}
+
+// UploadedFile describes a file shared by UploadFile.
+type UploadedFile struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title,omitempty"`
+	Size      int    `json:"size"`
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// shareLookups and shareLookupInterval bound how long UploadFile waits for Slack to
+// report the message a file was shared in; sharing completes asynchronously.
+var (
+	shareLookups        = 5
+	shareLookupInterval = 500 * time.Millisecond
+)
+
+// UploadFile shares a file in a channel the way files.uploadV2 does: it asks
+// files.getUploadURLExternal for an upload URL, sends the content there, and calls
+// files.completeUploadExternal with the channel, thread, and message. It returns
+// the file and the ts of the message it was shared in, which is empty when Slack
+// has not finished sharing after a few seconds.
+func (c *APIClient) UploadFile(ctx context.Context, opts UploadFileOptions) (*UploadedFile, string, error) {
+	if err := c.checkWritable("files.completeUploadExternal"); err != nil {
+		return nil, "", err
+	}
+	if opts.Channel == "" {
+		return nil, "", ErrChannelRequired
+	}
+	if err := c.checkChannel(opts.Channel); err != nil {
+		return nil, "", err
+	}
+	if opts.Filename == "" || len(opts.Content) == 0 {
+		return nil, "", fmt.Errorf("upload file: a file name and content are required")
+	}
+
+	var target struct {
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	if err := c.call(ctx, "files.getUploadURLExternal", url.Values{
+		"filename": {opts.Filename},
+		"length":   {strconv.Itoa(len(opts.Content))},
+	}, &target); err != nil {
+		return nil, "", err
+	}
+	if err := c.sendUpload(ctx, target.UploadURL, opts.Content); err != nil {
+		return nil, "", err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = opts.Filename
+	}
+	files, _ := json.Marshal([]map[string]string{{"id": target.FileID, "title": title}})
+	form := url.Values{"files": {string(files)}, "channel_id": {opts.Channel}}
+	if opts.ThreadTS != "" {
+		form.Set("thread_ts", opts.ThreadTS)
+	}
+	if opts.Text != "" {
+		form.Set("initial_comment", opts.Text)
+	}
+	if opts.Blocks != "" {
+		form.Set("blocks", opts.Blocks)
+	}
+	var completed struct {
+		Files []slackapi.File `json:"files"`
+	}
+	if err := c.call(ctx, "files.completeUploadExternal", form, &completed); err != nil {
+		return nil, "", err
+	}
+	file := slackapi.File{ID: target.FileID, Name: opts.Filename, Title: title, Size: len(opts.Content)}
+	if len(completed.Files) > 0 {
+		file = completed.Files[0]
+	}
+	return &UploadedFile{
+		ID:        file.ID,
+		Name:      file.Name,
+		Title:     file.Title,
+		Size:      file.Size,
+		Permalink: file.Permalink,
+	}, c.sharedTS(ctx, file, opts.Channel), nil
+}
+
+// sendUpload posts file content to the URL files.getUploadURLExternal returned.
+func (c *APIClient) sendUpload(ctx context.Context, uploadURL string, content []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("upload file: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	httpClient := c.http
+	if httpClient == nil {
+		httpClient = defaultHTTPClient()
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("upload file: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// sharedTS returns the ts of the message file was shared in, asking files.info
+// while Slack finishes sharing it.
+func (c *APIClient) sharedTS(ctx context.Context, file slackapi.File, channel string) string {
+	for attempt := 0; ; attempt++ {
+		for _, shares := range []map[string][]slackapi.ShareFileInfo{file.Shares.Public, file.Shares.Private} {
+			if s := shares[channel]; len(s) > 0 {
+				return s[0].Ts
+			}
+		}
+		if attempt == shareLookups {
+			return ""
+		}
+		select {
+		case <-ctx.Done():
+			return ""
+		case <-time.After(shareLookupInterval):
+		}
+		var info struct {
+			File slackapi.File `json:"file"`
+		}
+		if err := c.call(ctx, "files.info", url.Values{"file": {file.ID}}, &info); err != nil {
+			return ""
+		}
+		file = info.File
+	}
+}
+
+// GetFileInfo fetches a file's metadata with files.info.
+func (c *APIClient) GetFileInfo(ctx context.Context, fileID string) (*slackapi.File, error) {
+	if fileID == "" {
+		return nil, ErrFileRequired
+	}
+	file, _, _, err := c.sdk.GetFileInfoContext(ctx, fileID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get file info: %w", err)
+	}
+	return file, nil
+}
+
+// ShareFilePublicURL creates a public URL for a file (files.sharedPublicURL), so it
+// can be viewed without signing in to Slack. Only user tokens may call it.
+func (c *APIClient) ShareFilePublicURL(ctx context.Context, fileID string) (*slackapi.File, error) {
+	if err := c.checkWritable("files.sharedPublicURL"); err != nil {
+		return nil, err
+	}
+	if fileID == "" {
+		return nil, ErrFileRequired
+	}
+	file, _, _, err := c.sdk.ShareFilePublicURLContext(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("share public url: %w", err)
+	}
+	return file, nil
+}
+
+// RevokeFilePublicURL disables a file's public URL (files.revokePublicURL).
+func (c *APIClient) RevokeFilePublicURL(ctx context.Context, fileID string) (*slackapi.File, error) {
+	if err := c.checkWritable("files.revokePublicURL"); err != nil {
+		return nil, err
+	}
+	if fileID == "" {
+		return nil, ErrFileRequired
+	}
+	file, err := c.sdk.RevokeFilePublicURLContext(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("revoke public url: %w", err)
+	}
+	return file, nil
+}
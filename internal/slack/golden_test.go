@@ -0,0 +1,90 @@
+package slack
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+// longText crosses every truncation width used by the human output with multi-byte runes.
+var longText = strings.Repeat("デプロイ完了 🚀👍🏽 ", 24)
+
+func TestPrintableGolden(t *testing.T) {
+	ctx := context.Background()
+	users := mockSearchUserResolver{users: map[string]string{"U1": "alice", "U2": "bob"}}
+
+	reactions := &ReactionListResult{
+		OK: true, Channel: "#deploys", ChannelID: "C1", Timestamp: "1700000000.000100",
+		Message: &Message{Timestamp: "1700000000.000100", Text: longText, User: "U1"},
+		Reactions: []ReactionItem{
+			{Name: "rocket", Count: 2, Users: []string{"U1", "U2"}},
+			{Name: "eyes", Count: 7},
+		},
+	}
+	reactions.SetUserResolver(ctx, users)
+
+	pins := &PinListResult{OK: true, Channel: "#deploys", Items: []PinnedItem{
+		{Type: "message", Message: &Message{Timestamp: "1700000000.000100", Text: longText, User: "U1", Permalink: "https://example.slack.com/archives/C1/p1700000000000100"}, CreatedBy: "U2", Created: 1700000100},
+		{Type: "file", Created: 1700000200},
+	}}
+	pins.SetUserResolver(ctx, users)
+
+	threads := &ThreadListResult{Channel: "#deploys", ChannelID: "C1", HasMore: true, Threads: []ThreadSummary{
+		{Channel: "C1", TS: "1700000000.000100", User: "U1", Text: "line one\n" + longText, ReplyCount: 3, LatestReply: "1700003600.000200"},
+		{Channel: "C1", TS: "1700007200.000300", User: "U9", Text: "short"},
+	}}
+	threads.SetUserResolver(ctx, users)
+
+	huddles := &HuddleListResult{Channel: "#standup", ChannelID: "C2", HasMore: true, Huddles: []Huddle{
+		{ID: "R1", TS: "1700000000.000100", CreatedBy: "U1", DateStart: 1700000000, DateEnd: 1700000900, HasEnded: true, Participants: []string{"U1", "U2"}, ReplyCount: 4},
+		{ID: "R2", TS: "1700090000.000100", CreatedBy: "U2"},
+	}}
+	huddles.SetUserResolver(ctx, users)
+
+	tests := []struct {
+		name string
+		p    interface{ Lines() []string }
+	}{
+		{"PostMessageResult", &PostMessageResult{OK: true, Channel: "#deploys", Timestamp: "1700000000.000100", UnresolvedMentions: []string{"@nobody", "#nowhere"}}},
+		{"PostMessageResult_file", &PostMessageResult{OK: true, Channel: "#deploys", File: &UploadedFile{ID: "F1", Name: "report.pdf", Size: 2048, Permalink: "https://example.slack.com/files/F1"}}},
+		{"EditMessageResult", &EditMessageResult{OK: true, Channel: "#deploys", Timestamp: "1700000000.000100", Text: "edited"}},
+		{"DeleteMessageResult", &DeleteMessageResult{OK: true, Channel: "#deploys", Timestamp: "1700000000.000100"}},
+		{"ReactionResult_add", &ReactionResult{OK: true, Action: "add", Channel: "#deploys", Emoji: "rocket"}},
+		{"ReactionResult_remove", &ReactionResult{OK: true, Action: "remove", Channel: "#deploys", Emoji: "rocket"}},
+		{"ReactionListResult", reactions},
+		{"ReactionListResult_empty", &ReactionListResult{Channel: "#deploys", Timestamp: "1700000000.000100"}},
+		{"EmojiListResult", &EmojiListResult{OK: true, Count: 3, Emoji: map[string]string{
+			"party-parrot": "https://emoji.slack-edge.com/T1/party-parrot/" + strings.Repeat("a", 40) + ".gif",
+			"shipit":       "alias:squirrel",
+			"ｄｅｐｌｏｙ":       "https://emoji.slack-edge.com/T1/デプロイ完了デプロイ完了デプロイ完了デプロイ完了デプロイ完了デプロイ完了.png",
+		}}},
+		{"EmojiListResult_empty", &EmojiListResult{OK: true}},
+		{"ChannelJoinResult", &ChannelJoinResult{OK: true, Channel: "#deploys", ChannelID: "C1"}},
+		{"ChannelLeaveResult", &ChannelLeaveResult{OK: true, Channel: "#deploys", ChannelID: "C1"}},
+		{"PinResult_add", &PinResult{OK: true, Action: "add", Channel: "#deploys", Timestamp: "1700000000.000100"}},
+		{"PinResult_remove", &PinResult{OK: true, Action: "remove", Channel: "#deploys", Timestamp: "1700000000.000100"}},
+		{"PinListResult", pins},
+		{"PinListResult_empty", &PinListResult{Channel: "#deploys"}},
+		{"AuthTestResponse", &AuthTestResponse{OK: true, URL: "https://example.slack.com/", Team: "Example", TeamID: "T1", User: "alice", UserID: "U1", BotID: "B1",
+			Token: &TokenInfo{Type: TokenUser, Description: "User token"}, Scopes: []string{"channels:read", "chat:write"}, Warnings: []string{"token expires soon"}}},
+		{"AuthTestResponse_invalid", &AuthTestResponse{Team: "Example", TeamID: "T1"}},
+		{"SearchResult", &SearchResult{Query: "deploy", Messages: SearchMessages{Total: 2, Matches: []SearchMatch{
+			{Channel: SearchChannel{ID: "C1", Name: "deploys"}, User: "U1", Username: "alice", Timestamp: "1700000000.000100", Text: "deploy done", Permalink: "https://example.slack.com/archives/C1/p1700000000000100"},
+			{Channel: SearchChannel{ID: "C2"}, User: "U2", Timestamp: "1700000001.000100", Text: "rollout:\n" + longText},
+		}}}},
+		{"SearchResult_empty", &SearchResult{Query: "nothing"}},
+		{"ThreadListResult", threads},
+		{"ThreadListResult_empty", &ThreadListResult{Channel: "#deploys"}},
+		{"ThreadFollowResult_follow", &ThreadFollowResult{OK: true, Action: "follow", Channel: "#deploys", Timestamp: "1700000000.000100"}},
+		{"ThreadFollowResult_unfollow", &ThreadFollowResult{OK: true, Action: "unfollow", Channel: "#deploys", Timestamp: "1700000000.000100"}},
+		{"HuddleListResult", huddles},
+		{"HuddleListResult_empty", &HuddleListResult{Channel: "#standup"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
@@ -3,11 +3,13 @@ package slack
 import (
 	"context"
 	"fmt"
+	"net/http"
 )
 
 // AuthTest verifies the user token is valid.
 func (c *APIClient) AuthTest(ctx context.Context) (*AuthTestResponse, error) {
-	resp, err := c.sdk.AuthTestContext(ctx)
+	header := http.Header{}
+	resp, err := c.sdk.AuthTestContext(withHeaderCapture(ctx, header))
 	if err != nil {
 		return nil, fmt.Errorf("auth test: %w", err)
 	}
@@ -19,5 +21,17 @@ func (c *APIClient) AuthTest(ctx context.Context) (*AuthTestResponse, error) {
 		TeamID: resp.TeamID,
 		UserID: resp.UserID,
 		BotID:  resp.BotID,
+		IsBot:  resp.BotID != "",
+		Scopes: ParseScopes(header.Get("X-OAuth-Scopes")),
 	}, nil
 }
+
+// AuthRevoke revokes the client's own token. Slack reports whether it was revoked;
+// the token stops working immediately.
+func (c *APIClient) AuthRevoke(ctx context.Context) (bool, error) {
+	resp, err := c.sdk.SendAuthRevokeContext(ctx, "")
+	if err != nil {
+		return false, fmt.Errorf("auth revoke: %w", err)
+	}
+	return resp.Revoked, nil
+}
@@ -0,0 +1,252 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// HuddleSubtype is the message subtype Slack posts when a huddle starts in a conversation.
+const HuddleSubtype = "huddle_thread"
+
+// HuddleParams wraps arguments for ListHuddles.
+type HuddleParams struct {
+	Channel string
+	Oldest  string
+	Latest  string
+	// Limit is the maximum number of huddles to return.
+	Limit int
+	// MaxMessages bounds how much history is scanned for huddle messages (default 1000).
+	MaxMessages int
+}
+
+// Huddle describes one huddle held in a conversation.
+type Huddle struct {
+	ID           string   `json:"id"`
+	Channel      string   `json:"channel"`
+	TS           string   `json:"ts"`
+	CreatedBy    string   `json:"created_by"`
+	DateStart    int64    `json:"date_start"`
+	DateEnd      int64    `json:"date_end,omitempty"`
+	HasEnded     bool     `json:"has_ended"`
+	Participants []string `json:"participants"`
+	Active       []string `json:"active_participants,omitempty"`
+	ReplyCount   int      `json:"reply_count,omitempty"`
+}
+
+// Duration returns how long the huddle lasted, or zero while it is still running.
+func (h Huddle) Duration() time.Duration {
+	if h.DateEnd == 0 || h.DateEnd < h.DateStart {
+		return 0
+	}
+	return time.Duration(h.DateEnd-h.DateStart) * time.Second
+}
+
+// huddleHistoryPage is the subset of conversations.history needed to read huddle rooms,
+// which slack-go's Message type does not decode.
+type huddleHistoryPage struct {
+	Messages []struct {
+		TS         string      `json:"ts"`
+		User       string      `json:"user"`
+		SubType    string      `json:"subtype"`
+		ReplyCount int         `json:"reply_count"`
+		Room       *huddleRoom `json:"room"`
+	} `json:"messages"`
+}
+
+type huddleRoom struct {
+	ID                 string   `json:"id"`
+	CreatedBy          string   `json:"created_by"`
+	DateStart          int64    `json:"date_start"`
+	DateEnd            int64    `json:"date_end"`
+	HasEnded           bool     `json:"has_ended"`
+	Participants       []string `json:"participants"`
+	ParticipantHistory []string `json:"participant_history"`
+}
+
+// ListHuddles scans channel history for huddle_thread messages, newest first.
+func (c *APIClient) ListHuddles(ctx context.Context, params HuddleParams) ([]Huddle, bool, error) {
+	if params.Channel == "" {
+		return nil, false, ErrChannelRequired
+	}
+	if err := c.checkChannel(params.Channel); err != nil {
+		return nil, false, err
+	}
+	if params.MaxMessages <= 0 {
+		params.MaxMessages = 1000
+	}
+
+	var raw bytes.Buffer
+	captureCtx := withResponseCapture(ctx, &raw)
+	huddles := []Huddle{}
+	scanned := 0
+	cursor := ""
+	for {
+		resp, err := c.sdk.GetConversationHistoryContext(captureCtx, &slackapi.GetConversationHistoryParameters{
+			ChannelID: params.Channel,
+			Cursor:    cursor,
+			Oldest:    params.Oldest,
+			Latest:    params.Latest,
+			Limit:     200,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("list huddles: %w", err)
+		}
+		var page huddleHistoryPage
+		if err := json.Unmarshal(raw.Bytes(), &page); err != nil {
+			return nil, false, fmt.Errorf("list huddles: decode history: %w", err)
+		}
+		for _, msg := range page.Messages {
+			if msg.SubType != HuddleSubtype {
+				continue
+			}
+			huddles = append(huddles, newHuddle(params.Channel, msg.TS, msg.User, msg.ReplyCount, msg.Room))
+			if params.Limit > 0 && len(huddles) >= params.Limit {
+				return huddles, true, nil
+			}
+		}
+		scanned += len(resp.Messages)
+		cursor = resp.ResponseMetaData.NextCursor
+		if !resp.HasMore || cursor == "" {
+			return huddles, false, nil
+		}
+		if scanned >= params.MaxMessages {
+			return huddles, true, nil
+		}
+	}
+}
+
+func newHuddle(channel, ts, user string, replies int, room *huddleRoom) Huddle {
+	h := Huddle{Channel: channel, TS: ts, CreatedBy: user, ReplyCount: replies, Participants: []string{}}
+	if room == nil {
+		return h
+	}
+	h.ID = room.ID
+	if room.CreatedBy != "" {
+		h.CreatedBy = room.CreatedBy
+	}
+	h.DateStart = room.DateStart
+	h.DateEnd = room.DateEnd
+	h.HasEnded = room.HasEnded
+	if len(room.ParticipantHistory) > 0 {
+		h.Participants = room.ParticipantHistory
+	} else if len(room.Participants) > 0 {
+		h.Participants = room.Participants
+	}
+	if !room.HasEnded {
+		h.Active = room.Participants
+	}
+	return h
+}
+
+// HuddleListResult is the output of huddles list.
+type HuddleListResult struct {
+	Channel      string             `json:"channel"`
+	ChannelID    string             `json:"channel_id"`
+	Huddles      []Huddle           `json:"huddles"`
+	HasMore      bool               `json:"has_more"`
+	userResolver SearchUserResolver `json:"-"`
+	ctx          context.Context    `json:"-"`
+	rawJSON      bool               `json:"-"`
+}
+
+// SetUserResolver configures participant name resolution.
+func (r *HuddleListResult) SetUserResolver(ctx context.Context, resolver SearchUserResolver) {
+	r.ctx = ctx
+	r.userResolver = resolver
+}
+
+// SetRawJSON controls whether JSON output should preserve raw Slack user IDs.
+func (r *HuddleListResult) SetRawJSON(raw bool) {
+	r.rawJSON = raw
+}
+
+// MarshalJSON resolves creator and participant IDs to @names, keeping IDs in *_id fields.
+func (r HuddleListResult) MarshalJSON() ([]byte, error) {
+	type huddleOutput struct {
+		Huddle
+		CreatedByID    string   `json:"created_by_id,omitempty"`
+		ParticipantIDs []string `json:"participant_ids,omitempty"`
+		DurationSec    int64    `json:"duration_seconds,omitempty"`
+	}
+	out := struct {
+		Channel   string         `json:"channel"`
+		ChannelID string         `json:"channel_id"`
+		Huddles   []huddleOutput `json:"huddles"`
+		HasMore   bool           `json:"has_more"`
+	}{Channel: r.Channel, ChannelID: r.ChannelID, HasMore: r.HasMore, Huddles: make([]huddleOutput, 0, len(r.Huddles))}
+
+	for _, h := range r.Huddles {
+		entry := huddleOutput{Huddle: h, DurationSec: int64(h.Duration() / time.Second)}
+		if !r.rawJSON && r.userResolver != nil && r.ctx != nil {
+			if name := r.userRef(h.CreatedBy); h.CreatedBy != "" && name != h.CreatedBy {
+				entry.CreatedByID = h.CreatedBy
+				entry.CreatedBy = name
+			}
+			entry.ParticipantIDs = h.Participants
+			entry.Participants = r.userRefs(h.Participants)
+			entry.Active = r.userRefs(h.Active)
+		}
+		out.Huddles = append(out.Huddles, entry)
+	}
+	return json.Marshal(out)
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *HuddleListResult) Lines() []string {
+	title := fmt.Sprintf("Huddles in %s (%d)", r.Channel, len(r.Huddles))
+	lines := []string{title, strings.Repeat("-", len(title))}
+	if len(r.Huddles) == 0 {
+		return append(lines, "No huddles found.")
+	}
+	for _, h := range r.Huddles {
+		when := h.TS
+		if h.DateStart > 0 {
+			when = time.Unix(h.DateStart, 0).Local().Format("2006-01-02 15:04")
+		}
+		status := "ongoing"
+		if h.HasEnded {
+			status = h.Duration().String()
+		}
+		line := fmt.Sprintf("[%s] started by %s (%s)", when, r.userRef(h.CreatedBy), status)
+		if len(h.Participants) > 0 {
+			line += ": " + strings.Join(r.userRefs(h.Participants), ", ")
+		}
+		if h.ReplyCount > 0 {
+			line += fmt.Sprintf(" [thread: %d replies, ts: %s]", h.ReplyCount, h.TS)
+		}
+		lines = append(lines, line)
+	}
+	if r.HasMore {
+		lines = append(lines, "More huddles may exist; raise --limit or --max-messages.")
+	}
+	return lines
+}
+
+func (r HuddleListResult) userRef(userID string) string {
+	if userID == "" {
+		return "unknown"
+	}
+	if r.userResolver != nil && r.ctx != nil {
+		if name := r.userResolver.GetMentionName(r.ctx, userID); name != "" && name != userID {
+			return formatSearchUserRef(name)
+		}
+	}
+	return userID
+}
+
+func (r HuddleListResult) userRefs(ids []string) []string {
+	if len(ids) == 0 {
+		return ids
+	}
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i] = r.userRef(id)
+	}
+	return refs
+}
@@ -3,6 +3,7 @@ package slack
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,6 +59,12 @@ func ParseTimeRange(since, until string) (string, string, error) {
 
 func parseTimeInput(value string) (time.Time, error) {
 	switch {
+	case strings.HasSuffix(value, "d"):
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid day duration %q", value)
+		}
+		return time.Now().AddDate(0, 0, -days), nil
 	case strings.HasSuffix(value, "h"), strings.HasSuffix(value, "m"), strings.HasSuffix(value, "s"):
 		dur, err := time.ParseDuration(value)
 		if err != nil {
@@ -78,6 +85,9 @@ func (c *APIClient) ListConversationsHistory(ctx context.Context, params History
 	if params.Channel == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(params.Channel); err != nil {
+		return nil, err
+	}
 	options := &slackapi.GetConversationHistoryParameters{ChannelID: params.Channel}
 	options.Cursor = params.Cursor
 	options.Limit = params.Limit
@@ -93,6 +103,9 @@ func (c *APIClient) ListThreadReplies(ctx context.Context, params ThreadParams)
 	if params.Channel == "" || params.Thread == "" {
 		return nil, false, "", fmt.Errorf("channel and thread are required")
 	}
+	if err := c.checkChannel(params.Channel); err != nil {
+		return nil, false, "", err
+	}
 	opts := &slackapi.GetConversationRepliesParameters{ChannelID: params.Channel, Timestamp: params.Thread}
 	opts.Cursor = params.Cursor
 	opts.Limit = params.Limit
@@ -102,21 +115,79 @@ func (c *APIClient) ListThreadReplies(ctx context.Context, params ThreadParams)
 	return msgs, hasMore, nextCursor, err
 }
 
+// GetMessage fetches a single message by timestamp with an inclusive history lookup,
+// falling back to conversations.replies for thread replies, which history omits.
+func (c *APIClient) GetMessage(ctx context.Context, channel, timestamp string) (*slackapi.Message, error) {
+	if timestamp == "" {
+		return nil, ErrTimestampRequired
+	}
+	history, err := c.ListConversationsHistory(ctx, HistoryParams{Channel: channel, Latest: timestamp, Oldest: timestamp, Inclusive: true, Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("get message: %w", err)
+	}
+	for i := range history.Messages {
+		if history.Messages[i].Timestamp == timestamp {
+			return &history.Messages[i], nil
+		}
+	}
+	replies, _, _, err := c.ListThreadReplies(ctx, ThreadParams{Channel: channel, Thread: timestamp, Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("get message: %w", err)
+	}
+	for i := range replies {
+		if replies[i].Timestamp == timestamp {
+			return &replies[i], nil
+		}
+	}
+	return nil, fmt.Errorf("get message %s: %w", timestamp, ErrNotFound)
+}
+
 // PostMessage sends a message to a channel.
 func (c *APIClient) PostMessage(ctx context.Context, channel string, opts PostMessageOptions) (*PostMessageResult, error) {
+	if err := c.checkWritable("chat.postMessage"); err != nil {
+		return nil, err
+	}
 	if channel == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return nil, err
+	}
 	if opts.Text == "" && len(opts.Blocks) == 0 {
 		return nil, ErrTextRequired
 	}
 
+	respChannel, respTimestamp, err := c.sdk.PostMessageContext(ctx, channel, messageOptions(opts)...)
+	if err != nil {
+		return nil, fmt.Errorf("post message: %w", err)
+	}
+
+	return &PostMessageResult{
+		OK:        true,
+		Channel:   respChannel,
+		Timestamp: respTimestamp,
+		Text:      opts.Text,
+	}, nil
+}
+
+// messageOptions converts PostMessageOptions into chat.postMessage arguments.
+func messageOptions(opts PostMessageOptions) []slackapi.MsgOption {
 	msgOpts := []slackapi.MsgOption{
 		slackapi.MsgOptionText(opts.Text, false),
 	}
 
+	if opts.LinkNames {
+		// slack-go has no dedicated option for link_names.
+		params := slackapi.NewPostMessageParameters()
+		params.LinkNames = 1
+		msgOpts = append(msgOpts, slackapi.MsgOptionPostMessageParameters(params))
+	}
+
 	if opts.ThreadTS != "" {
 		msgOpts = append(msgOpts, slackapi.MsgOptionTS(opts.ThreadTS))
+		if opts.Broadcast {
+			msgOpts = append(msgOpts, slackapi.MsgOptionBroadcast())
+		}
 	}
 
 	if len(opts.Blocks) > 0 {
@@ -127,6 +198,16 @@ func (c *APIClient) PostMessage(ctx context.Context, channel string, opts PostMe
 		msgOpts = append(msgOpts, slackapi.MsgOptionAsUser(true))
 	}
 
+	if opts.Username != "" {
+		msgOpts = append(msgOpts, slackapi.MsgOptionUsername(opts.Username))
+	}
+	if opts.IconEmoji != "" {
+		msgOpts = append(msgOpts, slackapi.MsgOptionIconEmoji(normalizeEmojiIcon(opts.IconEmoji)))
+	}
+	if opts.IconURL != "" {
+		msgOpts = append(msgOpts, slackapi.MsgOptionIconURL(opts.IconURL))
+	}
+
 	// Only add disable options if unfurl is explicitly false
 	if !opts.UnfurlLinks {
 		msgOpts = append(msgOpts, slackapi.MsgOptionDisableLinkUnfurl())
@@ -134,25 +215,52 @@ func (c *APIClient) PostMessage(ctx context.Context, channel string, opts PostMe
 	if !opts.UnfurlMedia {
 		msgOpts = append(msgOpts, slackapi.MsgOptionDisableMediaUnfurl())
 	}
+	if opts.Parse != "" {
+		msgOpts = append(msgOpts, slackapi.MsgOptionParse(opts.Parse == "full"))
+	}
+	if opts.NoMrkdwn {
+		msgOpts = append(msgOpts, slackapi.MsgOptionDisableMarkdown())
+	}
+	if opts.Metadata != nil {
+		msgOpts = append(msgOpts, slackapi.MsgOptionMetadata(*opts.Metadata))
+	}
+	return msgOpts
+}
+
+// normalizeEmojiIcon wraps an emoji name in colons, as chat.postMessage expects.
+func normalizeEmojiIcon(name string) string {
+	return ":" + strings.Trim(strings.TrimSpace(name), ":") + ":"
+}
 
-	respChannel, respTimestamp, err := c.sdk.PostMessageContext(ctx, channel, msgOpts...)
+// GetPermalink returns the permalink for a message.
+func (c *APIClient) GetPermalink(ctx context.Context, channel, timestamp string) (string, error) {
+	if channel == "" {
+		return "", ErrChannelRequired
+	}
+	if err := c.checkChannel(channel); err != nil {
+		return "", err
+	}
+	if timestamp == "" {
+		return "", ErrTimestampRequired
+	}
+	link, err := c.sdk.GetPermalinkContext(ctx, &slackapi.PermalinkParameters{Channel: channel, Ts: timestamp})
 	if err != nil {
-		return nil, fmt.Errorf("post message: %w", err)
+		return "", fmt.Errorf("get permalink: %w", err)
 	}
-
-	return &PostMessageResult{
-		OK:        true,
-		Channel:   respChannel,
-		Timestamp: respTimestamp,
-		Text:      opts.Text,
-	}, nil
+	return link, nil
 }
 
 // EditMessage updates an existing message.
 func (c *APIClient) EditMessage(ctx context.Context, channel, timestamp, text string) (*EditMessageResult, error) {
+	if err := c.checkWritable("chat.update"); err != nil {
+		return nil, err
+	}
 	if channel == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return nil, err
+	}
 	if timestamp == "" {
 		return nil, ErrTimestampRequired
 	}
@@ -180,9 +288,15 @@ func (c *APIClient) EditMessage(ctx context.Context, channel, timestamp, text st
 
 // DeleteMessage deletes a message.
 func (c *APIClient) DeleteMessage(ctx context.Context, channel, timestamp string) (*DeleteMessageResult, error) {
+	if err := c.checkWritable("chat.delete"); err != nil {
+		return nil, err
+	}
 	if channel == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return nil, err
+	}
 	if timestamp == "" {
 		return nil, ErrTimestampRequired
 	}
@@ -198,3 +312,74 @@ func (c *APIClient) DeleteMessage(ctx context.Context, channel, timestamp string
 		Timestamp: timestamp,
 	}, nil
 }
+
+// ScheduleMessage schedules a message for postAt and returns its
+// scheduled_message_id. slack-go drops the ID from the chat.scheduleMessage response,
+// so it is looked up with chat.scheduledMessages.list; it is "" if that lookup fails.
+func (c *APIClient) ScheduleMessage(ctx context.Context, channel string, postAt time.Time, opts PostMessageOptions) (string, error) {
+	if err := c.checkWritable("chat.scheduleMessage"); err != nil {
+		return "", err
+	}
+	if channel == "" {
+		return "", ErrChannelRequired
+	}
+	if err := c.checkChannel(channel); err != nil {
+		return "", err
+	}
+	if opts.Text == "" && len(opts.Blocks) == 0 {
+		return "", ErrTextRequired
+	}
+	postAtUnix := strconv.FormatInt(postAt.Unix(), 10)
+	respChannel, _, err := c.sdk.ScheduleMessageContext(ctx, channel, postAtUnix, messageOptions(opts)...)
+	if err != nil {
+		return "", fmt.Errorf("schedule message: %w", err)
+	}
+	if respChannel == "" {
+		respChannel = channel
+	}
+	scheduled, _, err := c.sdk.GetScheduledMessagesContext(ctx, &slackapi.GetScheduledMessagesParameters{
+		Channel: respChannel,
+		Oldest:  postAtUnix,
+		Latest:  postAtUnix,
+	})
+	if err != nil {
+		return "", nil
+	}
+	var candidates []slackapi.ScheduledMessage
+	for _, msg := range scheduled {
+		if msg.PostAt != int(postAt.Unix()) {
+			continue
+		}
+		if msg.Text == opts.Text {
+			return msg.ID, nil
+		}
+		candidates = append(candidates, msg)
+	}
+	// Slack may store the text escaped; a single message at that time is still ours.
+	if len(candidates) == 1 {
+		return candidates[0].ID, nil
+	}
+	return "", nil
+}
+
+// DeleteScheduledMessage cancels a message scheduled with chat.scheduleMessage that has
+// not been posted yet.
+func (c *APIClient) DeleteScheduledMessage(ctx context.Context, channel, scheduledID string) error {
+	if err := c.checkWritable("chat.deleteScheduledMessage"); err != nil {
+		return err
+	}
+	if channel == "" {
+		return ErrChannelRequired
+	}
+	if err := c.checkChannel(channel); err != nil {
+		return err
+	}
+	_, err := c.sdk.DeleteScheduledMessageContext(ctx, &slackapi.DeleteScheduledMessageParameters{
+		Channel:            channel,
+		ScheduledMessageID: scheduledID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete scheduled message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package slack
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var permalinkTSPattern = regexp.MustCompile(`^p(\d{10})(\d{6})$`)
+
+// ParsePermalink extracts the channel ID and message timestamp from a Slack message
+// permalink such as https://workspace.slack.com/archives/C123/p1705312365000100.
+// Reply permalinks carry the reply's own timestamp in the path, so the thread_ts
+// query parameter is ignored.
+func ParsePermalink(link string) (channelID, timestamp string, err error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("invalid permalink %q", link)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "archives" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid permalink %q: expected /archives/<channel>/p<timestamp>", link)
+	}
+	m := permalinkTSPattern.FindStringSubmatch(parts[2])
+	if m == nil {
+		return "", "", fmt.Errorf("invalid permalink %q: expected /archives/<channel>/p<timestamp>", link)
+	}
+	return strings.ToUpper(parts[1]), m[1] + "." + m[2], nil
+}
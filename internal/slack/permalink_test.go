@@ -0,0 +1,36 @@
+package slack
+
+import "testing"
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		link    string
+		channel string
+		ts      string
+		wantErr bool
+	}{
+		{link: "https://acme.slack.com/archives/C0123ABC/p1705312365000100", channel: "C0123ABC", ts: "1705312365.000100"},
+		{link: "https://acme.slack.com/archives/c0123abc/p1705312400000200?thread_ts=1705312365.000100&cid=C0123ABC", channel: "C0123ABC", ts: "1705312400.000200"},
+		{link: " https://acme.slack.com/archives/D0456/p1705312365000100/ ", channel: "D0456", ts: "1705312365.000100"},
+		{link: "https://acme.slack.com/archives/C0123ABC", wantErr: true},
+		{link: "https://acme.slack.com/archives/C0123ABC/1705312365000100", wantErr: true},
+		{link: "https://acme.slack.com/files/U1/F1/report.pdf", wantErr: true},
+		{link: "1705312365.000100", wantErr: true},
+	}
+	for _, tt := range tests {
+		channel, ts, err := ParsePermalink(tt.link)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePermalink(%q) = %s, %s; want error", tt.link, channel, ts)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePermalink(%q): %v", tt.link, err)
+			continue
+		}
+		if channel != tt.channel || ts != tt.ts {
+			t.Errorf("ParsePermalink(%q) = %s, %s; want %s, %s", tt.link, channel, ts, tt.channel, tt.ts)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package slack
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
 	slackapi "github.com/slack-go/slack"
@@ -9,9 +11,15 @@ import (
 
 // AddPin pins a message to a channel.
 func (c *APIClient) AddPin(ctx context.Context, channel, timestamp string) error {
+	if err := c.checkWritable("pins.add"); err != nil {
+		return err
+	}
 	if channel == "" {
 		return ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return err
+	}
 	if timestamp == "" {
 		return ErrTimestampRequired
 	}
@@ -26,9 +34,15 @@ func (c *APIClient) AddPin(ctx context.Context, channel, timestamp string) error
 
 // RemovePin removes a pin from a message.
 func (c *APIClient) RemovePin(ctx context.Context, channel, timestamp string) error {
+	if err := c.checkWritable("pins.remove"); err != nil {
+		return err
+	}
 	if channel == "" {
 		return ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return err
+	}
 	if timestamp == "" {
 		return ErrTimestampRequired
 	}
@@ -41,24 +55,45 @@ func (c *APIClient) RemovePin(ctx context.Context, channel, timestamp string) er
 	return c.sdk.RemovePinContext(ctx, channel, itemRef)
 }
 
-// ListPins lists all pinned items in a channel.
+// pinListPage is the subset of pins.list that slack-go's Item does not decode.
+type pinListPage struct {
+	Items []struct {
+		Created   int64  `json:"created"`
+		CreatedBy string `json:"created_by"`
+	} `json:"items"`
+}
+
+// ListPins lists all pinned items in a channel, with who pinned each one and when.
+// Message permalinks missing from pins.list are fetched with chat.getPermalink.
 func (c *APIClient) ListPins(ctx context.Context, channel string) (*PinListResult, error) {
 	if channel == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return nil, err
+	}
 
-	items, _, err := c.sdk.ListPinsContext(ctx, channel)
+	var raw bytes.Buffer
+	items, _, err := c.sdk.ListPinsContext(withResponseCapture(ctx, &raw), channel)
 	if err != nil {
 		return nil, fmt.Errorf("list pins: %w", err)
 	}
+	var page pinListPage
+	if err := json.Unmarshal(raw.Bytes(), &page); err != nil {
+		return nil, fmt.Errorf("list pins: decode: %w", err)
+	}
 
 	// Convert slack-go Items to our PinnedItem structure
 	pinnedItems := make([]PinnedItem, 0, len(items))
-	for _, item := range items {
+	for i, item := range items {
 		pinnedItem := PinnedItem{
 			Type:    item.Type,
 			Channel: item.Channel,
 		}
+		if i < len(page.Items) {
+			pinnedItem.Created = page.Items[i].Created
+			pinnedItem.CreatedBy = page.Items[i].CreatedBy
+		}
 
 		// If it's a message, convert the message data
 		if item.Message != nil {
@@ -66,6 +101,11 @@ func (c *APIClient) ListPins(ctx context.Context, channel string) (*PinListResul
 				Timestamp: item.Message.Timestamp,
 				Text:      item.Message.Text,
 				User:      item.Message.User,
+				Permalink: item.Message.Permalink,
+			}
+			if pinnedItem.Message.Permalink == "" {
+				// A missing link is not worth failing the listing over.
+				pinnedItem.Message.Permalink, _ = c.GetPermalink(ctx, channel, item.Message.Timestamp)
 			}
 		}
 
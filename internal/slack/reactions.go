@@ -9,9 +9,15 @@ import (
 
 // AddReaction adds an emoji reaction to a message.
 func (c *APIClient) AddReaction(ctx context.Context, channel, timestamp, emoji string) error {
+	if err := c.checkWritable("reactions.add"); err != nil {
+		return err
+	}
 	if channel == "" {
 		return ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return err
+	}
 	if timestamp == "" {
 		return ErrTimestampRequired
 	}
@@ -29,9 +35,15 @@ func (c *APIClient) AddReaction(ctx context.Context, channel, timestamp, emoji s
 
 // RemoveReaction removes an emoji reaction from a message.
 func (c *APIClient) RemoveReaction(ctx context.Context, channel, timestamp, emoji string) error {
+	if err := c.checkWritable("reactions.remove"); err != nil {
+		return err
+	}
 	if channel == "" {
 		return ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return err
+	}
 	if timestamp == "" {
 		return ErrTimestampRequired
 	}
@@ -47,11 +59,16 @@ func (c *APIClient) RemoveReaction(ctx context.Context, channel, timestamp, emoj
 	return c.sdk.RemoveReactionContext(ctx, emoji, itemRef)
 }
 
-// GetReactions retrieves all reactions on a specific message.
-func (c *APIClient) GetReactions(ctx context.Context, channel, timestamp string) (*ReactionListResult, error) {
+// GetReactions retrieves all reactions on a specific message, and with
+// params.IncludeMessage the message's text and author.
+func (c *APIClient) GetReactions(ctx context.Context, params ReactionParams) (*ReactionListResult, error) {
+	channel, timestamp := params.Channel, params.Timestamp
 	if channel == "" {
 		return nil, ErrChannelRequired
 	}
+	if err := c.checkChannel(channel); err != nil {
+		return nil, err
+	}
 	if timestamp == "" {
 		return nil, ErrTimestampRequired
 	}
@@ -61,11 +78,11 @@ func (c *APIClient) GetReactions(ctx context.Context, channel, timestamp string)
 		Timestamp: timestamp,
 	}
 
-	params := slackapi.GetReactionsParameters{
+	options := slackapi.GetReactionsParameters{
 		Full: true, // Get full details including user list
 	}
 
-	reactions, err := c.sdk.GetReactionsContext(ctx, itemRef, params)
+	reactions, err := c.sdk.GetReactionsContext(ctx, itemRef, options)
 	if err != nil {
 		return nil, fmt.Errorf("get reactions: %w", err)
 	}
@@ -80,11 +97,22 @@ func (c *APIClient) GetReactions(ctx context.Context, channel, timestamp string)
 		})
 	}
 
-	return &ReactionListResult{
+	result := &ReactionListResult{
 		OK:        true,
 		Channel:   channel,
 		ChannelID: channel,
 		Timestamp: timestamp,
 		Reactions: reactionItems,
-	}, nil
+	}
+	if params.IncludeMessage {
+		msg, err := c.GetMessage(ctx, channel, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		result.Message = &Message{Timestamp: msg.Timestamp, Text: msg.Text, User: msg.User}
+		if result.Message.User == "" {
+			result.Message.User = msg.BotID
+		}
+	}
+	return result, nil
 }
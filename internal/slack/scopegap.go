@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"net/http"
+	"sync"
+)
+
+// ScopeGap describes the most recent missing_scope answer from the Slack API.
+type ScopeGap struct {
+	// Method is the Web API method that was refused, e.g. conversations.history.
+	Method string
+	// Needed is Slack's comma-separated list of scopes that would authorize the call.
+	Needed string
+	// Provided is Slack's comma-separated list of scopes the token has.
+	Provided string
+}
+
+var (
+	scopeGapMu sync.Mutex
+	scopeGap   *ScopeGap
+)
+
+// LastScopeGap returns the most recent missing_scope answer seen by the shared transport.
+func LastScopeGap() (ScopeGap, bool) {
+	scopeGapMu.Lock()
+	defer scopeGapMu.Unlock()
+	if scopeGap == nil {
+		return ScopeGap{}, false
+	}
+	return *scopeGap, true
+}
+
+// recordScopeGap remembers resp when it is a missing_scope error.
+func recordScopeGap(req *http.Request, resp *http.Response) {
+	envelope, ok := peekSlackResponse(resp)
+	if !ok || envelope.OK || envelope.Error != "missing_scope" {
+		return
+	}
+	scopeGapMu.Lock()
+	defer scopeGapMu.Unlock()
+	scopeGap = &ScopeGap{Method: APIMethod(req), Needed: envelope.Needed, Provided: envelope.Provided}
+}
@@ -14,7 +14,7 @@ type UserAPIClient struct {
 
 // NewUserClient creates a new UserAPIClient using the provided user token.
 func NewUserClient(userToken string) *UserAPIClient {
-	return &UserAPIClient{sdk: slackapi.New(userToken)}
+	return &UserAPIClient{sdk: slackapi.New(userToken, slackapi.OptionHTTPClient(defaultHTTPClient()))}
 }
 
 // SearchMessages searches messages across the workspace using search.messages API.
@@ -0,0 +1,75 @@
+package slack
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CallStats counts the Slack API traffic that went through the shared transport.
+type CallStats struct {
+	// Calls is every HTTP request sent to Slack, retries included.
+	Calls int `json:"api_calls"`
+	// Retries is the subset of Calls that repeated an earlier failed attempt.
+	Retries int `json:"retries"`
+	// RateLimited counts HTTP 429 answers.
+	RateLimited int `json:"rate_limited"`
+	// RateLimitWait is the time the transport slept before retrying rate-limited calls.
+	RateLimitWait time.Duration `json:"-"`
+	// BudgetWait is the time calls were held for a budgets entry in config.
+	BudgetWait time.Duration `json:"-"`
+	// Methods counts Calls per API method.
+	Methods map[string]int `json:"methods,omitempty"`
+}
+
+var (
+	statsMu sync.Mutex
+	stats   = CallStats{Methods: map[string]int{}}
+)
+
+// Stats returns a snapshot of the API traffic since the process started or ResetStats.
+func Stats() CallStats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	snapshot := stats
+	snapshot.Methods = make(map[string]int, len(stats.Methods))
+	for method, n := range stats.Methods {
+		snapshot.Methods[method] = n
+	}
+	return snapshot
+}
+
+// ResetStats zeroes the API traffic counters.
+func ResetStats() {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats = CallStats{Methods: map[string]int{}}
+}
+
+// recordCall counts one attempt of req and its outcome.
+func recordCall(req *http.Request, attempt int, resp *http.Response) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.Calls++
+	stats.Methods[APIMethod(req)]++
+	if attempt > 0 {
+		stats.Retries++
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		stats.RateLimited++
+	}
+}
+
+// recordRateLimitWait adds time spent waiting out a rate limit.
+func recordRateLimitWait(d time.Duration) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.RateLimitWait += d
+}
+
+// recordBudgetWait adds time a call was held for its group budget.
+func recordBudgetWait(d time.Duration) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.BudgetWait += d
+}
@@ -0,0 +1,301 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+)
+
+// ThreadListParams wraps arguments for ListActiveThreads.
+type ThreadListParams struct {
+	Channel string
+	// Oldest and Latest bound the activity window: a thread is active when it was
+	// started or last replied to inside it.
+	Oldest string
+	Latest string
+	// Limit is the maximum number of threads to return.
+	Limit int
+	// MaxMessages bounds how much history is scanned for thread parents (default 1000).
+	MaxMessages int
+}
+
+// ThreadSummary describes one thread in a conversation.
+type ThreadSummary struct {
+	Channel     string `json:"channel"`
+	TS          string `json:"ts"`
+	User        string `json:"user"`
+	Text        string `json:"text"`
+	ReplyCount  int    `json:"reply_count"`
+	LatestReply string `json:"latest_reply,omitempty"`
+}
+
+// LastActivity returns the timestamp of the latest reply, or of the parent when the
+// thread has none.
+func (t ThreadSummary) LastActivity() string {
+	if t.LatestReply != "" {
+		return t.LatestReply
+	}
+	return t.TS
+}
+
+// ListActiveThreads scans channel history for threads started or replied to within
+// the window, most recently active first. Threads whose parent lies beyond
+// MaxMessages are missed; the boolean reports whether more threads may exist.
+func (c *APIClient) ListActiveThreads(ctx context.Context, params ThreadListParams) ([]ThreadSummary, bool, error) {
+	if params.Channel == "" {
+		return nil, false, ErrChannelRequired
+	}
+	if params.MaxMessages <= 0 {
+		params.MaxMessages = 1000
+	}
+
+	inWindow := func(ts string) bool {
+		return ts != "" &&
+			(params.Oldest == "" || CompareTS(ts, params.Oldest) >= 0) &&
+			(params.Latest == "" || CompareTS(ts, params.Latest) <= 0)
+	}
+
+	threads := []ThreadSummary{}
+	scanned := 0
+	truncated := false
+	cursor := ""
+	for {
+		// Replies can be recent on old parents, so history is not bounded by Oldest.
+		resp, err := c.ListConversationsHistory(ctx, HistoryParams{
+			Channel: params.Channel,
+			Cursor:  cursor,
+			Latest:  params.Latest,
+			Limit:   200,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("list threads: %w", err)
+		}
+		for _, msg := range resp.Messages {
+			if msg.ReplyCount == 0 {
+				continue
+			}
+			if !inWindow(msg.Timestamp) && !inWindow(msg.LatestReply) {
+				continue
+			}
+			user := msg.User
+			if user == "" {
+				user = msg.BotID
+			}
+			threads = append(threads, ThreadSummary{
+				Channel:     params.Channel,
+				TS:          msg.Timestamp,
+				User:        user,
+				Text:        msg.Text,
+				ReplyCount:  msg.ReplyCount,
+				LatestReply: msg.LatestReply,
+			})
+		}
+		scanned += len(resp.Messages)
+		cursor = resp.ResponseMetaData.NextCursor
+		if !resp.HasMore || cursor == "" {
+			break
+		}
+		if scanned >= params.MaxMessages {
+			truncated = true
+			break
+		}
+	}
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		return CompareTS(threads[i].LastActivity(), threads[j].LastActivity()) > 0
+	})
+	if params.Limit > 0 && len(threads) > params.Limit {
+		return threads[:params.Limit], true, nil
+	}
+	return threads, truncated, nil
+}
+
+// CompareTS orders Slack timestamps numerically, without the precision loss of
+// parsing them as floats. It returns -1, 0, or 1 like strings.Compare.
+func CompareTS(a, b string) int {
+	aSec, aFrac, _ := strings.Cut(a, ".")
+	bSec, bFrac, _ := strings.Cut(b, ".")
+	as, _ := strconv.ParseInt(aSec, 10, 64)
+	bs, _ := strconv.ParseInt(bSec, 10, 64)
+	if as != bs {
+		if as < bs {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(aFrac+strings.Repeat("0", 6-min(len(aFrac), 6)), bFrac+strings.Repeat("0", 6-min(len(bFrac), 6)))
+}
+
+// FollowThread subscribes the signed-in user to a thread's replies. Slack has no
+// public method for this; it calls subscriptions.thread.add, which only accepts
+// browser-session (xoxc) tokens.
+func (c *APIClient) FollowThread(ctx context.Context, channel, threadTS string) error {
+	return c.threadSubscription(ctx, "subscriptions.thread.add", channel, threadTS)
+}
+
+// UnfollowThread unsubscribes the signed-in user from a thread's replies through
+// subscriptions.thread.remove, with the same token restriction as FollowThread.
+func (c *APIClient) UnfollowThread(ctx context.Context, channel, threadTS string) error {
+	return c.threadSubscription(ctx, "subscriptions.thread.remove", channel, threadTS)
+}
+
+func (c *APIClient) threadSubscription(ctx context.Context, method, channel, threadTS string) error {
+	if err := c.checkWritable(method); err != nil {
+		return err
+	}
+	if channel == "" {
+		return ErrChannelRequired
+	}
+	if err := c.checkChannel(channel); err != nil {
+		return err
+	}
+	if threadTS == "" {
+		return ErrTimestampRequired
+	}
+	return c.call(ctx, method, url.Values{"channel": {channel}, "thread_ts": {threadTS}}, nil)
+}
+
+// call posts a form to a Web API method slack-go does not wrap and decodes the
+// response into out. Slack errors are returned as slack-go's SlackErrorResponse, so
+// they are classified like any other API error.
+func (c *APIClient) call(ctx context.Context, method string, form url.Values, out any) error {
+	form.Set("token", c.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+method, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpClient := c.http
+	if httpClient == nil {
+		httpClient = defaultHTTPClient()
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retry, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &slackapi.RateLimitedError{RetryAfter: time.Duration(retry) * time.Second}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", method, resp.Status)
+	}
+
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("%s: decode: %w", method, err)
+	}
+	var status slackapi.SlackResponse
+	if err := json.Unmarshal(body, &status); err != nil {
+		return fmt.Errorf("%s: decode: %w", method, err)
+	}
+	if !status.Ok {
+		return fmt.Errorf("%s: %w", method, slackapi.SlackErrorResponse{Err: status.Error, ResponseMetadata: status.ResponseMetadata})
+	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("%s: decode: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// ThreadListResult represents the result of listing active threads.
+type ThreadListResult struct {
+	Channel      string             `json:"channel"`
+	ChannelID    string             `json:"channel_id"`
+	Threads      []ThreadSummary    `json:"threads"`
+	HasMore      bool               `json:"has_more"`
+	userResolver SearchUserResolver `json:"-"`
+	ctx          context.Context    `json:"-"`
+	rawJSON      bool               `json:"-"`
+}
+
+// SetUserResolver configures thread author name resolution.
+func (r *ThreadListResult) SetUserResolver(ctx context.Context, resolver SearchUserResolver) {
+	r.ctx = ctx
+	r.userResolver = resolver
+}
+
+// SetRawJSON controls whether JSON output should preserve raw Slack user IDs.
+func (r *ThreadListResult) SetRawJSON(raw bool) {
+	r.rawJSON = raw
+}
+
+// MarshalJSON resolves thread authors to @names, keeping IDs in user_id.
+func (r ThreadListResult) MarshalJSON() ([]byte, error) {
+	type threadOutput struct {
+		ThreadSummary
+		UserID string `json:"user_id,omitempty"`
+	}
+	out := struct {
+		Channel   string         `json:"channel"`
+		ChannelID string         `json:"channel_id"`
+		Threads   []threadOutput `json:"threads"`
+		HasMore   bool           `json:"has_more"`
+	}{Channel: r.Channel, ChannelID: r.ChannelID, HasMore: r.HasMore, Threads: make([]threadOutput, 0, len(r.Threads))}
+
+	for _, t := range r.Threads {
+		entry := threadOutput{ThreadSummary: t}
+		if !r.rawJSON && r.userResolver != nil && r.ctx != nil && t.User != "" {
+			if name := userRef(r.ctx, r.userResolver, t.User); name != "@"+t.User {
+				entry.UserID = t.User
+				entry.User = name
+			}
+		}
+		out.Threads = append(out.Threads, entry)
+	}
+	return json.Marshal(out)
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *ThreadListResult) Lines() []string {
+	title := fmt.Sprintf("Active threads in %s (%d)", r.Channel, len(r.Threads))
+	lines := []string{title, strings.Repeat("-", len(title))}
+	if len(r.Threads) == 0 {
+		return append(lines, "No active threads.")
+	}
+	for _, t := range r.Threads {
+		text := output.Truncate(strings.ReplaceAll(t.Text, "\n", " "), 80)
+		last := t.LastActivity()
+		if sec, _, _ := strings.Cut(last, "."); sec != "" {
+			if unix, err := strconv.ParseInt(sec, 10, 64); err == nil {
+				last = time.Unix(unix, 0).Local().Format("2006-01-02 15:04")
+			}
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s", t.TS, userRef(r.ctx, r.userResolver, t.User), text))
+		lines = append(lines, fmt.Sprintf("  %d replies, last %s", t.ReplyCount, last))
+	}
+	if r.HasMore {
+		lines = append(lines, "More threads may exist; raise --limit or --max-messages.")
+	}
+	return lines
+}
+
+// ThreadFollowResult represents the result of following or unfollowing a thread.
+type ThreadFollowResult struct {
+	OK        bool   `json:"ok"`
+	Action    string `json:"action"`
+	Channel   string `json:"channel"`
+	ChannelID string `json:"channel_id"`
+	Timestamp string `json:"ts"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r *ThreadFollowResult) Lines() []string {
+	if r.Action == "follow" {
+		return []string{fmt.Sprintf("✓ Following thread %s in %s", r.Timestamp, r.Channel)}
+	}
+	return []string{fmt.Sprintf("✓ Unfollowed thread %s in %s", r.Timestamp, r.Channel)}
+}
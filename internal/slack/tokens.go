@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// TokenType identifies the kind of Slack token by its prefix.
+type TokenType string
+
+const (
+	TokenUser    TokenType = "user"
+	TokenBot     TokenType = "bot"
+	TokenClient  TokenType = "client"
+	TokenApp     TokenType = "app"
+	TokenUnknown TokenType = "unknown"
+)
+
+// minTokenLength is shorter than any token Slack issues; anything below it has
+// almost certainly been truncated while copying.
+const minTokenLength = 20
+
+var tokenPrefixes = []struct {
+	prefix      string
+	kind        TokenType
+	description string
+}{
+	{"xoxp-", TokenUser, "OAuth user token: acts as the installing user"},
+	{"xoxb-", TokenBot, "Bot token: acts as the app's bot user"},
+	{"xoxc-", TokenClient, "Browser client token: acts as the signed-in user, requires the d= cookie"},
+	{"xapp-", TokenApp, "App-level token: opens Socket Mode connections only, cannot call Web API methods"},
+}
+
+// TokenInfo describes a token without revealing it.
+type TokenInfo struct {
+	Type        TokenType `json:"type"`
+	Prefix      string    `json:"prefix,omitempty"`
+	Description string    `json:"description"`
+	Problems    []string  `json:"problems,omitempty"`
+}
+
+// ClassifyToken reports the token type and any formatting mistakes that would make
+// Slack reject it with an unhelpful invalid_auth.
+func ClassifyToken(token string) TokenInfo {
+	info := TokenInfo{Type: TokenUnknown, Description: "Unrecognized token format"}
+	if strings.TrimSpace(token) == "" {
+		info.Description = "No token configured"
+		return info
+	}
+
+	// Surrounding whitespace is trimmed when the config is loaded, so only the
+	// mistakes that survive trimming are reported.
+	candidate := strings.TrimSpace(token)
+	if strings.HasPrefix(strings.ToLower(candidate), "bearer ") {
+		info.Problems = append(info.Problems, `token includes a "Bearer " prefix; store the token only`)
+		candidate = strings.TrimSpace(candidate[len("bearer "):])
+	}
+	if unquoted := strings.Trim(candidate, `"'`); unquoted != candidate {
+		info.Problems = append(info.Problems, "token is wrapped in quotes")
+		candidate = unquoted
+	}
+	if strings.ContainsAny(candidate, " \t\r\n") {
+		info.Problems = append(info.Problems, "token contains whitespace")
+	}
+
+	for _, p := range tokenPrefixes {
+		if strings.HasPrefix(candidate, p.prefix) {
+			info.Type, info.Prefix, info.Description = p.kind, p.prefix, p.description
+			break
+		}
+	}
+	if info.Type == TokenUnknown {
+		info.Problems = append(info.Problems, "token does not start with xoxp-, xoxb-, xoxc-, or xapp-")
+	} else if len(candidate) < minTokenLength {
+		info.Problems = append(info.Problems, "token is too short; it may have been truncated")
+	}
+	return info
+}
+
+// ParseScopes splits a comma-separated scope list such as the X-OAuth-Scopes header.
+func ParseScopes(header string) []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// NormalizeCookie extracts the d cookie value that xoxc- tokens require from what a
+// user is likely to paste: the bare value, "d=<value>", or a whole Set-Cookie style
+// string with attributes. Decoded values are URL-encoded again, since Slack expects
+// the cookie exactly as the browser stores it.
+func NormalizeCookie(raw string) (string, error) {
+	value := strings.Trim(strings.TrimSpace(raw), `"'`)
+	if i := strings.Index(value, ";"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	value = strings.TrimPrefix(value, "d=")
+	if value == "" {
+		return "", errors.New("d cookie is required")
+	}
+	if !strings.HasPrefix(value, "xoxd-") {
+		return "", errors.New("d cookie must start with xoxd-; copy the value of the cookie named d for app.slack.com")
+	}
+	if strings.ContainsAny(value, " \t\r\n") {
+		return "", errors.New("d cookie contains whitespace")
+	}
+	if strings.ContainsAny(value, "+/=") {
+		value = url.QueryEscape(value)
+	}
+	if len(value) < minTokenLength {
+		return "", errors.New("d cookie is too short; it may have been truncated")
+	}
+	return value, nil
+}
@@ -0,0 +1,425 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/breaker"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/ratelimit"
+	"github.com/kehao95/slack-agent-cli/internal/tracing"
+)
+
+// maxInspectBytes bounds how much of a request or response body is buffered for inspection.
+const maxInspectBytes = 1 << 20
+
+// defaultTransport returns the base transport shared by all Slack API clients.
+func defaultTransport() http.RoundTripper {
+	return &budgetTransport{base: &breakerTransport{base: &retryTransport{base: &captureTransport{base: &tracingTransport{base: http.DefaultTransport}}}}}
+}
+
+// defaultHTTPClient returns an HTTP client using the shared Slack transport.
+func defaultHTTPClient() *http.Client {
+	return &http.Client{Transport: defaultTransport()}
+}
+
+// APIMethod returns the Slack Web API method name for a request, e.g. chat.postMessage.
+func APIMethod(req *http.Request) string {
+	if req == nil || req.URL == nil {
+		return ""
+	}
+	return path.Base(req.URL.Path)
+}
+
+// requestParam returns a form or query parameter from a Slack API request without
+// consuming the request body.
+func requestParam(req *http.Request, name string) string {
+	if req == nil {
+		return ""
+	}
+	if value := req.URL.Query().Get(name); value != "" {
+		return value
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxInspectBytes))
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return ""
+	}
+	return values.Get(name)
+}
+
+// slackEnvelope holds the status fields shared by every Slack Web API response.
+type slackEnvelope struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	// Needed and Provided accompany missing_scope errors as comma-separated scope lists.
+	Needed   string `json:"needed"`
+	Provided string `json:"provided"`
+}
+
+// peekSlackResponse decodes the status fields of a JSON Slack response without consuming
+// its body. It returns false when the response is not JSON.
+func peekSlackResponse(resp *http.Response) (slackEnvelope, bool) {
+	var envelope slackEnvelope
+	if resp == nil || resp.Body == nil {
+		return envelope, false
+	}
+	if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return envelope, false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxInspectBytes))
+	rest := resp.Body
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if err != nil || json.Unmarshal(body, &envelope) != nil {
+		return slackEnvelope{}, false
+	}
+	return envelope, true
+}
+
+// slackResponseError peeks at a JSON Slack response and returns its error field when ok=false.
+func slackResponseError(resp *http.Response) string {
+	envelope, ok := peekSlackResponse(resp)
+	if !ok || envelope.OK {
+		return ""
+	}
+	return envelope.Error
+}
+
+// tracingTransport wraps each Slack API call in a client span when tracing is enabled.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !tracing.Enabled() {
+		return t.base.RoundTrip(req)
+	}
+
+	method := APIMethod(req)
+	ctx, span := tracing.Start(req.Context(), "slack "+method, tracing.KindClient)
+	defer span.End()
+
+	span.SetAttribute("slack.method", method)
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("slack.retry_attempt", tracing.RetryAttempt(ctx))
+	if channel := requestParam(req, "channel"); channel != "" {
+		span.SetAttribute("slack.channel", channel)
+	}
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		return resp, err
+	}
+
+	span.SetAttribute("http.response.status_code", resp.StatusCode)
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, convErr := strconv.Atoi(retryAfter); convErr == nil {
+			span.SetAttribute("slack.retry_after_seconds", seconds)
+		}
+	}
+	switch {
+	case resp.StatusCode >= 400:
+		span.SetAttribute("slack.status", "http_error")
+		span.SetStatus(tracing.StatusError, resp.Status)
+	default:
+		if apiErr := slackResponseError(resp); apiErr != "" {
+			span.SetAttribute("slack.status", "error")
+			span.SetAttribute("slack.error", apiErr)
+			span.SetStatus(tracing.StatusError, apiErr)
+		} else {
+			span.SetAttribute("slack.status", "ok")
+			span.SetStatus(tracing.StatusOK, "")
+		}
+	}
+	return resp, nil
+}
+
+type responseCaptureKey struct{}
+
+// withResponseCapture asks the shared transport to copy raw response bodies into buf. It lets
+// callers read fields slack-go drops while decoding (such as a huddle message's room).
+func withResponseCapture(ctx context.Context, buf *bytes.Buffer) context.Context {
+	return context.WithValue(ctx, responseCaptureKey{}, buf)
+}
+
+type headerCaptureKey struct{}
+
+// withHeaderCapture asks the shared transport to copy response headers into header, for
+// values Slack only reports there (such as the token's X-OAuth-Scopes).
+func withHeaderCapture(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, headerCaptureKey{}, header)
+}
+
+// captureTransport tees response bodies into the buffer installed by withResponseCapture,
+// copies headers into the map installed by withHeaderCapture, and records missing_scope
+// answers for LastScopeGap.
+type captureTransport struct {
+	base http.RoundTripper
+}
+
+func (t *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		recordScopeGap(req, resp)
+	}
+	if header, _ := req.Context().Value(headerCaptureKey{}).(http.Header); err == nil && header != nil {
+		for key, values := range resp.Header {
+			header[key] = values
+		}
+	}
+	buf, _ := req.Context().Value(responseCaptureKey{}).(*bytes.Buffer)
+	if err != nil || buf == nil || resp.Body == nil {
+		return resp, err
+	}
+	buf.Reset()
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.TeeReader(resp.Body, buf), resp.Body}
+	return resp, nil
+}
+
+// RetryPolicy controls how the shared transport retries failed Slack API calls.
+type RetryPolicy struct {
+	// Max is the number of retries after the first attempt; 0 disables retrying.
+	Max int
+	// BaseBackoff is the first wait; each later retry doubles it, up to MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// RateLimit retries HTTP 429 after Retry-After, unless that exceeds MaxBackoff.
+	RateLimit bool
+	// Network retries calls that failed to connect (DNS or dial errors). A call that
+	// failed after it was sent is not retried, since Slack may have acted on it.
+	Network bool
+	// Server retries HTTP 5xx responses.
+	Server bool
+}
+
+// DefaultRetryPolicy is used until SetRetryPolicy is called.
+var DefaultRetryPolicy = RetryPolicy{Max: 2, BaseBackoff: time.Second, MaxBackoff: 30 * time.Second, RateLimit: true, Network: true}
+
+var retryPolicy atomic.Pointer[RetryPolicy]
+
+// SetRetryPolicy sets the retry policy for every Slack API client, including ones
+// already created.
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicy.Store(&policy)
+}
+
+func currentRetryPolicy() RetryPolicy {
+	if policy := retryPolicy.Load(); policy != nil {
+		return *policy
+	}
+	return DefaultRetryPolicy
+}
+
+// backoff returns the wait before retry number attempt+1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.BaseBackoff
+	for i := 0; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// retryWait reports whether a failed attempt should be retried and how long to wait first.
+func (p RetryPolicy) retryWait(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	switch {
+	case err != nil:
+		return p.backoff(attempt), p.Network && notSent(err)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if !p.RateLimit {
+			return 0, false
+		}
+		wait := p.backoff(attempt)
+		if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil {
+			retryAfter := time.Duration(seconds) * time.Second
+			if retryAfter > p.MaxBackoff {
+				return 0, false
+			}
+			if retryAfter > wait {
+				wait = retryAfter
+			}
+		}
+		return wait, true
+	case resp.StatusCode >= 500:
+		return p.backoff(attempt), p.Server
+	}
+	return 0, false
+}
+
+// notSent reports whether err means the request never reached Slack.
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryTransport repeats a Slack API call according to the current RetryPolicy. Requests
+// whose body cannot be replayed are sent once.
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy := currentRetryPolicy()
+	ctx := req.Context()
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	firstAttempt := tracing.RetryAttempt(ctx)
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(attemptReq)
+		recordCall(req, attempt, resp)
+		if attempt >= policy.Max || !replayable || ctx.Err() != nil {
+			return resp, err
+		}
+		wait, retry := policy.retryWait(attempt, resp, err)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxInspectBytes))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			recordRateLimitWait(wait)
+		}
+
+		attemptReq = req.Clone(tracing.WithRetryAttempt(ctx, firstAttempt+attempt+1))
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+var circuitBreaker atomic.Pointer[breaker.Breaker]
+
+// SetCircuitBreaker makes every Slack API client record failures in b and refuse calls
+// to methods whose breaker is open. A nil b turns the check off.
+func SetCircuitBreaker(b *breaker.Breaker) {
+	circuitBreaker.Store(b)
+}
+
+// serverErrors are Slack error codes that mean the endpoint itself is failing.
+var serverErrors = map[string]bool{
+	"fatal_error":         true,
+	"internal_error":      true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+}
+
+// breakerTransport consults the circuit breaker before each call, after retries have run
+// their course. Connection errors, HTTP 5xx, and Slack server errors count as failures;
+// rate limits count as neither, and any other answer (including auth and permission
+// errors) shows the endpoint is up and clears the streak.
+type breakerTransport struct {
+	base http.RoundTripper
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := circuitBreaker.Load()
+	if b == nil {
+		return t.base.RoundTrip(req)
+	}
+	method := APIMethod(req)
+	if err := b.Allow(method); err != nil {
+		return nil, &cerrors.ErrorWithExitCode{Err: err, ExitCode: cerrors.ExitBreaker, Code: cerrors.CodeBreakerOpen}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil:
+		if req.Context().Err() == nil {
+			_ = b.Failure(method, err.Error())
+		}
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode >= 500:
+		_ = b.Failure(method, resp.Status)
+	default:
+		if apiErr := slackResponseError(resp); serverErrors[apiErr] {
+			_ = b.Failure(method, apiErr)
+		} else {
+			_ = b.Success(method)
+		}
+	}
+	return resp, err
+}
+
+var budgets atomic.Pointer[ratelimit.Budgets]
+
+// SetBudgets makes every Slack API client hold calls to budgeted method groups until
+// b has room for them. A nil b turns the budgets off.
+func SetBudgets(b *ratelimit.Budgets) {
+	budgets.Store(b)
+}
+
+// budgetTransport waits for the group budget of each call before anything else, so
+// retries of one call count once and calls refused by a breaker still count.
+type budgetTransport struct {
+	base http.RoundTripper
+}
+
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := budgets.Load()
+	if b == nil {
+		return t.base.RoundTrip(req)
+	}
+	start := time.Now()
+	err := b.Wait(req.Context(), APIMethod(req))
+	if waited := time.Since(start); waited >= time.Millisecond {
+		recordBudgetWait(waited)
+	}
+	var exhausted *ratelimit.ExhaustedError
+	if errors.As(err, &exhausted) {
+		return nil, &cerrors.ErrorWithExitCode{Err: err, ExitCode: cerrors.ExitRateLimit, Code: cerrors.CodeBudgetExhausted}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
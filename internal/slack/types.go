@@ -4,19 +4,37 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/mrkdwn"
+	"github.com/kehao95/slack-agent-cli/internal/output"
 )
 
 // PostMessageOptions wraps arguments for posting a message.
 type PostMessageOptions struct {
 	Text        string
 	ThreadTS    string
+	Broadcast   bool // also post a thread reply to the channel (reply_broadcast)
+	LinkNames   bool // let Slack link @names and #channels (link_names=1)
 	Blocks      []slackapi.Block
 	UnfurlLinks bool
 	UnfurlMedia bool
-	AsUser      bool
+	// Parse is "full" or "none"; empty leaves Slack's default.
+	Parse string
+	// NoMrkdwn sends mrkdwn=false, so Slack shows *bold* and _italic_ literally.
+	NoMrkdwn bool
+	// Metadata is attached to the message as Slack message metadata.
+	Metadata *slackapi.SlackMetadata
+	AsUser   bool
+	// Username, IconEmoji, and IconURL customize the bot identity for this message.
+	// They require a bot token with chat:write.customize.
+	Username  string
+	IconEmoji string
+	IconURL   string
 }
 
 // PostMessageResult represents the result of posting a message.
@@ -25,14 +43,30 @@ type PostMessageResult struct {
 	Channel   string `json:"channel"`
 	Timestamp string `json:"ts"`
 	Text      string `json:"text,omitempty"`
+	// UnresolvedMentions lists @names and #channels that --resolve-mentions could not match.
+	UnresolvedMentions []string `json:"unresolved_mentions,omitempty"`
+	IdempotencyKey     string   `json:"idempotency_key,omitempty"`
+	TraceID            string   `json:"trace_id,omitempty"`
+	// File is set when the message shared an uploaded file (messages send --file).
+	File *UploadedFile `json:"file,omitempty"`
 }
 
 // Lines implements the output.Printable interface for human-readable output.
 func (r *PostMessageResult) Lines() []string {
+	ts := r.Timestamp
+	if ts == "" && r.File != nil {
+		ts = "(not yet reported by Slack)"
+	}
 	lines := []string{
 		"Message sent successfully",
 		fmt.Sprintf("Channel: %s", r.Channel),
-		fmt.Sprintf("Timestamp: %s", r.Timestamp),
+		fmt.Sprintf("Timestamp: %s", ts),
+	}
+	if r.File != nil {
+		lines = append(lines, fmt.Sprintf("File: %s (%d bytes) %s", r.File.Name, r.File.Size, r.File.Permalink))
+	}
+	if len(r.UnresolvedMentions) > 0 {
+		lines = append(lines, "Unresolved mentions (sent as plain text): "+strings.Join(r.UnresolvedMentions, ", "))
 	}
 	return lines
 }
@@ -91,13 +125,26 @@ func (r *ReactionResult) Lines() []string {
 	return []string{actionText}
 }
 
+// ReactionParams wraps the arguments to GetReactions.
+type ReactionParams struct {
+	Channel   string
+	Timestamp string
+	// IncludeMessage also fetches the reacted-to message's text and author.
+	IncludeMessage bool
+}
+
 // ReactionListResult represents the result of listing reactions on a message.
 type ReactionListResult struct {
 	OK        bool           `json:"ok"`
 	Channel   string         `json:"channel"`
 	ChannelID string         `json:"channel_id"`
 	Timestamp string         `json:"ts"`
+	Message   *Message       `json:"message,omitempty"`
 	Reactions []ReactionItem `json:"reactions"`
+
+	userResolver SearchUserResolver `json:"-"`
+	ctx          context.Context    `json:"-"`
+	rawJSON      bool               `json:"-"`
 }
 
 // ReactionItem represents a single reaction (emoji) with count and users.
@@ -112,8 +159,12 @@ func (r *ReactionListResult) Lines() []string {
 	lines := []string{
 		fmt.Sprintf("Reactions on message in %s", r.Channel),
 		fmt.Sprintf("Timestamp: %s", r.Timestamp),
-		"───────────────────────────────",
 	}
+	if r.Message != nil {
+		text := output.Truncate(r.Message.Text, 100)
+		lines = append(lines, fmt.Sprintf("Message: %s: %s", userRef(r.ctx, r.userResolver, r.Message.User), text))
+	}
+	lines = append(lines, "───────────────────────────────")
 
 	if len(r.Reactions) == 0 {
 		lines = append(lines, "No reactions on this message.")
@@ -132,6 +183,33 @@ func (r *ReactionListResult) Lines() []string {
 	return lines
 }
 
+// SetUserResolver configures message author name resolution.
+func (r *ReactionListResult) SetUserResolver(ctx context.Context, resolver SearchUserResolver) {
+	r.ctx = ctx
+	r.userResolver = resolver
+}
+
+// SetRawJSON controls whether JSON output should preserve raw Slack user IDs.
+func (r *ReactionListResult) SetRawJSON(raw bool) {
+	r.rawJSON = raw
+}
+
+// MarshalJSON resolves the message author to an @name, keeping the ID in user_id.
+func (r ReactionListResult) MarshalJSON() ([]byte, error) {
+	out := struct {
+		OK        bool           `json:"ok"`
+		Channel   string         `json:"channel"`
+		ChannelID string         `json:"channel_id"`
+		Timestamp string         `json:"ts"`
+		Message   *messageOutput `json:"message,omitempty"`
+		Reactions []ReactionItem `json:"reactions"`
+	}{OK: r.OK, Channel: r.Channel, ChannelID: r.ChannelID, Timestamp: r.Timestamp, Reactions: r.Reactions}
+	if r.Message != nil {
+		out.Message = newMessageOutput(*r.Message, r.ctx, r.userResolver, r.rawJSON)
+	}
+	return json.Marshal(out)
+}
+
 // EmojiListResult represents the result of listing custom emoji.
 type EmojiListResult struct {
 	OK    bool              `json:"ok"`
@@ -162,6 +240,7 @@ func (r *EmojiListResult) Lines() []string {
 	for name := range r.Emoji {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 
 	// Display up to 50 emoji in human-readable mode
 	displayCount := len(names)
@@ -173,9 +252,7 @@ func (r *EmojiListResult) Lines() []string {
 		name := names[i]
 		value := r.Emoji[name]
 		// Truncate long URLs for readability
-		if len(value) > 60 {
-			value = value[:57] + "..."
-		}
+		value = output.Truncate(value, 60)
 		lines = append(lines, fmt.Sprintf(":%s: → %s", name, value))
 	}
 
@@ -239,9 +316,12 @@ func (r *PinResult) Lines() []string {
 
 // PinListResult represents the result of listing pins.
 type PinListResult struct {
-	OK      bool         `json:"ok"`
-	Channel string       `json:"channel"`
-	Items   []PinnedItem `json:"items"`
+	OK           bool               `json:"ok"`
+	Channel      string             `json:"channel"`
+	Items        []PinnedItem       `json:"items"`
+	userResolver SearchUserResolver `json:"-"`
+	ctx          context.Context    `json:"-"`
+	rawJSON      bool               `json:"-"`
 }
 
 // PinnedItem represents a pinned item in a channel.
@@ -258,6 +338,48 @@ type Message struct {
 	Timestamp string `json:"ts"`
 	Text      string `json:"text"`
 	User      string `json:"user"`
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// SetUserResolver configures creator and author name resolution.
+func (r *PinListResult) SetUserResolver(ctx context.Context, resolver SearchUserResolver) {
+	r.ctx = ctx
+	r.userResolver = resolver
+}
+
+// SetRawJSON controls whether JSON output should preserve raw Slack user IDs.
+func (r *PinListResult) SetRawJSON(raw bool) {
+	r.rawJSON = raw
+}
+
+// MarshalJSON resolves creator and author IDs to @names, keeping IDs in *_id fields.
+func (r PinListResult) MarshalJSON() ([]byte, error) {
+	type itemOutput struct {
+		PinnedItem
+		Message     *messageOutput `json:"message,omitempty"`
+		CreatedByID string         `json:"created_by_id,omitempty"`
+	}
+	out := struct {
+		OK      bool         `json:"ok"`
+		Channel string       `json:"channel"`
+		Items   []itemOutput `json:"items"`
+	}{OK: r.OK, Channel: r.Channel, Items: make([]itemOutput, 0, len(r.Items))}
+
+	resolve := !r.rawJSON && r.userResolver != nil && r.ctx != nil
+	for _, item := range r.Items {
+		entry := itemOutput{PinnedItem: item}
+		if item.Message != nil {
+			entry.Message = newMessageOutput(*item.Message, r.ctx, r.userResolver, r.rawJSON)
+		}
+		if resolve {
+			if name := userRef(r.ctx, r.userResolver, item.CreatedBy); item.CreatedBy != "" && name != item.CreatedBy {
+				entry.CreatedByID = item.CreatedBy
+				entry.CreatedBy = name
+			}
+		}
+		out.Items = append(out.Items, entry)
+	}
+	return json.Marshal(out)
 }
 
 // Lines implements the output.Printable interface for human-readable output.
@@ -276,24 +398,60 @@ func (r *PinListResult) Lines() []string {
 		if item.Type == "message" && item.Message != nil {
 			msg := item.Message
 			// Format: [timestamp] @user: text
-			userDisplay := msg.User
-			if userDisplay == "" {
-				userDisplay = "unknown"
-			}
-			text := msg.Text
-			if len(text) > 100 {
-				text = text[:97] + "..."
-			}
-			lines = append(lines, fmt.Sprintf("[%s] @%s: %s", msg.Timestamp, userDisplay, text))
+			text := output.Truncate(msg.Text, 100)
+			lines = append(lines, fmt.Sprintf("[%s] %s: %s", msg.Timestamp, userRef(r.ctx, r.userResolver, msg.User), text))
 		} else {
 			// Non-message pins (files, etc.)
 			lines = append(lines, fmt.Sprintf("[%s] %s item", item.Type, item.Type))
 		}
+		if item.Created > 0 {
+			pinned := "  Pinned " + time.Unix(item.Created, 0).Local().Format("2006-01-02 15:04")
+			if item.CreatedBy != "" {
+				pinned += " by " + userRef(r.ctx, r.userResolver, item.CreatedBy)
+			}
+			lines = append(lines, pinned)
+		}
+		if item.Message != nil && item.Message.Permalink != "" {
+			lines = append(lines, "  "+item.Message.Permalink)
+		}
 	}
 
 	return lines
 }
 
+// messageOutput is a Message in JSON output, with the author resolved to an @name and
+// the ID kept in user_id.
+type messageOutput struct {
+	Message
+	UserID string `json:"user_id,omitempty"`
+}
+
+func newMessageOutput(msg Message, ctx context.Context, resolver SearchUserResolver, raw bool) *messageOutput {
+	out := &messageOutput{Message: msg}
+	if raw || resolver == nil || ctx == nil || msg.User == "" {
+		return out
+	}
+	if name := userRef(ctx, resolver, msg.User); name != msg.User {
+		out.UserID = msg.User
+		out.User = name
+	}
+	return out
+}
+
+// userRef formats a user ID as an @name for pin and reaction output, falling back to
+// @ID when it cannot be resolved.
+func userRef(ctx context.Context, resolver SearchUserResolver, userID string) string {
+	if userID == "" {
+		return "unknown"
+	}
+	if resolver != nil && ctx != nil {
+		if name := resolver.GetMentionName(ctx, userID); name != "" && name != userID {
+			return formatSearchUserRef(name)
+		}
+	}
+	return "@" + userID
+}
+
 // HistoryParams wraps the arguments to conversations.history.
 type HistoryParams struct {
 	Channel   string
@@ -332,6 +490,11 @@ type AuthTestResponse struct {
 	UserID string `json:"user_id"`
 	BotID  string `json:"bot_id,omitempty"`
 	IsBot  bool   `json:"is_bot"`
+	// Token and Warnings are filled in by auth test; Scopes comes from the
+	// X-OAuth-Scopes header and is empty for browser client tokens.
+	Token    *TokenInfo `json:"token,omitempty"`
+	Scopes   []string   `json:"scopes,omitempty"`
+	Warnings []string   `json:"warnings,omitempty"`
 }
 
 // Lines implements the output.Printable interface for human-readable output.
@@ -347,6 +510,15 @@ func (r *AuthTestResponse) Lines() []string {
 	if r.BotID != "" {
 		lines = append(lines, fmt.Sprintf("Bot ID: %s", r.BotID))
 	}
+	if r.Token != nil {
+		lines = append(lines, fmt.Sprintf("Token: %s (%s)", r.Token.Type, r.Token.Description))
+	}
+	if len(r.Scopes) > 0 {
+		lines = append(lines, fmt.Sprintf("Scopes: %s", strings.Join(r.Scopes, ", ")))
+	}
+	for _, warning := range r.Warnings {
+		lines = append(lines, fmt.Sprintf("Warning: %s", warning))
+	}
 	return lines
 }
 
@@ -374,6 +546,7 @@ type SearchResult struct {
 	channelResolver SearchChannelResolver `json:"-"`
 	ctx             context.Context       `json:"-"`
 	rawJSON         bool                  `json:"-"`
+	extractEntities bool                  `json:"-"`
 }
 
 // SearchMessages contains the list of matching messages.
@@ -427,6 +600,11 @@ func (r *SearchResult) SetRawJSON(raw bool) {
 	r.rawJSON = raw
 }
 
+// SetExtractEntities adds an "entities" object (URLs, mentions, emoji) to each match in JSON output.
+func (r *SearchResult) SetExtractEntities(extract bool) {
+	r.extractEntities = extract
+}
+
 // MarshalJSON enriches search results with resolved user and channel references.
 func (r SearchResult) MarshalJSON() ([]byte, error) {
 	type output struct {
@@ -466,12 +644,19 @@ func (r SearchResult) MarshalJSON() ([]byte, error) {
 			}
 		}
 
+		if r.extractEntities {
+			entry["entities"] = r.searchEntities(match.Text)
+		}
+
 		result.Messages.Matches[i] = entry
 	}
 
 	return json.Marshal(result)
 }
 
+// searchSnippetChars caps each match in human search output; JSON keeps the full text.
+const searchSnippetChars = 200
+
 // Lines implements the output.Printable interface for human-readable search results.
 func (r *SearchResult) Lines() []string {
 	lines := []string{
@@ -505,7 +690,7 @@ func (r *SearchResult) Lines() []string {
 
 		lines = append(lines, "")
 		lines = append(lines, fmt.Sprintf("[%s] #%s @%s:", ts, channelName, username))
-		lines = append(lines, fmt.Sprintf("  %s", match.Text))
+		lines = append(lines, "  "+output.Truncate(strings.ReplaceAll(match.Text, "\n", " "), searchSnippetChars))
 		if match.Permalink != "" {
 			lines = append(lines, fmt.Sprintf("  %s", match.Permalink))
 		}
@@ -527,6 +712,31 @@ func (r SearchResult) resolvedSearchUserRef(userID string) string {
 	return userID
 }
 
+func (r SearchResult) searchEntities(text string) mrkdwn.Entities {
+	e := mrkdwn.Extract(text)
+	if r.rawJSON || r.ctx == nil {
+		return e
+	}
+	if r.userResolver != nil {
+		for i, user := range e.Users {
+			if name := r.userResolver.GetMentionName(r.ctx, user.ID); name != "" && name != user.ID {
+				e.Users[i].Name = name
+			}
+		}
+	}
+	if r.channelResolver != nil {
+		for i, channel := range e.Channels {
+			if channel.Name != "" {
+				continue
+			}
+			if name := strings.TrimPrefix(r.channelResolver.ResolveName(r.ctx, channel.ID), "#"); name != "" && name != channel.ID {
+				e.Channels[i].Name = name
+			}
+		}
+	}
+	return e
+}
+
 func (r SearchResult) resolvedSearchChannelRef(channel SearchChannel) string {
 	name := strings.TrimSpace(channel.Name)
 	if name == "" && r.channelResolver != nil && r.ctx != nil && channel.ID != "" {
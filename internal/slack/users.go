@@ -3,6 +3,7 @@ package slack
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	slackapi "github.com/slack-go/slack"
 )
@@ -32,6 +33,15 @@ func (c *APIClient) ListUsers(ctx context.Context, cursor string, limit int) ([]
 	return users, "", nil
 }
 
+// GetBotInfo fetches a bot's name and app (bots.info).
+func (c *APIClient) GetBotInfo(ctx context.Context, botID string) (*slackapi.Bot, error) {
+	bot, err := c.sdk.GetBotInfoContext(ctx, botID)
+	if err != nil {
+		return nil, fmt.Errorf("get bot info: %w", err)
+	}
+	return bot, nil
+}
+
 // GetUserGroups fetches all user groups from the workspace.
 func (c *APIClient) GetUserGroups(ctx context.Context) ([]slackapi.UserGroup, error) {
 	groups, err := c.sdk.GetUserGroupsContext(ctx)
@@ -41,6 +51,26 @@ func (c *APIClient) GetUserGroups(ctx context.Context) ([]slackapi.UserGroup, er
 	return groups, nil
 }
 
+// GetUserGroupMembers fetches the user IDs in a usergroup (usergroups.users.list).
+func (c *APIClient) GetUserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	members, err := c.sdk.GetUserGroupMembersContext(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("get usergroup members: %w", err)
+	}
+	return members, nil
+}
+
+// UpdateUserGroupMembers replaces the members of a usergroup (usergroups.users.update).
+func (c *APIClient) UpdateUserGroupMembers(ctx context.Context, groupID string, userIDs []string) error {
+	if err := c.checkWritable("usergroups.users.update"); err != nil {
+		return err
+	}
+	if _, err := c.sdk.UpdateUserGroupMembersContext(ctx, groupID, strings.Join(userIDs, ",")); err != nil {
+		return fmt.Errorf("update usergroup members: %w", err)
+	}
+	return nil
+}
+
 // GetUserPresence fetches the presence status of a specific user.
 func (c *APIClient) GetUserPresence(ctx context.Context, userID string) (*slackapi.UserPresence, error) {
 	presence, err := c.sdk.GetUserPresenceContext(ctx, userID)
@@ -49,3 +79,12 @@ func (c *APIClient) GetUserPresence(ctx context.Context, userID string) (*slacka
 	}
 	return presence, nil
 }
+
+// GetDNDInfo fetches a user's Do Not Disturb status.
+func (c *APIClient) GetDNDInfo(ctx context.Context, userID string) (*slackapi.DNDStatus, error) {
+	status, err := c.sdk.GetDNDInfoContext(ctx, &userID)
+	if err != nil {
+		return nil, fmt.Errorf("get dnd info: %w", err)
+	}
+	return status, nil
+}
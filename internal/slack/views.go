@@ -0,0 +1,81 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// PublishHomeView publishes a user's App Home tab (views.publish). hash, when set,
+// makes Slack reject the update if the view changed since that hash was returned.
+func (c *APIClient) PublishHomeView(ctx context.Context, userID string, view slackapi.HomeTabViewRequest, hash string) (*slackapi.ViewResponse, error) {
+	if err := c.checkWritable("views.publish"); err != nil {
+		return nil, err
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	resp, err := c.sdk.PublishViewContext(ctx, userID, view, hash)
+	if err != nil {
+		return nil, viewError("publish view", err)
+	}
+	return resp, nil
+}
+
+// OpenView opens a modal in response to an interaction (views.open).
+func (c *APIClient) OpenView(ctx context.Context, triggerID string, view slackapi.ModalViewRequest) (*slackapi.ViewResponse, error) {
+	if err := c.checkWritable("views.open"); err != nil {
+		return nil, err
+	}
+	if triggerID == "" {
+		return nil, fmt.Errorf("trigger ID is required")
+	}
+	resp, err := c.sdk.OpenViewContext(ctx, triggerID, view)
+	if err != nil {
+		return nil, viewError("open view", err)
+	}
+	return resp, nil
+}
+
+// PushView pushes a modal onto the open modal stack (views.push).
+func (c *APIClient) PushView(ctx context.Context, triggerID string, view slackapi.ModalViewRequest) (*slackapi.ViewResponse, error) {
+	if err := c.checkWritable("views.push"); err != nil {
+		return nil, err
+	}
+	if triggerID == "" {
+		return nil, fmt.Errorf("trigger ID is required")
+	}
+	resp, err := c.sdk.PushViewContext(ctx, triggerID, view)
+	if err != nil {
+		return nil, viewError("push view", err)
+	}
+	return resp, nil
+}
+
+// UpdateView replaces an open modal, identified by viewID or externalID (views.update).
+func (c *APIClient) UpdateView(ctx context.Context, view slackapi.ModalViewRequest, viewID, externalID, hash string) (*slackapi.ViewResponse, error) {
+	if err := c.checkWritable("views.update"); err != nil {
+		return nil, err
+	}
+	if viewID == "" && externalID == "" {
+		return nil, fmt.Errorf("view ID or external ID is required")
+	}
+	resp, err := c.sdk.UpdateViewContext(ctx, view, externalID, hash, viewID)
+	if err != nil {
+		return nil, viewError("update view", err)
+	}
+	return resp, nil
+}
+
+// viewError wraps a views.* failure, adding the per-field messages Slack returns
+// with invalid_arguments (e.g. "must be less than 25 characters [json-pointer:/view/title/text]").
+func viewError(operation string, err error) error {
+	var slackErr slackapi.SlackErrorResponse
+	if errors.As(err, &slackErr) && len(slackErr.ResponseMetadata.Messages) > 0 {
+		return fmt.Errorf("%s: %w: %s", operation, err, strings.Join(slackErr.ResponseMetadata.Messages, "; "))
+	}
+	return fmt.Errorf("%s: %w", operation, err)
+}
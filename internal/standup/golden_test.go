@@ -0,0 +1,35 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	at := func(hour, min int) time.Time { return time.Date(2024, 1, 15, hour, min, 0, 0, time.UTC) }
+	responses := []Response{
+		{UserID: "U1", User: "@alice", FirstReplyAt: at(9, 12), Text: "Yesterday: shipped the importer\nToday: tests",
+			Replies: []Reply{{TS: "1705309920.000200", Text: "Yesterday: shipped the importer\nToday: tests"}}},
+		{UserID: "U3", FirstReplyAt: at(9, 40), Text: "Out sick yesterday\n\nBack today",
+			Replies: []Reply{{TS: "1705311600.000300", Text: "Out sick yesterday"}, {TS: "1705311700.000400", Text: "Back today"}}},
+	}
+	tests := []struct {
+		name string
+		p    output.Printable
+	}{
+		{"Result", Result{Channel: "#team", ChannelID: "C123ABC", Prompt: "What did you do yesterday?", TS: "1705309200.000100",
+			Responded: 2, Expected: 3, Responses: responses, Missing: []string{"@bob"}}},
+		{"Result_open", Result{Channel: "#team", ChannelID: "C123ABC", Prompt: "What did you do yesterday?", TS: "1705309200.000100",
+			Responded: 2, Responses: responses}},
+		{"Result_no_replies", Result{Channel: "#team", ChannelID: "C123ABC", Prompt: "Standup!", TS: "1705309200.000100",
+			Responses: []Response{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
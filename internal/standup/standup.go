@@ -0,0 +1,152 @@
+// Package standup gathers replies to a standup prompt thread into a per-user
+// summary for slk standup collect.
+package standup
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// Reply is one message a participant posted in the standup thread.
+type Reply struct {
+	TS   string `json:"ts"`
+	Text string `json:"text"`
+}
+
+// Response is everything one participant replied, oldest first.
+type Response struct {
+	UserID       string    `json:"user_id"`
+	User         string    `json:"user,omitempty"`
+	FirstReplyAt time.Time `json:"first_reply_at"`
+	// Text joins the replies with blank lines, for consumers that want one answer per
+	// person.
+	Text    string  `json:"text"`
+	Replies []Reply `json:"replies"`
+}
+
+// Collector accumulates thread replies by user. Adding a reply again with the same
+// timestamp replaces its text, so a final sweep picks up edits.
+type Collector struct {
+	users   []string
+	replies map[string][]Reply
+}
+
+// NewCollector returns an empty Collector.
+func NewCollector() *Collector {
+	return &Collector{replies: map[string][]Reply{}}
+}
+
+// Add records a reply and reports whether it is the user's first. Replies without a
+// user, such as bot messages, are ignored.
+func (c *Collector) Add(userID, ts, text string) bool {
+	if userID == "" || ts == "" {
+		return false
+	}
+	replies, seen := c.replies[userID]
+	if !seen {
+		c.users = append(c.users, userID)
+	}
+	if i := slices.IndexFunc(replies, func(r Reply) bool { return r.TS == ts }); i >= 0 {
+		replies[i].Text = text
+		return false
+	}
+	replies = append(replies, Reply{TS: ts, Text: text})
+	slices.SortFunc(replies, func(a, b Reply) int { return slack.CompareTS(a.TS, b.TS) })
+	c.replies[userID] = replies
+	return !seen
+}
+
+// Has reports whether userID has replied.
+func (c *Collector) Has(userID string) bool {
+	_, ok := c.replies[userID]
+	return ok
+}
+
+// Responses returns one Response per user, ordered by first reply. User names are
+// left for the caller to fill in.
+func (c *Collector) Responses() []Response {
+	responses := make([]Response, 0, len(c.users))
+	for _, userID := range c.users {
+		replies := c.replies[userID]
+		texts := make([]string, len(replies))
+		for i, r := range replies {
+			texts[i] = r.Text
+		}
+		responses = append(responses, Response{
+			UserID:       userID,
+			FirstReplyAt: tsTime(replies[0].TS),
+			Text:         strings.Join(texts, "\n\n"),
+			Replies:      replies,
+		})
+	}
+	slices.SortStableFunc(responses, func(a, b Response) int {
+		return slack.CompareTS(a.Replies[0].TS, b.Replies[0].TS)
+	})
+	return responses
+}
+
+// Missing returns the expected user IDs that have not replied, in the given order.
+func (c *Collector) Missing(expected []string) []string {
+	var missing []string
+	for _, userID := range expected {
+		if !c.Has(userID) {
+			missing = append(missing, userID)
+		}
+	}
+	return missing
+}
+
+func tsTime(ts string) time.Time {
+	sec, err := strconv.ParseFloat(ts, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), 0)
+}
+
+// Result is the output of standup collect.
+type Result struct {
+	Channel   string    `json:"channel"`
+	ChannelID string    `json:"channel_id"`
+	Prompt    string    `json:"prompt"`
+	TS        string    `json:"ts"`
+	StartedAt time.Time `json:"started_at"`
+	Deadline  time.Time `json:"deadline"`
+	EndedAt   time.Time `json:"ended_at"`
+	// Complete is set when every --users participant replied; it is false when no
+	// participants were named.
+	Complete  bool       `json:"complete"`
+	Responded int        `json:"responded"`
+	Expected  int        `json:"expected,omitempty"`
+	Responses []Response `json:"responses"`
+	// Missing lists the named participants who did not reply, as @names.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// Lines implements the output.Printable interface for human-readable output.
+func (r Result) Lines() []string {
+	count := fmt.Sprintf("%d responded", r.Responded)
+	if r.Expected > 0 {
+		count = fmt.Sprintf("%d of %d responded", r.Responded, r.Expected)
+	}
+	lines := []string{fmt.Sprintf("Standup in %s (thread %s): %s", r.Channel, r.TS, count)}
+	for _, resp := range r.Responses {
+		who := resp.User
+		if who == "" {
+			who = resp.UserID
+		}
+		lines = append(lines, fmt.Sprintf("%s at %s:", who, resp.FirstReplyAt.Format("15:04")))
+		for _, line := range strings.Split(resp.Text, "\n") {
+			lines = append(lines, strings.TrimRight("  "+line, " "))
+		}
+	}
+	if len(r.Missing) > 0 {
+		lines = append(lines, "Missing: "+strings.Join(r.Missing, ", "))
+	}
+	return lines
+}
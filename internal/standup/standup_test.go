@@ -0,0 +1,46 @@
+package standup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	if !c.Add("U2", "1705312500.000300", "blocked on review") {
+		t.Error("first reply from U2 should be reported as first")
+	}
+	if !c.Add("U1", "1705312400.000200", "shipped the importer") {
+		t.Error("first reply from U1 should be reported as first")
+	}
+	if c.Add("U2", "1705312450.000250", "yesterday: docs") {
+		t.Error("second reply from U2 should not be reported as first")
+	}
+	// A later sweep sees an edited reply under the same timestamp.
+	c.Add("U1", "1705312400.000200", "shipped the importer and its tests")
+	if c.Add("", "1705312600.000400", "bot summary") {
+		t.Error("replies without a user should be ignored")
+	}
+
+	got := c.Responses()
+	if len(got) != 2 || got[0].UserID != "U1" || got[1].UserID != "U2" {
+		t.Fatalf("Responses() order = %+v, want U1 then U2", got)
+	}
+	if got[0].Text != "shipped the importer and its tests" {
+		t.Errorf("U1 text = %q, want the edited text", got[0].Text)
+	}
+	wantU2 := []Reply{{TS: "1705312450.000250", Text: "yesterday: docs"}, {TS: "1705312500.000300", Text: "blocked on review"}}
+	if !reflect.DeepEqual(got[1].Replies, wantU2) {
+		t.Errorf("U2 replies = %+v, want %+v", got[1].Replies, wantU2)
+	}
+	if got[1].Text != "yesterday: docs\n\nblocked on review" {
+		t.Errorf("U2 text = %q", got[1].Text)
+	}
+	if got[1].FirstReplyAt.Unix() != 1705312450 {
+		t.Errorf("U2 first_reply_at = %v", got[1].FirstReplyAt)
+	}
+
+	if missing := c.Missing([]string{"U3", "U1", "U4"}); !reflect.DeepEqual(missing, []string{"U3", "U4"}) {
+		t.Errorf("Missing() = %v, want [U3 U4]", missing)
+	}
+}
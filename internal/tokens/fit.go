@@ -0,0 +1,182 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// minStringRunes is the shortest a string value is cut to when fitting a budget.
+const minStringRunes = 40
+
+// Truncation describes what was cut to fit output into a token budget.
+type Truncation struct {
+	// Field is the top-level array whose trailing items were dropped.
+	Field   string `json:"field,omitempty"`
+	Kept    int    `json:"kept"`
+	Omitted int    `json:"omitted"`
+	// StringsShortenedTo is set when long string values were cut to this many characters.
+	StringsShortenedTo int `json:"strings_shortened_to,omitempty"`
+	// OverBudget is set when nothing more could be cut and the output still exceeds the budget.
+	OverBudget bool `json:"over_budget,omitempty"`
+}
+
+// FitJSON trims a JSON object until Max estimates it at no more than budget tokens.
+// Trailing items of the largest top-level array go first; if that would leave none, long
+// strings are shortened as well. The result carries a "truncated" field describing the
+// cut. Documents that already fit are returned unchanged with a nil Truncation.
+func FitJSON(data []byte, budget int) ([]byte, *Truncation, error) {
+	if Max(string(data)) <= budget {
+		return data, nil, nil
+	}
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("fit output to token budget: output is not a JSON object: %w", err)
+	}
+
+	out, cut, err := dropItems(doc, budget, 0)
+	for limit := longestString(doc) / 2; err == nil && lostEverything(cut); limit /= 2 {
+		limit = max(limit, minStringRunes)
+		out, cut, err = dropItems(shortenStrings(doc, limit).(map[string]any), budget, limit)
+		if limit == minStringRunes {
+			break
+		}
+	}
+	return out, cut, err
+}
+
+// lostEverything reports whether cut is over budget or dropped every array item.
+func lostEverything(cut *Truncation) bool {
+	return cut.OverBudget || cut.Field != "" && cut.Kept == 0
+}
+
+// dropItems keeps as many leading items of the largest top-level array as fit, and adds
+// the "truncated" field. shortenedTo records a string limit already applied to doc.
+func dropItems(doc map[string]any, budget, shortenedTo int) ([]byte, *Truncation, error) {
+	field, items := largestArray(doc)
+	trial := func(keep int) (map[string]any, *Truncation) {
+		trimmed := make(map[string]any, len(doc)+1)
+		for key, value := range doc {
+			trimmed[key] = value
+		}
+		cut := &Truncation{Field: field, Kept: keep, Omitted: len(items) - keep, StringsShortenedTo: shortenedTo}
+		if field != "" {
+			trimmed[field] = items[:keep]
+		}
+		trimmed["truncated"] = cut
+		return trimmed, cut
+	}
+
+	lo, hi := 0, len(items)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		candidate, _ := trial(mid)
+		fits, err := fitsBudget(candidate, budget)
+		if err != nil {
+			return nil, nil, err
+		}
+		if fits {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	best, cut := trial(lo)
+	out, err := json.Marshal(best)
+	if err != nil {
+		return nil, nil, err
+	}
+	if Max(string(out)) > budget {
+		cut.OverBudget = true
+		out, err = json.Marshal(best)
+	}
+	return out, cut, err
+}
+
+func fitsBudget(doc any, budget int) (bool, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return false, err
+	}
+	return Max(string(data)) <= budget, nil
+}
+
+func largestArray(doc map[string]any) (string, []any) {
+	var (
+		field string
+		items []any
+		size  int
+	)
+	for key, value := range doc {
+		arr, ok := value.([]any)
+		if !ok || len(arr) == 0 {
+			continue
+		}
+		encoded, _ := json.Marshal(arr)
+		if len(encoded) > size || len(encoded) == size && key < field {
+			field, items, size = key, arr, len(encoded)
+		}
+	}
+	return field, items
+}
+
+func longestString(value any) int {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v)
+	case map[string]any:
+		longest := 0
+		for _, item := range v {
+			longest = max(longest, longestString(item))
+		}
+		return longest
+	case []any:
+		longest := 0
+		for _, item := range v {
+			longest = max(longest, longestString(item))
+		}
+		return longest
+	}
+	return 0
+}
+
+// shortenStrings returns a copy of value with strings cut to at most limit characters.
+func shortenStrings(value any, limit int) any {
+	switch v := value.(type) {
+	case string:
+		if utf8.RuneCountInString(v) <= limit {
+			return v
+		}
+		return string([]rune(v)[:limit-1]) + "…"
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = shortenStrings(item, limit)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = shortenStrings(item, limit)
+		}
+		return out
+	}
+	return value
+}
+
+// FitLines keeps as many leading lines as fit in budget, ending with a note on how many
+// were dropped. Lines that already fit are returned unchanged with a nil Truncation.
+func FitLines(lines []string, budget int) ([]string, *Truncation) {
+	total := 0
+	for i, line := range lines {
+		total += Max(line) + 1
+		if total > budget {
+			cut := &Truncation{Kept: i, Omitted: len(lines) - i}
+			return append(lines[:i:i], fmt.Sprintf("... %d more lines omitted to fit %d tokens", cut.Omitted, budget)), cut
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,70 @@
+// Package tokens gives rough LLM token counts for command output without shipping a
+// tokenizer. Text is split the way BPE pre-tokenizers split it (words, digit groups,
+// punctuation runs, whitespace), and each piece is costed with per-family averages.
+// Expect the numbers to be in the right range, not exact.
+package tokens
+
+import (
+	"math"
+	"regexp"
+)
+
+// Family describes how one tokenizer family tends to split text.
+type Family struct {
+	Name string
+	// WordBytes is the average number of bytes of a word covered by one token.
+	WordBytes float64
+	// PunctBytes is the average number of bytes of a punctuation run covered by one token.
+	PunctBytes float64
+}
+
+// Families are the tokenizer families reported by Estimate.
+var Families = []Family{
+	{Name: "gpt", WordBytes: 6, PunctBytes: 2},
+	{Name: "claude", WordBytes: 4.5, PunctBytes: 1.5},
+	{Name: "llama", WordBytes: 5.5, PunctBytes: 2},
+}
+
+var pieces = regexp.MustCompile(`'(?:s|t|re|ve|m|ll|d)| ?\p{L}+| ?\p{N}{1,3}| ?[^\s\p{L}\p{N}]+|\s+`)
+
+// Count estimates the tokens text costs in family.
+func Count(text string, family Family) int {
+	total := 0.0
+	for _, piece := range pieces.FindAllString(text, -1) {
+		switch c := piece[len(piece)-1]; {
+		case c == ' ' || c == '\n' || c == '\t' || c == '\r':
+			total++
+		case isPunct(piece):
+			total += math.Ceil(float64(len(piece)) / family.PunctBytes)
+		default:
+			total += math.Ceil(float64(len(piece)) / family.WordBytes)
+		}
+	}
+	return int(total)
+}
+
+// Estimate returns the estimated token count of text for every family.
+func Estimate(text string) map[string]int {
+	counts := make(map[string]int, len(Families))
+	for _, family := range Families {
+		counts[family.Name] = Count(text, family)
+	}
+	return counts
+}
+
+// Max returns the highest estimate across families, the safe figure for a budget.
+func Max(text string) int {
+	highest := 0
+	for _, n := range Estimate(text) {
+		if n > highest {
+			highest = n
+		}
+	}
+	return highest
+}
+
+var punctOnly = regexp.MustCompile(`^ ?[^\s\p{L}\p{N}]+$`)
+
+func isPunct(piece string) bool {
+	return punctOnly.MatchString(piece)
+}
@@ -0,0 +1,86 @@
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	gpt := Families[0]
+	if got := Count("hello world", gpt); got != 2 {
+		t.Fatalf("Count(hello world) = %d, want 2", got)
+	}
+	if got := Count("", gpt); got != 0 {
+		t.Fatalf("Count(empty) = %d, want 0", got)
+	}
+	// Digits split into groups of three, like BPE pre-tokenizers.
+	if got := Count("1705312365", gpt); got != 4 {
+		t.Fatalf("Count(1705312365) = %d, want 4", got)
+	}
+	prose := strings.Repeat("The deploy finished without errors. ", 20)
+	if n := Max(prose); n < len(prose)/6 || n > len(prose)/2 {
+		t.Fatalf("Max(prose) = %d for %d bytes, outside the plausible range", n, len(prose))
+	}
+}
+
+func TestFitJSONDropsTrailingItems(t *testing.T) {
+	var messages []map[string]string
+	for i := 0; i < 50; i++ {
+		messages = append(messages, map[string]string{"ts": fmt.Sprintf("1705312%03d.000100", i), "text": "Deploy finished for service number " + fmt.Sprint(i)})
+	}
+	data, _ := json.Marshal(map[string]any{"ok": true, "channel": "#deploys", "messages": messages})
+
+	out, cut, err := FitJSON(data, 200)
+	if err != nil {
+		t.Fatalf("FitJSON() error = %v", err)
+	}
+	if Max(string(out)) > 200 {
+		t.Fatalf("output still over budget: %d tokens", Max(string(out)))
+	}
+	var got struct {
+		Channel   string            `json:"channel"`
+		Messages  []json.RawMessage `json:"messages"`
+		Truncated Truncation        `json:"truncated"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if cut == nil || got.Truncated.Field != "messages" || got.Truncated.Kept != len(got.Messages) || got.Truncated.Kept+got.Truncated.Omitted != 50 || got.Truncated.Kept == 0 {
+		t.Fatalf("truncation = %+v, kept %d messages", got.Truncated, len(got.Messages))
+	}
+	if got.Channel != "#deploys" {
+		t.Fatalf("other fields must be kept, channel = %q", got.Channel)
+	}
+
+	same, cut, err := FitJSON(data, 100000)
+	if err != nil || cut != nil || string(same) != string(data) {
+		t.Fatalf("output under budget must be unchanged (cut=%v, err=%v)", cut, err)
+	}
+}
+
+func TestFitJSONShortensStrings(t *testing.T) {
+	data, _ := json.Marshal(map[string]any{"ok": true, "text": strings.Repeat("word ", 2000)})
+	out, cut, err := FitJSON(data, 300)
+	if err != nil {
+		t.Fatalf("FitJSON() error = %v", err)
+	}
+	if cut.StringsShortenedTo == 0 || cut.OverBudget || Max(string(out)) > 300 {
+		t.Fatalf("truncation = %+v, %d tokens", cut, Max(string(out)))
+	}
+}
+
+func TestFitLines(t *testing.T) {
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("@alice (U%03d) - Alice Smith", i)
+	}
+	out, cut := FitLines(lines, 100)
+	if cut == nil || len(out) != cut.Kept+1 || cut.Kept+cut.Omitted != 100 {
+		t.Fatalf("FitLines() kept %d lines, cut = %+v", len(out), cut)
+	}
+	if !strings.Contains(out[len(out)-1], "more lines omitted") {
+		t.Fatalf("last line = %q", out[len(out)-1])
+	}
+}
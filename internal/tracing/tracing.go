@@ -0,0 +1,303 @@
+// Package tracing provides lightweight OpenTelemetry-compatible tracing for slk.
+//
+// Tracing is opt-in: it is enabled only when OTEL_EXPORTER_OTLP_ENDPOINT (or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) is set. Spans are buffered in memory and
+// exported with the OTLP/HTTP JSON protocol so the CLI does not need to pull in the
+// full OpenTelemetry SDK. When tracing is disabled every helper is a cheap no-op.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpanKind mirrors the OTLP span kind enumeration.
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindClient   SpanKind = 3
+)
+
+// StatusCode mirrors the OTLP span status enumeration.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// DefaultServiceName is reported as service.name unless OTEL_SERVICE_NAME is set.
+const DefaultServiceName = "slk"
+
+// batchSize is the number of finished spans buffered before an export is triggered.
+const batchSize = 256
+
+// Exporter ships finished spans to a tracing backend.
+type Exporter interface {
+	Export(ctx context.Context, spans []*Span) error
+}
+
+// Tracer records spans and hands them to an Exporter in batches.
+type Tracer struct {
+	exporter Exporter
+
+	mu      sync.Mutex
+	pending []*Span
+	wg      sync.WaitGroup
+}
+
+// Span is a single timed operation. A nil *Span is valid and ignores all calls.
+type Span struct {
+	tracer *Tracer
+
+	mu           sync.Mutex
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Kind         SpanKind
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   map[string]interface{}
+	Status       StatusCode
+	StatusMsg    string
+	ended        bool
+}
+
+type spanContextKey struct{}
+
+var (
+	globalMu sync.RWMutex
+	global   *Tracer
+)
+
+// NewTracer creates a tracer that exports through exporter.
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// Setup enables tracing when an OTLP endpoint is configured in the environment.
+// It returns a shutdown function that flushes buffered spans; the function is
+// always safe to call, even when tracing stays disabled.
+func Setup() func(context.Context) error {
+	endpoint := tracesEndpoint()
+	if endpoint == "" {
+		return func(context.Context) error { return nil }
+	}
+	exporter := NewOTLPExporter(endpoint, serviceName(), parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")))
+	tracer := NewTracer(exporter)
+	SetGlobal(tracer)
+	return func(ctx context.Context) error {
+		SetGlobal(nil)
+		return tracer.Shutdown(ctx)
+	}
+}
+
+// SetGlobal installs tracer as the process-wide tracer. Passing nil disables tracing.
+func SetGlobal(tracer *Tracer) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	global = tracer
+}
+
+// Enabled reports whether a global tracer is installed.
+func Enabled() bool {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return global != nil
+}
+
+// Start begins a span as a child of any span already stored in ctx.
+// When tracing is disabled it returns ctx unchanged and a nil span.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	globalMu.RLock()
+	tracer := global
+	globalMu.RUnlock()
+	if tracer == nil {
+		return ctx, nil
+	}
+	return tracer.Start(ctx, name, kind)
+}
+
+// Start begins a span on this tracer.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	span := &Span{
+		tracer:     t,
+		SpanID:     randomHex(8),
+		Name:       name,
+		Kind:       kind,
+		StartTime:  time.Now(),
+		Attributes: map[string]interface{}{},
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.TraceID = parent.TraceID
+		span.ParentSpanID = parent.SpanID
+	} else {
+		span.TraceID = randomHex(16)
+	}
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// SpanFromContext returns the active span stored in ctx, if any.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// Shutdown waits for in-flight exports and flushes any remaining spans.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.wg.Wait()
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = nil
+	t.mu.Unlock()
+	if len(batch) == 0 || t.exporter == nil {
+		return nil
+	}
+	return t.exporter.Export(ctx, batch)
+}
+
+func (t *Tracer) finish(span *Span) {
+	t.mu.Lock()
+	t.pending = append(t.pending, span)
+	if len(t.pending) < batchSize {
+		t.mu.Unlock()
+		return
+	}
+	batch := t.pending
+	t.pending = nil
+	t.mu.Unlock()
+
+	if t.exporter == nil {
+		return
+	}
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = t.exporter.Export(ctx, batch)
+	}()
+}
+
+// SetName renames the span, e.g. once the executed command is known.
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Name = name
+}
+
+// SetAttribute records a string, bool, integer, or float attribute on the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil || key == "" || value == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Attributes[key] = value
+}
+
+// SetStatus sets the span status. Error status messages are kept for export.
+func (s *Span) SetStatus(code StatusCode, msg string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Status = code
+	s.StatusMsg = msg
+}
+
+// RecordError marks the span as failed when err is non-nil.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.SetStatus(StatusError, err.Error())
+}
+
+// End finishes the span. Calling End more than once has no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.EndTime = time.Now()
+	s.mu.Unlock()
+	s.tracer.finish(s)
+}
+
+func tracesEndpoint() string {
+	if endpoint := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")); endpoint != "" {
+		return endpoint
+	}
+	endpoint := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"))
+	if endpoint == "" {
+		return ""
+	}
+	return strings.TrimRight(endpoint, "/") + "/v1/traces"
+}
+
+func serviceName() string {
+	if name := strings.TrimSpace(os.Getenv("OTEL_SERVICE_NAME")); name != "" {
+		return name
+	}
+	return DefaultServiceName
+}
+
+// parseHeaders parses the OTEL_EXPORTER_OTLP_HEADERS format: key1=value1,key2=value2.
+func parseHeaders(raw string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return strings.Repeat("0", n*2)
+	}
+	return hex.EncodeToString(buf)
+}
+
+type retryAttemptKey struct{}
+
+// WithRetryAttempt marks ctx as carrying the given retry attempt (0 = first try) so
+// API spans started under it record a slack.retry_attempt attribute.
+func WithRetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, attempt)
+}
+
+// RetryAttempt returns the retry attempt recorded in ctx, or 0.
+func RetryAttempt(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+	return attempt
+}
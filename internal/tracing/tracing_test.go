@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type recordingExporter struct {
+	spans []*Span
+}
+
+func (e *recordingExporter) Export(_ context.Context, spans []*Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestStartDisabledIsNoop(t *testing.T) {
+	SetGlobal(nil)
+	ctx, span := Start(context.Background(), "noop", KindInternal)
+	if span != nil {
+		t.Fatalf("expected nil span when tracing is disabled")
+	}
+	if SpanFromContext(ctx) != nil {
+		t.Fatalf("expected no span in context")
+	}
+	// Nil spans must tolerate every call.
+	span.SetAttribute("k", "v")
+	span.RecordError(errors.New("boom"))
+	span.End()
+}
+
+func TestChildSpansShareTrace(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter)
+
+	ctx, parent := tracer.Start(context.Background(), "parent", KindInternal)
+	_, child := tracer.Start(WithRetryAttempt(ctx, 2), "child", KindClient)
+	child.RecordError(errors.New("rate_limited"))
+	child.End()
+	child.End()
+	parent.End()
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if len(exporter.spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(exporter.spans))
+	}
+	if child.TraceID != parent.TraceID {
+		t.Errorf("child trace %q != parent trace %q", child.TraceID, parent.TraceID)
+	}
+	if child.ParentSpanID != parent.SpanID {
+		t.Errorf("child parent = %q, want %q", child.ParentSpanID, parent.SpanID)
+	}
+	if child.Status != StatusError || child.StatusMsg != "rate_limited" {
+		t.Errorf("child status = %v %q", child.Status, child.StatusMsg)
+	}
+}
+
+func TestRetryAttempt(t *testing.T) {
+	if got := RetryAttempt(context.Background()); got != 0 {
+		t.Errorf("RetryAttempt() = %d, want 0", got)
+	}
+	if got := RetryAttempt(WithRetryAttempt(context.Background(), 3)); got != 3 {
+		t.Errorf("RetryAttempt() = %d, want 3", got)
+	}
+}
+
+func TestOTLPExporterPayload(t *testing.T) {
+	var received otlpRequest
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL, "slk-test", map[string]string{"Authorization": "Bearer x"})
+	tracer := NewTracer(exporter)
+	_, span := tracer.Start(context.Background(), "chat.postMessage", KindClient)
+	span.SetAttribute("slack.method", "chat.postMessage")
+	span.SetAttribute("slack.retry_attempt", 1)
+	span.End()
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if auth != "Bearer x" {
+		t.Errorf("Authorization header = %q", auth)
+	}
+	if len(received.ResourceSpans) != 1 || len(received.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected payload shape: %+v", received)
+	}
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 || spans[0].Name != "chat.postMessage" || spans[0].Kind != KindClient {
+		t.Fatalf("unexpected spans: %+v", spans)
+	}
+	attrs := map[string]otlpValue{}
+	for _, attr := range spans[0].Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	if v := attrs["slack.method"].StringValue; v == nil || *v != "chat.postMessage" {
+		t.Errorf("slack.method attribute = %v", v)
+	}
+	if v := attrs["slack.retry_attempt"].IntValue; v == nil || *v != "1" {
+		t.Errorf("slack.retry_attempt attribute = %v", v)
+	}
+	service := received.ResourceSpans[0].Resource.Attributes[0]
+	if service.Key != "service.name" || *service.Value.StringValue != "slk-test" {
+		t.Errorf("service attribute = %+v", service)
+	}
+}
+
+func TestTracesEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	if got := tracesEndpoint(); got != "" {
+		t.Errorf("tracesEndpoint() = %q, want empty", got)
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318/")
+	if got := tracesEndpoint(); got != "http://collector:4318/v1/traces" {
+		t.Errorf("tracesEndpoint() = %q", got)
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://traces:4318/custom")
+	if got := tracesEndpoint(); got != "http://traces:4318/custom" {
+		t.Errorf("tracesEndpoint() = %q", got)
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	got := parseHeaders("api-key=secret, x-team = core,invalid")
+	if len(got) != 2 || got["api-key"] != "secret" || got["x-team"] != "core" {
+		t.Errorf("parseHeaders() = %v", got)
+	}
+}
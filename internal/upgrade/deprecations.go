@@ -0,0 +1,164 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DefaultManifestURL is the deprecation manifest on the main branch, so announcements
+// reach older binaries before the release that removes anything ships.
+const DefaultManifestURL = "https://raw.githubusercontent.com/kehao95/slack-agent-cli/main/deprecations.json"
+
+// Deprecation kinds.
+const (
+	KindCommand = "command"
+	KindFlag    = "flag"
+	KindConfig  = "config"
+)
+
+// Warning codes reported by version checks.
+const (
+	WarnUpdateAvailable    = "update_available"
+	WarnUnsupportedVersion = "unsupported_version"
+	WarnDeprecated         = "deprecated"
+)
+
+// Manifest is the machine-readable list of upcoming breaking changes
+// (deprecations.json at the repository root).
+type Manifest struct {
+	// MinimumVersion is the oldest release still supported; older binaries get an
+	// unsupported_version warning.
+	MinimumVersion string        `json:"minimum_version,omitempty"`
+	Deprecations   []Deprecation `json:"deprecations"`
+}
+
+// Deprecation announces that a command, flag, or config key will be removed.
+// Target is a command path ("messages next"), a flag with its command path
+// ("messages list --unread", or "--human" for a global flag), or a dotted config
+// key ("channels.*.require_mention").
+type Deprecation struct {
+	Kind         string `json:"kind"`
+	Target       string `json:"target"`
+	DeprecatedIn string `json:"deprecated_in"`
+	RemovedIn    string `json:"removed_in,omitempty"`
+	Replacement  string `json:"replacement,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
+
+// Warning is one structured finding of a version check.
+type Warning struct {
+	Code         string `json:"code"`
+	Kind         string `json:"kind,omitempty"`
+	Target       string `json:"target,omitempty"`
+	DeprecatedIn string `json:"deprecated_in,omitempty"`
+	RemovedIn    string `json:"removed_in,omitempty"`
+	Replacement  string `json:"replacement,omitempty"`
+	Message      string `json:"message"`
+}
+
+// ParseManifest decodes and validates a deprecation manifest.
+func ParseManifest(data []byte) (Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("decode deprecation manifest: %w", err)
+	}
+	if m.MinimumVersion != "" && !IsRelease(m.MinimumVersion) {
+		return Manifest{}, fmt.Errorf("deprecation manifest: invalid minimum_version %q", m.MinimumVersion)
+	}
+	for i, d := range m.Deprecations {
+		switch d.Kind {
+		case KindCommand, KindFlag, KindConfig:
+		default:
+			return Manifest{}, fmt.Errorf("deprecation manifest: entry %d: unknown kind %q", i, d.Kind)
+		}
+		if d.Target == "" {
+			return Manifest{}, fmt.Errorf("deprecation manifest: entry %d: missing target", i)
+		}
+		if !IsRelease(d.DeprecatedIn) {
+			return Manifest{}, fmt.Errorf("deprecation manifest: entry %d: invalid deprecated_in %q", i, d.DeprecatedIn)
+		}
+		if d.RemovedIn != "" && !IsRelease(d.RemovedIn) {
+			return Manifest{}, fmt.Errorf("deprecation manifest: entry %d: invalid removed_in %q", i, d.RemovedIn)
+		}
+	}
+	return m, nil
+}
+
+// Manifest fetches the deprecation manifest from ManifestURL, or DefaultManifestURL
+// when unset.
+func (c *Client) Manifest(ctx context.Context) (Manifest, error) {
+	url := c.ManifestURL
+	if url == "" {
+		url = DefaultManifestURL
+	}
+	data, err := c.get(ctx, url, "application/json")
+	if err != nil {
+		return Manifest{}, err
+	}
+	return ParseManifest(data)
+}
+
+// Warnings returns what the manifest means for a binary at version current: an
+// unsupported_version warning when current is older than MinimumVersion, and a
+// deprecated warning for every entry not yet removed in current. Development builds
+// see every deprecation but are never reported as unsupported.
+func (m Manifest) Warnings(current string) []Warning {
+	release := IsRelease(current)
+	var warnings []Warning
+	if release && m.MinimumVersion != "" && CompareVersions(current, m.MinimumVersion) < 0 {
+		warnings = append(warnings, Warning{
+			Code:    WarnUnsupportedVersion,
+			Message: fmt.Sprintf("slk %s is older than the minimum supported version %s", current, m.MinimumVersion),
+		})
+	}
+	for _, d := range m.Deprecations {
+		if release && d.RemovedIn != "" && CompareVersions(current, d.RemovedIn) >= 0 {
+			continue
+		}
+		warnings = append(warnings, d.Warning())
+	}
+	SortWarnings(warnings)
+	return warnings
+}
+
+// Warning describes the deprecation as a warning.
+func (d Deprecation) Warning() Warning {
+	msg := fmt.Sprintf("%s %s is deprecated since %s", d.Kind, d.Target, d.DeprecatedIn)
+	if d.RemovedIn != "" {
+		msg += fmt.Sprintf(" and will be removed in %s", d.RemovedIn)
+	}
+	if d.Replacement != "" {
+		msg += "; use " + d.Replacement + " instead"
+	}
+	if d.Note != "" {
+		msg += ". " + d.Note
+	}
+	return Warning{
+		Code:         WarnDeprecated,
+		Kind:         d.Kind,
+		Target:       d.Target,
+		DeprecatedIn: d.DeprecatedIn,
+		RemovedIn:    d.RemovedIn,
+		Replacement:  d.Replacement,
+		Message:      msg,
+	}
+}
+
+var warningRank = map[string]int{WarnUpdateAvailable: 0, WarnUnsupportedVersion: 1, WarnDeprecated: 2}
+
+// SortWarnings orders warnings by code (update_available, unsupported_version,
+// deprecated), then kind and target, so output is stable.
+func SortWarnings(warnings []Warning) {
+	sort.SliceStable(warnings, func(i, j int) bool {
+		a, b := warnings[i], warnings[j]
+		if a.Code != b.Code {
+			return warningRank[a.Code] < warningRank[b.Code]
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Target < b.Target
+	})
+}
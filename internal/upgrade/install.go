@@ -0,0 +1,156 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned when a download does not match checksums.txt.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// maxBinarySize bounds how much of an archive entry is extracted, so a corrupt or
+// hostile archive cannot fill the disk.
+const maxBinarySize = 256 << 20
+
+// Verify checks data against the SHA-256 listed for name in a GoReleaser
+// checksums.txt ("<hex>  <name>" per line) and returns the digest.
+func Verify(checksums []byte, name string, data []byte) (string, error) {
+	want := ""
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			want = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if want == "" {
+		return "", fmt.Errorf("%s is not listed in %s", name, ChecksumsAsset)
+	}
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if got != want {
+		return "", fmt.Errorf("%w for %s: got %s, want %s", ErrChecksumMismatch, name, got, want)
+	}
+	return got, nil
+}
+
+// Extract returns the contents of the file called binary from a .tar.gz or .zip
+// archive. The file may sit in a subdirectory of the archive.
+func Extract(archiveName string, archive []byte, binary string) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		return extractZip(archive, binary)
+	}
+	return extractTarGz(archive, binary)
+}
+
+func extractTarGz(archive []byte, binary string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in archive", binary)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == binary {
+			return readLimited(tr)
+		}
+	}
+}
+
+func extractZip(archive []byte, binary string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || path.Base(f.Name) != binary {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		defer rc.Close()
+		return readLimited(rc)
+	}
+	return nil, fmt.Errorf("%s not found in archive", binary)
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBinarySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	if len(data) > maxBinarySize {
+		return nil, fmt.Errorf("binary in archive is larger than %d MiB", maxBinarySize>>20)
+	}
+	return data, nil
+}
+
+// Replace atomically swaps the executable at exePath for binary, keeping its file
+// mode. The new file is written next to the old one and renamed over it, so a
+// failure part way leaves the old binary in place. Windows cannot overwrite a
+// running executable, so there the old binary is first moved aside to
+// <name>.old, which the next upgrade removes.
+func Replace(exePath string, binary []byte) error {
+	info, err := os.Stat(exePath)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(exePath)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(exePath)+".new-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	old := exePath + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(tmpName, exePath); err == nil {
+		return nil
+	}
+	// Renaming over a running executable fails on Windows; move it aside first.
+	if err := os.Rename(exePath, old); err != nil {
+		return fmt.Errorf("move %s aside: %w", exePath, err)
+	}
+	if err := os.Rename(tmpName, exePath); err != nil {
+		_ = os.Rename(old, exePath)
+		return fmt.Errorf("install %s: %w", exePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+// Package upgrade finds slk releases on GitHub and replaces the running binary with a
+// verified download.
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL lists the project's releases, newest first.
+const DefaultAPIURL = "https://api.github.com/repos/kehao95/slack-agent-cli/releases"
+
+// Release channels.
+const (
+	ChannelStable     = "stable"
+	ChannelPrerelease = "prerelease"
+)
+
+// ChecksumsAsset is the file GoReleaser publishes with the SHA-256 of every archive.
+const ChecksumsAsset = "checksums.txt"
+
+// Release is a published GitHub release.
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	Name       string  `json:"name"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Published  string  `json:"published_at"`
+	URL        string  `json:"html_url"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Version returns the release tag without its leading "v".
+func (r Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset returns the attached file with the given name.
+func (r Release) Asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Client talks to the GitHub releases API. The zero value uses DefaultAPIURL,
+// DefaultManifestURL, and a 60 second timeout. NewClient also sends $GITHUB_TOKEN
+// when set, to raise the anonymous rate limit.
+type Client struct {
+	APIURL      string
+	ManifestURL string
+	Token       string
+	HTTP        *http.Client
+}
+
+// NewClient returns a client for the project's releases.
+func NewClient() *Client {
+	return &Client{Token: strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))}
+}
+
+// Latest returns the newest release on the channel: the newest non-prerelease for
+// ChannelStable, the newest release of any kind for ChannelPrerelease.
+func (c *Client) Latest(ctx context.Context, channel string) (Release, error) {
+	if channel != ChannelStable && channel != ChannelPrerelease {
+		return Release{}, fmt.Errorf("unknown channel %q (use %s or %s)", channel, ChannelStable, ChannelPrerelease)
+	}
+	body, err := c.get(ctx, c.apiURL(), "application/vnd.github+json")
+	if err != nil {
+		return Release{}, err
+	}
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return Release{}, fmt.Errorf("decode releases: %w", err)
+	}
+	var latest *Release
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || (r.Prerelease && channel == ChannelStable) {
+			continue
+		}
+		if latest == nil || CompareVersions(r.Version(), latest.Version()) > 0 {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return Release{}, fmt.Errorf("no %s release found", channel)
+	}
+	return *latest, nil
+}
+
+// Download fetches a release asset.
+func (c *Client) Download(ctx context.Context, asset Asset) ([]byte, error) {
+	return c.get(ctx, asset.URL, "application/octet-stream")
+}
+
+func (c *Client) apiURL() string {
+	if c.APIURL != "" {
+		return c.APIURL
+	}
+	return DefaultAPIURL
+}
+
+func (c *Client) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	client := c.HTTP
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, fmt.Errorf("GET %s returned %s: %s", url, resp.Status, bytes.TrimSpace(snippet))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// ArchiveName returns the name GoReleaser gives the archive for a version and
+// platform, e.g. slk_1.4.0_Linux_x86_64.tar.gz or slk_1.4.0_Windows_x86_64.zip.
+func ArchiveName(version, goos, goarch string) string {
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	}
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("slk_%s_%s_%s%s", strings.TrimPrefix(version, "v"), strings.ToUpper(goos[:1])+goos[1:], arch, ext)
+}
+
+// BinaryName returns the name of the slk executable inside an archive.
+func BinaryName(goos string) string {
+	if goos == "windows" {
+		return "slk.exe"
+	}
+	return "slk"
+}
+
+// CurrentArchiveName returns ArchiveName for the running platform.
+func CurrentArchiveName(version string) string {
+	return ArchiveName(version, runtime.GOOS, runtime.GOARCH)
+}
+
+// CompareVersions orders two semantic versions (with or without a leading "v"),
+// returning -1, 0, or 1. A release sorts after its prereleases, and prerelease
+// identifiers compare numerically when both are numbers, e.g. rc.2 < rc.10.
+func CompareVersions(a, b string) int {
+	coreA, preA, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	coreB, preB, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+	if c := compareIdentifiers(strings.Split(coreA, "."), strings.Split(coreB, ".")); c != 0 {
+		return c
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return compareIdentifiers(strings.Split(preA, "."), strings.Split(preB, "."))
+}
+
+func compareIdentifiers(a, b []string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if i >= len(a) {
+			return -1
+		}
+		if i >= len(b) {
+			return 1
+		}
+		na, errA := strconv.Atoi(a[i])
+		nb, errB := strconv.Atoi(b[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				return sign(na - nb)
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsRelease reports whether version looks like a tagged release rather than a
+// development build ("dev", or a pseudo-version from go install).
+func IsRelease(version string) bool {
+	core, pre, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	for _, p := range parts {
+		if _, err := strconv.Atoi(p); err != nil {
+			return false
+		}
+	}
+	return !pseudoVersionSuffix.MatchString(pre)
+}
+
+// pseudoVersionSuffix matches the -<timestamp>-<commit> ending of a Go pseudo-version,
+// e.g. 0.0.0-20240101000000-abcdef123456.
+var pseudoVersionSuffix = regexp.MustCompile(`(^|\.)\d{14}-[0-9a-f]{12}(\+.*)?$`)
@@ -0,0 +1,68 @@
+package upgrade
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// SignatureAsset is the detached Ed25519 signature of ChecksumsAsset that releases
+// publish next to it.
+const SignatureAsset = ChecksumsAsset + ".sig"
+
+// SigningKey is the release signing public key, base64 of the raw 32-byte Ed25519 key
+// or of its DER (SubjectPublicKeyInfo) encoding. Release builds set it with
+// -ldflags "-X github.com/kehao95/slack-agent-cli/internal/upgrade.SigningKey=...".
+var SigningKey = ""
+
+// ErrNoSigningKey is returned by VerifySignature for builds without a SigningKey.
+var ErrNoSigningKey = errors.New("built without a release signing key")
+
+// ErrBadSignature is returned when checksums.txt does not match its signature.
+var ErrBadSignature = errors.New("signature verification failed")
+
+// VerifySignature checks that sig is a signature of checksums by key (in SigningKey's
+// format). sig is the raw 64-byte signature, as openssl pkeyutl -sign -rawin writes it,
+// or its base64 encoding.
+func VerifySignature(checksums, sig []byte, key string) error {
+	if key == "" {
+		return ErrNoSigningKey
+	}
+	pub, err := parseSigningKey(key)
+	if err != nil {
+		return err
+	}
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sig)))
+		if err != nil || len(decoded) != ed25519.SignatureSize {
+			return fmt.Errorf("%w: %s is not an Ed25519 signature", ErrBadSignature, SignatureAsset)
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(pub, checksums, sig) {
+		return fmt.Errorf("%w: %s was not signed by the release key", ErrBadSignature, ChecksumsAsset)
+	}
+	return nil
+}
+
+func parseSigningKey(key string) (ed25519.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release signing key: %w", err)
+	}
+	if len(der) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(der), nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release signing key: %w", err)
+	}
+	pub, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid release signing key: not an Ed25519 key")
+	}
+	return pub, nil
+}
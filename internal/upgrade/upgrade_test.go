@@ -0,0 +1,225 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "v1.2.3", 0},
+		{"1.10.0", "1.9.9", 1},
+		{"1.2.3", "1.2.3-rc.1", 1},
+		{"1.2.3-rc.2", "1.2.3-rc.10", -1},
+		{"1.2.3-beta", "1.2.3-alpha", 1},
+		{"0.9.0", "1.0.0-rc.1", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIsRelease(t *testing.T) {
+	for version, want := range map[string]bool{
+		"1.4.0":                                true,
+		"v1.4.0-rc.1":                          true,
+		"dev":                                  false,
+		"1.4":                                  false,
+		"v0.0.0-20240101000000-abcdef123456":   false,
+		"v1.4.1-0.20240101000000-abcdef123456": false,
+	} {
+		if got := IsRelease(version); got != want {
+			t.Errorf("IsRelease(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	if got := ArchiveName("v1.4.0", "linux", "amd64"); got != "slk_1.4.0_Linux_x86_64.tar.gz" {
+		t.Fatalf("linux archive = %q", got)
+	}
+	if got := ArchiveName("1.4.0", "darwin", "arm64"); got != "slk_1.4.0_Darwin_arm64.tar.gz" {
+		t.Fatalf("darwin archive = %q", got)
+	}
+	if got := ArchiveName("1.4.0", "windows", "amd64"); got != "slk_1.4.0_Windows_x86_64.zip" {
+		t.Fatalf("windows archive = %q", got)
+	}
+}
+
+func TestLatestByChannel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"tag_name": "v1.5.0", "draft": true},
+			{"tag_name": "v1.5.0-rc.1", "prerelease": true},
+			{"tag_name": "v1.4.0"},
+			{"tag_name": "v1.3.2"}
+		]`)
+	}))
+	defer srv.Close()
+	client := &Client{APIURL: srv.URL}
+
+	stable, err := client.Latest(context.Background(), ChannelStable)
+	if err != nil || stable.Tag != "v1.4.0" {
+		t.Fatalf("stable = %q, %v", stable.Tag, err)
+	}
+	pre, err := client.Latest(context.Background(), ChannelPrerelease)
+	if err != nil || pre.Tag != "v1.5.0-rc.1" {
+		t.Fatalf("prerelease = %q, %v", pre.Tag, err)
+	}
+	if _, err := client.Latest(context.Background(), "nightly"); err == nil {
+		t.Fatal("expected unknown channel error")
+	}
+}
+
+func TestVerifyExtractReplace(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho new\n")
+	archive := tarGz(t, map[string][]byte{"README.md": []byte("docs"), "slk": binary})
+	sum := sha256.Sum256(archive)
+	name := "slk_1.4.0_Linux_x86_64.tar.gz"
+	checksums := []byte(fmt.Sprintf("0000  slk_1.4.0_Darwin_arm64.tar.gz\n%s  %s\n", hex.EncodeToString(sum[:]), name))
+
+	if _, err := Verify(checksums, name, archive); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if _, err := Verify(checksums, name, append(archive, 0)); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("tampered archive err = %v, want ErrChecksumMismatch", err)
+	}
+	if _, err := Verify(checksums, "slk_1.4.0_Windows_x86_64.zip", archive); err == nil {
+		t.Fatal("expected error for an archive missing from checksums.txt")
+	}
+
+	got, err := Extract(name, archive, "slk")
+	if err != nil || !bytes.Equal(got, binary) {
+		t.Fatalf("Extract = %q, %v", got, err)
+	}
+
+	exe := filepath.Join(t.TempDir(), "slk")
+	if err := os.WriteFile(exe, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replace(exe, got); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	data, err := os.ReadFile(exe)
+	if err != nil || !bytes.Equal(data, binary) {
+		t.Fatalf("installed binary = %q, %v", data, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	if len(entries) != 1 {
+		t.Fatalf("leftover files after Replace: %v", entries)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksums := []byte("9f2c  slk_1.4.0_Linux_x86_64.tar.gz\n")
+	sig := ed25519.Sign(priv, checksums)
+
+	for _, key := range []string{base64.StdEncoding.EncodeToString(pub), base64.StdEncoding.EncodeToString(der)} {
+		if err := VerifySignature(checksums, sig, key); err != nil {
+			t.Errorf("VerifySignature(raw signature, key %s) = %v", key, err)
+		}
+		if err := VerifySignature(checksums, []byte(base64.StdEncoding.EncodeToString(sig)+"\n"), key); err != nil {
+			t.Errorf("VerifySignature(base64 signature) = %v", err)
+		}
+	}
+	key := base64.StdEncoding.EncodeToString(pub)
+	if err := VerifySignature(append([]byte("0000  evil.tar.gz\n"), checksums...), sig, key); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("tampered checksums err = %v, want ErrBadSignature", err)
+	}
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	if err := VerifySignature(checksums, sig, base64.StdEncoding.EncodeToString(otherPub)); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("other key err = %v, want ErrBadSignature", err)
+	}
+	if err := VerifySignature(checksums, []byte("not a signature"), key); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("garbage signature err = %v, want ErrBadSignature", err)
+	}
+	if err := VerifySignature(checksums, sig, ""); !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("no key err = %v, want ErrNoSigningKey", err)
+	}
+}
+
+func tarGz(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestManifestWarnings(t *testing.T) {
+	m, err := ParseManifest([]byte(`{
+		"minimum_version": "1.2.0",
+		"deprecations": [
+			{"kind": "flag", "target": "messages list --unread", "deprecated_in": "1.3.0", "removed_in": "2.0.0", "replacement": "messages next"},
+			{"kind": "command", "target": "huddles list", "deprecated_in": "1.0.0", "removed_in": "1.1.0"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseManifest: %v", err)
+	}
+
+	warnings := m.Warnings("1.1.5")
+	if len(warnings) != 2 || warnings[0].Code != WarnUnsupportedVersion || warnings[1].Target != "messages list --unread" {
+		t.Fatalf("warnings for 1.1.5 = %+v", warnings)
+	}
+	if want := "flag messages list --unread is deprecated since 1.3.0 and will be removed in 2.0.0; use messages next instead"; warnings[1].Message != want {
+		t.Fatalf("message = %q, want %q", warnings[1].Message, want)
+	}
+	if warnings := m.Warnings("2.0.0"); len(warnings) != 0 {
+		t.Fatalf("warnings for 2.0.0 = %+v, want none", warnings)
+	}
+	if warnings := m.Warnings("dev"); len(warnings) != 2 || warnings[0].Code != WarnDeprecated {
+		t.Fatalf("warnings for dev build = %+v", warnings)
+	}
+
+	for _, bad := range []string{
+		`{"deprecations": [{"kind": "env", "target": "X", "deprecated_in": "1.0.0"}]}`,
+		`{"deprecations": [{"kind": "flag", "target": "--human", "deprecated_in": "soon"}]}`,
+		`{"minimum_version": "latest", "deprecations": []}`,
+	} {
+		if _, err := ParseManifest([]byte(bad)); err == nil {
+			t.Errorf("ParseManifest(%s) succeeded, want error", bad)
+		}
+	}
+}
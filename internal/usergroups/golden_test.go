@@ -0,0 +1,27 @@
+package usergroups
+
+import (
+	"testing"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *MembersResult
+	}{
+		{"MembersResult", &MembersResult{OK: true, ID: "S1", Handle: "oncall", Name: "On-call", Members: []Member{
+			{ID: "U1", Name: "alice", RealName: "Alice Chen", DisplayName: "Ali", Email: "alice@example.com"},
+			{ID: "U2", Name: "bob", RealName: "bob"},
+			{ID: "U3", Name: "carol", RealName: "Carol Díaz", IsDeleted: true},
+			{ID: "U4"},
+		}}},
+		{"MembersResult_empty", &MembersResult{OK: true, ID: "S2", Handle: "nobody", Members: []Member{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package usergroups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// MembersClient defines the Slack operations needed to expand a usergroup.
+type MembersClient interface {
+	GetUserGroupMembers(ctx context.Context, groupID string) ([]string, error)
+	GetUserInfo(ctx context.Context, userID string) (*slackapi.User, error)
+}
+
+// Member is one user in a usergroup. Profile fields are set only when resolved.
+type Member struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	RealName    string `json:"real_name,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	TZ          string `json:"tz,omitempty"`
+	IsDeleted   bool   `json:"is_deleted,omitempty"`
+}
+
+// MembersResult contains the expanded member list of a usergroup.
+type MembersResult struct {
+	OK      bool     `json:"ok"`
+	ID      string   `json:"id"`
+	Handle  string   `json:"handle"`
+	Name    string   `json:"name,omitempty"`
+	Members []Member `json:"members"`
+}
+
+// Members lists the users in group. With resolve, each member's profile is
+// looked up so the result carries display names and emails.
+func Members(ctx context.Context, client MembersClient, group CachedUserGroup, resolve bool) (*MembersResult, error) {
+	ids, err := client.GetUserGroupMembers(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := &MembersResult{
+		OK:      true,
+		ID:      group.ID,
+		Handle:  group.Handle,
+		Name:    group.Name,
+		Members: make([]Member, 0, len(ids)),
+	}
+	for _, id := range ids {
+		member := Member{ID: id}
+		if resolve {
+			user, err := client.GetUserInfo(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("resolve member %s: %w", id, err)
+			}
+			member = Member{
+				ID:          user.ID,
+				Name:        user.Name,
+				RealName:    user.RealName,
+				DisplayName: user.Profile.DisplayName,
+				Email:       user.Profile.Email,
+				TZ:          user.TZ,
+				IsDeleted:   user.Deleted,
+			}
+		}
+		result.Members = append(result.Members, member)
+	}
+	return result, nil
+}
+
+// StripEmails clears every member's email address.
+func (r *MembersResult) StripEmails() {
+	for i := range r.Members {
+		r.Members[i].Email = ""
+	}
+}
+
+// Lines implements the output.Printable interface for MembersResult.
+func (r *MembersResult) Lines() []string {
+	title := fmt.Sprintf("@%s (%s): %d members", r.Handle, r.ID, len(r.Members))
+	lines := []string{title, strings.Repeat("-", len(title))}
+	for _, m := range r.Members {
+		if m.Name == "" {
+			lines = append(lines, m.ID)
+			continue
+		}
+		line := fmt.Sprintf("@%s (%s)", m.Name, m.ID)
+		name := m.DisplayName
+		if name == "" {
+			name = m.RealName
+		}
+		if name != "" && name != m.Name {
+			line += " - " + name
+		}
+		if m.Email != "" {
+			line += " <" + m.Email + ">"
+		}
+		if m.IsDeleted {
+			line += " [deleted]"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
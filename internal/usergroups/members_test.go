@@ -0,0 +1,62 @@
+package usergroups
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+type mockMembersClient struct {
+	members []string
+	users   map[string]*slackapi.User
+	lookups int
+}
+
+func (m *mockMembersClient) GetUserGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	if groupID != "S1" {
+		return nil, errors.New("no_such_subteam")
+	}
+	return m.members, nil
+}
+
+func (m *mockMembersClient) GetUserInfo(ctx context.Context, userID string) (*slackapi.User, error) {
+	m.lookups++
+	u, ok := m.users[userID]
+	if !ok {
+		return nil, errors.New("user_not_found")
+	}
+	return u, nil
+}
+
+func TestMembers(t *testing.T) {
+	client := &mockMembersClient{
+		members: []string{"U1", "U2"},
+		users: map[string]*slackapi.User{
+			"U1": {ID: "U1", Name: "alice", RealName: "Alice Smith", TZ: "Europe/Berlin", Profile: slackapi.UserProfile{Email: "alice@example.com"}},
+			"U2": {ID: "U2", Name: "bob", Profile: slackapi.UserProfile{DisplayName: "Bobby"}},
+		},
+	}
+	group := CachedUserGroup{ID: "S1", Handle: "oncall", Name: "On-call"}
+
+	result, err := Members(context.Background(), client, group, false)
+	if err != nil {
+		t.Fatalf("Members() error = %v", err)
+	}
+	if len(result.Members) != 2 || result.Members[0].Name != "" || client.lookups != 0 {
+		t.Fatalf("unresolved members = %+v, lookups = %d", result.Members, client.lookups)
+	}
+
+	result, err = Members(context.Background(), client, group, true)
+	if err != nil {
+		t.Fatalf("Members(resolve) error = %v", err)
+	}
+	if got := result.Members[0]; got.Name != "alice" || got.Email != "alice@example.com" || got.TZ != "Europe/Berlin" {
+		t.Fatalf("resolved member = %+v", got)
+	}
+	lines := result.Lines()
+	if lines[0] != "@oncall (S1): 2 members" || lines[2] != "@alice (U1) - Alice Smith <alice@example.com>" || lines[3] != "@bob (U2) - Bobby" {
+		t.Fatalf("Lines() = %q", lines)
+	}
+}
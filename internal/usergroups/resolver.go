@@ -3,6 +3,7 @@ package usergroups
 
 import (
 	"context"
+	"strings"
 
 	slackapi "github.com/slack-go/slack"
 
@@ -62,6 +63,28 @@ func (r *Resolver) GetHandle(ctx context.Context, groupID string) string {
 	return groupID
 }
 
+// FindByHandle returns the usergroup with the given handle, ignoring case and a
+// leading @. A usergroup ID (S...) is also accepted.
+func (r *Resolver) FindByHandle(ctx context.Context, handle string) (CachedUserGroup, bool, error) {
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	if handle == "" {
+		return CachedUserGroup{}, false, nil
+	}
+	groups, err := r.loadOrFetchUserGroups(ctx)
+	if err != nil {
+		return CachedUserGroup{}, false, err
+	}
+	if g, ok := groups[handle]; ok {
+		return g, true, nil
+	}
+	for _, g := range groups {
+		if strings.EqualFold(g.Handle, handle) {
+			return g, true, nil
+		}
+	}
+	return CachedUserGroup{}, false, nil
+}
+
 // loadOrFetchUserGroups returns the cached usergroup map, fetching all usergroups if cache is empty.
 func (r *Resolver) loadOrFetchUserGroups(ctx context.Context) (map[string]CachedUserGroup, error) {
 	// Try to load from cache first
@@ -0,0 +1,193 @@
+package users
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/notify"
+)
+
+// Availability verdicts, from the most to the least severe.
+const (
+	VerdictDeactivated = "deactivated"
+	VerdictOutOfOffice = "out_of_office"
+	VerdictDND         = "dnd"
+	VerdictOffHours    = "off_hours"
+	VerdictAway        = "away"
+	VerdictAvailable   = "available"
+)
+
+// oooEmoji are status emoji people set when they are away for the day or longer.
+var oooEmoji = map[string]bool{
+	":palm_tree:":             true,
+	":desert_island:":         true,
+	":beach_with_umbrella:":   true,
+	":airplane:":              true,
+	":airplane_departure:":    true,
+	":face_with_thermometer:": true,
+	":thermometer:":           true,
+	":baby:":                  true,
+}
+
+// oooText matches status text that says someone is out.
+var oooText = regexp.MustCompile(`(?i)\b(ooo|out of (the )?office|vacation(ing)?|holidays?|pto|on leave|(parental|maternity|paternity|annual|sick) leave|out sick|off sick|sick day)\b`)
+
+// Signals is what Slack reports about a user that bears on their availability.
+type Signals struct {
+	User *slackapi.User
+	// Presence is nil when it was not fetched.
+	Presence *slackapi.UserPresence
+	DND      notify.Availability
+}
+
+// UserAvailability is the availability verdict for one user.
+type UserAvailability struct {
+	User    string `json:"user"`
+	UserID  string `json:"user_id"`
+	Verdict string `json:"verdict"`
+	// Available means they can be reached now; Assignable means they are not out of
+	// office or deactivated, so work given to them will be seen soon.
+	Available  bool     `json:"available"`
+	Assignable bool     `json:"assignable"`
+	Reasons    []string `json:"reasons,omitempty"`
+	// NextAvailable is when DND or working hours next allow reaching them.
+	NextAvailable *time.Time `json:"next_available,omitempty"`
+	Presence      string     `json:"presence,omitempty"`
+	StatusEmoji   string     `json:"status_emoji,omitempty"`
+	StatusText    string     `json:"status_text,omitempty"`
+	StatusExpires *time.Time `json:"status_expires,omitempty"`
+	Timezone      string     `json:"timezone"`
+	LocalTime     time.Time  `json:"local_time"`
+}
+
+// AvailabilityResult is the output of users availability.
+type AvailabilityResult struct {
+	OK    bool               `json:"ok"`
+	Users []UserAvailability `json:"users"`
+}
+
+// Location returns the user's time zone from users.info, falling back to their UTC
+// offset and then UTC.
+func Location(user *slackapi.User) *time.Location {
+	if user == nil {
+		return time.UTC
+	}
+	if user.TZ != "" {
+		if loc, err := time.LoadLocation(user.TZ); err == nil {
+			return loc
+		}
+	}
+	if user.TZOffset != 0 {
+		return time.FixedZone(user.TZLabel, user.TZOffset)
+	}
+	return time.UTC
+}
+
+// IsOutOfOffice reports whether a status emoji or text says the person is out, such
+// as :palm_tree: or "OOO until Monday".
+func IsOutOfOffice(emoji, text string) bool {
+	return oooEmoji[strings.ToLower(emoji)] || oooText.MatchString(text)
+}
+
+// Assess combines deactivation, status, DND, working hours in the user's own time
+// zone, and presence into one verdict at now. Expired statuses are ignored.
+func Assess(sig Signals, hours notify.WorkingHours, now time.Time) UserAvailability {
+	u := sig.User
+	loc := Location(u)
+	a := UserAvailability{
+		UserID:    u.ID,
+		Timezone:  loc.String(),
+		LocalTime: now.In(loc),
+	}
+	if sig.Presence != nil {
+		a.Presence = sig.Presence.Presence
+	}
+	if exp := u.Profile.StatusExpiration; exp == 0 || time.Unix(int64(exp), 0).After(now) {
+		a.StatusEmoji = u.Profile.StatusEmoji
+		a.StatusText = u.Profile.StatusText
+		if exp != 0 {
+			expires := time.Unix(int64(exp), 0).In(loc)
+			a.StatusExpires = &expires
+		}
+	}
+
+	decision := notify.Decide(now, loc, sig.DND, hours, 0, false)
+	if decision.Action != notify.ActionSend {
+		at := decision.At
+		a.NextAvailable = &at
+	}
+	snoozed := now.Before(sig.DND.SnoozeUntil)
+	inDND := !sig.DND.DNDStart.IsZero() && !now.Before(sig.DND.DNDStart) && now.Before(sig.DND.DNDEnd)
+	offHours := !hours.Next(now.In(loc)).Equal(now.In(loc))
+	ooo := IsOutOfOffice(a.StatusEmoji, a.StatusText)
+
+	if u.Deleted {
+		a.Reasons = append(a.Reasons, "account deactivated")
+	}
+	if ooo {
+		a.Reasons = append(a.Reasons, "status says out of office: "+strings.TrimSpace(a.StatusEmoji+" "+a.StatusText))
+	}
+	if snoozed {
+		a.Reasons = append(a.Reasons, "notifications snoozed")
+	}
+	if inDND {
+		a.Reasons = append(a.Reasons, "in Do Not Disturb")
+	}
+	if offHours {
+		a.Reasons = append(a.Reasons, "outside working hours")
+	}
+	if a.Presence == "away" {
+		a.Reasons = append(a.Reasons, "presence away")
+	}
+
+	switch {
+	case u.Deleted:
+		a.Verdict = VerdictDeactivated
+	case ooo:
+		a.Verdict = VerdictOutOfOffice
+	case snoozed || inDND:
+		a.Verdict = VerdictDND
+	case offHours:
+		a.Verdict = VerdictOffHours
+	case a.Presence == "away":
+		a.Verdict = VerdictAway
+	default:
+		a.Verdict = VerdictAvailable
+	}
+	a.Available = a.Verdict == VerdictAvailable
+	a.Assignable = a.Verdict != VerdictDeactivated && a.Verdict != VerdictOutOfOffice
+	return a
+}
+
+// Lines implements the output.Printable interface for AvailabilityResult.
+func (r *AvailabilityResult) Lines() []string {
+	if len(r.Users) == 0 {
+		return []string{"No users."}
+	}
+	var lines []string
+	for _, u := range r.Users {
+		icon := "🟢"
+		switch {
+		case !u.Assignable:
+			icon = "⛔"
+		case !u.Available:
+			icon = "🟡"
+		}
+		line := fmt.Sprintf("%s %s %s (%s, %s)", icon, u.User, u.Verdict, u.LocalTime.Format("Mon 15:04"), u.Timezone)
+		if len(u.Reasons) > 0 {
+			line += ": " + strings.Join(u.Reasons, "; ")
+		}
+		lines = append(lines, line)
+		if u.NextAvailable != nil {
+			lines = append(lines, "   next available "+u.NextAvailable.Format("Mon 2006-01-02 15:04 MST"))
+		}
+		if u.StatusExpires != nil {
+			lines = append(lines, "   status clears "+u.StatusExpires.Format("Mon 2006-01-02 15:04 MST"))
+		}
+	}
+	return lines
+}
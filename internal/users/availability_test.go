@@ -0,0 +1,118 @@
+package users
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kehao95/slack-agent-cli/internal/notify"
+)
+
+func TestIsOutOfOffice(t *testing.T) {
+	tests := []struct {
+		emoji, text string
+		want        bool
+	}{
+		{":palm_tree:", "", true},
+		{":Palm_Tree:", "back soon", true},
+		{"", "OOO until Monday", true},
+		{"", "Out of the office", true},
+		{"", "on parental leave", true},
+		{"", "PTO", true},
+		{":calendar:", "In a meeting", false},
+		{"", "Looking at oooh shiny things", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := IsOutOfOffice(tt.emoji, tt.text); got != tt.want {
+			t.Errorf("IsOutOfOffice(%q, %q) = %v, want %v", tt.emoji, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAssess(t *testing.T) {
+	hours, err := notify.ParseWorkingHours("09:00-18:00", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Friday Jan 19 2024, 10:00 UTC.
+	now := time.Date(2024, time.January, 19, 10, 0, 0, 0, time.UTC)
+	user := func(mutate func(*slackapi.User)) *slackapi.User {
+		u := &slackapi.User{ID: "U1", TZ: "UTC"}
+		if mutate != nil {
+			mutate(u)
+		}
+		return u
+	}
+	active := &slackapi.UserPresence{Presence: "active"}
+	away := &slackapi.UserPresence{Presence: "away"}
+
+	tests := []struct {
+		name           string
+		sig            Signals
+		wantVerdict    string
+		wantAssignable bool
+		wantReasons    []string
+		wantNext       time.Time
+	}{
+		{name: "available", sig: Signals{User: user(nil), Presence: active}, wantVerdict: VerdictAvailable, wantAssignable: true},
+		{name: "away", sig: Signals{User: user(nil), Presence: away}, wantVerdict: VerdictAway, wantAssignable: true, wantReasons: []string{"presence away"}},
+		{
+			name:        "vacation status",
+			sig:         Signals{User: user(func(u *slackapi.User) { u.Profile.StatusEmoji = ":palm_tree:" }), Presence: active},
+			wantVerdict: VerdictOutOfOffice, wantReasons: []string{"status says out of office: :palm_tree:"},
+		},
+		{
+			name: "expired vacation status",
+			sig: Signals{User: user(func(u *slackapi.User) {
+				u.Profile.StatusText = "OOO"
+				u.Profile.StatusExpiration = int(now.Add(-time.Hour).Unix())
+			}), Presence: active},
+			wantVerdict: VerdictAvailable, wantAssignable: true,
+		},
+		{
+			name:        "snoozed",
+			sig:         Signals{User: user(nil), Presence: active, DND: notify.Availability{SnoozeUntil: now.Add(time.Hour)}},
+			wantVerdict: VerdictDND, wantAssignable: true, wantReasons: []string{"notifications snoozed"}, wantNext: now.Add(time.Hour),
+		},
+		{
+			name:        "after hours in their zone",
+			sig:         Signals{User: user(func(u *slackapi.User) { u.TZ = "Asia/Tokyo" }), Presence: away},
+			wantVerdict: VerdictOffHours, wantAssignable: true, wantReasons: []string{"outside working hours", "presence away"},
+			// 19:00 Friday in Tokyo: next is Monday 09:00 JST.
+			wantNext: time.Date(2024, time.January, 22, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "deactivated",
+			sig:         Signals{User: user(func(u *slackapi.User) { u.Deleted = true }), Presence: away},
+			wantVerdict: VerdictDeactivated, wantReasons: []string{"account deactivated", "presence away"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.sig.User.TZ == "Asia/Tokyo" {
+				if _, err := time.LoadLocation("Asia/Tokyo"); err != nil {
+					t.Skipf("tzdata unavailable: %v", err)
+				}
+			}
+			got := Assess(tt.sig, hours, now)
+			if got.Verdict != tt.wantVerdict {
+				t.Errorf("verdict = %q, want %q", got.Verdict, tt.wantVerdict)
+			}
+			if got.Available != (tt.wantVerdict == VerdictAvailable) || got.Assignable != tt.wantAssignable {
+				t.Errorf("available, assignable = %v, %v", got.Available, got.Assignable)
+			}
+			if !reflect.DeepEqual(got.Reasons, tt.wantReasons) {
+				t.Errorf("reasons = %q, want %q", got.Reasons, tt.wantReasons)
+			}
+			switch {
+			case tt.wantNext.IsZero() && got.NextAvailable != nil:
+				t.Errorf("next available = %v, want none", got.NextAvailable)
+			case !tt.wantNext.IsZero() && (got.NextAvailable == nil || !got.NextAvailable.Equal(tt.wantNext)):
+				t.Errorf("next available = %v, want %v", got.NextAvailable, tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package users
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/output/testkit"
+)
+
+func TestPrintableGolden(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	now := time.Date(2024, time.March, 1, 9, 30, 0, 0, tokyo)
+	next := time.Date(2024, time.March, 4, 9, 0, 0, 0, tokyo)
+	back := time.Date(2024, time.March, 8, 0, 0, 0, 0, tokyo)
+	tests := []struct {
+		name string
+		p    interface{ Lines() []string }
+	}{
+		{"ListResult", &ListResult{OK: true, NextCursor: "dXNlcjpVMDI=", Users: []UserInfo{
+			{ID: "U1", Name: "alice", RealName: "Alice Liddell", DisplayName: "Alice"},
+			{ID: "U2", Name: "deploybot", RealName: "Deploy Bot", IsBot: true},
+			{ID: "U3", Name: "mallory", IsDeleted: true},
+			{ID: "U4", RealName: "名前だけ"},
+		}}},
+		{"ListResult_empty", &ListResult{OK: true}},
+		{"UserInfoResult", &UserInfoResult{OK: true, User: UserInfo{ID: "U1", Name: "alice", RealName: "Alice Liddell", Email: "alice@example.com", Title: "SRE"}}},
+		{"UserInfoResult_deleted_bot", &UserInfoResult{OK: true, User: UserInfo{ID: "B1", IsBot: true, IsDeleted: true}}},
+		{"PresenceResult_active", &PresenceResult{OK: true, Presence: "active", Online: true, ConnectionCount: 2, LastActivity: 1700000000}},
+		{"AvailabilityResult", &AvailabilityResult{OK: true, Users: []UserAvailability{
+			{User: "@alice", UserID: "U1", Verdict: VerdictAvailable, Available: true, Assignable: true, Presence: "active", Timezone: "Asia/Tokyo", LocalTime: now},
+			{User: "@bob", UserID: "U2", Verdict: VerdictOutOfOffice, Reasons: []string{"status says out of office: :palm_tree: Vacation"},
+				StatusEmoji: ":palm_tree:", StatusText: "Vacation", StatusExpires: &back, Timezone: "Asia/Tokyo", LocalTime: now},
+			{User: "@carol", UserID: "U3", Verdict: VerdictOffHours, Assignable: true, Reasons: []string{"outside working hours", "presence away"},
+				NextAvailable: &next, Presence: "away", Timezone: "Asia/Tokyo", LocalTime: now},
+		}}},
+		{"AvailabilityResult_empty", &AvailabilityResult{OK: true}},
+		{"PresenceResult_away", &PresenceResult{OK: true, Presence: "away", AutoAway: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testkit.Lines(t, tt.name, tt.p)
+		})
+	}
+}
@@ -4,11 +4,14 @@ package users
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	slackapi "github.com/slack-go/slack"
 
 	"github.com/kehao95/slack-agent-cli/internal/cache"
 	"github.com/kehao95/slack-agent-cli/internal/errors"
+	"github.com/kehao95/slack-agent-cli/internal/warnings"
 )
 
 // UserClient defines the Slack operations needed for user lookups.
@@ -25,12 +28,19 @@ type CachedUser struct {
 	RealName    string `json:"real_name"`
 	DisplayName string `json:"display_name"`
 	IsBot       bool   `json:"is_bot"`
+	// Deactivated is set for deactivated (deleted) accounts.
+	Deactivated bool `json:"deactivated,omitempty"`
 }
 
+// deactivatedLabel follows the display name of a deactivated user.
+const deactivatedLabel = " [deactivated]"
+
 // Resolver resolves user IDs to display names using a disk cache.
 type Resolver struct {
 	client UserClient
 	cache  *cache.Store
+	// excludeDeactivated makes name lookups refuse deactivated accounts.
+	excludeDeactivated bool
 }
 
 // NewResolver creates a Resolver with no cache (API-only).
@@ -43,6 +53,12 @@ func NewCachedResolver(client UserClient, store *cache.Store) *Resolver {
 	return &Resolver{client: client, cache: store}
 }
 
+// SetExcludeDeactivated makes ResolveName and FindByName refuse deactivated accounts,
+// so nothing is sent to a person who has left. IDs still resolve to names.
+func (r *Resolver) SetExcludeDeactivated(exclude bool) {
+	r.excludeDeactivated = exclude
+}
+
 // RefreshCache clears the user cache.
 func (r *Resolver) RefreshCache(ctx context.Context) error {
 	if r.cache != nil {
@@ -56,7 +72,8 @@ func (r *Resolver) RefreshCache(ctx context.Context) error {
 	return nil
 }
 
-// GetDisplayName returns a human-friendly name for a user ID.
+// GetDisplayName returns a human-friendly name for a user ID, followed by
+// " [deactivated]" for deactivated accounts.
 // If the cache is empty, it will fetch all users from the API first.
 func (r *Resolver) GetDisplayName(ctx context.Context, userID string) string {
 	users, err := r.loadOrFetchUsers(ctx)
@@ -68,6 +85,7 @@ func (r *Resolver) GetDisplayName(ctx context.Context, userID string) string {
 				return displayName(toCachedUser(info))
 			}
 		}
+		warnUnresolved()
 		return userID
 	}
 
@@ -89,6 +107,7 @@ func (r *Resolver) GetDisplayName(ctx context.Context, userID string) string {
 		}
 	}
 
+	warnUnresolved()
 	return userID
 }
 
@@ -102,6 +121,7 @@ func (r *Resolver) GetMentionName(ctx context.Context, userID string) string {
 				return mentionName(toCachedUser(info))
 			}
 		}
+		warnUnresolved()
 		return userID
 	}
 
@@ -121,9 +141,15 @@ func (r *Resolver) GetMentionName(ctx context.Context, userID string) string {
 		}
 	}
 
+	warnUnresolved()
 	return userID
 }
 
+// warnUnresolved records that a user ID is shown in place of a name.
+func warnUnresolved() {
+	warnings.Add(warnings.NamesUnresolved, "some user IDs could not be resolved to names and are shown as IDs; run 'slk cache populate users --all' if the user cache is partial")
+}
+
 // GetUser returns cached user info or fetches it.
 func (r *Resolver) GetUser(ctx context.Context, userID string) (CachedUser, error) {
 	users, err := r.loadOrFetchUsers(ctx)
@@ -151,6 +177,65 @@ func (r *Resolver) GetUser(ctx context.Context, userID string) (CachedUser, erro
 	return cu, nil
 }
 
+// FindByName returns the user whose handle or display name matches name, ignoring case
+// and a leading @. Handles take precedence over display names, which are not unique.
+func (r *Resolver) FindByName(ctx context.Context, name string) (CachedUser, bool) {
+	u, err := r.ResolveName(ctx, name)
+	return u, err == nil
+}
+
+// ResolveName is FindByName with the reason a name did not resolve: a not-found
+// error, or an ambiguous-name error listing every user with that display name.
+// Deactivated accounts keep their display names, so an active user sharing one is
+// preferred; with SetExcludeDeactivated they never match.
+func (r *Resolver) ResolveName(ctx context.Context, name string) (CachedUser, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(name), "@")
+	if trimmed == "" {
+		return CachedUser{}, errors.UserNotFoundError(name)
+	}
+	users, err := r.loadOrFetchUsers(ctx)
+	if err != nil {
+		return CachedUser{}, fmt.Errorf("resolve user @%s: %w", trimmed, err)
+	}
+	var byDisplay, active []CachedUser
+	for _, u := range users {
+		if strings.EqualFold(u.Name, trimmed) {
+			if u.Deactivated && r.excludeDeactivated {
+				return CachedUser{}, deactivatedError(trimmed)
+			}
+			return u, nil
+		}
+		if strings.EqualFold(u.DisplayName, trimmed) {
+			byDisplay = append(byDisplay, u)
+			if !u.Deactivated {
+				active = append(active, u)
+			}
+		}
+	}
+	if len(active) > 0 {
+		byDisplay = active
+	} else if len(byDisplay) > 0 && r.excludeDeactivated {
+		return CachedUser{}, deactivatedError(trimmed)
+	}
+	switch len(byDisplay) {
+	case 0:
+		return CachedUser{}, errors.UserNotFoundError("@" + trimmed)
+	case 1:
+		return byDisplay[0], nil
+	}
+	sort.Slice(byDisplay, func(i, j int) bool { return byDisplay[i].ID < byDisplay[j].ID })
+	candidates := make([]errors.Candidate, len(byDisplay))
+	for i, u := range byDisplay {
+		candidates[i] = errors.Candidate{ID: u.ID, Name: u.Name, RealName: u.RealName, Deactivated: u.Deactivated}
+	}
+	return CachedUser{}, errors.AmbiguousUserError("@"+trimmed, candidates)
+}
+
+func deactivatedError(name string) error {
+	return errors.NotFoundError("user", "@"+name,
+		"Hint: The account is deactivated, and exclude_deactivated_users is set in config")
+}
+
 // loadOrFetchUsers returns the cached user map, fetching all users if cache is empty.
 func (r *Resolver) loadOrFetchUsers(ctx context.Context) (map[string]CachedUser, error) {
 	// Try to load from cache first
@@ -244,17 +329,21 @@ func toCachedUser(u *slackapi.User) CachedUser {
 		RealName:    u.RealName,
 		DisplayName: u.Profile.DisplayName,
 		IsBot:       u.IsBot,
+		Deactivated: u.Deleted,
 	}
 }
 
 func displayName(u CachedUser) string {
+	name := u.Name
 	if u.DisplayName != "" {
-		return u.DisplayName
+		name = u.DisplayName
+	} else if u.RealName != "" {
+		name = u.RealName
 	}
-	if u.RealName != "" {
-		return u.RealName
+	if u.Deactivated && name != "" {
+		name += deactivatedLabel
 	}
-	return u.Name
+	return name
 }
 
 func mentionName(u CachedUser) string {
@@ -3,11 +3,14 @@ package users
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	slackapi "github.com/slack-go/slack"
 
 	"github.com/kehao95/slack-agent-cli/internal/cache"
+	cerrors "github.com/kehao95/slack-agent-cli/internal/errors"
 )
 
 type mockUserClient struct {
@@ -276,3 +279,95 @@ func TestResolver_GetUser(t *testing.T) {
 		t.Errorf("expected 1 API call for uncached user, got %d", client.callsGetOne)
 	}
 }
+
+func TestResolver_FindByName(t *testing.T) {
+	client := &mockUserClient{
+		allUsers: []slackapi.User{
+			{ID: "U1", Name: "alice", Profile: slackapi.UserProfile{DisplayName: "Al"}},
+			{ID: "U2", Name: "bob", Profile: slackapi.UserProfile{DisplayName: "Al"}},
+			{ID: "U3", Name: "carol", Profile: slackapi.UserProfile{DisplayName: "CJ"}},
+		},
+	}
+	resolver := NewResolver(client)
+
+	tests := []struct {
+		name   string
+		wantID string
+		found  bool
+	}{
+		{"@Alice", "U1", true},
+		{"cj", "U3", true},
+		{"Al", "", false}, // display name shared by two users
+		{"dave", "", false},
+	}
+	for _, tt := range tests {
+		u, ok := resolver.FindByName(context.Background(), tt.name)
+		if ok != tt.found || u.ID != tt.wantID {
+			t.Errorf("FindByName(%q) = %q, %v; want %q, %v", tt.name, u.ID, ok, tt.wantID, tt.found)
+		}
+	}
+}
+
+func TestResolver_ResolveNameAmbiguous(t *testing.T) {
+	client := &mockUserClient{
+		allUsers: []slackapi.User{
+			{ID: "U2", Name: "albert", RealName: "Albert Jones", Profile: slackapi.UserProfile{DisplayName: "Al"}},
+			{ID: "U1", Name: "alice", RealName: "Alice Smith", Profile: slackapi.UserProfile{DisplayName: "Al"}},
+		},
+	}
+	resolver := NewResolver(client)
+
+	_, err := resolver.ResolveName(context.Background(), "@al")
+	var ambiguous *cerrors.AmbiguousNameError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("ResolveName(@al) error = %v, want AmbiguousNameError", err)
+	}
+	want := []cerrors.Candidate{{ID: "U1", Name: "alice", RealName: "Alice Smith"}, {ID: "U2", Name: "albert", RealName: "Albert Jones"}}
+	if !reflect.DeepEqual(ambiguous.Candidates, want) {
+		t.Errorf("candidates = %+v, want %+v", ambiguous.Candidates, want)
+	}
+
+	if u, err := resolver.ResolveName(context.Background(), "@Alice"); err != nil || u.ID != "U1" {
+		t.Errorf("ResolveName(@Alice) = %q, %v; want U1", u.ID, err)
+	}
+	if _, err := resolver.ResolveName(context.Background(), "@dave"); !cerrors.IsNotFoundError(err) {
+		t.Errorf("ResolveName(@dave) error = %v, want not found", err)
+	}
+}
+
+func TestResolver_DeactivatedUsers(t *testing.T) {
+	client := &mockUserClient{
+		allUsers: []slackapi.User{
+			{ID: "U1", Name: "sam.old", Deleted: true, Profile: slackapi.UserProfile{DisplayName: "Sam"}},
+			{ID: "U2", Name: "sam", Profile: slackapi.UserProfile{DisplayName: "Sam"}},
+			{ID: "U3", Name: "pat", Deleted: true, Profile: slackapi.UserProfile{DisplayName: "Pat"}},
+		},
+	}
+	resolver := NewResolver(client)
+	ctx := context.Background()
+
+	if got := resolver.GetDisplayName(ctx, "U3"); got != "Pat [deactivated]" {
+		t.Errorf("GetDisplayName(U3) = %q", got)
+	}
+	if got := resolver.GetMentionName(ctx, "U3"); got != "pat" {
+		t.Errorf("GetMentionName(U3) = %q", got)
+	}
+	// An active user sharing a display name wins over a deactivated one.
+	if u, err := resolver.ResolveName(ctx, "@Sam"); err != nil || u.ID != "U2" {
+		t.Errorf("ResolveName(@Sam) = %q, %v; want U2", u.ID, err)
+	}
+	if u, err := resolver.ResolveName(ctx, "@pat"); err != nil || u.ID != "U3" {
+		t.Errorf("ResolveName(@pat) = %q, %v; want U3", u.ID, err)
+	}
+
+	resolver.SetExcludeDeactivated(true)
+	for _, name := range []string{"@pat", "@Pat", "@sam.old"} {
+		_, err := resolver.ResolveName(ctx, name)
+		if !cerrors.IsNotFoundError(err) || !strings.Contains(err.Error(), "deactivated") {
+			t.Errorf("ResolveName(%s) error = %v, want deactivated not found", name, err)
+		}
+	}
+	if u, err := resolver.ResolveName(ctx, "@Sam"); err != nil || u.ID != "U2" {
+		t.Errorf("ResolveName(@Sam) excluding deactivated = %q, %v; want U2", u.ID, err)
+	}
+}
@@ -2,7 +2,10 @@ package users
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
@@ -24,6 +27,61 @@ type ListParams struct {
 	Limit       int
 	Cursor      string
 	IncludeBots bool
+	Filter      Filter
+	Fields      []string // JSON fields to keep on each user; empty keeps all
+}
+
+// Filter narrows a user listing. When any filter is set, List pages through the
+// whole workspace before filtering so the result is complete.
+type Filter struct {
+	ActiveOnly   bool
+	DeletedOnly  bool
+	AdminOnly    bool
+	TZ           string // glob such as "Europe/*"; * matches any run of characters
+	NameContains string // case-insensitive match on handle, real name, or display name
+}
+
+// Active reports whether any filter is set.
+func (f Filter) Active() bool {
+	return f.ActiveOnly || f.DeletedOnly || f.AdminOnly || f.TZ != "" || f.NameContains != ""
+}
+
+// Match reports whether the user passes every filter.
+func (f Filter) Match(u UserInfo) bool {
+	if f.ActiveOnly && u.IsDeleted {
+		return false
+	}
+	if f.DeletedOnly && !u.IsDeleted {
+		return false
+	}
+	if f.AdminOnly && !u.IsAdmin && !u.IsOwner {
+		return false
+	}
+	if f.TZ != "" && !globMatch(f.TZ, u.TZ) {
+		return false
+	}
+	if f.NameContains != "" {
+		needle := strings.ToLower(strings.TrimPrefix(f.NameContains, "@"))
+		if !strings.Contains(strings.ToLower(u.Name), needle) &&
+			!strings.Contains(strings.ToLower(u.RealName), needle) &&
+			!strings.Contains(strings.ToLower(u.DisplayName), needle) {
+			return false
+		}
+	}
+	return true
+}
+
+// globMatch matches value against a case-insensitive glob where * spans any
+// characters, including "/", so "America/*" also matches "America/Argentina/Salta".
+func globMatch(pattern, value string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	re, err := regexp.Compile("(?i)^" + expr + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
 }
 
 // ListResult contains the result of a users list operation.
@@ -31,6 +89,70 @@ type ListResult struct {
 	OK         bool       `json:"ok"`
 	Users      []UserInfo `json:"users"`
 	NextCursor string     `json:"next_cursor,omitempty"`
+
+	fields []string
+}
+
+// UserFields lists the JSON field names accepted by ListParams.Fields.
+func UserFields() []string {
+	t := reflect.TypeOf(UserInfo{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		names = append(names, name)
+	}
+	return names
+}
+
+// selectFields validates field names against UserInfo's JSON tags.
+func selectFields(fields []string) ([]string, error) {
+	known := make(map[string]bool)
+	for _, name := range UserFields() {
+		known[name] = true
+	}
+	var selected []string
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !known[field] {
+			return nil, fmt.Errorf("unknown user field %q (valid: %s)", field, strings.Join(UserFields(), ", "))
+		}
+		selected = append(selected, field)
+	}
+	return selected, nil
+}
+
+// MarshalJSON emits only the selected user fields when ListParams.Fields was set.
+func (r ListResult) MarshalJSON() ([]byte, error) {
+	type plain ListResult
+	if len(r.fields) == 0 {
+		return json.Marshal(plain(r))
+	}
+	users := make([]map[string]json.RawMessage, 0, len(r.Users))
+	for _, u := range r.Users {
+		data, err := json.Marshal(u)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		picked := make(map[string]json.RawMessage, len(r.fields))
+		for _, field := range r.fields {
+			if value, ok := all[field]; ok {
+				picked[field] = value
+			}
+		}
+		users = append(users, picked)
+	}
+	return json.Marshal(struct {
+		OK         bool                         `json:"ok"`
+		Users      []map[string]json.RawMessage `json:"users"`
+		NextCursor string                       `json:"next_cursor,omitempty"`
+	}{r.OK, users, r.NextCursor})
 }
 
 // UserInfo contains a subset of user information.
@@ -41,8 +163,11 @@ type UserInfo struct {
 	DisplayName string `json:"display_name"`
 	Email       string `json:"email,omitempty"`
 	Title       string `json:"title,omitempty"`
+	TZ          string `json:"tz,omitempty"`
 	IsBot       bool   `json:"is_bot"`
 	IsDeleted   bool   `json:"is_deleted"`
+	IsAdmin     bool   `json:"is_admin,omitempty"`
+	IsOwner     bool   `json:"is_owner,omitempty"`
 }
 
 // UserInfoResult contains the result of a user info lookup.
@@ -67,11 +192,24 @@ func (s *Service) List(ctx context.Context, params ListParams) (*ListResult, err
 	if params.Limit <= 0 {
 		params.Limit = 100
 	}
+	fields, err := selectFields(params.Fields)
+	if err != nil {
+		return nil, err
+	}
 
 	users, nextCursor, err := s.client.ListUsers(ctx, params.Cursor, params.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
+	// Filters apply to the whole workspace, so keep paging until the end.
+	for params.Filter.Active() && nextCursor != "" {
+		var page []slackapi.User
+		page, nextCursor, err = s.client.ListUsers(ctx, nextCursor, params.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("list users: %w", err)
+		}
+		users = append(users, page...)
+	}
 
 	// Filter out bots if requested
 	var filtered []UserInfo
@@ -79,13 +217,18 @@ func (s *Service) List(ctx context.Context, params ListParams) (*ListResult, err
 		if !params.IncludeBots && u.IsBot {
 			continue
 		}
-		filtered = append(filtered, toUserInfo(&u))
+		info := toUserInfo(&u)
+		if !params.Filter.Match(info) {
+			continue
+		}
+		filtered = append(filtered, info)
 	}
 
 	return &ListResult{
 		OK:         true,
 		Users:      filtered,
 		NextCursor: nextCursor,
+		fields:     fields,
 	}, nil
 }
 
@@ -120,6 +263,18 @@ func (s *Service) GetPresence(ctx context.Context, userID string) (*PresenceResu
 	}, nil
 }
 
+// StripEmails clears every user's email address.
+func (r *ListResult) StripEmails() {
+	for i := range r.Users {
+		r.Users[i].Email = ""
+	}
+}
+
+// StripEmails clears the user's email address.
+func (r *UserInfoResult) StripEmails() {
+	r.User.Email = ""
+}
+
 // Lines implements the output.Printable interface for ListResult.
 func (r *ListResult) Lines() []string {
 	if len(r.Users) == 0 {
@@ -250,7 +405,10 @@ func toUserInfo(u *slackapi.User) UserInfo {
 		DisplayName: u.Profile.DisplayName,
 		Email:       u.Profile.Email,
 		Title:       u.Profile.Title,
+		TZ:          u.TZ,
 		IsBot:       u.IsBot,
 		IsDeleted:   u.Deleted,
+		IsAdmin:     u.IsAdmin,
+		IsOwner:     u.IsOwner || u.IsPrimaryOwner,
 	}
 }
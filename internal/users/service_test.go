@@ -2,7 +2,9 @@ package users
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	slackapi "github.com/slack-go/slack"
@@ -112,6 +114,66 @@ func TestService_List(t *testing.T) {
 	}
 }
 
+func TestService_ListFilters(t *testing.T) {
+	mock := &mockUserClient{allUsers: []slackapi.User{
+		{ID: "U1", Name: "alice", RealName: "Alice Smith", TZ: "Europe/Berlin", IsAdmin: true},
+		{ID: "U2", Name: "bob", RealName: "Bob Jones", TZ: "Europe/London", Deleted: true, IsAdmin: true},
+		{ID: "U3", Name: "carol", RealName: "Carol Smithers", TZ: "America/Argentina/Salta", IsPrimaryOwner: true},
+		{ID: "U4", Name: "dave", RealName: "Dave Brown", TZ: "Europe/Paris"},
+	}}
+	service := NewService(mock)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"active admins", Filter{ActiveOnly: true, AdminOnly: true}, []string{"U1", "U3"}},
+		{"deleted only", Filter{DeletedOnly: true}, []string{"U2"}},
+		{"tz glob", Filter{TZ: "europe/*", ActiveOnly: true}, []string{"U1", "U4"}},
+		{"tz glob spans slash", Filter{TZ: "America/*"}, []string{"U3"}},
+		{"name contains", Filter{NameContains: "SMITH"}, []string{"U1", "U3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.List(context.Background(), ListParams{Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var got []string
+			for _, u := range result.Users {
+				got = append(got, u.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("List() users = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ListFields(t *testing.T) {
+	mock := &mockUserClient{allUsers: []slackapi.User{
+		{ID: "U1", Name: "alice", RealName: "Alice Smith", Profile: slackapi.UserProfile{Email: "alice@example.com"}},
+	}}
+	service := NewService(mock)
+
+	result, err := service.List(context.Background(), ListParams{Fields: []string{"id", "email"}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got := string(data); got != `{"ok":true,"users":[{"email":"alice@example.com","id":"U1"}]}` {
+		t.Fatalf("unexpected JSON: %s", got)
+	}
+
+	if _, err := service.List(context.Background(), ListParams{Fields: []string{"phone"}}); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
+
 func TestService_GetInfo(t *testing.T) {
 	tests := []struct {
 		name      string
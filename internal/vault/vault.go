@@ -0,0 +1,264 @@
+// Package vault stores Slack tokens for several identities in an encrypted file.
+//
+// The vault is a JSON envelope holding an AES-256-GCM sealed list of entries. The key
+// is derived from SLACK_CLI_VAULT_PASSPHRASE when that is set, and otherwise read from
+// a random key file created next to the vault with mode 0600.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PassphraseEnv names the environment variable that supplies a vault passphrase.
+const PassphraseEnv = "SLACK_CLI_VAULT_PASSPHRASE"
+
+const (
+	currentVersion   = 1
+	kdfKeyFile       = "keyfile"
+	kdfPBKDF2        = "pbkdf2-sha256"
+	pbkdf2Iterations = 600000
+	keySize          = 32
+)
+
+// Entry is one stored token.
+type Entry struct {
+	TeamID    string    `json:"team_id"`
+	Team      string    `json:"team,omitempty"`
+	UserID    string    `json:"user_id"`
+	User      string    `json:"user,omitempty"`
+	Kind      string    `json:"kind"` // "user" or "bot"
+	Token     string    `json:"token"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ID identifies an entry as <team_id>/<user_id>.
+func (e Entry) ID() string {
+	return e.TeamID + "/" + e.UserID
+}
+
+// envelope is the on-disk format.
+type envelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt,omitempty"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Vault is an open, decrypted vault.
+type Vault struct {
+	path    string
+	entries []Entry
+}
+
+// DefaultPath returns the vault path next to the config file.
+func DefaultPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "vault.json")
+}
+
+// Open reads and decrypts the vault at path. A missing vault opens empty.
+func Open(path string) (*Vault, error) {
+	v := &Vault{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return v, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read vault: %w", err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("parse vault: %w", err)
+	}
+	key, err := v.key(env.KDF, env.Salt, false)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("decrypt vault: wrong key or passphrase, or the file is corrupted")
+	}
+	if err := json.Unmarshal(plain, &v.entries); err != nil {
+		return nil, fmt.Errorf("parse vault entries: %w", err)
+	}
+	return v, nil
+}
+
+// Path returns the vault file path.
+func (v *Vault) Path() string {
+	return v.path
+}
+
+// Entries returns the stored entries sorted by ID.
+func (v *Vault) Entries() []Entry {
+	entries := append([]Entry(nil), v.entries...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID() < entries[j].ID() })
+	return entries
+}
+
+// Remove deletes the vault at path and its key file, returning the paths that existed.
+func Remove(path string) ([]string, error) {
+	var removed []string
+	for _, p := range []string{path, path + ".key"} {
+		err := os.Remove(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return removed, fmt.Errorf("delete %s: %w", p, err)
+		}
+		removed = append(removed, p)
+	}
+	return removed, nil
+}
+
+// Put adds an entry, replacing any entry with the same ID.
+func (v *Vault) Put(entry Entry) {
+	for i, existing := range v.entries {
+		if existing.ID() == entry.ID() {
+			v.entries[i] = entry
+			return
+		}
+	}
+	v.entries = append(v.entries, entry)
+}
+
+// Find returns the entry matching an ID (T123/U456) or a user ID or name that is
+// unique across teams.
+func (v *Vault) Find(ref string) (Entry, error) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "@")
+	var matches []Entry
+	for _, e := range v.entries {
+		if e.ID() == ref {
+			return e, nil
+		}
+		if e.UserID == ref || (e.User != "" && strings.EqualFold(e.User, ref)) {
+			matches = append(matches, e)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Entry{}, fmt.Errorf("no vault entry matches %q", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, e := range matches {
+			ids[i] = e.ID()
+		}
+		return Entry{}, fmt.Errorf("%q matches several entries (%s); use the full ID", ref, strings.Join(ids, ", "))
+	}
+}
+
+// Save encrypts and writes the vault with mode 0600, creating the key file on first use.
+func (v *Vault) Save() error {
+	kdf, salt := kdfKeyFile, []byte(nil)
+	if os.Getenv(PassphraseEnv) != "" {
+		kdf, salt = kdfPBKDF2, make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("generate salt: %w", err)
+		}
+	}
+	key, err := v.key(kdf, salt, true)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(v.entries)
+	if err != nil {
+		return fmt.Errorf("encode vault entries: %w", err)
+	}
+	env := envelope{Version: currentVersion, KDF: kdf, Salt: salt, Nonce: make([]byte, gcm.NonceSize())}
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+	env.Ciphertext = gcm.Seal(nil, env.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode vault: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(v.path), 0o700); err != nil {
+		return fmt.Errorf("create vault directory: %w", err)
+	}
+	tmp := v.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write vault: %w", err)
+	}
+	if err := os.Rename(tmp, v.path); err != nil {
+		return fmt.Errorf("write vault: %w", err)
+	}
+	return nil
+}
+
+// key returns the encryption key for the given KDF. With create, a missing key file
+// is generated.
+func (v *Vault) key(kdf string, salt []byte, create bool) ([]byte, error) {
+	switch kdf {
+	case kdfPBKDF2:
+		passphrase := os.Getenv(PassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("vault is passphrase-protected; set %s", PassphraseEnv)
+		}
+		return pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, keySize)
+	case kdfKeyFile:
+		return v.readKeyFile(create)
+	default:
+		return nil, fmt.Errorf("unsupported vault kdf %q", kdf)
+	}
+}
+
+func (v *Vault) readKeyFile(create bool) ([]byte, error) {
+	path := v.path + ".key"
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("vault key %s is invalid", path)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) || !create {
+		return nil, fmt.Errorf("read vault key: %w", err)
+	}
+	key = make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate vault key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create vault directory: %w", err)
+	}
+	if err := os.WriteFile(path, key, 0o600); err != nil {
+		return nil, fmt.Errorf("write vault key: %w", err)
+	}
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("init cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,102 @@
+package vault
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVaultRoundTrip(t *testing.T) {
+	t.Setenv(PassphraseEnv, "")
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	v, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open missing vault: %v", err)
+	}
+	v.Put(Entry{TeamID: "T1", UserID: "U1", User: "alice", Kind: "user", Token: "xoxp-secret-1", CreatedAt: time.Now()})
+	v.Put(Entry{TeamID: "T1", UserID: "U2", User: "bob", Kind: "user", Token: "xoxp-secret-2"})
+	v.Put(Entry{TeamID: "T1", UserID: "U1", User: "alice", Kind: "user", Token: "xoxp-secret-3"})
+	if err := v.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "xoxp-secret") {
+		t.Fatal("vault file contains a plaintext token")
+	}
+	for _, p := range []string{path, path + ".key"} {
+		if info, err := os.Stat(p); err != nil || info.Mode().Perm() != 0o600 {
+			t.Fatalf("%s: mode %v, err %v; want 0600", p, info.Mode().Perm(), err)
+		}
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	entries := reopened.Entries()
+	if len(entries) != 2 || entries[0].Token != "xoxp-secret-3" {
+		t.Fatalf("entries = %+v, want 2 with U1 replaced", entries)
+	}
+	if e, err := reopened.Find("@bob"); err != nil || e.UserID != "U2" {
+		t.Fatalf("Find(@bob) = %+v, %v", e, err)
+	}
+	if _, err := reopened.Find("U9"); err == nil {
+		t.Fatal("expected error for unknown entry")
+	}
+}
+
+func TestVaultPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.json")
+	t.Setenv(PassphraseEnv, "correct horse")
+
+	v, _ := Open(path)
+	v.Put(Entry{TeamID: "T1", UserID: "U1", Kind: "user", Token: "xoxp-secret"})
+	if err := v.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(path + ".key"); !os.IsNotExist(err) {
+		t.Fatal("passphrase vault should not create a key file")
+	}
+	if _, err := Open(path); err != nil {
+		t.Fatalf("Open with passphrase: %v", err)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := Open(path); err == nil {
+		t.Fatal("expected error with wrong passphrase")
+	}
+	t.Setenv(PassphraseEnv, "")
+	if _, err := Open(path); err == nil || !strings.Contains(err.Error(), PassphraseEnv) {
+		t.Fatalf("expected passphrase hint, got %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	t.Setenv(PassphraseEnv, "")
+	path := filepath.Join(t.TempDir(), "vault.json")
+	v, _ := Open(path)
+	v.Put(Entry{TeamID: "T1", UserID: "U1", Kind: "user", Token: "xoxp-secret-1"})
+	if err := v.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	removed, err := Remove(path)
+	if err != nil || len(removed) != 2 {
+		t.Fatalf("Remove = %v, %v; want the vault and its key", removed, err)
+	}
+	for _, p := range []string{path, path + ".key"} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("%s still exists (%v)", p, err)
+		}
+	}
+	if removed, err := Remove(path); err != nil || len(removed) != 0 {
+		t.Fatalf("Remove(missing) = %v, %v", removed, err)
+	}
+}
@@ -0,0 +1,58 @@
+// Package warnings collects degradations that did not fail a command, such as a partial
+// cache leaving user IDs unresolved, so JSON output can report them to programs instead
+// of only changing what a person would see.
+package warnings
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Warning codes. Like error codes, a code keeps its meaning once released.
+const (
+	// CachePartial means a metadata cache was only partly populated, so lookups may miss.
+	CachePartial = "cache_partial"
+	// NamesUnresolved means some IDs could not be resolved to names and are shown as IDs.
+	NamesUnresolved = "names_unresolved"
+	// PartialResults means some items were skipped after errors.
+	PartialResults = "partial_results"
+	// Truncated means results stop at a limit and more data exists.
+	Truncated = "truncated"
+	// OutputTruncated means --max-output-tokens cut the output.
+	OutputTruncated = "output_truncated"
+	// CookieExpiring means the xoxc- session cookie expires soon.
+	CookieExpiring = "cookie_expiring"
+)
+
+// Warning is one degradation.
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+var (
+	mu      sync.Mutex
+	pending []Warning
+)
+
+// Add records a warning. Repeats of a pending warning are dropped.
+func Add(code, format string, args ...interface{}) {
+	w := Warning{Code: code, Message: fmt.Sprintf(format, args...)}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, existing := range pending {
+		if existing == w {
+			return
+		}
+	}
+	pending = append(pending, w)
+}
+
+// Take returns the pending warnings and clears them.
+func Take() []Warning {
+	mu.Lock()
+	defer mu.Unlock()
+	taken := pending
+	pending = nil
+	return taken
+}
@@ -0,0 +1,21 @@
+package warnings
+
+import "testing"
+
+func TestAddDedupesAndTakeClears(t *testing.T) {
+	Take()
+	Add(CachePartial, "channel cache is partial")
+	Add(CachePartial, "channel cache is partial")
+	Add(NamesUnresolved, "%d user IDs unresolved", 2)
+
+	got := Take()
+	if len(got) != 2 {
+		t.Fatalf("Take() = %v, want 2 warnings", got)
+	}
+	if got[1] != (Warning{Code: NamesUnresolved, Message: "2 user IDs unresolved"}) {
+		t.Errorf("Take()[1] = %+v", got[1])
+	}
+	if rest := Take(); len(rest) != 0 {
+		t.Errorf("second Take() = %v, want none", rest)
+	}
+}
@@ -0,0 +1,349 @@
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// BoardVersion is the current board file format.
+const BoardVersion = 1
+
+// Change kinds a Board applies.
+const (
+	ChangePosted          = "posted"
+	ChangeEdited          = "edited"
+	ChangeDeleted         = "deleted"
+	ChangeReactionAdded   = "reaction_added"
+	ChangeReactionRemoved = "reaction_removed"
+	ChangePinned          = "pinned"
+	ChangeUnpinned        = "unpinned"
+)
+
+// Message is the current state of one watched message.
+type Message struct {
+	TS       string `json:"ts"`
+	ThreadTS string `json:"thread_ts,omitempty"`
+	// User is the author's user ID, or the bot ID for bot messages.
+	User      string              `json:"user,omitempty"`
+	Text      string              `json:"text,omitempty"`
+	Reactions map[string]Reaction `json:"reactions,omitempty"`
+	Pinned    bool                `json:"pinned"`
+	Edited    bool                `json:"edited"`
+	Deleted   bool                `json:"deleted"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// Reaction is one emoji's tally on a message.
+type Reaction struct {
+	Count int      `json:"count"`
+	Users []string `json:"users,omitempty"`
+}
+
+// Change is one event that moves a message's state.
+type Change struct {
+	Kind     string
+	Channel  string
+	TS       string
+	ThreadTS string
+	User     string
+	Text     string
+	Reaction string
+}
+
+// Board is the materialized state of recent messages in watched channels: reaction
+// counts, pins, edits, and deletions. It answers "current board state" questions, such
+// as which requests carry :white_check_mark:, without replaying events. A Board is safe
+// for concurrent use.
+type Board struct {
+	mu        sync.Mutex
+	path      string
+	limit     int
+	updatedAt time.Time
+	channels  map[string]map[string]*Message
+}
+
+// boardFile is the on-disk form: messages per channel, oldest first.
+type boardFile struct {
+	Version   int                  `json:"version"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	Channels  map[string][]Message `json:"channels"`
+}
+
+// LoadBoard reads the board file at path, or returns an empty board when it does not
+// exist. Each channel keeps its newest limit messages.
+func LoadBoard(path string, limit int) (*Board, error) {
+	b := &Board{path: path, limit: limit, channels: map[string]map[string]*Message{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read board state: %w", err)
+	}
+	var file boardFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse board state %s: %w", path, err)
+	}
+	b.updatedAt = file.UpdatedAt
+	for channel, msgs := range file.Channels {
+		tracked := b.channel(channel)
+		for i := range msgs {
+			tracked[msgs[i].TS] = &msgs[i]
+		}
+		b.prune(channel)
+	}
+	return b, nil
+}
+
+// Path returns the file the board is saved to.
+func (b *Board) Path() string {
+	return b.path
+}
+
+// Apply records one change at now and reports whether the board changed. Changes to
+// messages the board has not seen start tracking them.
+func (b *Board) Apply(c Change, now time.Time) bool {
+	if c.Channel == "" || c.TS == "" {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tracked := b.channel(c.Channel)
+	msg, known := tracked[c.TS]
+	if !known {
+		msg = &Message{TS: c.TS, ThreadTS: c.ThreadTS}
+	}
+	before := *msg
+	before.Reactions = copyReactions(msg.Reactions)
+
+	switch c.Kind {
+	case ChangePosted:
+		// A reaction can arrive before the board has seen the message itself.
+		if known && msg.Text != "" {
+			return false
+		}
+		msg.User, msg.Text = c.User, c.Text
+	case ChangeEdited:
+		if known && msg.Text != c.Text {
+			msg.Edited = true
+		}
+		msg.Text = c.Text
+	case ChangeDeleted:
+		msg.Deleted = true
+	case ChangeReactionAdded:
+		r := msg.Reactions[c.Reaction]
+		if c.User != "" && contains(r.Users, c.User) {
+			return false
+		}
+		if c.User != "" {
+			r.Users = append(r.Users, c.User)
+		}
+		r.Count++
+		if msg.Reactions == nil {
+			msg.Reactions = map[string]Reaction{}
+		}
+		msg.Reactions[c.Reaction] = r
+	case ChangeReactionRemoved:
+		r, ok := msg.Reactions[c.Reaction]
+		if !ok {
+			return false
+		}
+		r.Users = remove(r.Users, c.User)
+		if r.Count--; r.Count <= 0 {
+			delete(msg.Reactions, c.Reaction)
+		} else {
+			msg.Reactions[c.Reaction] = r
+		}
+	case ChangePinned:
+		msg.Pinned = true
+	case ChangeUnpinned:
+		msg.Pinned = false
+	default:
+		return false
+	}
+	if known && sameState(before, *msg) {
+		return false
+	}
+	msg.UpdatedAt = now
+	b.updatedAt = now
+	tracked[c.TS] = msg
+	b.prune(c.Channel)
+	return true
+}
+
+// Reconcile replaces the state of a channel's top-level messages with a fresh read of
+// its history, newest first or in any order, and reports whether the board changed.
+// Tracked top-level messages missing from the snapshot are marked deleted when they
+// fall inside its range, or anywhere when complete says the snapshot reaches the
+// start of the channel.
+func (b *Board) Reconcile(channel string, snapshot []Message, complete bool, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tracked := b.channel(channel)
+	changed := false
+	seen := make(map[string]bool, len(snapshot))
+	oldest := ""
+	for _, m := range snapshot {
+		seen[m.TS] = true
+		if oldest == "" || slack.CompareTS(m.TS, oldest) < 0 {
+			oldest = m.TS
+		}
+		if cur, ok := tracked[m.TS]; ok && sameState(*cur, m) {
+			continue
+		}
+		m.UpdatedAt = now
+		tracked[m.TS] = &m
+		changed = true
+	}
+	for ts, msg := range tracked {
+		if seen[ts] || msg.Deleted || (msg.ThreadTS != "" && msg.ThreadTS != ts) {
+			continue
+		}
+		if complete || (oldest != "" && slack.CompareTS(ts, oldest) > 0) {
+			msg.Deleted = true
+			msg.UpdatedAt = now
+			changed = true
+		}
+	}
+	if changed {
+		b.updatedAt = now
+		b.prune(channel)
+	}
+	return changed
+}
+
+// Messages returns a copy of a channel's tracked messages, oldest first.
+func (b *Board) Messages(channel string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sorted(channel)
+}
+
+// JSON encodes the board in its file format, limited to channels when any are given.
+func (b *Board) JSON(channels ...string) ([]byte, error) {
+	return b.marshal(channels)
+}
+
+func (b *Board) marshal(channels []string) ([]byte, error) {
+	b.mu.Lock()
+	file := boardFile{Version: BoardVersion, UpdatedAt: b.updatedAt, Channels: map[string][]Message{}}
+	if len(channels) == 0 {
+		for channel := range b.channels {
+			channels = append(channels, channel)
+		}
+	}
+	for _, channel := range channels {
+		file.Channels[channel] = b.sorted(channel)
+	}
+	b.mu.Unlock()
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// Save atomically writes the board back to its file.
+func (b *Board) Save() error {
+	data, err := b.marshal(nil)
+	if err != nil {
+		return fmt.Errorf("marshal board state: %w", err)
+	}
+	if dir := filepath.Dir(b.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("create board state dir: %w", err)
+		}
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write board state tmp: %w", err)
+	}
+	if err := os.Rename(tmp, b.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename board state tmp: %w", err)
+	}
+	return nil
+}
+
+func (b *Board) channel(channel string) map[string]*Message {
+	tracked := b.channels[channel]
+	if tracked == nil {
+		tracked = map[string]*Message{}
+		b.channels[channel] = tracked
+	}
+	return tracked
+}
+
+func (b *Board) sorted(channel string) []Message {
+	msgs := make([]Message, 0, len(b.channels[channel]))
+	for _, m := range b.channels[channel] {
+		c := *m
+		c.Reactions = copyReactions(m.Reactions)
+		msgs = append(msgs, c)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return slack.CompareTS(msgs[i].TS, msgs[j].TS) < 0 })
+	return msgs
+}
+
+// prune drops a channel's oldest messages beyond the board's limit.
+func (b *Board) prune(channel string) {
+	tracked := b.channels[channel]
+	if b.limit <= 0 || len(tracked) <= b.limit {
+		return
+	}
+	msgs := b.sorted(channel)
+	for _, m := range msgs[:len(msgs)-b.limit] {
+		delete(tracked, m.TS)
+	}
+}
+
+// sameState compares messages ignoring when they were last updated.
+func sameState(a, b Message) bool {
+	a.UpdatedAt, b.UpdatedAt = time.Time{}, time.Time{}
+	if len(a.Reactions) == 0 && len(b.Reactions) == 0 {
+		a.Reactions, b.Reactions = nil, nil
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func copyReactions(in map[string]Reaction) map[string]Reaction {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]Reaction, len(in))
+	for name, r := range in {
+		r.Users = append([]string(nil), r.Users...)
+		out[name] = r
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(list []string, s string) []string {
+	out := list[:0:0]
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package watch
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBoardApply(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	b, err := LoadBoard(filepath.Join(t.TempDir(), "board.json"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := []struct {
+		change      Change
+		wantChanged bool
+	}{
+		{Change{Kind: ChangePosted, Channel: "C1", TS: "1.000100", User: "U1", Text: "Fix login"}, true},
+		{Change{Kind: ChangePosted, Channel: "C1", TS: "1.000100", User: "U1", Text: "Fix login"}, false},
+		{Change{Kind: ChangeReactionAdded, Channel: "C1", TS: "1.000100", User: "U2", Reaction: "eyes"}, true},
+		{Change{Kind: ChangeReactionAdded, Channel: "C1", TS: "1.000100", User: "U2", Reaction: "eyes"}, false},
+		{Change{Kind: ChangeReactionAdded, Channel: "C1", TS: "1.000100", User: "U3", Reaction: "eyes"}, true},
+		{Change{Kind: ChangeReactionRemoved, Channel: "C1", TS: "1.000100", User: "U2", Reaction: "eyes"}, true},
+		{Change{Kind: ChangeReactionRemoved, Channel: "C1", TS: "1.000100", User: "U2", Reaction: "hammer"}, false},
+		{Change{Kind: ChangePinned, Channel: "C1", TS: "1.000100", User: "U2"}, true},
+		{Change{Kind: ChangeEdited, Channel: "C1", TS: "1.000100", Text: "Fix login on Safari"}, true},
+		{Change{Kind: ChangeReactionAdded, Channel: "C1", TS: "2.000100", User: "U2", Reaction: "white_check_mark"}, true},
+		{Change{Kind: ChangeDeleted, Channel: "C1", TS: "3.000100"}, true},
+	}
+	for i, step := range steps {
+		if got := b.Apply(step.change, now); got != step.wantChanged {
+			t.Errorf("step %d %s: changed = %v, want %v", i, step.change.Kind, got, step.wantChanged)
+		}
+	}
+
+	msgs := b.Messages("C1")
+	if len(msgs) != 3 {
+		t.Fatalf("messages = %+v", msgs)
+	}
+	first := msgs[0]
+	if first.Text != "Fix login on Safari" || !first.Edited || !first.Pinned || first.Deleted {
+		t.Errorf("first = %+v", first)
+	}
+	if r := first.Reactions["eyes"]; r.Count != 1 || len(r.Users) != 1 || r.Users[0] != "U3" {
+		t.Errorf("eyes = %+v", r)
+	}
+	if msgs[1].Reactions["white_check_mark"].Count != 1 || msgs[1].Text != "" {
+		t.Errorf("second = %+v", msgs[1])
+	}
+	if !msgs[2].Deleted {
+		t.Errorf("third = %+v", msgs[2])
+	}
+}
+
+func TestBoardReconcile(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	b, err := LoadBoard(filepath.Join(t.TempDir(), "board.json"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Apply(Change{Kind: ChangePosted, Channel: "C1", TS: "1.000100", Text: "old, outside the snapshot"}, now)
+	b.Apply(Change{Kind: ChangePosted, Channel: "C1", TS: "3.000100", Text: "deleted since"}, now)
+	b.Apply(Change{Kind: ChangePosted, Channel: "C1", TS: "4.000100", ThreadTS: "2.000100", Text: "thread reply"}, now)
+
+	snapshot := []Message{
+		{TS: "5.000100", Text: "new", Reactions: map[string]Reaction{"eyes": {Count: 2, Users: []string{"U1", "U2"}}}},
+		{TS: "2.000100", ThreadTS: "2.000100", Text: "root", Pinned: true},
+	}
+	if !b.Reconcile("C1", snapshot, false, now) {
+		t.Fatal("reconcile reported no change")
+	}
+	if b.Reconcile("C1", snapshot, false, now.Add(time.Minute)) {
+		t.Error("reconciling the same snapshot reported a change")
+	}
+
+	byTS := map[string]Message{}
+	for _, m := range b.Messages("C1") {
+		byTS[m.TS] = m
+	}
+	if byTS["1.000100"].Deleted {
+		t.Error("message older than the snapshot was marked deleted")
+	}
+	if !byTS["3.000100"].Deleted {
+		t.Error("message missing from the snapshot was not marked deleted")
+	}
+	if byTS["4.000100"].Deleted {
+		t.Error("thread reply was marked deleted by a history snapshot")
+	}
+	if byTS["5.000100"].Reactions["eyes"].Count != 2 || !byTS["2.000100"].Pinned {
+		t.Errorf("snapshot not applied: %+v", byTS)
+	}
+
+	if !b.Reconcile("C1", snapshot, true, now) || !b.Messages("C1")[0].Deleted {
+		t.Error("complete snapshot did not mark the oldest message deleted")
+	}
+}
+
+func TestBoardRoundTripAndLimit(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "nested", "board.json")
+	b, err := LoadBoard(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ts := range []string{"1.000100", "2.000100", "3.000100"} {
+		b.Apply(Change{Kind: ChangePosted, Channel: "C1", TS: ts, Text: "msg " + ts}, now)
+	}
+	b.Apply(Change{Kind: ChangeReactionAdded, Channel: "C1", TS: "3.000100", User: "U1", Reaction: "eyes"}, now)
+	if err := b.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadBoard(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs := loaded.Messages("C1")
+	if len(msgs) != 2 || msgs[0].TS != "2.000100" || msgs[1].Reactions["eyes"].Count != 1 || !msgs[1].UpdatedAt.Equal(now) {
+		t.Fatalf("loaded = %+v", msgs)
+	}
+	if loaded.Path() != path {
+		t.Errorf("path = %q", loaded.Path())
+	}
+}
@@ -0,0 +1,73 @@
+// Package watch keeps the on-disk state of the watch command: the position of
+// --mode poll, which emulates the Socket Mode event stream by diffing channel history,
+// and the per-message board kept by --track-state.
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// StateVersion is the current state file format.
+const StateVersion = 1
+
+// State records the newest message ts seen in each polled channel, so a restarted
+// poller resumes where it stopped instead of replaying or skipping messages.
+type State struct {
+	Version int `json:"version"`
+	// Latest maps a channel ID to the newest message ts emitted.
+	Latest map[string]string `json:"latest"`
+
+	path string
+}
+
+// LoadState reads the state file at path, or returns empty state when it does not exist.
+func LoadState(path string) (*State, error) {
+	state := &State{Version: StateVersion, Latest: map[string]string{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read watch state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse watch state %s: %w", path, err)
+	}
+	if state.Latest == nil {
+		state.Latest = map[string]string{}
+	}
+	return state, nil
+}
+
+// Path returns the file the state is saved to.
+func (s *State) Path() string {
+	return s.path
+}
+
+// Save atomically writes the state back to its file.
+func (s *State) Save() error {
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("create watch state dir: %w", err)
+		}
+	}
+	s.Version = StateVersion
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal watch state: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write watch state tmp: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename watch state tmp: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package watch
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Latest) != 0 {
+		t.Fatalf("new state = %+v", state)
+	}
+	state.Latest["C1"] = "1705312365.000100"
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Latest["C1"] != "1705312365.000100" || loaded.Version != StateVersion || loaded.Path() != path {
+		t.Fatalf("loaded state = %+v", loaded)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kehao95/slack-agent-cli/internal/output"
+	"github.com/kehao95/slack-agent-cli/internal/slack"
+)
+
+// TestPrintableGoldenCoverage fails when a type gains a Lines method without a golden
+// file: every Printable needs testdata/<Type>.golden or testdata/<Type>_<case>.golden
+// next to it. Regenerate goldens with SLK_UPDATE_GOLDEN=1 go test ./...
+func TestPrintableGoldenCoverage(t *testing.T) {
+	fset := token.NewFileSet()
+	for _, root := range []string{"cmd", "internal"} {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+			if err != nil {
+				return err
+			}
+			for _, decl := range file.Decls {
+				name, ok := printableReceiver(decl)
+				if !ok {
+					continue
+				}
+				dir := filepath.Join(filepath.Dir(path), "testdata")
+				exact, _ := filepath.Glob(filepath.Join(dir, name+".golden"))
+				cases, _ := filepath.Glob(filepath.Join(dir, name+"_*.golden"))
+				if len(exact)+len(cases) == 0 {
+					t.Errorf("%s: %s.Lines has no golden file in %s", path, name, dir)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// printableReceiver returns the receiver type name of a `Lines() []string` method.
+func printableReceiver(decl ast.Decl) (string, bool) {
+	fn, ok := decl.(*ast.FuncDecl)
+	if !ok || fn.Recv == nil || fn.Name.Name != "Lines" || len(fn.Type.Params.List) != 0 {
+		return "", false
+	}
+	if fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
+		return "", false
+	}
+	if result, ok := fn.Type.Results.List[0].Type.(*ast.ArrayType); !ok || result.Len != nil || identName(result.Elt) != "string" {
+		return "", false
+	}
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	return identName(recv), true
+}
+
+func identName(expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// stdioFile is the one file allowed to name os.Stdout and os.Stderr.
+var stdioFile = filepath.Join("internal", "output", "streams.go")
+
+// TestStdioContract keeps the machine-first contract: data goes to stdout and
+// everything else to stderr, and both only through output.Stdout and output.Stderr
+// (or output.Print, Statusf, and Warnf). It fails on fmt.Print*, the print builtins,
+// and any use of os.Stdout or os.Stderr outside internal/output/streams.go.
+func TestStdioContract(t *testing.T) {
+	fset := token.NewFileSet()
+	for _, root := range []string{"cmd", "internal"} {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.SelectorExpr:
+					pkg := identName(n.X)
+					switch {
+					case pkg == "fmt" && (n.Sel.Name == "Print" || n.Sel.Name == "Printf" || n.Sel.Name == "Println"):
+						t.Errorf("%s: fmt.%s writes to stdout directly; use output.Print or output.Stdout()", fset.Position(n.Pos()), n.Sel.Name)
+					case pkg == "os" && (n.Sel.Name == "Stdout" || n.Sel.Name == "Stderr") && path != stdioFile:
+						t.Errorf("%s: use output.%s() instead of os.%s", fset.Position(n.Pos()), n.Sel.Name, n.Sel.Name)
+					}
+				case *ast.CallExpr:
+					if name := identName(n.Fun); name == "print" || name == "println" {
+						t.Errorf("%s: builtin %s writes to stderr unfiltered; use output.Statusf", fset.Position(n.Pos()), name)
+					}
+				}
+				return true
+			})
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// FuzzTruncate checks that shortening text for human output never splits a
+// multi-byte rune or grapheme cluster, both in output.Truncate and in the
+// Printables that use it.
+func FuzzTruncate(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"deploy finished",
+		"héllo wörld",
+		"デプロイが完了しました。詳細はダッシュボードを確認してください。",
+		"👋🏽 family: 👨‍👩‍👧‍👦 flags: 🇯🇵🇺🇸",
+		strings.Repeat("e\u0301", 101),
+		strings.Repeat("🇯🇵", 60),
+		strings.Repeat("ab障", 40),
+		"line one\nline two\r\n\ttabbed",
+	} {
+		f.Add(seed, 100)
+	}
+	f.Fuzz(func(t *testing.T, text string, n int) {
+		if !utf8.ValidString(text) {
+			t.Skip()
+		}
+		n = n%200 + 1
+		if n <= 0 {
+			n += 200
+		}
+		got := output.Truncate(text, n)
+		if !utf8.ValidString(got) {
+			t.Fatalf("Truncate(%q, %d) = %q is not valid UTF-8", text, n, got)
+		}
+		if count := output.GraphemeCount(got); count > n {
+			t.Fatalf("Truncate(%q, %d) has %d characters", text, n, count)
+		}
+		if output.GraphemeCount(text) <= n && got != text {
+			t.Fatalf("Truncate(%q, %d) = %q, want it unchanged", text, n, got)
+		}
+		kept := strings.TrimSuffix(got, "...")
+		if want, _ := output.CutGraphemes(text, output.GraphemeCount(kept)); kept != want {
+			t.Fatalf("Truncate(%q, %d) = %q, cut inside a character", text, n, got)
+		}
+
+		msg := &slack.Message{Timestamp: "1700000000.000100", Text: text, User: "U1"}
+		reactions := &slack.ReactionListResult{Channel: "#fuzz", Timestamp: msg.Timestamp, Message: msg,
+			Reactions: []slack.ReactionItem{{Name: "eyes", Count: 1, Users: []string{"U1"}}}}
+		pins := &slack.PinListResult{Channel: "#fuzz", Items: []slack.PinnedItem{{Type: "message", Message: msg, Created: 1700000100}}}
+		threads := &slack.ThreadListResult{Channel: "#fuzz", Threads: []slack.ThreadSummary{{Channel: "C1", TS: msg.Timestamp, User: "U1", Text: text, ReplyCount: 1}}}
+		emoji := &slack.EmojiListResult{OK: true, Count: 1, Emoji: map[string]string{"fuzz": text}}
+		search := &slack.SearchResult{Query: "fuzz", Messages: slack.SearchMessages{Total: 1, Matches: []slack.SearchMatch{{User: "U1", Timestamp: msg.Timestamp, Text: text}}}}
+		for _, p := range []output.Printable{reactions, pins, threads, emoji, search} {
+			for _, line := range p.Lines() {
+				if !utf8.ValidString(line) {
+					t.Fatalf("%T.Lines() produced invalid UTF-8 %q for %q", p, line, text)
+				}
+			}
+		}
+	})
+}